@@ -166,6 +166,9 @@ type Config struct {
 	SSLCAKey string
 	// SSLCertsDir is the path to the certificate/key directory.
 	SSLCertsDir string
+	// CertSources are the files of the certificate/key directory which
+	// are loaded from secret stores.
+	CertSources []security.CertSource
 
 	// User running this process. It could be the user under which
 	// the server is running or the user passed in client calls.
@@ -265,6 +268,7 @@ func (cfg *Config) InitDefaults() {
 	cfg.SQLAddr = defaultSQLAddr
 	cfg.SQLAdvertiseAddr = cfg.SQLAddr
 	cfg.SSLCertsDir = DefaultCertsDirectory
+	cfg.CertSources = nil
 	cfg.RPCHeartbeatInterval = defaultRPCHeartbeatInterval
 	cfg.ClusterName = ""
 	cfg.DisableClusterNameVerification = false
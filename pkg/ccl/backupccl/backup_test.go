@@ -9878,7 +9878,7 @@ func TestBackupRestoreSystemUsers(t *testing.T) {
 			{"app_role", "app", "false"},
 			{"app_role", "test_role", "false"},
 		})
-		sqlDBRestore.CheckQueryResults(t, "SHOW USERS", [][]string{
+		sqlDBRestore.CheckQueryResults(t, "SELECT username, options, member_of FROM [SHOW USERS]", [][]string{
 			{"admin", "", "{}"},
 			{"app", "", "{admin,app_role}"},
 			{"app_role", "", "{}"},
@@ -9909,7 +9909,7 @@ func TestBackupRestoreSystemUsers(t *testing.T) {
 		sqlDBRestore1.CheckQueryResults(t, "SELECT * FROM system.role_members", [][]string{
 			{"admin", "root", "true"},
 		})
-		sqlDBRestore1.CheckQueryResults(t, "SHOW USERS", [][]string{
+		sqlDBRestore1.CheckQueryResults(t, "SELECT username, options, member_of FROM [SHOW USERS]", [][]string{
 			{"admin", "", "{}"},
 			{"app", "", "{}"},
 			{"app_role", "", "{}"},
@@ -883,6 +883,13 @@ func createImportingDescriptors(
 								desc.RegionConfig.RegionEnumID,
 								desc.RegionConfig.Placement,
 								superRegions,
+								multiregion.WithSecondaryRegion(desc.RegionConfig.SecondaryRegion),
+								multiregion.WithFallbackRegions(desc.RegionConfig.FallbackRegions),
+								multiregion.WithPlacementExceptions(desc.RegionConfig.PlacementExceptions),
+								multiregion.WithReplicaOverrides(desc.RegionConfig.RegionReplicaOverrides),
+								multiregion.WithSurvivalGoalReplicas(
+									desc.RegionConfig.SurvivalGoalNumVoters, desc.RegionConfig.SurvivalGoalNumReplicas,
+								),
 							)
 							if err := sql.ApplyZoneConfigFromDatabaseRegionConfig(
 								ctx,
@@ -518,7 +518,7 @@ func restoreOldVersionClusterTest(exportDir string) func(t *testing.T) {
 		// Ensure that the restore succeeds.
 		sqlDB.Exec(t, `RESTORE FROM $1`, localFoo)
 
-		sqlDB.CheckQueryResults(t, "SHOW USERS", [][]string{
+		sqlDB.CheckQueryResults(t, "SELECT username, options, member_of FROM [SHOW USERS]", [][]string{
 			{"admin", "", "{}"},
 			{"craig", "", "{}"},
 			{"root", "", "{admin}"},
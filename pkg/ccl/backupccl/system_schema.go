@@ -361,6 +361,18 @@ var systemTableBackupConfiguration = map[string]systemBackupConfiguration{
 	systemschema.TenantSettingsTable.GetName(): {
 		shouldIncludeInClusterBackup: optInToClusterBackup,
 	},
+	systemschema.RoleTOTPTable.GetName(): {
+		shouldIncludeInClusterBackup: optInToClusterBackup,
+	},
+	systemschema.WebAuthnCredentialsTable.GetName(): {
+		shouldIncludeInClusterBackup: optInToClusterBackup,
+	},
+	systemschema.RoleLastLoginTable.GetName(): {
+		shouldIncludeInClusterBackup: optInToClusterBackup,
+	},
+	systemschema.RoleProfilesTable.GetName(): {
+		shouldIncludeInClusterBackup: optInToClusterBackup,
+	},
 }
 
 // GetSystemTablesToIncludeInClusterBackup returns a set of system table names that
@@ -47,6 +47,10 @@ func authGSS(
 ) (*pgwire.AuthBehaviors, error) {
 	behaviors := &pgwire.AuthBehaviors{}
 
+	if err := useKeytab(GSSKeytab.Get(&execCfg.Settings.SV)); err != nil {
+		return nil, err
+	}
+
 	connClose, gssUser, err := getGssUser(c)
 	behaviors.SetConnClose(connClose)
 	if err != nil {
@@ -82,7 +86,11 @@ func authGSS(
 	}
 
 	behaviors.SetAuthenticator(func(
-		_ context.Context, _ security.SQLUsername, _ bool, _ pgwire.PasswordRetrievalFn,
+		_ context.Context,
+		_ security.SQLUsername,
+		_ bool,
+		_ pgwire.PasswordRetrievalFn,
+		_ security.DistinguishedName,
 	) error {
 		// Enforce krb_realm option, if any.
 		if realms := entry.GetOptions("krb_realm"); len(realms) > 0 {
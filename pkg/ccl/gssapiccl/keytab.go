@@ -0,0 +1,60 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+// See comment on build tag in gssapi.go.
+
+//go:build gss
+// +build gss
+
+package gssapiccl
+
+// This file contains the code that configures the keytab used by the
+// GSSAPI library to accept security contexts.
+
+import (
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+// #cgo LDFLAGS: -lgssapi_krb5 -lcom_err -lkrb5 -lkrb5support -ldl -lk5crypto -lresolv
+//
+// #include <gssapi/gssapi.h>
+// #include <gssapi/gssapi_krb5.h>
+// #include <stdlib.h>
+import "C"
+
+// acceptorKeytab tracks the keytab registered with the GSSAPI library,
+// which is process-wide.
+var acceptorKeytab struct {
+	syncutil.Mutex
+	path string
+}
+
+// useKeytab registers the keytab at the given path as the one used to
+// accept security contexts, or reverts to the default keytab if path
+// is empty. The keytab itself is opened by the library for every
+// security context, so only changes of path need to be registered.
+func useKeytab(path string) error {
+	acceptorKeytab.Lock()
+	defer acceptorKeytab.Unlock()
+	if path == acceptorKeytab.path {
+		return nil
+	}
+	var cPath *C.char
+	if path != "" {
+		cPath = C.CString(path)
+		defer C.free(unsafe.Pointer(cPath))
+	}
+	if majStat := C.krb5_gss_register_acceptor_identity(cPath); majStat != C.GSS_S_COMPLETE {
+		return errors.Errorf("registering GSS keytab %q failed", path)
+	}
+	acceptorKeytab.path = path
+	return nil
+}
@@ -0,0 +1,31 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package gssapiccl
+
+import "github.com/cockroachdb/cockroach/pkg/settings"
+
+// GSSKeytabSettingName is the name of the GSSKeytab cluster setting.
+const GSSKeytabSettingName = "server.gss_authentication.keytab"
+
+// GSSKeytab is the path of the keytab used to accept GSSAPI security
+// contexts. The keytab is read again for every authentication attempt,
+// so rotated keys are picked up without restarting the server. When
+// empty, the keytab named by the KRB5_KTNAME environment variable (or
+// the Kerberos library's default keytab) is used.
+//
+// The setting is system-only: it names a file on the nodes of the host
+// cluster, and pointing it at another keytab would let a tenant accept
+// the tickets of other principals.
+var GSSKeytab = settings.RegisterStringSetting(
+	settings.SystemOnly,
+	GSSKeytabSettingName,
+	"path of the Kerberos keytab used for GSSAPI authentication on every node; "+
+		"if empty, KRB5_KTNAME or the default keytab is used",
+	"",
+)
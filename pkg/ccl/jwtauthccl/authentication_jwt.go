@@ -0,0 +1,347 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package jwtauthccl
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/hba"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/identmap"
+	"github.com/cockroachdb/cockroach/pkg/util/httputil"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+	"github.com/coreos/go-oidc"
+)
+
+// This file implements the "jwt" HBA authentication method, which
+// accepts a signed JSON Web Token (JWT) in place of the password of a
+// SQL client. This enables services to authenticate using short-lived
+// tokens issued by an identity provider instead of long-lived
+// passwords.
+//
+// The token is verified against the keys published by its issuer,
+// which must be listed in the "issuer" options of the HBA entry. The
+// keys are retrieved from the issuer's JWKS endpoint, discovered using
+// OpenID Connect discovery unless the "jwks_url" option is specified.
+//
+// The value of the "claim" option (by default "sub") is then used as
+// the principal of the client. The principal is mapped to SQL
+// usernames using the identity map named by the "map" option, if any,
+// and the connection is accepted only if the requested SQL username is
+// one of them. For example:
+//
+//     hostssl all all all jwt issuer=https://accounts.example.com audience=crdb claim=email map=jwt
+
+const authCleartextPassword int32 = 3
+
+func init() {
+	// Tokens are bearer credentials: only accept them over TLS.
+	pgwire.RegisterAuthMethod("jwt", authJWT, hba.ConnHostSSL, checkEntry)
+}
+
+// jwtConf is the configuration of the JWT method, as decoded from the
+// options of an HBA entry.
+type jwtConf struct {
+	issuers  []string
+	jwksURL  string
+	audience string
+	claim    string
+	mapName  string
+}
+
+// parseJWTOptions decodes and validates the options of an HBA entry
+// using the jwt method.
+func parseJWTOptions(entry hba.Entry) (*jwtConf, error) {
+	conf := &jwtConf{claim: "sub"}
+	for _, op := range entry.Options {
+		switch op[0] {
+		case "issuer":
+			if err := checkURL(op[1]); err != nil {
+				return nil, errors.Wrapf(err, "invalid issuer")
+			}
+			conf.issuers = append(conf.issuers, op[1])
+		case "jwks_url":
+			if err := checkURL(op[1]); err != nil {
+				return nil, errors.Wrapf(err, "invalid jwks_url")
+			}
+			conf.jwksURL = op[1]
+		case "audience":
+			conf.audience = op[1]
+		case "claim":
+			if op[1] == "" {
+				return nil, errors.New("claim cannot be empty")
+			}
+			conf.claim = op[1]
+		case "map":
+			conf.mapName = op[1]
+		default:
+			return nil, errors.Errorf("unsupported option %s", op[0])
+		}
+	}
+	if len(conf.issuers) == 0 {
+		return nil, errors.New(`at least one "issuer" option is required`)
+	}
+	if conf.jwksURL != "" && len(conf.issuers) > 1 {
+		return nil, errors.New(`"jwks_url" can only be used with a single "issuer"`)
+	}
+	return conf, nil
+}
+
+func checkURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return errors.Newf("%q is not an HTTP(S) URL", s)
+	}
+	return nil
+}
+
+// checkEntry validates the options of an HBA entry using the jwt
+// method.
+func checkEntry(_ *settings.Values, entry hba.Entry) error {
+	_, err := parseJWTOptions(entry)
+	return err
+}
+
+// authJWT is the AuthMethod constructor for HBA method "jwt": request
+// a token in place of a cleartext password from the client, and verify
+// that it was issued to a principal which maps to the requested user.
+func authJWT(
+	_ context.Context,
+	c pgwire.AuthConn,
+	_ tls.ConnectionState,
+	execCfg *sql.ExecutorConfig,
+	entry *hba.Entry,
+	identMap *identmap.Conf,
+) (*pgwire.AuthBehaviors, error) {
+	conf, err := parseJWTOptions(*entry)
+	if err != nil {
+		return nil, err
+	}
+	b := &pgwire.AuthBehaviors{}
+	// The identity map is applied to the principal contained in the
+	// token, which is only known once the token has been received.
+	b.SetRoleMapper(pgwire.UseProvidedIdentity)
+	b.SetAuthenticator(func(
+		ctx context.Context,
+		systemIdentity security.SQLUsername,
+		_ bool,
+		_ pgwire.PasswordRetrievalFn,
+		_ security.DistinguishedName,
+	) error {
+		if err := c.SendAuthRequest(authCleartextPassword, nil /* data */); err != nil {
+			return err
+		}
+		pwdData, err := c.GetPwdData()
+		if err != nil {
+			c.LogAuthFailed(ctx, eventpb.AuthFailReason_PRE_HOOK_ERROR, err)
+			return err
+		}
+		// Make a string out of the 0-terminated byte array.
+		if bytes.IndexByte(pwdData, 0) != len(pwdData)-1 {
+			err := errors.New("expected 0-terminated byte array")
+			c.LogAuthFailed(ctx, eventpb.AuthFailReason_PRE_HOOK_ERROR, err)
+			return err
+		}
+		token := string(pwdData[:len(pwdData)-1])
+
+		principal, err := conf.verify(ctx, token)
+		if err != nil {
+			c.LogAuthInfof(ctx, "JWT verification failed: %v", err)
+			return security.NewErrPasswordUserAuthFailed(systemIdentity)
+		}
+		c.LogAuthInfof(ctx, "JWT principal: %q", principal)
+		if err := conf.checkPrincipal(principal, systemIdentity, identMap); err != nil {
+			c.LogAuthInfof(ctx, "%v", err)
+			return security.NewErrPasswordUserAuthFailed(systemIdentity)
+		}
+
+		// Do the license check last so that administrators are able to
+		// test whether their JWT configuration is correct.
+		return utilccl.CheckEnterpriseEnabled(execCfg.Settings, execCfg.ClusterID(), execCfg.Organization(), "JWT authentication")
+	})
+	return b, nil
+}
+
+// verify checks the signature and validity of the token, and returns
+// the principal contained in the configured claim.
+func (conf *jwtConf) verify(ctx context.Context, token string) (string, error) {
+	// The issuer must be known before the token can be verified, since
+	// it determines the keys used for the verification.
+	issuer, err := unverifiedIssuer(token)
+	if err != nil {
+		return "", err
+	}
+	found := false
+	for _, iss := range conf.issuers {
+		if iss == issuer {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return "", errors.Newf("issuer %q is not accepted", issuer)
+	}
+	keySet, err := getKeySet(ctx, issuer, conf.jwksURL)
+	if err != nil {
+		return "", err
+	}
+	verifier := oidc.NewVerifier(issuer, keySet, &oidc.Config{
+		ClientID:             conf.audience,
+		SkipClientIDCheck:    conf.audience == "",
+		SupportedSigningAlgs: supportedSigningAlgs,
+	})
+	idToken, err := verifier.Verify(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	var claims map[string]interface{}
+	if err := idToken.Claims(&claims); err != nil {
+		return "", err
+	}
+	principal, ok := claims[conf.claim].(string)
+	if !ok || principal == "" {
+		return "", errors.Newf("token does not contain a string claim %q", conf.claim)
+	}
+	return principal, nil
+}
+
+// checkPrincipal returns an error unless the principal maps to the
+// requested user. Mappings to the root user or reserved users are
+// never accepted.
+func (conf *jwtConf) checkPrincipal(
+	principal string, requested security.SQLUsername, identMap *identmap.Conf,
+) error {
+	var users []security.SQLUsername
+	if conf.mapName != "" {
+		var err error
+		users, err = identMap.Map(conf.mapName, principal)
+		if err != nil {
+			return err
+		}
+	} else {
+		u, err := security.MakeSQLUsernameFromUserInput(principal, security.UsernameValidation)
+		if err != nil {
+			return err
+		}
+		users = []security.SQLUsername{u}
+	}
+	for _, u := range users {
+		if u.IsRootUser() || u.IsReserved() {
+			return errors.Newf("JWT principal %q mapped to reserved database role %q", principal, u.Normalized())
+		}
+		if u == requested {
+			return nil
+		}
+	}
+	return errors.Newf("JWT principal %q does not map to database user %q", principal, requested.Normalized())
+}
+
+// supportedSigningAlgs are the asymmetric signature algorithms accepted
+// for tokens. Symmetric algorithms (e.g. HS256) are not supported since
+// they would require sharing a secret with the issuer.
+var supportedSigningAlgs = []string{
+	oidc.RS256, oidc.RS384, oidc.RS512,
+	oidc.ES256, oidc.ES384, oidc.ES512,
+	oidc.PS256, oidc.PS384, oidc.PS512,
+}
+
+// unverifiedIssuer extracts the "iss" claim from the token, without
+// verifying its signature.
+func unverifiedIssuer(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", errors.New("malformed JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", errors.Wrap(err, "malformed JWT payload")
+	}
+	var claims struct {
+		Issuer string `json:"iss"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", errors.Wrap(err, "malformed JWT payload")
+	}
+	return claims.Issuer, nil
+}
+
+// keySetHTTPTimeout bounds the requests to the issuers' discovery and
+// JWKS endpoints.
+const keySetHTTPTimeout = 10 * time.Second
+
+// keySets caches the key sets of the issuers, so that the JWKS
+// endpoints are not queried for every connection. A key set fetches
+// the keys again when it encounters a token signed with an unknown
+// key, so key rotations are picked up automatically.
+var keySets struct {
+	syncutil.Mutex
+	m map[[2]string]oidc.KeySet
+}
+
+// getKeySet returns the key set for the given issuer, using OpenID
+// Connect discovery to find its JWKS endpoint if jwksURL is empty.
+func getKeySet(ctx context.Context, issuer, jwksURL string) (oidc.KeySet, error) {
+	key := [2]string{issuer, jwksURL}
+	keySets.Lock()
+	keySet, ok := keySets.m[key]
+	keySets.Unlock()
+	if ok {
+		return keySet, nil
+	}
+
+	// The context passed to the key set is retained to fetch the keys
+	// in the future, so it must outlive the current connection.
+	httpClient := httputil.NewClientWithTimeout(keySetHTTPTimeout)
+	keySetCtx := oidc.ClientContext(context.Background(), httpClient.Client)
+	if jwksURL == "" {
+		provider, err := oidc.NewProvider(oidc.ClientContext(ctx, httpClient.Client), issuer)
+		if err != nil {
+			return nil, err
+		}
+		var discovered struct {
+			JWKSURL string `json:"jwks_uri"`
+		}
+		if err := provider.Claims(&discovered); err != nil {
+			return nil, err
+		}
+		if discovered.JWKSURL == "" {
+			return nil, errors.Newf("issuer %q does not advertise a JWKS endpoint", issuer)
+		}
+		jwksURL = discovered.JWKSURL
+	}
+	keySet = oidc.NewRemoteKeySet(keySetCtx, jwksURL)
+
+	keySets.Lock()
+	defer keySets.Unlock()
+	if existing, ok := keySets.m[key]; ok {
+		// Another connection populated the cache concurrently.
+		return existing, nil
+	}
+	if keySets.m == nil {
+		keySets.m = make(map[[2]string]oidc.KeySet)
+	}
+	keySets.m[key] = keySet
+	return keySet, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package jwtauthccl
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/hba"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/identmap"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJWTOptions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	tests := []struct {
+		name    string
+		options [][2]string
+		wantErr string
+		want    *jwtConf
+	}{
+		{"no issuer", nil, `"issuer" option is required`, nil},
+		{"defaults",
+			[][2]string{{"issuer", "https://a.example.com"}},
+			"",
+			&jwtConf{issuers: []string{"https://a.example.com"}, claim: "sub"}},
+		{"all options",
+			[][2]string{{"issuer", "https://a.example.com"}, {"jwks_url", "https://a.example.com/keys"},
+				{"audience", "crdb"}, {"claim", "email"}, {"map", "jwt"}},
+			"",
+			&jwtConf{issuers: []string{"https://a.example.com"}, jwksURL: "https://a.example.com/keys",
+				audience: "crdb", claim: "email", mapName: "jwt"}},
+		{"multiple issuers",
+			[][2]string{{"issuer", "https://a.example.com"}, {"issuer", "https://b.example.com"}},
+			"",
+			&jwtConf{issuers: []string{"https://a.example.com", "https://b.example.com"}, claim: "sub"}},
+		{"jwks with multiple issuers",
+			[][2]string{{"issuer", "https://a.example.com"}, {"issuer", "https://b.example.com"},
+				{"jwks_url", "https://a.example.com/keys"}},
+			"single", nil},
+		{"bad issuer", [][2]string{{"issuer", "ftp://a.example.com"}}, "invalid issuer", nil},
+		{"empty claim", [][2]string{{"issuer", "https://a.example.com"}, {"claim", ""}}, "claim cannot be empty", nil},
+		{"unknown option", [][2]string{{"issuer", "https://a.example.com"}, {"foo", "bar"}}, "unsupported option foo", nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conf, err := parseJWTOptions(hba.Entry{Options: tc.options})
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, conf)
+		})
+	}
+}
+
+func TestUnverifiedIssuer(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	header := encode(`{"alg":"RS256"}`)
+
+	iss, err := unverifiedIssuer(header + "." + encode(`{"iss":"https://a.example.com","sub":"x"}`) + ".sig")
+	require.NoError(t, err)
+	require.Equal(t, "https://a.example.com", iss)
+
+	for _, token := range []string{
+		"",
+		"notatoken",
+		header + "." + encode(`{"iss":`) + ".sig",
+		header + ".!!!.sig",
+	} {
+		_, err := unverifiedIssuer(token)
+		require.Error(t, err, "token: %q", token)
+	}
+}
+
+func TestCheckPrincipal(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	identMap, err := identmap.From(strings.NewReader(`
+jwt /^(.*)@example.com$ \1
+jwt admin@example.com root
+`))
+	require.NoError(t, err)
+	user := func(s string) security.SQLUsername { return security.MakeSQLUsernameFromPreNormalizedString(s) }
+
+	noMap := &jwtConf{}
+	require.NoError(t, noMap.checkPrincipal("carl", user("carl"), identMap))
+	require.Error(t, noMap.checkPrincipal("carl", user("alice"), identMap))
+	require.Error(t, noMap.checkPrincipal("root", user("root"), identMap))
+
+	withMap := &jwtConf{mapName: "jwt"}
+	require.NoError(t, withMap.checkPrincipal("carl@example.com", user("carl"), identMap))
+	require.Error(t, withMap.checkPrincipal("carl@example.com", user("alice"), identMap))
+	require.Error(t, withMap.checkPrincipal("carl@other.com", user("carl"), identMap))
+	err = withMap.checkPrincipal("admin@example.com", user("root"), identMap)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "reserved")
+}
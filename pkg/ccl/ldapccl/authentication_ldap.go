@@ -0,0 +1,286 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package ldapccl
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/hba"
+	"github.com/cockroachdb/errors"
+)
+
+// This file implements the "ldap" HBA authentication method, which
+// verifies the password provided by the client against an LDAP
+// directory (e.g. Active Directory) instead of system.users.
+//
+// The configuration options mirror those of PostgreSQL:
+// https://www.postgresql.org/docs/current/auth-ldap.html
+//
+// Two modes are supported:
+//
+//   - simple bind: the client's password is used to bind as the DN
+//     formed by ldapprefix + username + ldapsuffix.
+//
+//   - search+bind: the server first binds as ldapbinddn (or
+//     anonymously), searches the subtree rooted at ldapbasedn for the
+//     entry whose ldapsearchattribute is equal to the username, then
+//     binds as that entry using the client's password.
+//
+// The password of ldapbinddn can be loaded from a file or an external
+// secret store with ldapbindpasswdfile instead of being stored in the
+// cluster settings with ldapbindpasswd, e.g.
+// ldapbindpasswdfile=vault://vault.example.com:8200/secret/data/crdb?field=ldap
+// (see security.LoadSecret). The secret is loaded when users
+// authenticate, so it can be rotated without changing the HBA
+// configuration.
+//
+// Note that option values containing commas, such as distinguished
+// names, must be quoted in their entirety, e.g.
+//
+//     host all all all ldap ldapserver=ldap.example.com "ldapbasedn=dc=example,dc=com"
+
+func init() {
+	pgwire.RegisterAuthProvider("ldap", pgwire.AuthProviderFunc(authLDAP), hba.ConnAny, checkEntry)
+}
+
+// ldapConf is the configuration of the LDAP method, as decoded from
+// the options of an HBA entry.
+type ldapConf struct {
+	servers  []string
+	port     int
+	ldaps    bool
+	startTLS bool
+
+	// Simple bind mode.
+	prefix string
+	suffix string
+
+	// Search+bind mode.
+	baseDN          string
+	bindDN          string
+	bindPassword    string
+	bindPasswordRef string // secret reference, see security.LoadSecret
+	searchAttribute string
+}
+
+// searchMode returns true if the configuration uses the search+bind
+// mode.
+func (conf *ldapConf) searchMode() bool {
+	return conf.baseDN != ""
+}
+
+// validSearchAttribute matches LDAP attribute descriptions (RFC 4512,
+// section 2.5), possibly with options.
+var validSearchAttribute = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]*(;[a-zA-Z0-9-]+)*$`)
+
+// parseLDAPOptions decodes and validates the options of an HBA entry
+// using the ldap method.
+func parseLDAPOptions(entry hba.Entry) (*ldapConf, error) {
+	conf := &ldapConf{searchAttribute: "uid"}
+	var hasSearchAttribute, hasBindOptions bool
+	for _, op := range entry.Options {
+		switch op[0] {
+		case "ldapserver":
+			conf.servers = append(conf.servers, strings.Fields(op[1])...)
+		case "ldapport":
+			port, err := strconv.Atoi(op[1])
+			if err != nil || port <= 0 || port > 65535 {
+				return nil, errors.Errorf("invalid ldapport: %s", op[1])
+			}
+			conf.port = port
+		case "ldapscheme":
+			switch op[1] {
+			case "ldap":
+				conf.ldaps = false
+			case "ldaps":
+				conf.ldaps = true
+			default:
+				return nil, errors.Errorf("invalid ldapscheme: %s (must be ldap or ldaps)", op[1])
+			}
+		case "ldaptls":
+			switch op[1] {
+			case "0":
+				conf.startTLS = false
+			case "1":
+				conf.startTLS = true
+			default:
+				return nil, errors.Errorf("ldaptls must be set to 0 or 1: %s", op[1])
+			}
+		case "ldapprefix":
+			conf.prefix = op[1]
+		case "ldapsuffix":
+			conf.suffix = op[1]
+		case "ldapbasedn":
+			conf.baseDN = op[1]
+		case "ldapbinddn":
+			conf.bindDN = op[1]
+			hasBindOptions = true
+		case "ldapbindpasswd":
+			conf.bindPassword = op[1]
+			hasBindOptions = true
+		case "ldapbindpasswdfile":
+			if err := security.ValidateSecretRef(op[1]); err != nil {
+				return nil, errors.Wrap(err, "invalid ldapbindpasswdfile")
+			}
+			conf.bindPasswordRef = op[1]
+			hasBindOptions = true
+		case "ldapsearchattribute":
+			if !validSearchAttribute.MatchString(op[1]) {
+				return nil, errors.Errorf("invalid ldapsearchattribute: %s", op[1])
+			}
+			conf.searchAttribute = op[1]
+			hasSearchAttribute = true
+		case "map":
+		// OK.
+		default:
+			return nil, errors.WithHint(
+				errors.Errorf("unsupported option %s", op[0]),
+				`Option values containing commas must be quoted together with the `+
+					`option name, for example "ldapbasedn=dc=example,dc=com".`)
+		}
+	}
+	if len(conf.servers) == 0 {
+		return nil, errors.New(`the "ldapserver" option is required`)
+	}
+	if conf.ldaps && conf.startTLS {
+		return nil, errors.New(`"ldaptls=1" cannot be combined with "ldapscheme=ldaps"`)
+	}
+	if conf.bindPassword != "" && conf.bindPasswordRef != "" {
+		return nil, errors.New(`"ldapbindpasswd" cannot be combined with "ldapbindpasswdfile"`)
+	}
+	if conf.searchMode() {
+		if conf.prefix != "" || conf.suffix != "" {
+			return nil, errors.New(
+				`"ldapprefix" and "ldapsuffix" cannot be combined with "ldapbasedn"`)
+		}
+	} else if hasBindOptions || hasSearchAttribute {
+		return nil, errors.New(
+			`"ldapbinddn", "ldapbindpasswd", "ldapbindpasswdfile" and "ldapsearchattribute" require "ldapbasedn"`)
+	}
+	if conf.port == 0 {
+		conf.port = 389
+		if conf.ldaps {
+			conf.port = 636
+		}
+	}
+	return conf, nil
+}
+
+// checkEntry validates the options of an HBA entry using the ldap
+// method.
+func checkEntry(_ *settings.Values, entry hba.Entry) error {
+	_, err := parseLDAPOptions(entry)
+	return err
+}
+
+// authLDAP is the AuthProvider for HBA method "ldap": verify the
+// password provided by the client using the configured LDAP server(s).
+func authLDAP(
+	ctx context.Context,
+	systemIdentity security.SQLUsername,
+	c pgwire.AuthConn,
+	creds pgwire.AuthCredentials,
+) error {
+	conf, err := parseLDAPOptions(*creds.Entry)
+	if err != nil {
+		return err
+	}
+	if creds.Password == "" {
+		// An empty password would result in an unauthenticated bind,
+		// which LDAP servers commonly accept.
+		c.LogAuthInfof(ctx, "empty password provided")
+		return security.NewErrPasswordUserAuthFailed(systemIdentity)
+	}
+	execCfg := creds.ExecCfg
+	if err := conf.authenticate(ctx, &execCfg.Settings.SV, systemIdentity.Normalized(), creds.Password); err != nil {
+		c.LogAuthInfof(ctx, "LDAP authentication failed: %v", err)
+		return security.NewErrPasswordUserAuthFailed(systemIdentity)
+	}
+
+	// Do the license check last so that administrators are able to
+	// test whether their LDAP configuration is correct.
+	return utilccl.CheckEnterpriseEnabled(execCfg.Settings, execCfg.ClusterID(), execCfg.Organization(), "LDAP authentication")
+}
+
+// authenticate verifies the credentials of the given user against the
+// configured LDAP servers. The servers are tried in order; the next
+// server is only tried if the connection to the previous one fails.
+func (conf *ldapConf) authenticate(
+	ctx context.Context, sv *settings.Values, user, password string,
+) error {
+	timeout := LDAPTimeout.Get(sv)
+	rootCAs, err := parseCACertificate(LDAPCACertificate.Get(sv))
+	if err != nil {
+		return err
+	}
+	if conf.bindPasswordRef != "" {
+		bindPassword, err := security.LoadSecret(ctx, conf.bindPasswordRef)
+		if err != nil {
+			return errors.Wrap(err, "loading the LDAP bind password")
+		}
+		confWithPassword := *conf
+		confWithPassword.bindPassword = string(bindPassword)
+		conf = &confWithPassword
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var connErr error
+	for _, server := range conf.servers {
+		c, err := dialLDAP(ctx, server, conf.port, conf.ldaps, rootCAs)
+		if err != nil {
+			connErr = errors.CombineErrors(connErr, errors.Wrapf(err, "connecting to %s", server))
+			continue
+		}
+		if conf.startTLS {
+			err = c.startTLS(server, rootCAs)
+		}
+		if err == nil {
+			err = conf.authenticateWithConn(c, user, password, int64(timeout.Seconds()))
+		}
+		c.close()
+		return err
+	}
+	return connErr
+}
+
+// authenticateWithConn verifies the credentials of the given user
+// using an established connection to an LDAP server.
+func (conf *ldapConf) authenticateWithConn(
+	c *ldapConn, user, password string, timeLimitSecs int64,
+) error {
+	if !conf.searchMode() {
+		if strings.ContainsAny(user, dnSpecialChars) {
+			return errors.Newf("user name %q contains characters that are not allowed in a DN", user)
+		}
+		return c.bind(conf.prefix+user+conf.suffix, password)
+	}
+
+	// Search+bind mode. The bind is anonymous if no bind DN was
+	// configured.
+	if err := c.bind(conf.bindDN, conf.bindPassword); err != nil {
+		return errors.Wrap(err, "binding for search")
+	}
+	dn, err := c.searchDN(conf.baseDN, conf.searchAttribute, user, timeLimitSecs)
+	if err != nil {
+		return err
+	}
+	return c.bind(dn, password)
+}
+
+// dnSpecialChars are the characters which have a special meaning in
+// the string representation of a distinguished name (RFC 4514).
+const dnSpecialChars = ",+\"\\<>;=#\x00"
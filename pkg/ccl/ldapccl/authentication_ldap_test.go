@@ -0,0 +1,227 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package ldapccl
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/hba"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLDAPOptions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	tests := []struct {
+		name    string
+		options [][2]string
+		wantErr string
+		want    *ldapConf
+	}{
+		{"no server", nil, `"ldapserver" option is required`, nil},
+		{"simple bind",
+			[][2]string{{"ldapserver", "a b"}, {"ldapprefix", "cn="}, {"ldapsuffix", ",dc=example,dc=com"}},
+			"",
+			&ldapConf{servers: []string{"a", "b"}, port: 389, prefix: "cn=", suffix: ",dc=example,dc=com", searchAttribute: "uid"}},
+		{"search+bind over ldaps",
+			[][2]string{{"ldapserver", "a"}, {"ldapscheme", "ldaps"}, {"ldapbasedn", "dc=example"},
+				{"ldapbinddn", "cn=svc"}, {"ldapbindpasswd", "pw"}, {"ldapsearchattribute", "sAMAccountName"}},
+			"",
+			&ldapConf{servers: []string{"a"}, port: 636, ldaps: true, baseDN: "dc=example",
+				bindDN: "cn=svc", bindPassword: "pw", searchAttribute: "sAMAccountName"}},
+		{"bind password from a secret store",
+			[][2]string{{"ldapserver", "a"}, {"ldapbasedn", "dc=example"}, {"ldapbinddn", "cn=svc"},
+				{"ldapbindpasswdfile", "vault://vault:8200/secret/data/crdb?field=ldap"}},
+			"",
+			&ldapConf{servers: []string{"a"}, port: 389, baseDN: "dc=example", bindDN: "cn=svc",
+				bindPasswordRef: "vault://vault:8200/secret/data/crdb?field=ldap", searchAttribute: "uid"}},
+		{"bind password and password file",
+			[][2]string{{"ldapserver", "a"}, {"ldapbasedn", "dc=example"},
+				{"ldapbindpasswd", "pw"}, {"ldapbindpasswdfile", "/f"}},
+			"cannot be combined", nil},
+		{"unsupported secret store",
+			[][2]string{{"ldapserver", "a"}, {"ldapbasedn", "dc=example"}, {"ldapbindpasswdfile", "foo://bar"}},
+			"unsupported secret reference scheme", nil},
+		{"starttls with port",
+			[][2]string{{"ldapserver", "a"}, {"ldaptls", "1"}, {"ldapport", "1389"}},
+			"",
+			&ldapConf{servers: []string{"a"}, port: 1389, startTLS: true, searchAttribute: "uid"}},
+		{"mixed modes",
+			[][2]string{{"ldapserver", "a"}, {"ldapprefix", "cn="}, {"ldapbasedn", "dc=example"}},
+			"cannot be combined", nil},
+		{"search options without base DN",
+			[][2]string{{"ldapserver", "a"}, {"ldapbinddn", "cn=svc"}},
+			`require "ldapbasedn"`, nil},
+		{"starttls over ldaps",
+			[][2]string{{"ldapserver", "a"}, {"ldaptls", "1"}, {"ldapscheme", "ldaps"}},
+			"cannot be combined", nil},
+		{"bad port", [][2]string{{"ldapserver", "a"}, {"ldapport", "0"}}, "invalid ldapport", nil},
+		{"bad attribute",
+			[][2]string{{"ldapserver", "a"}, {"ldapbasedn", "dc=example"}, {"ldapsearchattribute", "uid=*"}},
+			"invalid ldapsearchattribute", nil},
+		{"unquoted DN", [][2]string{{"ldapserver", "a"}, {"dc", "com"}}, "unsupported option dc", nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conf, err := parseLDAPOptions(hba.Entry{Options: tc.options})
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, conf)
+		})
+	}
+}
+
+func TestBERInteger(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	for _, tc := range []struct {
+		v   int64
+		enc []byte
+	}{
+		{0, []byte{0x02, 0x01, 0x00}},
+		{127, []byte{0x02, 0x01, 0x7f}},
+		{128, []byte{0x02, 0x02, 0x00, 0x80}},
+		{256, []byte{0x02, 0x02, 0x01, 0x00}},
+		{-1, []byte{0x02, 0x01, 0xff}},
+		{-129, []byte{0x02, 0x02, 0xff, 0x7f}},
+	} {
+		enc := berInteger(berTagInteger, tc.v)
+		require.Equal(t, tc.enc, enc, "encoding %d", tc.v)
+		el, rest, err := parseBERElement(enc)
+		require.NoError(t, err)
+		require.Empty(t, rest)
+		v, err := el.int64()
+		require.NoError(t, err)
+		require.Equal(t, tc.v, v)
+	}
+
+	// Long-form lengths round-trip through the stream decoder.
+	long := berString(berTagOctetString, string(bytes.Repeat([]byte{'x'}, 300)))
+	require.Equal(t, []byte{0x04, 0x82, 0x01, 0x2c}, long[:4])
+	el, err := readBERElement(bufio.NewReader(bytes.NewReader(long)))
+	require.NoError(t, err)
+	require.Len(t, el.contents, 300)
+}
+
+// serveFakeLDAP serves LDAP requests on conn until an unbind request is
+// received or the connection is closed. Binds succeed for the DN and
+// password pairs in passwords; searches return the DNs in entries for
+// the value of the equality filter.
+func serveFakeLDAP(conn net.Conn, passwords map[string]string, entries map[string][]string) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	result := func(tag byte, code int64) []byte {
+		return berEncode(tag,
+			berInteger(berTagEnumerated, code),
+			berString(berTagOctetString, ""),
+			berString(berTagOctetString, ""))
+	}
+	for {
+		msg, err := readBERElement(r)
+		if err != nil {
+			return
+		}
+		idEl, rest, _ := parseBERElement(msg.contents)
+		id, _ := idEl.int64()
+		op, _, _ := parseBERElement(rest)
+		var replies [][]byte
+		switch op.tag {
+		case ldapTagBindRequest:
+			_, rest, _ := parseBERElement(op.contents)
+			dn, rest, _ := parseBERElement(rest)
+			pw, _, _ := parseBERElement(rest)
+			code := int64(49) // invalidCredentials
+			if expected, ok := passwords[string(dn.contents)]; ok && expected == string(pw.contents) {
+				code = ldapResultSuccess
+			}
+			replies = append(replies, result(ldapTagBindResponse, code))
+		case ldapTagSearchRequest:
+			// Skip baseObject, scope, derefAliases, sizeLimit, timeLimit
+			// and typesOnly.
+			rest := op.contents
+			for i := 0; i < 6; i++ {
+				_, rest, _ = parseBERElement(rest)
+			}
+			filter, _, _ := parseBERElement(rest)
+			_, rest, _ = parseBERElement(filter.contents)
+			value, _, _ := parseBERElement(rest)
+			for _, dn := range entries[string(value.contents)] {
+				replies = append(replies, berEncode(ldapTagSearchResultEntry,
+					berString(berTagOctetString, dn), berEncode(berTagSequence)))
+			}
+			replies = append(replies, result(ldapTagSearchResultDone, ldapResultSuccess))
+		case ldapTagUnbindRequest:
+			return
+		}
+		for _, reply := range replies {
+			if _, err := conn.Write(berEncode(berTagSequence, berInteger(berTagInteger, id), reply)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func TestLDAPAuthenticate(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	passwords := map[string]string{
+		"cn=svc":                 "svcpw",
+		"uid=alice,dc=example":   "secret",
+		"cn=alice,ou=people,o=x": "secret",
+	}
+	entries := map[string][]string{
+		"alice": {"uid=alice,dc=example"},
+		"dup":   {"uid=dup,ou=a,dc=example", "uid=dup,ou=b,dc=example"},
+	}
+	authenticate := func(conf *ldapConf, user, password string) error {
+		client, server := net.Pipe()
+		go serveFakeLDAP(server, passwords, entries)
+		c := &ldapConn{conn: client, r: bufio.NewReader(client)}
+		defer c.close()
+		return conf.authenticateWithConn(c, user, password, 10 /* timeLimitSecs */)
+	}
+	isInvalidCredentials := func(err error) bool {
+		var ldapErr *ldapResultError
+		return errors.As(err, &ldapErr) && ldapErr.code == 49
+	}
+
+	t.Run("simple bind", func(t *testing.T) {
+		conf := &ldapConf{prefix: "cn=", suffix: ",ou=people,o=x"}
+		require.NoError(t, authenticate(conf, "alice", "secret"))
+		require.True(t, isInvalidCredentials(authenticate(conf, "alice", "wrong")))
+		require.True(t, isInvalidCredentials(authenticate(conf, "bob", "secret")))
+		err := authenticate(conf, "alice,ou=people,o=x", "secret")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "not allowed in a DN")
+	})
+
+	t.Run("search+bind", func(t *testing.T) {
+		conf := &ldapConf{baseDN: "dc=example", bindDN: "cn=svc", bindPassword: "svcpw", searchAttribute: "uid"}
+		require.NoError(t, authenticate(conf, "alice", "secret"))
+		require.True(t, isInvalidCredentials(authenticate(conf, "alice", "wrong")))
+		require.True(t, errors.Is(authenticate(conf, "carol", "secret"), errLDAPEntryNotFound))
+		err := authenticate(conf, "dup", "secret")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "more than one entry")
+
+		conf.bindPassword = "wrong"
+		err = authenticate(conf, "alice", "secret")
+		require.True(t, isInvalidCredentials(err))
+		require.Contains(t, err.Error(), "binding for search")
+	})
+}
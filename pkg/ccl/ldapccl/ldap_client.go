@@ -0,0 +1,461 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package ldapccl
+
+// This file contains a minimal LDAPv3 client (RFC 4511). It only
+// supports the operations needed to authenticate a user: simple bind,
+// StartTLS and a single-entry search by attribute equality.
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+)
+
+// BER identifiers used by the LDAP protocol operations below.
+const (
+	berTagBoolean     byte = 0x01
+	berTagInteger     byte = 0x02
+	berTagOctetString byte = 0x04
+	berTagEnumerated  byte = 0x0a
+	berTagSequence    byte = 0x30
+
+	ldapTagBindRequest           byte = 0x60 // [APPLICATION 0], constructed
+	ldapTagBindResponse          byte = 0x61 // [APPLICATION 1], constructed
+	ldapTagUnbindRequest         byte = 0x42 // [APPLICATION 2], primitive
+	ldapTagSearchRequest         byte = 0x63 // [APPLICATION 3], constructed
+	ldapTagSearchResultEntry     byte = 0x64 // [APPLICATION 4], constructed
+	ldapTagSearchResultDone      byte = 0x65 // [APPLICATION 5], constructed
+	ldapTagSearchResultReference byte = 0x73 // [APPLICATION 19], constructed
+	ldapTagExtendedRequest       byte = 0x77 // [APPLICATION 23], constructed
+	ldapTagExtendedResponse      byte = 0x78 // [APPLICATION 24], constructed
+
+	ldapTagSimpleAuthentication byte = 0x80 // [0], primitive
+	ldapTagExtendedRequestName  byte = 0x80 // [0], primitive
+	ldapTagEqualityMatchFilter  byte = 0xa3 // [3], constructed
+)
+
+// Values used in LDAP requests and responses.
+const (
+	ldapVersion = 3
+
+	ldapScopeWholeSubtree     = 2
+	ldapNeverDerefAliases     = 0
+	ldapNoAttributes          = "1.1"
+	ldapStartTLSOID           = "1.3.6.1.4.1.1466.20037"
+	ldapResultSuccess         = 0
+	ldapResultSizeLimitExceed = 4
+)
+
+// maxLDAPMessageSize bounds the size of the messages accepted from the
+// LDAP server.
+const maxLDAPMessageSize = 1 << 20
+
+// berElement is a decoded BER tag-length-value triplet.
+type berElement struct {
+	tag      byte
+	contents []byte
+}
+
+// berEncode encodes a BER element with the given tag, whose contents
+// are the concatenation of the given byte slices.
+func berEncode(tag byte, contents ...[]byte) []byte {
+	n := 0
+	for _, c := range contents {
+		n += len(c)
+	}
+	buf := make([]byte, 0, n+6)
+	buf = append(buf, tag)
+	buf = appendBERLength(buf, n)
+	for _, c := range contents {
+		buf = append(buf, c...)
+	}
+	return buf
+}
+
+// appendBERLength appends the definite-length encoding of n to buf.
+func appendBERLength(buf []byte, n int) []byte {
+	if n < 0x80 {
+		return append(buf, byte(n))
+	}
+	var tmp [8]byte
+	i := len(tmp)
+	for ; n > 0; n >>= 8 {
+		i--
+		tmp[i] = byte(n)
+	}
+	buf = append(buf, 0x80|byte(len(tmp)-i))
+	return append(buf, tmp[i:]...)
+}
+
+// berInteger encodes v as a two's complement BER integer using the
+// minimum number of octets.
+func berInteger(tag byte, v int64) []byte {
+	n := 1
+	for i := v; i > 127 || i < -128; i >>= 8 {
+		n++
+	}
+	b := make([]byte, n)
+	for j := n - 1; j >= 0; j-- {
+		b[j] = byte(v)
+		v >>= 8
+	}
+	return berEncode(tag, b)
+}
+
+func berString(tag byte, s string) []byte {
+	return berEncode(tag, []byte(s))
+}
+
+func berBool(v bool) []byte {
+	if v {
+		return berEncode(berTagBoolean, []byte{0xff})
+	}
+	return berEncode(berTagBoolean, []byte{0})
+}
+
+// parseBERElement decodes the first BER element in b and returns it
+// alongside the remaining bytes.
+func parseBERElement(b []byte) (el berElement, rest []byte, err error) {
+	if len(b) < 2 {
+		return el, nil, errors.New("truncated BER element")
+	}
+	el.tag = b[0]
+	if el.tag&0x1f == 0x1f {
+		return el, nil, errors.New("multi-byte BER tags are not supported")
+	}
+	n, hdrLen := int(b[1]), 2
+	if n&0x80 != 0 {
+		k := n & 0x7f
+		if k == 0 || k > 4 || len(b) < 2+k {
+			return el, nil, errors.New("unsupported or truncated BER length")
+		}
+		n = 0
+		for _, c := range b[2 : 2+k] {
+			n = n<<8 | int(c)
+		}
+		hdrLen += k
+	}
+	if n < 0 || len(b)-hdrLen < n {
+		return el, nil, errors.New("truncated BER element")
+	}
+	el.contents = b[hdrLen : hdrLen+n]
+	return el, b[hdrLen+n:], nil
+}
+
+// readBERElement reads a single BER element from r.
+func readBERElement(r *bufio.Reader) (berElement, error) {
+	var el berElement
+	var err error
+	if el.tag, err = r.ReadByte(); err != nil {
+		return el, err
+	}
+	if el.tag&0x1f == 0x1f {
+		return el, errors.New("multi-byte BER tags are not supported")
+	}
+	l, err := r.ReadByte()
+	if err != nil {
+		return el, err
+	}
+	n := int(l)
+	if l&0x80 != 0 {
+		k := int(l & 0x7f)
+		if k == 0 || k > 4 {
+			return el, errors.New("unsupported BER length encoding")
+		}
+		n = 0
+		for i := 0; i < k; i++ {
+			c, err := r.ReadByte()
+			if err != nil {
+				return el, err
+			}
+			n = n<<8 | int(c)
+		}
+	}
+	if n < 0 || n > maxLDAPMessageSize {
+		return el, errors.Newf("LDAP message too large (%d bytes)", n)
+	}
+	el.contents = make([]byte, n)
+	if _, err := io.ReadFull(r, el.contents); err != nil {
+		return el, err
+	}
+	return el, nil
+}
+
+// int64 decodes the contents of an INTEGER or ENUMERATED element.
+func (el berElement) int64() (int64, error) {
+	if len(el.contents) == 0 || len(el.contents) > 8 {
+		return 0, errors.New("invalid BER integer")
+	}
+	// Sign-extend from the first octet.
+	v := int64(int8(el.contents[0]))
+	for _, c := range el.contents[1:] {
+		v = v<<8 | int64(c)
+	}
+	return v, nil
+}
+
+// ldapResultError is returned when the server responds to a request
+// with a result code other than success.
+type ldapResultError struct {
+	code    int64
+	message string
+}
+
+func (e *ldapResultError) Error() string {
+	if e.message == "" {
+		return "LDAP result code " + strconv.FormatInt(e.code, 10)
+	}
+	return "LDAP result code " + strconv.FormatInt(e.code, 10) + ": " + e.message
+}
+
+// checkLDAPResult decodes the LDAPResult at the start of the contents
+// of a response, and returns an error if it does not indicate success.
+func checkLDAPResult(contents []byte) error {
+	codeEl, rest, err := parseBERElement(contents)
+	if err != nil {
+		return err
+	}
+	if codeEl.tag != berTagEnumerated {
+		return errors.New("malformed LDAP result")
+	}
+	code, err := codeEl.int64()
+	if err != nil {
+		return err
+	}
+	// Skip matchedDN.
+	if _, rest, err = parseBERElement(rest); err != nil {
+		return err
+	}
+	diagEl, _, err := parseBERElement(rest)
+	if err != nil {
+		return err
+	}
+	if code != ldapResultSuccess {
+		return &ldapResultError{code: code, message: string(diagEl.contents)}
+	}
+	return nil
+}
+
+// ldapConn is a client connection to an LDAP server.
+type ldapConn struct {
+	conn  net.Conn
+	r     *bufio.Reader
+	msgID int64
+}
+
+// dialLDAP opens a connection to the given LDAP server. If useTLS is
+// set, the TLS handshake is performed immediately (i.e. the "ldaps"
+// scheme). The context deadline, if any, applies to all subsequent
+// exchanges on the connection.
+func dialLDAP(
+	ctx context.Context, server string, port int, useTLS bool, rootCAs *x509.CertPool,
+) (*ldapConn, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(server, strconv.Itoa(port)))
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+	c := &ldapConn{conn: conn, r: bufio.NewReader(conn)}
+	if useTLS {
+		if err := c.handshakeTLS(server, rootCAs); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// handshakeTLS upgrades the connection to TLS, verifying the server
+// certificate against rootCAs (or the system roots if nil).
+func (c *ldapConn) handshakeTLS(server string, rootCAs *x509.CertPool) error {
+	tlsConn := tls.Client(c.conn, &tls.Config{
+		ServerName: server,
+		RootCAs:    rootCAs,
+		MinVersion: tls.VersionTLS12,
+	})
+	if err := tlsConn.Handshake(); err != nil {
+		return errors.Wrap(err, "LDAP TLS handshake")
+	}
+	c.conn = tlsConn
+	c.r = bufio.NewReader(tlsConn)
+	return nil
+}
+
+// close sends an unbind request to the server, on a best-effort basis,
+// and closes the connection.
+func (c *ldapConn) close() {
+	_, _ = c.send(berEncode(ldapTagUnbindRequest))
+	_ = c.conn.Close()
+}
+
+// send sends a request with the given protocol operation, and returns
+// the message ID assigned to it.
+func (c *ldapConn) send(op []byte) (int64, error) {
+	c.msgID++
+	msg := berEncode(berTagSequence, berInteger(berTagInteger, c.msgID), op)
+	if _, err := c.conn.Write(msg); err != nil {
+		return 0, err
+	}
+	return c.msgID, nil
+}
+
+// receive reads the next message from the server and returns its
+// protocol operation. An error is returned if the message is not a
+// response to the request with the given message ID, which includes
+// unsolicited notifications such as a notice of disconnection.
+func (c *ldapConn) receive(msgID int64) (berElement, error) {
+	msg, err := readBERElement(c.r)
+	if err != nil {
+		return berElement{}, err
+	}
+	if msg.tag != berTagSequence {
+		return berElement{}, errors.New("malformed LDAP message")
+	}
+	idEl, rest, err := parseBERElement(msg.contents)
+	if err != nil {
+		return berElement{}, err
+	}
+	id, err := idEl.int64()
+	if err != nil {
+		return berElement{}, err
+	}
+	op, _, err := parseBERElement(rest)
+	if err != nil {
+		return berElement{}, err
+	}
+	if id != msgID {
+		if op.tag == ldapTagExtendedResponse {
+			if err := checkLDAPResult(op.contents); err != nil {
+				return berElement{}, errors.Wrap(err, "unsolicited LDAP notification")
+			}
+		}
+		return berElement{}, errors.Newf("unexpected LDAP message ID %d, expected %d", id, msgID)
+	}
+	return op, nil
+}
+
+// startTLS performs the StartTLS extended operation (RFC 4511, section
+// 4.14) and upgrades the connection to TLS.
+func (c *ldapConn) startTLS(server string, rootCAs *x509.CertPool) error {
+	id, err := c.send(berEncode(ldapTagExtendedRequest,
+		berString(ldapTagExtendedRequestName, ldapStartTLSOID)))
+	if err != nil {
+		return err
+	}
+	op, err := c.receive(id)
+	if err != nil {
+		return err
+	}
+	if op.tag != ldapTagExtendedResponse {
+		return errors.Newf("unexpected LDAP response to StartTLS request (tag %#x)", op.tag)
+	}
+	if err := checkLDAPResult(op.contents); err != nil {
+		return errors.Wrap(err, "StartTLS")
+	}
+	return c.handshakeTLS(server, rootCAs)
+}
+
+// bind performs a simple bind with the given DN and password.
+//
+// Note that per RFC 4513, section 5.1.2, a simple bind with an empty
+// password is an "unauthenticated" bind, which many servers accept
+// regardless of the DN. Callers are responsible for rejecting empty
+// passwords when the result of the bind is used to authenticate a user.
+func (c *ldapConn) bind(dn, password string) error {
+	id, err := c.send(berEncode(ldapTagBindRequest,
+		berInteger(berTagInteger, ldapVersion),
+		berString(berTagOctetString, dn),
+		berString(ldapTagSimpleAuthentication, password)))
+	if err != nil {
+		return err
+	}
+	op, err := c.receive(id)
+	if err != nil {
+		return err
+	}
+	if op.tag != ldapTagBindResponse {
+		return errors.Newf("unexpected LDAP response to bind request (tag %#x)", op.tag)
+	}
+	return checkLDAPResult(op.contents)
+}
+
+// errLDAPEntryNotFound is returned by searchDN when no entry matches.
+var errLDAPEntryNotFound = errors.New("no matching LDAP entry found")
+
+// searchDN searches the subtree rooted at baseDN for an entry whose
+// attribute attr is equal to value, and returns its DN. An error is
+// returned unless exactly one entry matches.
+//
+// The value is sent as part of a structured equality filter, so it
+// does not need to be escaped.
+func (c *ldapConn) searchDN(baseDN, attr, value string, timeLimitSecs int64) (string, error) {
+	id, err := c.send(berEncode(ldapTagSearchRequest,
+		berString(berTagOctetString, baseDN),
+		berInteger(berTagEnumerated, ldapScopeWholeSubtree),
+		berInteger(berTagEnumerated, ldapNeverDerefAliases),
+		// We only need to know whether there is more than one match.
+		berInteger(berTagInteger, 2 /* sizeLimit */),
+		berInteger(berTagInteger, timeLimitSecs),
+		berBool(true /* typesOnly */),
+		berEncode(ldapTagEqualityMatchFilter,
+			berString(berTagOctetString, attr),
+			berString(berTagOctetString, value)),
+		berEncode(berTagSequence, berString(berTagOctetString, ldapNoAttributes)),
+	))
+	if err != nil {
+		return "", err
+	}
+	var dns []string
+	for {
+		op, err := c.receive(id)
+		if err != nil {
+			return "", err
+		}
+		switch op.tag {
+		case ldapTagSearchResultEntry:
+			name, _, err := parseBERElement(op.contents)
+			if err != nil {
+				return "", err
+			}
+			dns = append(dns, string(name.contents))
+		case ldapTagSearchResultReference:
+			// Referrals are not followed.
+		case ldapTagSearchResultDone:
+			resErr := checkLDAPResult(op.contents)
+			if len(dns) > 1 {
+				return "", errors.Newf("LDAP search for %s=%q returned more than one entry", attr, value)
+			}
+			if resErr != nil {
+				var ldapErr *ldapResultError
+				if !errors.As(resErr, &ldapErr) || ldapErr.code != ldapResultSizeLimitExceed {
+					return "", resErr
+				}
+			}
+			if len(dns) == 0 {
+				return "", errLDAPEntryNotFound
+			}
+			return dns[0], nil
+		default:
+			return "", errors.Newf("unexpected LDAP response to search request (tag %#x)", op.tag)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package ldapccl
+
+import (
+	"crypto/x509"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/errors"
+)
+
+// All cluster settings necessary for the LDAP authentication method.
+const (
+	baseLDAPSettingName          = "server.ldap_authentication."
+	LDAPTimeoutSettingName       = baseLDAPSettingName + "timeout"
+	LDAPCACertificateSettingName = baseLDAPSettingName + "ca_certificate"
+)
+
+// LDAPTimeout bounds the total duration of the exchange with the LDAP
+// server(s) for a single authentication attempt.
+var LDAPTimeout = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	LDAPTimeoutSettingName,
+	"maximum duration of the exchange with the LDAP server during a SQL login",
+	10*time.Second,
+	settings.PositiveDuration,
+)
+
+// LDAPCACertificate is a PEM-encoded certificate bundle used to verify
+// the certificate presented by the LDAP server over TLS. When empty,
+// the host's root CA set is used.
+var LDAPCACertificate = settings.RegisterValidatedStringSetting(
+	settings.TenantWritable,
+	LDAPCACertificateSettingName,
+	"PEM-encoded CA certificates used to verify LDAP servers over TLS; "+
+		"if empty, the system root CAs are used",
+	"",
+	func(_ *settings.Values, s string) error {
+		_, err := parseCACertificate(s)
+		return err
+	},
+)
+
+// parseCACertificate parses the value of the LDAPCACertificate cluster
+// setting. A nil pool is returned if the setting is empty.
+func parseCACertificate(s string) (*x509.CertPool, error) {
+	if s == "" {
+		return nil, nil
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(s)) {
+		return nil, errors.New("no valid PEM-encoded certificate found")
+	}
+	return pool, nil
+}
@@ -36,6 +36,7 @@ func initializeMultiRegionMetadata(
 	primaryRegion catpb.RegionName,
 	regions []tree.Name,
 	dataPlacement tree.DataPlacement,
+	secondaryRegion catpb.RegionName,
 ) (*multiregion.RegionConfig, error) {
 	if err := CheckClusterSupportsMultiRegion(execCfg); err != nil {
 		return nil, err
@@ -98,7 +99,13 @@ func initializeMultiRegionMetadata(
 	}
 	regionConfig := multiregion.MakeRegionConfig(
 		regionNames, primaryRegion, survivalGoal, regionEnumID, placement, nil,
+		multiregion.WithSecondaryRegion(secondaryRegion),
 	)
+	if secondaryRegion != "" {
+		if err := multiregion.CanSetSecondaryRegion(secondaryRegion, regionConfig); err != nil {
+			return nil, err
+		}
+	}
 	if err := multiregion.ValidateRegionConfig(regionConfig); err != nil {
 		return nil, err
 	}
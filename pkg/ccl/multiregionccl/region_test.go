@@ -10,8 +10,10 @@ package multiregionccl_test
 
 import (
 	"context"
+	gosql "database/sql"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/cockroachdb/cockroach/pkg/base"
@@ -989,3 +991,91 @@ INSERT INTO db.rbr VALUES (1,1),(2,2),(3,3);
 		}
 	}
 }
+
+// TestCancelMultiRegionConversion ensures that a database is converted back
+// to a regular database when the job of ALTER DATABASE ... CONVERT TO
+// MULTIREGION is canceled after some of its steps have completed.
+func TestCancelMultiRegionConversion(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	skip.UnderRace(t, "times out under race")
+
+	// The job is blocked before it adds the regions, which is after it has set
+	// the primary region of the database.
+	reachedAddRegions := make(chan struct{})
+	unblockAddRegions := make(chan struct{})
+	var once sync.Once
+	knobs := base.TestingKnobs{
+		SQLExecutor: &sql.ExecutorTestingKnobs{
+			BeforeMultiRegionConversionStep: func(status string) {
+				if strings.HasPrefix(status, "add regions") {
+					once.Do(func() {
+						close(reachedAddRegions)
+						<-unblockAddRegions
+					})
+				}
+			},
+		},
+		// Decrease the adopt loop interval so that retries happen quickly.
+		JobsTestingKnobs: jobs.NewTestingKnobsWithShortIntervals(),
+	}
+
+	_, sqlDB, cleanup := multiregionccltestutils.TestingCreateMultiRegionCluster(
+		t, 3 /* numServers */, knobs,
+	)
+	defer cleanup()
+
+	_, err := sqlDB.Exec(`
+CREATE DATABASE db;
+CREATE TABLE db.t(k INT PRIMARY KEY);
+`)
+	require.NoError(t, err)
+
+	convertErr := make(chan error, 1)
+	go func() {
+		_, err := sqlDB.Exec(`ALTER DATABASE db CONVERT TO MULTIREGION PRIMARY REGION "us-east1" ` +
+			`REGIONS "us-east2", "us-east3" TABLE LOCALITY GLOBAL`)
+		convertErr <- err
+	}()
+	<-reachedAddRegions
+
+	var primaryRegion string
+	require.NoError(t, sqlDB.QueryRow(
+		`SELECT region FROM [SHOW REGIONS FROM DATABASE db] WHERE "primary"`,
+	).Scan(&primaryRegion))
+	require.Equal(t, "us-east1", primaryRegion)
+
+	_, err = sqlDB.Exec(`CANCEL JOB (
+	SELECT job_id FROM [SHOW JOBS] WHERE job_type = 'MULTIREGION CONVERSION'
+)`)
+	require.NoError(t, err)
+	close(unblockAddRegions)
+	require.True(t, testutils.IsError(<-convertErr, "job canceled by user"))
+
+	testutils.SucceedsSoon(t, func() error {
+		var status string
+		if err := sqlDB.QueryRow(
+			`SELECT status FROM [SHOW JOBS] WHERE job_type = 'MULTIREGION CONVERSION'`,
+		).Scan(&status); err != nil {
+			return err
+		}
+		if status != string(jobs.StatusCanceled) {
+			return errors.Newf("expected job to be canceled, got %s", status)
+		}
+		return nil
+	})
+
+	// The primary region set by the job has been dropped, and the steps which
+	// had not run when the job was canceled have not been run.
+	var createStmt string
+	require.NoError(t, sqlDB.QueryRow(
+		`SELECT create_statement FROM [SHOW CREATE DATABASE db]`,
+	).Scan(&createStmt))
+	require.Equal(t, "CREATE DATABASE db", createStmt)
+	var locality gosql.NullString
+	require.NoError(t, sqlDB.QueryRow(
+		`SELECT locality FROM [SHOW TABLES FROM db] WHERE table_name = 't'`,
+	).Scan(&locality))
+	require.False(t, locality.Valid, "unexpected locality %s", locality.String)
+}
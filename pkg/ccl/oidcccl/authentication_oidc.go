@@ -43,16 +43,18 @@ const (
 	beginAuthCounterName     = counterPrefix + "begin_auth"
 	beginCallbackCounterName = counterPrefix + "begin_callback"
 	loginSuccessCounterName  = counterPrefix + "login_success"
+	cliLoginSuccessName      = counterPrefix + "cli_login_success"
 	enableCounterName        = counterPrefix + "enable"
 	hmacKeySize              = 32
 	stateTokenSize           = 32
 )
 
 var (
-	beginAuthUseCounter     = telemetry.GetCounterOnce(beginAuthCounterName)
-	beginCallbackUseCounter = telemetry.GetCounterOnce(beginCallbackCounterName)
-	loginSuccessUseCounter  = telemetry.GetCounterOnce(loginSuccessCounterName)
-	enableUseCounter        = telemetry.GetCounterOnce(enableCounterName)
+	beginAuthUseCounter       = telemetry.GetCounterOnce(beginAuthCounterName)
+	beginCallbackUseCounter   = telemetry.GetCounterOnce(beginCallbackCounterName)
+	loginSuccessUseCounter    = telemetry.GetCounterOnce(loginSuccessCounterName)
+	cliLoginSuccessUseCounter = telemetry.GetCounterOnce(cliLoginSuccessName)
+	enableUseCounter          = telemetry.GetCounterOnce(enableCounterName)
 )
 
 // oidcAuthenticationServer is an implementation of the OpenID Connect authentication code flow
@@ -314,6 +316,19 @@ var ConfigureOIDC = func(
 			return
 		}
 
+		extra, err := kast.extra(stateTokenSize)
+		if err != nil {
+			log.Errorf(ctx, "OIDC: decoding state token: %v", err)
+			http.Error(w, genericCallbackHTTPError, http.StatusBadRequest)
+			return
+		}
+		cliRequest, err := decodeCLILoginRequest(extra)
+		if err != nil {
+			log.Errorf(ctx, "OIDC: decoding state token: %v", err)
+			http.Error(w, genericCallbackHTTPError, http.StatusBadRequest)
+			return
+		}
+
 		oauth2Token, err := oidcAuthentication.oauth2Config.Exchange(ctx, r.URL.Query().Get(codeKey))
 		if err != nil {
 			log.Errorf(ctx, "OIDC: failed to exchange code for token: %v", err)
@@ -359,6 +374,23 @@ var ConfigureOIDC = func(
 		}
 
 		username := match[1]
+		if cliRequest != nil {
+			// The login was initiated by `cockroach sql --sso`: hand the ID
+			// token over to the SQL shell instead of creating a web session.
+			// The SQL connection is then authenticated using the token.
+			org := sql.ClusterOrganization.Get(&st.SV)
+			if err := utilccl.CheckEnterpriseEnabled(st, cluster, org, "OIDC"); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if err := writeCLILoginResponse(w, cliRequest, rawIDToken, username); err != nil {
+				log.Errorf(ctx, "OIDC: failed to complete CLI authentication: %v", err)
+				return
+			}
+			telemetry.Inc(cliLoginSuccessUseCounter)
+			return
+		}
+
 		cookie, err := userLoginFromSSO(ctx, username)
 		if err != nil {
 			log.Errorf(ctx, "OIDC: failed to complete authentication: unable to create session for %s: %v", username, err)
@@ -395,7 +427,14 @@ var ConfigureOIDC = func(
 
 		telemetry.Inc(beginAuthUseCounter)
 
-		kast, err := newKeyAndSignedToken(hmacKeySize, stateTokenSize)
+		cliRequest, err := parseCLILoginRequest(r.URL.Query())
+		if err != nil {
+			log.Errorf(ctx, "OIDC: %v", err)
+			http.Error(w, genericLoginHTTPError, http.StatusBadRequest)
+			return
+		}
+
+		kast, err := newKeyAndSignedToken(hmacKeySize, stateTokenSize, cliRequest.encode())
 		if err != nil {
 			log.Errorf(ctx, "OIDC: unable to generate key and signed message: %v", err)
 			http.Error(w, genericLoginHTTPError, http.StatusInternalServerError)
@@ -236,18 +236,18 @@ func TestOIDCEnabled(t *testing.T) {
 }
 
 func TestKeyAndSignedTokenIsValid(t *testing.T) {
-	kastValid, err := newKeyAndSignedToken(32, 32)
+	kastValid, err := newKeyAndSignedToken(32, 32, nil /* extra */)
 	require.NoError(t, err)
-	kastModifiedCookie, err := newKeyAndSignedToken(32, 32)
+	kastModifiedCookie, err := newKeyAndSignedToken(32, 32, nil /* extra */)
 	require.NoError(t, err)
 	kastModifiedCookie.secretKeyCookie.Value = kastModifiedCookie.secretKeyCookie.Value + "Z"
-	kastModifiedTokenPayload, err := newKeyAndSignedToken(32, 32)
+	kastModifiedTokenPayload, err := newKeyAndSignedToken(32, 32, nil /* extra */)
 	require.NoError(t, err)
 	kastModifiedTokenPayload.signedTokenEncoded = kastModifiedCookie.signedTokenEncoded + "Z"
-	kastEmptyCookie, err := newKeyAndSignedToken(32, 32)
+	kastEmptyCookie, err := newKeyAndSignedToken(32, 32, nil /* extra */)
 	require.NoError(t, err)
 	kastEmptyCookie.secretKeyCookie.Value = ""
-	kastEmptyToken, err := newKeyAndSignedToken(32, 32)
+	kastEmptyToken, err := newKeyAndSignedToken(32, 32, nil /* extra */)
 	require.NoError(t, err)
 	kastEmptyToken.signedTokenEncoded = ""
 
@@ -368,3 +368,44 @@ func Test_getRegionSpecificRedirectURL(t *testing.T) {
 		})
 	}
 }
+
+func TestCLILoginRequest(t *testing.T) {
+	const nonce = "0123456789abcdef0123456789abcdef"
+
+	// Logins from the DB Console do not carry a CLI request.
+	req, err := parseCLILoginRequest(url.Values{})
+	require.NoError(t, err)
+	require.Nil(t, req)
+	require.Nil(t, req.encode())
+	req, err = decodeCLILoginRequest(nil)
+	require.NoError(t, err)
+	require.Nil(t, req)
+
+	req, err = parseCLILoginRequest(url.Values{cliPortKey: {"26260"}, cliNonceKey: {nonce}})
+	require.NoError(t, err)
+	require.Equal(t, &cliLoginRequest{port: 26260, nonce: nonce}, req)
+
+	// The request survives the round trip through the state token.
+	kast, err := newKeyAndSignedToken(32, 32, req.encode())
+	require.NoError(t, err)
+	valid, err := kast.validate()
+	require.NoError(t, err)
+	require.True(t, valid)
+	extra, err := kast.extra(32)
+	require.NoError(t, err)
+	decoded, err := decodeCLILoginRequest(extra)
+	require.NoError(t, err)
+	require.Equal(t, req, decoded)
+
+	for _, q := range []url.Values{
+		{cliPortKey: {"26260"}},
+		{cliNonceKey: {nonce}},
+		{cliPortKey: {"0"}, cliNonceKey: {nonce}},
+		{cliPortKey: {"65536"}, cliNonceKey: {nonce}},
+		{cliPortKey: {"26260"}, cliNonceKey: {"short"}},
+		{cliPortKey: {"26260"}, cliNonceKey: {"<script>alert(1)</script>0123456789"}},
+	} {
+		_, err := parseCLILoginRequest(q)
+		require.Error(t, err, "query: %v", q)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package oidcccl
+
+import (
+	"encoding/binary"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+)
+
+// This file contains the support for logins initiated by the SQL shell
+// using `cockroach sql --sso`.
+//
+// The SQL shell listens on a loopback port, then opens a browser on
+// `/oidc/v1/login?cli_port=<port>&cli_nonce=<nonce>`. The port and
+// nonce are carried through the OIDC flow inside the HMAC-protected
+// state token. Once the callback has verified the ID token, instead of
+// creating a web session, it responds with a page that posts the raw
+// ID token, the SQL username derived from it and the nonce back to the
+// SQL shell on the loopback port. The SQL shell checks the nonce and
+// uses the ID token in place of a password, to be verified by the
+// "jwt" HBA authentication method.
+
+const (
+	cliPortKey  = "cli_port"
+	cliNonceKey = "cli_nonce"
+)
+
+// validCLINonce matches the nonces generated by the SQL shell.
+var validCLINonce = regexp.MustCompile(`^[0-9a-f]{16,64}$`)
+
+// cliLoginRequest identifies a login initiated by the SQL shell.
+type cliLoginRequest struct {
+	port  uint16
+	nonce string
+}
+
+// parseCLILoginRequest extracts the parameters of a login initiated by
+// the SQL shell from the query parameters of the login request. It
+// returns nil if the login was not initiated by the SQL shell.
+func parseCLILoginRequest(query url.Values) (*cliLoginRequest, error) {
+	portStr, nonce := query.Get(cliPortKey), query.Get(cliNonceKey)
+	if portStr == "" && nonce == "" {
+		return nil, nil
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil || port == 0 {
+		return nil, errors.Newf("invalid %s: %q", cliPortKey, portStr)
+	}
+	if !validCLINonce.MatchString(nonce) {
+		return nil, errors.Newf("invalid %s", cliNonceKey)
+	}
+	return &cliLoginRequest{port: uint16(port), nonce: nonce}, nil
+}
+
+// encode encodes the request for inclusion in the state token.
+func (r *cliLoginRequest) encode() []byte {
+	if r == nil {
+		return nil
+	}
+	b := make([]byte, 2, 2+len(r.nonce))
+	binary.BigEndian.PutUint16(b, r.port)
+	return append(b, r.nonce...)
+}
+
+// decodeCLILoginRequest decodes the output of encode. It returns nil if
+// the input is empty.
+func decodeCLILoginRequest(b []byte) (*cliLoginRequest, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	if len(b) < 2 {
+		return nil, errors.New("malformed CLI login request")
+	}
+	r := &cliLoginRequest{port: binary.BigEndian.Uint16(b), nonce: string(b[2:])}
+	if r.port == 0 || !validCLINonce.MatchString(r.nonce) {
+		return nil, errors.New("malformed CLI login request")
+	}
+	return r, nil
+}
+
+var cliLoginResponseTemplate = template.Must(template.New("cli-login").Parse(`<!DOCTYPE html>
+<html>
+<head><title>CockroachDB SQL shell login</title></head>
+<body onload="document.forms[0].submit()">
+<p>Completing the login of the SQL shell...</p>
+<form method="POST" action="{{.Action}}">
+<input type="hidden" name="id_token" value="{{.IDToken}}">
+<input type="hidden" name="user" value="{{.User}}">
+<input type="hidden" name="nonce" value="{{.Nonce}}">
+<noscript><input type="submit" value="Continue"></noscript>
+</form>
+</body>
+</html>
+`))
+
+// writeCLILoginResponse responds to the callback with a page that
+// hands the ID token over to the SQL shell listening on the loopback
+// interface.
+func writeCLILoginResponse(
+	w http.ResponseWriter, r *cliLoginRequest, rawIDToken string, username string,
+) error {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	return cliLoginResponseTemplate.Execute(w, struct {
+		Action, IDToken, User, Nonce string
+	}{
+		Action:  fmt.Sprintf("http://127.0.0.1:%d/", r.port),
+		IDToken: rawIDToken,
+		User:    username,
+		Nonce:   r.nonce,
+	})
+}
@@ -32,17 +32,20 @@ type keyAndSignedToken struct {
 
 // newKeyAndSignedToken creates an instance of `keyAndSignedToken` by randomly generating a key
 // and a message of the requested sizes and encoding them into the datatypes we need in order to
-// proceed with a secure OIDC auth request.
-func newKeyAndSignedToken(keySize int, tokenSize int) (*keyAndSignedToken, error) {
+// proceed with a secure OIDC auth request. The `extra` bytes, if any, are appended to the random
+// message so that they are covered by the HMAC and can be retrieved in the callback using
+// `extra()`.
+func newKeyAndSignedToken(keySize int, tokenSize int, extra []byte) (*keyAndSignedToken, error) {
 	secretKey := make([]byte, keySize)
 	if _, err := crypto_rand.Read(secretKey); err != nil {
 		return nil, err
 	}
 
-	token := make([]byte, tokenSize)
+	token := make([]byte, tokenSize, tokenSize+len(extra))
 	if _, err := crypto_rand.Read(token); err != nil {
 		return nil, err
 	}
+	token = append(token, extra...)
 
 	mac := hmac.New(sha256.New, secretKey)
 	_, err := mac.Write(token)
@@ -96,6 +99,19 @@ func (kast *keyAndSignedToken) validate() (bool, error) {
 	return hmac.Equal(signedToken.TokenMAC, mac.Sum(nil)), nil
 }
 
+// extra returns the bytes that were appended to the random message of the given size when the
+// keyAndSignedToken was created. The keyAndSignedToken must have been validated beforehand.
+func (kast *keyAndSignedToken) extra(tokenSize int) ([]byte, error) {
+	signedToken, err := decodeOIDCState(kast.signedTokenEncoded)
+	if err != nil {
+		return nil, err
+	}
+	if len(signedToken.Token) < tokenSize {
+		return nil, errors.New("state token too short")
+	}
+	return signedToken.Token[tokenSize:], nil
+}
+
 func encodeOIDCState(statePb serverpb.OIDCState) (string, error) {
 	stateBytes, err := protoutil.Marshal(&statePb)
 	if err != nil {
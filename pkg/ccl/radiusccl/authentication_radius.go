@@ -0,0 +1,258 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package radiusccl
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/hba"
+	"github.com/cockroachdb/errors"
+)
+
+// This file implements the "radius" HBA authentication method, which
+// verifies the password provided by the client, typically a one-time
+// password, using a RADIUS server.
+//
+// The configuration options mirror those of PostgreSQL:
+// https://www.postgresql.org/docs/current/auth-radius.html
+//
+//   - radiusservers: the RADIUS servers, tried in order until one of
+//     them responds.
+//   - radiussecrets: the shared secrets of the servers.
+//   - radiusports: the ports of the servers (default 1812).
+//   - radiusidentifiers: the NAS-Identifier sent to the servers
+//     (default "cockroachdb").
+//
+// Each of the last three options takes either a single value, used for
+// all the servers, or one value per server. In addition, the secrets
+// can be read from files using the radiussecretfiles option instead of
+// radiussecrets, so that they need not be stored in the cluster
+// settings. The files are read on every authentication attempt, so
+// secrets can be rotated without changing the HBA configuration.
+// Instead of a path, a secret URL can be used to load the secret from
+// an external secret store, e.g. vault://vault.example.com:8200/secret/data/crdb?field=radius
+// (see security.LoadSecret).
+//
+// Values are comma-separated lists, so options with multiple values
+// must be quoted in their entirety, e.g.
+//
+//     host all all all radius "radiusservers=r1.example.com,r2.example.com" radiussecretfiles=/etc/crdb/radius-secret
+
+const (
+	defaultRADIUSPort       = 1812
+	defaultRADIUSIdentifier = "cockroachdb"
+)
+
+func init() {
+	pgwire.RegisterAuthProvider("radius", pgwire.AuthProviderFunc(authRADIUS), hba.ConnAny, checkEntry)
+}
+
+// radiusConf is the configuration of the RADIUS method, as decoded
+// from the options of an HBA entry.
+type radiusConf struct {
+	servers     []string
+	ports       []int
+	secrets     []string
+	secretFiles []string
+	identifiers []string
+}
+
+// splitList splits the comma-separated value of an option.
+func splitList(name, value string) ([]string, error) {
+	parts := strings.Split(value, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+		if parts[i] == "" {
+			return nil, errors.Errorf("invalid %s: empty list element", name)
+		}
+	}
+	return parts, nil
+}
+
+// parseRADIUSOptions decodes and validates the options of an HBA entry
+// using the radius method.
+func parseRADIUSOptions(entry hba.Entry) (*radiusConf, error) {
+	conf := &radiusConf{}
+	for _, op := range entry.Options {
+		var list *[]string
+		switch op[0] {
+		case "radiusservers":
+			list = &conf.servers
+		case "radiussecrets":
+			list = &conf.secrets
+		case "radiussecretfiles":
+			list = &conf.secretFiles
+		case "radiusidentifiers":
+			list = &conf.identifiers
+		case "radiusports":
+			ports, err := splitList(op[0], op[1])
+			if err != nil {
+				return nil, err
+			}
+			for _, p := range ports {
+				port, err := strconv.Atoi(p)
+				if err != nil || port <= 0 || port > 65535 {
+					return nil, errors.Errorf("invalid radiusports: %s", p)
+				}
+				conf.ports = append(conf.ports, port)
+			}
+			continue
+		case "map":
+			continue
+		default:
+			return nil, errors.WithHint(
+				errors.Errorf("unsupported option %s", op[0]),
+				`Options with multiple values must be quoted together with the `+
+					`option name, for example "radiusservers=r1.example.com,r2.example.com".`)
+		}
+		values, err := splitList(op[0], op[1])
+		if err != nil {
+			return nil, err
+		}
+		*list = append(*list, values...)
+	}
+	if len(conf.servers) == 0 {
+		return nil, errors.New(`the "radiusservers" option is required`)
+	}
+	if (len(conf.secrets) == 0) == (len(conf.secretFiles) == 0) {
+		return nil, errors.New(`exactly one of "radiussecrets" or "radiussecretfiles" is required`)
+	}
+	for _, f := range conf.secretFiles {
+		if err := security.ValidateSecretRef(f); err != nil {
+			return nil, errors.Wrap(err, "invalid radiussecretfiles")
+		}
+	}
+	for _, l := range []struct {
+		name string
+		n    int
+	}{
+		{"radiussecrets", len(conf.secrets)},
+		{"radiussecretfiles", len(conf.secretFiles)},
+		{"radiusports", len(conf.ports)},
+		{"radiusidentifiers", len(conf.identifiers)},
+	} {
+		if l.n > 1 && l.n != len(conf.servers) {
+			return nil, errors.Errorf(
+				"the number of %s (%d) must be 1 or match the number of radiusservers (%d)",
+				l.name, l.n, len(conf.servers))
+		}
+	}
+	return conf, nil
+}
+
+// pick returns the i-th element of a per-server list, the only element
+// of a single-element list, or def if the list is empty.
+func pick(list []string, i int, def string) string {
+	switch len(list) {
+	case 0:
+		return def
+	case 1:
+		return list[0]
+	default:
+		return list[i]
+	}
+}
+
+// server returns the address and shared secret of the i-th server.
+func (conf *radiusConf) server(ctx context.Context, i int) (*radiusServer, error) {
+	s := &radiusServer{host: conf.servers[i], port: defaultRADIUSPort}
+	switch len(conf.ports) {
+	case 0:
+	case 1:
+		s.port = conf.ports[0]
+	default:
+		s.port = conf.ports[i]
+	}
+	if len(conf.secretFiles) > 0 {
+		path := pick(conf.secretFiles, i, "")
+		secret, err := security.LoadSecret(ctx, path)
+		if err != nil {
+			return nil, errors.Wrap(err, "reading RADIUS secret")
+		}
+		s.secret = secret
+	} else {
+		s.secret = []byte(pick(conf.secrets, i, ""))
+	}
+	if len(s.secret) == 0 {
+		return nil, errors.Newf("empty RADIUS secret for server %s", s.host)
+	}
+	return s, nil
+}
+
+// checkEntry validates the options of an HBA entry using the radius
+// method.
+func checkEntry(_ *settings.Values, entry hba.Entry) error {
+	_, err := parseRADIUSOptions(entry)
+	return err
+}
+
+// authRADIUS is the AuthProvider for HBA method "radius": verify the
+// password provided by the client using the configured RADIUS server(s).
+func authRADIUS(
+	ctx context.Context,
+	systemIdentity security.SQLUsername,
+	c pgwire.AuthConn,
+	creds pgwire.AuthCredentials,
+) error {
+	conf, err := parseRADIUSOptions(*creds.Entry)
+	if err != nil {
+		return err
+	}
+	execCfg := creds.ExecCfg
+	if err := conf.authenticate(ctx, &execCfg.Settings.SV, systemIdentity.Normalized(), creds.Password); err != nil {
+		c.LogAuthInfof(ctx, "RADIUS authentication failed: %v", err)
+		return security.NewErrPasswordUserAuthFailed(systemIdentity)
+	}
+
+	// Do the license check last so that administrators are able to
+	// test whether their RADIUS configuration is correct.
+	return utilccl.CheckEnterpriseEnabled(execCfg.Settings, execCfg.ClusterID(), execCfg.Organization(), "RADIUS authentication")
+}
+
+// authenticate verifies the credentials of the given user using the
+// configured RADIUS servers. The servers are tried in order; the next
+// server is only tried if the previous one could not be reached or did
+// not respond.
+func (conf *radiusConf) authenticate(
+	ctx context.Context, sv *settings.Values, user, password string,
+) error {
+	return conf.authenticateWithPolicy(ctx, user, password, RADIUSTimeout.Get(sv), int(RADIUSMaxRetries.Get(sv)))
+}
+
+// authenticateWithPolicy is like authenticate, with the given timeout
+// and number of retransmissions per server.
+func (conf *radiusConf) authenticateWithPolicy(
+	ctx context.Context, user, password string, timeout time.Duration, maxRetries int,
+) error {
+	var connErr error
+	for i := range conf.servers {
+		s, err := conf.server(ctx, i)
+		if err != nil {
+			return err
+		}
+		r := &radiusRequest{
+			user:       user,
+			password:   password,
+			identifier: pick(conf.identifiers, i, defaultRADIUSIdentifier),
+		}
+		err = s.exchange(ctx, r, timeout, maxRetries)
+		if err == nil || errors.Is(err, errRADIUSAccessRejected) || ctx.Err() != nil {
+			return err
+		}
+		connErr = errors.CombineErrors(connErr, errors.Wrapf(err, "contacting %s", s.host))
+	}
+	return connErr
+}
@@ -0,0 +1,232 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package radiusccl
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"encoding/binary"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/hba"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRADIUSOptions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	tests := []struct {
+		name    string
+		options [][2]string
+		wantErr string
+		want    *radiusConf
+	}{
+		{"no server", [][2]string{{"radiussecrets", "s"}}, `"radiusservers" option is required`, nil},
+		{"no secret", [][2]string{{"radiusservers", "a"}}, "exactly one of", nil},
+		{"both secrets",
+			[][2]string{{"radiusservers", "a"}, {"radiussecrets", "s"}, {"radiussecretfiles", "/f"}},
+			"exactly one of", nil},
+		{"single server",
+			[][2]string{{"radiusservers", "a"}, {"radiussecrets", "s"}},
+			"",
+			&radiusConf{servers: []string{"a"}, secrets: []string{"s"}}},
+		{"multiple servers",
+			[][2]string{{"radiusservers", "a, b"}, {"radiussecretfiles", "/f"},
+				{"radiusports", "1812,1645"}, {"radiusidentifiers", "crdb"}},
+			"",
+			&radiusConf{servers: []string{"a", "b"}, secretFiles: []string{"/f"},
+				ports: []int{1812, 1645}, identifiers: []string{"crdb"}}},
+		{"secret store",
+			[][2]string{{"radiusservers", "a"}, {"radiussecretfiles", "vault://vault:8200/secret/data/crdb?field=radius"}},
+			"",
+			&radiusConf{servers: []string{"a"}, secretFiles: []string{"vault://vault:8200/secret/data/crdb?field=radius"}}},
+		{"unsupported secret store",
+			[][2]string{{"radiusservers", "a"}, {"radiussecretfiles", "foo://bar"}},
+			"unsupported secret reference scheme", nil},
+		{"repeated options",
+			[][2]string{{"radiusservers", "a"}, {"radiusservers", "b"}, {"radiussecrets", "s"}},
+			"",
+			&radiusConf{servers: []string{"a", "b"}, secrets: []string{"s"}}},
+		{"mismatched lists",
+			[][2]string{{"radiusservers", "a,b,c"}, {"radiussecrets", "s,t"}},
+			"must be 1 or match", nil},
+		{"bad port",
+			[][2]string{{"radiusservers", "a"}, {"radiussecrets", "s"}, {"radiusports", "0"}},
+			"invalid radiusports", nil},
+		{"empty element",
+			[][2]string{{"radiusservers", "a,"}, {"radiussecrets", "s"}},
+			"empty list element", nil},
+		{"unknown option",
+			[][2]string{{"radiusservers", "a"}, {"radiussecrets", "s"}, {"radiustimeout", "3"}},
+			"unsupported option radiustimeout", nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			conf, err := parseRADIUSOptions(hba.Entry{Options: tc.options})
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, conf)
+		})
+	}
+}
+
+// revealPassword reverses hidePassword, as done by RADIUS servers.
+func revealPassword(hidden, reqAuth, secret []byte) string {
+	out := make([]byte, len(hidden))
+	prev := reqAuth
+	for i := 0; i < len(hidden); i += 16 {
+		h := md5.New()
+		h.Write(secret)
+		h.Write(prev)
+		b := h.Sum(nil)
+		for j := 0; j < 16; j++ {
+			out[i+j] = hidden[i+j] ^ b[j]
+		}
+		prev = hidden[i : i+16]
+	}
+	return string(bytes.TrimRight(out, "\x00"))
+}
+
+func TestHidePassword(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	reqAuth := bytes.Repeat([]byte{0x42}, radiusAuthenticatorLen)
+	secret := []byte("s3cr3t")
+	for _, pw := range []string{"a", "0123456789abcdef", "0123456789abcdefg", string(bytes.Repeat([]byte{'x'}, 128))} {
+		hidden := hidePassword(pw, reqAuth, secret)
+		require.Zero(t, len(hidden)%16)
+		require.GreaterOrEqual(t, len(hidden), len(pw))
+		require.Equal(t, pw, revealPassword(hidden, reqAuth, secret))
+	}
+}
+
+// serveFakeRADIUS answers Access-Requests received on conn until it is
+// closed. Requests are accepted if the password matches the one in
+// passwords for the user. The first drop requests are ignored.
+func serveFakeRADIUS(conn net.PacketConn, secret []byte, passwords map[string]string, drop int) {
+	buf := make([]byte, radiusMaxPacketLen)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		if drop > 0 {
+			drop--
+			continue
+		}
+		req := append([]byte(nil), buf[:n]...)
+		reqAuth := req[4:radiusHeaderLen]
+		var user, password string
+		validMAC := false
+		for attrs := req[radiusHeaderLen:]; len(attrs) >= 2; attrs = attrs[attrs[1]:] {
+			value := attrs[2:attrs[1]]
+			switch attrs[0] {
+			case radiusAttrUserName:
+				user = string(value)
+			case radiusAttrUserPassword:
+				password = revealPassword(value, reqAuth, secret)
+			case radiusAttrMessageAuthenticator:
+				received := append([]byte(nil), value...)
+				copy(value, make([]byte, md5.Size))
+				mac := hmac.New(md5.New, secret)
+				mac.Write(req)
+				validMAC = hmac.Equal(mac.Sum(nil), received)
+			}
+		}
+		if !validMAC {
+			continue
+		}
+		code := radiusAccessReject
+		if expected, ok := passwords[user]; ok && expected == password {
+			code = radiusAccessAccept
+		}
+		resp := make([]byte, radiusHeaderLen)
+		resp[0], resp[1] = code, req[1]
+		binary.BigEndian.PutUint16(resp[2:], radiusHeaderLen)
+		h := md5.New()
+		h.Write(resp[:4])
+		h.Write(reqAuth)
+		h.Write(secret)
+		copy(resp[4:], h.Sum(nil))
+		if _, err := conn.WriteTo(resp, addr); err != nil {
+			return
+		}
+	}
+}
+
+func TestRADIUSExchange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	secret := []byte("s3cr3t")
+	passwords := map[string]string{"alice": "123456"}
+	startServer := func(t *testing.T, drop int) *radiusServer {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+		go serveFakeRADIUS(conn, secret, passwords, drop)
+		return &radiusServer{host: "127.0.0.1", port: conn.LocalAddr().(*net.UDPAddr).Port, secret: secret}
+	}
+	request := func(user, password string) *radiusRequest {
+		return &radiusRequest{user: user, password: password, identifier: defaultRADIUSIdentifier}
+	}
+	const timeout = 200 * time.Millisecond
+
+	t.Run("accept and reject", func(t *testing.T) {
+		s := startServer(t, 0)
+		require.NoError(t, s.exchange(ctx, request("alice", "123456"), timeout, 0))
+		require.True(t, errors.Is(s.exchange(ctx, request("alice", "000000"), timeout, 0), errRADIUSAccessRejected))
+		require.True(t, errors.Is(s.exchange(ctx, request("bob", "123456"), timeout, 0), errRADIUSAccessRejected))
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		s := startServer(t, 0)
+		s.secret = []byte("wrong")
+		// The server discards the request, and a response signed with
+		// another secret would be discarded by the client.
+		require.True(t, errors.Is(s.exchange(ctx, request("alice", "123456"), timeout, 1), errRADIUSTimeout))
+	})
+
+	t.Run("retransmission", func(t *testing.T) {
+		s := startServer(t, 2)
+		require.True(t, errors.Is(s.exchange(ctx, request("alice", "123456"), timeout, 1), errRADIUSTimeout))
+		s = startServer(t, 2)
+		require.NoError(t, s.exchange(ctx, request("alice", "123456"), timeout, 2))
+	})
+
+	t.Run("failover", func(t *testing.T) {
+		dir := t.TempDir()
+		secretFile := filepath.Join(dir, "secret")
+		require.NoError(t, ioutil.WriteFile(secretFile, append(secret, '\n'), 0600))
+
+		unresponsive, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer unresponsive.Close()
+		s := startServer(t, 0)
+		conf := &radiusConf{
+			servers:     []string{"127.0.0.1", "127.0.0.1"},
+			ports:       []int{unresponsive.LocalAddr().(*net.UDPAddr).Port, s.port},
+			secretFiles: []string{secretFile},
+		}
+		require.NoError(t, conf.authenticateWithPolicy(ctx, "alice", "123456", timeout, 0))
+		require.True(t, errors.Is(conf.authenticateWithPolicy(ctx, "alice", "000000", timeout, 0), errRADIUSAccessRejected))
+	})
+}
@@ -0,0 +1,253 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package radiusccl
+
+// This file contains a minimal RADIUS client (RFC 2865). It only
+// supports the Access-Request exchange with the User-Password
+// attribute (PAP).
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/binary"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// RADIUS packet codes.
+const (
+	radiusAccessRequest   byte = 1
+	radiusAccessAccept    byte = 2
+	radiusAccessReject    byte = 3
+	radiusAccessChallenge byte = 11
+)
+
+// RADIUS attribute types.
+const (
+	radiusAttrUserName             byte = 1
+	radiusAttrUserPassword         byte = 2
+	radiusAttrNASIdentifier        byte = 32
+	radiusAttrMessageAuthenticator byte = 80
+)
+
+const (
+	radiusHeaderLen        = 20
+	radiusAuthenticatorLen = 16
+	// radiusMaxPacketLen is the maximum size of a RADIUS packet.
+	radiusMaxPacketLen = 4096
+	// radiusMaxAttrValueLen is the maximum length of an attribute value.
+	radiusMaxAttrValueLen = 253
+	// radiusMaxPasswordLen is the maximum length of the User-Password
+	// attribute before hiding.
+	radiusMaxPasswordLen = 128
+)
+
+// errRADIUSAccessRejected is returned when the RADIUS server rejects
+// the credentials. An Access-Challenge is treated as a rejection since
+// challenges cannot be relayed to SQL clients.
+var errRADIUSAccessRejected = errors.New("access rejected by RADIUS server")
+
+// radiusRequest is an Access-Request to be sent to a RADIUS server.
+type radiusRequest struct {
+	user       string
+	password   string
+	identifier string
+}
+
+// encode encodes the request with the given identifier and request
+// authenticator. The User-Password attribute is hidden and the packet
+// is signed with a Message-Authenticator attribute using the shared
+// secret.
+func (r *radiusRequest) encode(id byte, reqAuth []byte, secret []byte) ([]byte, error) {
+	if len(r.user) == 0 || len(r.user) > radiusMaxAttrValueLen {
+		return nil, errors.Newf("user name must be between 1 and %d bytes long", radiusMaxAttrValueLen)
+	}
+	if len(r.password) == 0 || len(r.password) > radiusMaxPasswordLen {
+		return nil, errors.Newf("password must be between 1 and %d bytes long", radiusMaxPasswordLen)
+	}
+	pkt := make([]byte, radiusHeaderLen, radiusMaxPacketLen)
+	pkt[0] = radiusAccessRequest
+	pkt[1] = id
+	copy(pkt[4:], reqAuth)
+	// The Message-Authenticator is placed first and computed last, with
+	// its value zeroed during the computation (RFC 3579, section 3.2).
+	pkt = appendAttr(pkt, radiusAttrMessageAuthenticator, make([]byte, md5.Size))
+	pkt = appendAttr(pkt, radiusAttrUserName, []byte(r.user))
+	pkt = appendAttr(pkt, radiusAttrUserPassword, hidePassword(r.password, reqAuth, secret))
+	pkt = appendAttr(pkt, radiusAttrNASIdentifier, []byte(r.identifier))
+	binary.BigEndian.PutUint16(pkt[2:], uint16(len(pkt)))
+	mac := hmac.New(md5.New, secret)
+	mac.Write(pkt)
+	copy(pkt[radiusHeaderLen+2:], mac.Sum(nil))
+	return pkt, nil
+}
+
+func appendAttr(pkt []byte, typ byte, value []byte) []byte {
+	pkt = append(pkt, typ, byte(len(value)+2))
+	return append(pkt, value...)
+}
+
+// hidePassword implements the hiding of the User-Password attribute
+// (RFC 2865, section 5.2).
+func hidePassword(password string, reqAuth []byte, secret []byte) []byte {
+	// The password is padded with zeroes to a multiple of 16 bytes.
+	n := (len(password) + 15) / 16 * 16
+	out := make([]byte, n)
+	copy(out, password)
+	prev := reqAuth
+	for i := 0; i < n; i += 16 {
+		h := md5.New()
+		h.Write(secret)
+		h.Write(prev)
+		b := h.Sum(nil)
+		for j := 0; j < 16; j++ {
+			out[i+j] ^= b[j]
+		}
+		prev = out[i : i+16]
+	}
+	return out
+}
+
+// checkResponse verifies that pkt is a well-formed response to the
+// request with the given identifier and request authenticator, signed
+// with the shared secret, and returns its code.
+func checkResponse(pkt []byte, id byte, reqAuth []byte, secret []byte) (byte, error) {
+	if len(pkt) < radiusHeaderLen {
+		return 0, errors.New("RADIUS response too short")
+	}
+	if pkt[1] != id {
+		return 0, errors.Newf("unexpected RADIUS response identifier %d", pkt[1])
+	}
+	length := int(binary.BigEndian.Uint16(pkt[2:]))
+	if length < radiusHeaderLen || length > len(pkt) {
+		return 0, errors.Newf("invalid RADIUS response length %d", length)
+	}
+	// Octets beyond the length field are padding and must be ignored.
+	pkt = pkt[:length]
+
+	// Response Authenticator = MD5(Code+ID+Length+RequestAuth+Attributes+Secret).
+	h := md5.New()
+	h.Write(pkt[:4])
+	h.Write(reqAuth)
+	h.Write(pkt[radiusHeaderLen:])
+	h.Write(secret)
+	if !hmac.Equal(h.Sum(nil), pkt[4:radiusHeaderLen]) {
+		return 0, errors.New("invalid RADIUS response authenticator")
+	}
+
+	// Verify the Message-Authenticator, if any. It is computed over the
+	// packet with the request authenticator in place of the response
+	// authenticator, and the attribute's value zeroed.
+	for attrs := pkt[radiusHeaderLen:]; len(attrs) > 0; {
+		if len(attrs) < 2 || int(attrs[1]) < 2 || int(attrs[1]) > len(attrs) {
+			return 0, errors.New("malformed RADIUS response attributes")
+		}
+		typ, attrLen := attrs[0], int(attrs[1])
+		if typ == radiusAttrMessageAuthenticator {
+			if attrLen != md5.Size+2 {
+				return 0, errors.New("malformed RADIUS Message-Authenticator")
+			}
+			offset := len(pkt) - len(attrs)
+			signed := append([]byte(nil), pkt...)
+			copy(signed[4:], reqAuth)
+			received := append([]byte(nil), signed[offset+2:offset+attrLen]...)
+			copy(signed[offset+2:offset+attrLen], make([]byte, md5.Size))
+			mac := hmac.New(md5.New, secret)
+			mac.Write(signed)
+			if !hmac.Equal(mac.Sum(nil), received) {
+				return 0, errors.New("invalid RADIUS Message-Authenticator")
+			}
+		}
+		attrs = attrs[attrLen:]
+	}
+	return pkt[0], nil
+}
+
+// radiusServer is the address and shared secret of a RADIUS server.
+type radiusServer struct {
+	host   string
+	port   int
+	secret []byte
+}
+
+// errRADIUSTimeout is returned when a RADIUS server does not respond
+// to any transmission of a request.
+var errRADIUSTimeout = errors.New("timed out waiting for RADIUS response")
+
+// exchange sends the request to the server, retransmitting it up to
+// maxRetries times if no valid response is received within timeout,
+// and returns nil if the server accepted the credentials.
+func (s *radiusServer) exchange(
+	ctx context.Context, r *radiusRequest, timeout time.Duration, maxRetries int,
+) error {
+	var idAndAuth [1 + radiusAuthenticatorLen]byte
+	if _, err := rand.Read(idAndAuth[:]); err != nil {
+		return err
+	}
+	id, reqAuth := idAndAuth[0], idAndAuth[1:]
+	pkt, err := r.encode(id, reqAuth, s.secret)
+	if err != nil {
+		return err
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", net.JoinHostPort(s.host, strconv.Itoa(s.port)))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	buf := make([]byte, radiusMaxPacketLen)
+	// Retransmissions use the same identifier and request authenticator
+	// (RFC 2865, section 2.5).
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if _, err := conn.Write(pkt); err != nil {
+			return err
+		}
+		deadline := time.Now().Add(timeout)
+		if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+			deadline = ctxDeadline
+		}
+		if err := conn.SetReadDeadline(deadline); err != nil {
+			return err
+		}
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				if netErr := (net.Error)(nil); errors.As(err, &netErr) && netErr.Timeout() {
+					break
+				}
+				return err
+			}
+			// Responses which do not match the request are silently
+			// discarded (RFC 2865, section 3).
+			code, err := checkResponse(buf[:n], id, reqAuth, s.secret)
+			if err != nil {
+				continue
+			}
+			switch code {
+			case radiusAccessAccept:
+				return nil
+			case radiusAccessReject, radiusAccessChallenge:
+				return errRADIUSAccessRejected
+			default:
+				return errors.Newf("unexpected RADIUS response code %d", code)
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return errRADIUSTimeout
+}
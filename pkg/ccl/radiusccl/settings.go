@@ -0,0 +1,45 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package radiusccl
+
+import (
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+)
+
+// All cluster settings necessary for the RADIUS authentication method.
+const (
+	baseRADIUSSettingName       = "server.radius_authentication."
+	RADIUSTimeoutSettingName    = baseRADIUSSettingName + "timeout"
+	RADIUSMaxRetriesSettingName = baseRADIUSSettingName + "max_retries"
+)
+
+// RADIUSTimeout bounds the time to wait for a response to a single
+// transmission of a request to a RADIUS server.
+var RADIUSTimeout = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	RADIUSTimeoutSettingName,
+	"maximum duration to wait for a response to a RADIUS request before "+
+		"retransmitting it or trying the next server",
+	3*time.Second,
+	settings.PositiveDuration,
+)
+
+// RADIUSMaxRetries is the number of times a request is retransmitted to
+// a RADIUS server which did not respond, before the next server is
+// tried.
+var RADIUSMaxRetries = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	RADIUSMaxRetriesSettingName,
+	"number of times a RADIUS request is retransmitted to an unresponsive "+
+		"server before trying the next server",
+	2,
+	settings.NonNegativeInt,
+)
@@ -129,8 +129,9 @@ func TestVerifyPassword(t *testing.T) {
 		t.Run(tc.testName, func(t *testing.T) {
 			execCfg := s.ExecutorConfig().(sql.ExecutorConfig)
 			username := security.MakeSQLUsernameFromPreNormalizedString(tc.username)
-			exists, canLoginSQL, canLoginDBConsole, isSuperuser, _, pwRetrieveFn, err := sql.GetUserSessionInitInfo(
-				context.Background(), &execCfg, &ie, username, "", /* databaseName */
+			info, err := sql.GetUserSessionInitInfo(
+				context.Background(), &execCfg, &ie, username, "" /* databaseName */, "", /* applicationName */
+				"", /* sourceAddress */
 			)
 
 			if err != nil {
@@ -146,16 +147,16 @@ func TestVerifyPassword(t *testing.T) {
 			validDBConsole := true
 			expired := false
 
-			if !exists || !canLoginSQL {
+			if !info.UserExists || !info.CanLoginSQL {
 				valid = false
 			}
 
-			if !exists || !canLoginDBConsole {
+			if !info.UserExists || !info.CanLoginDBConsole {
 				validDBConsole = false
 			}
-			if exists && (canLoginSQL || canLoginDBConsole) {
+			if info.UserExists && (info.CanLoginSQL || info.CanLoginDBConsole) {
 				var hashedPassword security.PasswordHash
-				expired, hashedPassword, err = pwRetrieveFn(ctx)
+				expired, hashedPassword, err = info.PasswordRetrievalFn(ctx)
 				if err != nil {
 					t.Errorf(
 						"credentials %s/%s failed with error %s, wanted no error",
@@ -195,7 +196,7 @@ func TestVerifyPassword(t *testing.T) {
 					tc.shouldAuthenticateDBConsole,
 				)
 			}
-			require.Equal(t, tc.isSuperuser, isSuperuser)
+			require.Equal(t, tc.isSuperuser, info.IsSuperuser)
 		})
 	}
 }
@@ -339,6 +339,24 @@ sql_safe_updates = FALSE.`,
 Set the session variable default_transaction_read_only to on.`,
 	}
 
+	SSO = FlagInfo{
+		Name: "sso",
+		Description: `
+Authenticate using the single sign-on (OIDC) provider configured for
+the DB Console. A web browser is opened to complete the login, and the
+resulting identity token is used instead of a password. The SQL user
+name is derived from the token, so --user is ignored. This requires
+the "jwt" authentication method to be configured for the provider in
+the cluster's host-based authentication rules.`,
+	}
+
+	SSOURL = FlagInfo{
+		Name: "sso-url",
+		Description: `
+The base URL of the DB Console used by --sso. Defaults to
+https://<host>:8080, where <host> is the SQL server address.`,
+	}
+
 	Set = FlagInfo{
 		Name: "set",
 		Description: `
@@ -803,6 +821,19 @@ fields. It is permissible for the <cert-principal> string to contain colons.
 `,
 	}
 
+	CertSource = FlagInfo{
+		Name: "cert-source",
+		Description: `
+A <filename>=<secret> mapping which loads a file of the certificates directory,
+such as ca.crt or node.key, from a secret store instead of managing it locally.
+The secret is either a local path or a URL such as
+vault://vault.example.com:8200/secret/data/crdb?field=ca (HashiCorp Vault, with
+the token in the VAULT_TOKEN environment variable). The files are written to
+the certificates directory on startup and refreshed every 5 minutes, or on
+SIGHUP. This flag can be specified multiple times.
+`,
+	}
+
 	CAKey = FlagInfo{
 		Name:        "ca-key",
 		EnvVar:      "COCKROACH_CA_KEY",
@@ -184,6 +184,13 @@ type cliContext struct {
 	// TODO(knz): Relax this when SCRAM is implemented.
 	allowUnencryptedClientPassword bool
 
+	// ssoLogin indicates that the SQL shell should authenticate using the
+	// single sign-on provider configured for the DB Console.
+	ssoLogin bool
+	// ssoConsoleURL is the base URL of the DB Console used for ssoLogin.
+	// If empty, it is derived from the SQL server address.
+	ssoConsoleURL string
+
 	// logConfigInput is the YAML input for the logging configuration.
 	logConfigInput settableString
 	// logConfig is the resulting logging configuration after the input
@@ -227,6 +234,8 @@ func setCliContextDefaults() {
 	cliCtx.sqlConnUser = security.RootUser
 	cliCtx.sqlConnDBName = ""
 	cliCtx.allowUnencryptedClientPassword = false
+	cliCtx.ssoLogin = false
+	cliCtx.ssoConsoleURL = ""
 	cliCtx.logConfigInput = settableString{s: ""}
 	cliCtx.logConfig = logconfig.Config{}
 	cliCtx.ambiguousLogDir = false
@@ -444,6 +453,7 @@ var startCtx struct {
 	serverInsecure         bool
 	serverSSLCertsDir      string
 	serverCertPrincipalMap []string
+	serverCertSources      []string
 	serverListenAddr       string
 
 	// The TLS auto-handshake parameters.
@@ -485,6 +495,7 @@ func setStartContextDefaults() {
 	startCtx.serverInsecure = baseCfg.Insecure
 	startCtx.serverSSLCertsDir = base.DefaultCertsDirectory
 	startCtx.serverCertPrincipalMap = nil
+	startCtx.serverCertSources = nil
 	startCtx.serverListenAddr = ""
 	startCtx.initToken = ""
 	startCtx.numExpectedNodes = 0
@@ -512,6 +512,9 @@ func init() {
 		// Certificate principal map.
 		stringSliceFlag(f, &startCtx.serverCertPrincipalMap, cliflags.CertPrincipalMap)
 
+		// Certificates and keys loaded from secret stores.
+		stringSliceFlag(f, &startCtx.serverCertSources, cliflags.CertSource)
+
 		// Cluster name verification.
 		varFlag(f, clusterNameSetter{&baseCfg.ClusterName}, cliflags.ClusterName)
 		boolFlag(f, &baseCfg.DisableClusterNameVerification, cliflags.DisableClusterNameVerification)
@@ -729,6 +732,13 @@ func init() {
 		boolFlag(f, &cliCtx.EmbeddedMode, cliflags.EmbeddedMode)
 	}
 
+	// Single sign-on is only supported by the SQL shell.
+	{
+		f := sqlShellCmd.Flags()
+		boolFlag(f, &cliCtx.ssoLogin, cliflags.SSO)
+		stringFlag(f, &cliCtx.ssoConsoleURL, cliflags.SSOURL)
+	}
+
 	// Commands that establish a SQL connection.
 	sqlCmds := []*cobra.Command{
 		sqlShellCmd,
@@ -1120,6 +1130,11 @@ func extraServerFlagInit(cmd *cobra.Command) error {
 	serverCfg.User = security.NodeUserName()
 	serverCfg.Insecure = startCtx.serverInsecure
 	serverCfg.SSLCertsDir = startCtx.serverSSLCertsDir
+	certSources, err := security.ParseCertSources(startCtx.serverCertSources)
+	if err != nil {
+		return err
+	}
+	serverCfg.CertSources = certSources
 
 	// Construct the main RPC listen address.
 	serverCfg.Addr = net.JoinHostPort(startCtx.serverListenAddr, serverListenPort)
@@ -16,6 +16,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/cli/clisqlclient"
 	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server/pgurl"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/util/envutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -78,6 +79,19 @@ func makeSQLClient(appName string, defaultMode defaultSQLDb) (clisqlclient.Conn,
 	// If there is no application name already, use the provided one.
 	sqlCtx.ApplicationName = catconstants.ReportableAppNamePrefix + appName
 
+	if cliCtx.ssoLogin {
+		if cliCtx.Insecure {
+			return nil, errors.New("single sign-on is not supported in insecure mode")
+		}
+		token, user, err := ssoLogin(context.Background(), ssoConsoleURL(baseURL))
+		if err != nil {
+			return nil, err
+		}
+		// The identity token is verified by the server in place of a
+		// password.
+		baseURL.WithUsername(user).WithAuthn(pgurl.AuthnPassword(true, token))
+	}
+
 	// How we're going to authenticate.
 	usePw, _, _ := baseURL.GetAuthnPassword()
 	if usePw && cliCtx.Insecure {
@@ -0,0 +1,141 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cli
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/server/pgurl"
+	"github.com/cockroachdb/errors"
+)
+
+// ssoLoginTimeout bounds the time the user has to complete the login
+// in the browser.
+const ssoLoginTimeout = 5 * time.Minute
+
+// ssoConsoleURL returns the base URL of the DB Console to use for
+// single sign-on: the value of --sso-url if specified, or the HTTP
+// port of the SQL server otherwise.
+func ssoConsoleURL(purl *pgurl.URL) string {
+	if cliCtx.ssoConsoleURL != "" {
+		return strings.TrimSuffix(cliCtx.ssoConsoleURL, "/")
+	}
+	host := "localhost"
+	if _, h, _ := purl.GetNetworking(); h != "" {
+		host = h
+	}
+	return "https://" + net.JoinHostPort(host, base.DefaultHTTPPort)
+}
+
+// ssoLoginResult is the outcome of a login handed over by the browser.
+type ssoLoginResult struct {
+	token, user string
+}
+
+// ssoLogin authenticates the user with the OIDC provider configured
+// for the DB Console at consoleURL, using a web browser. It returns
+// the resulting ID token, to be used in place of a password, and the
+// SQL username it was issued for.
+//
+// The login is handed over from the browser to the SQL shell by a form
+// posted to a temporary HTTP server listening on the loopback
+// interface. The form must include the nonce passed to the DB Console
+// when the login was initiated, so that other local processes cannot
+// inject credentials.
+func ssoLogin(ctx context.Context, consoleURL string) (token, user string, err error) {
+	nonceBytes := make([]byte, 16)
+	if _, err := rand.Read(nonceBytes); err != nil {
+		return "", "", err
+	}
+	nonce := hex.EncodeToString(nonceBytes)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", "", errors.Wrap(err, "listening for the single sign-on response")
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+
+	resultCh := make(chan ssoLoginResult, 1)
+	srv := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			if err := r.ParseForm(); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if subtle.ConstantTimeCompare([]byte(r.PostForm.Get("nonce")), []byte(nonce)) != 1 {
+				http.Error(w, "invalid nonce", http.StatusForbidden)
+				return
+			}
+			res := ssoLoginResult{token: r.PostForm.Get("id_token"), user: r.PostForm.Get("user")}
+			if res.token == "" || res.user == "" {
+				http.Error(w, "incomplete login response", http.StatusBadRequest)
+				return
+			}
+			select {
+			case resultCh <- res:
+			default:
+			}
+			fmt.Fprintln(w, "Login successful. You can close this window and return to the SQL shell.")
+		}),
+	}
+	go func() { _ = srv.Serve(ln) }()
+	defer func() { _ = srv.Close() }()
+
+	loginURL := consoleURL + "/oidc/v1/login?" + url.Values{
+		"cli_port":  {strconv.Itoa(port)},
+		"cli_nonce": {nonce},
+	}.Encode()
+	fmt.Fprintf(stderr, "#\n# Complete the single sign-on login in your browser. If the browser\n"+
+		"# does not open automatically, visit the following URL:\n#\n#   %s\n#\n", loginURL)
+	if err := openBrowser(loginURL); err != nil {
+		fmt.Fprintf(stderr, "# (unable to open a browser: %v)\n", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ssoLoginTimeout)
+	defer cancel()
+	select {
+	case res := <-resultCh:
+		return res.token, res.user, nil
+	case <-ctx.Done():
+		return "", "", errors.Wrap(ctx.Err(), "waiting for the single sign-on login")
+	}
+}
+
+// openBrowser opens the given URL in the user's web browser.
+func openBrowser(u string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", u)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", u)
+	default:
+		cmd = exec.Command("xdg-open", u)
+	}
+	return cmd.Start()
+}
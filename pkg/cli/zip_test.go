@@ -68,15 +68,18 @@ table_name NOT IN (
 	-- allowlisted tables that don't need to be in debug zip
 	'backward_dependencies',
 	'builtin_functions',
+	'cluster_auth_attempts',
 	'cluster_contended_keys',
 	'cluster_contended_indexes',
 	'cluster_contended_tables',
 	'cluster_inflight_traces',
 	'cross_db_references',
 	'databases',
+	'effective_session_settings',
 	'forward_dependencies',
 	'index_columns',
 	'lost_descriptors_with_data',
+	'node_failed_login_attempts',
 	'table_columns',
 	'table_row_statistics',
 	'ranges',
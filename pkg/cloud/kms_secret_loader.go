@@ -0,0 +1,82 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cloud
+
+import (
+	"context"
+	"io/ioutil"
+	"net/url"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	security.RegisterSecretLoader("kms", security.SecretLoaderFunc(loadKMSSecret))
+}
+
+// loadKMSSecret loads a secret encrypted with a KMS key, using URLs of
+// the form:
+//
+//   kms:///<path>?key=<KMS URI>
+//
+// where <path> is a local file containing the ciphertext and <KMS URI>
+// is the URL-encoded URI of the key, in the format used by the
+// encryption options of BACKUP, e.g.
+//
+//   kms:///etc/crdb/ldap-password.enc?key=aws%3A%2F%2F<key id>%3FAUTH%3Dimplicit%26REGION%3Dus-east-1
+//
+// Secrets are loaded before the cluster settings are available, so the
+// KMS is accessed with the default settings and without restrictions
+// on outbound connections or implicit credentials: the secret
+// references are part of the node configuration.
+func loadKMSSecret(ctx context.Context, u *url.URL) ([]byte, error) {
+	keyURI := u.Query().Get("key")
+	if keyURI == "" {
+		return nil, errors.New("invalid kms secret reference: missing key parameter")
+	}
+	if u.Host != "" || u.Path == "" {
+		return nil, errors.New("invalid kms secret reference: expected kms:///<path>?key=<KMS URI>")
+	}
+	ciphertext, err := ioutil.ReadFile(u.Path)
+	if err != nil {
+		return nil, err
+	}
+	kms, err := KMSFromURI(keyURI, &secretKMSEnv{settings: cluster.MakeClusterSettings()})
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = kms.Close() }()
+	plaintext, err := kms.Decrypt(ctx, ciphertext)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decrypting %s", u.Path)
+	}
+	return plaintext, nil
+}
+
+// secretKMSEnv is the KMSEnv used to decrypt secrets.
+type secretKMSEnv struct {
+	settings *cluster.Settings
+}
+
+var _ KMSEnv = &secretKMSEnv{}
+
+// ClusterSettings implements the KMSEnv interface.
+func (e *secretKMSEnv) ClusterSettings() *cluster.Settings {
+	return e.settings
+}
+
+// KMSConfig implements the KMSEnv interface.
+func (e *secretKMSEnv) KMSConfig() *base.ExternalIODirConfig {
+	return &base.ExternalIODirConfig{}
+}
@@ -322,6 +322,37 @@ const (
 	// when DateStyle/IntervalStyle is enabled.
 	DateStyleIntervalStyleCastRewrite
 
+	// RoleTOTPTable adds the system table holding the TOTP secrets of the
+	// users enrolled for multi-factor authentication.
+	RoleTOTPTable
+
+	// WebAuthnCredentialsTable adds the system table holding the security
+	// keys registered by the users to log in to the DB Console.
+	WebAuthnCredentialsTable
+
+	// RoleLastLoginTable adds the system table holding the time of the last
+	// successful login of each role.
+	RoleLastLoginTable
+
+	// RoleProfilesTable adds the system table holding the role profiles,
+	// which bundle role options shared by several roles.
+	RoleProfilesTable
+
+	// DatabaseRoleSettingsApplicationName adds the application_name column
+	// to system.database_role_settings, so that the default settings of the
+	// roles can be scoped to the applications of the sessions.
+	DatabaseRoleSettingsApplicationName
+
+	// DatabaseRoleSettingsSourceNetwork adds the source_network column to
+	// system.database_role_settings, so that the default settings of the
+	// roles can be scoped to the networks the sessions come from.
+	DatabaseRoleSettingsSourceNetwork
+
+	// DatabaseRoleSettingsLocked adds the locked_settings column to
+	// system.database_role_settings, so that the default settings of the
+	// roles can be locked against changes by the sessions.
+	DatabaseRoleSettingsLocked
+
 	// *************************************************
 	// Step (1): Add new versions here.
 	// Do not add new versions to a patch release.
@@ -536,6 +567,34 @@ var versionsSingleton = keyedVersions{
 		Key:     DateStyleIntervalStyleCastRewrite,
 		Version: roachpb.Version{Major: 22, Minor: 1, Internal: 94},
 	},
+	{
+		Key:     RoleTOTPTable,
+		Version: roachpb.Version{Major: 22, Minor: 1, Internal: 96},
+	},
+	{
+		Key:     WebAuthnCredentialsTable,
+		Version: roachpb.Version{Major: 22, Minor: 1, Internal: 98},
+	},
+	{
+		Key:     RoleLastLoginTable,
+		Version: roachpb.Version{Major: 22, Minor: 1, Internal: 100},
+	},
+	{
+		Key:     RoleProfilesTable,
+		Version: roachpb.Version{Major: 22, Minor: 1, Internal: 102},
+	},
+	{
+		Key:     DatabaseRoleSettingsApplicationName,
+		Version: roachpb.Version{Major: 22, Minor: 1, Internal: 104},
+	},
+	{
+		Key:     DatabaseRoleSettingsSourceNetwork,
+		Version: roachpb.Version{Major: 22, Minor: 1, Internal: 106},
+	},
+	{
+		Key:     DatabaseRoleSettingsLocked,
+		Version: roachpb.Version{Major: 22, Minor: 1, Internal: 108},
+	},
 
 	// *************************************************
 	// Step (2): Add new versions here.
@@ -378,6 +378,11 @@ var specs = []stmtSpec{
 		stmt:   "alter_database_primary_region_stmt",
 		inline: []string{"primary_region_clause", "opt_equal"},
 	},
+	{
+		name:   "alter_database_set_secondary_region",
+		stmt:   "alter_database_set_secondary_region_stmt",
+		inline: []string{"secondary_region_clause", "opt_equal"},
+	},
 	{
 		name: "alter_database_drop_region",
 		stmt: "alter_database_drop_region_stmt",
@@ -591,7 +596,7 @@ var specs = []stmtSpec{
 	},
 	{
 		name:   "create_database_stmt",
-		inline: []string{"opt_with", "opt_encoding_clause", "opt_connection_limit", "opt_equal", "opt_primary_region_clause", "primary_region_clause", "opt_regions_list", "region_or_regions", "opt_survival_goal_clause", "survival_goal_clause", "opt_equal"},
+		inline: []string{"opt_with", "opt_encoding_clause", "opt_connection_limit", "opt_equal", "opt_primary_region_clause", "primary_region_clause", "opt_regions_list", "region_or_regions", "opt_survival_goal_clause", "survival_goal_clause", "opt_secondary_region_clause", "secondary_region_clause", "opt_equal"},
 		replace: map[string]string{
 			"non_reserved_word_or_sconst": "encoding",
 			"signed_iconst":               "limit"},
@@ -21,10 +21,18 @@ DOCS_SRCS = [
   "//docs/generated/sql/bnf:alter_column.bnf",
   "//docs/generated/sql/bnf:alter_database_add_region_stmt.bnf",
   "//docs/generated/sql/bnf:alter_database_add_super_region.bnf",
+  "//docs/generated/sql/bnf:alter_database_alter_super_region.bnf",
+  "//docs/generated/sql/bnf:alter_database_convert_to_multiregion_stmt.bnf",
+  "//docs/generated/sql/bnf:alter_database_default_locality_stmt.bnf",
   "//docs/generated/sql/bnf:alter_database_drop_region.bnf",
+  "//docs/generated/sql/bnf:alter_database_drop_secondary_region.bnf",
   "//docs/generated/sql/bnf:alter_database_drop_super_region.bnf",
   "//docs/generated/sql/bnf:alter_database_owner.bnf",
   "//docs/generated/sql/bnf:alter_database_primary_region.bnf",
+  "//docs/generated/sql/bnf:alter_database_region_replicas_stmt.bnf",
+  "//docs/generated/sql/bnf:alter_database_rename_region.bnf",
+  "//docs/generated/sql/bnf:alter_database_reset_primary_region_stmt.bnf",
+  "//docs/generated/sql/bnf:alter_database_set_secondary_region.bnf",
   "//docs/generated/sql/bnf:alter_database_stmt.bnf",
   "//docs/generated/sql/bnf:alter_database_survival_goal_stmt.bnf",
   "//docs/generated/sql/bnf:alter_database_to_schema_stmt.bnf",
@@ -17,6 +17,8 @@ MISC_SRCS = [
   "//pkg/sql/schemachanger/scop:validation_visitor_generated.go",
   "//pkg/sql/schemachanger/scpb:elements_generated.go",
   "//pkg/sql/schemachanger/scpb:uml/table.puml",
+  "//pkg/sql/sem/tree:json_ast_types_generated.go",
+  "//pkg/sql/sem/tree:stmt_visitor_generated.go",
   "//pkg/sql:txnstatetransitions_diagram.gv",
   "//pkg/sql:txnstatetransitions_report.txt",
   "//pkg/util/interval/generic:example_interval_btree.go",
@@ -440,8 +440,8 @@ func makeAlterDatabaseAddRegion(s *Smither) (tree.Statement, bool) {
 	}
 
 	ast := &tree.AlterDatabaseAddRegion{
-		Region: tree.Name(regions[rand.Intn(len(regions))]),
-		Name:   tree.Name("defaultdb"),
+		Regions: tree.NameList{tree.Name(regions[rand.Intn(len(regions))])},
+		Name:    tree.Name("defaultdb"),
 	}
 
 	return ast, true
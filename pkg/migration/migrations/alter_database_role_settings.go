@@ -0,0 +1,123 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/migration"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+)
+
+// Target schema changes in the system.database_role_settings table, adding
+// the application_name column and making it part of the primary key, so
+// that a role can have several rows of settings in the same database.
+const (
+	addApplicationNameToDatabaseRoleSettings = `
+ALTER TABLE system.database_role_settings
+  ADD COLUMN IF NOT EXISTS application_name STRING NOT NULL DEFAULT ''`
+
+	alterDatabaseRoleSettingsPrimaryKey = `
+ALTER TABLE system.database_role_settings
+  DROP CONSTRAINT "primary",
+  ADD CONSTRAINT "primary" PRIMARY KEY (database_id, role_name, application_name)`
+)
+
+// Target schema changes in the system.database_role_settings table, adding
+// the source_network column and making it part of the primary key.
+const (
+	addSourceNetworkToDatabaseRoleSettings = `
+ALTER TABLE system.database_role_settings
+  ADD COLUMN IF NOT EXISTS source_network STRING NOT NULL DEFAULT ''`
+
+	alterDatabaseRoleSettingsPrimaryKeyWithSourceNetwork = `
+ALTER TABLE system.database_role_settings
+  DROP CONSTRAINT "primary",
+  ADD CONSTRAINT "primary" PRIMARY KEY (database_id, role_name, application_name, source_network)`
+)
+
+// Target schema change in the system.database_role_settings table, adding
+// the locked_settings column.
+const addLockedSettingsToDatabaseRoleSettings = `
+ALTER TABLE system.database_role_settings
+  ADD COLUMN IF NOT EXISTS locked_settings STRING[] FAMILY "primary"`
+
+// alterSystemDatabaseRoleSettingsAddApplicationName changes the schema of the
+// system.database_role_settings table so that the default settings of the
+// roles can be scoped to the applications of the sessions.
+func alterSystemDatabaseRoleSettingsAddApplicationName(
+	ctx context.Context, cs clusterversion.ClusterVersion, d migration.TenantDeps, _ *jobs.Job,
+) error {
+	for _, op := range []operation{
+		{
+			name:           "add-database-role-settings-application-name-col",
+			schemaList:     []string{"application_name"},
+			query:          addApplicationNameToDatabaseRoleSettings,
+			schemaExistsFn: hasColumn,
+		},
+		{
+			name:           "alter-database-role-settings-primary-key",
+			schemaList:     []string{"application_name"},
+			query:          alterDatabaseRoleSettingsPrimaryKey,
+			schemaExistsFn: hasPrimaryKeyColumn,
+		},
+	} {
+		if err := migrateTable(ctx, cs, d, op, keys.DatabaseRoleSettingsTableID, systemschema.DatabaseRoleSettingsTable); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// alterSystemDatabaseRoleSettingsAddSourceNetwork changes the schema of the
+// system.database_role_settings table so that the default settings of the
+// roles can be scoped to the networks the sessions come from.
+func alterSystemDatabaseRoleSettingsAddSourceNetwork(
+	ctx context.Context, cs clusterversion.ClusterVersion, d migration.TenantDeps, _ *jobs.Job,
+) error {
+	for _, op := range []operation{
+		{
+			name:           "add-database-role-settings-source-network-col",
+			schemaList:     []string{"source_network"},
+			query:          addSourceNetworkToDatabaseRoleSettings,
+			schemaExistsFn: hasColumn,
+		},
+		{
+			name:           "alter-database-role-settings-primary-key-source-network",
+			schemaList:     []string{"source_network"},
+			query:          alterDatabaseRoleSettingsPrimaryKeyWithSourceNetwork,
+			schemaExistsFn: hasPrimaryKeyColumn,
+		},
+	} {
+		if err := migrateTable(ctx, cs, d, op, keys.DatabaseRoleSettingsTableID, systemschema.DatabaseRoleSettingsTable); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// alterSystemDatabaseRoleSettingsAddLockedSettings changes the schema of the
+// system.database_role_settings table so that the default settings of the
+// roles can be locked against changes by the sessions.
+func alterSystemDatabaseRoleSettingsAddLockedSettings(
+	ctx context.Context, cs clusterversion.ClusterVersion, d migration.TenantDeps, _ *jobs.Job,
+) error {
+	op := operation{
+		name:           "add-database-role-settings-locked-settings-col",
+		schemaList:     []string{"locked_settings"},
+		query:          addLockedSettingsToDatabaseRoleSettings,
+		schemaExistsFn: hasColumn,
+	}
+	return migrateTable(ctx, cs, d, op, keys.DatabaseRoleSettingsTableID, systemschema.DatabaseRoleSettingsTable)
+}
@@ -0,0 +1,416 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package migrations_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/migration/migrations"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/testcluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+func TestAlterSystemDatabaseRoleSettingsTable(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	clusterArgs := base.TestClusterArgs{
+		ServerArgs: base.TestServerArgs{
+			Knobs: base.TestingKnobs{
+				Server: &server.TestingKnobs{
+					DisableAutomaticVersionUpgrade: make(chan struct{}),
+					BinaryVersionOverride: clusterversion.ByKey(
+						clusterversion.DatabaseRoleSettingsApplicationName - 1),
+				},
+			},
+		},
+	}
+
+	var (
+		ctx = context.Background()
+
+		tc    = testcluster.StartTestCluster(t, 1, clusterArgs)
+		s     = tc.Server(0)
+		sqlDB = tc.ServerConn(0)
+	)
+	defer tc.Stopper().Stop(ctx)
+
+	var (
+		validationSchemas = []migrations.Schema{
+			{Name: "application_name", ValidationFn: migrations.HasColumn},
+			{Name: "application_name", ValidationFn: migrations.HasPrimaryKeyColumn},
+		}
+	)
+
+	// Inject the old copy of the descriptor.
+	migrations.InjectLegacyTable(ctx, t, s, systemschema.DatabaseRoleSettingsTable, getDeprecatedDatabaseRoleSettingsDescriptor)
+	// Validate that the table has the old schema.
+	migrations.ValidateSchemaExists(
+		ctx,
+		t,
+		s,
+		sqlDB,
+		keys.DatabaseRoleSettingsTableID,
+		systemschema.DatabaseRoleSettingsTable,
+		[]string{},
+		validationSchemas,
+		false, /* expectExists */
+	)
+
+	tdb := sqlutils.MakeSQLRunner(sqlDB)
+	tdb.Exec(t, `CREATE USER testuser`)
+	tdb.Exec(t, `ALTER ROLE testuser SET application_name = 'foo'`)
+
+	// Run the migration.
+	migrations.Migrate(
+		t,
+		sqlDB,
+		clusterversion.DatabaseRoleSettingsApplicationName,
+		nil,   /* done */
+		false, /* expectError */
+	)
+	// Validate that the table has the new schema.
+	migrations.ValidateSchemaExists(
+		ctx,
+		t,
+		s,
+		sqlDB,
+		keys.DatabaseRoleSettingsTableID,
+		systemschema.DatabaseRoleSettingsTable,
+		[]string{},
+		validationSchemas,
+		true, /* expectExists */
+	)
+
+	// The existing settings apply to all the applications, and the role can
+	// now have several rows of settings in the same database.
+	tdb.Exec(t, `ALTER ROLE testuser FOR APPLICATION 'batch-%' SET application_name = 'bar'`)
+	tdb.CheckQueryResults(t,
+		`SELECT role_name, application_name, settings FROM system.database_role_settings ORDER BY 1, 2`,
+		[][]string{
+			{"testuser", "", "{application_name=foo}"},
+			{"testuser", "batch-%", "{application_name=bar}"},
+		})
+}
+
+func TestAlterSystemDatabaseRoleSettingsTableSourceNetwork(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	clusterArgs := base.TestClusterArgs{
+		ServerArgs: base.TestServerArgs{
+			Knobs: base.TestingKnobs{
+				Server: &server.TestingKnobs{
+					DisableAutomaticVersionUpgrade: make(chan struct{}),
+					BinaryVersionOverride: clusterversion.ByKey(
+						clusterversion.DatabaseRoleSettingsSourceNetwork - 1),
+				},
+			},
+		},
+	}
+
+	var (
+		ctx = context.Background()
+
+		tc    = testcluster.StartTestCluster(t, 1, clusterArgs)
+		s     = tc.Server(0)
+		sqlDB = tc.ServerConn(0)
+	)
+	defer tc.Stopper().Stop(ctx)
+
+	var (
+		validationSchemas = []migrations.Schema{
+			{Name: "source_network", ValidationFn: migrations.HasColumn},
+			{Name: "source_network", ValidationFn: migrations.HasPrimaryKeyColumn},
+		}
+	)
+
+	// Inject the old copy of the descriptor.
+	migrations.InjectLegacyTable(ctx, t, s, systemschema.DatabaseRoleSettingsTable,
+		getDeprecatedDatabaseRoleSettingsDescriptorWithApplicationName)
+	// Validate that the table has the old schema.
+	migrations.ValidateSchemaExists(
+		ctx,
+		t,
+		s,
+		sqlDB,
+		keys.DatabaseRoleSettingsTableID,
+		systemschema.DatabaseRoleSettingsTable,
+		[]string{},
+		validationSchemas,
+		false, /* expectExists */
+	)
+
+	tdb := sqlutils.MakeSQLRunner(sqlDB)
+	tdb.Exec(t, `CREATE USER testuser`)
+	tdb.Exec(t, `ALTER ROLE testuser FOR APPLICATION 'batch-%' SET application_name = 'foo'`)
+	tdb.ExpectErr(t, "must be finalized to use FROM NETWORK",
+		`ALTER ROLE testuser FROM NETWORK '10.0.0.0/8' SET application_name = 'bar'`)
+
+	// Run the migration.
+	migrations.Migrate(
+		t,
+		sqlDB,
+		clusterversion.DatabaseRoleSettingsSourceNetwork,
+		nil,   /* done */
+		false, /* expectError */
+	)
+	// Validate that the table has the new schema.
+	migrations.ValidateSchemaExists(
+		ctx,
+		t,
+		s,
+		sqlDB,
+		keys.DatabaseRoleSettingsTableID,
+		systemschema.DatabaseRoleSettingsTable,
+		[]string{},
+		validationSchemas,
+		true, /* expectExists */
+	)
+
+	// The existing settings apply to all the networks.
+	tdb.Exec(t, `ALTER ROLE testuser FROM NETWORK '10.0.0.0/8' SET application_name = 'bar'`)
+	tdb.CheckQueryResults(t,
+		`SELECT role_name, application_name, source_network, settings
+FROM system.database_role_settings ORDER BY 1, 2, 3`,
+		[][]string{
+			{"testuser", "", "10.0.0.0/8", "{application_name=bar}"},
+			{"testuser", "batch-%", "", "{application_name=foo}"},
+		})
+}
+
+func TestAlterSystemDatabaseRoleSettingsTableLockedSettings(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	clusterArgs := base.TestClusterArgs{
+		ServerArgs: base.TestServerArgs{
+			Knobs: base.TestingKnobs{
+				Server: &server.TestingKnobs{
+					DisableAutomaticVersionUpgrade: make(chan struct{}),
+					BinaryVersionOverride: clusterversion.ByKey(
+						clusterversion.DatabaseRoleSettingsLocked - 1),
+				},
+			},
+		},
+	}
+
+	var (
+		ctx = context.Background()
+
+		tc    = testcluster.StartTestCluster(t, 1, clusterArgs)
+		s     = tc.Server(0)
+		sqlDB = tc.ServerConn(0)
+	)
+	defer tc.Stopper().Stop(ctx)
+
+	var (
+		validationSchemas = []migrations.Schema{
+			{Name: "locked_settings", ValidationFn: migrations.HasColumn},
+		}
+	)
+
+	// Inject the old copy of the descriptor.
+	migrations.InjectLegacyTable(ctx, t, s, systemschema.DatabaseRoleSettingsTable,
+		getDeprecatedDatabaseRoleSettingsDescriptorWithSourceNetwork)
+	// Validate that the table has the old schema.
+	migrations.ValidateSchemaExists(
+		ctx,
+		t,
+		s,
+		sqlDB,
+		keys.DatabaseRoleSettingsTableID,
+		systemschema.DatabaseRoleSettingsTable,
+		[]string{},
+		validationSchemas,
+		false, /* expectExists */
+	)
+
+	tdb := sqlutils.MakeSQLRunner(sqlDB)
+	tdb.Exec(t, `CREATE USER testuser`)
+	tdb.Exec(t, `ALTER ROLE testuser SET application_name = 'foo'`)
+	tdb.ExpectErr(t, "must be finalized to use LOCKED",
+		`ALTER ROLE testuser SET statement_timeout = '10s' LOCKED`)
+
+	// Run the migration.
+	migrations.Migrate(
+		t,
+		sqlDB,
+		clusterversion.DatabaseRoleSettingsLocked,
+		nil,   /* done */
+		false, /* expectError */
+	)
+	// Validate that the table has the new schema.
+	migrations.ValidateSchemaExists(
+		ctx,
+		t,
+		s,
+		sqlDB,
+		keys.DatabaseRoleSettingsTableID,
+		systemschema.DatabaseRoleSettingsTable,
+		[]string{},
+		validationSchemas,
+		true, /* expectExists */
+	)
+
+	// The existing settings are not locked.
+	tdb.Exec(t, `ALTER ROLE testuser SET statement_timeout = '10s' LOCKED`)
+	tdb.CheckQueryResults(t,
+		`SELECT role_name, settings, locked_settings FROM system.database_role_settings`,
+		[][]string{
+			{"testuser", "{application_name=foo,statement_timeout=10s}", "{statement_timeout}"},
+		})
+}
+
+// getDeprecatedDatabaseRoleSettingsDescriptor returns the
+// system.database_role_settings table descriptor that was being used before
+// adding the application_name column in the current version.
+func getDeprecatedDatabaseRoleSettingsDescriptor() *descpb.TableDescriptor {
+	return &descpb.TableDescriptor{
+		Name:                    "database_role_settings",
+		ID:                      keys.DatabaseRoleSettingsTableID,
+		ParentID:                keys.SystemDatabaseID,
+		UnexposedParentSchemaID: keys.PublicSchemaID,
+		Version:                 1,
+		Columns: []descpb.ColumnDescriptor{
+			{Name: "database_id", ID: 1, Type: types.Oid},
+			{Name: "role_name", ID: 2, Type: types.String},
+			{Name: "settings", ID: 3, Type: types.StringArray},
+		},
+		NextColumnID: 4,
+		Families: []descpb.ColumnFamilyDescriptor{
+			{
+				Name:            "primary",
+				ID:              0,
+				ColumnNames:     []string{"database_id", "role_name", "settings"},
+				ColumnIDs:       []descpb.ColumnID{1, 2, 3},
+				DefaultColumnID: 3,
+			},
+		},
+		NextFamilyID: 1,
+		PrimaryIndex: descpb.IndexDescriptor{
+			Name:                "primary",
+			ID:                  1,
+			Unique:              true,
+			KeyColumnNames:      []string{"database_id", "role_name"},
+			KeyColumnDirections: []descpb.IndexDescriptor_Direction{descpb.IndexDescriptor_ASC, descpb.IndexDescriptor_ASC},
+			KeyColumnIDs:        []descpb.ColumnID{1, 2},
+		},
+		NextIndexID:    2,
+		Privileges:     catpb.NewCustomSuperuserPrivilegeDescriptor(privilege.ReadWriteData, security.NodeUserName()),
+		NextMutationID: 1,
+		FormatVersion:  3,
+	}
+}
+
+// getDeprecatedDatabaseRoleSettingsDescriptorWithApplicationName returns the
+// system.database_role_settings table descriptor that was being used before
+// adding the source_network column in the current version.
+func getDeprecatedDatabaseRoleSettingsDescriptorWithApplicationName() *descpb.TableDescriptor {
+	emptyString := "'':::STRING"
+	return &descpb.TableDescriptor{
+		Name:                    "database_role_settings",
+		ID:                      keys.DatabaseRoleSettingsTableID,
+		ParentID:                keys.SystemDatabaseID,
+		UnexposedParentSchemaID: keys.PublicSchemaID,
+		Version:                 1,
+		Columns: []descpb.ColumnDescriptor{
+			{Name: "database_id", ID: 1, Type: types.Oid},
+			{Name: "role_name", ID: 2, Type: types.String},
+			{Name: "settings", ID: 3, Type: types.StringArray},
+			{Name: "application_name", ID: 4, Type: types.String, DefaultExpr: &emptyString},
+		},
+		NextColumnID: 5,
+		Families: []descpb.ColumnFamilyDescriptor{
+			{
+				Name:            "primary",
+				ID:              0,
+				ColumnNames:     []string{"database_id", "role_name", "settings", "application_name"},
+				ColumnIDs:       []descpb.ColumnID{1, 2, 3, 4},
+				DefaultColumnID: 3,
+			},
+		},
+		NextFamilyID: 1,
+		PrimaryIndex: descpb.IndexDescriptor{
+			Name:           "primary",
+			ID:             1,
+			Unique:         true,
+			KeyColumnNames: []string{"database_id", "role_name", "application_name"},
+			KeyColumnDirections: []descpb.IndexDescriptor_Direction{
+				descpb.IndexDescriptor_ASC, descpb.IndexDescriptor_ASC, descpb.IndexDescriptor_ASC,
+			},
+			KeyColumnIDs: []descpb.ColumnID{1, 2, 4},
+		},
+		NextIndexID:    2,
+		Privileges:     catpb.NewCustomSuperuserPrivilegeDescriptor(privilege.ReadWriteData, security.NodeUserName()),
+		NextMutationID: 1,
+		FormatVersion:  3,
+	}
+}
+
+// getDeprecatedDatabaseRoleSettingsDescriptorWithSourceNetwork returns the
+// system.database_role_settings table descriptor that was being used before
+// adding the locked_settings column in the current version.
+func getDeprecatedDatabaseRoleSettingsDescriptorWithSourceNetwork() *descpb.TableDescriptor {
+	emptyString := "'':::STRING"
+	return &descpb.TableDescriptor{
+		Name:                    "database_role_settings",
+		ID:                      keys.DatabaseRoleSettingsTableID,
+		ParentID:                keys.SystemDatabaseID,
+		UnexposedParentSchemaID: keys.PublicSchemaID,
+		Version:                 1,
+		Columns: []descpb.ColumnDescriptor{
+			{Name: "database_id", ID: 1, Type: types.Oid},
+			{Name: "role_name", ID: 2, Type: types.String},
+			{Name: "settings", ID: 3, Type: types.StringArray},
+			{Name: "application_name", ID: 4, Type: types.String, DefaultExpr: &emptyString},
+			{Name: "source_network", ID: 5, Type: types.String, DefaultExpr: &emptyString},
+		},
+		NextColumnID: 6,
+		Families: []descpb.ColumnFamilyDescriptor{
+			{
+				Name:            "primary",
+				ID:              0,
+				ColumnNames:     []string{"database_id", "role_name", "settings", "application_name", "source_network"},
+				ColumnIDs:       []descpb.ColumnID{1, 2, 3, 4, 5},
+				DefaultColumnID: 3,
+			},
+		},
+		NextFamilyID: 1,
+		PrimaryIndex: descpb.IndexDescriptor{
+			Name:           "primary",
+			ID:             1,
+			Unique:         true,
+			KeyColumnNames: []string{"database_id", "role_name", "application_name", "source_network"},
+			KeyColumnDirections: []descpb.IndexDescriptor_Direction{
+				descpb.IndexDescriptor_ASC, descpb.IndexDescriptor_ASC, descpb.IndexDescriptor_ASC, descpb.IndexDescriptor_ASC,
+			},
+			KeyColumnIDs: []descpb.ColumnID{1, 2, 4, 5},
+		},
+		NextIndexID:    2,
+		Privileges:     catpb.NewCustomSuperuserPrivilegeDescriptor(privilege.ReadWriteData, security.NodeUserName()),
+		NextMutationID: 1,
+		FormatVersion:  3,
+	}
+}
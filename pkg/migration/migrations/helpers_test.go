@@ -29,9 +29,10 @@ import (
 )
 
 var (
-	HasColumn         = hasColumn
-	HasIndex          = hasIndex
-	CreateSystemTable = createSystemTable
+	HasColumn           = hasColumn
+	HasIndex            = hasIndex
+	HasPrimaryKeyColumn = hasPrimaryKeyColumn
+	CreateSystemTable   = createSystemTable
 )
 
 type Schema struct {
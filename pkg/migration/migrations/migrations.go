@@ -131,6 +131,48 @@ var migrations = []migration.Migration{
 		NoPrecondition,
 		fixCastForStyleMigration,
 	),
+	migration.NewTenantMigration(
+		"add the system.role_totp table",
+		toCV(clusterversion.RoleTOTPTable),
+		NoPrecondition,
+		roleTOTPTableMigration,
+	),
+	migration.NewTenantMigration(
+		"add the system.webauthn_credentials table",
+		toCV(clusterversion.WebAuthnCredentialsTable),
+		NoPrecondition,
+		webAuthnCredentialsTableMigration,
+	),
+	migration.NewTenantMigration(
+		"add the system.role_last_login table",
+		toCV(clusterversion.RoleLastLoginTable),
+		NoPrecondition,
+		roleLastLoginTableMigration,
+	),
+	migration.NewTenantMigration(
+		"add the system.role_profiles table",
+		toCV(clusterversion.RoleProfilesTable),
+		NoPrecondition,
+		roleProfilesTableMigration,
+	),
+	migration.NewTenantMigration(
+		"add column application_name to table system.database_role_settings",
+		toCV(clusterversion.DatabaseRoleSettingsApplicationName),
+		NoPrecondition,
+		alterSystemDatabaseRoleSettingsAddApplicationName,
+	),
+	migration.NewTenantMigration(
+		"add column source_network to table system.database_role_settings",
+		toCV(clusterversion.DatabaseRoleSettingsSourceNetwork),
+		NoPrecondition,
+		alterSystemDatabaseRoleSettingsAddSourceNetwork,
+	),
+	migration.NewTenantMigration(
+		"add column locked_settings to table system.database_role_settings",
+		toCV(clusterversion.DatabaseRoleSettingsLocked),
+		NoPrecondition,
+		alterSystemDatabaseRoleSettingsAddLockedSettings,
+	),
 }
 
 func init() {
@@ -248,3 +248,25 @@ func hasIndex(storedTable, expectedTable catalog.TableDescriptor, indexName stri
 	}
 	return true, nil
 }
+
+// hasPrimaryKeyColumn returns true if the given column is a key column of the
+// primary index of storedTable. Unlike hasIndex, it does not compare the rest
+// of the primary index with the one of expectedTable, since the primary index
+// which results from a change of primary key differs from the one which is
+// created with the table, for instance by its ID.
+func hasPrimaryKeyColumn(
+	storedTable, expectedTable catalog.TableDescriptor, colName string,
+) (bool, error) {
+	isKeyColumn := func(idx catalog.Index) bool {
+		for i := 0; i < idx.NumKeyColumns(); i++ {
+			if idx.GetKeyColumnName(i) == colName {
+				return true
+			}
+		}
+		return false
+	}
+	if !isKeyColumn(expectedTable.GetPrimaryIndex()) {
+		return false, errors.Errorf("column %s is not a primary key column", colName)
+	}
+	return isKeyColumn(storedTable.GetPrimaryIndex()), nil
+}
@@ -93,6 +93,9 @@ func (ctx *SecurityContext) GetCertificateManager() (*security.CertificateManage
 		if ctx.tenID != roachpb.SystemTenantID {
 			opts = append(opts, security.ForTenant(ctx.tenID.ToUint64()))
 		}
+		if len(ctx.config.CertSources) > 0 {
+			opts = append(opts, security.WithCertSources(ctx.config.CertSources))
+		}
 		ctx.lazy.certificateManager.cm, ctx.lazy.certificateManager.err =
 			security.NewCertificateManager(ctx.config.SSLCertsDir, ctx, opts...)
 
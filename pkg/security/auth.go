@@ -106,8 +106,12 @@ func Contains(sl []string, s string) bool {
 }
 
 // UserAuthCertHook builds an authentication hook based on the security
-// mode and client certificate.
-func UserAuthCertHook(insecureMode bool, tlsState *tls.ConnectionState) (UserAuthHook, error) {
+// mode and client certificate. If roleSubject is not nil, the subject
+// of the client certificate must match it instead of the principals of
+// the certificate.
+func UserAuthCertHook(
+	insecureMode bool, tlsState *tls.ConnectionState, roleSubject DistinguishedName,
+) (UserAuthHook, error) {
 	var certUsers []string
 
 	if !insecureMode {
@@ -140,6 +144,17 @@ func UserAuthCertHook(insecureMode bool, tlsState *tls.ConnectionState) (UserAut
 			return errors.Errorf("using tenant client certificate as user certificate is not allowed")
 		}
 
+		// The subject configured for the role, if any, identifies the
+		// client certificates which can authenticate as the requested
+		// user regardless of their common name.
+		if roleSubject != nil {
+			if certSubject := CertificateSubject(tlsState.PeerCertificates[0]); !certSubject.Equal(roleSubject) {
+				return errors.Errorf("requested user is %s, but certificate subject %q does not match the subject of the role",
+					systemIdentity, certSubject)
+			}
+			return nil
+		}
+
 		// The client certificate user must match the requested user.
 		if !Contains(certUsers, systemIdentity.Normalized()) {
 			return errors.Errorf("requested user is %s, but certificate is for %s", systemIdentity, certUsers)
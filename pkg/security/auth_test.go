@@ -217,7 +217,7 @@ func TestAuthenticationHook(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
-			hook, err := security.UserAuthCertHook(tc.insecure, makeFakeTLSState(tc.tlsSpec))
+			hook, err := security.UserAuthCertHook(tc.insecure, makeFakeTLSState(tc.tlsSpec), nil /* roleSubject */)
 			if (err == nil) != tc.buildHookSuccess {
 				t.Fatalf("expected success=%t, got err=%v", tc.buildHookSuccess, err)
 			}
@@ -235,3 +235,41 @@ func TestAuthenticationHook(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthenticationHookRoleSubject(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	fooUser := security.MakeSQLUsernameFromPreNormalizedString("foo")
+	fooSubject, err := security.ParseDistinguishedName("CN=Foo Service,OU=Eng")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		tlsSpec     string
+		roleSubject security.DistinguishedName
+		success     bool
+	}{
+		// The subject replaces the common name check.
+		{"(Eng)Foo Service", fooSubject, true},
+		{"(Eng)foo", fooSubject, false},
+		{"Foo Service", fooSubject, false},
+		// Without subject, the common name must match.
+		{"(Eng)Foo Service", nil, false},
+		{"(Eng)foo", nil, true},
+		// Tenant certificates are rejected regardless of the subject.
+		{"(Tenants)Foo Service", security.DistinguishedName{
+			{Type: "CN", Value: "Foo Service"}, {Type: "OU", Value: "Tenants"},
+		}, false},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		t.Run(tc.tlsSpec, func(t *testing.T) {
+			hook, err := security.UserAuthCertHook(false /* insecure */, makeFakeTLSState(tc.tlsSpec), tc.roleSubject)
+			require.NoError(t, err)
+			err = hook(ctx, fooUser, true /* clientConnection */)
+			if (err == nil) != tc.success {
+				t.Fatalf("expected success=%t, got err=%v", tc.success, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/errors/oserror"
+)
+
+// CertSource is a file of the certificates directory whose contents
+// are loaded from a secret store, for example the CA certificate or the
+// node key. The file is written to the certificates directory when the
+// certificate manager is created and refreshed periodically, so that
+// the certificates can be rotated in the secret store.
+type CertSource struct {
+	// Filename is the name of the file in the certificates directory,
+	// e.g. ca.crt or node.key.
+	Filename string
+	// Ref references the secret, see LoadSecret.
+	Ref string
+}
+
+// ParseCertSources parses the specifications of certificate sources, of
+// the form <filename>=<secret reference>, for example:
+//
+//   ca-client.crt=vault://vault.example.com:8200/secret/data/crdb?field=ca_client
+func ParseCertSources(specs []string) ([]CertSource, error) {
+	sources := make([]CertSource, 0, len(specs))
+	seen := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		eq := strings.IndexByte(spec, '=')
+		if eq < 0 {
+			return nil, errors.Newf("invalid certificate source %q: expected <filename>=<secret>", spec)
+		}
+		s := CertSource{Filename: spec[:eq], Ref: spec[eq+1:]}
+		if filepath.Base(s.Filename) != s.Filename ||
+			!(strings.HasSuffix(s.Filename, certExtension) || strings.HasSuffix(s.Filename, keyExtension)) {
+			return nil, errors.Newf("invalid certificate source %q: %q is not a certificate or key file name",
+				spec, s.Filename)
+		}
+		if _, err := CertInfoFromFilename(s.Filename); err != nil {
+			return nil, errors.Wrapf(err, "invalid certificate source %q", spec)
+		}
+		if err := ValidateSecretRef(s.Ref); err != nil {
+			return nil, errors.Wrapf(err, "invalid certificate source %q", spec)
+		}
+		if seen[s.Filename] {
+			return nil, errors.Newf("duplicate certificate source for %s", s.Filename)
+		}
+		seen[s.Filename] = true
+		sources = append(sources, s)
+	}
+	return sources, nil
+}
+
+// syncCertSources loads the certificate sources and writes their
+// contents to the certificates directory. It returns true iff any file
+// was modified.
+func syncCertSources(ctx context.Context, certsDir string, sources []CertSource) (bool, error) {
+	if len(sources) == 0 {
+		return false, nil
+	}
+	if err := os.MkdirAll(certsDir, 0700); err != nil {
+		return false, errors.Wrap(err, "creating certs directory")
+	}
+	changed := false
+	for _, s := range sources {
+		contents, err := loadSecretUncached(ctx, s.Ref)
+		if err != nil {
+			return changed, errors.Wrapf(err, "loading %s", s.Filename)
+		}
+		if len(contents) == 0 {
+			return changed, errors.Newf("loading %s: empty secret", s.Filename)
+		}
+		if !bytes.HasSuffix(contents, []byte("\n")) {
+			contents = append(contents, '\n')
+		}
+		path := filepath.Join(certsDir, s.Filename)
+		existing, err := ioutil.ReadFile(path)
+		if err != nil && !oserror.IsNotExist(err) {
+			return changed, err
+		}
+		if bytes.Equal(existing, contents) {
+			continue
+		}
+		mode := os.FileMode(0644)
+		if strings.HasSuffix(s.Filename, keyExtension) {
+			mode = 0600
+		}
+		if err := writeFileAtomically(path, contents, mode); err != nil {
+			return changed, errors.Wrapf(err, "writing %s", s.Filename)
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+// writeFileAtomically writes a file by renaming a temporary file, so
+// that the certificate loader never observes a partially written file.
+func writeFileAtomically(path string, contents []byte, mode os.FileMode) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := f.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+	if _, err := f.Write(contents); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Chmod(mode); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
@@ -0,0 +1,105 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCertSources(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	sources, err := ParseCertSources([]string{
+		"ca.crt=/etc/crdb/ca.crt",
+		"node.key=vault://vault:8200/secret/data/crdb?field=node_key",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []CertSource{
+		{Filename: "ca.crt", Ref: "/etc/crdb/ca.crt"},
+		{Filename: "node.key", Ref: "vault://vault:8200/secret/data/crdb?field=node_key"},
+	}, sources)
+
+	for _, tc := range []struct {
+		spec, err string
+	}{
+		{"ca.crt", "expected <filename>=<secret>"},
+		{"ca.pem=/f", "is not a certificate or key file name"},
+		{"../ca.crt=/f", "is not a certificate or key file name"},
+		{"foo.crt=/f", `unknown prefix "foo"`},
+		{"ca.crt=foo://bar", "unsupported secret reference scheme"},
+	} {
+		_, err := ParseCertSources([]string{tc.spec})
+		require.Error(t, err, tc.spec)
+		require.Contains(t, err.Error(), tc.err)
+	}
+
+	_, err = ParseCertSources([]string{"ca.crt=/a", "ca.crt=/b"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "duplicate certificate source for ca.crt")
+}
+
+func TestSyncCertSources(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	secretsDir := t.TempDir()
+	certsDir := filepath.Join(t.TempDir(), "certs")
+	write := func(name, contents string) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(secretsDir, name), []byte(contents), 0600))
+	}
+	write("ca", "ca contents")
+	write("key", "key contents\n")
+	sources := []CertSource{
+		{Filename: "ca.crt", Ref: filepath.Join(secretsDir, "ca")},
+		{Filename: "node.key", Ref: filepath.Join(secretsDir, "key")},
+	}
+
+	changed, err := syncCertSources(ctx, certsDir, sources)
+	require.NoError(t, err)
+	require.True(t, changed)
+	contents, err := ioutil.ReadFile(filepath.Join(certsDir, "ca.crt"))
+	require.NoError(t, err)
+	require.Equal(t, "ca contents\n", string(contents))
+	info, err := os.Stat(filepath.Join(certsDir, "node.key"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// Nothing changed.
+	changed, err = syncCertSources(ctx, certsDir, sources)
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	// The files are replaced when the secrets are rotated.
+	write("ca", "new ca contents")
+	changed, err = syncCertSources(ctx, certsDir, sources)
+	require.NoError(t, err)
+	require.True(t, changed)
+	contents, err = ioutil.ReadFile(filepath.Join(certsDir, "ca.crt"))
+	require.NoError(t, err)
+	require.Equal(t, "new ca contents\n", string(contents))
+
+	// No temporary files are left behind.
+	files, err := ioutil.ReadDir(certsDir)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	require.NoError(t, os.Remove(filepath.Join(secretsDir, "key")))
+	_, err = syncCertSources(ctx, certsDir, sources)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "loading node.key")
+}
@@ -0,0 +1,352 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security
+
+import (
+	"bufio"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/errors"
+)
+
+// ClientCertSubjectMappingSettingName is the name of the cluster
+// setting that maps the subjects of client certificates to SQL roles.
+const ClientCertSubjectMappingSettingName = "security.client_cert.subject_mapping"
+
+// clientCertSubjectMapping maps SQL roles to the subjects of the client
+// certificates which can authenticate as them. Each line contains a
+// role name followed by a distinguished name, for example:
+//
+//   alice CN=Alice Smith,OU=Engineering,O=Example Corp,C=US
+//
+// Empty lines and lines starting with # are ignored. The SUBJECT role
+// option takes precedence over this setting.
+//
+// Since the mapping decides which certificates can authenticate as which
+// roles, including admins, only the system tenant can change it.
+var clientCertSubjectMapping = settings.RegisterValidatedStringSetting(
+	settings.SystemOnly,
+	ClientCertSubjectMappingSettingName,
+	"mapping from SQL roles to the subject distinguished names of the client "+
+		"certificates which can authenticate as them, one role name and subject per line; "+
+		"the SUBJECT role option takes precedence",
+	"",
+	func(_ *settings.Values, s string) error {
+		_, err := parseClientCertSubjectMapping(s)
+		return err
+	},
+).WithPublic()
+
+// DNAttribute is an attribute of a distinguished name, for example
+// CN=alice.
+type DNAttribute struct {
+	// Type is the upper case short name of the attribute type, or its
+	// dotted OID if it does not have a well-known short name.
+	Type string
+	// Value is the unescaped value of the attribute.
+	Value string
+}
+
+// DistinguishedName is the list of the attributes of a distinguished
+// name, such as the subject of a certificate. The grouping of the
+// attributes into relative distinguished names is not retained.
+type DistinguishedName []DNAttribute
+
+// dnAttributeShortNames maps the OIDs of the well-known attribute types
+// to their short names.
+var dnAttributeShortNames = map[string]string{
+	"2.5.4.3":                    "CN",
+	"2.5.4.5":                    "SERIALNUMBER",
+	"2.5.4.6":                    "C",
+	"2.5.4.7":                    "L",
+	"2.5.4.8":                    "ST",
+	"2.5.4.9":                    "STREET",
+	"2.5.4.10":                   "O",
+	"2.5.4.11":                   "OU",
+	"2.5.4.17":                   "POSTALCODE",
+	"0.9.2342.19200300.100.1.1":  "UID",
+	"0.9.2342.19200300.100.1.25": "DC",
+	"1.2.840.113549.1.9.1":       "EMAILADDRESS",
+}
+
+// canonicalDNAttributeType returns the canonical form of the given
+// attribute type, that is its upper case short name if it has one.
+func canonicalDNAttributeType(t string) string {
+	if name, ok := dnAttributeShortNames[t]; ok {
+		return name
+	}
+	return strings.ToUpper(t)
+}
+
+// ParseDistinguishedName parses a distinguished name in the string
+// representation of RFC 4514, for example "CN=alice,OU=Engineering,O=Example".
+// Hex-encoded attribute values (#...) are not supported.
+func ParseDistinguishedName(s string) (DistinguishedName, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, errors.New("empty distinguished name")
+	}
+	var dn DistinguishedName
+	for rest := s; ; {
+		eq := strings.IndexByte(rest, '=')
+		if strings.TrimSpace(rest) == "" {
+			return nil, errors.Newf("invalid distinguished name %q: trailing separator", s)
+		}
+		if eq < 0 {
+			return nil, errors.Newf("invalid distinguished name %q: missing value after %q", s, rest)
+		}
+		typ := strings.TrimSpace(rest[:eq])
+		if !isValidDNAttributeType(typ) {
+			return nil, errors.Newf("invalid distinguished name %q: invalid attribute type %q", s, typ)
+		}
+		value, n, err := parseDNAttributeValue(rest[eq+1:])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid distinguished name %q", s)
+		}
+		dn = append(dn, DNAttribute{Type: canonicalDNAttributeType(typ), Value: value})
+		rest = rest[eq+1+n:]
+		if rest == "" {
+			return dn, nil
+		}
+		// Skip the separator between two attributes.
+		rest = rest[1:]
+	}
+}
+
+// isValidDNAttributeType returns true iff t is a keyword or a dotted
+// OID.
+func isValidDNAttributeType(t string) bool {
+	if t == "" {
+		return false
+	}
+	if t[0] >= '0' && t[0] <= '9' {
+		for _, part := range strings.Split(t, ".") {
+			if part == "" {
+				return false
+			}
+			for _, c := range part {
+				if c < '0' || c > '9' {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	for i, c := range t {
+		isLetter := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if !isLetter && (i == 0 || !(c >= '0' && c <= '9') && c != '-') {
+			return false
+		}
+	}
+	return true
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// parseDNAttributeValue parses the attribute value at the start of s,
+// up to the next unescaped separator. It returns the unescaped value
+// and the number of bytes consumed.
+func parseDNAttributeValue(s string) (string, int, error) {
+	i := 0
+	// Leading spaces are insignificant.
+	for i < len(s) && s[i] == ' ' {
+		i++
+	}
+	if i < len(s) && s[i] == '#' {
+		return "", 0, errors.New("hex-encoded attribute values are not supported")
+	}
+	var buf []byte
+	// trailing is the number of unescaped spaces at the end of buf,
+	// which are insignificant.
+	trailing := 0
+	for ; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case ',', '+', ';':
+			buf = buf[:len(buf)-trailing]
+			if len(buf) == 0 {
+				return "", 0, errors.New("empty attribute value")
+			}
+			if !utf8.Valid(buf) {
+				return "", 0, errors.New("attribute value is not valid UTF-8")
+			}
+			return string(buf), i, nil
+		case '\\':
+			if i+1 >= len(s) {
+				return "", 0, errors.New("unterminated escape sequence")
+			}
+			if i+2 < len(s) && isHexDigit(s[i+1]) && isHexDigit(s[i+2]) {
+				b, _ := hex.DecodeString(s[i+1 : i+3])
+				buf = append(buf, b...)
+				i += 2
+			} else {
+				buf = append(buf, s[i+1])
+				i++
+			}
+			trailing = 0
+		case '"', '<', '>':
+			return "", 0, errors.Newf("character %q must be escaped", c)
+		default:
+			buf = append(buf, c)
+			if c == ' ' {
+				trailing++
+			} else {
+				trailing = 0
+			}
+		}
+	}
+	buf = buf[:len(buf)-trailing]
+	if len(buf) == 0 {
+		return "", 0, errors.New("empty attribute value")
+	}
+	if !utf8.Valid(buf) {
+		return "", 0, errors.New("attribute value is not valid UTF-8")
+	}
+	return string(buf), len(s), nil
+}
+
+// CertificateSubject returns the subject of the given certificate.
+func CertificateSubject(cert *x509.Certificate) DistinguishedName {
+	names := cert.Subject.Names
+	if len(names) == 0 {
+		// The certificate was not parsed from its DER encoding, e.g. it
+		// is a template: use the fields of the subject instead.
+		for _, rdn := range cert.Subject.ToRDNSequence() {
+			names = append(names, rdn...)
+		}
+	}
+	dn := make(DistinguishedName, 0, len(names))
+	for _, name := range names {
+		dn = append(dn, makeDNAttribute(name))
+	}
+	return dn
+}
+
+func makeDNAttribute(name pkix.AttributeTypeAndValue) DNAttribute {
+	return DNAttribute{
+		Type:  canonicalDNAttributeType(name.Type.String()),
+		Value: fmt.Sprint(name.Value),
+	}
+}
+
+// Equal returns true iff the two distinguished names have the same
+// attributes, regardless of their order. Attribute types are compared
+// case-insensitively and values are compared exactly.
+func (dn DistinguishedName) Equal(other DistinguishedName) bool {
+	if len(dn) != len(other) {
+		return false
+	}
+	a, b := dn.sorted(), other.sorted()
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (dn DistinguishedName) sorted() DistinguishedName {
+	res := append(DistinguishedName(nil), dn...)
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Type != res[j].Type {
+			return res[i].Type < res[j].Type
+		}
+		return res[i].Value < res[j].Value
+	})
+	return res
+}
+
+// String returns the RFC 4514 representation of the distinguished name.
+func (dn DistinguishedName) String() string {
+	var buf strings.Builder
+	for i, attr := range dn {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(attr.Type)
+		buf.WriteByte('=')
+		for j := 0; j < len(attr.Value); j++ {
+			c := attr.Value[j]
+			switch {
+			case strings.IndexByte(`"+,;<>\`, c) >= 0,
+				j == 0 && (c == ' ' || c == '#'),
+				j == len(attr.Value)-1 && c == ' ':
+				buf.WriteByte('\\')
+				buf.WriteByte(c)
+			case c < ' ':
+				fmt.Fprintf(&buf, "\\%02x", c)
+			default:
+				buf.WriteByte(c)
+			}
+		}
+	}
+	return buf.String()
+}
+
+// parseClientCertSubjectMapping parses the value of the
+// security.client_cert.subject_mapping cluster setting. The result maps
+// normalized role names to subjects.
+func parseClientCertSubjectMapping(s string) (map[string]DistinguishedName, error) {
+	res := make(map[string]DistinguishedName)
+	scanner := bufio.NewScanner(strings.NewReader(s))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		sep := strings.IndexAny(line, " \t")
+		if sep < 0 {
+			return nil, errors.WithHint(
+				errors.Newf("line %d: missing certificate subject", lineNum),
+				"Each line must contain a role name followed by a distinguished name.")
+		}
+		user, err := MakeSQLUsernameFromUserInput(line[:sep], UsernameValidation)
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", lineNum)
+		}
+		dn, err := ParseDistinguishedName(line[sep+1:])
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", lineNum)
+		}
+		if _, ok := res[user.Normalized()]; ok {
+			return nil, errors.Newf("line %d: duplicate certificate subject for role %s", lineNum, user)
+		}
+		res[user.Normalized()] = dn
+	}
+	return res, scanner.Err()
+}
+
+// GetClientCertSubject returns the subject of the client certificates
+// which can authenticate as the given role. The SUBJECT role option
+// roleSubject, if non-empty, takes precedence over the
+// security.client_cert.subject_mapping cluster setting. The result is
+// nil if no subject is configured for the role, in which case the
+// common name of the certificate is used instead.
+func GetClientCertSubject(
+	sv *settings.Values, user SQLUsername, roleSubject string,
+) (DistinguishedName, error) {
+	if roleSubject != "" {
+		return ParseDistinguishedName(roleSubject)
+	}
+	mapping, err := parseClientCertSubjectMapping(clientCertSubjectMapping.Get(sv))
+	if err != nil {
+		return nil, err
+	}
+	return mapping[user.Normalized()], nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDistinguishedName(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testCases := []struct {
+		input    string
+		expected string
+		err      string
+	}{
+		{input: "CN=alice", expected: "CN=alice"},
+		{input: "cn=alice, ou=Eng , O=Example Corp", expected: "CN=alice,OU=Eng,O=Example Corp"},
+		{input: "CN=alice+UID=42,DC=example,DC=com", expected: "CN=alice,UID=42,DC=example,DC=com"},
+		{input: "2.5.4.3=alice;0.9.2342.19200300.100.1.25=com", expected: "CN=alice,DC=com"},
+		{input: `CN=Smith\, John,O=a\+b`, expected: `CN=Smith\, John,O=a\+b`},
+		{input: `CN=\41lice\20`, expected: `CN=Alice\ `},
+		{input: `CN=caf\c3\a9`, expected: `CN=café`},
+		{input: "", err: "empty distinguished name"},
+		{input: "alice", err: "missing value"},
+		{input: "CN=", err: "empty attribute value"},
+		{input: "CN=alice,", err: "trailing separator"},
+		{input: "C N=alice", err: "invalid attribute type"},
+		{input: "1..2=alice", err: "invalid attribute type"},
+		{input: "CN=#04026869", err: "hex-encoded attribute values are not supported"},
+		{input: `CN=a"b`, err: "must be escaped"},
+		{input: `CN=alice\`, err: "unterminated escape sequence"},
+		{input: `CN=\ff`, err: "not valid UTF-8"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			dn, err := ParseDistinguishedName(tc.input)
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, dn.String())
+		})
+	}
+}
+
+func TestDistinguishedNameEqual(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testCases := []struct {
+		a, b  string
+		equal bool
+	}{
+		{"CN=alice,O=Example", "CN=alice,O=Example", true},
+		{"CN=alice,O=Example", "o=Example, cn=alice", true},
+		{"CN=alice+O=Example", "CN=alice,O=Example", true},
+		{"CN=alice,O=Example", "CN=Alice,O=Example", false},
+		{"CN=alice,O=Example", "CN=alice", false},
+		{"CN=alice,OU=a,OU=b", "CN=alice,OU=a,OU=a", false},
+	}
+	for _, tc := range testCases {
+		a, err := ParseDistinguishedName(tc.a)
+		require.NoError(t, err)
+		b, err := ParseDistinguishedName(tc.b)
+		require.NoError(t, err)
+		require.Equal(t, tc.equal, a.Equal(b), "%s / %s", tc.a, tc.b)
+	}
+}
+
+func TestCertificateSubject(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	expected, err := ParseDistinguishedName("CN=alice,OU=Eng,O=Example,C=US,DC=example")
+	require.NoError(t, err)
+
+	// A parsed certificate lists all the attributes of its subject,
+	// including those without a field in pkix.Name.
+	parsed := &x509.Certificate{Subject: pkix.Name{Names: []pkix.AttributeTypeAndValue{
+		{Type: asn1.ObjectIdentifier{2, 5, 4, 6}, Value: "US"},
+		{Type: asn1.ObjectIdentifier{2, 5, 4, 10}, Value: "Example"},
+		{Type: asn1.ObjectIdentifier{2, 5, 4, 11}, Value: "Eng"},
+		{Type: asn1.ObjectIdentifier{0, 9, 2342, 19200300, 100, 1, 25}, Value: "example"},
+		{Type: asn1.ObjectIdentifier{2, 5, 4, 3}, Value: "alice"},
+	}}}
+	require.True(t, CertificateSubject(parsed).Equal(expected), "%s", CertificateSubject(parsed))
+
+	// Otherwise the fields of the subject are used.
+	template := &x509.Certificate{Subject: pkix.Name{
+		CommonName:         "alice",
+		OrganizationalUnit: []string{"Eng"},
+		Organization:       []string{"Example"},
+		Country:            []string{"US"},
+		ExtraNames: []pkix.AttributeTypeAndValue{
+			{Type: asn1.ObjectIdentifier{0, 9, 2342, 19200300, 100, 1, 25}, Value: "example"},
+		},
+	}}
+	require.True(t, CertificateSubject(template).Equal(expected), "%s", CertificateSubject(template))
+}
+
+func TestGetClientCertSubject(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	alice := MakeSQLUsernameFromPreNormalizedString("alice")
+	bob := MakeSQLUsernameFromPreNormalizedString("bob")
+
+	// No subject by default.
+	dn, err := GetClientCertSubject(&st.SV, alice, "")
+	require.NoError(t, err)
+	require.Nil(t, dn)
+
+	clientCertSubjectMapping.Override(ctx, &st.SV, `
+# Engineering.
+Alice	CN=Alice Smith,O=Example
+bob   CN=Bob,O=Example
+`)
+	dn, err = GetClientCertSubject(&st.SV, alice, "")
+	require.NoError(t, err)
+	require.Equal(t, "CN=Alice Smith,O=Example", dn.String())
+	dn, err = GetClientCertSubject(&st.SV, bob, "")
+	require.NoError(t, err)
+	require.Equal(t, "CN=Bob,O=Example", dn.String())
+
+	// The role option takes precedence.
+	dn, err = GetClientCertSubject(&st.SV, alice, "CN=alice,OU=Eng")
+	require.NoError(t, err)
+	require.Equal(t, "CN=alice,OU=Eng", dn.String())
+
+	for _, tc := range []struct {
+		mapping string
+		err     string
+	}{
+		{"alice", "line 1: missing certificate subject"},
+		{"alice CN=a\nalice CN=b", "line 2: duplicate certificate subject for role alice"},
+		{"alice CN", "line 1: invalid distinguished name"},
+	} {
+		_, err := parseClientCertSubjectMapping(tc.mapping)
+		require.Error(t, err, tc.mapping)
+		require.Contains(t, err.Error(), tc.err)
+	}
+}
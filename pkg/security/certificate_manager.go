@@ -17,6 +17,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
@@ -106,6 +107,10 @@ type CertificateManager struct {
 
 	tlsSettings TLSSettings
 
+	// certSources are the files of the certificates directory loaded
+	// from secret stores.
+	certSources []CertSource
+
 	// The metrics struct is initialized at init time and metrics do their
 	// own locking.
 	certMetrics CertificateMetrics
@@ -131,6 +136,9 @@ type CertificateManager struct {
 	// TLS configs. Initialized lazily. Wiped on every successful Load().
 	// Server-side config.
 	serverConfig *tls.Config
+	// The staged client CA used to build serverConfig. The server-side
+	// config is rebuilt when the rotation of the client CA progresses.
+	serverConfigClientCA stagedClientCA
 	// Server-side config for the Admin UI.
 	uiServerConfig *tls.Config
 	// Client-side config for the cockroach node.
@@ -166,6 +174,7 @@ func makeCertificateManager(
 		CertsLocator:     MakeCertsLocator(certsDir),
 		tenantIdentifier: o.tenantIdentifier,
 		tlsSettings:      tlsSettings,
+		certSources:      o.certSources,
 		certMetrics: CertificateMetrics{
 			CAExpiration:         metric.NewGauge(metaCAExpiration),
 			ClientCAExpiration:   metric.NewGauge(metaClientCAExpiration),
@@ -183,6 +192,10 @@ type cmOptions struct {
 	// tenantIdentifier, if set, specifies the tenant to use for loading tenant
 	// client certs.
 	tenantIdentifier uint64
+
+	// certSources, if set, specifies the files of the certificates
+	// directory to load from secret stores.
+	certSources []CertSource
 }
 
 // Option is an option to NewCertificateManager.
@@ -197,11 +210,24 @@ func ForTenant(tenantIdentifier uint64) Option {
 	}
 }
 
+// WithCertSources is an option to NewCertificateManager which loads the
+// given files of the certificates directory from secret stores. They
+// are loaded before the certificates directory is first read, and
+// refreshed periodically once RegisterSignalHandler is called.
+func WithCertSources(sources []CertSource) Option {
+	return func(opts *cmOptions) {
+		opts.certSources = sources
+	}
+}
+
 // NewCertificateManager creates a new certificate manager.
 func NewCertificateManager(
 	certsDir string, tlsSettings TLSSettings, opts ...Option,
 ) (*CertificateManager, error) {
 	cm := makeCertificateManager(certsDir, tlsSettings, opts...)
+	if _, err := syncCertSources(context.Background(), cm.certsDir, cm.certSources); err != nil {
+		return nil, makeError(err, "problem loading certificate sources")
+	}
 	return cm, cm.LoadCertificates()
 }
 
@@ -232,28 +258,55 @@ func (cm *CertificateManager) Metrics() CertificateMetrics {
 }
 
 // RegisterSignalHandler registers a signal handler for SIGHUP, triggering a
-// refresh of the certificates directory on notification.
+// refresh of the certificates directory on notification. If certificate
+// sources are configured, they are also refreshed every
+// SecretRefreshInterval, and the certificates directory is reloaded when
+// they change.
 func (cm *CertificateManager) RegisterSignalHandler(stopper *stop.Stopper) {
 	ctx := context.Background()
 	go func() {
 		ch := sysutil.RefreshSignaledChan()
+		var refreshC <-chan time.Time
+		if len(cm.certSources) > 0 {
+			ticker := time.NewTicker(SecretRefreshInterval)
+			defer ticker.Stop()
+			refreshC = ticker.C
+		}
 		for {
 			select {
 			case <-stopper.ShouldQuiesce():
 				return
 			case sig := <-ch:
 				log.Ops.Infof(ctx, "received signal %q, triggering certificate reload", sig)
-				if err := cm.LoadCertificates(); err != nil {
-					log.Ops.Warningf(ctx, "could not reload certificates: %v", err)
-					log.StructuredEvent(ctx, &eventpb.CertsReload{Success: false, ErrorMessage: err.Error()})
-				} else {
-					log.StructuredEvent(ctx, &eventpb.CertsReload{Success: true})
+				if _, err := syncCertSources(ctx, cm.certsDir, cm.certSources); err != nil {
+					log.Ops.Warningf(ctx, "could not refresh certificate sources: %v", err)
+				}
+				cm.reloadCertificates(ctx)
+			case <-refreshC:
+				changed, err := syncCertSources(ctx, cm.certsDir, cm.certSources)
+				if err != nil {
+					log.Ops.Warningf(ctx, "could not refresh certificate sources: %v", err)
+				}
+				if changed {
+					log.Ops.Infof(ctx, "certificate sources changed, triggering certificate reload")
+					cm.reloadCertificates(ctx)
 				}
 			}
 		}
 	}()
 }
 
+// reloadCertificates reloads the certificates directory and reports
+// the outcome.
+func (cm *CertificateManager) reloadCertificates(ctx context.Context) {
+	if err := cm.LoadCertificates(); err != nil {
+		log.Ops.Warningf(ctx, "could not reload certificates: %v", err)
+		log.StructuredEvent(ctx, &eventpb.CertsReload{Success: false, ErrorMessage: err.Error()})
+	} else {
+		log.StructuredEvent(ctx, &eventpb.CertsReload{Success: true})
+	}
+}
+
 // A CertsLocator provides locations to certificates.
 type CertsLocator struct {
 	certsDir string // os.ExpandEnv'ed
@@ -808,7 +861,8 @@ func (cm *CertificateManager) getEmbeddedServerTLSConfig(
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
 
-	if cm.serverConfig != nil {
+	rotation := cm.tlsSettings.clientCARotation()
+	if cm.serverConfig != nil && cm.serverConfigClientCA == rotation {
 		return cm.serverConfig, nil
 	}
 
@@ -842,19 +896,30 @@ func (cm *CertificateManager) getEmbeddedServerTLSConfig(
 		return nil, err
 	}
 
+	var nodeClientCert *CertInfo
+	if rotation.active && !cm.IsForTenant() {
+		if nodeClientCert, err = cm.getNodeClientCertLocked(); err != nil {
+			return nil, err
+		}
+	}
+	clientCAs, warning := clientCAPEMs(clientCA.FileContents, rotation, nodeClientCert)
+	if warning != nil {
+		log.Ops.Warningf(context.Background(), "%v", warning)
+	}
+
 	cfg, err := newServerTLSConfig(
 		cm.tlsSettings,
 		nodeCert.FileContents,
 		nodeCert.KeyFileContents,
 		ca.FileContents,
-		clientCA.FileContents,
-		tenantCA.FileContents,
+		append(clientCAs, tenantCA.FileContents)...,
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	cm.serverConfig = cfg
+	cm.serverConfigClientCA = rotation
 	return cfg, nil
 }
 
@@ -0,0 +1,163 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security
+
+import (
+	"crypto/x509"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/errors"
+)
+
+// The client CA can be rotated without restarting the nodes using two
+// cluster settings:
+//
+// - security.client_ca.staged_bundle contains the PEM-encoded
+//   certificates of the new client CA.
+// - security.client_ca.rotation_phase controls whether the staged
+//   certificates are trusted. In the "transition" phase, the client
+//   certificates signed by either the current client CA (loaded from
+//   the certificates directory) or the staged certificates are
+//   accepted, so that the client certificates can be reissued
+//   progressively. In the "active" phase, only the client certificates
+//   signed by the staged certificates are accepted.
+//
+// Since cluster settings are propagated to all the nodes, the rotation
+// applies cluster-wide. The rotation is completed by installing the new
+// client CA in the certificates directory of every node, after which the
+// settings can be reset. Since the settings decide which client
+// certificates are trusted by the nodes, only the system tenant can change
+// them.
+
+const (
+	clientCARotationOff        = 0
+	clientCARotationTransition = 1
+	clientCARotationActive     = 2
+)
+
+// ClientCAStagedBundleSettingName is the name of the cluster setting
+// containing the staged client CA certificates.
+const ClientCAStagedBundleSettingName = "security.client_ca.staged_bundle"
+
+// ClientCARotationPhaseSettingName is the name of the cluster setting
+// controlling the phase of the rotation of the client CA.
+const ClientCARotationPhaseSettingName = "security.client_ca.rotation_phase"
+
+var clientCAStagedBundle = settings.RegisterValidatedStringSetting(
+	settings.SystemOnly,
+	ClientCAStagedBundleSettingName,
+	"PEM-encoded certificates of the new client CA, to rotate the client CA without restarting the nodes "+
+		"(see "+ClientCARotationPhaseSettingName+")",
+	"",
+	func(_ *settings.Values, s string) error {
+		_, err := parseClientCABundle(s)
+		return err
+	},
+).WithPublic()
+
+var clientCARotationPhase = settings.RegisterEnumSetting(
+	settings.SystemOnly,
+	ClientCARotationPhaseSettingName,
+	"phase of the rotation of the client CA: when off, the staged client CA certificates in "+
+		ClientCAStagedBundleSettingName+" are ignored; during the transition, the client certificates signed by "+
+		"either the current or the staged client CA are accepted; when active, only the client certificates "+
+		"signed by the staged client CA are accepted",
+	"off",
+	map[int64]string{
+		clientCARotationOff:        "off",
+		clientCARotationTransition: "transition",
+		clientCARotationActive:     "active",
+	},
+).WithPublic()
+
+// stagedClientCA describes the staged client CA certificates which apply
+// to the verification of client certificates.
+type stagedClientCA struct {
+	// bundle is the PEM-encoded staged certificates, or empty if there
+	// are none or if the rotation is off.
+	bundle string
+	// active is true iff the staged certificates replace the current
+	// client CA instead of being trusted in addition to it.
+	active bool
+}
+
+// parseClientCABundle parses the PEM-encoded client CA certificates s.
+// All the certificates must be CA certificates.
+func parseClientCABundle(s string) ([]*x509.Certificate, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	blocks, err := PEMToCertificates([]byte(s))
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) == 0 {
+		return nil, errors.New("no certificates found in the PEM data")
+	}
+	certs := make([]*x509.Certificate, len(blocks))
+	for i, block := range blocks {
+		certs[i], err = x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse certificate #%d", i)
+		}
+		if !certs[i].IsCA {
+			return nil, errors.Newf("certificate #%d (%s) is not a CA certificate", i, certs[i].Subject)
+		}
+	}
+	return certs, nil
+}
+
+// clientCAPEMs returns the PEM-encoded CA certificates to verify the
+// client certificates with, given the current client CA and the staged
+// one.
+//
+// The current client CA is only dropped in the active phase if the
+// client certificate of the node, used to connect to the other nodes,
+// is signed by the staged client CA; otherwise the node would be unable
+// to join the cluster. If the staged client CA cannot be activated, both
+// client CAs are trusted and the reason is returned as a warning.
+func clientCAPEMs(
+	currentClientCA []byte, staged stagedClientCA, nodeClientCert *CertInfo,
+) (pems [][]byte, warning error) {
+	if staged.bundle == "" {
+		return [][]byte{currentClientCA}, nil
+	}
+	stagedCerts, err := parseClientCABundle(staged.bundle)
+	if err != nil {
+		return [][]byte{currentClientCA}, errors.Wrap(err, "invalid staged client CA")
+	}
+	transition := [][]byte{currentClientCA, []byte(staged.bundle)}
+	if !staged.active {
+		return transition, nil
+	}
+	if nodeClientCert != nil && len(nodeClientCert.ParsedCertificates) > 0 {
+		roots := x509.NewCertPool()
+		for _, c := range stagedCerts {
+			roots.AddCert(c)
+		}
+		intermediates := x509.NewCertPool()
+		for _, c := range nodeClientCert.ParsedCertificates[1:] {
+			intermediates.AddCert(c)
+		}
+		if _, err := nodeClientCert.ParsedCertificates[0].Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		}); err != nil {
+			return transition, errors.WithHint(
+				errors.Wrapf(err, "cannot activate the staged client CA: "+
+					"the node client certificate %s is not signed by it", nodeClientCert.Filename),
+				"Reissue the node client certificates with the new client CA before activating it.")
+		}
+	}
+	return [][]byte{[]byte(staged.bundle)}, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+// makeTestCA generates a CA certificate and returns it along with its
+// PEM encoding and private key.
+func makeTestCA(t *testing.T) (*x509.Certificate, []byte, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := GenerateCA(key, time.Hour)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), key
+}
+
+// makeTestNodeClientCert generates a client certificate for the node
+// user signed by the given CA.
+func makeTestNodeClientCert(
+	t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey,
+) *CertInfo {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := GenerateClientCert(ca, caKey, key.Public(), 30*time.Minute, NodeUserName())
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &CertInfo{Filename: "client.node.crt", ParsedCertificates: []*x509.Certificate{cert}}
+}
+
+func TestParseClientCABundle(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	_, ca1PEM, _ := makeTestCA(t)
+	_, ca2PEM, _ := makeTestCA(t)
+
+	certs, err := parseClientCABundle("")
+	require.NoError(t, err)
+	require.Empty(t, certs)
+
+	certs, err = parseClientCABundle(string(ca1PEM) + string(ca2PEM))
+	require.NoError(t, err)
+	require.Len(t, certs, 2)
+
+	_, err = parseClientCABundle("not a certificate")
+	require.EqualError(t, err, "no certificates found in the PEM data")
+
+	ca, _, caKey := makeTestCA(t)
+	nodeCert := makeTestNodeClientCert(t, ca, caKey)
+	_, err = parseClientCABundle(string(pem.EncodeToMemory(&pem.Block{
+		Type: "CERTIFICATE", Bytes: nodeCert.ParsedCertificates[0].Raw,
+	})))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not a CA certificate")
+}
+
+func TestClientCAPEMs(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	oldCA, oldPEM, oldKey := makeTestCA(t)
+	newCA, newPEM, newKey := makeTestCA(t)
+	oldNodeCert := makeTestNodeClientCert(t, oldCA, oldKey)
+	newNodeCert := makeTestNodeClientCert(t, newCA, newKey)
+	staged := string(newPEM)
+
+	// No rotation in progress.
+	pems, warning := clientCAPEMs(oldPEM, stagedClientCA{}, oldNodeCert)
+	require.NoError(t, warning)
+	require.Equal(t, [][]byte{oldPEM}, pems)
+
+	// During the transition, both CAs are trusted.
+	pems, warning = clientCAPEMs(oldPEM, stagedClientCA{bundle: staged}, oldNodeCert)
+	require.NoError(t, warning)
+	require.Equal(t, [][]byte{oldPEM, newPEM}, pems)
+
+	// Once active, only the new CA is trusted.
+	pems, warning = clientCAPEMs(oldPEM, stagedClientCA{bundle: staged, active: true}, newNodeCert)
+	require.NoError(t, warning)
+	require.Equal(t, [][]byte{newPEM}, pems)
+
+	// The new CA is not activated if the node could not connect to the
+	// other nodes anymore.
+	pems, warning = clientCAPEMs(oldPEM, stagedClientCA{bundle: staged, active: true}, oldNodeCert)
+	require.Error(t, warning)
+	require.Contains(t, warning.Error(), "cannot activate the staged client CA")
+	require.Equal(t, [][]byte{oldPEM, newPEM}, pems)
+}
+
+func TestClusterTLSSettingsClientCARotation(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	tlsSettings := ClusterTLSSettings(st)
+	_, caPEM, _ := makeTestCA(t)
+
+	// The staged bundle is ignored until the transition starts.
+	clientCAStagedBundle.Override(ctx, &st.SV, string(caPEM))
+	require.Equal(t, stagedClientCA{}, tlsSettings.clientCARotation())
+
+	clientCARotationPhase.Override(ctx, &st.SV, clientCARotationTransition)
+	require.Equal(t, stagedClientCA{bundle: string(caPEM)}, tlsSettings.clientCARotation())
+
+	clientCARotationPhase.Override(ctx, &st.SV, clientCARotationActive)
+	require.Equal(t, stagedClientCA{bundle: string(caPEM), active: true}, tlsSettings.clientCARotation())
+}
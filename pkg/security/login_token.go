@@ -0,0 +1,140 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Login tokens are short-lived credentials which are accepted in place
+// of a password by the "token" HBA method, so that orchestration systems
+// can hand out ephemeral credentials without storing passwords. A token
+// has the form:
+//
+//   crdb-login-v1.<payload>.<signature>
+//
+// where the payload is the base64url-encoded JSON encoding of a
+// loginTokenPayload, and the signature the base64url-encoded
+// HMAC-SHA256 of everything before it. The signing key is loaded from a
+// secret reference (see LoadSecret); changing the key invalidates all
+// the tokens issued so far.
+
+const (
+	// MaxLoginTokenLifetime is the maximum lifetime of a login token.
+	MaxLoginTokenLifetime = 24 * time.Hour
+
+	// loginTokenPrefix identifies the login tokens and their version.
+	loginTokenPrefix = "crdb-login-v1."
+	// loginTokenSigningKeySize is the size of the keys used to sign the
+	// login tokens.
+	loginTokenSigningKeySize = 32
+	// loginTokenClockSkew is the tolerated clock difference between the
+	// node which issued a token and the node which validates it.
+	loginTokenClockSkew = time.Minute
+)
+
+// ErrInvalidLoginToken is returned when a login token is not valid.
+var ErrInvalidLoginToken = errors.New("invalid login token")
+
+// loginTokenPayload is the signed content of a login token.
+type loginTokenPayload struct {
+	// User is the normalized name of the user the token was issued for.
+	User string `json:"user"`
+	// IssuedAt and ExpiresAt are Unix timestamps, in seconds.
+	IssuedAt  int64 `json:"iat"`
+	ExpiresAt int64 `json:"exp"`
+}
+
+// LoadLoginTokenSigningKey loads the key used to sign the login tokens
+// from the given secret reference. The secret must contain a
+// base64-encoded 256-bit key.
+func LoadLoginTokenSigningKey(ctx context.Context, ref string) ([]byte, error) {
+	return loadKeyFromSecret(ctx, ref, "login token signing key", loginTokenSigningKeySize)
+}
+
+// IsLoginToken returns true if s looks like a login token. It does not
+// check its validity.
+func IsLoginToken(s string) bool {
+	return strings.HasPrefix(s, loginTokenPrefix)
+}
+
+func signLoginToken(key []byte, signed string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(signed))
+	return mac.Sum(nil)
+}
+
+// CreateLoginToken creates a login token for the given user, valid for
+// the given lifetime starting at now.
+func CreateLoginToken(
+	key []byte, user SQLUsername, now time.Time, lifetime time.Duration,
+) (string, error) {
+	if lifetime <= 0 || lifetime > MaxLoginTokenLifetime {
+		return "", errors.Newf("the lifetime of a login token must be positive and at most %s",
+			MaxLoginTokenLifetime)
+	}
+	payload, err := json.Marshal(loginTokenPayload{
+		User:      user.Normalized(),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(lifetime).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	signed := loginTokenPrefix + base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signLoginToken(key, signed)), nil
+}
+
+// ValidateLoginToken returns nil if the given token was signed with the
+// given key for the given user, and has not expired at the given time.
+// The errors are marked with ErrInvalidLoginToken.
+func ValidateLoginToken(key []byte, user SQLUsername, token string, now time.Time) error {
+	if !IsLoginToken(token) {
+		return errors.Mark(errors.New("not a login token"), ErrInvalidLoginToken)
+	}
+	i := strings.LastIndexByte(token, '.')
+	signed, encodedSig := token[:i], token[i+1:]
+	sig, err := base64.RawURLEncoding.DecodeString(encodedSig)
+	if err != nil || !hmac.Equal(sig, signLoginToken(key, signed)) {
+		return errors.Mark(errors.New("invalid signature"), ErrInvalidLoginToken)
+	}
+	payloadBytes, err := base64.RawURLEncoding.DecodeString(signed[len(loginTokenPrefix):])
+	if err != nil {
+		return errors.Mark(errors.Wrap(err, "decoding the payload"), ErrInvalidLoginToken)
+	}
+	var payload loginTokenPayload
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		return errors.Mark(errors.Wrap(err, "decoding the payload"), ErrInvalidLoginToken)
+	}
+	issuedAt, expiresAt := time.Unix(payload.IssuedAt, 0), time.Unix(payload.ExpiresAt, 0)
+	if now.Add(loginTokenClockSkew).Before(issuedAt) {
+		return errors.Mark(
+			errors.Newf("token issue time is in the future (%v)", issuedAt), ErrInvalidLoginToken)
+	}
+	if !now.Before(expiresAt) {
+		return errors.Mark(
+			errors.Newf("token expiration time is in the past (%v)", expiresAt), ErrInvalidLoginToken)
+	}
+	if payload.User != user.Normalized() {
+		return errors.Mark(
+			errors.Newf("token is for the wrong user %q, wanted %q", payload.User, user),
+			ErrInvalidLoginToken)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginToken(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	key := []byte(strings.Repeat("k", loginTokenSigningKeySize))
+	alice := MakeSQLUsernameFromPreNormalizedString("alice")
+	now := time.Unix(1650000000, 0)
+
+	token, err := CreateLoginToken(key, alice, now, time.Hour)
+	require.NoError(t, err)
+	require.True(t, IsLoginToken(token))
+	require.NoError(t, ValidateLoginToken(key, alice, token, now))
+	require.NoError(t, ValidateLoginToken(key, alice, token, now.Add(59*time.Minute)))
+	// Tolerate some clock skew between the nodes.
+	require.NoError(t, ValidateLoginToken(key, alice, token, now.Add(-time.Second)))
+
+	for _, tc := range []struct {
+		name  string
+		key   []byte
+		user  SQLUsername
+		token string
+		now   time.Time
+		err   string
+	}{
+		{"expired", key, alice, token, now.Add(time.Hour), "expiration time is in the past"},
+		{"future", key, alice, token, now.Add(-time.Hour), "issue time is in the future"},
+		{"wrong user", key, MakeSQLUsernameFromPreNormalizedString("bob"), token, now, "wrong user"},
+		{"wrong key", []byte(strings.Repeat("x", loginTokenSigningKeySize)), alice, token, now, "invalid signature"},
+		{"tampered", key, alice, token[:len(token)-2] + "AA", now, "invalid signature"},
+		{"password", key, alice, "hunter2", now, "not a login token"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateLoginToken(tc.key, tc.user, tc.token, tc.now)
+			require.True(t, errors.Is(err, ErrInvalidLoginToken), "%v", err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+
+	for _, lifetime := range []time.Duration{0, -time.Minute, MaxLoginTokenLifetime + time.Second} {
+		_, err := CreateLoginToken(key, alice, now, lifetime)
+		require.Error(t, err)
+	}
+}
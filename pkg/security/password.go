@@ -21,16 +21,12 @@ import (
 	"io"
 	"math"
 	"regexp"
-	"runtime"
 	"strconv"
-	"sync"
 	"unsafe"
 
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/settings"
-	"github.com/cockroachdb/cockroach/pkg/util/envutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
-	"github.com/cockroachdb/cockroach/pkg/util/quotapool"
 	"github.com/cockroachdb/errors"
 	"github.com/xdg-go/scram"
 	"github.com/xdg-go/stringprep"
@@ -57,12 +53,15 @@ var BcryptCost = settings.RegisterIntSetting(
 	// It incurs a password check latency of ~60ms on AMD 3950X 3.7GHz.
 	// For reference, value 11 incurs ~110ms latency on the same hw, value 12 incurs ~390ms.
 	10,
-	func(i int64) error {
-		if i < int64(bcrypt.MinCost) || i > int64(bcrypt.MaxCost) {
-			return bcrypt.InvalidCostError(int(i))
-		}
-		return nil
-	}).WithPublic()
+	validateBcryptCost,
+).WithPublic()
+
+func validateBcryptCost(i int64) error {
+	if i < int64(bcrypt.MinCost) || i > int64(bcrypt.MaxCost) {
+		return bcrypt.InvalidCostError(int(i))
+	}
+	return nil
+}
 
 // BcryptCostSettingName is the name of the cluster setting BcryptCost.
 const BcryptCostSettingName = "server.user_login.password_hashes.default_cost.crdb_bcrypt"
@@ -88,12 +87,15 @@ var SCRAMCost = settings.RegisterIntSetting(
 	// For reference, value 250000 incurs ~125ms latency on the same hw,
 	// value 1000000 incurs ~500ms.
 	119680,
-	func(i int64) error {
-		if i < scramMinCost || i > scramMaxCost {
-			return errors.Newf("cost not in allowed range (%d,%d)", scramMinCost, scramMaxCost)
-		}
-		return nil
-	}).WithPublic()
+	validateSCRAMCost,
+).WithPublic()
+
+func validateSCRAMCost(i int64) error {
+	if i < scramMinCost || i > scramMaxCost {
+		return errors.Newf("cost not in allowed range (%d,%d)", scramMinCost, scramMaxCost)
+	}
+	return nil
+}
 
 const scramMinCost = 4096         // as per RFC 5802.
 const scramMaxCost = 240000000000 // arbitrary value to prevent unreasonably long logins
@@ -270,6 +272,24 @@ func LoadPasswordHash(ctx context.Context, storedHash []byte) (res PasswordHash)
 	return res
 }
 
+// PreHashedPassword returns the pre-hashed form of the password hash,
+// which can be provided as the password of a user to store the same hash
+// again, for example in another cluster. It returns false if the hash
+// cannot be provided pre-hashed.
+func PreHashedPassword(hash PasswordHash) (string, bool) {
+	switch h := hash.(type) {
+	case bcryptHash:
+		if bytes.HasPrefix(h, []byte(crdbBcryptPrefix)) {
+			return string(h), true
+		}
+		return crdbBcryptPrefix + string(h), true
+	case *scramHash:
+		return string(h.bytes), true
+	default:
+		return "", false
+	}
+}
+
 var sha256NewSum = sha256.New().Sum(nil)
 
 // TODO(mjibson): properly apply SHA-256 to the password. The current code
@@ -301,15 +321,13 @@ func CompareHashAndCleartextPassword(
 func (b bcryptHash) compareWithCleartextPassword(
 	ctx context.Context, cleartext string,
 ) (ok bool, err error) {
-	sem := getExpensiveHashComputeSem(ctx)
-	alloc, err := sem.Acquire(ctx, 1)
-	if err != nil {
+	var cmpErr error
+	if err := runExpensiveHashCompute(ctx, func() {
+		cmpErr = bcrypt.CompareHashAndPassword([]byte(b), appendEmptySha256(cleartext))
+	}); err != nil {
 		return false, err
 	}
-	defer alloc.Release()
-
-	err = bcrypt.CompareHashAndPassword([]byte(b), appendEmptySha256(cleartext))
-	if err != nil {
+	if err := cmpErr; err != nil {
 		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
 			return false, nil
 		}
@@ -322,13 +340,6 @@ func (b bcryptHash) compareWithCleartextPassword(
 func (s *scramHash) compareWithCleartextPassword(
 	ctx context.Context, cleartext string,
 ) (ok bool, err error) {
-	sem := getExpensiveHashComputeSem(ctx)
-	alloc, err := sem.Acquire(ctx, 1)
-	if err != nil {
-		return false, err
-	}
-	defer alloc.Release()
-
 	// Server-side verification of a plaintext password
 	// against a pre-computed stored SCRAM server key.
 	//
@@ -346,7 +357,12 @@ func (s *scramHash) compareWithCleartextPassword(
 		prepared = cleartext
 	}
 
-	saltedPassword := pbkdf2.Key([]byte(prepared), []byte(s.decoded.Salt), s.decoded.Iters, sha256.Size, sha256.New)
+	var saltedPassword []byte
+	if err := runExpensiveHashCompute(ctx, func() {
+		saltedPassword = pbkdf2.Key([]byte(prepared), []byte(s.decoded.Salt), s.decoded.Iters, sha256.Size, sha256.New)
+	}); err != nil {
+		return false, err
+	}
 	// As per xdg-go/scram and pg's scram_ServerKey().
 	// Note: the string "Server Key" is part of the SCRAM algorithm,
 	// see IETF RFC 5802.
@@ -409,20 +425,41 @@ func GetConfiguredPasswordHashMethod(ctx context.Context, sv *settings.Values) (
 // HashPassword takes a raw password and returns a hashed password, hashed
 // using the currently configured method.
 func HashPassword(ctx context.Context, sv *settings.Values, password string) ([]byte, error) {
+	return HashPasswordWithCost(ctx, sv, password, 0 /* cost */)
+}
+
+// HashPasswordWithCost is like HashPassword, but uses the given hashing
+// cost instead of the default cost of the configured method, unless the
+// cost is zero. The cost is interpreted like the default cost cluster
+// setting of the method, that is as the bcrypt cost for crdb-bcrypt
+// and as the iteration count for scram-sha-256.
+func HashPasswordWithCost(
+	ctx context.Context, sv *settings.Values, password string, cost int64,
+) ([]byte, error) {
 	method := GetConfiguredPasswordHashMethod(ctx, sv)
 	switch method {
 	case HashBCrypt:
-		sem := getExpensiveHashComputeSem(ctx)
-		alloc, err := sem.Acquire(ctx, 1)
-		if err != nil {
+		if cost == 0 {
+			cost = BcryptCost.Get(sv)
+		} else if err := validateBcryptCost(cost); err != nil {
+			return nil, errors.Wrapf(err, "invalid %s cost", method)
+		}
+		var hashed []byte
+		var hashErr error
+		if err := runExpensiveHashCompute(ctx, func() {
+			hashed, hashErr = bcrypt.GenerateFromPassword(appendEmptySha256(password), int(cost))
+		}); err != nil {
 			return nil, err
 		}
-		defer alloc.Release()
-		return bcrypt.GenerateFromPassword(appendEmptySha256(password), int(BcryptCost.Get(sv)))
+		return hashed, hashErr
 
 	case HashSCRAMSHA256:
-		cost := int(SCRAMCost.Get(sv))
-		return hashPasswordUsingSCRAM(ctx, cost, password)
+		if cost == 0 {
+			cost = SCRAMCost.Get(sv)
+		} else if err := validateSCRAMCost(cost); err != nil {
+			return nil, errors.Wrapf(err, "invalid %s cost", method)
+		}
+		return hashPasswordUsingSCRAM(ctx, int(cost), password)
 
 	default:
 		return nil, errors.Newf("unsupported hash method: %v", method)
@@ -457,15 +494,15 @@ func hashPasswordUsingSCRAM(ctx context.Context, cost int, cleartext string) ([]
 	}
 
 	// The computation of the SCRAM hash is expensive. Use the shared
-	// semaphore for it. We reuse the same pattern as the bcrypt case above.
-	sem := getExpensiveHashComputeSem(ctx)
-	alloc, err := sem.Acquire(ctx, 1)
-	if err != nil {
+	// hashing workers for it. We reuse the same pattern as the bcrypt
+	// case above.
+	var creds scram.StoredCredentials
+	if err := runExpensiveHashCompute(ctx, func() {
+		// Compute the credentials.
+		creds = client.GetStoredCredentials(scram.KeyFactors{Iters: cost, Salt: string(salt)})
+	}); err != nil {
 		return nil, err
 	}
-	defer alloc.Release()
-	// Compute the credentials.
-	creds := client.GetStoredCredentials(scram.KeyFactors{Iters: cost, Salt: string(salt)})
 	// Encode them in our standard hash format.
 	return encodeScramHash(salt, creds), nil
 }
@@ -686,49 +723,6 @@ var MinPasswordLength = settings.RegisterIntSetting(
 	settings.NonNegativeInt,
 ).WithPublic()
 
-// expensiveHashComputeSemOnce wraps a semaphore that limits the
-// number of concurrent calls to the bcrypt and sha256 hash
-// functions. This is needed to avoid the risk of a DoS attacks by
-// malicious users or broken client apps that would starve the server
-// of CPU resources just by computing hashes.
-//
-// We use a sync.Once to delay the creation of the semaphore to the
-// first time the password functions are used. This gives a chance to
-// the server process to update GOMAXPROCS before we compute the
-// maximum amount of concurrency for the semaphore.
-var expensiveHashComputeSemOnce struct {
-	sem  *quotapool.IntPool
-	once sync.Once
-}
-
-// envMaxHashComputeConcurrency allows a user to override the semaphore
-// configuration using an environment variable.
-// If the env var is set to a value >= 1, that value is used.
-// Otherwise, a default is computed from the configure GOMAXPROCS.
-var envMaxHashComputeConcurrency = envutil.EnvOrDefaultInt("COCKROACH_MAX_PW_HASH_COMPUTE_CONCURRENCY", 0)
-
-// getExpensiveHashComputeSem retrieves the hashing semaphore.
-func getExpensiveHashComputeSem(ctx context.Context) *quotapool.IntPool {
-	expensiveHashComputeSemOnce.once.Do(func() {
-		var n int
-		if envMaxHashComputeConcurrency >= 1 {
-			// The operator knows better. Use what they tell us to use.
-			n = envMaxHashComputeConcurrency
-		} else {
-			// We divide by 8 so that the max CPU usage of hash checks
-			// never exceeds ~10% of total CPU resources allocated to this
-			// process.
-			n = runtime.GOMAXPROCS(-1) / 8
-		}
-		if n < 1 {
-			n = 1
-		}
-		log.VInfof(ctx, 1, "configured maximum hashing concurrency: %d", n)
-		expensiveHashComputeSemOnce.sem = quotapool.NewIntPool("password_hashes", uint64(n))
-	})
-	return expensiveHashComputeSemOnce.sem
-}
-
 // bcryptCostToSCRAMIterCount maps the bcrypt cost in a pre-hashed
 // password using the crdb-bcrypt method to an “equivalent” cost
 // (iteration count) for the scram-sha-256 method. This is used to
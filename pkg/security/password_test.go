@@ -72,3 +72,73 @@ func TestBCryptToSCRAMConversion(t *testing.T) {
 		})
 	}
 }
+
+func TestHashPasswordWithCost(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	s := cluster.MakeTestingClusterSettings()
+
+	const cleartext = "hello"
+
+	PasswordHashMethod.Override(ctx, &s.SV, int64(HashBCrypt))
+	for _, tc := range []struct {
+		cost, expected int
+	}{
+		{0, int(BcryptCost.Get(&s.SV))},
+		{bcrypt.MinCost, bcrypt.MinCost},
+	} {
+		raw, err := HashPasswordWithCost(ctx, &s.SV, cleartext, int64(tc.cost))
+		require.NoError(t, err)
+		cost, err := bcrypt.Cost(raw)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, cost)
+	}
+	_, err := HashPasswordWithCost(ctx, &s.SV, cleartext, int64(bcrypt.MaxCost+1))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid crdb-bcrypt cost")
+
+	PasswordHashMethod.Override(ctx, &s.SV, int64(HashSCRAMSHA256))
+	for _, tc := range []struct {
+		cost, expected int
+	}{
+		{0, int(SCRAMCost.Get(&s.SV))},
+		{scramMinCost, scramMinCost},
+	} {
+		raw, err := HashPasswordWithCost(ctx, &s.SV, cleartext, int64(tc.cost))
+		require.NoError(t, err)
+		ok, creds := GetSCRAMStoredCredentials(LoadPasswordHash(ctx, raw))
+		require.True(t, ok)
+		require.Equal(t, tc.expected, creds.Iters)
+	}
+	_, err = HashPasswordWithCost(ctx, &s.SV, cleartext, scramMinCost-1)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid scram-sha-256 cost")
+}
+
+func TestPreHashedPassword(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	s := cluster.MakeTestingClusterSettings()
+
+	const cleartext = "hello"
+
+	for _, method := range []HashMethod{HashBCrypt, HashSCRAMSHA256} {
+		t.Run(method.String(), func(t *testing.T) {
+			PasswordHashMethod.Override(ctx, &s.SV, int64(method))
+			raw, err := HashPasswordWithCost(ctx, &s.SV, cleartext, 0 /* cost */)
+			require.NoError(t, err)
+
+			// The pre-hashed password is stored as the same hash.
+			preHashed, ok := PreHashedPassword(LoadPasswordHash(ctx, raw))
+			require.True(t, ok)
+			isPreHashed, supported, _, _, hashed, err := CheckPasswordHashValidity(ctx, []byte(preHashed))
+			require.NoError(t, err)
+			require.True(t, isPreHashed)
+			require.True(t, supported)
+			require.Equal(t, raw, hashed)
+		})
+	}
+
+	_, ok := PreHashedPassword(MissingPasswordHash)
+	require.False(t, ok)
+}
@@ -0,0 +1,186 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/envutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// The expensive password hash computations, i.e. bcrypt and the
+// iterations of SCRAM, run on a fixed pool of worker goroutines
+// rather than on the goroutines of the connections which request them.
+// This bounds the CPU used to compute hashes, so that a burst of logins
+// or of password changes, possibly with high hashing costs, cannot
+// starve the rest of the process, e.g. the established sessions. This
+// also avoids the risk of DoS attacks by malicious users or broken
+// client apps that would starve the server of CPU resources just by
+// computing hashes.
+//
+// The computations wait in a bounded queue for a worker to become
+// available. When the queue is full, new computations are rejected
+// right away rather than piling up.
+
+// envMaxHashComputeConcurrency allows a user to override the number of
+// hashing workers using an environment variable.
+// If the env var is set to a value >= 1, that value is used.
+// Otherwise, a default is computed from the configure GOMAXPROCS.
+var envMaxHashComputeConcurrency = envutil.EnvOrDefaultInt("COCKROACH_MAX_PW_HASH_COMPUTE_CONCURRENCY", 0)
+
+// envMaxHashComputeQueueLength allows a user to override the number of
+// hash computations which can wait for a worker using an environment
+// variable. If the env var is set to a value >= 1, that value is used.
+// Otherwise, a default is computed from the number of workers.
+var envMaxHashComputeQueueLength = envutil.EnvOrDefaultInt("COCKROACH_MAX_PW_HASH_COMPUTE_QUEUE_LENGTH", 0)
+
+// defaultHashComputeQueueLengthPerWorker is the default number of hash
+// computations which can wait for each worker.
+const defaultHashComputeQueueLengthPerWorker = 128
+
+// ErrHashComputeOverloaded is returned when a password hash computation
+// is rejected because too many computations are already waiting.
+var ErrHashComputeOverloaded = errors.New("too many concurrent password hash computations")
+
+// hashComputeOverloadedLogEvery rate-limits the logging of the rejected
+// hash computations.
+var hashComputeOverloadedLogEvery = log.Every(10 * time.Second)
+
+// The states of a hashTask.
+const (
+	hashTaskQueued int32 = iota
+	hashTaskRunning
+	hashTaskCanceled
+)
+
+// hashTask is a hash computation queued for the workers.
+type hashTask struct {
+	fn func()
+	// state is accessed atomically, so that a task is either run by a
+	// worker or canceled by its requester, but not both.
+	state int32
+	// done is closed once the task has run.
+	done chan struct{}
+	// started is the time at which a worker started the task. It is
+	// set before done is closed.
+	started time.Time
+}
+
+// hashWorkerPool is a pool of hashing workers.
+type hashWorkerPool struct {
+	tasks chan *hashTask
+}
+
+// newHashWorkerPool starts the given number of hashing workers. They
+// run until the pool is closed.
+func newHashWorkerPool(workers, queueLength int) *hashWorkerPool {
+	p := &hashWorkerPool{tasks: make(chan *hashTask, queueLength)}
+	for i := 0; i < workers; i++ {
+		go p.runWorker()
+	}
+	return p
+}
+
+// close stops the workers once the queued computations have run.
+func (p *hashWorkerPool) close() {
+	close(p.tasks)
+}
+
+// runWorker runs the hash computations of the queue.
+func (p *hashWorkerPool) runWorker() {
+	for t := range p.tasks {
+		if !atomic.CompareAndSwapInt32(&t.state, hashTaskQueued, hashTaskRunning) {
+			// The requester gave up waiting.
+			continue
+		}
+		t.started = timeutil.Now()
+		t.fn()
+		close(t.done)
+	}
+}
+
+// run runs the given hash computation on the workers, and waits for it
+// to complete. An error is returned if the computation is rejected
+// because the queue is full, or if the context is canceled before the
+// computation starts, in which case it does not run.
+func (p *hashWorkerPool) run(ctx context.Context, fn func()) error {
+	t := &hashTask{fn: fn, done: make(chan struct{})}
+	queued := timeutil.Now()
+	select {
+	case p.tasks <- t:
+	default:
+		if hashComputeOverloadedLogEvery.ShouldLog() {
+			log.Ops.Warningf(ctx, "rejecting password hash computation: %v", ErrHashComputeOverloaded)
+		}
+		return ErrHashComputeOverloaded
+	}
+	select {
+	case <-t.done:
+		log.VEventf(ctx, 2, "password hash computed in %s, after waiting %s for a worker",
+			timeutil.Since(t.started), t.started.Sub(queued))
+		return nil
+	case <-ctx.Done():
+		if atomic.CompareAndSwapInt32(&t.state, hashTaskQueued, hashTaskCanceled) {
+			return ctx.Err()
+		}
+		// The computation already started. It does not observe the
+		// context, so wait for it to complete.
+		<-t.done
+		return nil
+	}
+}
+
+// hashWorkers holds the pool of hashing workers of the process.
+//
+// We use a sync.Once to delay the creation of the workers to the first
+// time the password functions are used. This gives a chance to the
+// server process to update GOMAXPROCS before we compute the number of
+// workers.
+var hashWorkers struct {
+	pool *hashWorkerPool
+	once sync.Once
+}
+
+// runExpensiveHashCompute runs the given hash computation on the
+// hashing workers of the process, starting them on the first call. See
+// hashWorkerPool.run.
+func runExpensiveHashCompute(ctx context.Context, fn func()) error {
+	hashWorkers.once.Do(func() {
+		var n int
+		if envMaxHashComputeConcurrency >= 1 {
+			// The operator knows better. Use what they tell us to use.
+			n = envMaxHashComputeConcurrency
+		} else {
+			// We divide by 8 so that the max CPU usage of hash checks
+			// never exceeds ~10% of total CPU resources allocated to this
+			// process.
+			n = runtime.GOMAXPROCS(-1) / 8
+		}
+		if n < 1 {
+			n = 1
+		}
+		queueLength := envMaxHashComputeQueueLength
+		if queueLength < 1 {
+			queueLength = n * defaultHashComputeQueueLengthPerWorker
+		}
+		log.VInfof(ctx, 1, "configured maximum hashing concurrency: %d, queue length: %d", n, queueLength)
+		// The workers of the process are never stopped.
+		hashWorkers.pool = newHashWorkerPool(n, queueLength)
+	})
+	return hashWorkers.pool.run(ctx, fn)
+}
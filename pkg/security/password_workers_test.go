@@ -0,0 +1,68 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashWorkerPool(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+
+	p := newHashWorkerPool(1 /* workers */, 1 /* queueLength */)
+	defer p.close()
+
+	ran := false
+	require.NoError(t, p.run(ctx, func() { ran = true }))
+	require.True(t, ran)
+
+	// Occupy the worker.
+	started, unblock := make(chan struct{}), make(chan struct{})
+	blockedErr := make(chan error, 1)
+	go func() {
+		blockedErr <- p.run(ctx, func() {
+			close(started)
+			<-unblock
+		})
+	}()
+	<-started
+
+	// A computation can wait in the queue, until its context is
+	// canceled, in which case it does not run.
+	cancelCtx, cancel := context.WithCancel(ctx)
+	queuedErr := make(chan error, 1)
+	canceledRan := false
+	go func() {
+		queuedErr <- p.run(cancelCtx, func() { canceledRan = true })
+	}()
+	// Once the queue is full, the computations are rejected.
+	require.Eventually(t, func() bool {
+		return len(p.tasks) == cap(p.tasks)
+	}, 10*time.Second, time.Millisecond)
+	require.ErrorIs(t, p.run(ctx, func() {}), ErrHashComputeOverloaded)
+
+	cancel()
+	require.ErrorIs(t, <-queuedErr, context.Canceled)
+	close(unblock)
+	require.NoError(t, <-blockedErr)
+
+	// The canceled computation is skipped by the worker.
+	ran = false
+	require.NoError(t, p.run(ctx, func() { ran = true }))
+	require.True(t, ran)
+	require.False(t, canceledRan)
+}
@@ -0,0 +1,304 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/envutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// Secrets, such as certificates, keys and the passwords used by the HBA
+// authentication methods, can be loaded from external secret stores
+// instead of local files. A secret is referenced either by a local path
+// or by a URL whose scheme selects the SecretLoader, for example:
+//
+//   /etc/crdb/radius-secret
+//   vault://vault.example.com:8200/secret/data/crdb?field=radius
+//
+// The "file" and "vault" schemes are built in. Other packages register
+// additional schemes with RegisterSecretLoader, e.g. to decrypt secrets
+// using a cloud KMS.
+
+// SecretRefreshInterval is the interval after which the secrets loaded
+// from external secret stores are fetched again.
+var SecretRefreshInterval = envutil.EnvOrDefaultDuration(
+	"COCKROACH_SECRET_REFRESH_INTERVAL", 5*time.Minute)
+
+// SecretLoader loads secrets from a secret store.
+type SecretLoader interface {
+	// LoadSecret returns the secret referenced by the given URL.
+	LoadSecret(ctx context.Context, u *url.URL) ([]byte, error)
+}
+
+// SecretLoaderFunc is a SecretLoader implemented as a function.
+type SecretLoaderFunc func(ctx context.Context, u *url.URL) ([]byte, error)
+
+// LoadSecret implements the SecretLoader interface.
+func (f SecretLoaderFunc) LoadSecret(ctx context.Context, u *url.URL) ([]byte, error) {
+	return f(ctx, u)
+}
+
+var secretLoaders = map[string]SecretLoader{
+	"file":  SecretLoaderFunc(loadFileSecret),
+	"vault": &vaultSecretLoader{},
+}
+
+// RegisterSecretLoader registers the SecretLoader for the secret
+// references using the given URL scheme. It is meant to be called from
+// init functions.
+func RegisterSecretLoader(scheme string, loader SecretLoader) {
+	if _, ok := secretLoaders[scheme]; ok {
+		panic(errors.AssertionFailedf("secret loader for scheme %q already registered", scheme))
+	}
+	secretLoaders[scheme] = loader
+}
+
+// parseSecretRef parses a secret reference. References without a
+// scheme are local paths.
+func parseSecretRef(ref string) (*url.URL, error) {
+	if !strings.Contains(ref, "://") {
+		return &url.URL{Scheme: "file", Path: ref}, nil
+	}
+	u, err := url.Parse(ref)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid secret reference")
+	}
+	if _, ok := secretLoaders[u.Scheme]; !ok {
+		return nil, errors.Newf("unsupported secret reference scheme %q", u.Scheme)
+	}
+	return u, nil
+}
+
+// ValidateSecretRef returns an error if the given secret reference
+// cannot be loaded by any registered SecretLoader.
+func ValidateSecretRef(ref string) error {
+	_, err := parseSecretRef(ref)
+	return err
+}
+
+// LoadSecret loads the secret referenced by ref, a local path or the
+// URL of a secret in an external secret store.
+//
+// Local files are read on every call, but the secrets loaded from
+// external secret stores are cached for SecretRefreshInterval, so that
+// LoadSecret can be called on every authentication attempt. If a secret
+// cannot be refreshed, the previous value is used until it can.
+func LoadSecret(ctx context.Context, ref string) ([]byte, error) {
+	u, err := parseSecretRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "file" {
+		return loadFileSecret(ctx, u)
+	}
+	return secretCache.get(ctx, ref, u)
+}
+
+// loadSecretUncached is like LoadSecret, but always fetches the secret.
+func loadSecretUncached(ctx context.Context, ref string) ([]byte, error) {
+	u, err := parseSecretRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	return secretLoaders[u.Scheme].LoadSecret(ctx, u)
+}
+
+type cachedSecret struct {
+	value     []byte
+	fetchedAt time.Time
+}
+
+// secretCacheMap caches the secrets loaded from external secret stores.
+type secretCacheMap struct {
+	syncutil.Mutex
+	secrets map[string]cachedSecret
+}
+
+var secretCache = secretCacheMap{secrets: make(map[string]cachedSecret)}
+
+func (c *secretCacheMap) get(ctx context.Context, ref string, u *url.URL) ([]byte, error) {
+	c.Lock()
+	cached, ok := c.secrets[ref]
+	c.Unlock()
+	if ok && timeutil.Since(cached.fetchedAt) < SecretRefreshInterval {
+		return cached.value, nil
+	}
+	value, err := secretLoaders[u.Scheme].LoadSecret(ctx, u)
+	if err != nil {
+		if !ok {
+			return nil, err
+		}
+		log.Ops.Warningf(ctx, "could not refresh secret %s, using the previous value: %v",
+			redactSecretRef(u), err)
+		return cached.value, nil
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.secrets[ref] = cachedSecret{value: value, fetchedAt: timeutil.Now()}
+	return value, nil
+}
+
+// redactSecretRef returns the secret reference without its query
+// parameters and user information, for logging.
+func redactSecretRef(u *url.URL) string {
+	r := *u
+	r.User = nil
+	r.RawQuery = ""
+	return r.String()
+}
+
+// loadFileSecret reads a secret from a local file. The trailing newline
+// characters, if any, are not part of the secret.
+func loadFileSecret(_ context.Context, u *url.URL) ([]byte, error) {
+	if u.Host != "" {
+		return nil, errors.Newf("invalid file secret reference: unexpected host %q", u.Host)
+	}
+	contents, err := ioutil.ReadFile(u.Path)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(strings.TrimRight(string(contents), "\r\n")), nil
+}
+
+// vaultSecretLoader loads secrets from the KV secrets engine of
+// HashiCorp Vault, using URLs of the form:
+//
+//   vault://<host>:<port>/<path>?field=<field>&token_file=<path>
+//
+// The secret is read from https://<host>:<port>/v1/<path>, so <path>
+// includes the mount point of the secrets engine and, for version 2 of
+// the KV engine, the "data" segment, e.g. secret/data/crdb. The field
+// parameter selects the field of the secret to use (default "value").
+//
+// The Vault token is read from the file named by the token_file
+// parameter if set, or from the VAULT_TOKEN environment variable
+// otherwise. The VAULT_NAMESPACE and VAULT_CACERT environment variables
+// are also honored.
+type vaultSecretLoader struct {
+	// client, if set, is used instead of an HTTP client configured from
+	// the environment. Used in tests.
+	client *http.Client
+}
+
+func (l *vaultSecretLoader) LoadSecret(ctx context.Context, u *url.URL) ([]byte, error) {
+	if u.Host == "" {
+		return nil, errors.New("invalid vault secret reference: missing host")
+	}
+	secretPath := strings.Trim(u.Path, "/")
+	if secretPath == "" {
+		return nil, errors.New("invalid vault secret reference: missing secret path")
+	}
+	params := u.Query()
+	field := params.Get("field")
+	if field == "" {
+		field = "value"
+	}
+	token := os.Getenv("VAULT_TOKEN")
+	if tokenFile := params.Get("token_file"); tokenFile != "" {
+		contents, err := ioutil.ReadFile(tokenFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "reading the vault token")
+		}
+		token = strings.TrimSpace(string(contents))
+	}
+	if token == "" {
+		return nil, errors.WithHint(errors.New("no vault token"),
+			"Set the VAULT_TOKEN environment variable or the token_file parameter.")
+	}
+
+	client := l.client
+	if client == nil {
+		var err error
+		if client, err = makeVaultClient(); err != nil {
+			return nil, err
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		"https://"+u.Host+path.Join("/v1", secretPath), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if ns := os.Getenv("VAULT_NAMESPACE"); ns != "" {
+		req.Header.Set("X-Vault-Namespace", ns)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading secret from vault")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Newf("reading secret %s from vault: %s", secretPath, resp.Status)
+	}
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, errors.Wrap(err, "decoding vault response")
+	}
+	data := body.Data
+	// Version 2 of the KV engine nests the fields of the secret next to
+	// its metadata.
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+	value, ok := data[field]
+	if !ok {
+		return nil, errors.Newf("vault secret %s has no field %q", secretPath, field)
+	}
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.Newf("field %q of vault secret %s is not a string", field, secretPath)
+	}
+	return []byte(s), nil
+}
+
+// makeVaultClient returns an HTTP client to connect to Vault, trusting
+// the CA certificates in the file named by VAULT_CACERT in addition to
+// the system roots.
+func makeVaultClient() (*http.Client, error) {
+	caFile := os.Getenv("VAULT_CACERT")
+	if caFile == "" {
+		return &http.Client{Timeout: 30 * time.Second}, nil
+	}
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	pem, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading VAULT_CACERT")
+	}
+	if !roots.AppendCertsFromPEM(pem) {
+		return nil, errors.Newf("no certificates found in %s", caFile)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: roots}
+	return &http.Client{Transport: transport, Timeout: 30 * time.Second}, nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFileSecret(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, ioutil.WriteFile(path, []byte("s3cr3t\r\n"), 0600))
+
+	for _, ref := range []string{path, "file://" + path} {
+		secret, err := LoadSecret(ctx, ref)
+		require.NoError(t, err)
+		require.Equal(t, "s3cr3t", string(secret))
+	}
+
+	_, err := LoadSecret(ctx, "file://host"+path)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unexpected host")
+
+	_, err = LoadSecret(ctx, "foo://bar")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `unsupported secret reference scheme "foo"`)
+}
+
+func TestVaultSecretLoader(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/crdb":
+			// KV version 2.
+			_, _ = w.Write([]byte(`{"data": {"data": {"value": "v2", "ldap": "ldappw"}, "metadata": {"version": 3}}}`))
+		case "/v1/kv/crdb":
+			// KV version 1.
+			_, _ = w.Write([]byte(`{"data": {"value": "v1", "port": 1812}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "https://")
+	loader := &vaultSecretLoader{client: srv.Client()}
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, ioutil.WriteFile(tokenFile, []byte("root-token\n"), 0600))
+	load := func(path string, params string) (string, error) {
+		u, err := url.Parse("vault://" + host + path + "?token_file=" + tokenFile + params)
+		require.NoError(t, err)
+		secret, err := loader.LoadSecret(ctx, u)
+		return string(secret), err
+	}
+
+	secret, err := load("/secret/data/crdb", "")
+	require.NoError(t, err)
+	require.Equal(t, "v2", secret)
+	secret, err = load("/secret/data/crdb", "&field=ldap")
+	require.NoError(t, err)
+	require.Equal(t, "ldappw", secret)
+	secret, err = load("/kv/crdb", "")
+	require.NoError(t, err)
+	require.Equal(t, "v1", secret)
+
+	for _, tc := range []struct {
+		path, params, err string
+	}{
+		{"/secret/data/crdb", "&field=missing", `has no field "missing"`},
+		{"/kv/crdb", "&field=port", "is not a string"},
+		{"/secret/data/other", "", "404 Not Found"},
+		{"/", "", "missing secret path"},
+	} {
+		_, err := load(tc.path, tc.params)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), tc.err)
+	}
+
+	// Without a token.
+	u, err := url.Parse("vault://" + host + "/secret/data/crdb")
+	require.NoError(t, err)
+	_, err = loader.LoadSecret(ctx, u)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no vault token")
+}
+
+func TestSecretCache(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	calls := 0
+	fail := false
+	secretLoaders["test-cache"] = SecretLoaderFunc(func(context.Context, *url.URL) ([]byte, error) {
+		calls++
+		if fail {
+			return nil, errors.New("unavailable")
+		}
+		return []byte(strconv.Itoa(calls)), nil
+	})
+	defer delete(secretLoaders, "test-cache")
+
+	defer func(d time.Duration) { SecretRefreshInterval = d }(SecretRefreshInterval)
+	defer func() {
+		secretCache.Lock()
+		defer secretCache.Unlock()
+		delete(secretCache.secrets, "test-cache://a")
+	}()
+
+	// The secret is cached.
+	secret, err := LoadSecret(ctx, "test-cache://a")
+	require.NoError(t, err)
+	require.Equal(t, "1", string(secret))
+	secret, err = LoadSecret(ctx, "test-cache://a")
+	require.NoError(t, err)
+	require.Equal(t, "1", string(secret))
+	require.Equal(t, 1, calls)
+
+	// The secret is refreshed once the refresh interval elapsed, and the
+	// previous value is used if it cannot be refreshed.
+	SecretRefreshInterval = 0
+	secret, err = LoadSecret(ctx, "test-cache://a")
+	require.NoError(t, err)
+	require.Equal(t, "2", string(secret))
+	fail = true
+	secret, err = LoadSecret(ctx, "test-cache://a")
+	require.NoError(t, err)
+	require.Equal(t, "2", string(secret))
+
+	// Secrets which were never loaded fail.
+	_, err = LoadSecret(ctx, "test-cache://b")
+	require.Error(t, err)
+}
@@ -31,6 +31,7 @@ type TLSSettings interface {
 	ocspEnabled() bool
 	ocspStrict() bool
 	ocspTimeout() time.Duration
+	clientCARotation() stagedClientCA
 }
 
 var ocspMode = settings.RegisterEnumSetting(
@@ -68,6 +69,17 @@ func (c clusterTLSSettings) ocspTimeout() time.Duration {
 	return ocspTimeout.Get(&c.settings.SV)
 }
 
+func (c clusterTLSSettings) clientCARotation() stagedClientCA {
+	phase := clientCARotationPhase.Get(&c.settings.SV)
+	if phase == clientCARotationOff {
+		return stagedClientCA{}
+	}
+	return stagedClientCA{
+		bundle: clientCAStagedBundle.Get(&c.settings.SV),
+		active: phase == clientCARotationActive,
+	}
+}
+
 // ClusterTLSSettings creates a TLSSettings backed by the
 // given cluster settings.
 func ClusterTLSSettings(settings *cluster.Settings) TLSSettings {
@@ -75,7 +87,8 @@ func ClusterTLSSettings(settings *cluster.Settings) TLSSettings {
 }
 
 // CommandTLSSettings defines the TLS settings for command-line tools.
-// OCSP is not currently supported in this mode.
+// OCSP and the rotation of the client CA are not currently supported in
+// this mode.
 type CommandTLSSettings struct{}
 
 var _ TLSSettings = CommandTLSSettings{}
@@ -91,3 +104,7 @@ func (CommandTLSSettings) ocspStrict() bool {
 func (CommandTLSSettings) ocspTimeout() time.Duration {
 	return 0
 }
+
+func (CommandTLSSettings) clientCARotation() stagedClientCA {
+	return stagedClientCA{}
+}
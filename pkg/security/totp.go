@@ -0,0 +1,212 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Users can be required to present a time-based one-time password
+// (TOTP, RFC 6238) in addition to their primary credentials. The codes
+// have 6 digits and are derived from a secret shared with the
+// authenticator application of the user using HMAC-SHA1, with a
+// period of 30 seconds. This is the configuration supported by all
+// the common authenticator applications.
+//
+// The TOTP secrets are stored encrypted with AES-256-GCM, using a key
+// loaded from a secret reference (see LoadSecret).
+
+const (
+	// TOTPDigits is the number of digits of the TOTP codes.
+	TOTPDigits = 6
+	// TOTPPeriod is the validity period of a TOTP code.
+	TOTPPeriod = 30 * time.Second
+
+	// totpSecretSize is the size of the generated TOTP secrets, as
+	// recommended by RFC 4226.
+	totpSecretSize = 20
+	// totpSkew is the number of periods before and after the current
+	// one whose codes are accepted, to tolerate clock drift and the
+	// time needed to type the code.
+	totpSkew = 1
+	// totpEncryptionKeySize is the size of the keys used to encrypt the
+	// TOTP secrets.
+	totpEncryptionKeySize = 32
+)
+
+// ErrInvalidTOTPCode is returned when a TOTP code is not valid.
+var ErrInvalidTOTPCode = errors.New("invalid TOTP code")
+
+// GenerateTOTPSecret generates a new random TOTP secret.
+func GenerateTOTPSecret() ([]byte, error) {
+	secret := make([]byte, totpSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// TOTPProvisioningURI returns the otpauth:// URI that enrolls the given
+// secret in an authenticator application, usually by way of a QR code.
+func TOTPProvisioningURI(issuer string, user SQLUsername, secret []byte) string {
+	label := url.PathEscape(issuer + ":" + user.Normalized())
+	params := url.Values{}
+	params.Set("secret", base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secret))
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(TOTPDigits))
+	params.Set("period", fmt.Sprint(int(TOTPPeriod/time.Second)))
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// totpCounter returns the TOTP counter at the given time.
+func totpCounter(t time.Time) int64 {
+	return t.Unix() / int64(TOTPPeriod/time.Second)
+}
+
+// hotpCode computes the HOTP code (RFC 4226) for the given counter.
+func hotpCode(secret []byte, counter int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(counter))
+	mac := hmac.New(sha1.New, secret)
+	_, _ = mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0xf
+	value := binary.BigEndian.Uint32(sum[offset:]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", TOTPDigits, value%1000000)
+}
+
+// VerifyTOTPCode checks a TOTP code against the given secret at the
+// given time. To prevent the reuse of codes, the codes whose counter is
+// not greater than lastCounter are rejected. The counter of the code is
+// returned, and must be recorded by the caller as the next lastCounter.
+func VerifyTOTPCode(secret []byte, code string, now time.Time, lastCounter int64) (int64, error) {
+	if !IsTOTPCode(code) {
+		return 0, ErrInvalidTOTPCode
+	}
+	current := totpCounter(now)
+	for counter := current - totpSkew; counter <= current+totpSkew; counter++ {
+		if counter <= lastCounter {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(hotpCode(secret, counter)), []byte(code)) == 1 {
+			return counter, nil
+		}
+	}
+	return 0, ErrInvalidTOTPCode
+}
+
+// IsTOTPCode returns true if s has the format of a TOTP code.
+func IsTOTPCode(s string) bool {
+	if len(s) != TOTPDigits {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// SplitTOTPCode splits a password followed by a TOTP code, as entered
+// by the users who are required to present both at once. ok is false
+// if the password does not end with a TOTP code.
+func SplitTOTPCode(passwordAndCode string) (password, code string, ok bool) {
+	if len(passwordAndCode) <= TOTPDigits {
+		return passwordAndCode, "", false
+	}
+	i := len(passwordAndCode) - TOTPDigits
+	if !IsTOTPCode(passwordAndCode[i:]) {
+		return passwordAndCode, "", false
+	}
+	return passwordAndCode[:i], passwordAndCode[i:], true
+}
+
+// LoadTOTPEncryptionKey loads the key used to encrypt the TOTP secrets
+// from the given secret reference. The secret must contain a
+// base64-encoded 256-bit key.
+func LoadTOTPEncryptionKey(ctx context.Context, ref string) ([]byte, error) {
+	return loadKeyFromSecret(ctx, ref, "TOTP encryption key", totpEncryptionKeySize)
+}
+
+// loadKeyFromSecret loads a base64-encoded key of the given size from
+// a secret reference. desc describes the key in the errors.
+func loadKeyFromSecret(ctx context.Context, ref string, desc string, size int) ([]byte, error) {
+	if ref == "" {
+		return nil, errors.Newf("no %s is configured", desc)
+	}
+	encoded, err := LoadSecret(ctx, ref)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading the %s", desc)
+	}
+	key, err := base64.StdEncoding.DecodeString(string(encoded))
+	if err != nil {
+		return nil, errors.Wrapf(err, "decoding the %s", desc)
+	}
+	if len(key) != size {
+		return nil, errors.Newf("the %s must be %d bytes long, found %d", desc, size, len(key))
+	}
+	return key, nil
+}
+
+func newTOTPCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptTOTPSecret encrypts a TOTP secret with the given key.
+func EncryptTOTPSecret(key, secret []byte) ([]byte, error) {
+	gcm, err := newTOTPCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, secret, nil /* additionalData */), nil
+}
+
+// DecryptTOTPSecret decrypts a TOTP secret encrypted by
+// EncryptTOTPSecret.
+func DecryptTOTPSecret(key, ciphertext []byte) ([]byte, error) {
+	gcm, err := newTOTPCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("invalid encrypted TOTP secret")
+	}
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	secret, err := gcm.Open(nil, nonce, ciphertext, nil /* additionalData */)
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypting the TOTP secret")
+	}
+	return secret, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security
+
+import (
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyTOTPCode(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	// The SHA1 test vectors of RFC 6238, truncated to 6 digits.
+	secret := []byte("12345678901234567890")
+	for _, tc := range []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	} {
+		now := time.Unix(tc.unix, 0)
+		counter, err := VerifyTOTPCode(secret, tc.code, now, 0)
+		require.NoError(t, err)
+		require.Equal(t, tc.unix/30, counter)
+
+		// The codes of the adjacent periods are accepted.
+		_, err = VerifyTOTPCode(secret, tc.code, now.Add(TOTPPeriod), 0)
+		require.NoError(t, err)
+		_, err = VerifyTOTPCode(secret, tc.code, now.Add(-TOTPPeriod), 0)
+		require.NoError(t, err)
+		_, err = VerifyTOTPCode(secret, tc.code, now.Add(3*TOTPPeriod), 0)
+		require.Equal(t, ErrInvalidTOTPCode, err)
+
+		// A code cannot be used twice.
+		_, err = VerifyTOTPCode(secret, tc.code, now, counter)
+		require.Equal(t, ErrInvalidTOTPCode, err)
+	}
+
+	for _, code := range []string{"", "12345", "1234567", "28708a"} {
+		_, err := VerifyTOTPCode(secret, code, time.Unix(59, 0), 0)
+		require.Equal(t, ErrInvalidTOTPCode, err, code)
+	}
+}
+
+func TestSplitTOTPCode(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	for _, tc := range []struct {
+		input, password, code string
+		ok                    bool
+	}{
+		{"hunter2123456", "hunter2", "123456", true},
+		{"hunter2", "hunter2", "", false},
+		{"123456", "123456", "", false},
+		{"hunter2l23456", "hunter2l23456", "", false},
+	} {
+		password, code, ok := SplitTOTPCode(tc.input)
+		require.Equal(t, tc.password, password, tc.input)
+		require.Equal(t, tc.code, code, tc.input)
+		require.Equal(t, tc.ok, ok, tc.input)
+	}
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	uri := TOTPProvisioningURI("CockroachDB", MakeSQLUsernameFromPreNormalizedString("carl"), []byte("12345678901234567890"))
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+	require.Equal(t, "otpauth", u.Scheme)
+	require.Equal(t, "totp", u.Host)
+	require.Equal(t, "/CockroachDB:carl", u.Path)
+	require.Equal(t, "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", u.Query().Get("secret"))
+	require.Equal(t, "6", u.Query().Get("digits"))
+	require.Equal(t, "30", u.Query().Get("period"))
+}
+
+func TestTOTPSecretEncryption(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	keyFile := filepath.Join(t.TempDir(), "totp.key")
+	key := []byte(strings.Repeat("k", totpEncryptionKeySize))
+	require.NoError(t, ioutil.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0600))
+
+	loaded, err := LoadTOTPEncryptionKey(ctx, keyFile)
+	require.NoError(t, err)
+	require.Equal(t, key, loaded)
+
+	secret, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+	encrypted, err := EncryptTOTPSecret(key, secret)
+	require.NoError(t, err)
+	require.NotContains(t, string(encrypted), string(secret))
+	decrypted, err := DecryptTOTPSecret(key, encrypted)
+	require.NoError(t, err)
+	require.Equal(t, secret, decrypted)
+
+	otherKey := []byte(strings.Repeat("o", totpEncryptionKeySize))
+	_, err = DecryptTOTPSecret(otherKey, encrypted)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "decrypting the TOTP secret")
+
+	_, err = LoadTOTPEncryptionKey(ctx, "")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no TOTP encryption key is configured")
+	require.NoError(t, ioutil.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(key[:16])), 0600))
+	_, err = LoadTOTPEncryptionKey(ctx, keyFile)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "must be 32 bytes long")
+}
@@ -0,0 +1,326 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Users can log in to the DB Console with security keys, using the Web
+// Authentication API (WebAuthn, https://www.w3.org/TR/webauthn-2/).
+// This file implements the verification of the registration and
+// authentication ceremonies performed by the browser.
+//
+// The attestation statements of the security keys are not verified:
+// the security keys are trusted on first use, since they are registered
+// from an authenticated session.
+
+// WebAuthnChallengeSize is the size of the challenges sent to the
+// browser.
+const WebAuthnChallengeSize = 32
+
+// The COSE algorithms (RFC 8152) supported for the public keys of the
+// credentials, in order of preference.
+const (
+	COSEAlgES256 = -7
+	COSEAlgEdDSA = -8
+	COSEAlgRS256 = -257
+)
+
+// WebAuthnAlgorithms lists the supported COSE algorithms, in order of
+// preference.
+var WebAuthnAlgorithms = []int{COSEAlgES256, COSEAlgEdDSA, COSEAlgRS256}
+
+// The flags of the authenticator data.
+const (
+	webauthnFlagUserPresent  = 0x01
+	webauthnFlagAttestedData = 0x40
+)
+
+// ErrInvalidWebAuthnResponse is returned when the response of the
+// browser to a WebAuthn ceremony cannot be verified.
+var ErrInvalidWebAuthnResponse = errors.New("invalid WebAuthn response")
+
+func invalidWebAuthnResponse(err error) error {
+	return errors.Mark(errors.Wrap(err, "invalid WebAuthn response"), ErrInvalidWebAuthnResponse)
+}
+
+// WebAuthnCredential is a security key registered by a user.
+type WebAuthnCredential struct {
+	// ID is the credential ID chosen by the authenticator.
+	ID []byte
+	// PublicKey is the COSE-encoded public key of the credential.
+	PublicKey []byte
+	// SignCount is the last signature counter reported by the
+	// authenticator.
+	SignCount uint32
+}
+
+// GenerateWebAuthnChallenge generates a new random challenge.
+func GenerateWebAuthnChallenge() ([]byte, error) {
+	challenge := make([]byte, WebAuthnChallengeSize)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, err
+	}
+	return challenge, nil
+}
+
+// webauthnClientData is the client data collected by the browser.
+type webauthnClientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+// checkWebAuthnOrigin returns an error if origin is not an origin of the
+// given relying party, i.e. its host is not rpID or a subdomain of it.
+// Plain HTTP is only accepted on localhost.
+func checkWebAuthnOrigin(rpID, origin string) error {
+	u, err := url.Parse(origin)
+	if err != nil {
+		return errors.Wrap(err, "invalid WebAuthn origin")
+	}
+	host := u.Hostname()
+	if host != rpID && !strings.HasSuffix(host, "."+rpID) {
+		return errors.Newf("WebAuthn origin %q does not match the relying party ID %q", origin, rpID)
+	}
+	switch u.Scheme {
+	case "https":
+	case "http":
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			return errors.Newf("WebAuthn origin %q does not use HTTPS", origin)
+		}
+	default:
+		return errors.Newf("invalid WebAuthn origin %q", origin)
+	}
+	return nil
+}
+
+// verifyWebAuthnClientData checks the client data collected by the
+// browser during a ceremony of the given type.
+func verifyWebAuthnClientData(rpID, ceremony string, challenge, clientDataJSON []byte) error {
+	var cd webauthnClientData
+	if err := json.Unmarshal(clientDataJSON, &cd); err != nil {
+		return errors.Wrap(err, "decoding the WebAuthn client data")
+	}
+	if cd.Type != ceremony {
+		return errors.Newf("unexpected WebAuthn ceremony %q", cd.Type)
+	}
+	got, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(cd.Challenge, "="))
+	if err != nil || subtle.ConstantTimeCompare(got, challenge) != 1 {
+		return errors.New("the WebAuthn challenge does not match")
+	}
+	return checkWebAuthnOrigin(rpID, cd.Origin)
+}
+
+// webauthnAuthData is the decoded authenticator data.
+type webauthnAuthData struct {
+	flags     byte
+	signCount uint32
+	// credentialID and publicKey are only set when the authenticator
+	// data contains attested credential data, i.e. on registration.
+	credentialID []byte
+	publicKey    []byte
+}
+
+// parseWebAuthnAuthData decodes the authenticator data and checks that
+// it is scoped to the given relying party and that the user was present.
+func parseWebAuthnAuthData(rpID string, data []byte) (webauthnAuthData, error) {
+	var ad webauthnAuthData
+	// rpIdHash (32), flags (1), signCount (4).
+	if len(data) < 37 {
+		return ad, errors.New("truncated WebAuthn authenticator data")
+	}
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	if subtle.ConstantTimeCompare(data[:32], rpIDHash[:]) != 1 {
+		return ad, errors.New("the WebAuthn authenticator data is not scoped to the relying party")
+	}
+	ad.flags = data[32]
+	ad.signCount = binary.BigEndian.Uint32(data[33:37])
+	if ad.flags&webauthnFlagUserPresent == 0 {
+		return ad, errors.New("the user was not present")
+	}
+	if ad.flags&webauthnFlagAttestedData == 0 {
+		return ad, nil
+	}
+	// aaguid (16), credentialIdLength (2), credentialId, credentialPublicKey.
+	rest := data[37:]
+	if len(rest) < 18 {
+		return ad, errors.New("truncated WebAuthn attested credential data")
+	}
+	n := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+	if len(rest) < n {
+		return ad, errors.New("truncated WebAuthn credential ID")
+	}
+	ad.credentialID, rest = rest[:n], rest[n:]
+	_, keyLen, err := decodeCBOR(rest)
+	if err != nil {
+		return ad, errors.Wrap(err, "decoding the WebAuthn credential public key")
+	}
+	ad.publicKey = rest[:keyLen]
+	return ad, nil
+}
+
+// ParseWebAuthnRegistration verifies the response of the browser to a
+// registration ceremony and returns the new credential.
+func ParseWebAuthnRegistration(
+	rpID string, challenge, clientDataJSON, attestationObject []byte,
+) (*WebAuthnCredential, error) {
+	if err := verifyWebAuthnClientData(rpID, "webauthn.create", challenge, clientDataJSON); err != nil {
+		return nil, invalidWebAuthnResponse(err)
+	}
+	obj, _, err := decodeCBOR(attestationObject)
+	if err != nil {
+		return nil, invalidWebAuthnResponse(errors.Wrap(err, "decoding the WebAuthn attestation object"))
+	}
+	m, _ := obj.(map[interface{}]interface{})
+	authData, ok := m["authData"].([]byte)
+	if !ok {
+		return nil, invalidWebAuthnResponse(errors.New("the WebAuthn attestation object has no authenticator data"))
+	}
+	ad, err := parseWebAuthnAuthData(rpID, authData)
+	if err == nil && ad.publicKey == nil {
+		err = errors.New("the WebAuthn authenticator data has no attested credential")
+	}
+	if err != nil {
+		return nil, invalidWebAuthnResponse(err)
+	}
+	// Check that the public key is supported.
+	if _, _, err := parseCOSEKey(ad.publicKey); err != nil {
+		return nil, invalidWebAuthnResponse(err)
+	}
+	return &WebAuthnCredential{
+		ID:        append([]byte(nil), ad.credentialID...),
+		PublicKey: append([]byte(nil), ad.publicKey...),
+		SignCount: ad.signCount,
+	}, nil
+}
+
+// VerifyWebAuthnAssertion verifies the response of the browser to an
+// authentication ceremony, performed with the given credential. It
+// returns the new signature counter of the credential, which must be
+// recorded by the caller.
+func VerifyWebAuthnAssertion(
+	rpID string,
+	challenge []byte,
+	cred *WebAuthnCredential,
+	clientDataJSON, authenticatorData, signature []byte,
+) (uint32, error) {
+	if err := verifyWebAuthnClientData(rpID, "webauthn.get", challenge, clientDataJSON); err != nil {
+		return 0, invalidWebAuthnResponse(err)
+	}
+	ad, err := parseWebAuthnAuthData(rpID, authenticatorData)
+	if err != nil {
+		return 0, invalidWebAuthnResponse(err)
+	}
+	alg, pub, err := parseCOSEKey(cred.PublicKey)
+	if err != nil {
+		return 0, err
+	}
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte(nil), authenticatorData...), clientDataHash[:]...)
+	if !verifyCOSESignature(alg, pub, signed, signature) {
+		return 0, invalidWebAuthnResponse(errors.New("invalid signature"))
+	}
+	// Authenticators which do not implement a signature counter always
+	// report 0. Otherwise, a counter which does not increase indicates
+	// that the authenticator may have been cloned.
+	if (ad.signCount != 0 || cred.SignCount != 0) && ad.signCount <= cred.SignCount {
+		return 0, invalidWebAuthnResponse(errors.New("the signature counter of the security key did not increase"))
+	}
+	return ad.signCount, nil
+}
+
+// parseCOSEKey decodes a COSE public key (RFC 8152).
+func parseCOSEKey(data []byte) (alg int64, pub crypto.PublicKey, _ error) {
+	v, n, err := decodeCBOR(data)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "decoding the COSE key")
+	}
+	m, ok := v.(map[interface{}]interface{})
+	if !ok || n != len(data) {
+		return 0, nil, errors.New("invalid COSE key")
+	}
+	kty, _ := m[int64(1)].(int64)
+	alg, _ = m[int64(3)].(int64)
+	switch {
+	case kty == 2 && alg == COSEAlgES256:
+		crv, _ := m[int64(-1)].(int64)
+		x, _ := m[int64(-2)].([]byte)
+		y, _ := m[int64(-3)].([]byte)
+		if crv != 1 || len(x) != 32 || len(y) != 32 {
+			return 0, nil, errors.New("invalid COSE EC2 key")
+		}
+		key := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+		if !key.Curve.IsOnCurve(key.X, key.Y) {
+			return 0, nil, errors.New("invalid COSE EC2 key")
+		}
+		return alg, key, nil
+	case kty == 1 && alg == COSEAlgEdDSA:
+		crv, _ := m[int64(-1)].(int64)
+		x, _ := m[int64(-2)].([]byte)
+		if crv != 6 || len(x) != ed25519.PublicKeySize {
+			return 0, nil, errors.New("invalid COSE OKP key")
+		}
+		return alg, ed25519.PublicKey(x), nil
+	case kty == 3 && alg == COSEAlgRS256:
+		n, _ := m[int64(-1)].([]byte)
+		e, _ := m[int64(-2)].([]byte)
+		if len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			return 0, nil, errors.New("invalid COSE RSA key")
+		}
+		var exp int
+		for _, b := range e {
+			exp = exp<<8 | int(b)
+		}
+		return alg, &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: exp}, nil
+	default:
+		return 0, nil, errors.Newf("unsupported COSE key (type %d, algorithm %d)", kty, alg)
+	}
+}
+
+// verifyCOSESignature verifies a signature made with the private key of
+// the given public key.
+func verifyCOSESignature(alg int64, pub crypto.PublicKey, data, sig []byte) bool {
+	switch alg {
+	case COSEAlgES256:
+		h := sha256.Sum256(data)
+		return ecdsa.VerifyASN1(pub.(*ecdsa.PublicKey), h[:], sig)
+	case COSEAlgEdDSA:
+		return ed25519.Verify(pub.(ed25519.PublicKey), data, sig)
+	case COSEAlgRS256:
+		h := sha256.Sum256(data)
+		return rsa.VerifyPKCS1v15(pub.(*rsa.PublicKey), crypto.SHA256, h[:], sig) == nil
+	default:
+		return false
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security
+
+import (
+	"encoding/binary"
+
+	"github.com/cockroachdb/errors"
+)
+
+// The WebAuthn attestation objects and the COSE public keys of the
+// credentials are encoded with CBOR (RFC 8949). Authenticators use the
+// CTAP2 canonical encoding, so only the definite-length items need to
+// be supported.
+
+// cborMaxDepth bounds the nesting of the decoded items.
+const cborMaxDepth = 16
+
+var errCBORTruncated = errors.New("truncated CBOR item")
+
+// cborDecoder decodes CBOR items into the following Go values:
+//
+//   - unsigned and negative integers: int64
+//   - byte strings: []byte
+//   - text strings: string
+//   - arrays: []interface{}
+//   - maps: map[interface{}]interface{}, whose keys are int64 or string
+//   - false, true and null: bool and nil
+type cborDecoder struct {
+	data []byte
+	pos  int
+}
+
+// decodeCBOR decodes the first CBOR item of data and returns the number
+// of bytes it occupies.
+func decodeCBOR(data []byte) (interface{}, int, error) {
+	d := cborDecoder{data: data}
+	v, err := d.decode(0 /* depth */)
+	return v, d.pos, err
+}
+
+// head decodes the initial byte and the argument of an item.
+func (d *cborDecoder) head() (major byte, arg uint64, err error) {
+	if d.pos >= len(d.data) {
+		return 0, 0, errCBORTruncated
+	}
+	b := d.data[d.pos]
+	d.pos++
+	major, info := b>>5, b&0x1f
+	var n int
+	switch {
+	case info < 24:
+		return major, uint64(info), nil
+	case info == 24:
+		n = 1
+	case info == 25:
+		n = 2
+	case info == 26:
+		n = 4
+	case info == 27:
+		n = 8
+	default:
+		return 0, 0, errors.Newf("unsupported CBOR additional information %d", info)
+	}
+	if len(d.data)-d.pos < n {
+		return 0, 0, errCBORTruncated
+	}
+	buf := d.data[d.pos : d.pos+n]
+	d.pos += n
+	switch n {
+	case 1:
+		arg = uint64(buf[0])
+	case 2:
+		arg = uint64(binary.BigEndian.Uint16(buf))
+	case 4:
+		arg = uint64(binary.BigEndian.Uint32(buf))
+	default:
+		arg = binary.BigEndian.Uint64(buf)
+	}
+	return major, arg, nil
+}
+
+func (d *cborDecoder) bytes(n uint64) ([]byte, error) {
+	if uint64(len(d.data)-d.pos) < n {
+		return nil, errCBORTruncated
+	}
+	b := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return b, nil
+}
+
+func (d *cborDecoder) decode(depth int) (interface{}, error) {
+	if depth > cborMaxDepth {
+		return nil, errors.New("CBOR item nested too deeply")
+	}
+	major, arg, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+	switch major {
+	case 0, 1:
+		if arg > 1<<63-1 {
+			return nil, errors.New("CBOR integer out of range")
+		}
+		if major == 1 {
+			return -1 - int64(arg), nil
+		}
+		return int64(arg), nil
+	case 2:
+		return d.bytes(arg)
+	case 3:
+		b, err := d.bytes(arg)
+		return string(b), err
+	case 4:
+		// Each item occupies at least one byte.
+		if arg > uint64(len(d.data)-d.pos) {
+			return nil, errCBORTruncated
+		}
+		items := make([]interface{}, arg)
+		for i := range items {
+			if items[i], err = d.decode(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	case 5:
+		if arg > uint64(len(d.data)-d.pos) {
+			return nil, errCBORTruncated
+		}
+		m := make(map[interface{}]interface{}, arg)
+		for i := uint64(0); i < arg; i++ {
+			k, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			switch k.(type) {
+			case int64, string:
+			default:
+				return nil, errors.Newf("unsupported CBOR map key type %T", k)
+			}
+			if m[k], err = d.decode(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	case 6:
+		// Tags are ignored.
+		return d.decode(depth + 1)
+	case 7:
+		switch arg {
+		case 20:
+			return false, nil
+		case 21:
+			return true, nil
+		case 22:
+			return nil, nil
+		}
+	}
+	return nil, errors.Newf("unsupported CBOR item (major type %d)", major)
+}
@@ -0,0 +1,277 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// cborPair is a key-value pair of a CBOR map, encoded in order.
+type cborPair struct {
+	k, v interface{}
+}
+
+// encodeCBOR encodes the values produced by the CBOR decoder, using
+// []cborPair for the maps.
+func encodeCBOR(v interface{}) []byte {
+	head := func(major byte, arg uint64) []byte {
+		switch {
+		case arg < 24:
+			return []byte{major<<5 | byte(arg)}
+		case arg < 1<<8:
+			return []byte{major<<5 | 24, byte(arg)}
+		case arg < 1<<16:
+			return []byte{major<<5 | 25, byte(arg >> 8), byte(arg)}
+		default:
+			b := []byte{major<<5 | 26, 0, 0, 0, 0}
+			binary.BigEndian.PutUint32(b[1:], uint32(arg))
+			return b
+		}
+	}
+	switch v := v.(type) {
+	case int:
+		if v < 0 {
+			return head(1, uint64(-1-v))
+		}
+		return head(0, uint64(v))
+	case []byte:
+		return append(head(2, uint64(len(v))), v...)
+	case string:
+		return append(head(3, uint64(len(v))), v...)
+	case []interface{}:
+		b := head(4, uint64(len(v)))
+		for _, item := range v {
+			b = append(b, encodeCBOR(item)...)
+		}
+		return b
+	case []cborPair:
+		b := head(5, uint64(len(v)))
+		for _, p := range v {
+			b = append(b, encodeCBOR(p.k)...)
+			b = append(b, encodeCBOR(p.v)...)
+		}
+		return b
+	case bool:
+		if v {
+			return []byte{0xf5}
+		}
+		return []byte{0xf4}
+	case nil:
+		return []byte{0xf6}
+	default:
+		panic(errors.Newf("unsupported type %T", v))
+	}
+}
+
+func TestDecodeCBOR(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	value := []cborPair{
+		{1, 2},
+		{-1, -300},
+		{"b", []byte("bytes")},
+		{"a", []interface{}{"text", true, false, nil, 100000}},
+	}
+	data := encodeCBOR(value)
+	v, n, err := decodeCBOR(append(data, 0xff))
+	require.NoError(t, err)
+	require.Equal(t, len(data), n)
+	require.Equal(t, map[interface{}]interface{}{
+		int64(1):  int64(2),
+		int64(-1): int64(-300),
+		"b":       []byte("bytes"),
+		"a":       []interface{}{"text", true, false, nil, int64(100000)},
+	}, v)
+
+	for i := 0; i < len(data); i++ {
+		_, _, err := decodeCBOR(data[:i])
+		require.Error(t, err, "prefix of length %d", i)
+	}
+
+	// Indefinite lengths are not supported.
+	_, _, err = decodeCBOR([]byte{0x9f, 0x01, 0xff})
+	require.Error(t, err)
+}
+
+func TestCheckWebAuthnOrigin(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	for _, tc := range []struct {
+		rpID, origin string
+		ok           bool
+	}{
+		{"example.com", "https://example.com", true},
+		{"example.com", "https://console.example.com:8080", true},
+		{"example.com", "https://badexample.com", false},
+		{"example.com", "http://console.example.com", false},
+		{"example.com", "ftp://example.com", false},
+		{"localhost", "http://localhost:8080", true},
+		{"127.0.0.1", "http://127.0.0.1:8080", true},
+	} {
+		err := checkWebAuthnOrigin(tc.rpID, tc.origin)
+		require.Equal(t, tc.ok, err == nil, "%s %s: %v", tc.rpID, tc.origin, err)
+	}
+}
+
+// testAuthenticator simulates a security key.
+type testAuthenticator struct {
+	credID    []byte
+	signer    crypto.Signer
+	coseKey   []byte
+	signCount uint32
+}
+
+func newTestAuthenticator(t *testing.T, ed bool) *testAuthenticator {
+	a := &testAuthenticator{credID: []byte("credential-id")}
+	if ed {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		a.signer = priv
+		a.coseKey = encodeCBOR([]cborPair{{1, 1}, {3, COSEAlgEdDSA}, {-1, 6}, {-2, []byte(pub)}})
+		return a
+	}
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	a.signer = priv
+	x, y := make([]byte, 32), make([]byte, 32)
+	priv.X.FillBytes(x)
+	priv.Y.FillBytes(y)
+	a.coseKey = encodeCBOR([]cborPair{{1, 2}, {3, COSEAlgES256}, {-1, 1}, {-2, x}, {-3, y}})
+	return a
+}
+
+func (a *testAuthenticator) authData(rpID string, attested bool) []byte {
+	h := sha256.Sum256([]byte(rpID))
+	data := append([]byte(nil), h[:]...)
+	flags := byte(webauthnFlagUserPresent)
+	if attested {
+		flags |= webauthnFlagAttestedData
+	}
+	data = append(data, flags, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(data[33:], a.signCount)
+	if attested {
+		data = append(data, make([]byte, 16)...)
+		data = append(data, byte(len(a.credID)>>8), byte(len(a.credID)))
+		data = append(data, a.credID...)
+		data = append(data, a.coseKey...)
+	}
+	return data
+}
+
+func makeClientDataJSON(t *testing.T, ceremony string, challenge []byte, origin string) []byte {
+	data, err := json.Marshal(map[string]interface{}{
+		"type":        ceremony,
+		"challenge":   base64.RawURLEncoding.EncodeToString(challenge),
+		"origin":      origin,
+		"crossOrigin": false,
+	})
+	require.NoError(t, err)
+	return data
+}
+
+func (a *testAuthenticator) register(t *testing.T, rpID, origin string, challenge []byte) (_, _ []byte) {
+	attestation := encodeCBOR([]cborPair{
+		{"fmt", "none"},
+		{"attStmt", []cborPair{}},
+		{"authData", a.authData(rpID, true /* attested */)},
+	})
+	return makeClientDataJSON(t, "webauthn.create", challenge, origin), attestation
+}
+
+func (a *testAuthenticator) assert(
+	t *testing.T, rpID, origin string, challenge []byte,
+) (_, _, _ []byte) {
+	a.signCount++
+	cd := makeClientDataJSON(t, "webauthn.get", challenge, origin)
+	authData := a.authData(rpID, false /* attested */)
+	h := sha256.Sum256(cd)
+	signed := append(append([]byte(nil), authData...), h[:]...)
+	var sig []byte
+	var err error
+	if _, ok := a.signer.(ed25519.PrivateKey); ok {
+		sig, err = a.signer.Sign(rand.Reader, signed, crypto.Hash(0))
+	} else {
+		digest := sha256.Sum256(signed)
+		sig, err = a.signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	require.NoError(t, err)
+	return cd, authData, sig
+}
+
+func TestWebAuthnCeremonies(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const rpID = "example.com"
+	const origin = "https://console.example.com:8080"
+
+	run := func(name string, ed bool) {
+		t.Run(name, func(t *testing.T) {
+			a := newTestAuthenticator(t, ed)
+			challenge, err := GenerateWebAuthnChallenge()
+			require.NoError(t, err)
+
+			// Registration.
+			cd, attestation := a.register(t, rpID, origin, challenge)
+			cred, err := ParseWebAuthnRegistration(rpID, challenge, cd, attestation)
+			require.NoError(t, err)
+			require.Equal(t, a.credID, cred.ID)
+			require.Equal(t, a.coseKey, cred.PublicKey)
+
+			otherChallenge, err := GenerateWebAuthnChallenge()
+			require.NoError(t, err)
+			_, err = ParseWebAuthnRegistration(rpID, otherChallenge, cd, attestation)
+			require.True(t, errors.Is(err, ErrInvalidWebAuthnResponse), "%v", err)
+			_, err = ParseWebAuthnRegistration("other.com", challenge, cd, attestation)
+			require.True(t, errors.Is(err, ErrInvalidWebAuthnResponse), "%v", err)
+			cd, attestation = a.register(t, rpID, "https://example.org", challenge)
+			_, err = ParseWebAuthnRegistration(rpID, challenge, cd, attestation)
+			require.True(t, errors.Is(err, ErrInvalidWebAuthnResponse), "%v", err)
+
+			// Authentication.
+			cd, authData, sig := a.assert(t, rpID, origin, challenge)
+			signCount, err := VerifyWebAuthnAssertion(rpID, challenge, cred, cd, authData, sig)
+			require.NoError(t, err)
+			require.Equal(t, uint32(1), signCount)
+
+			// The signature counter must increase.
+			cred.SignCount = signCount
+			_, err = VerifyWebAuthnAssertion(rpID, challenge, cred, cd, authData, sig)
+			require.True(t, errors.Is(err, ErrInvalidWebAuthnResponse), "%v", err)
+			require.Contains(t, err.Error(), "signature counter")
+
+			cd, authData, sig = a.assert(t, rpID, origin, challenge)
+			sig[len(sig)-1] ^= 1
+			_, err = VerifyWebAuthnAssertion(rpID, challenge, cred, cd, authData, sig)
+			require.True(t, errors.Is(err, ErrInvalidWebAuthnResponse), "%v", err)
+
+			// The registration ceremony cannot be used to authenticate.
+			cd, _ = a.register(t, rpID, origin, challenge)
+			_, authData, sig = a.assert(t, rpID, origin, challenge)
+			_, err = VerifyWebAuthnAssertion(rpID, challenge, cred, cd, authData, sig)
+			require.True(t, errors.Is(err, ErrInvalidWebAuthnResponse), "%v", err)
+		})
+	}
+	run("es256", false /* ed */)
+	run("eddsa", true /* ed */)
+}
@@ -165,6 +165,10 @@ func (a *authenticationV2Server) login(w http.ResponseWriter, r *http.Request) {
 
 	// Verify the provided username/password pair.
 	verified, expired, err := a.authServer.verifyPasswordDBConsole(a.ctx, username, r.Form.Get("password"))
+	if errors.Is(err, errSecurityKeyRequired) {
+		http.Error(w, "a security key is required to log in", http.StatusUnauthorized)
+		return
+	}
 	if err != nil {
 		apiV2InternalError(r.Context(), err, w)
 		return
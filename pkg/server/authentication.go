@@ -33,7 +33,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/ui"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/logtags"
@@ -97,6 +99,10 @@ var webSessionTimeout = settings.RegisterDurationSetting(
 type authenticationServer struct {
 	cfg       *base.Config
 	sqlServer *SQLServer
+
+	// webauthnChallenges holds the outstanding challenges of the WebAuthn
+	// ceremonies.
+	webauthnChallenges webauthnChallenges
 }
 
 // newAuthenticationServer allocates and returns a new REST server for
@@ -133,6 +139,7 @@ func (s *authenticationServer) RegisterGateway(
 func (s *authenticationServer) UserLogin(
 	ctx context.Context, req *serverpb.UserLoginRequest,
 ) (*serverpb.UserLoginResponse, error) {
+	start := timeutil.Now()
 	if req.Username == "" {
 		return nil, status.Errorf(
 			codes.Unauthenticated,
@@ -146,13 +153,23 @@ func (s *authenticationServer) UserLogin(
 	// table: the APIs extract the username from the session table
 	// without further normalization.
 	username, _ := security.MakeSQLUsernameFromUserInput(req.Username, security.UsernameValidation)
+	remoteAddr := grpcRemoteAddr(ctx)
 
 	// Verify the provided username/password pair.
 	verified, expired, err := s.verifyPasswordDBConsole(ctx, username, req.Password)
+	if errors.Is(err, errSecurityKeyRequired) {
+		s.logAuthFailed(ctx, start, remoteAddr, username, httpAuthMethodPassword,
+			eventpb.AuthFailReason_METHOD_NOT_FOUND, err)
+		return nil, err
+	}
 	if err != nil {
+		s.logAuthFailed(ctx, start, remoteAddr, username, httpAuthMethodPassword,
+			eventpb.AuthFailReason_USER_RETRIEVAL_ERROR, err)
 		return nil, apiInternalError(ctx, err)
 	}
 	if expired {
+		s.logAuthFailed(ctx, start, remoteAddr, username, httpAuthMethodPassword,
+			eventpb.AuthFailReason_CREDENTIALS_EXPIRED, nil)
 		return nil, status.Errorf(
 			codes.Unauthenticated,
 			"the password for %s has expired",
@@ -160,6 +177,8 @@ func (s *authenticationServer) UserLogin(
 		)
 	}
 	if !verified {
+		s.logAuthFailed(ctx, start, remoteAddr, username, httpAuthMethodPassword,
+			eventpb.AuthFailReason_CREDENTIALS_INVALID, nil)
 		return nil, errWebAuthenticationFailure
 	}
 
@@ -167,6 +186,7 @@ func (s *authenticationServer) UserLogin(
 	if err != nil {
 		return nil, apiInternalError(ctx, err)
 	}
+	s.logAuthOK(ctx, start, remoteAddr, username, httpAuthMethodPassword)
 
 	// Set the cookie header on the outgoing response.
 	if err := grpc.SetHeader(ctx, metadata.Pairs("set-cookie", cookie.String())); err != nil {
@@ -250,6 +270,7 @@ var errWebAuthenticationFailure = status.Errorf(
 func (s *authenticationServer) UserLoginFromSSO(
 	ctx context.Context, reqUsername string,
 ) (*http.Cookie, error) {
+	start := timeutil.Now()
 	// In CockroachDB SQL, unlike in PostgreSQL, usernames are
 	// case-insensitive. Therefore we need to normalize the username
 	// here, so that the normalized username is retained in the session
@@ -257,22 +278,38 @@ func (s *authenticationServer) UserLoginFromSSO(
 	// without further normalization.
 	username, _ := security.MakeSQLUsernameFromUserInput(reqUsername, security.UsernameValidation)
 
-	exists, _, canLoginDBConsole, _, _, _, err := sql.GetUserSessionInitInfo(
+	info, err := sql.GetUserSessionInitInfo(
 		ctx,
 		s.sqlServer.execCfg,
 		s.sqlServer.execCfg.InternalExecutor,
 		username,
 		"", /* databaseName */
+		"", /* applicationName */
+		"", /* sourceAddress */
 	)
 
 	if err != nil {
+		s.logAuthFailed(ctx, start, "" /* remoteAddr */, username, httpAuthMethodSSO,
+			eventpb.AuthFailReason_USER_RETRIEVAL_ERROR, err)
 		return nil, errors.Wrap(err, "failed creating session for username")
 	}
-	if !exists || !canLoginDBConsole {
+	if !info.UserExists {
+		s.logAuthFailed(ctx, start, "" /* remoteAddr */, username, httpAuthMethodSSO,
+			eventpb.AuthFailReason_USER_NOT_FOUND, nil)
+		return nil, errWebAuthenticationFailure
+	}
+	if !info.CanLoginDBConsole {
+		s.logAuthFailed(ctx, start, "" /* remoteAddr */, username, httpAuthMethodSSO,
+			eventpb.AuthFailReason_LOGIN_DISABLED, nil)
 		return nil, errWebAuthenticationFailure
 	}
 
-	return s.createSessionFor(ctx, username)
+	cookie, err := s.createSessionFor(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	s.logAuthOK(ctx, start, "" /* remoteAddr */, username, httpAuthMethodSSO)
+	return cookie, nil
 }
 
 // createSessionFor creates a login cookie for the given user.
@@ -417,25 +454,30 @@ WHERE id = $1`
 // This function should *not* be used to validate logins into the SQL
 // shell since it checks a separate authentication scheme.
 //
+// The users who registered a security key cannot log in with their
+// password: errSecurityKeyRequired is returned.
+//
 // The caller is responsible for ensuring that the username is normalized.
 // (CockroachDB has case-insensitive usernames, unlike PostgreSQL.)
 func (s *authenticationServer) verifyPasswordDBConsole(
 	ctx context.Context, username security.SQLUsername, password string,
 ) (valid bool, expired bool, err error) {
-	exists, _, canLoginDBConsole, _, _, pwRetrieveFn, err := sql.GetUserSessionInitInfo(
+	info, err := sql.GetUserSessionInitInfo(
 		ctx,
 		s.sqlServer.execCfg,
 		s.sqlServer.execCfg.InternalExecutor,
 		username,
 		"", /* databaseName */
+		"", /* applicationName */
+		"", /* sourceAddress */
 	)
 	if err != nil {
 		return false, false, err
 	}
-	if !exists || !canLoginDBConsole {
+	if !info.UserExists || !info.CanLoginDBConsole {
 		return false, false, nil
 	}
-	expired, hashedPassword, err := pwRetrieveFn(ctx)
+	expired, hashedPassword, err := info.PasswordRetrievalFn(ctx)
 	if err != nil {
 		return false, false, err
 	}
@@ -444,7 +486,32 @@ func (s *authenticationServer) verifyPasswordDBConsole(
 		return false, true, nil
 	}
 
+	// Users with the MFA role option append their TOTP code to their
+	// password.
+	var totpCode string
+	if info.MFARequired {
+		var ok bool
+		password, totpCode, ok = security.SplitTOTPCode(password)
+		if !ok {
+			return false, false, nil
+		}
+	}
+
 	ok, err := security.CompareHashAndCleartextPassword(ctx, hashedPassword, password)
+	if ok && err == nil {
+		// The users who registered a security key must use it to log in.
+		if err := s.checkSecurityKeyRequired(ctx, username); err != nil {
+			return false, false, err
+		}
+	}
+	if ok && err == nil && info.MFARequired {
+		if err := sql.VerifyTOTPCode(
+			ctx, s.sqlServer.execCfg, s.sqlServer.execCfg.InternalExecutor, username, totpCode,
+		); err != nil {
+			log.Warningf(ctx, "TOTP verification failed for user %s: %v", username, err)
+			return false, false, nil
+		}
+	}
 	if ok && err == nil {
 		// Password authentication succeeded using cleartext.  If the
 		// stored hash was encoded using crdb-bcrypt, we might want to
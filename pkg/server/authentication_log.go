@@ -0,0 +1,165 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"google.golang.org/grpc/metadata"
+)
+
+// The logins to the DB Console and the HTTP APIs are reported with the
+// same structured events as the SQL logins, on the SESSIONS channel,
+// when the server.auth_log.http_sessions.enabled cluster setting is
+// set. Like the changes to the roles and privileges, reported on the
+// USER_ADMIN and PRIVILEGES channels, they can thus be routed to
+// dedicated log sinks, separate from the SQL audit log.
+//
+// Regardless of that setting, the HTTP logins are also recorded along
+// with the SQL logins for crdb_internal.cluster_auth_attempts, and the
+// successful ones in system.role_last_login.
+
+// logHTTPSessionAuth is the cluster setting that enables the logging
+// of the HTTP login events.
+var logHTTPSessionAuth = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"server.auth_log.http_sessions.enabled",
+	"if set, log DB Console and HTTP API login events (note: may hinder performance on loaded nodes)",
+	false,
+)
+
+// The authentication methods reported for the HTTP logins.
+const (
+	httpAuthMethodPassword    = "password"
+	httpAuthMethodSecurityKey = "security_key"
+	httpAuthMethodSSO         = "sso"
+)
+
+// httpAuthTransport is the transport reported for the HTTP logins.
+const httpAuthTransport = "http"
+
+// grpcRemoteAddr returns the address of the HTTP client of a request
+// proxied by the gRPC gateway, if known.
+func grpcRemoteAddr(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if addrs := md.Get("x-forwarded-for"); len(addrs) > 0 {
+			return addrs[0]
+		}
+	}
+	return ""
+}
+
+// httpAuthDetails returns the details of an HTTP login reported in the
+// authentication events.
+func (s *authenticationServer) httpAuthDetails(
+	remoteAddr string, user security.SQLUsername,
+) (eventpb.CommonConnectionDetails, eventpb.CommonSessionDetails) {
+	connDetails := eventpb.CommonConnectionDetails{
+		InstanceID:    int32(s.sqlServer.execCfg.NodeID.SQLInstanceID()),
+		Network:       "tcp",
+		RemoteAddress: remoteAddr,
+	}
+	sessionDetails := eventpb.CommonSessionDetails{
+		Transport: httpAuthTransport,
+		User:      user.Normalized(),
+	}
+	return connDetails, sessionDetails
+}
+
+// recordAuthAttempt records an HTTP login started at the given time.
+// The cache of the authentication info is not reported for the HTTP
+// logins.
+func (s *authenticationServer) recordAuthAttempt(
+	start time.Time,
+	remoteAddr string,
+	user security.SQLUsername,
+	method string,
+	success bool,
+	reason string,
+	detail string,
+) {
+	now := timeutil.Now()
+	s.sqlServer.execCfg.AuthAttempts.Record(sql.AuthAttempt{
+		Timestamp:     now,
+		Username:      user.Normalized(),
+		RemoteAddress: remoteAddr,
+		Transport:     httpAuthTransport,
+		Method:        method,
+		Success:       success,
+		Reason:        reason,
+		Detail:        detail,
+		Latency:       now.Sub(start),
+	})
+}
+
+// logAuthOK reports a successful HTTP login started at the given time.
+func (s *authenticationServer) logAuthOK(
+	ctx context.Context,
+	start time.Time,
+	remoteAddr string,
+	user security.SQLUsername,
+	method string,
+) {
+	s.recordAuthAttempt(start, remoteAddr, user, method, true /* success */, "" /* reason */, "" /* detail */)
+	telemetry.Inc(sqltelemetry.AuthMethodCounter(sqltelemetry.AuthTransportHTTP, method))
+	s.sqlServer.execCfg.LastLogins.Record(user, sql.LastLogin{
+		Timestamp:     timeutil.Now(),
+		Transport:     httpAuthTransport,
+		Method:        method,
+		RemoteAddress: remoteAddr,
+	})
+	if !logHTTPSessionAuth.Get(&s.sqlServer.execCfg.Settings.SV) {
+		return
+	}
+	connDetails, sessionDetails := s.httpAuthDetails(remoteAddr, user)
+	log.StructuredEvent(ctx, &eventpb.ClientAuthenticationOk{
+		CommonConnectionDetails: connDetails,
+		CommonSessionDetails:    sessionDetails,
+		Method:                  method,
+	})
+}
+
+// logAuthFailed reports a failed HTTP login started at the given time.
+func (s *authenticationServer) logAuthFailed(
+	ctx context.Context,
+	start time.Time,
+	remoteAddr string,
+	user security.SQLUsername,
+	method string,
+	reason eventpb.AuthFailReason,
+	detailedErr error,
+) {
+	var errStr string
+	if detailedErr != nil {
+		errStr = detailedErr.Error()
+	}
+	s.recordAuthAttempt(start, remoteAddr, user, method, false /* success */, reason.String(), errStr)
+	if !logHTTPSessionAuth.Get(&s.sqlServer.execCfg.Settings.SV) {
+		return
+	}
+	connDetails, sessionDetails := s.httpAuthDetails(remoteAddr, user)
+	log.StructuredEvent(ctx, &eventpb.ClientAuthenticationFailed{
+		CommonConnectionDetails: connDetails,
+		CommonSessionDetails:    sessionDetails,
+		Reason:                  reason,
+		Detail:                  errStr,
+		Method:                  method,
+	})
+}
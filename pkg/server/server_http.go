@@ -141,6 +141,16 @@ func (s *httpServer) setupRoutes(
 	if s.cfg.EnableDemoLoginEndpoint {
 		s.mux.Handle(DemoLoginPath, http.HandlerFunc(authnServer.demoLogin))
 	}
+	// The WebAuthn endpoints, used to register security keys from an
+	// authenticated session and to log in with them.
+	if s.cfg.RequireWebSession() {
+		s.mux.Handle(webauthnRegisterBeginPath,
+			newAuthenticationMux(authnServer, http.HandlerFunc(authnServer.webauthnRegisterBegin)))
+		s.mux.Handle(webauthnRegisterFinishPath,
+			newAuthenticationMux(authnServer, http.HandlerFunc(authnServer.webauthnRegisterFinish)))
+		s.mux.Handle(webauthnLoginBeginPath, http.HandlerFunc(authnServer.webauthnLoginBegin))
+		s.mux.Handle(webauthnLoginFinishPath, http.HandlerFunc(authnServer.webauthnLoginFinish))
+	}
 
 	// Admin/Status servers. These are used by the UI via RPC-over-HTTP.
 	s.mux.Handle(statusPrefix, authenticatedHandler)
@@ -696,6 +696,8 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 		RegionsServer:           cfg.regionsServer,
 		SessionRegistry:         cfg.sessionRegistry,
 		ContentionRegistry:      contentionRegistry,
+		AuthAttempts:            sql.NewAuthAttempts(),
+		LastLogins:              sql.NewLastLoginRecorder(cfg.Settings, cfg.circularInternalExecutor, &sqlExecutorTestingKnobs),
 		SQLLiveness:             cfg.sqlLivenessProvider,
 		JobRegistry:             jobRegistry,
 		VirtualSchemas:          virtualSchemas,
@@ -832,6 +834,7 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 
 	distSQLServer.ServerConfig.SQLStatsController = pgServer.SQLServer.GetSQLStatsController()
 	distSQLServer.ServerConfig.IndexUsageStatsController = pgServer.SQLServer.GetIndexUsageStatsController()
+	execCfg.HBARules = pgServer
 
 	// Now that we have a pgwire.Server (which has a sql.Server), we can close a
 	// circular dependency between the rowexec.Server and sql.Server and set
@@ -1141,6 +1144,7 @@ func (s *SQLServer) preStart(
 		return err
 	}
 	s.stmtDiagnosticsRegistry.Start(ctx, stopper)
+	s.execCfg.LastLogins.Start(ctx, stopper)
 
 	// Before serving SQL requests, we have to make sure the database is
 	// in an acceptable form for this version of the software.
@@ -1310,8 +1314,18 @@ func (s *SQLServer) startServeSQL(
 		stop.TaskOpts{TaskName: "pgwire-listener", SpanOpt: stop.SterileRootSpan},
 		func(ctx context.Context) {
 			err := connManager.ServeWith(ctx, stopper, pgL, func(ctx context.Context, conn net.Conn) {
+				tcpKeepAlive.configure(ctx, conn)
+				// The remote address is replaced by the address of the
+				// original client if the connection comes from a trusted
+				// proxy.
+				clientConn, err := s.pgServer.MaybeReadProxyProtocolHeader(ctx, conn)
+				if err != nil {
+					log.Ops.Warningf(ctx, "%v", err)
+					_ = conn.Close()
+					return
+				}
+				conn = clientConn
 				connCtx := s.pgServer.AnnotateCtxForIncomingConn(ctx, conn)
-				tcpKeepAlive.configure(connCtx, conn)
 
 				if err := s.pgServer.ServeConn(connCtx, conn, pgwire.SocketTCP); err != nil {
 					log.Ops.Errorf(connCtx, "serving SQL client conn: %v", err)
@@ -32,6 +32,8 @@ type SQLStatusServer interface {
 	StatementDetails(context.Context, *StatementDetailsRequest) (*StatementDetailsResponse, error)
 	ListDistSQLFlows(context.Context, *ListDistSQLFlowsRequest) (*ListDistSQLFlowsResponse, error)
 	ListLocalDistSQLFlows(context.Context, *ListDistSQLFlowsRequest) (*ListDistSQLFlowsResponse, error)
+	ListAuthAttempts(context.Context, *ListAuthAttemptsRequest) (*ListAuthAttemptsResponse, error)
+	ListLocalAuthAttempts(context.Context, *ListAuthAttemptsRequest) (*ListAuthAttemptsResponse, error)
 	Profile(context.Context, *ProfileRequest) (*JSONResponse, error)
 	IndexUsageStatistics(context.Context, *IndexUsageStatisticsRequest) (*IndexUsageStatisticsResponse, error)
 	ResetIndexUsageStats(context.Context, *ResetIndexUsageStatsRequest) (*ResetIndexUsageStatsResponse, error)
@@ -85,10 +85,12 @@ func startListenRPCAndSQL(
 
 	if !cfg.SplitListenSQL {
 		// If the pg port is split, it will be opened above. Otherwise,
-		// we make it hang off the RPC listener via cmux here.
+		// we make it hang off the RPC listener via cmux here. Connections
+		// starting with a PROXY protocol header are routed to the SQL
+		// server too, since only SQL connections support it.
 		pgL = m.Match(func(r io.Reader) bool {
 			return pgwire.Match(r)
-		})
+		}, pgwire.MatchProxyProtocol)
 		// Also if the pg port is not split, the actual listen
 		// and advertise addresses for SQL become equal to that
 		// of RPC, regardless of what was configured.
@@ -385,6 +385,42 @@ func (b *baseStatusServer) ListLocalDistSQLFlows(
 	return response, nil
 }
 
+func (b *baseStatusServer) ListLocalAuthAttempts(
+	ctx context.Context, _ *serverpb.ListAuthAttemptsRequest,
+) (*serverpb.ListAuthAttemptsResponse, error) {
+	ctx = propagateGatewayMetadata(ctx)
+	ctx = b.AnnotateCtx(ctx)
+
+	if _, err := b.privilegeChecker.requireAdminUser(ctx); err != nil {
+		// NB: not using serverError() here since the priv checker
+		// already returns a proper gRPC error status.
+		return nil, err
+	}
+
+	nodeIDOrZero, _ := b.sqlServer.sqlIDContainer.OptionalNodeID()
+
+	attempts := b.sqlServer.execCfg.AuthAttempts.List()
+	response := &serverpb.ListAuthAttemptsResponse{
+		Attempts: make([]serverpb.AuthAttempt, 0, len(attempts)),
+	}
+	for _, a := range attempts {
+		response.Attempts = append(response.Attempts, serverpb.AuthAttempt{
+			NodeID:        nodeIDOrZero,
+			Timestamp:     a.Timestamp,
+			Username:      a.Username,
+			RemoteAddress: a.RemoteAddress,
+			Transport:     a.Transport,
+			Method:        a.Method,
+			Success:       a.Success,
+			Reason:        a.Reason,
+			Detail:        a.Detail,
+			Latency:       a.Latency,
+			CacheHit:      a.CacheHit,
+		})
+	}
+	return response, nil
+}
+
 func (b *baseStatusServer) localTxnIDResolution(
 	req *serverpb.TxnIDResolutionRequest,
 ) *serverpb.TxnIDResolutionResponse {
@@ -1324,6 +1360,7 @@ func (s *statusServer) Regions(
 
 func regionsResponseFromNodesResponse(nr *serverpb.NodesResponse) *serverpb.RegionsResponse {
 	regionsToZones := make(map[string]map[string]struct{})
+	regionsToLiveNodes := make(map[string]int32)
 	for _, node := range nr.Nodes {
 		var region string
 		var zone string
@@ -1344,6 +1381,9 @@ func regionsResponseFromNodesResponse(nr *serverpb.NodesResponse) *serverpb.Regi
 		if zone != "" {
 			regionsToZones[region][zone] = struct{}{}
 		}
+		if nr.LivenessByNodeID[node.Desc.NodeID] == livenesspb.NodeLivenessStatus_LIVE {
+			regionsToLiveNodes[region]++
+		}
 	}
 	ret := &serverpb.RegionsResponse{
 		Regions: make(map[string]*serverpb.RegionsResponse_Region, len(regionsToZones)),
@@ -1355,7 +1395,8 @@ func regionsResponseFromNodesResponse(nr *serverpb.NodesResponse) *serverpb.Regi
 		}
 		sort.Strings(zonesArr)
 		ret.Regions[region] = &serverpb.RegionsResponse_Region{
-			Zones: zonesArr,
+			Zones:     zonesArr,
+			LiveNodes: regionsToLiveNodes[region],
 		}
 	}
 	return ret
@@ -2991,6 +3032,58 @@ func (s *statusServer) ListDistSQLFlows(
 	return &response, nil
 }
 
+func (s *statusServer) ListAuthAttempts(
+	ctx context.Context, request *serverpb.ListAuthAttemptsRequest,
+) (*serverpb.ListAuthAttemptsResponse, error) {
+	ctx = propagateGatewayMetadata(ctx)
+	ctx = s.AnnotateCtx(ctx)
+
+	// Check permissions early to avoid fan-out to all nodes.
+	if _, err := s.privilegeChecker.requireAdminUser(ctx); err != nil {
+		// NB: not using serverError() here since the priv checker
+		// already returns a proper gRPC error status.
+		return nil, err
+	}
+
+	var response serverpb.ListAuthAttemptsResponse
+	dialFn := func(ctx context.Context, nodeID roachpb.NodeID) (interface{}, error) {
+		client, err := s.dialNode(ctx, nodeID)
+		return client, err
+	}
+	nodeFn := func(ctx context.Context, client interface{}, _ roachpb.NodeID) (interface{}, error) {
+		statusClient := client.(serverpb.StatusClient)
+		resp, err := statusClient.ListLocalAuthAttempts(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Errors) > 0 {
+			return nil, errors.Errorf("%s", resp.Errors[0].Message)
+		}
+		return resp, nil
+	}
+	responseFn := func(_ roachpb.NodeID, nodeResp interface{}) {
+		if nodeResp == nil {
+			return
+		}
+		attempts := nodeResp.(*serverpb.ListAuthAttemptsResponse).Attempts
+		response.Attempts = append(response.Attempts, attempts...)
+	}
+	errorFn := func(nodeID roachpb.NodeID, err error) {
+		errResponse := serverpb.ListActivityError{NodeID: nodeID, Message: err.Error()}
+		response.Errors = append(response.Errors, errResponse)
+	}
+
+	if err := s.iterateNodes(ctx, "authentication attempts list", dialFn, nodeFn, responseFn, errorFn); err != nil {
+		return nil, serverError(ctx, err)
+	}
+	// Per the contract of serverpb.ListAuthAttemptsResponse, sort the
+	// attempts by timestamp.
+	sort.SliceStable(response.Attempts, func(i, j int) bool {
+		return response.Attempts[i].Timestamp.Before(response.Attempts[j].Timestamp)
+	})
+	return &response, nil
+}
+
 // mergeDistSQLRemoteFlows takes in two slices of DistSQL remote flows (that
 // satisfy the contract of serverpb.ListDistSQLFlowsResponse) and merges them
 // together while adhering to the same contract.
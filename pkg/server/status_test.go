@@ -35,6 +35,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/liveness/livenesspb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/rpc"
 	"github.com/cockroachdb/cockroach/pkg/security"
@@ -3020,6 +3021,36 @@ func TestRegionsResponseFromNodesResponse(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "nodes with liveness",
+			resp: func() *serverpb.NodesResponse {
+				ret := makeNodeResponseWithLocalities([][]roachpb.Tier{
+					makeTiers("us-east1", "us-east1-a"),
+					makeTiers("us-east1", "us-east1-b"),
+					makeTiers("us-east1", "us-east1-c"),
+					makeTiers("us-east2", "us-east2-a"),
+				})
+				ret.LivenessByNodeID = make(map[roachpb.NodeID]livenesspb.NodeLivenessStatus)
+				for i := range ret.Nodes {
+					ret.Nodes[i].Desc.NodeID = roachpb.NodeID(i + 1)
+					ret.LivenessByNodeID[roachpb.NodeID(i+1)] = livenesspb.NodeLivenessStatus_LIVE
+				}
+				ret.LivenessByNodeID[2] = livenesspb.NodeLivenessStatus_DEAD
+				return ret
+			}(),
+			expected: &serverpb.RegionsResponse{
+				Regions: map[string]*serverpb.RegionsResponse_Region{
+					"us-east1": {
+						Zones:     []string{"us-east1-a", "us-east1-b", "us-east1-c"},
+						LiveNodes: 2,
+					},
+					"us-east2": {
+						Zones:     []string{"us-east2-a"},
+						LiveNodes: 1,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -756,6 +756,26 @@ func (t *tenantStatusServer) ListLocalDistSQLFlows(
 	return t.baseStatusServer.ListLocalDistSQLFlows(ctx, request)
 }
 
+func (t *tenantStatusServer) ListAuthAttempts(
+	ctx context.Context, request *serverpb.ListAuthAttemptsRequest,
+) (*serverpb.ListAuthAttemptsResponse, error) {
+	if t.sqlServer.SQLInstanceID() == 0 {
+		return nil, status.Errorf(codes.Unavailable, "instanceID not set")
+	}
+
+	return t.ListLocalAuthAttempts(ctx, request)
+}
+
+func (t *tenantStatusServer) ListLocalAuthAttempts(
+	ctx context.Context, request *serverpb.ListAuthAttemptsRequest,
+) (*serverpb.ListAuthAttemptsResponse, error) {
+	if t.sqlServer.SQLInstanceID() == 0 {
+		return nil, status.Errorf(codes.Unavailable, "instanceID not set")
+	}
+
+	return t.baseStatusServer.ListLocalAuthAttempts(ctx, request)
+}
+
 // Profile implements the profiling endpoint by delegating the request
 // to the local handler. If the requested node_id is not the same as
 // the current instance ID, it performs an RPC call to fetch the profile
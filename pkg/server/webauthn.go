@@ -0,0 +1,476 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Users can register security keys from an authenticated DB Console
+// session, and then log in to the DB Console with them using the Web
+// Authentication API (WebAuthn). Each ceremony is performed with two
+// requests: the first one returns the options to pass to
+// navigator.credentials.create() or navigator.credentials.get(),
+// including a challenge, and the second one carries the response of the
+// browser. The binary values are encoded with unpadded base64url.
+//
+// The challenges are kept in memory by the node which issued them, so
+// both requests of a ceremony must reach the same node.
+//
+// The users who registered a security key cannot log in to the DB
+// Console with their password anymore.
+
+const (
+	webauthnRegisterBeginPath  = "/webauthn/register/begin"
+	webauthnRegisterFinishPath = "/webauthn/register/finish"
+	webauthnLoginBeginPath     = "/webauthn/login/begin"
+	webauthnLoginFinishPath    = "/webauthn/login/finish"
+
+	// webauthnRelyingPartyName identifies the cluster in the prompts of
+	// the browser.
+	webauthnRelyingPartyName = "CockroachDB"
+	// webauthnChallengeTimeout is the time given to the users to complete
+	// a ceremony.
+	webauthnChallengeTimeout = 5 * time.Minute
+	// webauthnMaxChallenges bounds the number of outstanding challenges,
+	// since they can be requested without authentication.
+	webauthnMaxChallenges = 10000
+)
+
+// webauthnRelyingPartyID is the WebAuthn relying party ID of the
+// cluster, which scopes the security keys.
+var webauthnRelyingPartyID = settings.RegisterValidatedStringSetting(
+	settings.TenantWritable,
+	"server.webauthn.relying_party_id",
+	"the domain of the DB Console used to scope the security keys of the users, "+
+		"e.g. example.com to log in from https://console.example.com; "+
+		"if empty, security keys cannot be used",
+	"",
+	func(_ *settings.Values, s string) error {
+		if strings.ContainsAny(s, ":/ ") {
+			return errors.Newf("invalid relying party ID %q: must be a domain name, without scheme nor port", s)
+		}
+		return nil
+	},
+)
+
+var errSecurityKeyRequired = status.Errorf(
+	codes.Unauthenticated,
+	"a security key is required to log in",
+)
+
+// webauthnBytes is a binary value encoded as unpadded base64url in JSON,
+// as done by the WebAuthn API.
+type webauthnBytes []byte
+
+// MarshalJSON implements the json.Marshaler interface.
+func (b webauthnBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.RawURLEncoding.EncodeToString(b))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (b *webauthnBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+// webauthnCeremony is the type of a WebAuthn ceremony.
+type webauthnCeremony int
+
+const (
+	webauthnRegistration webauthnCeremony = iota
+	webauthnAuthentication
+)
+
+type webauthnChallenge struct {
+	user       security.SQLUsername
+	ceremony   webauthnCeremony
+	expiration time.Time
+}
+
+// webauthnChallenges holds the outstanding challenges.
+type webauthnChallenges struct {
+	syncutil.Mutex
+	m map[string]webauthnChallenge
+}
+
+// issue generates a new challenge for a ceremony of the given user.
+func (c *webauthnChallenges) issue(
+	user security.SQLUsername, ceremony webauthnCeremony, now time.Time,
+) ([]byte, error) {
+	challenge, err := security.GenerateWebAuthnChallenge()
+	if err != nil {
+		return nil, err
+	}
+	c.Lock()
+	defer c.Unlock()
+	if c.m == nil {
+		c.m = make(map[string]webauthnChallenge)
+	}
+	for k, v := range c.m {
+		if !now.Before(v.expiration) {
+			delete(c.m, k)
+		}
+	}
+	if len(c.m) >= webauthnMaxChallenges {
+		return nil, errors.New("too many outstanding WebAuthn challenges")
+	}
+	c.m[string(challenge)] = webauthnChallenge{
+		user:       user,
+		ceremony:   ceremony,
+		expiration: now.Add(webauthnChallengeTimeout),
+	}
+	return challenge, nil
+}
+
+// consume returns true if the given challenge was issued for a ceremony
+// of the given user and has not expired. Each challenge can only be
+// consumed once.
+func (c *webauthnChallenges) consume(
+	challenge []byte, user security.SQLUsername, ceremony webauthnCeremony, now time.Time,
+) bool {
+	c.Lock()
+	defer c.Unlock()
+	v, ok := c.m[string(challenge)]
+	if !ok {
+		return false
+	}
+	delete(c.m, string(challenge))
+	return v.user == user && v.ceremony == ceremony && now.Before(v.expiration)
+}
+
+type webauthnCredentialDescriptor struct {
+	Type string        `json:"type"`
+	ID   webauthnBytes `json:"id"`
+}
+
+func makeWebAuthnCredentialDescriptors(keys []sql.SecurityKey) []webauthnCredentialDescriptor {
+	descs := make([]webauthnCredentialDescriptor, len(keys))
+	for i := range keys {
+		descs[i] = webauthnCredentialDescriptor{Type: "public-key", ID: keys[i].ID}
+	}
+	return descs
+}
+
+type webauthnRelyingParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type webauthnUser struct {
+	ID          webauthnBytes `json:"id"`
+	Name        string        `json:"name"`
+	DisplayName string        `json:"displayName"`
+}
+
+type webauthnCredentialParameters struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+// webauthnRegistrationOptions are the options passed to
+// navigator.credentials.create().
+type webauthnRegistrationOptions struct {
+	Challenge          webauthnBytes                  `json:"challenge"`
+	RelyingParty       webauthnRelyingParty           `json:"rp"`
+	User               webauthnUser                   `json:"user"`
+	PubKeyCredParams   []webauthnCredentialParameters `json:"pubKeyCredParams"`
+	Timeout            int64                          `json:"timeout"`
+	ExcludeCredentials []webauthnCredentialDescriptor `json:"excludeCredentials"`
+	Attestation        string                         `json:"attestation"`
+}
+
+// webauthnRegistrationRequest carries the response of the browser to a
+// registration ceremony.
+type webauthnRegistrationRequest struct {
+	// Name identifies the new security key among the keys of the user.
+	Name              string        `json:"name"`
+	Challenge         webauthnBytes `json:"challenge"`
+	ClientDataJSON    webauthnBytes `json:"clientDataJSON"`
+	AttestationObject webauthnBytes `json:"attestationObject"`
+}
+
+// webauthnLoginRequest starts an authentication ceremony.
+type webauthnLoginRequest struct {
+	Username string `json:"username"`
+}
+
+// webauthnLoginOptions are the options passed to
+// navigator.credentials.get().
+type webauthnLoginOptions struct {
+	Challenge        webauthnBytes                  `json:"challenge"`
+	RelyingPartyID   string                         `json:"rpId"`
+	Timeout          int64                          `json:"timeout"`
+	AllowCredentials []webauthnCredentialDescriptor `json:"allowCredentials"`
+}
+
+// webauthnAssertionRequest carries the response of the browser to an
+// authentication ceremony.
+type webauthnAssertionRequest struct {
+	Username          string        `json:"username"`
+	Challenge         webauthnBytes `json:"challenge"`
+	CredentialID      webauthnBytes `json:"credentialId"`
+	ClientDataJSON    webauthnBytes `json:"clientDataJSON"`
+	AuthenticatorData webauthnBytes `json:"authenticatorData"`
+	Signature         webauthnBytes `json:"signature"`
+}
+
+// webauthnPrepare checks the method of a WebAuthn request, decodes its
+// body and returns the relying party ID. It returns false if the
+// request was rejected.
+func (s *authenticationServer) webauthnPrepare(
+	w http.ResponseWriter, r *http.Request, body interface{},
+) (rpID string, ok bool) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return "", false
+	}
+	rpID = webauthnRelyingPartyID.Get(&s.sqlServer.execCfg.Settings.SV)
+	if rpID == "" {
+		http.Error(w, "security keys are not enabled: "+
+			"the cluster setting "+webauthnRelyingPartyID.Key()+" is not set", http.StatusNotFound)
+		return "", false
+	}
+	if body != nil {
+		if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+			http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+			return "", false
+		}
+	}
+	return rpID, true
+}
+
+// webauthnRegisterBegin starts the registration of a security key by
+// the user of the current session.
+func (s *authenticationServer) webauthnRegisterBegin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rpID, ok := s.webauthnPrepare(w, r, nil /* body */)
+	if !ok {
+		return
+	}
+	user := getSQLUsername(ctx)
+	keys, err := sql.GetSecurityKeys(ctx, s.sqlServer.execCfg, s.sqlServer.execCfg.InternalExecutor, user)
+	if err != nil {
+		apiV2InternalError(ctx, err, w)
+		return
+	}
+	challenge, err := s.webauthnChallenges.issue(user, webauthnRegistration, timeutil.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	opts := webauthnRegistrationOptions{
+		Challenge:    challenge,
+		RelyingParty: webauthnRelyingParty{ID: rpID, Name: webauthnRelyingPartyName},
+		User: webauthnUser{
+			ID:          webauthnBytes(user.Normalized()),
+			Name:        user.Normalized(),
+			DisplayName: user.Normalized(),
+		},
+		Timeout:            webauthnChallengeTimeout.Milliseconds(),
+		ExcludeCredentials: makeWebAuthnCredentialDescriptors(keys),
+		Attestation:        "none",
+	}
+	for _, alg := range security.WebAuthnAlgorithms {
+		opts.PubKeyCredParams = append(opts.PubKeyCredParams,
+			webauthnCredentialParameters{Type: "public-key", Alg: alg})
+	}
+	writeJSONResponse(ctx, w, http.StatusOK, &opts)
+}
+
+// webauthnRegisterFinish completes the registration of a security key by
+// the user of the current session.
+func (s *authenticationServer) webauthnRegisterFinish(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req webauthnRegistrationRequest
+	rpID, ok := s.webauthnPrepare(w, r, &req)
+	if !ok {
+		return
+	}
+	user := getSQLUsername(ctx)
+	if !s.webauthnChallenges.consume(req.Challenge, user, webauthnRegistration, timeutil.Now()) {
+		http.Error(w, "invalid or expired challenge", http.StatusBadRequest)
+		return
+	}
+	cred, err := security.ParseWebAuthnRegistration(rpID, req.Challenge, req.ClientDataJSON, req.AttestationObject)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := sql.RegisterSecurityKey(
+		ctx, s.sqlServer.execCfg, s.sqlServer.execCfg.InternalExecutor, user,
+		sql.SecurityKey{Name: req.Name, WebAuthnCredential: *cred},
+	); err != nil {
+		switch pgerror.GetPGCode(err) {
+		case pgcode.DuplicateObject:
+			http.Error(w, err.Error(), http.StatusConflict)
+		case pgcode.InvalidParameterValue, pgcode.FeatureNotSupported:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			apiV2InternalError(ctx, err, w)
+		}
+		return
+	}
+	log.Infof(ctx, "user %s registered the security key %q", user, req.Name)
+	writeJSONResponse(ctx, w, http.StatusOK, struct{}{})
+}
+
+// webauthnLoginBegin starts the authentication of a user with a
+// security key.
+func (s *authenticationServer) webauthnLoginBegin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req webauthnLoginRequest
+	rpID, ok := s.webauthnPrepare(w, r, &req)
+	if !ok {
+		return
+	}
+	if req.Username == "" {
+		http.Error(w, "username not specified", http.StatusBadRequest)
+		return
+	}
+	user, _ := security.MakeSQLUsernameFromUserInput(req.Username, security.UsernameValidation)
+	keys, err := sql.GetSecurityKeys(ctx, s.sqlServer.execCfg, s.sqlServer.execCfg.InternalExecutor, user)
+	if err != nil {
+		apiV2InternalError(ctx, err, w)
+		return
+	}
+	challenge, err := s.webauthnChallenges.issue(user, webauthnAuthentication, timeutil.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	writeJSONResponse(ctx, w, http.StatusOK, &webauthnLoginOptions{
+		Challenge:        challenge,
+		RelyingPartyID:   rpID,
+		Timeout:          webauthnChallengeTimeout.Milliseconds(),
+		AllowCredentials: makeWebAuthnCredentialDescriptors(keys),
+	})
+}
+
+// webauthnLoginFinish completes the authentication of a user with a
+// security key, and creates a session for the user.
+func (s *authenticationServer) webauthnLoginFinish(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	start := timeutil.Now()
+	var req webauthnAssertionRequest
+	rpID, ok := s.webauthnPrepare(w, r, &req)
+	if !ok {
+		return
+	}
+	user, _ := security.MakeSQLUsernameFromUserInput(req.Username, security.UsernameValidation)
+	fail := func(reason string) {
+		log.Infof(ctx, "security key authentication failed for user %s: %s", user, reason)
+		s.logAuthFailed(ctx, start, r.RemoteAddr, user, httpAuthMethodSecurityKey,
+			eventpb.AuthFailReason_CREDENTIALS_INVALID, errors.Newf("%s", reason))
+		http.Error(w, "the provided credentials did not match any account on the server", http.StatusUnauthorized)
+	}
+	if !s.webauthnChallenges.consume(req.Challenge, user, webauthnAuthentication, timeutil.Now()) {
+		fail("invalid or expired challenge")
+		return
+	}
+	execCfg := s.sqlServer.execCfg
+	keys, err := sql.GetSecurityKeys(ctx, execCfg, execCfg.InternalExecutor, user)
+	if err != nil {
+		apiV2InternalError(ctx, err, w)
+		return
+	}
+	var key *sql.SecurityKey
+	for i := range keys {
+		if bytes.Equal(keys[i].ID, req.CredentialID) {
+			key = &keys[i]
+			break
+		}
+	}
+	if key == nil {
+		fail("unknown security key")
+		return
+	}
+	signCount, err := security.VerifyWebAuthnAssertion(
+		rpID, req.Challenge, &key.WebAuthnCredential, req.ClientDataJSON, req.AuthenticatorData, req.Signature,
+	)
+	if err != nil {
+		fail(err.Error())
+		return
+	}
+	if recorded, err := sql.RecordSecurityKeyUse(ctx, execCfg.InternalExecutor, user, *key, signCount); err != nil {
+		apiV2InternalError(ctx, err, w)
+		return
+	} else if !recorded {
+		fail("the security key was used concurrently")
+		return
+	}
+
+	info, err := sql.GetUserSessionInitInfo(
+		ctx, execCfg, execCfg.InternalExecutor, user, "" /* databaseName */, "", /* applicationName */
+		"", /* sourceAddress */
+	)
+	if err != nil {
+		apiV2InternalError(ctx, err, w)
+		return
+	}
+	if !info.UserExists || !info.CanLoginDBConsole {
+		fail("the user cannot log in to the DB Console")
+		return
+	}
+
+	cookie, err := s.createSessionFor(ctx, user)
+	if err != nil {
+		apiV2InternalError(ctx, err, w)
+		return
+	}
+	s.logAuthOK(ctx, start, r.RemoteAddr, user, httpAuthMethodSecurityKey)
+	http.SetCookie(w, cookie)
+	writeJSONResponse(ctx, w, http.StatusOK, struct{}{})
+}
+
+// checkSecurityKeyRequired returns errSecurityKeyRequired if the given
+// user registered security keys, and thus cannot log in to the DB
+// Console with a password.
+func (s *authenticationServer) checkSecurityKeyRequired(
+	ctx context.Context, user security.SQLUsername,
+) error {
+	execCfg := s.sqlServer.execCfg
+	keys, err := sql.GetSecurityKeys(ctx, execCfg, execCfg.InternalExecutor, user)
+	if err != nil {
+		return err
+	}
+	if len(keys) > 0 {
+		return errSecurityKeyRequired
+	}
+	return nil
+}
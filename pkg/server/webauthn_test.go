@@ -0,0 +1,72 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package server
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebAuthnChallenges(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	alice := security.MakeSQLUsernameFromPreNormalizedString("alice")
+	bob := security.MakeSQLUsernameFromPreNormalizedString("bob")
+	now := timeutil.Now()
+	var c webauthnChallenges
+
+	// Each challenge can only be used once.
+	challenge, err := c.issue(alice, webauthnAuthentication, now)
+	require.NoError(t, err)
+	require.Len(t, challenge, security.WebAuthnChallengeSize)
+	require.True(t, c.consume(challenge, alice, webauthnAuthentication, now))
+	require.False(t, c.consume(challenge, alice, webauthnAuthentication, now))
+
+	// The challenges are bound to a user and a ceremony.
+	challenge, err = c.issue(alice, webauthnAuthentication, now)
+	require.NoError(t, err)
+	require.False(t, c.consume(challenge, bob, webauthnAuthentication, now))
+	require.False(t, c.consume(challenge, alice, webauthnAuthentication, now))
+	challenge, err = c.issue(alice, webauthnRegistration, now)
+	require.NoError(t, err)
+	require.False(t, c.consume(challenge, alice, webauthnAuthentication, now))
+
+	// The challenges expire.
+	challenge, err = c.issue(alice, webauthnAuthentication, now)
+	require.NoError(t, err)
+	require.False(t, c.consume(challenge, alice, webauthnAuthentication, now.Add(webauthnChallengeTimeout)))
+
+	// The expired challenges are pruned.
+	_, err = c.issue(alice, webauthnAuthentication, now)
+	require.NoError(t, err)
+	_, err = c.issue(bob, webauthnAuthentication, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, c.m, 1)
+}
+
+func TestWebAuthnBytes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	var req webauthnAssertionRequest
+	require.NoError(t, json.Unmarshal([]byte(`{"username": "alice", "challenge": "_-8", "signature": "AQID"}`), &req))
+	require.Equal(t, webauthnBytes{0xff, 0xef}, req.Challenge)
+	require.Equal(t, webauthnBytes{1, 2, 3}, req.Signature)
+
+	data, err := json.Marshal(webauthnCredentialDescriptor{Type: "public-key", ID: webauthnBytes{0xff, 0xef}})
+	require.NoError(t, err)
+	require.Equal(t, `{"type":"public-key","id":"_-8"}`, string(data))
+}
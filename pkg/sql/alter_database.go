@@ -14,7 +14,10 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
@@ -26,14 +29,17 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/multiregion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
 	"github.com/cockroachdb/errors"
 )
@@ -150,14 +156,19 @@ func (p *planner) AlterDatabaseAddRegion(
 	// the database doesn't yet have a primary region. Since we need a primary region before
 	// we can add a region, return an error here.
 	if !dbDesc.IsMultiRegion() {
+		regionOrRegions := "region"
+		if len(n.Regions) > 1 {
+			regionOrRegions = "regions"
+		}
 		return nil, errors.WithHintf(
-			pgerror.Newf(pgcode.InvalidDatabaseDefinition, "cannot add region %s to database %s",
-				n.Region.String(),
+			pgerror.Newf(pgcode.InvalidDatabaseDefinition, "cannot add %s %s to database %s",
+				regionOrRegions,
+				tree.AsString(&n.Regions),
 				n.Name.String(),
 			),
 			"you must add a PRIMARY REGION first using ALTER DATABASE %s PRIMARY REGION %s",
 			n.Name.String(),
-			n.Region.String(),
+			n.Regions[0].String(),
 		)
 	}
 
@@ -204,13 +215,13 @@ func (n *alterDatabaseAddRegionNode) startExec(params runParams) error {
 		return err
 	}
 
-	telemetry.Inc(sqltelemetry.AlterDatabaseAddRegionCounter)
-
-	if err := params.p.checkRegionIsCurrentlyActive(
-		params.ctx,
-		catpb.RegionName(n.n.Region),
-	); err != nil {
-		return err
+	for _, region := range n.n.Regions {
+		if err := params.p.checkRegionIsCurrentlyActive(
+			params.ctx,
+			catpb.RegionName(region),
+		); err != nil {
+			return err
+		}
 	}
 
 	// Get the type descriptor for the multi-region enum.
@@ -223,39 +234,50 @@ func (n *alterDatabaseAddRegionNode) startExec(params runParams) error {
 		return err
 	}
 
-	placement, err := GetMultiRegionEnumAddValuePlacementCCL(
-		params.p.ExecCfg(),
-		typeDesc,
-		n.n.Region,
-	)
-	if err != nil {
-		return err
-	}
+	// All the regions are added to the enum as part of the same type schema
+	// change job, so that they are promoted, and the zone configurations are
+	// rewritten, only once.
+	added := make(tree.NameList, 0, len(n.n.Regions))
+	for _, region := range n.n.Regions {
+		placement, err := GetMultiRegionEnumAddValuePlacementCCL(
+			params.p.ExecCfg(),
+			typeDesc,
+			region,
+		)
+		if err != nil {
+			return err
+		}
 
-	// Add the new region value to the enum. This function adds the value to the enum and
-	// persists the new value to the supplied type descriptor.
-	jobDesc := fmt.Sprintf("Adding new region value %q to %q", tree.EnumValue(n.n.Region), tree.RegionEnum)
-	if err := params.p.addEnumValue(
-		params.ctx,
-		typeDesc,
-		&placement,
-		jobDesc,
-	); err != nil {
-		if pgerror.GetPGCode(err) == pgcode.DuplicateObject {
-			if n.n.IfNotExists {
-				params.p.BufferClientNotice(
-					params.ctx,
-					pgnotice.Newf("region %q already exists; skipping", n.n.Region),
+		// Add the new region value to the enum. This function adds the value to the enum and
+		// persists the new value to the supplied type descriptor.
+		jobDesc := fmt.Sprintf("Adding new region value %q to %q", tree.EnumValue(region), tree.RegionEnum)
+		if err := params.p.addEnumValue(
+			params.ctx,
+			typeDesc,
+			&placement,
+			jobDesc,
+		); err != nil {
+			if pgerror.GetPGCode(err) == pgcode.DuplicateObject {
+				if n.n.IfNotExists {
+					params.p.BufferClientNotice(
+						params.ctx,
+						pgnotice.Newf("region %q already exists; skipping", region),
+					)
+					continue
+				}
+				return pgerror.Newf(
+					pgcode.DuplicateObject,
+					"region %q already added to database",
+					region,
 				)
-				return nil
 			}
-			return pgerror.Newf(
-				pgcode.DuplicateObject,
-				"region %q already added to database",
-				n.n.Region,
-			)
+			return err
 		}
-		return err
+		added = append(added, region)
+	}
+
+	if len(added) == 0 {
+		return nil
 	}
 
 	// Validate the type descriptor after the changes. We have to do this explicitly here, because
@@ -264,15 +286,21 @@ func (n *alterDatabaseAddRegionNode) startExec(params runParams) error {
 		return err
 	}
 
-	// Log Alter Database Add Region event. This is an auditable log event and is
-	// recorded in the same transaction as the database descriptor, type
-	// descriptor, and zone configuration updates.
-	return params.p.logEvent(params.ctx,
-		n.desc.GetID(),
-		&eventpb.AlterDatabaseAddRegion{
-			DatabaseName: n.desc.GetName(),
-			RegionName:   n.n.Region.String(),
-		})
+	// Log an Alter Database Add Region event for each added region. These are
+	// auditable log events and are recorded in the same transaction as the
+	// database descriptor, type descriptor, and zone configuration updates.
+	for _, region := range added {
+		telemetry.Inc(sqltelemetry.AlterDatabaseAddRegionCounter)
+		if err := params.p.logEvent(params.ctx,
+			n.desc.GetID(),
+			&eventpb.AlterDatabaseAddRegion{
+				DatabaseName: n.desc.GetName(),
+				RegionName:   region.String(),
+			}); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (n *alterDatabaseAddRegionNode) Next(runParams) (bool, error) { return false, nil }
@@ -284,6 +312,9 @@ type alterDatabaseDropRegionNode struct {
 	desc                  *dbdesc.Mutable
 	removingPrimaryRegion bool
 	toDrop                []*typedesc.Mutable
+	// rehomeTo is the region to which the rows of the REGIONAL BY ROW tables
+	// homed in the dropped region are rehomed, with DROP REGION ... CASCADE.
+	rehomeTo catpb.RegionName
 }
 
 var allowDropFinalRegion = settings.RegisterBoolSetting(
@@ -424,18 +455,127 @@ func (p *planner) AlterDatabaseDropRegion(
 	if err != nil {
 		return nil, err
 	}
-	if err := multiregion.CanDropRegion(catpb.RegionName(n.Region), regionConfig); err != nil {
+	if err := multiregion.CanDropRegion(regionConfig); err != nil {
 		return nil, err
 	}
+	deps, err := p.regionDependencies(ctx, dbDesc, regionConfig, catpb.RegionName(n.Region), n.DropBehavior)
+	if err != nil {
+		return nil, err
+	}
+	if len(deps) > 0 {
+		return nil, errors.WithHint(
+			errors.WithDetail(
+				pgerror.Newf(pgcode.DependentObjectsStillExist,
+					"cannot drop region %q because other objects depend on it", n.Region),
+				strings.Join(deps, "\n"),
+			),
+			"you must first drop or alter the objects which depend on the region; "+
+				"use DROP REGION ... CASCADE to rehome the rows of REGIONAL BY ROW tables",
+		)
+	}
+
+	// With CASCADE, the rows of the REGIONAL BY ROW tables which are homed in
+	// the dropped region are rehomed to the given region, or to the primary
+	// region, by the type schema change job.
+	var rehomeTo catpb.RegionName
+	if n.DropBehavior == tree.DropCascade {
+		rehomeTo = regionConfig.PrimaryRegion()
+		if n.RehomeTo != "" {
+			rehomeTo = catpb.RegionName(n.RehomeTo)
+		}
+		if rehomeTo == catpb.RegionName(n.Region) {
+			return nil, pgerror.Newf(
+				pgcode.InvalidParameterValue,
+				"cannot rehome the rows to region %q as it is being dropped",
+				rehomeTo,
+			)
+		}
+		if !regionConfig.IsValidRegionNameString(string(rehomeTo)) {
+			return nil, pgerror.Newf(
+				pgcode.UndefinedObject,
+				"cannot rehome the rows to region %q as it has not been added to the database",
+				rehomeTo,
+			)
+		}
+	}
 
 	return &alterDatabaseDropRegionNode{
 		n,
 		dbDesc,
 		removingPrimaryRegion,
 		toDrop,
+		rehomeTo,
 	}, nil
 }
 
+// regionDependencies returns a description of each object which prevents the
+// given region from being dropped from the database: the super regions it is a
+// member of, the secondary region, the default table locality and the REGIONAL
+// BY TABLE tables homed in the region. Unless the rows are rehomed with
+// CASCADE, the REGIONAL BY ROW tables which have rows homed in the region are
+// included as well, since their partition for the region cannot be dropped.
+func (p *planner) regionDependencies(
+	ctx context.Context,
+	dbDesc *dbdesc.Mutable,
+	regionConfig multiregion.RegionConfig,
+	region catpb.RegionName,
+	behavior tree.DropBehavior,
+) ([]string, error) {
+	deps := multiregion.RegionDependencies(region, regionConfig)
+	if homedInDefaultTableLocality(dbDesc, region) {
+		deps = append(deps, fmt.Sprintf(
+			"region %q is used by the default table locality of the database", region,
+		))
+	}
+	// The rows of the tables can only be checked for regions which are values
+	// of the multi-region enum. The type schema changer reports regions which
+	// have not been added to the database.
+	if !regionConfig.IsValidRegionNameString(string(region)) {
+		return deps, nil
+	}
+	if err := p.forEachMutableTableInDatabase(ctx, dbDesc,
+		func(ctx context.Context, scName string, tbDesc *tabledesc.Mutable) error {
+			tn := tree.MakeTableNameWithSchema(
+				tree.Name(dbDesc.GetName()), tree.Name(scName), tree.Name(tbDesc.GetName()),
+			)
+			switch {
+			case tbDesc.IsLocalityRegionalByTable():
+				homedRegion, err := tbDesc.GetRegionalByTableRegion()
+				if err != nil {
+					return err
+				}
+				if homedRegion == region {
+					deps = append(deps, fmt.Sprintf("table %s is homed in region %q", tn.FQString(), region))
+				}
+			case tbDesc.IsLocalityRegionalByRow() && behavior != tree.DropCascade:
+				colName, err := tbDesc.GetRegionalByRowTableRegionColumnName()
+				if err != nil {
+					return err
+				}
+				row, err := p.QueryRowEx(
+					ctx,
+					"check-drop-region-rows",
+					p.txn,
+					sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+					fmt.Sprintf(
+						"SELECT 1 FROM [%d AS t] WHERE t.%s = %s LIMIT 1",
+						tbDesc.GetID(), colName.String(), lexbase.EscapeSQLString(string(region)),
+					),
+				)
+				if err != nil {
+					return err
+				}
+				if row != nil {
+					deps = append(deps, fmt.Sprintf("table %s has rows homed in region %q", tn.FQString(), region))
+				}
+			}
+			return nil
+		}); err != nil {
+		return nil, err
+	}
+	return deps, nil
+}
+
 // checkPrivilegesForMultiRegionOp ensures the current user has the required
 // privileges to perform a multi-region operation of the given (table|database)
 // descriptor. A multi-region operation can be altering the table's locality,
@@ -624,6 +764,15 @@ func (n *alterDatabaseDropRegionNode) startExec(params runParams) error {
 			}
 			return err
 		}
+		if n.rehomeTo != "" {
+			if err := params.p.setTypeSchemaChangeRehomeRowsToRegion(typeDesc, n.rehomeTo); err != nil {
+				return err
+			}
+		}
+		// The replica counts set for the region go away with it.
+		n.desc.SetRegionReplicaOverride(descpb.RegionReplicaOverride{
+			Region: catpb.RegionName(n.n.Region),
+		})
 	}
 
 	if err := params.p.writeNonDropDatabaseChange(
@@ -679,6 +828,19 @@ func (p *planner) AlterDatabasePrimaryRegion(
 	return &alterDatabasePrimaryRegionNode{n: n, desc: dbDesc}, nil
 }
 
+// fallbackRegions returns the fallback regions of the primary region set by
+// the statement, in order of preference.
+func (n *alterDatabasePrimaryRegionNode) fallbackRegions() catpb.RegionNames {
+	if len(n.n.FallbackRegions) == 0 {
+		return nil
+	}
+	ret := make(catpb.RegionNames, len(n.n.FallbackRegions))
+	for i, region := range n.n.FallbackRegions {
+		ret[i] = catpb.RegionName(region)
+	}
+	return ret
+}
+
 // switchPrimaryRegion performs the work in ALTER DATABASE ... PRIMARY REGION for the case
 // where the database is already a multi-region database.
 func (n *alterDatabasePrimaryRegionNode) switchPrimaryRegion(params runParams) error {
@@ -710,6 +872,26 @@ func (n *alterDatabasePrimaryRegionNode) switchPrimaryRegion(params runParams) e
 		)
 	}
 
+	if catpb.RegionName(n.n.PrimaryRegion) == prevRegionConfig.SecondaryRegion() {
+		return errors.WithHintf(
+			pgerror.Newf(pgcode.InvalidParameterValue,
+				"region %s is the secondary region of the database",
+				n.n.PrimaryRegion.String(),
+			),
+			"you must first drop the secondary region, using "+
+				"ALTER DATABASE %s DROP SECONDARY REGION, or set another secondary region",
+			n.n.Name.String(),
+		)
+	}
+
+	fallbackRegions := n.fallbackRegions()
+	if err := multiregion.CanSetFallbackRegions(
+		catpb.RegionName(n.n.PrimaryRegion), fallbackRegions, prevRegionConfig,
+	); err != nil {
+		return err
+	}
+	hadFallbackRegions := len(prevRegionConfig.FallbackRegions()) > 0
+
 	// Get the type descriptor for the multi-region enum.
 	typeDesc, err := params.p.Descriptors().GetMutableTypeVersionByID(
 		params.ctx,
@@ -724,6 +906,9 @@ func (n *alterDatabasePrimaryRegionNode) switchPrimaryRegion(params runParams) e
 	// To update the primary region we need to modify the database descriptor,
 	// update the multi-region enum, and write a new zone configuration.
 	n.desc.RegionConfig.PrimaryRegion = catpb.RegionName(n.n.PrimaryRegion)
+	// The fallback regions belong to the primary region, so they are reset
+	// unless they are listed again.
+	n.desc.RegionConfig.FallbackRegions = fallbackRegions
 	if err := params.p.writeNonDropDatabaseChange(
 		params.ctx,
 		n.desc,
@@ -792,11 +977,20 @@ func (n *alterDatabasePrimaryRegionNode) switchPrimaryRegion(params runParams) e
 
 	// If the old or new primary region is a member of a super region, we also
 	// have to update regional tables that are part of the default region.
-	opts := WithOnlyGlobalTables
+	opts := WithOnlyGlobalTablesAndTables(
+		catalog.MakeDescriptorIDSet(n.desc.RegionConfig.PlacementExceptions...),
+	)
 	if isNewPrimaryRegionMemberOfASuperRegion || isOldPrimaryRegionMemberOfASuperRegion {
 		opts = WithOnlyRegionalTablesAndGlobalTables
 	}
 
+	// The leases of the tables and partitions homed in the primary region fall
+	// back to the fallback regions, so the zone configurations of all the
+	// tables must be rebuilt if they are or were set.
+	if hadFallbackRegions || len(fallbackRegions) > 0 {
+		return params.p.updateZoneConfigsForTables(params.ctx, n.desc)
+	}
+
 	// Update all GLOBAL tables' zone configurations. This is required as if
 	// LOCALITY GLOBAL is used with PLACEMENT RESTRICTED, the global tables' zone
 	// configs must be explicitly rebuilt so as to move their primary region.
@@ -909,10 +1103,18 @@ func (n *alterDatabasePrimaryRegionNode) setInitialPrimaryRegion(params runParam
 		n.n.PrimaryRegion,
 		[]tree.Name{n.n.PrimaryRegion},
 		tree.DataPlacementUnspecified,
+		"", /* secondaryRegion */
 	)
 	if err != nil {
 		return err
 	}
+	// The primary region is the only region of the database at this point, so
+	// this only reports the fallback regions which have not been added yet.
+	if err := multiregion.CanSetFallbackRegions(
+		regionConfig.PrimaryRegion(), n.fallbackRegions(), *regionConfig,
+	); err != nil {
+		return err
+	}
 
 	// Check we are writing valid zone configurations.
 	if err := params.p.validateAllMultiRegionZoneConfigsInDatabase(
@@ -1085,6 +1287,31 @@ func (n *alterDatabaseSurvivalGoalNode) startExec(params runParams) error {
 		}
 	}
 
+	survivalGoal, err := TranslateSurvivalGoal(n.n.SurvivalGoal)
+	if err != nil {
+		return err
+	}
+	prevRegionConfig, err := SynthesizeRegionConfig(params.ctx, params.p.txn, n.desc.ID, params.p.Descriptors())
+	if err != nil {
+		return err
+	}
+	var numVoters, numReplicas int32
+	if n.n.NumVoters != nil {
+		if numVoters, err = regionReplicaCount(n.n.NumVoters, "num_voters"); err != nil {
+			return err
+		}
+	}
+	if n.n.NumReplicas != nil {
+		if numReplicas, err = regionReplicaCount(n.n.NumReplicas, "num_replicas"); err != nil {
+			return err
+		}
+	}
+	if err := validateSurvivalGoalReplicas(
+		survivalGoal, numVoters, numReplicas, prevRegionConfig,
+	); err != nil {
+		return err
+	}
+
 	if err := params.p.validateZoneConfigForMultiRegionDatabaseWasNotModifiedByUser(
 		params.ctx,
 		n.desc,
@@ -1097,13 +1324,17 @@ func (n *alterDatabaseSurvivalGoalNode) startExec(params runParams) error {
 			n.n.SurvivalGoal.TelemetryName(),
 		),
 	)
+	telemetry.Inc(
+		sqltelemetry.AlterDatabaseSurvivalGoalChangeCounter(
+			survivalGoalTelemetryName(n.desc.RegionConfig.SurvivalGoal),
+			n.n.SurvivalGoal.TelemetryName(),
+		),
+	)
 
-	// Update the survival goal in the database descriptor
-	survivalGoal, err := TranslateSurvivalGoal(n.n.SurvivalGoal)
-	if err != nil {
-		return err
-	}
+	// Update the survival goal in the database descriptor. The replica counts
+	// are reset unless they are specified again along with the survival goal.
 	n.desc.RegionConfig.SurvivalGoal = survivalGoal
+	n.desc.SetSurvivalGoalReplicas(numVoters, numReplicas)
 
 	if err := params.p.writeNonDropDatabaseChange(
 		params.ctx,
@@ -1147,6 +1378,47 @@ func (n *alterDatabaseSurvivalGoalNode) startExec(params runParams) error {
 	)
 }
 
+// validateSurvivalGoalReplicas returns an error if the replica counts given
+// along with a survival goal cannot be used to place the replicas of the
+// database. A count of 0 denotes the count derived from the survival goal.
+func validateSurvivalGoalReplicas(
+	survivalGoal descpb.SurvivalGoal,
+	numVoters, numReplicas int32,
+	regionConfig multiregion.RegionConfig,
+) error {
+	// At least 3 voters are needed to survive the failure of a zone, and to
+	// keep a minority of the voters in the home region under region
+	// survivability.
+	const minNumVoters = 3
+	if numVoters > 0 && numVoters < minNumVoters {
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"num_voters must be at least %d, got %d", minNumVoters, numVoters,
+		)
+	}
+	if numReplicas == 0 {
+		return nil
+	}
+	numRegions := len(regionConfig.Regions())
+	if numVoters == 0 {
+		numVoters, _ = getNumVotersAndNumReplicas(
+			numRegions, survivalGoal, regionConfig.IsPlacementRestricted(),
+		)
+	}
+	minNumReplicas := getMinNumReplicas(
+		numVoters, numRegions, survivalGoal, regionConfig.IsPlacementRestricted(),
+	)
+	if numReplicas < minNumReplicas {
+		return errors.WithDetailf(
+			pgerror.Newf(pgcode.InvalidParameterValue,
+				"num_replicas must be at least %d, got %d", minNumReplicas, numReplicas,
+			),
+			"%d voting replicas are placed across the %d regions of the database",
+			numVoters, numRegions,
+		)
+	}
+	return nil
+}
+
 func (n *alterDatabaseSurvivalGoalNode) Next(runParams) (bool, error) { return false, nil }
 func (n *alterDatabaseSurvivalGoalNode) Values() tree.Datums          { return tree.Datums{} }
 func (n *alterDatabaseSurvivalGoalNode) Close(context.Context)        {}
@@ -1154,6 +1426,8 @@ func (n *alterDatabaseSurvivalGoalNode) Close(context.Context)        {}
 type alterDatabasePlacementNode struct {
 	n    *tree.AlterDatabasePlacement
 	desc *dbdesc.Mutable
+	// exceptions are the IDs of the tables in n.ExceptTables.
+	exceptions []descpb.ID
 }
 
 // AlterDatabasePlacement transforms a tree.AlterDatabasePlacement into a plan node.
@@ -1189,7 +1463,29 @@ func (p *planner) AlterDatabasePlacement(
 		return nil, err
 	}
 
-	return &alterDatabasePlacementNode{n: n, desc: dbDesc}, nil
+	var exceptions catalog.DescriptorIDSet
+	for i := range n.ExceptTables {
+		tn := &n.ExceptTables[i]
+		_, tableDesc, err := p.ResolveMutableTableDescriptor(ctx, tn, true, tree.ResolveAnyTableKind)
+		if err != nil {
+			return nil, err
+		}
+		if tableDesc.GetParentID() != dbDesc.GetID() {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"table %s is not in database %s", tn.FQString(), n.Name,
+			)
+		}
+		if err := p.checkPrivilegesForMultiRegionOp(ctx, tableDesc); err != nil {
+			return nil, err
+		}
+		exceptions.Add(tableDesc.GetID())
+	}
+
+	return &alterDatabasePlacementNode{
+		n:          n,
+		desc:       dbDesc,
+		exceptions: exceptions.Ordered(),
+	}, nil
 }
 
 func (n *alterDatabasePlacementNode) startExec(params runParams) error {
@@ -1226,6 +1522,9 @@ func (n *alterDatabasePlacementNode) startExec(params runParams) error {
 			n.n.Placement.TelemetryName(),
 		),
 	)
+	if len(n.n.ExceptTables) > 0 {
+		telemetry.Inc(sqltelemetry.AlterDatabasePlacementExceptTablesCounter)
+	}
 
 	// Update the placement strategy in the database descriptor
 	newPlacement, err := TranslateDataPlacement(n.n.Placement)
@@ -1234,6 +1533,14 @@ func (n *alterDatabasePlacementNode) startExec(params runParams) error {
 	}
 	n.desc.SetPlacement(newPlacement)
 
+	// The tables whose placement changes are the exceptions before and after
+	// the statement.
+	changedExceptions := catalog.MakeDescriptorIDSet(n.desc.RegionConfig.PlacementExceptions...)
+	for _, id := range n.exceptions {
+		changedExceptions.Add(id)
+	}
+	n.desc.SetPlacementExceptions(n.exceptions)
+
 	if err := params.p.writeNonDropDatabaseChange(
 		params.ctx,
 		n.desc,
@@ -1266,11 +1573,12 @@ func (n *alterDatabasePlacementNode) startExec(params runParams) error {
 	// Regardless of the transition direction (DEFAULT -> RESTRICTED, RESTRICTED
 	// -> DEFAULT), we need to refresh the zone configuration of all GLOBAL
 	// table's inside the database to either carry a bespoke configuration or go
-	// back to inheriting it from the database.
+	// back to inheriting it from the database. The same holds for the tables
+	// which are, or were, exceptions to the RESTRICTED placement policy.
 	if err := params.p.updateZoneConfigsForTables(
 		params.ctx,
 		n.desc,
-		WithOnlyGlobalTables,
+		WithOnlyGlobalTablesAndTables(changedExceptions),
 	); err != nil {
 		return err
 	}
@@ -1347,39 +1655,14 @@ func (n *alterDatabaseAddSuperRegion) startExec(params runParams) error {
 		return err
 	}
 
-	regionNames, err := typeDesc.RegionNames()
+	regions, err := validateSuperRegionRegions(n.desc, typeDesc, n.n.SuperRegionName, n.n.Regions)
 	if err != nil {
 		return err
 	}
-
-	regionsInDatabase := make(map[catpb.RegionName]struct{})
-	for _, regionName := range regionNames {
-		regionsInDatabase[regionName] = struct{}{}
-	}
-
-	regionSet := make(map[catpb.RegionName]struct{})
-	regions := make([]catpb.RegionName, len(n.n.Regions))
-
-	// Check that the region is part of the database.
-	// And create a slice of the regions in the super region.
-	for i, region := range n.n.Regions {
-		_, found := regionsInDatabase[catpb.RegionName(region)]
-		if !found {
-			return errors.Newf("region %s not part of database", region)
-		}
-
-		regionSet[catpb.RegionName(region)] = struct{}{}
-		regions[i] = catpb.RegionName(region)
-	}
-
-	if err := multiregion.CanSatisfySurvivalGoal(n.desc.RegionConfig.SurvivalGoal, len(n.n.Regions)); err != nil {
-		return errors.Wrapf(err, "super region %s only has %d regions", n.n.SuperRegionName, len(n.n.Regions))
+	if err := checkSecondaryRegionInSuperRegion(n.desc, n.n.SuperRegionName, regions); err != nil {
+		return err
 	}
 
-	sort.Slice(regions, func(i, j int) bool {
-		return regions[i] < regions[j]
-	})
-
 	// Ensure that the super region name is not already used and that
 	// the super regions don't overlap.
 	for _, superRegion := range typeDesc.RegionConfig.SuperRegions {
@@ -1387,10 +1670,8 @@ func (n *alterDatabaseAddSuperRegion) startExec(params runParams) error {
 			return errors.Newf("super region %s already exists", superRegion.SuperRegionName)
 		}
 
-		for _, region := range superRegion.Regions {
-			if _, found := regionSet[region]; found {
-				return errors.Newf("region %s is already defined in super region %s", region, superRegion.SuperRegionName)
-			}
+		if err := checkSuperRegionsDoNotOverlap(superRegion, regions); err != nil {
+			return err
 		}
 	}
 
@@ -1399,6 +1680,8 @@ func (n *alterDatabaseAddSuperRegion) startExec(params runParams) error {
 		Regions:         regions,
 	})
 
+	telemetry.Inc(sqltelemetry.AlterDatabaseAddSuperRegionCounter)
+
 	if err := params.p.writeTypeSchemaChange(params.ctx, typeDesc, tree.AsStringWithFQNames(n.n, params.Ann())); err != nil {
 		return err
 	}
@@ -1414,6 +1697,89 @@ func (n *alterDatabaseAddSuperRegion) startExec(params runParams) error {
 	return nil
 }
 
+// validateSuperRegionRegions checks that the regions of a super region are
+// part of the database and that there are enough of them to satisfy the
+// survival goal of the database. It returns the regions in sorted order.
+func validateSuperRegionRegions(
+	desc *dbdesc.Mutable,
+	typeDesc *typedesc.Mutable,
+	superRegionName tree.Name,
+	superRegionRegions []tree.Name,
+) ([]catpb.RegionName, error) {
+	regionNames, err := typeDesc.RegionNames()
+	if err != nil {
+		return nil, err
+	}
+
+	regionsInDatabase := make(map[catpb.RegionName]struct{})
+	for _, regionName := range regionNames {
+		regionsInDatabase[regionName] = struct{}{}
+	}
+
+	regions := make([]catpb.RegionName, len(superRegionRegions))
+
+	// Check that the region is part of the database.
+	// And create a slice of the regions in the super region.
+	for i, region := range superRegionRegions {
+		_, found := regionsInDatabase[catpb.RegionName(region)]
+		if !found {
+			return nil, errors.Newf("region %s not part of database", region)
+		}
+
+		regions[i] = catpb.RegionName(region)
+	}
+
+	if err := multiregion.CanSatisfySurvivalGoal(desc.RegionConfig.SurvivalGoal, len(superRegionRegions)); err != nil {
+		return nil, errors.Wrapf(err, "super region %s only has %d regions", superRegionName, len(superRegionRegions))
+	}
+
+	sort.Slice(regions, func(i, j int) bool {
+		return regions[i] < regions[j]
+	})
+
+	return regions, nil
+}
+
+// checkSecondaryRegionInSuperRegion returns an error if exactly one of the
+// primary and secondary regions of the database is part of the given regions
+// of a super region, as the leaseholders must not fall back outside of the
+// super region of the primary region.
+func checkSecondaryRegionInSuperRegion(
+	desc *dbdesc.Mutable, superRegionName tree.Name, regions []catpb.RegionName,
+) error {
+	primaryRegion, secondaryRegion := desc.RegionConfig.PrimaryRegion, desc.RegionConfig.SecondaryRegion
+	if secondaryRegion == "" {
+		return nil
+	}
+	var hasPrimaryRegion, hasSecondaryRegion bool
+	for _, region := range regions {
+		hasPrimaryRegion = hasPrimaryRegion || region == primaryRegion
+		hasSecondaryRegion = hasSecondaryRegion || region == secondaryRegion
+	}
+	if hasPrimaryRegion != hasSecondaryRegion {
+		return errors.WithHintf(
+			pgerror.Newf(pgcode.InvalidParameterValue,
+				"the secondary region %s must be in the same super region as the primary region %s",
+				secondaryRegion, primaryRegion),
+			"super region %s must contain either both or neither of the regions", superRegionName,
+		)
+	}
+	return nil
+}
+
+// checkSuperRegionsDoNotOverlap returns an error if any of the given regions
+// is part of the given super region.
+func checkSuperRegionsDoNotOverlap(superRegion descpb.SuperRegion, regions []catpb.RegionName) error {
+	for _, region := range superRegion.Regions {
+		for _, r := range regions {
+			if region == r {
+				return errors.Newf("region %s is already defined in super region %s", region, superRegion.SuperRegionName)
+			}
+		}
+	}
+	return nil
+}
+
 // addSuperRegion adds the super region in sorted order based on the
 // name of super region.
 func addSuperRegion(r *descpb.TypeDescriptor_RegionConfig, superRegion descpb.SuperRegion) {
@@ -1492,8 +1858,10 @@ func (n *alterDatabaseDropSuperRegion) startExec(params runParams) error {
 	}
 
 	found := false
+	var droppedRegions []catpb.RegionName
 	for i, superRegion := range typeDesc.RegionConfig.SuperRegions {
 		if superRegion.SuperRegionName == string(n.n.SuperRegionName) {
+			droppedRegions = superRegion.Regions
 			typeDesc.RegionConfig.SuperRegions = append(typeDesc.RegionConfig.SuperRegions[:i], typeDesc.RegionConfig.SuperRegions[i+1:]...)
 			found = true
 			break
@@ -1504,6 +1872,14 @@ func (n *alterDatabaseDropSuperRegion) startExec(params runParams) error {
 		return errors.Newf("super region %s not found", n.n.SuperRegionName)
 	}
 
+	if err := params.p.checkSuperRegionDataUnpinned(
+		params.ctx, n.desc, n.n.SuperRegionName, droppedRegions, n.n.Override,
+	); err != nil {
+		return err
+	}
+
+	telemetry.Inc(sqltelemetry.AlterDatabaseDropSuperRegionCounter)
+
 	if err := params.p.writeTypeSchemaChange(params.ctx, typeDesc, tree.AsStringWithFQNames(n.n, params.Ann())); err != nil {
 		return err
 	}
@@ -1523,26 +1899,273 @@ func (n *alterDatabaseDropSuperRegion) Next(runParams) (bool, error) { return fa
 func (n *alterDatabaseDropSuperRegion) Values() tree.Datums          { return tree.Datums{} }
 func (n *alterDatabaseDropSuperRegion) Close(context.Context)        {}
 
-func (p *planner) isSuperRegionEnabled() error {
-	if !p.SessionData().EnableSuperRegions {
-		return errors.WithTelemetry(
-			pgerror.WithCandidateCode(
-				errors.WithHint(errors.New("super regions are only supported experimentally"),
-					"You can enable super regions by running `SET enable_super_regions = 'on'`."),
-				pgcode.FeatureNotSupported),
-			"sql.schema.super_regions_disabled",
-		)
-	}
-
-	return nil
+type alterDatabaseAlterSuperRegion struct {
+	n    *tree.AlterDatabaseAlterSuperRegion
+	desc *dbdesc.Mutable
 }
 
-func (p *planner) getSuperRegionsForDatabase(
-	ctx context.Context, desc catalog.DatabaseDescriptor,
-) ([]descpb.SuperRegion, error) {
-	typeID, err := desc.MultiRegionEnumID()
-	if err != nil {
-		return nil, err
+// AlterDatabaseAlterSuperRegion transforms a
+// tree.AlterDatabaseAlterSuperRegion into a plan node.
+func (p *planner) AlterDatabaseAlterSuperRegion(
+	ctx context.Context, n *tree.AlterDatabaseAlterSuperRegion,
+) (planNode, error) {
+	if err := p.isSuperRegionEnabled(); err != nil {
+		return nil, err
+	}
+
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER DATABASE",
+	); err != nil {
+		return nil, err
+	}
+
+	dbDesc, err := p.Descriptors().GetMutableDatabaseByName(ctx, p.txn, string(n.DatabaseName),
+		tree.DatabaseLookupFlags{Required: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkPrivilegesForMultiRegionOp(ctx, dbDesc); err != nil {
+		return nil, err
+	}
+
+	return &alterDatabaseAlterSuperRegion{n: n, desc: dbDesc}, nil
+}
+
+func (n *alterDatabaseAlterSuperRegion) startExec(params runParams) error {
+	// If the database is not a multi-region database, there should not be any
+	// super regions.
+	if !n.desc.IsMultiRegion() {
+		return errors.WithHintf(
+			pgerror.New(pgcode.InvalidName,
+				"database must be multi-region to support super regions",
+			),
+			"you must first add a primary region to the database using "+
+				"ALTER DATABASE %s PRIMARY REGION <region_name>",
+			n.n.DatabaseName.String(),
+		)
+	}
+
+	typeID, err := n.desc.MultiRegionEnumID()
+	if err != nil {
+		return err
+	}
+	typeDesc, err := params.p.Descriptors().GetMutableTypeVersionByID(params.ctx, params.p.txn, typeID)
+	if err != nil {
+		return err
+	}
+
+	regions, err := validateSuperRegionRegions(n.desc, typeDesc, n.n.SuperRegionName, n.n.Regions)
+	if err != nil {
+		return err
+	}
+	if err := checkSecondaryRegionInSuperRegion(n.desc, n.n.SuperRegionName, regions); err != nil {
+		return err
+	}
+
+	// Ensure that the super region exists and that it does not overlap with
+	// the other super regions.
+	idx := -1
+	for i, superRegion := range typeDesc.RegionConfig.SuperRegions {
+		if superRegion.SuperRegionName == string(n.n.SuperRegionName) {
+			idx = i
+			continue
+		}
+
+		if err := checkSuperRegionsDoNotOverlap(superRegion, regions); err != nil {
+			return err
+		}
+	}
+
+	if idx == -1 {
+		return errors.Newf("super region %s not found", n.n.SuperRegionName)
+	}
+
+	var removedRegions []catpb.RegionName
+	for _, region := range typeDesc.RegionConfig.SuperRegions[idx].Regions {
+		kept := false
+		for _, r := range regions {
+			if r == region {
+				kept = true
+				break
+			}
+		}
+		if !kept {
+			removedRegions = append(removedRegions, region)
+		}
+	}
+	if err := params.p.checkSuperRegionDataUnpinned(
+		params.ctx, n.desc, n.n.SuperRegionName, removedRegions, n.n.Override,
+	); err != nil {
+		return err
+	}
+
+	// The regions of the super region are modified in place, rather than
+	// through DROP SUPER REGION and ADD SUPER REGION, so that the data of the
+	// regional by row partitions is never left unconstrained to the super
+	// region.
+	typeDesc.RegionConfig.SuperRegions[idx].Regions = regions
+
+	telemetry.Inc(sqltelemetry.AlterDatabaseAlterSuperRegionCounter)
+
+	if err := params.p.writeTypeSchemaChange(params.ctx, typeDesc, tree.AsStringWithFQNames(n.n, params.Ann())); err != nil {
+		return err
+	}
+
+	// Update all regional and regional by row tables.
+	if err := params.p.updateZoneConfigsForTables(
+		params.ctx,
+		n.desc,
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// checkSuperRegionDataUnpinned is called before the given regions are removed
+// from a super region. The data of the tables homed in those regions is no
+// longer constrained to the super region once they are removed, so an error
+// listing the tables is returned unless override is set, in which case the
+// tables are listed in a notice instead.
+func (p *planner) checkSuperRegionDataUnpinned(
+	ctx context.Context,
+	dbDesc *dbdesc.Mutable,
+	superRegionName tree.Name,
+	regions []catpb.RegionName,
+	override bool,
+) error {
+	if len(regions) == 0 {
+		return nil
+	}
+	tables, err := p.tablesWithDataHomedInRegions(ctx, dbDesc, regions)
+	if err != nil {
+		return err
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+	regionNames := make(tree.NameList, len(regions))
+	for i, region := range regions {
+		regionNames[i] = tree.Name(region)
+	}
+	if !override {
+		return errors.WithHintf(
+			errors.WithDetailf(
+				pgerror.Newf(pgcode.DependentObjectsStillExist,
+					"cannot remove regions %s from super region %s as tables still have data homed in them",
+					tree.AsString(&regionNames), superRegionName,
+				),
+				"affected tables: %s", strings.Join(tables, ", "),
+			),
+			"the data of these tables will no longer be constrained to the super region; "+
+				"add WITH OVERRIDE to the statement to proceed anyway",
+		)
+	}
+	p.BufferClientNotice(
+		ctx,
+		pgnotice.Newf(
+			"the data of the following tables homed in regions %s is no longer constrained to super region %s: %s",
+			tree.AsString(&regionNames), superRegionName, strings.Join(tables, ", "),
+		),
+	)
+	return nil
+}
+
+// tablesWithDataHomedInRegions returns the names of the REGIONAL BY TABLE
+// tables homed in the given regions which have rows, and of the REGIONAL BY
+// ROW tables which have rows homed in the given regions.
+func (p *planner) tablesWithDataHomedInRegions(
+	ctx context.Context, dbDesc *dbdesc.Mutable, regions []catpb.RegionName,
+) ([]string, error) {
+	inRegions := make(map[catpb.RegionName]struct{}, len(regions))
+	var regionList strings.Builder
+	for i, region := range regions {
+		inRegions[region] = struct{}{}
+		if i > 0 {
+			regionList.WriteString(", ")
+		}
+		regionList.WriteString(lexbase.EscapeSQLString(string(region)))
+	}
+
+	var tables []string
+	if err := p.forEachMutableTableInDatabase(ctx, dbDesc,
+		func(ctx context.Context, scName string, tbDesc *tabledesc.Mutable) error {
+			var stmt string
+			switch {
+			case tbDesc.IsLocalityRegionalByTable():
+				region, err := tbDesc.GetRegionalByTableRegion()
+				if err != nil {
+					return err
+				}
+				if region == catpb.RegionName(tree.PrimaryRegionNotSpecifiedName) {
+					region = dbDesc.RegionConfig.PrimaryRegion
+				}
+				if _, ok := inRegions[region]; !ok {
+					return nil
+				}
+				stmt = fmt.Sprintf("SELECT 1 FROM [%d AS t] LIMIT 1", tbDesc.GetID())
+			case tbDesc.IsLocalityRegionalByRow():
+				colName, err := tbDesc.GetRegionalByRowTableRegionColumnName()
+				if err != nil {
+					return err
+				}
+				stmt = fmt.Sprintf(
+					"SELECT 1 FROM [%d AS t] WHERE t.%s IN (%s) LIMIT 1",
+					tbDesc.GetID(), colName.String(), regionList.String(),
+				)
+			default:
+				return nil
+			}
+			row, err := p.QueryRowEx(
+				ctx,
+				"check-super-region-data",
+				p.txn,
+				sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+				stmt,
+			)
+			if err != nil {
+				return err
+			}
+			if row != nil {
+				tn := tree.MakeTableNameWithSchema(
+					tree.Name(dbDesc.GetName()), tree.Name(scName), tree.Name(tbDesc.GetName()),
+				)
+				tables = append(tables, tn.FQString())
+			}
+			return nil
+		}); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+func (n *alterDatabaseAlterSuperRegion) Next(runParams) (bool, error) { return false, nil }
+func (n *alterDatabaseAlterSuperRegion) Values() tree.Datums          { return tree.Datums{} }
+func (n *alterDatabaseAlterSuperRegion) Close(context.Context)        {}
+
+func (p *planner) isSuperRegionEnabled() error {
+	if !p.SessionData().EnableSuperRegions {
+		return errors.WithTelemetry(
+			pgerror.WithCandidateCode(
+				errors.WithHint(errors.New("super regions are only supported experimentally"),
+					"You can enable super regions by running `SET enable_super_regions = 'on'`."),
+				pgcode.FeatureNotSupported),
+			"sql.schema.super_regions_disabled",
+		)
+	}
+
+	return nil
+}
+
+func (p *planner) getSuperRegionsForDatabase(
+	ctx context.Context, desc catalog.DatabaseDescriptor,
+) ([]descpb.SuperRegion, error) {
+	typeID, err := desc.MultiRegionEnumID()
+	if err != nil {
+		return nil, err
 	}
 	typeDesc, err := p.Descriptors().GetMutableTypeVersionByID(ctx, p.txn, typeID)
 	if err != nil {
@@ -1551,3 +2174,907 @@ func (p *planner) getSuperRegionsForDatabase(
 
 	return typeDesc.RegionConfig.SuperRegions, nil
 }
+
+type alterDatabaseSecondaryRegion struct {
+	n    *tree.AlterDatabaseSecondaryRegion
+	desc *dbdesc.Mutable
+}
+
+// AlterDatabaseSecondaryRegion transforms a tree.AlterDatabaseSecondaryRegion
+// into a plan node.
+func (p *planner) AlterDatabaseSecondaryRegion(
+	ctx context.Context, n *tree.AlterDatabaseSecondaryRegion,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER DATABASE",
+	); err != nil {
+		return nil, err
+	}
+
+	dbDesc, err := p.Descriptors().GetMutableDatabaseByName(ctx, p.txn, string(n.DatabaseName),
+		tree.DatabaseLookupFlags{Required: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkPrivilegesForMultiRegionOp(ctx, dbDesc); err != nil {
+		return nil, err
+	}
+
+	return &alterDatabaseSecondaryRegion{n: n, desc: dbDesc}, nil
+}
+
+func (n *alterDatabaseSecondaryRegion) startExec(params runParams) error {
+	// If the database is not a multi-region database, a secondary region cannot
+	// be set.
+	if !n.desc.IsMultiRegion() {
+		return errors.WithHintf(
+			pgerror.New(pgcode.InvalidName,
+				"database must be multi-region to support a secondary region",
+			),
+			"you must first add a primary region to the database using "+
+				"ALTER DATABASE %s PRIMARY REGION <region_name>",
+			n.n.DatabaseName.String(),
+		)
+	}
+
+	prevRegionConfig, err := SynthesizeRegionConfig(params.ctx, params.p.txn, n.desc.ID, params.p.Descriptors())
+	if err != nil {
+		return err
+	}
+	if err := multiregion.CanSetSecondaryRegion(
+		catpb.RegionName(n.n.SecondaryRegion), prevRegionConfig,
+	); err != nil {
+		return err
+	}
+
+	if err := params.p.validateZoneConfigForMultiRegionDatabaseWasNotModifiedByUser(
+		params.ctx,
+		n.desc,
+	); err != nil {
+		return err
+	}
+
+	telemetry.Inc(sqltelemetry.AlterDatabaseSetSecondaryRegionCounter)
+
+	n.desc.RegionConfig.SecondaryRegion = catpb.RegionName(n.n.SecondaryRegion)
+	if err := params.p.writeNonDropDatabaseChange(
+		params.ctx,
+		n.desc,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	); err != nil {
+		return err
+	}
+
+	updatedRegionConfig, err := SynthesizeRegionConfig(
+		params.ctx, params.p.txn, n.desc.ID, params.p.Descriptors(),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Update the database's zone configuration.
+	if err := ApplyZoneConfigFromDatabaseRegionConfig(
+		params.ctx,
+		n.desc.ID,
+		updatedRegionConfig,
+		params.p.txn,
+		params.p.execCfg,
+	); err != nil {
+		return err
+	}
+
+	// Update the zone configurations of the tables, as the leases of the tables
+	// and partitions homed in the primary region fall back to the secondary
+	// region.
+	return params.p.updateZoneConfigsForTables(params.ctx, n.desc)
+}
+
+func (n *alterDatabaseSecondaryRegion) Next(runParams) (bool, error) { return false, nil }
+func (n *alterDatabaseSecondaryRegion) Values() tree.Datums          { return tree.Datums{} }
+func (n *alterDatabaseSecondaryRegion) Close(context.Context)        {}
+
+type alterDatabaseDropSecondaryRegion struct {
+	n    *tree.AlterDatabaseDropSecondaryRegion
+	desc *dbdesc.Mutable
+}
+
+// AlterDatabaseDropSecondaryRegion transforms a
+// tree.AlterDatabaseDropSecondaryRegion into a plan node.
+func (p *planner) AlterDatabaseDropSecondaryRegion(
+	ctx context.Context, n *tree.AlterDatabaseDropSecondaryRegion,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER DATABASE",
+	); err != nil {
+		return nil, err
+	}
+
+	dbDesc, err := p.Descriptors().GetMutableDatabaseByName(ctx, p.txn, string(n.DatabaseName),
+		tree.DatabaseLookupFlags{Required: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !dbDesc.IsMultiRegion() || dbDesc.RegionConfig.SecondaryRegion == "" {
+		if n.IfExists {
+			p.BufferClientNotice(
+				ctx,
+				pgnotice.Newf("database %q does not have a secondary region; skipping", n.DatabaseName),
+			)
+			return newZeroNode(nil /* columns */), nil
+		}
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"database %q does not have a secondary region", n.DatabaseName,
+		)
+	}
+
+	if err := p.checkPrivilegesForMultiRegionOp(ctx, dbDesc); err != nil {
+		return nil, err
+	}
+
+	return &alterDatabaseDropSecondaryRegion{n: n, desc: dbDesc}, nil
+}
+
+func (n *alterDatabaseDropSecondaryRegion) startExec(params runParams) error {
+	if err := params.p.validateZoneConfigForMultiRegionDatabaseWasNotModifiedByUser(
+		params.ctx,
+		n.desc,
+	); err != nil {
+		return err
+	}
+
+	telemetry.Inc(sqltelemetry.AlterDatabaseDropSecondaryRegionCounter)
+
+	n.desc.RegionConfig.SecondaryRegion = ""
+	if err := params.p.writeNonDropDatabaseChange(
+		params.ctx,
+		n.desc,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	); err != nil {
+		return err
+	}
+
+	// Synthesizing the region config validates that the database still
+	// satisfies its survival goal without the secondary region.
+	updatedRegionConfig, err := SynthesizeRegionConfig(
+		params.ctx, params.p.txn, n.desc.ID, params.p.Descriptors(),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Update the database's zone configuration.
+	if err := ApplyZoneConfigFromDatabaseRegionConfig(
+		params.ctx,
+		n.desc.ID,
+		updatedRegionConfig,
+		params.p.txn,
+		params.p.execCfg,
+	); err != nil {
+		return err
+	}
+
+	// Update the zone configurations of the tables, as the leases of the tables
+	// and partitions homed in the primary region no longer fall back to the
+	// secondary region.
+	return params.p.updateZoneConfigsForTables(params.ctx, n.desc)
+}
+
+func (n *alterDatabaseDropSecondaryRegion) Next(runParams) (bool, error) { return false, nil }
+func (n *alterDatabaseDropSecondaryRegion) Values() tree.Datums          { return tree.Datums{} }
+func (n *alterDatabaseDropSecondaryRegion) Close(context.Context)        {}
+
+type alterDatabaseRenameRegion struct {
+	n    *tree.AlterDatabaseRenameRegion
+	desc *dbdesc.Mutable
+}
+
+// AlterDatabaseRenameRegion transforms a tree.AlterDatabaseRenameRegion into a
+// plan node.
+func (p *planner) AlterDatabaseRenameRegion(
+	ctx context.Context, n *tree.AlterDatabaseRenameRegion,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER DATABASE",
+	); err != nil {
+		return nil, err
+	}
+
+	dbDesc, err := p.Descriptors().GetMutableDatabaseByName(ctx, p.txn, string(n.DatabaseName),
+		tree.DatabaseLookupFlags{Required: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !dbDesc.IsMultiRegion() {
+		return nil, pgerror.Newf(pgcode.InvalidDatabaseDefinition,
+			"cannot rename region %s on a database which is not multi-region",
+			n.OldRegion,
+		)
+	}
+	if err := p.checkPrivilegesForMultiRegionOp(ctx, dbDesc); err != nil {
+		return nil, err
+	}
+	// Renaming a region renames the partitions of all REGIONAL BY ROW tables.
+	if err := p.checkPrivilegesForRepartitioningRegionalByRowTables(
+		ctx,
+		dbDesc,
+	); err != nil {
+		return nil, err
+	}
+
+	return &alterDatabaseRenameRegion{n: n, desc: dbDesc}, nil
+}
+
+func (n *alterDatabaseRenameRegion) startExec(params runParams) error {
+	oldRegion := catpb.RegionName(n.n.OldRegion)
+	newRegion := catpb.RegionName(n.n.NewRegion)
+
+	// The old region may no longer exist in the cluster, as its locality may
+	// have been relabeled, but the new region must.
+	if err := params.p.checkRegionIsCurrentlyActive(params.ctx, newRegion); err != nil {
+		return err
+	}
+
+	if err := params.p.validateZoneConfigForMultiRegionDatabaseWasNotModifiedByUser(
+		params.ctx,
+		n.desc,
+	); err != nil {
+		return err
+	}
+
+	typeDesc, err := params.p.Descriptors().GetMutableTypeVersionByID(
+		params.ctx,
+		params.p.txn,
+		n.desc.RegionConfig.RegionEnumID,
+	)
+	if err != nil {
+		return err
+	}
+
+	found, member := findEnumMemberByName(typeDesc, tree.EnumValue(oldRegion))
+	if !found {
+		return pgerror.Newf(pgcode.UndefinedObject,
+			"region %q has not been added to the database", oldRegion,
+		)
+	}
+	if enumMemberIsRemoving(member) {
+		return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"region %q is being dropped", oldRegion,
+		)
+	}
+	if enumMemberIsAdding(member) {
+		return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+			"region %q is being added, try again later", oldRegion,
+		)
+	}
+	if found, _ := findEnumMemberByName(typeDesc, tree.EnumValue(newRegion)); found {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"region %q already added to database", newRegion,
+		)
+	}
+
+	// Only the logical representation of the enum member changes. The physical
+	// representation, which is what is stored in the rows and in the
+	// partitioning of the REGIONAL BY ROW tables, is left untouched.
+	member.LogicalRepresentation = string(newRegion)
+	if typeDesc.RegionConfig.PrimaryRegion == oldRegion {
+		typeDesc.RegionConfig.PrimaryRegion = newRegion
+	}
+	for i := range typeDesc.RegionConfig.SuperRegions {
+		superRegion := &typeDesc.RegionConfig.SuperRegions[i]
+		for j := range superRegion.Regions {
+			if superRegion.Regions[j] == oldRegion {
+				superRegion.Regions[j] = newRegion
+			}
+		}
+		// The regions of a super region are kept sorted.
+		sort.Slice(superRegion.Regions, func(a, b int) bool {
+			return superRegion.Regions[a] < superRegion.Regions[b]
+		})
+	}
+	jobDesc := tree.AsStringWithFQNames(n.n, params.Ann())
+	if err := params.p.writeTypeSchemaChange(params.ctx, typeDesc, jobDesc); err != nil {
+		return err
+	}
+
+	if n.desc.RegionConfig.PrimaryRegion == oldRegion {
+		n.desc.RegionConfig.PrimaryRegion = newRegion
+	}
+	if n.desc.RegionConfig.SecondaryRegion == oldRegion {
+		n.desc.RegionConfig.SecondaryRegion = newRegion
+	}
+	for i := range n.desc.RegionConfig.FallbackRegions {
+		if n.desc.RegionConfig.FallbackRegions[i] == oldRegion {
+			n.desc.RegionConfig.FallbackRegions[i] = newRegion
+		}
+	}
+	if homedInDefaultTableLocality(n.desc, oldRegion) {
+		lc := tabledesc.LocalityConfigRegionalByTable(tree.Name(newRegion))
+		n.desc.SetDefaultTableLocality(&lc)
+	}
+	for _, override := range n.desc.RegionConfig.RegionReplicaOverrides {
+		if override.Region == oldRegion {
+			n.desc.SetRegionReplicaOverride(descpb.RegionReplicaOverride{Region: oldRegion})
+			override.Region = newRegion
+			n.desc.SetRegionReplicaOverride(override)
+			break
+		}
+	}
+	if err := params.p.writeNonDropDatabaseChange(params.ctx, n.desc, jobDesc); err != nil {
+		return err
+	}
+
+	// Re-home the REGIONAL BY TABLE tables which are homed in the renamed
+	// region.
+	b := params.p.Txn().NewBatch()
+	if err := params.p.forEachMutableTableInDatabase(
+		params.ctx,
+		n.desc,
+		func(ctx context.Context, scName string, tbDesc *tabledesc.Mutable) error {
+			if !tbDesc.IsLocalityRegionalByTable() {
+				return nil
+			}
+			rbt := tbDesc.LocalityConfig.GetRegionalByTable()
+			if rbt.Region == nil || *rbt.Region != oldRegion {
+				return nil
+			}
+			region := newRegion
+			rbt.Region = &region
+			return params.p.writeSchemaChangeToBatch(ctx, tbDesc, b)
+		},
+	); err != nil {
+		return err
+	}
+	if err := params.p.Txn().Run(params.ctx, b); err != nil {
+		return err
+	}
+
+	// Rename the partitions of the REGIONAL BY ROW tables and rewrite the zone
+	// configurations of the database and all of its tables in the same
+	// transaction, so that the zone configurations never refer to a region
+	// which does not exist.
+	regionChangeFinalizer, err := newDatabaseRegionChangeFinalizer(
+		params.ctx,
+		params.p.txn,
+		params.p.ExecCfg(),
+		params.p.Descriptors(),
+		n.desc.GetID(),
+		typeDesc.GetID(),
+	)
+	if err != nil {
+		return err
+	}
+	defer regionChangeFinalizer.cleanup()
+	if err := regionChangeFinalizer.finalize(params.ctx, params.p.txn); err != nil {
+		return err
+	}
+	return params.p.updateZoneConfigsForTables(
+		params.ctx,
+		n.desc,
+		WithOnlyRegionalTablesAndGlobalTables,
+	)
+}
+
+func (n *alterDatabaseRenameRegion) Next(runParams) (bool, error) { return false, nil }
+func (n *alterDatabaseRenameRegion) Values() tree.Datums          { return tree.Datums{} }
+func (n *alterDatabaseRenameRegion) Close(context.Context)        {}
+
+type alterDatabaseToMultiRegionNode struct {
+	n    *tree.AlterDatabaseToMultiRegion
+	desc *dbdesc.Mutable
+}
+
+// AlterDatabaseToMultiRegion transforms a tree.AlterDatabaseToMultiRegion into
+// a plan node.
+func (p *planner) AlterDatabaseToMultiRegion(
+	ctx context.Context, n *tree.AlterDatabaseToMultiRegion,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER DATABASE",
+	); err != nil {
+		return nil, err
+	}
+
+	dbDesc, err := p.Descriptors().GetMutableDatabaseByName(ctx, p.txn, string(n.Name),
+		tree.DatabaseLookupFlags{Required: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if dbDesc.GetID() == keys.SystemDatabaseID {
+		return nil, pgerror.Newf(
+			pgcode.FeatureNotSupported,
+			"converting the system database to a multi-region database is not supported",
+		)
+	}
+	if dbDesc.IsMultiRegion() {
+		return nil, errors.WithHintf(
+			pgerror.Newf(pgcode.InvalidDatabaseDefinition,
+				"database %s is already a multi-region database", n.Name,
+			),
+			"use ALTER DATABASE %s ADD REGION to add regions to the database",
+			n.Name,
+		)
+	}
+	if err := p.checkPrivilegesForMultiRegionOp(ctx, dbDesc); err != nil {
+		return nil, err
+	}
+
+	return &alterDatabaseToMultiRegionNode{n: n, desc: dbDesc}, nil
+}
+
+func (n *alterDatabaseToMultiRegionNode) startExec(params runParams) error {
+	// Validate as much as possible up front, so that the job does not fail
+	// part way through the conversion for reasons we could have caught here.
+	var regions []string
+	seen := make(map[catpb.RegionName]struct{})
+	for _, r := range append(tree.NameList{n.n.PrimaryRegion}, n.n.Regions...) {
+		region := catpb.RegionName(r)
+		if _, ok := seen[region]; ok {
+			continue
+		}
+		seen[region] = struct{}{}
+		if err := params.p.checkRegionIsCurrentlyActive(params.ctx, region); err != nil {
+			return err
+		}
+		if region != catpb.RegionName(n.n.PrimaryRegion) {
+			regions = append(regions, string(region))
+		}
+	}
+
+	survivalGoal, err := TranslateSurvivalGoal(n.n.SurvivalGoal)
+	if err != nil {
+		return err
+	}
+	if err := multiregion.CanSatisfySurvivalGoal(survivalGoal, len(seen)); err != nil {
+		return err
+	}
+
+	var tableLocality string
+	if l := n.n.TableLocality; l != nil {
+		if l.RegionalByRowColumn != tree.PrimaryRegionNotSpecifiedName || l.RegionalByRowExpr != nil {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"REGIONAL BY ROW AS is not supported when converting a database to a multi-region database",
+			)
+		}
+		if l.TableRegion != tree.PrimaryRegionNotSpecifiedName {
+			if _, ok := seen[catpb.RegionName(l.TableRegion)]; !ok {
+				return pgerror.Newf(pgcode.InvalidName,
+					"region %s is not one of the regions of the converted database",
+					l.TableRegion.String(),
+				)
+			}
+		}
+		// Tables are converted to REGIONAL BY TABLE IN PRIMARY REGION as part of
+		// setting the primary region, so there is nothing left to do for them.
+		if l.LocalityLevel != tree.LocalityLevelTable || l.TableRegion != tree.PrimaryRegionNotSpecifiedName {
+			tableLocality = tree.AsString(l)
+		}
+	}
+
+	if err := params.p.validateAllMultiRegionZoneConfigsInDatabase(
+		params.ctx,
+		n.desc,
+		&zoneConfigForMultiRegionValidatorSetInitialRegion{},
+	); err != nil {
+		return err
+	}
+	if err := params.p.forEachMutableTableInDatabase(
+		params.ctx,
+		n.desc,
+		func(ctx context.Context, scName string, tbDesc *tabledesc.Mutable) error {
+			if err := params.p.checkPrivilegesForMultiRegionOp(ctx, tbDesc); err != nil {
+				return err
+			}
+			return checkCanConvertTableToMultiRegion(n.desc, tbDesc)
+		},
+	); err != nil {
+		return err
+	}
+
+	_, err = params.p.extendedEvalCtx.QueueJob(params.ctx, jobs.Record{
+		Description:   tree.AsStringWithFQNames(n.n, params.Ann()),
+		Username:      params.p.User(),
+		DescriptorIDs: descpb.IDs{n.desc.GetID()},
+		Details: jobspb.MultiRegionConversionDetails{
+			DatabaseID:           n.desc.GetID(),
+			PrimaryRegion:        string(n.n.PrimaryRegion),
+			Regions:              regions,
+			SurviveRegionFailure: survivalGoal == descpb.SurvivalGoal_REGION_FAILURE,
+			TableLocality:        tableLocality,
+		},
+		// The database is converted back to a regular database if the job
+		// fails or is canceled; see multiRegionConversionResumer.OnFailOrCancel.
+		Progress: jobspb.MultiRegionConversionProgress{},
+	})
+	return err
+}
+
+func (n *alterDatabaseToMultiRegionNode) Next(runParams) (bool, error) { return false, nil }
+func (n *alterDatabaseToMultiRegionNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *alterDatabaseToMultiRegionNode) Close(context.Context)        {}
+
+type alterDatabaseRegionReplicas struct {
+	n    *tree.AlterDatabaseRegionReplicas
+	desc *dbdesc.Mutable
+}
+
+// AlterDatabaseRegionReplicas transforms a tree.AlterDatabaseRegionReplicas
+// into a plan node.
+func (p *planner) AlterDatabaseRegionReplicas(
+	ctx context.Context, n *tree.AlterDatabaseRegionReplicas,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER DATABASE",
+	); err != nil {
+		return nil, err
+	}
+
+	dbDesc, err := p.Descriptors().GetMutableDatabaseByName(ctx, p.txn, string(n.DatabaseName),
+		tree.DatabaseLookupFlags{Required: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkPrivilegesForMultiRegionOp(ctx, dbDesc); err != nil {
+		return nil, err
+	}
+
+	return &alterDatabaseRegionReplicas{n: n, desc: dbDesc}, nil
+}
+
+// regionReplicaCount returns the replica count given by ALTER DATABASE ...
+// SET REGION or ALTER DATABASE ... SURVIVE ... WITH, or 0 if it is reset to
+// the default.
+func regionReplicaCount(expr tree.Expr, name string) (int32, error) {
+	if _, ok := expr.(tree.DefaultVal); ok {
+		return 0, nil
+	}
+	numVal, ok := expr.(*tree.NumVal)
+	if !ok {
+		return 0, errors.AssertionFailedf("unexpected %s value %T", name, expr)
+	}
+	count, err := numVal.AsInt32()
+	if err != nil {
+		return 0, pgerror.Wrapf(err, pgcode.InvalidParameterValue, "invalid %s", name)
+	}
+	if count <= 0 {
+		return 0, pgerror.Newf(pgcode.InvalidParameterValue,
+			"%s must be positive, got %d", name, count,
+		)
+	}
+	return count, nil
+}
+
+func (n *alterDatabaseRegionReplicas) startExec(params runParams) error {
+	if !n.desc.IsMultiRegion() {
+		return errors.WithHintf(
+			pgerror.New(pgcode.InvalidDatabaseDefinition,
+				"database must be multi-region to set the replicas of a region",
+			),
+			"you must first add a primary region to the database using "+
+				"ALTER DATABASE %s PRIMARY REGION <region_name>",
+			n.n.DatabaseName.String(),
+		)
+	}
+
+	prevRegionConfig, err := SynthesizeRegionConfig(params.ctx, params.p.txn, n.desc.ID, params.p.Descriptors())
+	if err != nil {
+		return err
+	}
+	region := catpb.RegionName(n.n.Region)
+	if !prevRegionConfig.IsValidRegionNameString(string(region)) {
+		return pgerror.Newf(pgcode.UndefinedObject,
+			"region %q has not been added to the database", region,
+		)
+	}
+
+	override, _ := prevRegionConfig.ReplicaOverride(region)
+	override.Region = region
+	if n.n.NumVoters != nil {
+		if override.NumVoters, err = regionReplicaCount(n.n.NumVoters, "num_voters"); err != nil {
+			return err
+		}
+	}
+	if n.n.NumReplicas != nil {
+		if override.NumReplicas, err = regionReplicaCount(n.n.NumReplicas, "num_replicas"); err != nil {
+			return err
+		}
+	}
+	if override.NumVoters > 0 && override.NumReplicas > 0 && override.NumReplicas < override.NumVoters {
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"num_replicas (%d) of region %s must be greater than or equal to its num_voters (%d)",
+			override.NumReplicas, region, override.NumVoters,
+		)
+	}
+
+	if err := params.p.validateZoneConfigForMultiRegionDatabaseWasNotModifiedByUser(
+		params.ctx,
+		n.desc,
+	); err != nil {
+		return err
+	}
+
+	n.desc.SetRegionReplicaOverride(override)
+	if err := params.p.writeNonDropDatabaseChange(
+		params.ctx,
+		n.desc,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	); err != nil {
+		return err
+	}
+
+	updatedRegionConfig, err := SynthesizeRegionConfig(
+		params.ctx, params.p.txn, n.desc.ID, params.p.Descriptors(),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Update the database's zone configuration.
+	if err := ApplyZoneConfigFromDatabaseRegionConfig(
+		params.ctx,
+		n.desc.ID,
+		updatedRegionConfig,
+		params.p.txn,
+		params.p.execCfg,
+	); err != nil {
+		return err
+	}
+
+	// Update the zone configurations of the tables, as the number of voters of
+	// the tables and partitions homed in the region may have changed.
+	return params.p.updateZoneConfigsForTables(params.ctx, n.desc)
+}
+
+func (n *alterDatabaseRegionReplicas) Next(runParams) (bool, error) { return false, nil }
+func (n *alterDatabaseRegionReplicas) Values() tree.Datums          { return tree.Datums{} }
+func (n *alterDatabaseRegionReplicas) Close(context.Context)        {}
+
+type alterDatabaseResetPrimaryRegion struct {
+	n    *tree.AlterDatabaseResetPrimaryRegion
+	desc *dbdesc.Mutable
+}
+
+// AlterDatabaseResetPrimaryRegion transforms a
+// tree.AlterDatabaseResetPrimaryRegion into a plan node.
+func (p *planner) AlterDatabaseResetPrimaryRegion(
+	ctx context.Context, n *tree.AlterDatabaseResetPrimaryRegion,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER DATABASE",
+	); err != nil {
+		return nil, err
+	}
+
+	dbDesc, err := p.Descriptors().GetMutableDatabaseByName(ctx, p.txn, string(n.Name),
+		tree.DatabaseLookupFlags{Required: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !dbDesc.IsMultiRegion() {
+		return nil, pgerror.Newf(pgcode.InvalidDatabaseDefinition,
+			"database %s is not a multi-region database", n.Name,
+		)
+	}
+	if err := p.checkPrivilegesForMultiRegionOp(ctx, dbDesc); err != nil {
+		return nil, err
+	}
+
+	return &alterDatabaseResetPrimaryRegion{n: n, desc: dbDesc}, nil
+}
+
+func (n *alterDatabaseResetPrimaryRegion) startExec(params runParams) error {
+	// Validate as much as possible up front, so that the job does not fail
+	// part way through the demotion for reasons we could have caught here.
+	if allowDrop := allowDropFinalRegion.Get(&params.p.execCfg.Settings.SV); !allowDrop {
+		return pgerror.Newf(
+			pgcode.InvalidDatabaseDefinition,
+			"databases in this cluster must have at least 1 region",
+		)
+	}
+
+	superRegions, err := params.p.getSuperRegionsForDatabase(params.ctx, n.desc)
+	if err != nil {
+		return err
+	}
+	if len(superRegions) > 0 {
+		return errors.WithHintf(
+			pgerror.Newf(pgcode.DependentObjectsStillExist,
+				"database %s has super region %s", n.n.Name, superRegions[0].SuperRegionName,
+			),
+			"you must first drop the super regions of the database using "+
+				"ALTER DATABASE %s DROP SUPER REGION <name>",
+			n.n.Name,
+		)
+	}
+
+	if err := params.p.validateZoneConfigForMultiRegionDatabaseWasNotModifiedByUser(
+		params.ctx,
+		n.desc,
+	); err != nil {
+		return err
+	}
+
+	regionEnumOID := typedesc.TypeIDToOID(n.desc.RegionConfig.RegionEnumID)
+	if err := params.p.forEachMutableTableInDatabase(
+		params.ctx,
+		n.desc,
+		func(ctx context.Context, scName string, tbDesc *tabledesc.Mutable) error {
+			if err := params.p.checkPrivilegesForMultiRegionOp(ctx, tbDesc); err != nil {
+				return err
+			}
+			// The implicit region column of REGIONAL BY ROW tables is dropped by
+			// the job. Any other use of the region enum would prevent it from being
+			// dropped.
+			for _, col := range tbDesc.PublicColumns() {
+				t := col.GetType()
+				if t.Family() == types.ArrayFamily {
+					t = t.ArrayContents()
+				}
+				if !t.UserDefined() || t.Oid() != regionEnumOID || isImplicitRegionColumn(col) {
+					continue
+				}
+				return errors.WithHint(
+					pgerror.Newf(pgcode.DependentObjectsStillExist,
+						"column %s of %s %s uses the region type of the database",
+						tree.Name(col.GetName()), tbDesc.DescriptorType(), tree.Name(tbDesc.GetName()),
+					),
+					"you must first drop the column or change its type",
+				)
+			}
+			return nil
+		},
+	); err != nil {
+		return err
+	}
+
+	_, err = params.p.extendedEvalCtx.QueueJob(params.ctx, jobs.Record{
+		Description:   tree.AsStringWithFQNames(n.n, params.Ann()),
+		Username:      params.p.User(),
+		DescriptorIDs: descpb.IDs{n.desc.GetID()},
+		Details: jobspb.MultiRegionConversionDetails{
+			DatabaseID: n.desc.GetID(),
+			Demote:     true,
+		},
+		Progress: jobspb.MultiRegionConversionProgress{},
+		// The steps of the demotion, which drop the implicit region columns of
+		// the tables, cannot be undone. Each of them leaves the database in a
+		// valid state, and the steps completed by a failed job are recorded in
+		// its progress.
+		NonCancelable: true,
+	})
+	return err
+}
+
+// isImplicitRegionColumn returns whether the column is the hidden region
+// column added to a table when it is made REGIONAL BY ROW without an AS
+// clause.
+func isImplicitRegionColumn(col catalog.Column) bool {
+	return col.IsHidden() && col.GetName() == string(tree.RegionalByRowRegionDefaultColName)
+}
+
+func (n *alterDatabaseResetPrimaryRegion) Next(runParams) (bool, error) { return false, nil }
+func (n *alterDatabaseResetPrimaryRegion) Values() tree.Datums          { return tree.Datums{} }
+func (n *alterDatabaseResetPrimaryRegion) Close(context.Context)        {}
+
+type alterDatabaseDefaultLocality struct {
+	n    *tree.AlterDatabaseDefaultLocality
+	desc *dbdesc.Mutable
+}
+
+// AlterDatabaseDefaultLocality transforms a tree.AlterDatabaseDefaultLocality
+// into a plan node.
+func (p *planner) AlterDatabaseDefaultLocality(
+	ctx context.Context, n *tree.AlterDatabaseDefaultLocality,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER DATABASE",
+	); err != nil {
+		return nil, err
+	}
+
+	dbDesc, err := p.Descriptors().GetMutableDatabaseByName(ctx, p.txn, string(n.Name),
+		tree.DatabaseLookupFlags{Required: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkPrivilegesForMultiRegionOp(ctx, dbDesc); err != nil {
+		return nil, err
+	}
+
+	return &alterDatabaseDefaultLocality{n: n, desc: dbDesc}, nil
+}
+
+func (n *alterDatabaseDefaultLocality) startExec(params runParams) error {
+	if !n.desc.IsMultiRegion() {
+		return errors.WithHintf(
+			pgerror.New(pgcode.InvalidDatabaseDefinition,
+				"database must be multi-region to set a default table locality",
+			),
+			"you must first add a primary region to the database using "+
+				"ALTER DATABASE %s PRIMARY REGION <region_name>",
+			n.n.Name.String(),
+		)
+	}
+
+	var lc *catpb.LocalityConfig
+	telemetryName := "reset"
+	if l := n.n.Locality; l != nil {
+		telemetryName = l.TelemetryName()
+		if l.LocalityLevel == tree.LocalityLevelRow && l.RegionalByRowColumn != tree.RegionalByRowRegionNotSpecifiedName {
+			return errors.WithHint(
+				pgerror.New(pgcode.InvalidParameterValue,
+					"the default table locality cannot be REGIONAL BY ROW AS a column",
+				),
+				"the region column of a REGIONAL BY ROW table is specific to the table; "+
+					"use REGIONAL BY ROW for tables to get an implicit region column",
+			)
+		}
+		if l.RegionalByRowExpr != nil {
+			return errors.WithHint(
+				pgerror.New(pgcode.InvalidParameterValue,
+					"the default table locality cannot be REGIONAL BY ROW AS an expression",
+				),
+				"the expression computing the region of a REGIONAL BY ROW table is specific "+
+					"to the table; use ALTER TABLE ... SET LOCALITY REGIONAL BY ROW AS (<expr>) instead",
+			)
+		}
+		if l.LocalityLevel == tree.LocalityLevelTable && l.TableRegion != tree.PrimaryRegionNotSpecifiedName {
+			regionConfig, err := SynthesizeRegionConfig(params.ctx, params.p.txn, n.desc.ID, params.p.Descriptors())
+			if err != nil {
+				return err
+			}
+			if !regionConfig.IsValidRegionNameString(string(l.TableRegion)) {
+				return pgerror.Newf(pgcode.UndefinedObject,
+					"region %q has not been added to the database", l.TableRegion,
+				)
+			}
+		}
+		cfg := localityConfigFromLocality(l)
+		lc = &cfg
+	}
+
+	telemetry.Inc(sqltelemetry.AlterDatabaseDefaultTableLocalityCounter(telemetryName))
+
+	n.desc.SetDefaultTableLocality(lc)
+	return params.p.writeNonDropDatabaseChange(
+		params.ctx,
+		n.desc,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+// homedInDefaultTableLocality returns whether the default table locality of
+// the database is REGIONAL BY TABLE IN the given region.
+func homedInDefaultTableLocality(desc catalog.DatabaseDescriptor, region catpb.RegionName) bool {
+	lc := desc.GetRegionConfig().DefaultTableLocality
+	if lc == nil {
+		return false
+	}
+	rbt, ok := lc.Locality.(*catpb.LocalityConfig_RegionalByTable_)
+	return ok && rbt.RegionalByTable.Region != nil && *rbt.RegionalByTable.Region == region
+}
+
+func (n *alterDatabaseDefaultLocality) Next(runParams) (bool, error) { return false, nil }
+func (n *alterDatabaseDefaultLocality) Values() tree.Datums          { return tree.Datums{} }
+func (n *alterDatabaseDefaultLocality) Close(context.Context)        {}
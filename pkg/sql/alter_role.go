@@ -13,13 +13,17 @@ package sql
 import (
 	"context"
 	"fmt"
+	"net"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
@@ -45,11 +49,21 @@ type alterRoleSetNode struct {
 	isRole   bool
 	allRoles bool
 	// dbDescID == 0 means all databases.
-	dbDescID    descpb.ID
-	setVarKind  setVarBehavior
-	varName     string
-	sVar        sessionVar
-	typedValues []tree.TypedExpr
+	dbDescID descpb.ID
+	// applicationName is the pattern of the application names of the
+	// sessions which the settings apply to; empty means all applications.
+	applicationName string
+	// sourceNetwork is the network of the client addresses of the sessions
+	// which the settings apply to, in CIDR notation; empty means all
+	// networks.
+	sourceNetwork string
+	setVarKind    setVarBehavior
+	varName       string
+	sVar          sessionVar
+	typedValues   []tree.TypedExpr
+	// locked is set if the sessions cannot change the variable from its
+	// default value.
+	locked bool
 }
 
 // setVarBehavior is an enum that describes how to alter the session variable
@@ -81,8 +95,15 @@ func (p *planner) AlterRoleNode(
 	// CockroachDB does not support the superuser role option right now, but we
 	// make it so any member of the ADMIN role can only be edited by another ADMIN
 	// (done in startExec).
-	if err := p.CheckRoleOption(ctx, roleoption.CREATEROLE); err != nil {
-		return nil, err
+	//
+	// A user who must change their password is allowed to do so without
+	// the CREATEROLE and CREATELOGIN privileges.
+	mustChangeOwnPassword := p.SessionData().PasswordMustChange &&
+		isOwnPasswordChange(p.SessionData(), roleSpec, kvOptions)
+	if !mustChangeOwnPassword {
+		if err := p.CheckRoleOption(ctx, roleoption.CREATEROLE); err != nil {
+			return nil, err
+		}
 	}
 
 	asStringOrNull := func(e tree.Expr, op string) (func() (bool, string, error), error) {
@@ -95,11 +116,28 @@ func (p *planner) AlterRoleNode(
 	if err := roleOptions.CheckRoleOptionConflicts(); err != nil {
 		return nil, err
 	}
+	if err := p.checkRoleProfileOptions(ctx, roleOptions); err != nil {
+		return nil, err
+	}
 
 	// Check that the requested combination of password options is
 	// compatible with the user's own CREATELOGIN privilege.
-	if err := p.checkPasswordOptionConstraints(ctx, roleOptions, false /* newUser */); err != nil {
-		return nil, err
+	if !mustChangeOwnPassword {
+		if err := p.checkPasswordOptionConstraints(ctx, roleOptions, false /* newUser */); err != nil {
+			return nil, err
+		}
+	}
+
+	if roleOptions.Contains(roleoption.TERMINATESESSIONS) {
+		if !roleOptions.Contains(roleoption.NOLOGIN) &&
+			!roleOptions.Contains(roleoption.NOSQLLOGIN) &&
+			!roleOptions.Contains(roleoption.VALIDUNTIL) {
+			return nil, pgerror.New(pgcode.Syntax,
+				"TERMINATE SESSIONS can only be specified along with NOLOGIN, NOSQLLOGIN or VALID UNTIL")
+		}
+		if err := p.checkCanTerminateRoleSessions(ctx); err != nil {
+			return nil, err
+		}
 	}
 
 	roleName, err := roleSpec.ToSQLUsername(p.SessionData(), security.UsernameValidation)
@@ -115,13 +153,120 @@ func (p *planner) AlterRoleNode(
 	}, nil
 }
 
+// isOwnPasswordChange returns whether the options of an ALTER ROLE
+// statement for the given role only set the password of the session user.
+func isOwnPasswordChange(
+	sd *sessiondata.SessionData, roleSpec tree.RoleSpec, kvOptions tree.KVOptions,
+) bool {
+	if len(kvOptions) != 1 || kvOptions[0].Key != "password" || kvOptions[0].Value == tree.DNull {
+		return false
+	}
+	roleName, err := roleSpec.ToSQLUsername(sd, security.UsernameValidation)
+	return err == nil && roleName == sd.SessionUser()
+}
+
+// checkPasswordMustChange returns an error if the session user must change
+// their password, unless the statement does so. Session variables and
+// transactions can still be used, e.g. by drivers upon connection.
+func checkPasswordMustChange(sd *sessiondata.SessionData, stmt tree.Statement) error {
+	if !sd.PasswordMustChange {
+		return nil
+	}
+	switch n := stmt.(type) {
+	case *tree.SetVar, *tree.ShowVar,
+		*tree.BeginTransaction, *tree.CommitTransaction, *tree.RollbackTransaction,
+		*tree.Savepoint, *tree.ReleaseSavepoint, *tree.RollbackToSavepoint:
+		return nil
+	case *tree.AlterRole:
+		if isOwnPasswordChange(sd, n.Name, n.KVOptions) {
+			return nil
+		}
+	}
+	return errors.WithHint(
+		pgerror.Newf(pgcode.InsufficientPrivilege,
+			"the password of user %s must be changed", sd.SessionUser()),
+		"Use ALTER USER ... WITH PASSWORD to set a new password.",
+	)
+}
+
+// checkReadOnlyLogin returns an error if the session user has the READONLY
+// role option and the session attempts to use read-write transactions.
+func checkReadOnlyLogin(sd *sessiondata.SessionData, readOnly bool) error {
+	if readOnly || !sd.ReadOnlyLogin {
+		return nil
+	}
+	return errors.WithHint(
+		pgerror.Newf(pgcode.InsufficientPrivilege,
+			"user %s is restricted to read-only transactions", sd.SessionUser()),
+		"The user has the READONLY role option.",
+	)
+}
+
+// CheckAllowedDatabase returns an error if the ALLOWED DATABASES role
+// option of the user does not include the given database. A nil list of
+// allowed databases allows all the databases. The users with allowed
+// databases cannot use the empty database name, which would let them
+// reach the other databases with qualified names.
+func CheckAllowedDatabase(
+	user security.SQLUsername, allowedDatabases []string, dbName string,
+) error {
+	if allowedDatabases == nil {
+		return nil
+	}
+	for _, allowed := range allowedDatabases {
+		if allowed == dbName {
+			return nil
+		}
+	}
+	hint := fmt.Sprintf("The user can only use the databases %s.", strings.Join(allowedDatabases, ", "))
+	if dbName == "" {
+		return errors.WithHint(
+			pgerror.Newf(pgcode.InsufficientPrivilege,
+				"user %s must use one of its allowed databases", user),
+			hint,
+		)
+	}
+	return errors.WithHint(
+		pgerror.Newf(pgcode.InsufficientPrivilege,
+			"user %s is not allowed to use database %s", user, tree.Name(dbName)),
+		hint,
+	)
+}
+
+// checkAllowedDatabaseLookup returns an error if the session is not
+// allowed to resolve names in the given database because of the ALLOWED
+// DATABASES role option of its user. It prevents the qualified names from
+// reaching the databases which the session cannot use. The system
+// database remains reachable, since the planner resolves its tables on
+// behalf of the statements of the user, and its access is controlled by
+// privileges. The empty database name is used to resolve the virtual
+// schemas.
+func (p *planner) checkAllowedDatabaseLookup(dbName string) error {
+	sd := p.SessionData()
+	if sd.AllowedDatabases == nil || dbName == "" || dbName == catconstants.SystemDatabaseName {
+		return nil
+	}
+	return CheckAllowedDatabase(sd.SessionUser(), sd.AllowedDatabases, dbName)
+}
+
 func (p *planner) checkPasswordOptionConstraints(
 	ctx context.Context, roleOptions roleoption.List, newUser bool,
 ) error {
 	if roleOptions.Contains(roleoption.CREATELOGIN) ||
 		roleOptions.Contains(roleoption.NOCREATELOGIN) ||
 		roleOptions.Contains(roleoption.PASSWORD) ||
+		roleOptions.Contains(roleoption.COST) ||
 		roleOptions.Contains(roleoption.VALIDUNTIL) ||
+		roleOptions.Contains(roleoption.PASSWORDMUSTCHANGE) ||
+		roleOptions.Contains(roleoption.CONNECTIONLIMIT) ||
+		roleOptions.Contains(roleoption.SUBJECT) ||
+		roleOptions.Contains(roleoption.MFA) ||
+		roleOptions.Contains(roleoption.NOMFA) ||
+		roleOptions.Contains(roleoption.READONLY) ||
+		roleOptions.Contains(roleoption.NOREADONLY) ||
+		roleOptions.Contains(roleoption.ALLOWEDDATABASES) ||
+		roleOptions.Contains(roleoption.LOGINWINDOW) ||
+		roleOptions.Contains(roleoption.PROFILE) ||
 		roleOptions.Contains(roleoption.LOGIN) ||
 		// CREATE ROLE NOLOGIN is valid without CREATELOGIN.
 		(roleOptions.Contains(roleoption.NOLOGIN) && !newUser) ||
@@ -188,6 +333,17 @@ func (n *alterRoleNode) startExec(params runParams) error {
 	if err != nil {
 		return err
 	}
+	if hasPasswordOpt && hashedPassword != nil {
+		_, password, err := n.roleOptions.GetPassword()
+		if err != nil {
+			return err
+		}
+		if err := params.p.checkAndRecordPasswordHistory(
+			params.ctx, opName, n.roleName, password, hashedPassword,
+		); err != nil {
+			return err
+		}
+	}
 	if hasPasswordOpt {
 		// Updating PASSWORD is a special case since PASSWORD lives in system.users
 		// while the rest of the role options lives in system.role_options.
@@ -202,6 +358,9 @@ func (n *alterRoleNode) startExec(params runParams) error {
 		if err != nil {
 			return err
 		}
+		if err := n.updatePasswordMustChange(params, opName); err != nil {
+			return err
+		}
 		if sessioninit.CacheEnabled.Get(&params.p.ExecCfg().Settings.SV) {
 			// Bump user table versions to force a refresh of AuthInfo cache.
 			if err := params.p.bumpUsersTableVersion(params.ctx); err != nil {
@@ -210,6 +369,13 @@ func (n *alterRoleNode) startExec(params runParams) error {
 		}
 	}
 
+	if err := checkRoleProfileExists(params, opName, n.roleOptions); err != nil {
+		return err
+	}
+	if err := checkRoleSessionVarOptions(params, n.roleOptions); err != nil {
+		return err
+	}
+
 	// Get a map of statements to execute for role options and their values.
 	stmts, err := n.roleOptions.GetSQLStmts(sqltelemetry.AlterRole)
 	if err != nil {
@@ -257,6 +423,29 @@ func (n *alterRoleNode) startExec(params runParams) error {
 		if err := params.p.bumpRoleOptionsTableVersion(params.ctx); err != nil {
 			return err
 		}
+		// Make login-related changes visible to logins on this node as soon as
+		// the transaction commits, instead of waiting for the next lookup to
+		// refresh the cache. This is only done for implicit transactions: in an
+		// explicit transaction, later statements could change the role again,
+		// which would make the AuthInfo observed here stale by the time the
+		// transaction commits.
+		if params.p.EvalContext().TxnImplicit && (hasPasswordOpt ||
+			n.roleOptions.Contains(roleoption.PASSWORDMUSTCHANGE) ||
+			n.roleOptions.Contains(roleoption.VALIDUNTIL) ||
+			n.roleOptions.Contains(roleoption.LOGIN) ||
+			n.roleOptions.Contains(roleoption.NOLOGIN) ||
+			n.roleOptions.Contains(roleoption.SQLLOGIN) ||
+			n.roleOptions.Contains(roleoption.NOSQLLOGIN)) {
+			if err := params.p.writeThroughAuthInfoOnCommit(params.ctx, n.roleName); err != nil {
+				return err
+			}
+		}
+	}
+
+	if n.roleOptions.Contains(roleoption.TERMINATESESSIONS) {
+		if err := n.terminateSessionsIfDisabled(params, opName); err != nil {
+			return err
+		}
 	}
 
 	return params.p.logEvent(params.ctx,
@@ -267,6 +456,68 @@ func (n *alterRoleNode) startExec(params runParams) error {
 		})
 }
 
+// terminateSessionsIfDisabled terminates the sessions of the role once
+// the transaction commits, if the role can no longer log in, that is if
+// NOLOGIN or NOSQLLOGIN was specified or if VALID UNTIL was moved into
+// the past.
+func (n *alterRoleNode) terminateSessionsIfDisabled(params runParams, opName string) error {
+	disabled := n.roleOptions.Contains(roleoption.NOLOGIN) ||
+		n.roleOptions.Contains(roleoption.NOSQLLOGIN)
+	if !disabled {
+		row, err := params.extendedEvalCtx.ExecCfg.InternalExecutor.QueryRowEx(
+			params.ctx,
+			opName,
+			params.p.txn,
+			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+			`SELECT value::TIMESTAMPTZ <= now() FROM system.role_options
+WHERE username = $1 AND option = 'VALID UNTIL'`,
+			n.roleName,
+		)
+		if err != nil {
+			return err
+		}
+		disabled = row != nil && row[0] == tree.DBoolTrue
+	}
+	if disabled {
+		params.p.terminateRoleSessionsOnCommit([]security.SQLUsername{n.roleName})
+	}
+	return nil
+}
+
+// updatePasswordMustChange updates the PASSWORD MUST CHANGE role option
+// after the password of the role was changed. The option is set if it is
+// requested by the statement, or if the password of another user was reset
+// and server.user_login.password_must_change_after_reset.enabled is set;
+// otherwise, it is cleared.
+func (n *alterRoleNode) updatePasswordMustChange(params runParams, opName string) error {
+	sd := params.p.SessionData()
+	if n.roleOptions.Contains(roleoption.PASSWORDMUSTCHANGE) {
+		return nil
+	}
+	if n.roleName != sd.SessionUser() &&
+		passwordMustChangeAfterReset.Get(&params.p.ExecCfg().Settings.SV) {
+		// The option is applied with the other role options.
+		n.roleOptions = append(n.roleOptions, roleoption.RoleOption{Option: roleoption.PASSWORDMUSTCHANGE})
+		return nil
+	}
+	if _, err := params.extendedEvalCtx.ExecCfg.InternalExecutor.ExecEx(
+		params.ctx,
+		opName,
+		params.p.txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`DELETE FROM system.role_options WHERE username = $1 AND option = 'PASSWORD MUST CHANGE'`,
+		n.roleName,
+	); err != nil {
+		return err
+	}
+	if n.roleName == sd.SessionUser() {
+		params.p.sessionDataMutatorIterator.applyOnEachMutator(func(m sessionDataMutator) {
+			m.data.PasswordMustChange = false
+		})
+	}
+	return nil
+}
+
 func (*alterRoleNode) Next(runParams) (bool, error) { return false, nil }
 func (*alterRoleNode) Values() tree.Datums          { return tree.Datums{} }
 func (*alterRoleNode) Close(context.Context)        {}
@@ -310,21 +561,60 @@ func (p *planner) AlterRoleSet(ctx context.Context, n *tree.AlterRoleSet) (planN
 		dbDescID = dbDesc.GetID()
 	}
 
+	if n.ApplicationName != "" &&
+		!p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.DatabaseRoleSettingsApplicationName) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use FOR APPLICATION",
+			clusterversion.ByKey(clusterversion.DatabaseRoleSettingsApplicationName))
+	}
+
+	var sourceNetwork string
+	if n.SourceNetwork != "" {
+		if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.DatabaseRoleSettingsSourceNetwork) {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to use FROM NETWORK",
+				clusterversion.ByKey(clusterversion.DatabaseRoleSettingsSourceNetwork))
+		}
+		_, ipNet, err := net.ParseCIDR(n.SourceNetwork)
+		if err != nil {
+			return nil, pgerror.Wrapf(err, pgcode.InvalidParameterValue,
+				"invalid network %q", n.SourceNetwork)
+		}
+		// The network is stored in its canonical form, so that the same
+		// network always maps to the same row.
+		sourceNetwork = ipNet.String()
+	}
+
 	setVarKind, varName, sVar, typedValues, err := p.processSetOrResetClause(ctx, n.SetOrReset)
 	if err != nil {
 		return nil, err
 	}
 
+	if n.Locked {
+		if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.DatabaseRoleSettingsLocked) {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to use LOCKED",
+				clusterversion.ByKey(clusterversion.DatabaseRoleSettingsLocked))
+		}
+		if setVarKind != setSingleVar {
+			return nil, pgerror.New(pgcode.InvalidParameterValue,
+				"LOCKED can only be used to set the default value of a variable")
+		}
+	}
+
 	return &alterRoleSetNode{
-		roleName:    roleName,
-		ifExists:    n.IfExists,
-		isRole:      n.IsRole,
-		allRoles:    n.AllRoles,
-		dbDescID:    dbDescID,
-		setVarKind:  setVarKind,
-		varName:     varName,
-		sVar:        sVar,
-		typedValues: typedValues,
+		roleName:        roleName,
+		ifExists:        n.IfExists,
+		isRole:          n.IsRole,
+		allRoles:        n.AllRoles,
+		dbDescID:        dbDescID,
+		applicationName: n.ApplicationName,
+		sourceNetwork:   sourceNetwork,
+		setVarKind:      setVarKind,
+		varName:         varName,
+		sVar:            sVar,
+		typedValues:     typedValues,
+		locked:          n.Locked,
 	}, nil
 }
 
@@ -371,12 +661,19 @@ func (p *planner) processSetOrResetClause(
 	}
 	_, sVar, err = getSessionVar(varName, false /* missingOk */)
 	if err != nil {
-		return unknown, "", sessionVar{}, nil, err
-	}
-
-	// There must be a `Set` function defined. `RuntimeSet` is not allowed here
-	// since `RuntimeSet` cannot be used during session initialization.
-	if sVar.Set == nil {
+		// A variable which is unknown to this version can still be stored
+		// when validation is disabled, for the benefit of newer versions.
+		// The sessions of older versions ignore it.
+		if pgerror.GetPGCode(err) != pgcode.UndefinedObject {
+			return unknown, "", sessionVar{}, nil, err
+		}
+		if !p.SessionData().AllowUnvalidatedRoleSettings {
+			return unknown, "", sessionVar{}, nil, errors.WithHint(err, unvalidatedRoleSettingsHint)
+		}
+		sVar = sessionVar{}
+	} else if sVar.Set == nil {
+		// There must be a `Set` function defined. `RuntimeSet` is not allowed here
+		// since `RuntimeSet` cannot be used during session initialization.
 		return unknown, "", sessionVar{}, nil, newCannotChangeParameterError(varName)
 	}
 
@@ -415,18 +712,29 @@ func (n *alterRoleSetNode) startExec(params runParams) error {
 		return nil
 	}
 
+	keyColumns, qargs := n.settingsRowKey(params, roleName)
+	valueColumns := []string{"settings"}
+	hasLockedSettings := params.ExecCfg().Settings.Version.IsActive(
+		params.ctx, clusterversion.DatabaseRoleSettingsLocked,
+	)
+	if hasLockedSettings {
+		valueColumns = append(valueColumns, "locked_settings")
+	}
 	var deleteQuery = fmt.Sprintf(
-		`DELETE FROM %s WHERE database_id = $1 AND role_name = $2`,
-		sessioninit.DatabaseRoleSettingsTableName,
+		`DELETE FROM %s WHERE %s`,
+		sessioninit.DatabaseRoleSettingsTableName, settingsRowKeyFilter(keyColumns),
 	)
 	var upsertQuery = fmt.Sprintf(
-		`UPSERT INTO %s (database_id, role_name, settings) VALUES ($1, $2, $3)`,
+		`UPSERT INTO %s (%s, %s) VALUES (%s)`,
 		sessioninit.DatabaseRoleSettingsTableName,
+		strings.Join(keyColumns, ", "), strings.Join(valueColumns, ", "),
+		settingsRowPlaceholders(len(keyColumns)+len(valueColumns)),
 	)
 
 	// Instead of inserting an empty settings array, this function will make
-	// sure the row is deleted instead.
-	upsertOrDeleteFunc := func(newSettings []string) error {
+	// sure the row is deleted instead. The locked_settings column is NULL
+	// when no variable is locked.
+	upsertOrDeleteFunc := func(newSettings, newLockedSettings []string) error {
 		var rowsAffected int
 		var internalExecErr error
 		if newSettings == nil {
@@ -436,19 +744,24 @@ func (n *alterRoleSetNode) startExec(params runParams) error {
 				params.p.txn,
 				sessiondata.InternalExecutorOverride{User: security.RootUserName()},
 				deleteQuery,
-				n.dbDescID,
-				roleName,
+				qargs...,
 			)
 		} else {
+			upsertArgs := append(qargs, newSettings)
+			if hasLockedSettings {
+				var lockedSettings interface{} = tree.DNull
+				if len(newLockedSettings) > 0 {
+					lockedSettings = newLockedSettings
+				}
+				upsertArgs = append(upsertArgs, lockedSettings)
+			}
 			rowsAffected, internalExecErr = params.extendedEvalCtx.ExecCfg.InternalExecutor.ExecEx(
 				params.ctx,
 				opName,
 				params.p.txn,
 				sessiondata.InternalExecutorOverride{User: security.RootUserName()},
 				upsertQuery,
-				n.dbDescID,
-				roleName,
-				newSettings,
+				upsertArgs...,
 			)
 		}
 		if internalExecErr != nil {
@@ -470,10 +783,12 @@ func (n *alterRoleSetNode) startExec(params runParams) error {
 	}
 
 	if n.setVarKind == resetAllVars {
-		return upsertOrDeleteFunc(nil)
+		return upsertOrDeleteFunc(nil, nil)
 	}
 
-	hasOldSettings, newSettings, err := n.makeNewSettings(params, opName, roleName)
+	hasOldSettings, newSettings, newLockedSettings, err := n.makeNewSettings(
+		params, opName, roleName, hasLockedSettings,
+	)
 	if err != nil {
 		return err
 	}
@@ -482,7 +797,7 @@ func (n *alterRoleSetNode) startExec(params runParams) error {
 		if !hasOldSettings {
 			return nil
 		}
-		return upsertOrDeleteFunc(newSettings)
+		return upsertOrDeleteFunc(newSettings, newLockedSettings)
 	}
 
 	// The remaining case is `SET var = val`, to add a default setting.
@@ -493,7 +808,10 @@ func (n *alterRoleSetNode) startExec(params runParams) error {
 
 	newSetting := fmt.Sprintf("%s=%s", n.varName, strVal)
 	newSettings = append(newSettings, newSetting)
-	return upsertOrDeleteFunc(newSettings)
+	if n.locked {
+		newLockedSettings = append(newLockedSettings, n.varName)
+	}
+	return upsertOrDeleteFunc(newSettings, newLockedSettings)
 }
 
 // getRoleName resolves the roleName and performs additional validation
@@ -546,14 +864,63 @@ func (n *alterRoleSetNode) getRoleName(
 	return true, n.roleName, nil
 }
 
-// makeNewSettings first loads the existing settings for the (role, db), then
-// returns a newSettings list with any occurrence of varName removed.
+// settingsRowKey returns the primary key columns of the
+// system.database_role_settings row of the settings, along with their
+// values. The application_name and source_network columns are only part of
+// the key once they are added to the table.
+func (n *alterRoleSetNode) settingsRowKey(
+	params runParams, roleName security.SQLUsername,
+) (keyColumns []string, values []interface{}) {
+	keyColumns = []string{"database_id", "role_name"}
+	values = []interface{}{n.dbDescID, roleName}
+	version := params.ExecCfg().Settings.Version
+	if version.IsActive(params.ctx, clusterversion.DatabaseRoleSettingsApplicationName) {
+		keyColumns = append(keyColumns, "application_name")
+		values = append(values, n.applicationName)
+	}
+	if version.IsActive(params.ctx, clusterversion.DatabaseRoleSettingsSourceNetwork) {
+		keyColumns = append(keyColumns, "source_network")
+		values = append(values, n.sourceNetwork)
+	}
+	return keyColumns, values
+}
+
+// settingsRowKeyFilter returns a filter on the given key columns, whose
+// values are the first placeholders.
+func settingsRowKeyFilter(keyColumns []string) string {
+	filters := make([]string, len(keyColumns))
+	for i, col := range keyColumns {
+		filters[i] = fmt.Sprintf("%s = $%d", col, i+1)
+	}
+	return strings.Join(filters, " AND ")
+}
+
+// settingsRowPlaceholders returns a comma-separated list of the first n
+// placeholders.
+func settingsRowPlaceholders(n int) string {
+	ph := make([]string, n)
+	for i := range ph {
+		ph[i] = fmt.Sprintf("$%d", i+1)
+	}
+	return strings.Join(ph, ", ")
+}
+
+// makeNewSettings first loads the existing settings for the (role, db,
+// application, network), then returns a newSettings list with any
+// occurrence of varName removed, along with the names of the locked
+// variables other than varName.
 func (n *alterRoleSetNode) makeNewSettings(
-	params runParams, opName string, roleName security.SQLUsername,
-) (hasOldSettings bool, newSettings []string, err error) {
+	params runParams, opName string, roleName security.SQLUsername, hasLockedSettings bool,
+) (hasOldSettings bool, newSettings []string, newLockedSettings []string, err error) {
+	keyColumns, qargs := n.settingsRowKey(params, roleName)
+	lockedSettingsColumn := "NULL::STRING[]"
+	if hasLockedSettings {
+		lockedSettingsColumn = "locked_settings"
+	}
 	var selectQuery = fmt.Sprintf(
-		`SELECT settings FROM %s WHERE database_id = $1 AND role_name = $2`,
-		sessioninit.DatabaseRoleSettingsTableName,
+		`SELECT settings, %s FROM %s WHERE %s`,
+		lockedSettingsColumn, sessioninit.DatabaseRoleSettingsTableName,
+		settingsRowKeyFilter(keyColumns),
 	)
 	datums, err := params.extendedEvalCtx.ExecCfg.InternalExecutor.QueryRowEx(
 		params.ctx,
@@ -561,11 +928,10 @@ func (n *alterRoleSetNode) makeNewSettings(
 		params.p.txn,
 		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
 		selectQuery,
-		n.dbDescID,
-		roleName,
+		qargs...,
 	)
 	if err != nil {
-		return false, nil, err
+		return false, nil, nil, err
 	}
 	var oldSettings *tree.DArray
 	if datums != nil {
@@ -577,10 +943,22 @@ func (n *alterRoleSetNode) makeNewSettings(
 				newSettings = append(newSettings, oldSetting)
 			}
 		}
+		if datums[1] != tree.DNull {
+			for _, s := range tree.MustBeDArray(datums[1]).Array {
+				if name := string(tree.MustBeDString(s)); !strings.EqualFold(n.varName, name) {
+					newLockedSettings = append(newLockedSettings, name)
+				}
+			}
+		}
 	}
-	return oldSettings != nil, newSettings, nil
+	return oldSettings != nil, newSettings, newLockedSettings, nil
 }
 
+// unvalidatedRoleSettingsHint is the hint of the errors of defaults which
+// fail validation.
+const unvalidatedRoleSettingsHint = "set allow_unvalidated_role_settings to store " +
+	"a default which is only valid for a newer version"
+
 // getSessionVarVal evaluates typedValues to get a string value that can
 // be persisted as the default setting for the session variable. It also
 // performs validation to make sure the session variable exists and is
@@ -611,7 +989,13 @@ func (n *alterRoleSetNode) getSessionVarVal(params runParams) (string, error) {
 	// Validate the new string value, but don't actually apply it to any real
 	// session.
 	if err := CheckSessionVariableValueValid(params.ctx, params.ExecCfg().Settings, n.varName, strVal); err != nil {
-		return "", err
+		if !params.SessionData().AllowUnvalidatedRoleSettings {
+			return "", errors.WithHint(err, unvalidatedRoleSettingsHint)
+		}
+		// Invalid defaults are skipped when sessions are initialized, so
+		// they cannot prevent the role from logging in.
+		params.p.BufferClientNotice(params.ctx, pgnotice.Newf(
+			"storing the default value of %s without validation: %v", n.varName, err))
 	}
 	return strVal, nil
 }
@@ -229,7 +229,8 @@ func (n *alterTableSetLocalityNode) alterTableLocalityToRegionalByRow(
 		as := n.tableDesc.LocalityConfig.GetRegionalByRow().As
 
 		// If the REGIONAL BY ROW (AS <col>) is exactly the same, do nothing.
-		defaultColumnSpecified := as == nil && partColName == tree.RegionalByRowRegionNotSpecifiedName
+		defaultColumnSpecified := as == nil && partColName == tree.RegionalByRowRegionNotSpecifiedName &&
+			newLocality.RegionalByRowExpr == nil
 		sameAsColumnSpecified := as != nil && *as == string(partColName)
 		if defaultColumnSpecified || sameAsColumnSpecified {
 			return nil
@@ -260,6 +261,11 @@ func (n *alterTableSetLocalityNode) alterTableLocalityToRegionalByRow(
 	if err != nil && !createDefaultRegionCol {
 		return err
 	}
+	// REGIONAL BY ROW AS (<expr>) stores the region in a new implicit region
+	// column computed from the expression.
+	if newLocality.RegionalByRowExpr != nil && !createDefaultRegionCol {
+		return regionalByRowExprColumnExistsError(partColName)
+	}
 
 	enumTypeID, err := n.dbDesc.MultiRegionEnumID()
 	if err != nil {
@@ -313,7 +319,9 @@ func (n *alterTableSetLocalityNode) alterTableLocalityToRegionalByRow(
 		//
 		// Note we initially set the default expression to be primary_region,
 		// so that it is backfilled this way. When the backfill is complete,
-		// we will change this to use gateway_region.
+		// we will change this to use gateway_region. A column computed from
+		// REGIONAL BY ROW AS (<expr>) is backfilled with the result of the
+		// expression instead.
 		defaultColDef := &tree.AlterTableAddColumn{
 			ColumnDef: regionalByRowDefaultColDef(
 				enumOID,
@@ -321,6 +329,9 @@ func (n *alterTableSetLocalityNode) alterTableLocalityToRegionalByRow(
 				maybeRegionalByRowOnUpdateExpr(params.EvalContext(), enumOID),
 			),
 		}
+		if newLocality.RegionalByRowExpr != nil {
+			defaultColDef.ColumnDef = regionalByRowComputedColDef(enumOID, newLocality.RegionalByRowExpr)
+		}
 		tn, err := params.p.getQualifiedTableName(params.ctx, n.tableDesc)
 		if err != nil {
 			return err
@@ -350,6 +361,21 @@ func (n *alterTableSetLocalityNode) alterTableLocalityToRegionalByRow(
 			return err
 		}
 
+		// A computed region column keeps its expression once the mutation is
+		// finalized.
+		if newLocality.RegionalByRowExpr != nil {
+			return n.alterTableLocalityFromOrToRegionalByRow(
+				params,
+				tabledesc.LocalityConfigRegionalByRow(newLocality.RegionalByRowColumn),
+				mutationIdxAllowedInSameTxn,
+				newColumnName,
+				nil, /* newColumnID */
+				nil, /* newColumnDefaultExpr */
+				n.tableDesc.PrimaryIndex.KeyColumnNames[primaryIndexColIdxStart:],
+				n.tableDesc.PrimaryIndex.KeyColumnDirections[primaryIndexColIdxStart:],
+			)
+		}
+
 		// On the AlterPrimaryKeyMutation, sanitize and form the correct default
 		// expression to replace the crdb_region column with when the mutation
 		// is finalized.
@@ -0,0 +1,123 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// maxAuthAttempts is the number of authentication attempts, and
+// separately of failed authentication attempts, remembered by each node.
+const maxAuthAttempts = 1000
+
+// AuthAttempt describes an authentication attempt.
+type AuthAttempt struct {
+	Timestamp time.Time
+	// Username is the database user, or the system identity presented
+	// by the client if the attempt failed before a database user could
+	// be determined.
+	Username      string
+	RemoteAddress string
+	// Transport is the connection type of SQL logins, or "http" for the
+	// DB Console and HTTP API logins.
+	Transport string
+	Method    string
+	Success   bool
+	// Reason and Detail describe the failure of failed attempts.
+	Reason string
+	Detail string
+	// Latency is the time it took to authenticate the client, or to
+	// reject it.
+	Latency time.Duration
+	// CacheHit is set if the authentication info of the user was served
+	// from the cache rather than read from the system tables.
+	CacheHit bool
+}
+
+// authAttemptRing is a ring buffer of authentication attempts.
+type authAttemptRing struct {
+	attempts []AuthAttempt
+	// next is the position of the next attempt to be recorded in
+	// attempts, once it is full.
+	next int
+}
+
+func (r *authAttemptRing) record(attempt AuthAttempt) {
+	if len(r.attempts) < maxAuthAttempts {
+		r.attempts = append(r.attempts, attempt)
+		return
+	}
+	r.attempts[r.next] = attempt
+	r.next = (r.next + 1) % maxAuthAttempts
+}
+
+func (r *authAttemptRing) list() []AuthAttempt {
+	res := make([]AuthAttempt, 0, len(r.attempts))
+	res = append(res, r.attempts[r.next:]...)
+	return append(res, r.attempts[:r.next]...)
+}
+
+// AuthAttempts holds the most recent authentication attempts on this
+// node in ring buffers, so that they can be inspected using
+// crdb_internal.node_failed_login_attempts and
+// crdb_internal.cluster_auth_attempts without searching the logs. The
+// failed attempts are also kept separately, so that they are not pushed
+// out by the successful ones.
+type AuthAttempts struct {
+	mu struct {
+		syncutil.Mutex
+		all    authAttemptRing
+		failed authAttemptRing
+	}
+}
+
+// NewAuthAttempts creates a new AuthAttempts.
+func NewAuthAttempts() *AuthAttempts {
+	return &AuthAttempts{}
+}
+
+// Record records an authentication attempt, replacing the oldest one if
+// the buffer is full.
+func (a *AuthAttempts) Record(attempt AuthAttempt) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.mu.all.record(attempt)
+	if !attempt.Success {
+		a.mu.failed.record(attempt)
+	}
+}
+
+// List returns the recorded authentication attempts, from the oldest to
+// the most recent.
+func (a *AuthAttempts) List() []AuthAttempt {
+	if a == nil {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.mu.all.list()
+}
+
+// ListFailed returns the recorded failed authentication attempts, from
+// the oldest to the most recent.
+func (a *AuthAttempts) ListFailed() []AuthAttempt {
+	if a == nil {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.mu.failed.list()
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthAttempts(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	var nilAttempts *AuthAttempts
+	nilAttempts.Record(AuthAttempt{})
+	require.Empty(t, nilAttempts.List())
+	require.Empty(t, nilAttempts.ListFailed())
+
+	a := NewAuthAttempts()
+	require.Empty(t, a.List())
+	require.Empty(t, a.ListFailed())
+
+	// Record more attempts than can be remembered; the oldest ones are
+	// dropped, and the others are listed in order.
+	const extra = 10
+	for i := 0; i < maxAuthAttempts+extra; i++ {
+		a.Record(AuthAttempt{Username: fmt.Sprint(i)})
+	}
+	attempts := a.ListFailed()
+	require.Len(t, attempts, maxAuthAttempts)
+	for i, attempt := range attempts {
+		require.Equal(t, fmt.Sprint(i+extra), attempt.Username)
+	}
+
+	// The successful attempts do not push out the failed ones.
+	for i := 0; i < maxAuthAttempts; i++ {
+		a.Record(AuthAttempt{Username: "ok", Success: true})
+	}
+	require.Equal(t, attempts, a.ListFailed())
+	for _, attempt := range a.List() {
+		require.True(t, attempt.Success)
+	}
+}
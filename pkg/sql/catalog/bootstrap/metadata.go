@@ -320,6 +320,10 @@ func addSystemDescriptorsToSchema(target *MetadataSchema) {
 
 	// Tables introduced in 22.1.
 	target.AddDescriptorForSystemTenant(systemschema.TenantSettingsTable)
+	target.AddDescriptor(systemschema.RoleTOTPTable)
+	target.AddDescriptor(systemschema.WebAuthnCredentialsTable)
+	target.AddDescriptor(systemschema.RoleLastLoginTable)
+	target.AddDescriptor(systemschema.RoleProfilesTable)
 
 	// Adding a new system table? It should be added here to the metadata schema,
 	// and also created as a migration for older clusters.
@@ -84,6 +84,10 @@ const (
 	SQLInstancesTableName                  SystemTableName = "sql_instances"
 	SpanConfigurationsTableName            SystemTableName = "span_configurations"
 	TenantSettingsTableName                SystemTableName = "tenant_settings"
+	RoleTOTPTableName                      SystemTableName = "role_totp"
+	WebAuthnCredentialsTableName           SystemTableName = "webauthn_credentials"
+	RoleLastLoginTableName                 SystemTableName = "role_last_login"
+	RoleProfilesTableName                  SystemTableName = "role_profiles"
 )
 
 // Oid for virtual database and table.
@@ -156,6 +160,9 @@ const (
 	CrdbInternalActiveRangeFeedsTable
 	CrdbInternalTenantUsageDetailsViewID
 	CrdbInternalPgCatalogTableIsImplementedTableID
+	CrdbInternalNodeFailedLoginAttemptsTableID
+	CrdbInternalClusterAuthAttemptsTableID
+	CrdbInternalEffectiveSessionSettingsTableID
 	InformationSchemaID
 	InformationSchemaAdministrableRoleAuthorizationsID
 	InformationSchemaApplicableRolesID
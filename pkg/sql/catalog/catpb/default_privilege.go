@@ -152,6 +152,31 @@ func (p *DefaultPrivilegeDescriptor) RemoveUser(role DefaultPrivilegesRole) {
 	p.DefaultPrivilegesPerRole = append(p.DefaultPrivilegesPerRole[:idx], p.DefaultPrivilegesPerRole[idx+1:]...)
 }
 
+// RenameUser replaces oldUser by newUser in the default privilege descriptor,
+// both as a creator role and as a grantee. It returns whether the descriptor
+// was modified.
+func (p *DefaultPrivilegeDescriptor) RenameUser(oldUser, newUser security.SQLUsername) bool {
+	changed := false
+	oldRole := DefaultPrivilegesRole{Role: oldUser}
+	if idx := p.FindUserIndex(oldRole); idx != -1 {
+		defaultPrivilegesForRole := p.DefaultPrivilegesPerRole[idx]
+		defaultPrivilegesForRole.GetExplicitRole().UserProto = newUser.EncodeProto()
+		p.RemoveUser(oldRole)
+		*p.FindOrCreateUser(DefaultPrivilegesRole{Role: newUser}) = defaultPrivilegesForRole
+		changed = true
+	}
+	for i := range p.DefaultPrivilegesPerRole {
+		perObject := p.DefaultPrivilegesPerRole[i].DefaultPrivilegesPerObject
+		for objectType, privs := range perObject {
+			if privs.RenameUser(oldUser, newUser) {
+				perObject[objectType] = privs
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
 // Validate returns an assertion error if the default privilege descriptor
 // is invalid.
 func (p *DefaultPrivilegeDescriptor) Validate() error {
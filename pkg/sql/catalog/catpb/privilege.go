@@ -103,6 +103,26 @@ func (p *PrivilegeDescriptor) RemoveUser(user security.SQLUsername) {
 	p.Users = append(p.Users[:idx], p.Users[idx+1:]...)
 }
 
+// RenameUser replaces oldUser by newUser, both as the owner and in the list of
+// users holding privileges. It returns whether the descriptor was modified.
+func (p *PrivilegeDescriptor) RenameUser(oldUser, newUser security.SQLUsername) bool {
+	changed := false
+	if p.Owner() == oldUser {
+		p.SetOwner(newUser)
+		changed = true
+	}
+	if userPrivs, ok := p.FindUser(oldUser); ok {
+		privs := *userPrivs
+		privs.UserProto = newUser.EncodeProto()
+		p.RemoveUser(oldUser)
+		// The list of users is sorted, so the user is re-inserted at the
+		// position of its new name.
+		*p.FindOrCreateUser(newUser) = privs
+		changed = true
+	}
+	return changed
+}
+
 // NewCustomSuperuserPrivilegeDescriptor returns a privilege descriptor for the root user
 // and the admin role with specified privileges.
 func NewCustomSuperuserPrivilegeDescriptor(
@@ -666,3 +666,43 @@ func TestRevokeWithGrantOption(t *testing.T) {
 		}
 	}
 }
+
+func TestRenameUser(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testUser := security.TestUserName()
+	// The new name sorts before the other users of the descriptor, so that the
+	// renamed user has to move in the sorted list of users.
+	newUser := security.MakeSQLUsernameFromPreNormalizedString("aaa")
+
+	pd := catpb.NewPrivilegeDescriptor(
+		testUser, privilege.List{privilege.SELECT, privilege.INSERT}, privilege.List{privilege.SELECT}, testUser,
+	)
+	if !pd.RenameUser(testUser, newUser) {
+		t.Fatal("expected the descriptor to be modified")
+	}
+	if owner := pd.Owner(); owner != newUser {
+		t.Errorf("expected owner %s, got %s", newUser, owner)
+	}
+	if _, ok := pd.FindUser(testUser); ok {
+		t.Errorf("expected user %s to be renamed", testUser)
+	}
+	userPrivs, ok := pd.FindUser(newUser)
+	if !ok {
+		t.Fatalf("expected user %s to be found", newUser)
+	}
+	if expected := (privilege.List{privilege.SELECT, privilege.INSERT}).ToBitField(); userPrivs.Privileges != expected {
+		t.Errorf("expected privileges %d, got %d", expected, userPrivs.Privileges)
+	}
+	if expected := (privilege.List{privilege.SELECT}).ToBitField(); userPrivs.WithGrantOption != expected {
+		t.Errorf("expected grant options %d, got %d", expected, userPrivs.WithGrantOption)
+	}
+	for i := 1; i < len(pd.Users); i++ {
+		if !pd.Users[i-1].User().LessThan(pd.Users[i].User()) {
+			t.Errorf("users are not sorted: %v", pd.Users)
+		}
+	}
+	if pd.RenameUser(testUser, newUser) {
+		t.Error("expected renaming a user without privileges to be a no-op")
+	}
+}
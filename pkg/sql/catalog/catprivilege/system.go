@@ -63,6 +63,10 @@ var (
 		catconstants.SQLInstancesTableName,
 		catconstants.SpanConfigurationsTableName,
 		catconstants.TenantSettingsTableName,
+		catconstants.RoleTOTPTableName,
+		catconstants.WebAuthnCredentialsTableName,
+		catconstants.RoleLastLoginTableName,
+		catconstants.RoleProfilesTableName,
 	}
 
 	systemSuperuserPrivileges = func() map[descpb.NameInfo]privilege.List {
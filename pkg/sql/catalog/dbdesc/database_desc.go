@@ -14,6 +14,7 @@ package dbdesc
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
@@ -284,6 +285,73 @@ func (desc *immutable) validateMultiRegion(vea catalog.ValidationErrorAccumulato
 		vea.Report(errors.AssertionFailedf(
 			"primary region unset on a multi-region db %d", desc.GetID()))
 	}
+	if desc.RegionConfig.SecondaryRegion != "" &&
+		desc.RegionConfig.SecondaryRegion == desc.RegionConfig.PrimaryRegion {
+		vea.Report(errors.AssertionFailedf(
+			"secondary region is the primary region on a multi-region db %d", desc.GetID()))
+	}
+	if len(desc.RegionConfig.FallbackRegions) > 0 && desc.RegionConfig.SecondaryRegion != "" {
+		vea.Report(errors.AssertionFailedf(
+			"fallback regions set along with a secondary region on a multi-region db %d", desc.GetID()))
+	}
+	seenFallbackRegions := make(map[catpb.RegionName]struct{})
+	for _, region := range desc.RegionConfig.FallbackRegions {
+		if region == desc.RegionConfig.PrimaryRegion {
+			vea.Report(errors.AssertionFailedf(
+				"fallback region %s is the primary region on a multi-region db %d", region, desc.GetID()))
+		}
+		if _, ok := seenFallbackRegions[region]; ok {
+			vea.Report(errors.AssertionFailedf(
+				"duplicate fallback region %s on a multi-region db %d", region, desc.GetID()))
+		}
+		seenFallbackRegions[region] = struct{}{}
+	}
+	if len(desc.RegionConfig.PlacementExceptions) > 0 &&
+		desc.RegionConfig.Placement != descpb.DataPlacement_RESTRICTED {
+		vea.Report(errors.AssertionFailedf(
+			"placement exceptions set without a restricted placement on a multi-region db %d",
+			desc.GetID()))
+	}
+	seenOverrides := make(map[catpb.RegionName]struct{})
+	for _, override := range desc.RegionConfig.RegionReplicaOverrides {
+		if _, ok := seenOverrides[override.Region]; ok {
+			vea.Report(errors.AssertionFailedf(
+				"duplicate replica override for region %s on a multi-region db %d",
+				override.Region, desc.GetID()))
+		}
+		seenOverrides[override.Region] = struct{}{}
+		if override.NumVoters < 0 || override.NumReplicas < 0 ||
+			(override.NumVoters == 0 && override.NumReplicas == 0) {
+			vea.Report(errors.AssertionFailedf(
+				"invalid replica override for region %s on a multi-region db %d: %d voters, %d replicas",
+				override.Region, desc.GetID(), override.NumVoters, override.NumReplicas))
+		}
+	}
+	numVoters, numReplicas := desc.RegionConfig.SurvivalGoalNumVoters, desc.RegionConfig.SurvivalGoalNumReplicas
+	if numVoters < 0 || numReplicas < 0 || (numVoters > 0 && numReplicas > 0 && numReplicas < numVoters) {
+		vea.Report(errors.AssertionFailedf(
+			"invalid survival goal replica counts on a multi-region db %d: %d voters, %d replicas",
+			desc.GetID(), numVoters, numReplicas))
+	}
+	if lc := desc.RegionConfig.DefaultTableLocality; lc != nil {
+		switch l := lc.Locality.(type) {
+		case *catpb.LocalityConfig_Global_:
+		case *catpb.LocalityConfig_RegionalByTable_:
+			if l.RegionalByTable.Region != nil && *l.RegionalByTable.Region == "" {
+				vea.Report(errors.AssertionFailedf(
+					"empty region in default table locality on a multi-region db %d", desc.GetID()))
+			}
+		case *catpb.LocalityConfig_RegionalByRow_:
+			if l.RegionalByRow.As != nil {
+				vea.Report(errors.AssertionFailedf(
+					"default table locality is REGIONAL BY ROW AS %s on a multi-region db %d",
+					*l.RegionalByRow.As, desc.GetID()))
+			}
+		default:
+			vea.Report(errors.AssertionFailedf(
+				"unknown default table locality %T on a multi-region db %d", l, desc.GetID()))
+		}
+	}
 }
 
 // GetReferencedDescIDs returns the IDs of all descriptors referenced by
@@ -453,9 +521,10 @@ func (desc *Mutable) SetInitialMultiRegionConfig(config *multiregion.RegionConfi
 		)
 	}
 	desc.RegionConfig = &descpb.DatabaseDescriptor_RegionConfig{
-		SurvivalGoal:  config.SurvivalGoal(),
-		PrimaryRegion: config.PrimaryRegion(),
-		RegionEnumID:  config.RegionEnumID(),
+		SurvivalGoal:    config.SurvivalGoal(),
+		PrimaryRegion:   config.PrimaryRegion(),
+		SecondaryRegion: config.SecondaryRegion(),
+		RegionEnumID:    config.RegionEnumID(),
 	}
 	return nil
 }
@@ -471,6 +540,46 @@ func (desc *Mutable) SetPlacement(placement descpb.DataPlacement) {
 	desc.RegionConfig.Placement = placement
 }
 
+// SetPlacementExceptions sets the tables which are exceptions to the
+// RESTRICTED placement policy on the region config for a database descriptor.
+func (desc *Mutable) SetPlacementExceptions(tableIDs []descpb.ID) {
+	desc.RegionConfig.PlacementExceptions = tableIDs
+}
+
+// SetRegionReplicaOverride sets the replica counts of a region on the region
+// config for a database descriptor. The override of the region is removed if
+// neither of the counts is set.
+func (desc *Mutable) SetRegionReplicaOverride(override descpb.RegionReplicaOverride) {
+	var overrides []descpb.RegionReplicaOverride
+	for _, o := range desc.RegionConfig.RegionReplicaOverrides {
+		if o.Region != override.Region {
+			overrides = append(overrides, o)
+		}
+	}
+	if override.NumVoters != 0 || override.NumReplicas != 0 {
+		overrides = append(overrides, override)
+		sort.Slice(overrides, func(i, j int) bool {
+			return overrides[i].Region < overrides[j].Region
+		})
+	}
+	desc.RegionConfig.RegionReplicaOverrides = overrides
+}
+
+// SetSurvivalGoalReplicas sets the numbers of voting replicas and of replicas
+// used instead of the ones derived from the survival goal on the region config
+// for a database descriptor. A count of 0 resets it to the default.
+func (desc *Mutable) SetSurvivalGoalReplicas(numVoters, numReplicas int32) {
+	desc.RegionConfig.SurvivalGoalNumVoters = numVoters
+	desc.RegionConfig.SurvivalGoalNumReplicas = numReplicas
+}
+
+// SetDefaultTableLocality sets the locality of the tables created without a
+// LOCALITY clause on the region config for a database descriptor. A nil
+// locality resets it to REGIONAL BY TABLE IN PRIMARY REGION.
+func (desc *Mutable) SetDefaultTableLocality(lc *catpb.LocalityConfig) {
+	desc.RegionConfig.DefaultTableLocality = lc
+}
+
 // GetPostDeserializationChanges returns if the MutableDescriptor was changed after running
 // RunPostDeserializationChanges.
 func (desc *immutable) GetPostDeserializationChanges() catalog.PostDeserializationChanges {
@@ -738,6 +738,9 @@ type TypeDescriptor interface {
 	TransitioningRegionNames() (catpb.RegionNames, error)
 	// SuperRegions returns the list of super regions.
 	SuperRegions() ([]descpb.SuperRegion, error)
+	// RegionComments returns the comments of the regions on the multi-region
+	// enum which have one.
+	RegionComments() (map[catpb.RegionName]string, error)
 
 	// The following fields are set if the type is an enum or a multi-region enum.
 
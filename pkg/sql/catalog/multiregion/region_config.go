@@ -13,6 +13,7 @@
 package multiregion
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
@@ -36,9 +37,18 @@ type RegionConfig struct {
 	regions              catpb.RegionNames
 	transitioningRegions catpb.RegionNames
 	primaryRegion        catpb.RegionName
+	secondaryRegion      catpb.RegionName
+	fallbackRegions      catpb.RegionNames
 	regionEnumID         descpb.ID
 	placement            descpb.DataPlacement
+	placementExceptions  []descpb.ID
+	replicaOverrides     []descpb.RegionReplicaOverride
 	superRegions         []descpb.SuperRegion
+	// numVoters and numReplicas are the replica counts set along with the
+	// survival goal, or 0 if the counts derived from the survival goal are
+	// used.
+	numVoters   int32
+	numReplicas int32
 }
 
 // SurvivalGoal returns the survival goal configured on the RegionConfig.
@@ -51,6 +61,23 @@ func (r *RegionConfig) PrimaryRegion() catpb.RegionName {
 	return r.primaryRegion
 }
 
+// SecondaryRegion returns the secondary region configured on the
+// RegionConfig, or an empty region name if there is none.
+func (r *RegionConfig) SecondaryRegion() catpb.RegionName {
+	return r.secondaryRegion
+}
+
+// HasSecondaryRegion returns whether the RegionConfig has a secondary region.
+func (r *RegionConfig) HasSecondaryRegion() bool {
+	return r.secondaryRegion != ""
+}
+
+// FallbackRegions returns the regions to which the leaseholders of the data
+// homed in the primary region fall back, in order of preference.
+func (r *RegionConfig) FallbackRegions() catpb.RegionNames {
+	return r.fallbackRegions
+}
+
 // Regions returns the list of regions added to the RegionConfig.
 func (r *RegionConfig) Regions() catpb.RegionNames {
 	return r.regions
@@ -123,6 +150,54 @@ func (r *RegionConfig) IsPlacementRestricted() bool {
 	return r.placement == descpb.DataPlacement_RESTRICTED
 }
 
+// IsPlacementException returns true if the table with the given ID keeps
+// non-voting replicas in all the regions of the database under the RESTRICTED
+// placement policy.
+func (r *RegionConfig) IsPlacementException(tableID descpb.ID) bool {
+	for _, id := range r.placementExceptions {
+		if id == tableID {
+			return true
+		}
+	}
+	return false
+}
+
+// ForTable returns the RegionConfig to use for the table with the given ID. A
+// table which is an exception to the RESTRICTED placement policy is configured
+// as if the database used the DEFAULT placement policy.
+func (r RegionConfig) ForTable(tableID descpb.ID) RegionConfig {
+	if r.IsPlacementRestricted() && r.IsPlacementException(tableID) {
+		r.placement = descpb.DataPlacement_DEFAULT
+	}
+	return r
+}
+
+// HasReplicaOverrides returns whether replica counts have been set for any of
+// the regions of the RegionConfig.
+func (r *RegionConfig) HasReplicaOverrides() bool {
+	return len(r.replicaOverrides) > 0
+}
+
+// ReplicaOverride returns the replica counts set for the given region, if
+// any.
+func (r *RegionConfig) ReplicaOverride(
+	region catpb.RegionName,
+) (_ descpb.RegionReplicaOverride, ok bool) {
+	for _, override := range r.replicaOverrides {
+		if override.Region == region {
+			return override, true
+		}
+	}
+	return descpb.RegionReplicaOverride{}, false
+}
+
+// SurvivalGoalReplicas returns the numbers of voting replicas and of replicas
+// set along with the survival goal. Each of them is 0 if the count derived
+// from the survival goal is used.
+func (r *RegionConfig) SurvivalGoalReplicas() (numVoters, numReplicas int32) {
+	return r.numVoters, r.numReplicas
+}
+
 // SuperRegions returns the list of super regions in the database.
 func (r *RegionConfig) SuperRegions() []descpb.SuperRegion {
 	return r.superRegions
@@ -139,6 +214,47 @@ func WithTransitioningRegions(transitioningRegions catpb.RegionNames) MakeRegion
 	}
 }
 
+// WithSecondaryRegion is an option to include a secondary region into
+// MakeRegionConfig.
+func WithSecondaryRegion(secondaryRegion catpb.RegionName) MakeRegionConfigOption {
+	return func(r *RegionConfig) {
+		r.secondaryRegion = secondaryRegion
+	}
+}
+
+// WithFallbackRegions is an option to include the fallback regions of the
+// primary region into MakeRegionConfig.
+func WithFallbackRegions(fallbackRegions catpb.RegionNames) MakeRegionConfigOption {
+	return func(r *RegionConfig) {
+		r.fallbackRegions = fallbackRegions
+	}
+}
+
+// WithPlacementExceptions is an option to include the tables which are
+// exceptions to the RESTRICTED placement policy into MakeRegionConfig.
+func WithPlacementExceptions(tableIDs []descpb.ID) MakeRegionConfigOption {
+	return func(r *RegionConfig) {
+		r.placementExceptions = tableIDs
+	}
+}
+
+// WithReplicaOverrides is an option to include the replica counts set for
+// the regions of the database into MakeRegionConfig.
+func WithReplicaOverrides(overrides []descpb.RegionReplicaOverride) MakeRegionConfigOption {
+	return func(r *RegionConfig) {
+		r.replicaOverrides = overrides
+	}
+}
+
+// WithSurvivalGoalReplicas is an option to include the replica counts set
+// along with the survival goal into MakeRegionConfig.
+func WithSurvivalGoalReplicas(numVoters, numReplicas int32) MakeRegionConfigOption {
+	return func(r *RegionConfig) {
+		r.numVoters = numVoters
+		r.numReplicas = numReplicas
+	}
+}
+
 // MakeRegionConfig constructs a RegionConfig.
 func MakeRegionConfig(
 	regions catpb.RegionNames,
@@ -196,6 +312,21 @@ func ValidateRegionConfig(config RegionConfig) error {
 			"cannot have a database with restricted placement that is also region survivable")
 	}
 
+	if config.HasSecondaryRegion() {
+		if config.secondaryRegion == config.primaryRegion {
+			return errors.AssertionFailedf(
+				"the secondary region %s cannot be the primary region", config.secondaryRegion)
+		}
+		if !config.IsValidRegionNameString(string(config.secondaryRegion)) {
+			return errors.AssertionFailedf(
+				"the secondary region %s is not a region of the database", config.secondaryRegion)
+		}
+	}
+
+	if err := validateFallbackRegions(config); err != nil {
+		return err
+	}
+
 	err := ValidateSuperRegions(config.SuperRegions(), config.SurvivalGoal(), config.Regions(), func(err error) error {
 		return err
 	})
@@ -206,6 +337,32 @@ func ValidateRegionConfig(config RegionConfig) error {
 	return CanSatisfySurvivalGoal(config.survivalGoal, len(config.regions))
 }
 
+// validateFallbackRegions validates that the fallback regions of the given
+// RegionConfig are distinct regions of the database other than the primary
+// region, and that they are not set along with a secondary region.
+func validateFallbackRegions(config RegionConfig) error {
+	if len(config.fallbackRegions) > 0 && config.HasSecondaryRegion() {
+		return errors.AssertionFailedf(
+			"fallback regions cannot be set along with the secondary region %s", config.secondaryRegion)
+	}
+	seen := make(map[catpb.RegionName]struct{}, len(config.fallbackRegions))
+	for _, region := range config.fallbackRegions {
+		if region == config.primaryRegion {
+			return errors.AssertionFailedf(
+				"the fallback region %s cannot be the primary region", region)
+		}
+		if !config.IsValidRegionNameString(string(region)) {
+			return errors.AssertionFailedf(
+				"the fallback region %s is not a region of the database", region)
+		}
+		if _, ok := seen[region]; ok {
+			return errors.AssertionFailedf("duplicate fallback region %s", region)
+		}
+		seen[region] = struct{}{}
+	}
+	return nil
+}
+
 // ValidateSuperRegions validates that:
 //   1. Region names are unique within a super region and are sorted.
 //   2. All region within a super region map to a region on the RegionConfig.
@@ -312,15 +469,102 @@ func IsMemberOfSuperRegion(name catpb.RegionName, config RegionConfig) (bool, st
 	return false, ""
 }
 
-// CanDropRegion returns an error if the survival goal doesn't allow for
-// removing regions or if the region is part of a super region.
-func CanDropRegion(name catpb.RegionName, config RegionConfig) error {
-	isMember, superRegion := IsMemberOfSuperRegion(name, config)
-	if isMember {
-		return errors.WithHintf(
-			pgerror.Newf(pgcode.DependentObjectsStillExist, "region %s is part of super region %s", name, superRegion),
-			"you must first drop super region %s before you can drop the region %s", superRegion, name,
+// CanSetSecondaryRegion returns an error if the region cannot be the secondary
+// region of the database with the given RegionConfig: it must be a region of
+// the database other than the primary region, and it must be in the same super
+// region as the primary region, if any, so that the leaseholders do not fall
+// back outside of the super region.
+func CanSetSecondaryRegion(name catpb.RegionName, config RegionConfig) error {
+	if !config.IsValidRegionNameString(string(name)) {
+		return pgerror.Newf(pgcode.InvalidName, "region %s has not been added to the database", name)
+	}
+	if name == config.primaryRegion {
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"the secondary region cannot be the same as the primary region %s", name)
+	}
+	if len(config.fallbackRegions) > 0 {
+		return errors.WithHint(
+			pgerror.New(pgcode.InvalidParameterValue,
+				"a secondary region cannot be set when the primary region has fallback regions"),
+			"the fallback regions can be removed by setting the primary region without FALLBACK",
+		)
+	}
+	isPrimaryMember, primarySuperRegion := IsMemberOfSuperRegion(config.primaryRegion, config)
+	isSecondaryMember, secondarySuperRegion := IsMemberOfSuperRegion(name, config)
+	if (isPrimaryMember || isSecondaryMember) && primarySuperRegion != secondarySuperRegion {
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"the secondary region %s must be in the same super region as the primary region %s",
+			name, config.primaryRegion)
+	}
+	return nil
+}
+
+// CanSetFallbackRegions returns an error if the regions cannot be the fallback
+// regions of the given primary region in the database with the given
+// RegionConfig: they must be distinct regions of the database other than the
+// primary region, and the database must not have a secondary region, which
+// plays the same role.
+func CanSetFallbackRegions(
+	primaryRegion catpb.RegionName, fallbackRegions catpb.RegionNames, config RegionConfig,
+) error {
+	if len(fallbackRegions) > 0 && config.HasSecondaryRegion() {
+		return errors.WithHint(
+			pgerror.Newf(pgcode.InvalidParameterValue,
+				"fallback regions cannot be set when the database has the secondary region %s",
+				config.secondaryRegion),
+			"the secondary region can be dropped using ALTER DATABASE ... DROP SECONDARY REGION",
 		)
 	}
+	seen := make(map[catpb.RegionName]struct{}, len(fallbackRegions))
+	for _, region := range fallbackRegions {
+		if !config.IsValidRegionNameString(string(region)) {
+			return pgerror.Newf(pgcode.InvalidName, "region %s has not been added to the database", region)
+		}
+		if region == primaryRegion {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"the primary region %s cannot be one of its fallback regions", region)
+		}
+		if _, ok := seen[region]; ok {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"region %s is listed more than once in the fallback regions", region)
+		}
+		seen[region] = struct{}{}
+	}
+	return nil
+}
+
+// RegionDependencies returns a description of each part of the given
+// RegionConfig which references the region, that is the super regions the
+// region is a member of, the secondary region of the database and the fallback
+// regions of the primary region. The region cannot be dropped as long as any
+// of them exists.
+func RegionDependencies(name catpb.RegionName, config RegionConfig) []string {
+	var deps []string
+	for _, superRegion := range config.SuperRegions() {
+		for _, region := range superRegion.Regions {
+			if region == name {
+				deps = append(deps, fmt.Sprintf(
+					"region %q is part of super region %q", name, superRegion.SuperRegionName,
+				))
+				break
+			}
+		}
+	}
+	if name == config.secondaryRegion {
+		deps = append(deps, fmt.Sprintf("region %q is the secondary region of the database", name))
+	}
+	for _, region := range config.fallbackRegions {
+		if region == name {
+			deps = append(deps, fmt.Sprintf("region %q is a fallback region of the primary region", name))
+			break
+		}
+	}
+	return deps
+}
+
+// CanDropRegion returns an error if the survival goal doesn't allow for
+// removing regions. The objects which prevent a region from being dropped are
+// returned by RegionDependencies.
+func CanDropRegion(config RegionConfig) error {
 	return CanSatisfySurvivalGoal(config.survivalGoal, len(config.regions)-1)
 }
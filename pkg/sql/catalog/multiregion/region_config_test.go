@@ -52,6 +52,47 @@ func TestValidateRegionConfig(t *testing.T) {
 			err:          "cannot have a database with restricted placement that is also region survivable",
 			regionConfig: multiregion.MakeRegionConfig(catpb.RegionNames{"region_a", "region_b", "region_c"}, "region_b", descpb.SurvivalGoal_REGION_FAILURE, validRegionEnumID, descpb.DataPlacement_RESTRICTED, nil),
 		},
+		{
+			err: "the secondary region region_b cannot be the primary region",
+			regionConfig: multiregion.MakeRegionConfig(catpb.RegionNames{
+				"region_a",
+				"region_b",
+			}, "region_b", descpb.SurvivalGoal_ZONE_FAILURE, validRegionEnumID, descpb.DataPlacement_DEFAULT, nil,
+				multiregion.WithSecondaryRegion("region_b")),
+		},
+		{
+			err: "the secondary region region_c is not a region of the database",
+			regionConfig: multiregion.MakeRegionConfig(catpb.RegionNames{
+				"region_a",
+				"region_b",
+			}, "region_b", descpb.SurvivalGoal_ZONE_FAILURE, validRegionEnumID, descpb.DataPlacement_DEFAULT, nil,
+				multiregion.WithSecondaryRegion("region_c")),
+		},
+		{
+			err: "the fallback region region_c is not a region of the database",
+			regionConfig: multiregion.MakeRegionConfig(catpb.RegionNames{
+				"region_a",
+				"region_b",
+			}, "region_b", descpb.SurvivalGoal_ZONE_FAILURE, validRegionEnumID, descpb.DataPlacement_DEFAULT, nil,
+				multiregion.WithFallbackRegions(catpb.RegionNames{"region_a", "region_c"})),
+		},
+		{
+			err: "duplicate fallback region region_a",
+			regionConfig: multiregion.MakeRegionConfig(catpb.RegionNames{
+				"region_a",
+				"region_b",
+			}, "region_b", descpb.SurvivalGoal_ZONE_FAILURE, validRegionEnumID, descpb.DataPlacement_DEFAULT, nil,
+				multiregion.WithFallbackRegions(catpb.RegionNames{"region_a", "region_a"})),
+		},
+		{
+			err: "fallback regions cannot be set along with the secondary region region_a",
+			regionConfig: multiregion.MakeRegionConfig(catpb.RegionNames{
+				"region_a",
+				"region_b",
+			}, "region_b", descpb.SurvivalGoal_ZONE_FAILURE, validRegionEnumID, descpb.DataPlacement_DEFAULT, nil,
+				multiregion.WithSecondaryRegion("region_a"),
+				multiregion.WithFallbackRegions(catpb.RegionNames{"region_a"})),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -186,3 +227,174 @@ func TestValidateSuperRegionConfig(t *testing.T) {
 		)
 	}
 }
+
+func TestCanSetSecondaryRegion(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const validRegionEnumID = 100
+
+	regions := catpb.RegionNames{"region_a", "region_b", "region_c", "region_d"}
+	superRegions := []descpb.SuperRegion{
+		{SuperRegionName: "sr", Regions: catpb.RegionNames{"region_a", "region_b"}},
+	}
+	regionConfig := multiregion.MakeRegionConfig(
+		regions, "region_a", descpb.SurvivalGoal_ZONE_FAILURE, validRegionEnumID, descpb.DataPlacement_DEFAULT, superRegions,
+	)
+
+	testCases := []struct {
+		region catpb.RegionName
+		err    string
+	}{
+		{region: "region_b"},
+		{region: "region_a", err: "the secondary region cannot be the same as the primary region"},
+		{region: "region_e", err: "region region_e has not been added to the database"},
+		{region: "region_c", err: "must be in the same super region as the primary region"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.region), func(t *testing.T) {
+			err := multiregion.CanSetSecondaryRegion(tc.region, regionConfig)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.True(t, testutils.IsError(err, tc.err), "expected err %v, got %v", tc.err, err)
+		})
+	}
+}
+
+func TestCanSetFallbackRegions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const validRegionEnumID = 100
+
+	regions := catpb.RegionNames{"region_a", "region_b", "region_c"}
+	regionConfig := multiregion.MakeRegionConfig(
+		regions, "region_a", descpb.SurvivalGoal_ZONE_FAILURE, validRegionEnumID, descpb.DataPlacement_DEFAULT, nil,
+	)
+	withSecondaryRegion := multiregion.MakeRegionConfig(
+		regions, "region_a", descpb.SurvivalGoal_ZONE_FAILURE, validRegionEnumID, descpb.DataPlacement_DEFAULT, nil,
+		multiregion.WithSecondaryRegion("region_b"),
+	)
+
+	testCases := []struct {
+		name            string
+		primaryRegion   catpb.RegionName
+		fallbackRegions catpb.RegionNames
+		regionConfig    multiregion.RegionConfig
+		err             string
+	}{
+		{
+			name:            "valid",
+			primaryRegion:   "region_a",
+			fallbackRegions: catpb.RegionNames{"region_c", "region_b"},
+			regionConfig:    regionConfig,
+		},
+		{
+			name:            "new primary region",
+			primaryRegion:   "region_b",
+			fallbackRegions: catpb.RegionNames{"region_a"},
+			regionConfig:    regionConfig,
+		},
+		{
+			name:          "no fallback regions with a secondary region",
+			primaryRegion: "region_a",
+			regionConfig:  withSecondaryRegion,
+		},
+		{
+			name:            "unknown region",
+			primaryRegion:   "region_a",
+			fallbackRegions: catpb.RegionNames{"region_d"},
+			regionConfig:    regionConfig,
+			err:             "region region_d has not been added to the database",
+		},
+		{
+			name:            "primary region",
+			primaryRegion:   "region_a",
+			fallbackRegions: catpb.RegionNames{"region_b", "region_a"},
+			regionConfig:    regionConfig,
+			err:             "the primary region region_a cannot be one of its fallback regions",
+		},
+		{
+			name:            "duplicate",
+			primaryRegion:   "region_a",
+			fallbackRegions: catpb.RegionNames{"region_b", "region_b"},
+			regionConfig:    regionConfig,
+			err:             "region region_b is listed more than once in the fallback regions",
+		},
+		{
+			name:            "secondary region",
+			primaryRegion:   "region_a",
+			fallbackRegions: catpb.RegionNames{"region_c"},
+			regionConfig:    withSecondaryRegion,
+			err:             "fallback regions cannot be set when the database has the secondary region region_b",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := multiregion.CanSetFallbackRegions(tc.primaryRegion, tc.fallbackRegions, tc.regionConfig)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.True(t, testutils.IsError(err, tc.err), "expected err %v, got %v", tc.err, err)
+		})
+	}
+}
+
+func TestRegionDependencies(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	const validRegionEnumID = 100
+
+	regions := catpb.RegionNames{"region_a", "region_b", "region_c", "region_d"}
+	superRegions := []descpb.SuperRegion{
+		{SuperRegionName: "sr1", Regions: catpb.RegionNames{"region_a", "region_b"}},
+		{SuperRegionName: "sr2", Regions: catpb.RegionNames{"region_c"}},
+	}
+	regionConfig := multiregion.MakeRegionConfig(
+		regions, "region_a", descpb.SurvivalGoal_ZONE_FAILURE, validRegionEnumID, descpb.DataPlacement_DEFAULT, superRegions,
+		multiregion.WithSecondaryRegion("region_b"),
+	)
+
+	testCases := []struct {
+		region catpb.RegionName
+		deps   []string
+	}{
+		{
+			region: "region_a",
+			deps:   []string{`region "region_a" is part of super region "sr1"`},
+		},
+		{
+			region: "region_b",
+			deps: []string{
+				`region "region_b" is part of super region "sr1"`,
+				`region "region_b" is the secondary region of the database`,
+			},
+		},
+		{
+			region: "region_c",
+			deps:   []string{`region "region_c" is part of super region "sr2"`},
+		},
+		{region: "region_d"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.region), func(t *testing.T) {
+			require.Equal(t, tc.deps, multiregion.RegionDependencies(tc.region, regionConfig))
+		})
+	}
+
+	withFallbackRegions := multiregion.MakeRegionConfig(
+		regions, "region_a", descpb.SurvivalGoal_ZONE_FAILURE, validRegionEnumID, descpb.DataPlacement_DEFAULT, nil,
+		multiregion.WithFallbackRegions(catpb.RegionNames{"region_c", "region_d"}),
+	)
+	require.Equal(
+		t,
+		[]string{`region "region_d" is a fallback region of the primary region`},
+		multiregion.RegionDependencies("region_d", withFallbackRegions),
+	)
+}
@@ -562,6 +562,18 @@ func DatabaseDescs(databases []*dbdesc.Mutable, descriptorRewrites jobspb.DescRe
 			return err
 		}
 		db.Schemas = newSchemas
+
+		// Rewrite the IDs of the tables which are exceptions to the placement
+		// policy of the database, dropping the ones which are not restored.
+		if db.RegionConfig != nil && len(db.RegionConfig.PlacementExceptions) > 0 {
+			var exceptions []descpb.ID
+			for _, id := range db.RegionConfig.PlacementExceptions {
+				if rewrite, ok := descriptorRewrites[id]; ok {
+					exceptions = append(exceptions, rewrite.ID)
+				}
+			}
+			db.RegionConfig.PlacementExceptions = exceptions
+		}
 	}
 	return nil
 }
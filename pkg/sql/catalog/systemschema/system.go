@@ -558,11 +558,24 @@ CREATE TABLE system.database_role_settings (
     database_id  OID NOT NULL,
     role_name    STRING NOT NULL,
     settings     STRING[] NOT NULL,
-    CONSTRAINT "primary" PRIMARY KEY (database_id, role_name),
+    -- application_name is a LIKE pattern matching the application names of
+    -- the sessions which the settings apply to, or empty for all of them.
+    application_name STRING NOT NULL DEFAULT '',
+    -- source_network is the network, in CIDR notation, of the client
+    -- addresses of the sessions which the settings apply to, or empty for
+    -- all of them.
+    source_network STRING NOT NULL DEFAULT '',
+    -- locked_settings are the names of the variables of the settings which
+    -- the sessions cannot change, if any.
+    locked_settings STRING[],
+    CONSTRAINT "primary" PRIMARY KEY (database_id, role_name, application_name, source_network),
 		FAMILY "primary" (
 			database_id,
       role_name,
-      settings
+      settings,
+      application_name,
+      source_network,
+      locked_settings
 		)
 );`
 
@@ -667,6 +680,67 @@ CREATE TABLE system.tenant_settings (
 	CONSTRAINT "primary" PRIMARY KEY (tenant_id, name),
 	FAMILY (tenant_id, name, value, last_updated, value_type, reason)
 );`
+
+	// RoleTOTPTableSchema holds the TOTP secrets of the users enrolled for
+	// multi-factor authentication.
+	RoleTOTPTableSchema = `
+CREATE TABLE system.role_totp (
+	username     STRING NOT NULL,
+	-- secret is encrypted with the key configured by the
+	-- server.user_login.totp.encryption_key cluster setting.
+	secret       BYTES NOT NULL,
+	created      TIMESTAMP NOT NULL DEFAULT now(),
+	-- last_counter is the counter of the last accepted code, which
+	-- prevents the reuse of codes.
+	last_counter INT8 NOT NULL DEFAULT 0,
+	CONSTRAINT "primary" PRIMARY KEY (username),
+	FAMILY "primary" (username, secret, created, last_counter)
+);`
+
+	// WebAuthnCredentialsTableSchema holds the security keys registered by
+	// the users to log in to the DB Console.
+	WebAuthnCredentialsTableSchema = `
+CREATE TABLE system.webauthn_credentials (
+	username      STRING NOT NULL,
+	name          STRING NOT NULL,
+	credential_id BYTES NOT NULL,
+	-- public_key is the COSE-encoded public key of the credential.
+	public_key    BYTES NOT NULL,
+	-- sign_count is the signature counter reported by the authenticator
+	-- in its last assertion, used to detect cloned authenticators.
+	sign_count    INT8 NOT NULL DEFAULT 0,
+	created       TIMESTAMP NOT NULL DEFAULT now(),
+	last_used     TIMESTAMP,
+	CONSTRAINT "primary" PRIMARY KEY (username, name),
+	FAMILY "primary" (username, name, credential_id, public_key, sign_count, created, last_used)
+);`
+
+	// RoleLastLoginTableSchema holds the time and the origin of the last
+	// successful login of each role.
+	RoleLastLoginTableSchema = `
+CREATE TABLE system.role_last_login (
+	username       STRING NOT NULL,
+	last_login     TIMESTAMP NOT NULL,
+	-- transport is the connection type of SQL logins, or "http" for the
+	-- DB Console and HTTP API logins.
+	transport      STRING NOT NULL,
+	method         STRING NOT NULL,
+	remote_address STRING,
+	CONSTRAINT "primary" PRIMARY KEY (username),
+	FAMILY "primary" (username, last_login, transport, method, remote_address)
+);`
+
+	// RoleProfilesTableSchema holds the role profiles, which bundle role
+	// options shared by the roles assigned to them.
+	RoleProfilesTableSchema = `
+CREATE TABLE system.role_profiles (
+	profile_name STRING NOT NULL,
+	-- options maps the name of each role option of the profile to its
+	-- value, or to null if the option has no value.
+	options      JSONB NOT NULL,
+	CONSTRAINT "primary" PRIMARY KEY (profile_name),
+	FAMILY "primary" (profile_name, options)
+);`
 )
 
 func pk(name string) descpb.IndexDescriptor {
@@ -875,9 +949,10 @@ var (
 			pk("id"),
 		))
 
-	falseBoolString = "false"
-	trueBoolString  = "true"
-	zeroIntString   = "0:::INT8"
+	falseBoolString   = "false"
+	trueBoolString    = "true"
+	zeroIntString     = "0:::INT8"
+	emptyStringString = "'':::STRING"
 
 	// UsersTable is the descriptor for the users table.
 	UsersTable = registerSystemTable(
@@ -2184,13 +2259,16 @@ var (
 				{Name: "database_id", ID: 1, Type: types.Oid, Nullable: false},
 				{Name: "role_name", ID: 2, Type: types.String, Nullable: false},
 				{Name: "settings", ID: 3, Type: types.StringArray, Nullable: false},
+				{Name: "application_name", ID: 4, Type: types.String, Nullable: false, DefaultExpr: &emptyStringString},
+				{Name: "source_network", ID: 5, Type: types.String, Nullable: false, DefaultExpr: &emptyStringString},
+				{Name: "locked_settings", ID: 6, Type: types.StringArray, Nullable: true},
 			},
 			[]descpb.ColumnFamilyDescriptor{
 				{
 					Name:            "primary",
 					ID:              0,
-					ColumnNames:     []string{"database_id", "role_name", "settings"},
-					ColumnIDs:       []descpb.ColumnID{1, 2, 3},
+					ColumnNames:     []string{"database_id", "role_name", "settings", "application_name", "source_network", "locked_settings"},
+					ColumnIDs:       []descpb.ColumnID{1, 2, 3, 4, 5, 6},
 					DefaultColumnID: 3,
 				},
 			},
@@ -2198,11 +2276,11 @@ var (
 				Name:           tabledesc.LegacyPrimaryKeyIndexName,
 				ID:             1,
 				Unique:         true,
-				KeyColumnNames: []string{"database_id", "role_name"},
+				KeyColumnNames: []string{"database_id", "role_name", "application_name", "source_network"},
 				KeyColumnDirections: []descpb.IndexDescriptor_Direction{
-					descpb.IndexDescriptor_ASC, descpb.IndexDescriptor_ASC,
+					descpb.IndexDescriptor_ASC, descpb.IndexDescriptor_ASC, descpb.IndexDescriptor_ASC, descpb.IndexDescriptor_ASC,
 				},
-				KeyColumnIDs: []descpb.ColumnID{1, 2},
+				KeyColumnIDs: []descpb.ColumnID{1, 2, 4, 5},
 			},
 		))
 
@@ -2357,6 +2435,136 @@ var (
 				Version:      descpb.StrictIndexColumnIDGuaranteesVersion,
 			},
 		))
+
+	// RoleTOTPTable is the descriptor for the TOTP secrets table.
+	RoleTOTPTable = registerSystemTable(
+		RoleTOTPTableSchema,
+		systemTable(
+			catconstants.RoleTOTPTableName,
+			descpb.InvalidID, // dynamically assigned
+			[]descpb.ColumnDescriptor{
+				{Name: "username", ID: 1, Type: types.String},
+				{Name: "secret", ID: 2, Type: types.Bytes},
+				{Name: "created", ID: 3, Type: types.Timestamp, DefaultExpr: &nowString},
+				{Name: "last_counter", ID: 4, Type: types.Int, DefaultExpr: &zeroIntString},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"username", "secret", "created", "last_counter"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:                tabledesc.LegacyPrimaryKeyIndexName,
+				ID:                  1,
+				Unique:              true,
+				KeyColumnNames:      []string{"username"},
+				KeyColumnDirections: singleASC,
+				KeyColumnIDs:        singleID1,
+				Version:             descpb.StrictIndexColumnIDGuaranteesVersion,
+			},
+		))
+
+	// WebAuthnCredentialsTable is the descriptor for the security keys
+	// table.
+	WebAuthnCredentialsTable = registerSystemTable(
+		WebAuthnCredentialsTableSchema,
+		systemTable(
+			catconstants.WebAuthnCredentialsTableName,
+			descpb.InvalidID, // dynamically assigned
+			[]descpb.ColumnDescriptor{
+				{Name: "username", ID: 1, Type: types.String},
+				{Name: "name", ID: 2, Type: types.String},
+				{Name: "credential_id", ID: 3, Type: types.Bytes},
+				{Name: "public_key", ID: 4, Type: types.Bytes},
+				{Name: "sign_count", ID: 5, Type: types.Int, DefaultExpr: &zeroIntString},
+				{Name: "created", ID: 6, Type: types.Timestamp, DefaultExpr: &nowString},
+				{Name: "last_used", ID: 7, Type: types.Timestamp, Nullable: true},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"username", "name", "credential_id", "public_key", "sign_count", "created", "last_used"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5, 6, 7},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:           tabledesc.LegacyPrimaryKeyIndexName,
+				ID:             1,
+				Unique:         true,
+				KeyColumnNames: []string{"username", "name"},
+				KeyColumnDirections: []descpb.IndexDescriptor_Direction{
+					descpb.IndexDescriptor_ASC,
+					descpb.IndexDescriptor_ASC,
+				},
+				KeyColumnIDs: []descpb.ColumnID{1, 2},
+				Version:      descpb.StrictIndexColumnIDGuaranteesVersion,
+			},
+		))
+
+	// RoleLastLoginTable is the descriptor for the last logins table.
+	RoleLastLoginTable = registerSystemTable(
+		RoleLastLoginTableSchema,
+		systemTable(
+			catconstants.RoleLastLoginTableName,
+			descpb.InvalidID, // dynamically assigned
+			[]descpb.ColumnDescriptor{
+				{Name: "username", ID: 1, Type: types.String},
+				{Name: "last_login", ID: 2, Type: types.Timestamp},
+				{Name: "transport", ID: 3, Type: types.String},
+				{Name: "method", ID: 4, Type: types.String},
+				{Name: "remote_address", ID: 5, Type: types.String, Nullable: true},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"username", "last_login", "transport", "method", "remote_address"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:                tabledesc.LegacyPrimaryKeyIndexName,
+				ID:                  1,
+				Unique:              true,
+				KeyColumnNames:      []string{"username"},
+				KeyColumnDirections: singleASC,
+				KeyColumnIDs:        singleID1,
+				Version:             descpb.StrictIndexColumnIDGuaranteesVersion,
+			},
+		))
+
+	// RoleProfilesTable is the descriptor for the role profiles table.
+	RoleProfilesTable = registerSystemTable(
+		RoleProfilesTableSchema,
+		systemTable(
+			catconstants.RoleProfilesTableName,
+			descpb.InvalidID, // dynamically assigned
+			[]descpb.ColumnDescriptor{
+				{Name: "profile_name", ID: 1, Type: types.String},
+				{Name: "options", ID: 2, Type: types.Jsonb},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"profile_name", "options"},
+					ColumnIDs:   []descpb.ColumnID{1, 2},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:                tabledesc.LegacyPrimaryKeyIndexName,
+				ID:                  1,
+				Unique:              true,
+				KeyColumnNames:      []string{"profile_name"},
+				KeyColumnDirections: singleASC,
+				KeyColumnIDs:        singleID1,
+				Version:             descpb.StrictIndexColumnIDGuaranteesVersion,
+			},
+		))
 )
 
 type descRefByName struct {
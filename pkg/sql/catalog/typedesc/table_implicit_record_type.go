@@ -307,6 +307,13 @@ func (v TableImplicitRecordType) SuperRegions() ([]descpb.SuperRegion, error) {
 	)
 }
 
+// RegionComments implements the TypeDescriptor interface.
+func (v TableImplicitRecordType) RegionComments() (map[catpb.RegionName]string, error) {
+	return nil, errors.AssertionFailedf(
+		"can not get region comments of a implicit table record type",
+	)
+}
+
 // GetArrayTypeID implements the TypeDescriptorInterface.
 func (v TableImplicitRecordType) GetArrayTypeID() descpb.ID {
 	return 0
@@ -269,6 +269,22 @@ func (desc *immutable) SuperRegions() ([]descpb.SuperRegion, error) {
 	return desc.RegionConfig.SuperRegions, nil
 }
 
+// RegionComments implements the TypeDescriptor interface.
+func (desc *immutable) RegionComments() (map[catpb.RegionName]string, error) {
+	if desc.Kind != descpb.TypeDescriptor_MULTIREGION_ENUM {
+		return nil, errors.AssertionFailedf(
+			"can not get region comments of a non multi-region enum %d", desc.ID,
+		)
+	}
+	comments := make(map[catpb.RegionName]string)
+	for _, member := range desc.EnumMembers {
+		if member.Comment != "" {
+			comments[catpb.RegionName(member.LogicalRepresentation)] = member.Comment
+		}
+	}
+	return comments, nil
+}
+
 // RegionNamesIncludingTransitioning implements the TypeDescriptor interface.
 func (desc *immutable) RegionNamesIncludingTransitioning() (catpb.RegionNames, error) {
 	if desc.Kind != descpb.TypeDescriptor_MULTIREGION_ENUM {
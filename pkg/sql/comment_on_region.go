@@ -0,0 +1,107 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+type commentOnRegionNode struct {
+	n      *tree.CommentOnRegion
+	dbDesc *dbdesc.Mutable
+}
+
+// CommentOnRegion adds a comment on a region of a multi-region database. The
+// comment is stored on the member of the multi-region enum of the database
+// which represents the region.
+// Privileges: CREATE on database.
+func (p *planner) CommentOnRegion(ctx context.Context, n *tree.CommentOnRegion) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"COMMENT ON REGION",
+	); err != nil {
+		return nil, err
+	}
+
+	dbName := string(n.Database)
+	if dbName == "" {
+		dbName = p.CurrentDatabase()
+	}
+	if dbName == "" {
+		return nil, errNoDatabase
+	}
+	dbDesc, err := p.Descriptors().GetMutableDatabaseByName(ctx, p.txn, dbName,
+		tree.DatabaseLookupFlags{Required: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkPrivilegesForMultiRegionOp(ctx, dbDesc); err != nil {
+		return nil, err
+	}
+
+	return &commentOnRegionNode{n: n, dbDesc: dbDesc}, nil
+}
+
+func (n *commentOnRegionNode) startExec(params runParams) error {
+	if !n.dbDesc.IsMultiRegion() {
+		return pgerror.New(pgcode.InvalidDatabaseDefinition,
+			"database must be multi-region to comment on a region",
+		)
+	}
+
+	typeID, err := n.dbDesc.MultiRegionEnumID()
+	if err != nil {
+		return err
+	}
+	typeDesc, err := params.p.Descriptors().GetMutableTypeVersionByID(params.ctx, params.p.txn, typeID)
+	if err != nil {
+		return err
+	}
+
+	comment := ""
+	if n.n.Comment != nil {
+		comment = *n.n.Comment
+	}
+	found := false
+	for i := range typeDesc.EnumMembers {
+		member := &typeDesc.EnumMembers[i]
+		if member.LogicalRepresentation == string(n.n.Region) {
+			member.Comment = comment
+			found = true
+			break
+		}
+	}
+	if !found {
+		return errors.WithHintf(
+			pgerror.Newf(pgcode.UndefinedObject,
+				"region %q has not been added to the database", n.n.Region,
+			),
+			"available regions can be listed using SHOW REGIONS FROM DATABASE %s",
+			tree.Name(n.dbDesc.GetName()).String(),
+		)
+	}
+
+	return params.p.writeTypeSchemaChange(
+		params.ctx, typeDesc, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (n *commentOnRegionNode) Next(runParams) (bool, error) { return false, nil }
+func (n *commentOnRegionNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *commentOnRegionNode) Close(context.Context)        {}
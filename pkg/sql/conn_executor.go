@@ -297,6 +297,15 @@ type Server struct {
 	// TelemetryLoggingMetrics is used to track metrics for logging to the telemetry channel.
 	TelemetryLoggingMetrics *TelemetryLoggingMetrics
 
+	// sessionDefaults reports the session defaults applied to the new
+	// sessions, when sql.log.session_defaults.enabled is set.
+	sessionDefaults sessionDefaultsReporter
+
+	// userOpenTxns counts the open explicit transactions of each user on
+	// the node, and caches the count of those on the other nodes, when
+	// sql.max_open_transactions_per_user is set.
+	userOpenTxns userOpenTxnCounts
+
 	mu struct {
 		syncutil.Mutex
 		connectionCount int64
@@ -379,6 +388,7 @@ func NewServer(cfg *ExecutorConfig, pool *mon.BytesMonitor) *Server {
 			cfg.Settings,
 			&serverMetrics.ContentionSubsystemMetrics),
 	}
+	s.sessionDefaults.mu.every = log.Every(sessionDefaultsEventInterval)
 
 	telemetryLoggingMetrics := &TelemetryLoggingMetrics{}
 
@@ -483,6 +493,8 @@ func (s *Server) Start(ctx context.Context, stopper *stop.Stopper) {
 	s.reportedStats.Start(ctx, stopper)
 
 	s.txnIDCache.Start(ctx, stopper)
+
+	s.startSessionRevalidation(ctx, stopper)
 }
 
 // GetSQLStatsController returns the persistedsqlstats.Controller for current
@@ -647,6 +659,7 @@ func (s *Server) SetupConn(
 	memMetrics MemoryMetrics,
 	onDefaultIntSizeChange func(newSize int32),
 ) (ConnectionHandler, error) {
+	args.applyTimeoutCeilings(&s.cfg.Settings.SV)
 	sd := s.newSessionData(args)
 	sds := sessiondata.NewStack(sd)
 	// Set the SessionData from args.SessionDefaults. This also validates the
@@ -664,6 +677,10 @@ func (s *Server) SetupConn(
 		ctx, sdMutIterator, stmtBuf, clientComm, memMetrics, &s.Metrics,
 		s.sqlStats.GetApplicationStats(sd.ApplicationName),
 	)
+	ex.sessionTracing.loginTrace = args.LoginTrace
+	ex.authDetails = args.AuthDetails
+	ex.defaultsProvenance = args.DefaultsProvenance
+	s.sessionDefaults.report(ctx, &s.cfg.Settings.SV, &args)
 	return ConnectionHandler{ex}, nil
 }
 
@@ -758,7 +775,18 @@ func (s *Server) newSessionData(args SessionArgs) *sessiondata.SessionData {
 			UserProto: args.User.EncodeProto(),
 		},
 		LocalUnmigratableSessionData: sessiondata.LocalUnmigratableSessionData{
-			RemoteAddr: args.RemoteAddr,
+			RemoteAddr:            args.RemoteAddr,
+			PasswordMustChange:    args.PasswordMustChange,
+			PasswordAuthenticated: args.PasswordAuthenticated,
+			ReadOnlyLogin:         args.ReadOnlyLogin,
+			AllowedDatabases:      args.AllowedDatabases,
+			LockedVars:            args.LockedVars,
+			MaxStmtTimeout:        args.MaxStmtTimeout,
+			MaxTxnTimeout:         args.MaxTxnTimeout,
+			MaxIdleSessionTimeout: args.MaxIdleSessionTimeout,
+			MaxQualityOfService:   args.MaxQualityOfService,
+			MaxResultRows:         args.MaxResultRows,
+			MaxResultBytes:        args.MaxResultBytes,
 		},
 		LocalOnlySessionData: sessiondatapb.LocalOnlySessionData{
 			ResultsBufferSize: args.ConnResultsBufferSize,
@@ -1208,6 +1236,13 @@ type connExecutor struct {
 	transitionCtx  transitionCtx
 	sessionTracing SessionTracing
 
+	// authDetails describes how the session was authenticated.
+	authDetails SessionAuthDetails
+
+	// defaultsProvenance records the sources of the values of the session
+	// variables when the session was established.
+	defaultsProvenance []SessionDefaultProvenance
+
 	// eventLog for SQL statements and other important session events. Will be set
 	// if traceSessionEventLogEnabled; it is used by ex.sessionEventf()
 	eventLog trace.EventLog
@@ -1319,6 +1354,12 @@ type connExecutor struct {
 			implicit bool
 		}
 
+		// openTxnUser is the user for which the explicit transaction of the
+		// session is counted in Server.userOpenTxns, or nil if it is not
+		// counted. The user is recorded since SET ROLE can change the user
+		// of the session during the transaction.
+		openTxnUser *security.SQLUsername
+
 		// shouldExecuteOnTxnRestart indicates that ex.onTxnRestart will be
 		// called when txn is being retried. It is true when txn is started but
 		// can remain false when txn is executed within another higher-level
@@ -1539,11 +1580,6 @@ type prepStmtNamespace struct {
 	portals map[string]PreparedPortal
 }
 
-// HasActivePortals returns true if there are portals in the session.
-func (ns prepStmtNamespace) HasActivePortals() bool {
-	return len(ns.portals) > 0
-}
-
 // HasPortal returns true if there exists a given named portal in the session.
 func (ns prepStmtNamespace) HasPortal(s string) bool {
 	_, ok := ns.portals[s]
@@ -1566,6 +1602,41 @@ func (ns prepStmtNamespace) MigratablePreparedStatements() []sessiondatapb.Migra
 	return ret
 }
 
+// MigratablePortals returns the portals of the session, except for the
+// unnamed portal, which the next Bind message of the client replaces
+// anyway. When the session is serialized using the extended protocol, the
+// unnamed portal is usually the one of the statement which serializes it.
+//
+// The portals only exist until the end of their transaction, so they are
+// only migrated if the session is deserialized in the same implicit
+// transaction as the statements which use them.
+func (ns prepStmtNamespace) MigratablePortals() []sessiondatapb.MigratableSession_Portal {
+	ret := make([]sessiondatapb.MigratableSession_Portal, 0, len(ns.portals))
+	for name, portal := range ns.portals {
+		if name == "" {
+			continue
+		}
+		m := sessiondatapb.MigratableSession_Portal{
+			Name: name,
+			PreparedStatement: sessiondatapb.MigratableSession_PreparedStatement{
+				PlaceholderTypeHints: portal.Stmt.InferredTypes,
+				SQL:                  portal.Stmt.SQL,
+			},
+			Arguments:  make([]string, len(portal.Qargs)),
+			OutFormats: make([]int32, len(portal.OutFormats)),
+			Exhausted:  portal.exhausted,
+		}
+		for i, arg := range portal.Qargs {
+			m.Arguments[i] = tree.Serialize(arg)
+		}
+		for i, f := range portal.OutFormats {
+			m.OutFormats[i] = int32(f)
+		}
+		ret = append(ret, m)
+	}
+	return ret
+}
+
 func (ns prepStmtNamespace) String() string {
 	var sb strings.Builder
 	sb.WriteString("Prep stmts: ")
@@ -1652,6 +1723,7 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent) err
 			delete(ex.extraTxnState.prepStmtsNamespaceAtTxnRewindPos.portals, name)
 		}
 		ex.extraTxnState.savepoints.clear()
+		ex.releaseUserOpenTxn()
 		ex.onTxnFinish(ctx, ev)
 	case txnRestart:
 		ex.onTxnRestart(ctx)
@@ -1775,6 +1847,9 @@ func (ex *connExecutor) run(
 	ex.server.cfg.SessionRegistry.register(ex.sessionID, ex.queryCancelKey, ex)
 	ex.planner.extendedEvalCtx.setSessionID(ex.sessionID)
 	defer ex.server.cfg.SessionRegistry.deregister(ex.sessionID, ex.queryCancelKey)
+	if err := ex.runLogonStatements(ctx); err != nil {
+		return err
+	}
 	for {
 		ex.curStmtAST = nil
 		if err := ctx.Err(); err != nil {
@@ -2556,6 +2631,11 @@ func (ex *connExecutor) setTransactionModes(
 			rwMode = tree.ReadOnly
 		}
 	}
+	if rwMode == tree.ReadWrite {
+		if err := checkReadOnlyLogin(ex.sessionData(), false /* readOnly */); err != nil {
+			return err
+		}
+	}
 	return ex.state.setReadOnlyMode(rwMode)
 }
 
@@ -2653,6 +2733,7 @@ func (ex *connExecutor) initEvalCtx(ctx context.Context, evalCtx *extendedEvalCo
 		statsProvider:          ex.server.sqlStats,
 		indexUsageStats:        ex.indexUsageStats,
 		statementPreparer:      ex,
+		defaultsProvenance:     ex.defaultsProvenance,
 	}
 	evalCtx.copyFromExecCfg(ex.server.cfg)
 }
@@ -2990,6 +3071,14 @@ func (ex *connExecutor) user() security.SQLUsername {
 	return ex.sessionData().User()
 }
 
+// authenticatedUser is part of the registrySession interface.
+func (ex *connExecutor) authenticatedUser() (_ security.SQLUsername, withPassword bool) {
+	ex.mu.RLock()
+	defer ex.mu.RUnlock()
+	sd := ex.sessionDataStack.Base()
+	return sd.SessionUser(), sd.PasswordAuthenticated
+}
+
 // serialize is part of the registrySession interface.
 func (ex *connExecutor) serialize() serverpb.Session {
 	ex.mu.RLock()
@@ -3073,6 +3162,12 @@ func (ex *connExecutor) serialize() serverpb.Session {
 		remoteStr = sd.RemoteAddr.String()
 	}
 
+	var clientCertExpiry *time.Time
+	if expiry := ex.authDetails.ClientCertExpiry; !expiry.IsZero() {
+		expiry = expiry.UTC()
+		clientCertExpiry = &expiry
+	}
+
 	return serverpb.Session{
 		Username:                   sd.SessionUser().Normalized(),
 		ClientAddress:              remoteStr,
@@ -3085,6 +3180,10 @@ func (ex *connExecutor) serialize() serverpb.Session {
 		AllocBytes:                 ex.mon.AllocBytes(),
 		MaxAllocBytes:              ex.mon.MaximumBytes(),
 		LastActiveQueryNoConstants: lastActiveQueryNoConstants,
+		AuthMethod:                 ex.authDetails.Method,
+		IdentityMap:                ex.authDetails.IdentityMap,
+		ClientCertFingerprint:      ex.authDetails.ClientCertFingerprint,
+		ClientCertExpiry:           clientCertExpiry,
 	}
 }
 
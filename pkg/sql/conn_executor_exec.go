@@ -310,6 +310,9 @@ func (ex *connExecutor) execStmtInOpenState(
 
 	var timeoutTicker *time.Timer
 	queryTimedOut := false
+	// timeoutErr is the error of the timeout which applies to the query,
+	// reported if queryTimedOut is set.
+	timeoutErr := sqlerrors.QueryTimeoutError
 	// doneAfterFunc will be allocated only when timeoutTicker is non-nil.
 	var doneAfterFunc chan struct{}
 
@@ -373,8 +376,8 @@ func (ex *connExecutor) execStmtInOpenState(
 			retEv = eventNonRetriableErr{
 				IsCommit: fsm.FromBool(isCommit(ast)),
 			}
-			res.SetError(sqlerrors.QueryTimeoutError)
-			retPayload = eventNonRetriableErrPayload{err: sqlerrors.QueryTimeoutError}
+			res.SetError(timeoutErr)
+			retPayload = eventNonRetriableErrPayload{err: timeoutErr}
 		}
 	}(ctx, res)
 
@@ -482,24 +485,42 @@ func (ex *connExecutor) execStmtInOpenState(
 		p.extendedEvalCtx.Context = ctx
 	}
 
-	// We exempt `SET` statements from the statement timeout, particularly so as
-	// not to block the `SET statement_timeout` command itself.
-	if ex.sessionData().StmtTimeout > 0 && ast.StatementTag() != "SET" {
-		timerDuration :=
-			ex.sessionData().StmtTimeout - timeutil.Since(ex.phaseTimes.GetSessionPhaseTime(sessionphase.SessionQueryReceived))
-		// There's no need to proceed with execution if the timer has already expired.
-		if timerDuration < 0 {
-			queryTimedOut = true
-			return makeErrEvent(sqlerrors.QueryTimeoutError)
+	// We exempt `SET` statements from the statement and transaction timeouts,
+	// particularly so as not to block the `SET statement_timeout` command
+	// itself.
+	if ast.StatementTag() != "SET" {
+		hasTimeout := false
+		var timerDuration time.Duration
+		if ex.sessionData().StmtTimeout > 0 {
+			hasTimeout = true
+			timerDuration =
+				ex.sessionData().StmtTimeout - timeutil.Since(ex.phaseTimes.GetSessionPhaseTime(sessionphase.SessionQueryReceived))
 		}
-		doneAfterFunc = make(chan struct{}, 1)
-		timeoutTicker = time.AfterFunc(
-			timerDuration,
-			func() {
-				ex.cancelQuery(queryID)
+		// The transaction timeout also bounds the statements of the
+		// transaction, except for the ROLLBACK which ends it.
+		if _, isRollback := ast.(*tree.RollbackTransaction); ex.sessionData().TransactionTimeout > 0 && !isRollback {
+			txnTimerDuration := ex.sessionData().TransactionTimeout - timeutil.Since(ex.state.mu.txnStart)
+			if !hasTimeout || txnTimerDuration < timerDuration {
+				hasTimeout = true
+				timerDuration = txnTimerDuration
+				timeoutErr = sqlerrors.TransactionTimeoutError
+			}
+		}
+		if hasTimeout {
+			// There's no need to proceed with execution if the timer has already expired.
+			if timerDuration < 0 {
 				queryTimedOut = true
-				doneAfterFunc <- struct{}{}
-			})
+				return makeErrEvent(timeoutErr)
+			}
+			doneAfterFunc = make(chan struct{}, 1)
+			timeoutTicker = time.AfterFunc(
+				timerDuration,
+				func() {
+					ex.cancelQuery(queryID)
+					queryTimedOut = true
+					doneAfterFunc <- struct{}{}
+				})
+		}
 	}
 
 	defer func(ctx context.Context) {
@@ -521,10 +542,17 @@ func (ex *connExecutor) execStmtInOpenState(
 		}
 	}(ctx)
 
+	if err := checkPasswordMustChange(ex.sessionData(), ast); err != nil {
+		return makeErrEvent(err)
+	}
+
 	switch s := ast.(type) {
 	case *tree.BeginTransaction:
 		// BEGIN is only allowed if we are in an implicit txn.
 		if os.ImplicitTxn.Get() {
+			if err := ex.checkUserOpenTxnLimit(ctx); err != nil {
+				return makeErrEvent(err)
+			}
 			ex.sessionDataStack.PushTopClone()
 			return eventTxnUpgradeToExplicit{}, nil, nil
 		}
@@ -1238,7 +1266,7 @@ func (ex *connExecutor) handleTxnRowsGuardrails(
 		*alreadyLogged = shouldLog
 	}
 	if shouldLog {
-		commonSQLEventDetails := ex.planner.getCommonSQLEventDetails(defaultRedactionOptions)
+		commonSQLEventDetails := ex.planner.getCommonSQLEventDetails(ctx, defaultRedactionOptions)
 		var event eventpb.EventPayload
 		if ex.executorType == executorTypeInternal {
 			if isRead {
@@ -1389,6 +1417,12 @@ func (ex *connExecutor) execWithDistSQLEngine(
 		testingPushCallback,
 	)
 	recv.progressAtomic = progressAtomic
+	// The results of the internal queries do not reach the client, so they
+	// are not limited.
+	if stmtType == tree.Rows && ex.executorType != executorTypeInternal {
+		sd := planner.SessionData()
+		recv.setResultLimits(sd.MaxResultRows, sd.MaxResultBytes)
+	}
 	defer recv.Release()
 
 	evalCtx := planner.ExtendedEvalContext()
@@ -1484,6 +1518,9 @@ func (ex *connExecutor) beginTransactionTimestampsAndReadMode(
 	asOfClause := ex.asOfClauseWithSessionDefault(modes.AsOf)
 	if asOfClause.Expr == nil {
 		rwMode = ex.readWriteModeWithSessionDefault(modes.ReadWriteMode)
+		if err := checkReadOnlyLogin(ex.sessionData(), rwMode == tree.ReadOnly); err != nil {
+			return 0, time.Time{}, nil, err
+		}
 		return rwMode, now, nil, nil
 	}
 	ex.statsCollector.Reset(ex.applicationStats, ex.phaseTimes)
@@ -1532,6 +1569,9 @@ func (ex *connExecutor) execStmtInNoTxnState(
 		if err != nil {
 			return ex.makeErrEvent(err, s)
 		}
+		if err := ex.checkUserOpenTxnLimit(ctx); err != nil {
+			return ex.makeErrEvent(err, s)
+		}
 		ex.sessionDataStack.PushTopClone()
 		return eventStartExplicitTxn,
 			makeEventTxnStartPayload(
@@ -886,6 +886,13 @@ type streamingCommandResult struct {
 	// on the synchronization strategy.
 	w ieResultWriter
 
+	// discard, if set, indicates that the results are discarded instead of
+	// being written into w, which is then nil. This is the case for the logon
+	// statements of the sessions, which, unlike the internal executor, can
+	// send notices, change the session variables reported to the client and
+	// disable the buffering of the results; all of these are ignored.
+	discard bool
+
 	err          error
 	rowsAffected int
 
@@ -903,21 +910,33 @@ func (r *streamingCommandResult) SetColumns(ctx context.Context, cols colinfo.Re
 	if cols == nil {
 		cols = colinfo.ResultColumns{}
 	}
+	if r.discard {
+		return
+	}
 	_ = r.w.addResult(ctx, ieIteratorResult{cols: cols})
 }
 
 // BufferParamStatusUpdate is part of the RestrictedCommandResult interface.
 func (r *streamingCommandResult) BufferParamStatusUpdate(key string, val string) {
+	if r.discard {
+		return
+	}
 	panic("unimplemented")
 }
 
 // BufferNotice is part of the RestrictedCommandResult interface.
 func (r *streamingCommandResult) BufferNotice(notice pgnotice.Notice) {
+	if r.discard {
+		return
+	}
 	panic("unimplemented")
 }
 
 // ResetStmtType is part of the RestrictedCommandResult interface.
 func (r *streamingCommandResult) ResetStmtType(stmt tree.Statement) {
+	if r.discard {
+		return
+	}
 	panic("unimplemented")
 }
 
@@ -927,6 +946,9 @@ func (r *streamingCommandResult) AddRow(ctx context.Context, row tree.Datums) er
 	// result, so we will not double count the affected rows by an increment
 	// here.
 	r.rowsAffected++
+	if r.discard {
+		return nil
+	}
 	rowCopy := make(tree.Datums, len(row))
 	copy(rowCopy, row)
 	return r.w.addResult(ctx, ieIteratorResult{row: rowCopy})
@@ -944,6 +966,10 @@ func (r *streamingCommandResult) SupportsAddBatch() bool {
 }
 
 func (r *streamingCommandResult) DisableBuffering() {
+	if r.discard {
+		// The discarded results are never buffered.
+		return
+	}
 	panic("cannot disable buffering here")
 }
 
@@ -57,6 +57,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessioninit"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlliveness"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlstats/persistedsqlstats"
@@ -161,6 +162,9 @@ var crdbInternal = virtualSchema{
 		catconstants.CrdbInternalActiveRangeFeedsTable:              crdbInternalActiveRangeFeedsTable,
 		catconstants.CrdbInternalTenantUsageDetailsViewID:           crdbInternalTenantUsageDetailsView,
 		catconstants.CrdbInternalPgCatalogTableIsImplementedTableID: crdbInternalPgCatalogTableIsImplementedTable,
+		catconstants.CrdbInternalNodeFailedLoginAttemptsTableID:     crdbInternalNodeFailedLoginAttemptsTable,
+		catconstants.CrdbInternalClusterAuthAttemptsTableID:         crdbInternalClusterAuthAttemptsTable,
+		catconstants.CrdbInternalEffectiveSessionSettingsTableID:    crdbInternalEffectiveSessionSettingsTable,
 	},
 	validWithNoDatabaseContext: true,
 }
@@ -268,7 +272,10 @@ CREATE TABLE crdb_internal.databases (
 	regions STRING[],
 	survival_goal STRING,
 	placement_policy STRING,
-	create_statement STRING NOT NULL
+	create_statement STRING NOT NULL,
+	super_regions JSONB,
+	region_comments JSONB,
+	alter_statements STRING[] NOT NULL
 )`,
 	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
 		return forEachDatabaseDesc(ctx, p, nil /* all databases */, true, /* requiresPrivileges */
@@ -276,7 +283,10 @@ CREATE TABLE crdb_internal.databases (
 				var survivalGoal tree.Datum = tree.DNull
 				var primaryRegion tree.Datum = tree.DNull
 				var placement tree.Datum = tree.DNull
+				var superRegions tree.Datum = tree.DNull
+				var regionComments tree.Datum = tree.DNull
 				regions := tree.NewDArray(types.String)
+				alterStatements := tree.NewDArray(types.String)
 
 				createNode := tree.CreateDatabase{}
 				createNode.ConnectionLimit = -1
@@ -290,6 +300,11 @@ CREATE TABLE crdb_internal.databases (
 					if err != nil {
 						return err
 					}
+					// The secondary region is set after the super regions are added,
+					// if any. See showCreateDatabaseAlterStatements.
+					if len(regionConfig.SuperRegions()) == 0 {
+						createNode.SecondaryRegion = tree.Name(db.GetRegionConfig().SecondaryRegion)
+					}
 
 					createNode.Regions = make(tree.NameList, len(regionConfig.Regions()))
 					for i, region := range regionConfig.Regions() {
@@ -299,6 +314,40 @@ CREATE TABLE crdb_internal.databases (
 						createNode.Regions[i] = tree.Name(region)
 					}
 
+					// super_regions maps the name of each super region to the
+					// regions in it.
+					superRegionsJSON := json.NewObjectBuilder(len(regionConfig.SuperRegions()))
+					for _, superRegion := range regionConfig.SuperRegions() {
+						regionsJSON := json.NewArrayBuilder(len(superRegion.Regions))
+						for _, region := range superRegion.Regions {
+							regionsJSON.Add(json.FromString(string(region)))
+						}
+						superRegionsJSON.Add(superRegion.SuperRegionName, regionsJSON.Build())
+					}
+					superRegions = tree.NewDJSON(superRegionsJSON.Build())
+
+					// region_comments maps the name of each region with a comment to
+					// its comment.
+					regionEnumID, err := db.MultiRegionEnumID()
+					if err != nil {
+						return err
+					}
+					regionEnum, err := p.Descriptors().GetImmutableTypeByID(
+						ctx, p.txn, regionEnumID, tree.ObjectLookupFlags{},
+					)
+					if err != nil {
+						return err
+					}
+					comments, err := regionEnum.RegionComments()
+					if err != nil {
+						return err
+					}
+					regionCommentsJSON := json.NewObjectBuilder(len(comments))
+					for region, comment := range comments {
+						regionCommentsJSON.Add(string(region), json.FromString(comment))
+					}
+					regionComments = tree.NewDJSON(regionCommentsJSON.Build())
+
 					if db.GetRegionConfig().Placement == descpb.DataPlacement_RESTRICTED {
 						placement = tree.NewDString("restricted")
 						createNode.Placement = tree.DataPlacementRestricted
@@ -323,6 +372,18 @@ CREATE TABLE crdb_internal.databases (
 					default:
 						return errors.Newf("unknown survival goal: %d", db.GetRegionConfig().SurvivalGoal)
 					}
+
+					stmts, err := showCreateDatabaseAlterStatements(
+						db, regionConfig, createNode.SurvivalGoal, comments,
+					)
+					if err != nil {
+						return err
+					}
+					for _, stmt := range stmts {
+						if err := alterStatements.Append(tree.NewDString(stmt)); err != nil {
+							return err
+						}
+					}
 				}
 
 				return addRow(
@@ -334,6 +395,9 @@ CREATE TABLE crdb_internal.databases (
 					survivalGoal,                         // survival_goal
 					placement,                            // data_placement
 					tree.NewDString(createNode.String()), // create_statement
+					superRegions,                         // super_regions
+					regionComments,                       // region_comments
+					alterStatements,                      // alter_statements
 				)
 			})
 	},
@@ -696,6 +760,14 @@ func tsOrNull(micros int64) (tree.Datum, error) {
 	return tree.MakeDTimestamp(ts, time.Microsecond)
 }
 
+// dStringOrNull returns a DString for s, or NULL if s is empty.
+func dStringOrNull(s string) tree.Datum {
+	if s == "" {
+		return tree.DNull
+	}
+	return tree.NewDString(s)
+}
+
 // TODO(tbg): prefix with kv_.
 var crdbInternalJobsTable = virtualSchemaTable{
 	schema: `
@@ -1837,7 +1909,11 @@ CREATE TABLE crdb_internal.%s (
   oldest_query_start TIMESTAMP,      -- the time when the oldest query in the session was started
   kv_txn             STRING,         -- the ID of the current KV transaction
   alloc_bytes        INT,            -- the number of bytes allocated by the session
-  max_alloc_bytes    INT             -- the high water mark of bytes allocated by the session
+  max_alloc_bytes    INT,            -- the high water mark of bytes allocated by the session
+  auth_method        STRING,         -- the authentication method of the session
+  identity_map       STRING,         -- the identity map used to determine the user, if any
+  client_cert_fingerprint STRING,    -- the SHA-256 fingerprint of the client certificate, if any
+  client_cert_expiry TIMESTAMP       -- the expiration time of the client certificate, if any
 )
 `
 
@@ -1917,6 +1993,13 @@ func populateSessionsTable(
 		if err != nil {
 			return err
 		}
+		clientCertExpiryDatum := tree.DNull
+		if session.ClientCertExpiry != nil {
+			clientCertExpiryDatum, err = tree.MakeDTimestamp(*session.ClientCertExpiry, time.Microsecond)
+			if err != nil {
+				return err
+			}
+		}
 		if err := addRow(
 			tree.NewDInt(tree.DInt(session.NodeID)),
 			sessionID,
@@ -1930,6 +2013,10 @@ func populateSessionsTable(
 			kvTxnIDDatum,
 			tree.NewDInt(tree.DInt(session.AllocBytes)),
 			tree.NewDInt(tree.DInt(session.MaxAllocBytes)),
+			dStringOrNull(session.AuthMethod),
+			dStringOrNull(session.IdentityMap),
+			dStringOrNull(session.ClientCertFingerprint),
+			clientCertExpiryDatum,
 		); err != nil {
 			return err
 		}
@@ -1953,6 +2040,10 @@ func populateSessionsTable(
 				tree.DNull,                             // kv_txn
 				tree.DNull,                             // alloc_bytes
 				tree.DNull,                             // max_alloc_bytes
+				tree.DNull,                             // auth_method
+				tree.DNull,                             // identity_map
+				tree.DNull,                             // client_cert_fingerprint
+				tree.DNull,                             // client_cert_expiry
 			); err != nil {
 				return err
 			}
@@ -4124,7 +4215,8 @@ var crdbInternalRegionsTable = virtualSchemaTable{
 	schema: `
 CREATE TABLE crdb_internal.regions (
 	region STRING NOT NULL,
-	zones STRING[] NOT NULL
+	zones STRING[] NOT NULL,
+	live_nodes INT NOT NULL
 )
 	`,
 	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
@@ -4142,6 +4234,7 @@ CREATE TABLE crdb_internal.regions (
 			if err := addRow(
 				tree.NewDString(regionName),
 				zones,
+				tree.NewDInt(tree.DInt(regionMeta.LiveNodes)),
 			); err != nil {
 				return err
 			}
@@ -5632,3 +5725,246 @@ CREATE TABLE crdb_internal.transaction_contention_events (
 		return setupGenerator(ctx, worker, stopper)
 	},
 }
+
+var crdbInternalNodeFailedLoginAttemptsTable = virtualSchemaTable{
+	comment: `most recent failed authentication attempts (RAM; local node only)`,
+	schema: `
+CREATE TABLE crdb_internal.node_failed_login_attempts (
+  timestamp      TIMESTAMPTZ NOT NULL,
+  username       STRING NOT NULL,
+  source_address STRING NOT NULL,
+  method         STRING NOT NULL,
+  reason         STRING NOT NULL,
+  detail         STRING NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.node_failed_login_attempts"); err != nil {
+			return err
+		}
+		for _, a := range p.ExecCfg().AuthAttempts.ListFailed() {
+			ts, err := tree.MakeDTimestampTZ(a.Timestamp, time.Microsecond)
+			if err != nil {
+				return err
+			}
+			if err := addRow(
+				ts,
+				tree.NewDString(a.Username),
+				tree.NewDString(a.RemoteAddress),
+				tree.NewDString(a.Method),
+				tree.NewDString(a.Reason),
+				tree.NewDString(a.Detail),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+var crdbInternalClusterAuthAttemptsTable = virtualSchemaTable{
+	comment: `most recent authentication attempts on any node in the cluster (cluster RPC; expensive!)`,
+	schema: `
+CREATE TABLE crdb_internal.cluster_auth_attempts (
+  node_id        INT NOT NULL,
+  timestamp      TIMESTAMPTZ NOT NULL,
+  username       STRING NOT NULL,
+  source_address STRING NOT NULL,
+  transport      STRING NOT NULL,
+  method         STRING NOT NULL,
+  success        BOOL NOT NULL,
+  reason         STRING,
+  detail         STRING,
+  latency        INTERVAL NOT NULL,
+  cache_hit      BOOL NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.cluster_auth_attempts"); err != nil {
+			return err
+		}
+		response, err := p.extendedEvalCtx.SQLStatusServer.ListAuthAttempts(ctx, &serverpb.ListAuthAttemptsRequest{})
+		if err != nil {
+			return err
+		}
+		for _, a := range response.Attempts {
+			ts, err := tree.MakeDTimestampTZ(a.Timestamp, time.Microsecond)
+			if err != nil {
+				return err
+			}
+			reason, detail := tree.DNull, tree.DNull
+			if !a.Success {
+				reason, detail = tree.NewDString(a.Reason), tree.NewDString(a.Detail)
+			}
+			latency := tree.NewDInterval(
+				duration.MakeDuration(a.Latency.Nanoseconds(), 0 /* days */, 0 /* months */),
+				types.DefaultIntervalTypeMetadata,
+			)
+			if err := addRow(
+				tree.NewDInt(tree.DInt(a.NodeID)),
+				ts,
+				tree.NewDString(a.Username),
+				tree.NewDString(a.RemoteAddress),
+				tree.NewDString(a.Transport),
+				tree.NewDString(a.Method),
+				tree.MakeDBool(tree.DBool(a.Success)),
+				reason,
+				detail,
+				latency,
+				tree.MakeDBool(tree.DBool(a.CacheHit)),
+			); err != nil {
+				return err
+			}
+		}
+		for _, rpcErr := range response.Errors {
+			log.Warningf(ctx, "%v", rpcErr.Message)
+		}
+		return nil
+	},
+}
+
+// crdbInternalEffectiveSessionSettingsTable exposes the defaults of the
+// session variables which apply when a role logs into the current
+// database, along with where each of them comes from. Without a constraint
+// on role_name, the defaults of the current user are listed.
+var crdbInternalEffectiveSessionSettingsTable = virtualSchemaTable{
+	comment: `effective defaults of the session variables of a role in the current database`,
+	schema: `
+CREATE TABLE crdb_internal.effective_session_settings (
+  role_name     STRING NOT NULL,
+  database_name STRING,
+  variable      STRING NOT NULL,
+  value         STRING,
+  source        STRING NOT NULL,
+  source_role   STRING,
+  INDEX(role_name)
+)`,
+	indexes: []virtualIndex{{populate: func(ctx context.Context, constraint tree.Datum, p *planner,
+		_ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) (matched bool, err error) {
+		d := tree.UnwrapDatum(p.EvalContext(), constraint)
+		if d == tree.DNull {
+			return false, nil
+		}
+		roleName := security.MakeSQLUsernameFromPreNormalizedString(string(tree.MustBeDString(d)))
+		if roleName != p.User() {
+			if err := p.CheckRoleOption(ctx, roleoption.CREATEROLE); err != nil {
+				return false, err
+			}
+		}
+		exists, err := p.RoleExists(ctx, roleName)
+		if err != nil || !exists {
+			return false, err
+		}
+		return true, populateEffectiveSessionSettings(ctx, p, roleName, addRow)
+	}}},
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return populateEffectiveSessionSettings(ctx, p, p.User(), addRow)
+	},
+}
+
+// The sources of the effective defaults of the session variables, listed
+// in crdb_internal.effective_session_settings.
+const (
+	settingSourceRoleInDatabase     = "role in database"
+	settingSourceRole               = "role"
+	settingSourceAllRolesInDatabase = "all roles in database"
+	settingSourceAllRoles           = "all roles"
+	settingSourceDefault            = "default"
+)
+
+// settingSourceOfKey returns the source of a default setting stored in the
+// system.database_role_settings row identified by the key.
+func settingSourceOfKey(key sessioninit.SettingsCacheKey) string {
+	switch {
+	case !key.Username.Undefined() && key.DatabaseID != 0:
+		return settingSourceRoleInDatabase
+	case !key.Username.Undefined():
+		return settingSourceRole
+	case key.DatabaseID != 0:
+		return settingSourceAllRolesInDatabase
+	default:
+		return settingSourceAllRoles
+	}
+}
+
+func populateEffectiveSessionSettings(
+	ctx context.Context, p *planner, roleName security.SQLUsername, addRow func(...tree.Datum) error,
+) error {
+	dbID := descpb.ID(0)
+	dbNameDatum := tree.DNull
+	if dbName := p.CurrentDatabase(); dbName != "" {
+		dbDesc, err := p.Descriptors().GetImmutableDatabaseByName(ctx, p.txn, dbName,
+			tree.DatabaseLookupFlags{})
+		if err != nil {
+			return err
+		}
+		if dbDesc != nil {
+			dbID = dbDesc.GetID()
+			dbNameDatum = tree.NewDString(dbName)
+		}
+	}
+	settings, err := p.getEffectiveDefaultSettings(ctx, roleName, dbID)
+	if err != nil {
+		return err
+	}
+	settingsByVar := make(map[string]effectiveDefaultSetting, len(settings))
+	for _, setting := range settings {
+		settingsByVar[setting.variable] = setting
+	}
+
+	roleNameDatum := tree.NewDString(roleName.Normalized())
+	addSettingRow := func(setting effectiveDefaultSetting) error {
+		sourceRole := tree.DNull
+		if !setting.source.Username.Undefined() {
+			sourceRole = tree.NewDString(setting.source.Username.Normalized())
+		}
+		return addRow(
+			roleNameDatum,
+			dbNameDatum,
+			tree.NewDString(setting.variable),
+			tree.NewDString(setting.value),
+			tree.NewDString(settingSourceOfKey(setting.source)),
+			sourceRole,
+		)
+	}
+
+	for _, vName := range varNames {
+		gen := varGen[vName]
+		if gen.Hidden {
+			delete(settingsByVar, vName)
+			continue
+		}
+		if setting, ok := settingsByVar[vName]; ok {
+			delete(settingsByVar, vName)
+			if err := addSettingRow(setting); err != nil {
+				return err
+			}
+			continue
+		}
+		value := tree.DNull
+		if gen.GlobalDefault != nil {
+			value = tree.NewDString(gen.GlobalDefault(&p.ExecCfg().Settings.SV))
+		}
+		if err := addRow(
+			roleNameDatum,
+			dbNameDatum,
+			tree.NewDString(vName),
+			value,
+			tree.NewDString(settingSourceDefault),
+			tree.DNull,
+		); err != nil {
+			return err
+		}
+	}
+
+	// The remaining settings are the defaults of custom options.
+	customOptions := make([]string, 0, len(settingsByVar))
+	for vName := range settingsByVar {
+		customOptions = append(customOptions, vName)
+	}
+	sort.Strings(customOptions)
+	for _, vName := range customOptions {
+		if err := addSettingRow(settingsByVar[vName]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
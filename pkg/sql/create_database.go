@@ -95,6 +95,14 @@ func (p *planner) CreateDatabase(ctx context.Context, n *tree.CreateDatabase) (p
 		)
 	}
 
+	if n.SecondaryRegion != "" &&
+		n.PrimaryRegion == tree.PrimaryRegionNotSpecifiedName {
+		return nil, pgerror.New(
+			pgcode.InvalidDatabaseDefinition,
+			"PRIMARY REGION must be specified when using SECONDARY REGION",
+		)
+	}
+
 	if n.Placement != tree.DataPlacementUnspecified {
 		if !p.EvalContext().SessionData().PlacementEnabled {
 			return nil, errors.WithHint(pgerror.New(
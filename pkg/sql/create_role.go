@@ -74,6 +74,13 @@ func (p *planner) CreateRoleNode(
 	if err := roleOptions.CheckRoleOptionConflicts(); err != nil {
 		return nil, err
 	}
+	if err := p.checkRoleProfileOptions(ctx, roleOptions); err != nil {
+		return nil, err
+	}
+	if roleOptions.Contains(roleoption.TERMINATESESSIONS) {
+		return nil, pgerror.New(pgcode.Syntax,
+			"TERMINATE SESSIONS can only be specified with ALTER ROLE")
+	}
 
 	// Using CREATE ROLE syntax enables NOLOGIN by default.
 	if isRole && !roleOptions.Contains(roleoption.LOGIN) && !roleOptions.Contains(roleoption.NOLOGIN) {
@@ -162,6 +169,13 @@ func (n *CreateRoleNode) startExec(params runParams) error {
 		)
 	}
 
+	if err := checkRoleProfileExists(params, opName, n.roleOptions); err != nil {
+		return err
+	}
+	if err := checkRoleSessionVarOptions(params, n.roleOptions); err != nil {
+		return err
+	}
+
 	// Get a map of statements to execute for role options and their values.
 	stmts, err := n.roleOptions.GetSQLStmts(sqltelemetry.CreateRole)
 	if err != nil {
@@ -227,12 +241,24 @@ func retrievePasswordFromRoleOptions(
 	params runParams, roleOptions roleoption.List,
 ) (hasPasswordOpt bool, hashedPassword []byte, err error) {
 	if !roleOptions.Contains(roleoption.PASSWORD) {
+		if roleOptions.Contains(roleoption.COST) {
+			return false, nil, pgerror.New(pgcode.Syntax,
+				"COST can only be specified along with PASSWORD")
+		}
 		return false, nil, nil
 	}
 	isNull, password, err := roleOptions.GetPassword()
 	if err != nil {
 		return true, nil, err
 	}
+	cost, err := roleOptions.GetPasswordCost()
+	if err != nil {
+		return true, nil, err
+	}
+	if isNull && cost != 0 {
+		return true, nil, pgerror.New(pgcode.Syntax,
+			"COST cannot be specified along with PASSWORD NULL")
+	}
 	if !isNull && params.extendedEvalCtx.ExecCfg.RPCContext.Config.Insecure {
 		// We disallow setting a non-empty password in insecure mode
 		// because insecure means an observer may have MITM'ed the change
@@ -246,7 +272,7 @@ func retrievePasswordFromRoleOptions(
 	}
 
 	if !isNull {
-		if hashedPassword, err = params.p.checkPasswordAndGetHash(params.ctx, password); err != nil {
+		if hashedPassword, err = params.p.checkPasswordAndGetHash(params.ctx, password, cost); err != nil {
 			return true, nil, err
 		}
 	}
@@ -254,8 +280,10 @@ func retrievePasswordFromRoleOptions(
 	return true, hashedPassword, nil
 }
 
+// checkPasswordAndGetHash checks the password against the password
+// policy and hashes it, with the given hashing cost if it is not zero.
 func (p *planner) checkPasswordAndGetHash(
-	ctx context.Context, password string,
+	ctx context.Context, password string, cost int64,
 ) (hashedPassword []byte, err error) {
 	if password == "" {
 		return hashedPassword, security.ErrEmptyPassword
@@ -274,6 +302,10 @@ func (p *planner) checkPasswordAndGetHash(
 			if !schemeSupported {
 				return hashedPassword, unimplemented.NewWithIssueDetailf(issueNum, schemeName, "the password hash scheme %q is not supported", schemeName)
 			}
+			if cost != 0 {
+				return nil, pgerror.New(pgcode.Syntax,
+					"COST cannot be specified along with a pre-hashed password")
+			}
 			return hashedPassword, nil
 		}
 	}
@@ -283,9 +315,9 @@ func (p *planner) checkPasswordAndGetHash(
 			"Passwords must be %d characters or longer.", minLength)
 	}
 
-	hashedPassword, err = security.HashPassword(ctx, &st.SV, password)
+	hashedPassword, err = security.HashPasswordWithCost(ctx, &st.SV, password, cost)
 	if err != nil {
-		return hashedPassword, err
+		return hashedPassword, pgerror.WithCandidateCode(err, pgcode.InvalidParameterValue)
 	}
 
 	return hashedPassword, nil
@@ -1410,6 +1410,10 @@ func NewTableDesc(
 			}
 		}
 
+		if regionalByRowColExists && n.Locality.RegionalByRowExpr != nil {
+			return nil, regionalByRowExprColumnExistsError(regionalByRowCol)
+		}
+
 		if !regionalByRowColExists {
 			if n.Locality.RegionalByRowColumn != tree.RegionalByRowRegionNotSpecifiedName {
 				return nil, pgerror.Newf(
@@ -1419,14 +1423,18 @@ func NewTableDesc(
 				)
 			}
 			oid := typedesc.TypeIDToOID(regionConfig.RegionEnumID())
-			n.Defs = append(
-				n.Defs,
-				regionalByRowDefaultColDef(
-					oid,
-					regionalByRowGatewayRegionDefaultExpr(oid),
-					maybeRegionalByRowOnUpdateExpr(evalCtx, oid),
-				),
-			)
+			if n.Locality.RegionalByRowExpr != nil {
+				n.Defs = append(n.Defs, regionalByRowComputedColDef(oid, n.Locality.RegionalByRowExpr))
+			} else {
+				n.Defs = append(
+					n.Defs,
+					regionalByRowDefaultColDef(
+						oid,
+						regionalByRowGatewayRegionDefaultExpr(oid),
+						maybeRegionalByRowOnUpdateExpr(evalCtx, oid),
+					),
+				)
+			}
 			cdd = append(cdd, nil)
 		}
 
@@ -2287,6 +2295,21 @@ func newTableDesc(
 			return nil, err
 		}
 		regionConfig = &conf
+
+		// Tables created without a LOCALITY clause adopt the default table
+		// locality of the database, if any. CREATE TABLE AS does not accept a
+		// LOCALITY clause, and is left alone. The statement is copied so that
+		// it is logged as it was written.
+		if lc := db.GetRegionConfig().DefaultTableLocality; lc != nil &&
+			n.Locality == nil && !n.As() && !n.Persistence.IsTemporary() {
+			locality, err := localityFromLocalityConfig(lc)
+			if err != nil {
+				return nil, err
+			}
+			withLocality := *n
+			withLocality.Locality = locality
+			n = &withLocality
+		}
 	}
 
 	// We need to run NewTableDesc with caching disabled, because it needs to pull
@@ -2774,6 +2797,41 @@ func regionalByRowDefaultColDef(
 	return c
 }
 
+// regionalByRowComputedColDef returns the definition of the implicit region
+// column of a REGIONAL BY ROW AS (<expr>) table, which is a stored computed
+// column holding the region the expression evaluates to.
+func regionalByRowComputedColDef(oid oid.Oid, expr tree.Expr) *tree.ColumnTableDef {
+	c := &tree.ColumnTableDef{
+		Name:   tree.RegionalByRowRegionDefaultColName,
+		Type:   &tree.OIDTypeReference{OID: oid},
+		Hidden: true,
+	}
+	c.Nullable.Nullability = tree.NotNull
+	c.Computed.Computed = true
+	c.Computed.Expr = &tree.CastExpr{
+		Expr:       expr,
+		Type:       &tree.OIDTypeReference{OID: oid},
+		SyntaxMode: tree.CastShort,
+	}
+	return c
+}
+
+// regionalByRowExprColumnExistsError is returned when REGIONAL BY ROW AS
+// (<expr>) is used on a table which already has the column the expression
+// would be stored in.
+func regionalByRowExprColumnExistsError(colName tree.Name) error {
+	return errors.WithHintf(
+		pgerror.Newf(
+			pgcode.DuplicateColumn,
+			"cannot use REGIONAL BY ROW AS an expression as column %s already exists",
+			colName,
+		),
+		"the region computed from the expression is stored in column %s; "+
+			"use REGIONAL BY ROW AS %s to use the existing column instead",
+		colName, colName,
+	)
+}
+
 // setSequenceOwner adds sequence id to the sequence id list owned by a column
 // and set ownership values of sequence options.
 func setSequenceOwner(
@@ -165,7 +165,8 @@ func (r *databaseRegionChangeFinalizer) updateGlobalTablesZoneConfig(
 	}
 	// If we're not in PLACEMENT RESTRICTED, GLOBAL tables will inherit the
 	// database zone config. Therefore, their constraints do not have to be
-	// refreshed.
+	// refreshed. The same holds for the exceptions to the RESTRICTED placement
+	// policy.
 	if !regionConfig.IsPlacementRestricted() {
 		return nil
 	}
@@ -182,7 +183,13 @@ func (r *databaseRegionChangeFinalizer) updateGlobalTablesZoneConfig(
 		return err
 	}
 
-	err = r.localPlanner.updateZoneConfigsForTables(ctx, dbDesc, WithOnlyGlobalTables)
+	err = r.localPlanner.updateZoneConfigsForTables(
+		ctx,
+		dbDesc,
+		WithOnlyGlobalTablesAndTables(
+			catalog.MakeDescriptorIDSet(dbDesc.GetRegionConfig().PlacementExceptions...),
+		),
+	)
 	if err != nil {
 		return err
 	}
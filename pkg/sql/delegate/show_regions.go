@@ -46,6 +46,11 @@ ORDER BY database_name
 		if dbName == "" {
 			dbName = d.evalCtx.SessionData().Database
 		}
+		commentColumn := ""
+		if n.WithComment {
+			commentColumn = `,
+	r.region_comments->>r.region AS comment`
+		}
 		// Note the LEFT JOIN here -- in the case where regions no longer exist on the cluster
 		// but still exist on the database config, we want to still see this database region
 		// with no zones attached in this query.
@@ -58,18 +63,20 @@ SELECT
 	r.region = r.primary_region AS "primary",
 	COALESCE(zones_table.zones, '{}'::string[])
 AS
-	zones
+	zones%s
 FROM [
 	SELECT
 		name,
 		unnest(dbs.regions) AS region,
-		dbs.primary_region AS primary_region
+		dbs.primary_region AS primary_region,
+		dbs.region_comments AS region_comments
 	FROM crdb_internal.databases dbs
 	WHERE dbs.name = %s
 ] r
 LEFT JOIN zones_table ON (r.region = zones_table.region)
 ORDER BY "primary" DESC, "region"`,
 			zonesClause,
+			commentColumn,
 			lexbase.EscapeSQLString(dbName),
 		)
 		return parse(query)
@@ -92,6 +99,10 @@ ORDER BY
 
 		return parse(query)
 	case tree.ShowRegionsFromDefault:
+		if n.WithDetails {
+			sqltelemetry.IncrementShowCounter(sqltelemetry.RegionsWithDetails)
+			return parse(showRegionsWithDetailsQuery)
+		}
 		sqltelemetry.IncrementShowCounter(sqltelemetry.Regions)
 
 		query := fmt.Sprintf(
@@ -131,3 +142,67 @@ ORDER BY zones_table.region
 	}
 	return nil, errors.Newf("unhandled ShowRegionsFrom: %v", n.ShowRegionsFrom)
 }
+
+// showRegionsWithDetailsQuery implements SHOW REGIONS WITH DETAILS. In
+// addition to the columns of SHOW REGIONS, it shows the number of live nodes
+// in each region, the super regions each region is a part of (as
+// database.super_region) and the targets of the zone configurations which
+// constrain replicas or leaseholders to the region.
+const showRegionsWithDetailsQuery = `
+WITH databases_by_region(region, database_names) AS (
+	SELECT
+		region,
+		array_agg(name) as database_names
+	FROM [
+		SELECT
+			name,
+			unnest(regions) AS region
+		FROM crdb_internal.databases
+	] GROUP BY region
+),
+databases_by_primary_region(region, database_names) AS (
+	SELECT
+		primary_region,
+		array_agg(name)
+	FROM crdb_internal.databases
+	GROUP BY primary_region
+),
+super_regions_by_region(region, super_regions) AS (
+	SELECT
+		region,
+		array_agg(super_region ORDER BY super_region)
+	FROM [
+		SELECT
+			dbs.name || '.' || super_regions.key AS super_region,
+			jsonb_array_elements_text(super_regions.value) AS region
+		FROM crdb_internal.databases dbs, jsonb_each(dbs.super_regions) super_regions
+	] GROUP BY region
+),
+zones_table(region, zones, live_nodes) AS (
+	SELECT
+		region, zones, live_nodes
+	FROM crdb_internal.regions
+),
+zone_configs_by_region(region, zone_configs) AS (
+	SELECT
+		zones_table.region,
+		array_agg(zones.target ORDER BY zones.target)
+	FROM zones_table, crdb_internal.zones zones
+	WHERE zones.raw_config_sql ~ ('\+region=' || zones_table.region || '[:,\]]')
+	GROUP BY zones_table.region
+)
+SELECT
+	zones_table.region,
+	zones_table.zones,
+	zones_table.live_nodes,
+	COALESCE(databases_by_region.database_names, '{}'::string[]) AS database_names,
+	COALESCE(databases_by_primary_region.database_names, '{}'::string[]) AS primary_region_of,
+	COALESCE(super_regions_by_region.super_regions, '{}'::string[]) AS super_regions,
+	COALESCE(zone_configs_by_region.zone_configs, '{}'::string[]) AS zone_configs
+FROM zones_table
+LEFT JOIN databases_by_region ON (zones_table.region = databases_by_region.region)
+LEFT JOIN databases_by_primary_region ON (zones_table.region = databases_by_primary_region.region)
+LEFT JOIN super_regions_by_region ON (zones_table.region = super_regions_by_region.region)
+LEFT JOIN zone_configs_by_region ON (zones_table.region = zone_configs_by_region.region)
+ORDER BY zones_table.region
+`
@@ -11,23 +11,34 @@
 package delegate
 
 import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 )
 
 // delegateShowRoles implements SHOW ROLES which returns all the roles.
-// Privileges: SELECT on system.users.
+// Privileges: SELECT on system.users and system.role_last_login.
 func (d *delegator) delegateShowRoles() (tree.Statement, error) {
 	sqltelemetry.IncrementShowCounter(sqltelemetry.Roles)
-	return parse(`
+	// The time of the last login of the roles is reported once
+	// system.role_last_login exists.
+	lastLogin := `NULL::TIMESTAMP`
+	if d.evalCtx.Settings.Version.IsActive(d.ctx, clusterversion.RoleLastLoginTable) {
+		lastLogin = `(SELECT last_login FROM system.role_last_login AS l WHERE l.username = u.username)`
+	}
+	return parse(fmt.Sprintf(`
 SELECT
 	u.username,
 	IFNULL(string_agg(o.option || COALESCE('=' || o.value, ''), ', ' ORDER BY o.option), '') AS options,
-	ARRAY (SELECT role FROM system.role_members AS rm WHERE rm.member = u.username ORDER BY 1) AS member_of
+	ARRAY (SELECT role FROM system.role_members AS rm WHERE rm.member = u.username ORDER BY 1) AS member_of,
+	%s AS last_login
 FROM
-	system.users AS u LEFT JOIN system.role_options AS o ON u.username = o.username
+	system.users AS u LEFT JOIN system.role_options AS o
+		ON u.username = o.username AND o.option != 'PASSWORD HISTORY'
 GROUP BY
 	u.username
 ORDER BY 1;
-`)
+`, lastLogin))
 }
@@ -34,10 +34,16 @@ func (d *delegator) delegateShowCreate(n *tree.ShowCreate) (tree.Statement, erro
 }
 
 func (d *delegator) delegateShowCreateDatabase(n *tree.ShowCreate) (tree.Statement, error) {
+	// The CREATE DATABASE statement is followed by the statements restoring the
+	// parts of the database which it cannot express, if any.
 	const showCreateQuery = `
 SELECT
 	name AS database_name,
-	create_statement
+	concat_ws(
+		e';\n',
+		create_statement,
+		NULLIF(array_to_string(alter_statements, e';\n'), '')
+	) AS create_statement
 FROM crdb_internal.databases
 WHERE name = %s
 ;
@@ -109,6 +109,9 @@ func (p *planner) createDatabase(
 				),
 			)
 		}
+		if database.SecondaryRegion != "" {
+			telemetry.Inc(sqltelemetry.CreateDatabaseSecondaryRegionCounter)
+		}
 	}
 
 	regionConfig, err := p.maybeInitializeMultiRegionMetadata(
@@ -117,6 +120,7 @@ func (p *planner) createDatabase(
 		database.PrimaryRegion,
 		database.Regions,
 		database.Placement,
+		database.SecondaryRegion,
 	)
 	if err != nil {
 		return nil, false, err
@@ -308,6 +312,17 @@ func TranslateSurvivalGoal(g tree.SurvivalGoal) (descpb.SurvivalGoal, error) {
 	}
 }
 
+// survivalGoalTelemetryName returns the name under which a descpb.SurvivalGoal
+// is reported in telemetry, matching tree.SurvivalGoal.TelemetryName.
+func survivalGoalTelemetryName(g descpb.SurvivalGoal) string {
+	switch g {
+	case descpb.SurvivalGoal_REGION_FAILURE:
+		return "survive_region_failure"
+	default:
+		return "survive_zone_failure"
+	}
+}
+
 // TranslateDataPlacement translates a tree.DataPlacement into a
 // descpb.DataPlacement.
 func TranslateDataPlacement(g tree.DataPlacement) (descpb.DataPlacement, error) {
@@ -343,6 +358,7 @@ var InitializeMultiRegionMetadataCCL = func(
 	primaryRegion catpb.RegionName,
 	regions []tree.Name,
 	dataPlacement tree.DataPlacement,
+	secondaryRegion catpb.RegionName,
 ) (*multiregion.RegionConfig, error) {
 	return nil, sqlerrors.NewCCLRequiredError(
 		errors.New("creating multi-region databases requires a CCL binary"),
@@ -371,6 +387,7 @@ func (p *planner) maybeInitializeMultiRegionMetadata(
 	primaryRegion tree.Name,
 	regions []tree.Name,
 	placement tree.DataPlacement,
+	secondaryRegion tree.Name,
 ) (*multiregion.RegionConfig, error) {
 	if primaryRegion == "" && len(regions) == 0 {
 		defaultPrimaryRegion := DefaultPrimaryRegion.Get(&p.execCfg.Settings.SV)
@@ -398,6 +415,7 @@ func (p *planner) maybeInitializeMultiRegionMetadata(
 		catpb.RegionName(primaryRegion),
 		regions,
 		placement,
+		catpb.RegionName(secondaryRegion),
 	)
 	if err != nil {
 		return nil, err
@@ -46,6 +46,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/contextutil"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/metric"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
@@ -667,6 +668,14 @@ type DistSQLReceiver struct {
 	expectedRowsRead int64
 	progressAtomic   *uint64
 
+	// resultLimits bound the number of rows and the size of the results of
+	// the statement, as set by the MAX_RESULT_ROWS and MAX_RESULT_BYTES
+	// role options of the user. A zero limit is not enforced.
+	resultLimits struct {
+		maxRows, maxBytes int64
+		rows, bytes       int64
+	}
+
 	// contendedQueryMetric is a Counter that is incremented at most once if the
 	// query produces at least one contention event.
 	contendedQueryMetric *metric.Counter
@@ -897,6 +906,46 @@ func (r *DistSQLReceiver) Release() {
 	receiverSyncPool.Put(r)
 }
 
+// setResultLimits sets the limits on the number of rows and the size of
+// the results which the statement can return. The results are then pushed
+// one row at a time, so that they can be accounted for.
+func (r *DistSQLReceiver) setResultLimits(maxRows, maxBytes int64) {
+	r.resultLimits.maxRows = maxRows
+	r.resultLimits.maxBytes = maxBytes
+	if maxRows > 0 || maxBytes > 0 {
+		r.batchWriter = nil
+	}
+}
+
+// checkResultLimits accounts for a result row, and returns an error if the
+// results of the statement exceed their limits.
+func (r *DistSQLReceiver) checkResultLimits(row tree.Datums) error {
+	l := &r.resultLimits
+	l.rows++
+	if l.maxRows > 0 && l.rows > l.maxRows {
+		return errors.WithHint(
+			pgerror.Newf(pgcode.ProgramLimitExceeded,
+				"statement returned more than %d rows, the limit set by the MAX_RESULT_ROWS option of the user",
+				l.maxRows),
+			"Use LIMIT or more selective filters to reduce the number of rows.",
+		)
+	}
+	if l.maxBytes > 0 {
+		for _, d := range row {
+			l.bytes += int64(d.Size())
+		}
+		if l.bytes > l.maxBytes {
+			return errors.WithHint(
+				pgerror.Newf(pgcode.ProgramLimitExceeded,
+					"statement returned more than %s of results, the limit set by the MAX_RESULT_BYTES option of the user",
+					humanizeutil.IBytes(l.maxBytes)),
+				"Use LIMIT, more selective filters or fewer columns to reduce the size of the results.",
+			)
+		}
+	}
+	return nil
+}
+
 // clone clones the receiver for running sub- and post-queries. Not all fields
 // are cloned. The receiver should be released when no longer needed.
 func (r *DistSQLReceiver) clone() *DistSQLReceiver {
@@ -1108,6 +1157,12 @@ func (r *DistSQLReceiver) Push(
 			r.row[i] = encDatum.Datum
 		}
 	}
+	if r.resultLimits.maxRows > 0 || r.resultLimits.maxBytes > 0 {
+		if err := r.checkResultLimits(r.row); err != nil {
+			r.SetError(err)
+			return r.status
+		}
+	}
 	r.tracing.TraceExecRowsResult(r.ctx, r.row)
 	if commErr := r.resultWriter.AddRow(r.ctx, r.row); commErr != nil {
 		r.handleCommErr(commErr)
@@ -16,6 +16,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
@@ -33,24 +34,35 @@ import (
 // DropRoleNode deletes entries from the system.users table.
 // This is called from DROP USER and DROP ROLE.
 type DropRoleNode struct {
-	ifExists  bool
-	isRole    bool
-	roleNames []security.SQLUsername
+	ifExists          bool
+	isRole            bool
+	roleNames         []security.SQLUsername
+	terminateSessions bool
 }
 
 // DropRole represents a DROP ROLE statement.
 // Privileges: CREATEROLE privilege.
 func (p *planner) DropRole(ctx context.Context, n *tree.DropRole) (planNode, error) {
-	return p.DropRoleNode(ctx, n.Names, n.IfExists, n.IsRole, "DROP ROLE")
+	return p.DropRoleNode(ctx, n.Names, n.IfExists, n.IsRole, n.TerminateSessions, "DROP ROLE")
 }
 
 // DropRoleNode creates a "drop user" plan node. This can be called from DROP USER or DROP ROLE.
 func (p *planner) DropRoleNode(
-	ctx context.Context, roleSpecs tree.RoleSpecList, ifExists bool, isRole bool, opName string,
+	ctx context.Context,
+	roleSpecs tree.RoleSpecList,
+	ifExists bool,
+	isRole bool,
+	terminateSessions bool,
+	opName string,
 ) (*DropRoleNode, error) {
 	if err := p.CheckRoleOption(ctx, roleoption.CREATEROLE); err != nil {
 		return nil, err
 	}
+	if terminateSessions {
+		if err := p.checkCanTerminateRoleSessions(ctx); err != nil {
+			return nil, err
+		}
+	}
 
 	for _, r := range roleSpecs {
 		if r.RoleSpecType != tree.RoleName {
@@ -63,9 +75,10 @@ func (p *planner) DropRoleNode(
 	}
 
 	return &DropRoleNode{
-		ifExists:  ifExists,
-		isRole:    isRole,
-		roleNames: roleNames,
+		ifExists:          ifExists,
+		isRole:            isRole,
+		roleNames:         roleNames,
+		terminateSessions: terminateSessions,
 	}, nil
 }
 
@@ -298,6 +311,7 @@ func (n *DropRoleNode) startExec(params runParams) error {
 
 	// All safe - do the work.
 	var numRoleMembershipsDeleted, numRoleSettingsRowsDeleted int
+	var droppedRoles []security.SQLUsername
 	for normalizedUsername := range userNames {
 		// Specifically reject special users and roles. Some (root, admin) would fail with
 		// "privileges still exist" first.
@@ -345,6 +359,9 @@ func (n *DropRoleNode) startExec(params runParams) error {
 		if numUsersDeleted == 0 && !n.ifExists {
 			return errors.Errorf("role/user %s does not exist", normalizedUsername)
 		}
+		if numUsersDeleted > 0 {
+			droppedRoles = append(droppedRoles, normalizedUsername)
+		}
 
 		// Drop all role memberships involving the user/role.
 		rowsDeleted, err := params.extendedEvalCtx.ExecCfg.InternalExecutor.Exec(
@@ -388,6 +405,39 @@ func (n *DropRoleNode) startExec(params runParams) error {
 		}
 		numRoleSettingsRowsDeleted += rowsDeleted
 
+		if params.p.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.RoleTOTPTable) {
+			if _, err := params.extendedEvalCtx.ExecCfg.InternalExecutor.Exec(
+				params.ctx,
+				opName,
+				params.p.txn,
+				`DELETE FROM system.role_totp WHERE username = $1`,
+				normalizedUsername,
+			); err != nil {
+				return err
+			}
+		}
+		if params.p.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.WebAuthnCredentialsTable) {
+			if _, err := params.extendedEvalCtx.ExecCfg.InternalExecutor.Exec(
+				params.ctx,
+				opName,
+				params.p.txn,
+				`DELETE FROM system.webauthn_credentials WHERE username = $1`,
+				normalizedUsername,
+			); err != nil {
+				return err
+			}
+		}
+		if params.p.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.RoleLastLoginTable) {
+			if _, err := params.extendedEvalCtx.ExecCfg.InternalExecutor.Exec(
+				params.ctx,
+				opName,
+				params.p.txn,
+				`DELETE FROM system.role_last_login WHERE username = $1`,
+				normalizedUsername,
+			); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Bump role-related table versions to force a refresh of membership/auth
@@ -411,6 +461,10 @@ func (n *DropRoleNode) startExec(params runParams) error {
 		}
 	}
 
+	if n.terminateSessions && len(droppedRoles) > 0 {
+		params.p.terminateRoleSessionsOnCommit(droppedRoles)
+	}
+
 	normalizedNames := make([]string, len(n.roleNames))
 	for i, name := range n.roleNames {
 		normalizedNames[i] = name.Normalized()
@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scexec"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
@@ -187,10 +188,16 @@ var defaultRedactionOptions = redactionOptions{
 	omitSQLNameRedaction: false,
 }
 
-func (p *planner) getCommonSQLEventDetails(opt redactionOptions) eventpb.CommonSQLEventDetails {
+func (p *planner) getCommonSQLEventDetails(
+	ctx context.Context, opt redactionOptions,
+) eventpb.CommonSQLEventDetails {
+	var fmtOpts []tree.FmtCtxOption
+	if resolvesNamesInEventLog(p.stmt.AST) {
+		fmtOpts = append(fmtOpts, tree.FmtResolveNames(eventLogNameResolver{ctx: ctx, p: p}))
+	}
 	redactableStmt := formatStmtKeyAsRedactableString(
 		p.extendedEvalCtx.VirtualSchemas, p.stmt.AST,
-		p.extendedEvalCtx.EvalContext.Annotations, opt.toFlags(),
+		p.extendedEvalCtx.EvalContext.Annotations, opt.toFlags(), fmtOpts...,
 	)
 	commonSQLEventDetails := eventpb.CommonSQLEventDetails{
 		Statement:       redactableStmt,
@@ -207,6 +214,67 @@ func (p *planner) getCommonSQLEventDetails(opt redactionOptions) eventpb.CommonS
 	return commonSQLEventDetails
 }
 
+// resolvesNamesInEventLog returns whether the names of the databases and
+// tables referenced by the statement are resolved against the catalog when
+// the statement is recorded in the event log. This keeps the entries about
+// the ALTER DATABASE and ALTER TABLE statements unambiguous after the
+// objects they reference are renamed.
+func resolvesNamesInEventLog(stmt tree.Statement) bool {
+	if stmt == nil {
+		return false
+	}
+	tag := stmt.StatementTag()
+	return strings.HasPrefix(tag, "ALTER DATABASE") || strings.HasPrefix(tag, "ALTER TABLE")
+}
+
+// eventLogNameResolver implements tree.FmtNameResolver using the descriptors
+// visible to the transaction of the planner.
+type eventLogNameResolver struct {
+	ctx context.Context
+	p   *planner
+}
+
+var _ tree.FmtNameResolver = eventLogNameResolver{}
+
+// ResolveDatabaseName is part of the tree.FmtNameResolver interface.
+func (r eventLogNameResolver) ResolveDatabaseName(name tree.Name) (tree.Name, bool) {
+	if r.p.txn == nil {
+		return "", false
+	}
+	dbName := string(name)
+	if dbName == "" {
+		dbName = r.p.CurrentDatabase()
+	}
+	if dbName == "" {
+		return "", false
+	}
+	dbDesc, err := r.p.Descriptors().GetImmutableDatabaseByName(
+		r.ctx, r.p.txn, dbName, tree.DatabaseLookupFlags{AvoidLeased: true},
+	)
+	if err != nil || dbDesc == nil {
+		return "", false
+	}
+	return tree.Name(dbDesc.GetName()), true
+}
+
+// ResolveTableName is part of the tree.FmtNameResolver interface.
+func (r eventLogNameResolver) ResolveTableName(name *tree.TableName) (tree.TableName, bool) {
+	if r.p.txn == nil {
+		return tree.TableName{}, false
+	}
+	tn := *name
+	_, desc, err := resolver.ResolveExistingTableObject(r.ctx, r.p, &tn, tree.ObjectLookupFlags{
+		CommonLookupFlags: tree.CommonLookupFlags{AvoidLeased: true},
+		DesiredObjectKind: tree.TableObject,
+	})
+	if err != nil || desc == nil {
+		return tree.TableName{}, false
+	}
+	tn.ExplicitCatalog = true
+	tn.ExplicitSchema = true
+	return tn, true
+}
+
 // logEventsWithOptions is like logEvent() but it gives control to the
 // caller as to where the event is written to.
 //
@@ -219,7 +287,7 @@ func (p *planner) logEventsWithOptions(
 		p.extendedEvalCtx.ExecCfg, p.txn,
 		1+depth,
 		opts,
-		p.getCommonSQLEventDetails(opts.rOpts),
+		p.getCommonSQLEventDetails(ctx, opts.rOpts),
 		entries...)
 }
 
@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -1251,6 +1252,17 @@ type ExecutorConfig struct {
 	// contention observability.
 	ContentionRegistry *contention.Registry
 
+	// AuthAttempts holds the most recent authentication attempts on this
+	// node.
+	AuthAttempts *AuthAttempts
+
+	// LastLogins records the last successful login of each role.
+	LastLogins *LastLoginRecorder
+
+	// HBARules gives access to the host-based authentication rules of
+	// the pgwire server, for SHOW HBA RULES.
+	HBARules HBARuleLister
+
 	// RootMemoryMonitor is the root memory monitor of the entire server. Do not
 	// use this for normal purposes. It is to be used to establish any new
 	// root-level memory accounts that are not related to a user sessions.
@@ -1438,6 +1450,16 @@ type ExecutorTestingKnobs struct {
 		txnID uuid.UUID,
 		txnFingerprintID roachpb.TransactionFingerprintID,
 	)
+
+	// DisableLastLoginRecording, if set, prevents the last logins of the
+	// roles from being recorded, so that the output of SHOW ROLES is
+	// deterministic.
+	DisableLastLoginRecording bool
+
+	// BeforeMultiRegionConversionStep, if set, is called by the job of ALTER
+	// DATABASE ... CONVERT TO MULTIREGION and RESET PRIMARY REGION before each
+	// of its steps, with the running status of the step.
+	BeforeMultiRegionConversionStep func(status string)
 }
 
 // PGWireTestingKnobs contains knobs for the pgwire module.
@@ -1898,6 +1920,155 @@ type SessionArgs struct {
 	// authentication is skipped. Once the token is used to authenticate, this
 	// value should be zeroed out.
 	SessionRevivalToken []byte
+	// PasswordMustChange is set if the user has the PASSWORD MUST CHANGE
+	// role option. The session is then restricted to changing the
+	// password of the user.
+	PasswordMustChange bool
+	// PasswordAuthenticated is set if the session was authenticated with
+	// the password of the user.
+	PasswordAuthenticated bool
+	// ReadOnlyLogin is set if the user has the READONLY role option. The
+	// session is then restricted to read-only transactions.
+	ReadOnlyLogin bool
+	// AllowedDatabases is set if the user has the ALLOWED DATABASES role
+	// option. The session is then restricted to these databases.
+	AllowedDatabases []string
+	// LockedVars are the session variables set by a default setting of the
+	// role with LOCKED. The session cannot change them.
+	LockedVars map[string]struct{}
+	// MaxStmtTimeout and MaxTxnTimeout are the STATEMENT_TIMEOUT and
+	// TRANSACTION_TIMEOUT role options of the user, if set. The session
+	// is then restricted to shorter statement and transaction timeouts.
+	MaxStmtTimeout time.Duration
+	MaxTxnTimeout  time.Duration
+	// MaxIdleSessionTimeout is the IDLE_SESSION_TIMEOUT role option of the
+	// user, if set. The session cannot raise idle_in_session_timeout above
+	// it, nor disable it.
+	MaxIdleSessionTimeout time.Duration
+	// MaxQualityOfService is the QUALITY_OF_SERVICE role option of the user,
+	// if set. The session cannot raise default_transaction_quality_of_service
+	// above it.
+	MaxQualityOfService string
+	// MaxResultRows and MaxResultBytes are the MAX_RESULT_ROWS and
+	// MAX_RESULT_BYTES role options of the user, if set. They bound the
+	// results of the statements of the session.
+	MaxResultRows  int64
+	MaxResultBytes int64
+	// LoginTrace is the recording of the login sequence of the session, if
+	// sql.trace.login.enabled was set when the client connected.
+	LoginTrace tracing.Recording
+	// AuthDetails describes how the session was authenticated.
+	AuthDetails SessionAuthDetails
+	// DefaultsProvenance records the values provided for the session
+	// variables when the session was established, including the ones
+	// overridden by a source with a higher precedence. It is reported by
+	// SHOW SETTING PROVENANCE.
+	DefaultsProvenance []SessionDefaultProvenance
+}
+
+// AddDefaultProvenance records that the source provided the value of a
+// session variable when the session was established. It replaces the value
+// previously recorded for the same variable and source, if any.
+func (s *SessionArgs) AddDefaultProvenance(p SessionDefaultProvenance) {
+	for i := range s.DefaultsProvenance {
+		prev := &s.DefaultsProvenance[i]
+		if prev.Variable == p.Variable && prev.Source == p.Source && prev.Setting == p.Setting {
+			prev.Value = p.Value
+			return
+		}
+	}
+	s.DefaultsProvenance = append(s.DefaultsProvenance, p)
+}
+
+// applyTimeoutCeilings lowers the defaults of statement_timeout and
+// transaction_timeout to the MaxStmtTimeout and MaxTxnTimeout of the
+// session, when they are longer or disabled, including when they are
+// defaulted to the cluster settings. The lowered values are recorded as
+// provided by the role options of the user.
+func (s *SessionArgs) applyTimeoutCeilings(sv *settings.Values) {
+	for _, ceiling := range []struct {
+		varName string
+		max     time.Duration
+	}{
+		{varName: "statement_timeout", max: s.MaxStmtTimeout},
+		{varName: "transaction_timeout", max: s.MaxTxnTimeout},
+	} {
+		if ceiling.max == 0 {
+			continue
+		}
+		value, ok := s.SessionDefaults[ceiling.varName]
+		if !ok {
+			value = varGen[ceiling.varName].GlobalDefault(sv)
+		}
+		timeout, err := validateTimeoutVar(duration.IntervalStyle_POSTGRES, value, ceiling.varName)
+		if err == nil && !exceedsTimeoutCeiling(timeout, ceiling.max) {
+			continue
+		}
+		value = strconv.FormatInt(ceiling.max.Milliseconds(), 10)
+		if s.SessionDefaults == nil {
+			s.SessionDefaults = make(SessionDefaults)
+		}
+		s.SessionDefaults[ceiling.varName] = value
+		s.AddDefaultProvenance(SessionDefaultProvenance{
+			Variable: ceiling.varName, Value: value, Source: SessionDefaultSourceRoleOption,
+		})
+	}
+}
+
+// exceedsTimeoutCeiling returns whether the timeout exceeds the ceiling
+// set by a role option of the user. A disabled timeout always exceeds it,
+// and a zero ceiling sets no limit.
+func exceedsTimeoutCeiling(timeout, ceiling time.Duration) bool {
+	return ceiling != 0 && (timeout == 0 || timeout > ceiling)
+}
+
+// SessionDefaultSource identifies the source of a value provided for a
+// session variable when the session is established. The sources are
+// listed in order of precedence.
+type SessionDefaultSource int
+
+const (
+	// SessionDefaultSourceRoleOption is a role option of the user, or an
+	// option of its profile.
+	SessionDefaultSourceRoleOption SessionDefaultSource = iota
+	// SessionDefaultSourceWebhook is the authorization webhook.
+	SessionDefaultSourceWebhook
+	// SessionDefaultSourceLockedRoleSetting is a default setting of
+	// system.database_role_settings set with LOCKED.
+	SessionDefaultSourceLockedRoleSetting
+	// SessionDefaultSourceClient is a connection parameter of the client.
+	SessionDefaultSourceClient
+	// SessionDefaultSourceRoleSetting is a default setting of
+	// system.database_role_settings.
+	SessionDefaultSourceRoleSetting
+)
+
+// SessionDefaultProvenance is a value provided for a session variable when
+// the session is established, along with its source.
+type SessionDefaultProvenance struct {
+	Variable string
+	Value    string
+	Source   SessionDefaultSource
+	// Setting identifies the system.database_role_settings row which
+	// provided the value, for SessionDefaultSourceRoleSetting and
+	// SessionDefaultSourceLockedRoleSetting.
+	Setting sessioninit.SettingsCacheKey
+}
+
+// SessionAuthDetails describes how a session was authenticated, so that
+// it can be audited with SHOW SESSIONS.
+type SessionAuthDetails struct {
+	// Method is the authentication method of the HBA rule which matched
+	// the connection.
+	Method string
+	// IdentityMap is the name of the identity map used to map the system
+	// identity of the client to the database user, if any.
+	IdentityMap string
+	// ClientCertFingerprint is the SHA-256 fingerprint of the TLS client
+	// certificate presented by the client, if any, and ClientCertExpiry
+	// its expiration time.
+	ClientCertFingerprint string
+	ClientCertExpiry      time.Time
 }
 
 // SessionRegistry stores a set of all sessions on this node.
@@ -1938,6 +2109,9 @@ type registrySession interface {
 	cancelQuery(queryID ClusterWideID) bool
 	cancelCurrentQueries() bool
 	cancelSession()
+	// authenticatedUser returns the user the session was authenticated as,
+	// and whether it was authenticated with the password of the user.
+	authenticatedUser() (_ security.SQLUsername, withPassword bool)
 	// serialize serializes a Session into a serverpb.Session
 	// that can be served over RPC.
 	serialize() serverpb.Session
@@ -2152,6 +2326,19 @@ type SessionTracing struct {
 
 	// lastRecording will collect the recording when stopping tracing.
 	lastRecording []traceRow
+
+	// loginTrace is the recording of the login sequence of the session, if
+	// any. It is included in the first trace of the session.
+	loginTrace tracing.Recording
+	// loginTraceReported is set once loginTrace has been included in a
+	// trace of the session.
+	loginTraceReported bool
+}
+
+// LoginTrace returns the recording of the login sequence of the session,
+// or nil if it was not recorded.
+func (st *SessionTracing) LoginTrace() tracing.Recording {
+	return st.loginTrace
 }
 
 // getSessionTrace returns the session trace. If we're not currently tracing,
@@ -2230,6 +2417,13 @@ func (st *SessionTracing) StartTracing(
 		st.ex.ctxHolder.hijack(newConnCtx)
 	}
 
+	// Include the login sequence in the first trace of the session, so
+	// that a slow connection setup can be explained.
+	if st.loginTrace != nil && !st.loginTraceReported {
+		st.connSpan.ImportRemoteSpans(st.loginTrace)
+		st.loginTraceReported = true
+	}
+
 	// If we're inside a transaction, hijack the txn's ctx with one that has a
 	// recording span.
 	if _, ok := st.ex.machine.CurState().(stateNoTxn); !ok {
@@ -2999,6 +3193,10 @@ func (m *sessionDataMutator) SetStmtTimeout(timeout time.Duration) {
 	m.data.StmtTimeout = timeout
 }
 
+func (m *sessionDataMutator) SetTransactionTimeout(timeout time.Duration) {
+	m.data.TransactionTimeout = timeout
+}
+
 func (m *sessionDataMutator) SetLockTimeout(timeout time.Duration) {
 	m.data.LockTimeout = timeout
 }
@@ -3178,6 +3376,14 @@ func (m *sessionDataMutator) SetEnableImplicitTransactionForBatchStatements(val
 	m.data.EnableImplicitTransactionForBatchStatements = val
 }
 
+func (m *sessionDataMutator) SetAllowUnvalidatedRoleSettings(val bool) {
+	m.data.AllowUnvalidatedRoleSettings = val
+}
+
+func (m *sessionDataMutator) SetLogonStatements(val string) {
+	m.data.LogonStatements = val
+}
+
 // Utility functions related to scrubbing sensitive information on SQL Stats.
 
 // quantizeCounts ensures that the Count field in the
@@ -3223,13 +3429,20 @@ func scrubStmtStatKey(vt VirtualTabler, key string) (string, bool) {
 
 // formatStmtKeyAsRedactableString given an AST node this function will fully
 // qualify names using annotations to format it out into a redactable string.
+// Additional formatting options, such as tree.FmtResolveNames, can be
+// provided.
 func formatStmtKeyAsRedactableString(
-	vt VirtualTabler, rootAST tree.Statement, ann *tree.Annotations, fs tree.FmtFlags,
+	vt VirtualTabler,
+	rootAST tree.Statement,
+	ann *tree.Annotations,
+	fs tree.FmtFlags,
+	opts ...tree.FmtCtxOption,
 ) redact.RedactableString {
-	f := tree.NewFmtCtx(
-		tree.FmtAlwaysQualifyTableNames|tree.FmtMarkRedactionNode|fs,
+	opts = append([]tree.FmtCtxOption{
 		tree.FmtAnnotations(ann),
-		tree.FmtReformatTableNames(hideNonVirtualTableNameFunc(vt)))
+		tree.FmtReformatTableNames(hideNonVirtualTableNameFunc(vt)),
+	}, opts...)
+	f := tree.NewFmtCtx(tree.FmtAlwaysQualifyTableNames|tree.FmtMarkRedactionNode|fs, opts...)
 	f.FormatNode(rootAST)
 	formattedRedactableStatementString := f.CloseAndGetString()
 	return redact.RedactableString(formattedRedactableStatementString)
@@ -3259,10 +3472,7 @@ func HashForReporting(secret, appName string) string {
 // tree.FmtHideConstants. It does *not* anonymize the statement, since
 // the result will still contain names and identifiers.
 func formatStatementHideConstants(ast tree.Statement) string {
-	if ast == nil {
-		return ""
-	}
-	return tree.AsStringWithFlags(ast, tree.FmtHideConstants)
+	return tree.FormatStatementFingerprint(ast, 0 /* flags */)
 }
 
 // formatStatementSummary formats the statement using tree.FmtSummary
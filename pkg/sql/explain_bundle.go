@@ -119,12 +119,13 @@ func buildStatementBundle(
 	plan *planTop,
 	planString string,
 	trace tracing.Recording,
+	loginTrace tracing.Recording,
 	placeholders *tree.PlaceholderInfo,
 ) diagnosticsBundle {
 	if plan == nil {
 		return diagnosticsBundle{collectionErr: errors.AssertionFailedf("execution terminated early")}
 	}
-	b := makeStmtBundleBuilder(db, ie, plan, trace, placeholders)
+	b := makeStmtBundleBuilder(db, ie, plan, trace, loginTrace, placeholders)
 
 	b.addStatement()
 	b.addOptPlans()
@@ -132,6 +133,7 @@ func buildStatementBundle(
 	b.addDistSQLDiagrams()
 	b.addExplainVec()
 	b.addTrace()
+	b.addLoginTrace()
 	b.addEnv(ctx)
 
 	buf, err := b.finalize()
@@ -177,6 +179,7 @@ type stmtBundleBuilder struct {
 
 	plan         *planTop
 	trace        tracing.Recording
+	loginTrace   tracing.Recording
 	placeholders *tree.PlaceholderInfo
 
 	z memzipper.Zipper
@@ -187,9 +190,12 @@ func makeStmtBundleBuilder(
 	ie *InternalExecutor,
 	plan *planTop,
 	trace tracing.Recording,
+	loginTrace tracing.Recording,
 	placeholders *tree.PlaceholderInfo,
 ) stmtBundleBuilder {
-	b := stmtBundleBuilder{db: db, ie: ie, plan: plan, trace: trace, placeholders: placeholders}
+	b := stmtBundleBuilder{
+		db: db, ie: ie, plan: plan, trace: trace, loginTrace: loginTrace, placeholders: placeholders,
+	}
 	b.z.Init()
 	return b
 }
@@ -331,6 +337,16 @@ The UI can then be accessed at http://localhost:16686/search`, stmt)
 	}
 }
 
+// addLoginTrace adds the recording of the login sequence of the session
+// as file login-trace.txt, if it was recorded (see
+// sql.trace.login.enabled).
+func (b *stmtBundleBuilder) addLoginTrace() {
+	if b.loginTrace == nil {
+		return
+	}
+	b.z.AddFile("login-trace.txt", b.loginTrace.String())
+}
+
 func (b *stmtBundleBuilder) addEnv(ctx context.Context) {
 	c := makeStmtEnvCollector(ctx, b.ie)
 
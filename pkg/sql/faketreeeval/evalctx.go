@@ -278,6 +278,37 @@ func (*DummyEvalPlanner) RevalidateUniqueConstraint(
 	return errors.WithStack(errEvalPlanner)
 }
 
+// EnrollTOTP is part of the EvalPlanner interface.
+func (*DummyEvalPlanner) EnrollTOTP(ctx context.Context, user security.SQLUsername) (string, error) {
+	return "", errors.WithStack(errEvalPlanner)
+}
+
+// UnenrollTOTP is part of the EvalPlanner interface.
+func (*DummyEvalPlanner) UnenrollTOTP(ctx context.Context, user security.SQLUsername) (bool, error) {
+	return false, errors.WithStack(errEvalPlanner)
+}
+
+// ListSecurityKeys is part of the EvalPlanner interface.
+func (*DummyEvalPlanner) ListSecurityKeys(
+	ctx context.Context, user security.SQLUsername,
+) ([]string, error) {
+	return nil, errors.WithStack(errEvalPlanner)
+}
+
+// UnregisterSecurityKey is part of the EvalPlanner interface.
+func (*DummyEvalPlanner) UnregisterSecurityKey(
+	ctx context.Context, user security.SQLUsername, name string,
+) (bool, error) {
+	return false, errors.WithStack(errEvalPlanner)
+}
+
+// CreateLoginToken is part of the EvalPlanner interface.
+func (*DummyEvalPlanner) CreateLoginToken(
+	ctx context.Context, user security.SQLUsername, lifetime time.Duration,
+) (string, error) {
+	return "", errors.WithStack(errEvalPlanner)
+}
+
 // ExecutorConfig is part of the EvalPlanner interface.
 func (*DummyEvalPlanner) ExecutorConfig() interface{} {
 	return nil
@@ -511,16 +542,16 @@ type DummyPreparedStatementState struct{}
 
 var _ tree.PreparedStatementState = (*DummyPreparedStatementState)(nil)
 
-// HasActivePortals is part of the tree.PreparedStatementState interface.
-func (ps *DummyPreparedStatementState) HasActivePortals() bool {
-	return false
-}
-
 // MigratablePreparedStatements is part of the tree.PreparedStatementState interface.
 func (ps *DummyPreparedStatementState) MigratablePreparedStatements() []sessiondatapb.MigratableSession_PreparedStatement {
 	return nil
 }
 
+// MigratablePortals is part of the tree.PreparedStatementState interface.
+func (ps *DummyPreparedStatementState) MigratablePortals() []sessiondatapb.MigratableSession_Portal {
+	return nil
+}
+
 // HasPortal is part of the tree.PreparedStatementState interface.
 func (ps *DummyPreparedStatementState) HasPortal(_ string) bool {
 	return false
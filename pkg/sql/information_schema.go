@@ -2581,6 +2581,15 @@ func (r roleOptions) createRole() (tree.DBool, error) {
 }
 
 func forEachRoleQuery(ctx context.Context, p *planner) string {
+	// Only the settings which apply to all the applications and all the
+	// networks are reported.
+	settingsFilter := ""
+	if p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.DatabaseRoleSettingsApplicationName) {
+		settingsFilter = ` AND drs.application_name = ''`
+	}
+	if p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.DatabaseRoleSettingsSourceNetwork) {
+		settingsFilter += ` AND drs.source_network = ''`
+	}
 	return `
 SELECT
 	u.username,
@@ -2592,7 +2601,7 @@ FROM
 	LEFT JOIN system.role_options AS ro ON
 			ro.username = u.username
   LEFT JOIN system.database_role_settings AS drs ON 
-			drs.role_name = u.username AND drs.database_id = 0
+			drs.role_name = u.username AND drs.database_id = 0` + settingsFilter + `
 GROUP BY
 	u.username, "isRole", drs.settings;
 `
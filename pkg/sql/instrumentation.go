@@ -333,7 +333,8 @@ func (ih *instrumentationHelper) Finish(
 				&queryLevelStats,
 			)
 			bundle = buildStatementBundle(
-				ih.origCtx, cfg.DB, ie, &p.curPlan, ob.BuildString(), trace, placeholders,
+				ih.origCtx, cfg.DB, ie, &p.curPlan, ob.BuildString(), trace,
+				p.extendedEvalCtx.Tracing.LoginTrace(), placeholders,
 			)
 			bundle.insert(ctx, ih.fingerprint, ast, cfg.StmtDiagnosticsRecorder, ih.diagRequestID)
 			ih.stmtDiagnosticsRecorder.RemoveOngoing(ih.diagRequestID, ih.diagRequest)
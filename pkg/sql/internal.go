@@ -831,6 +831,11 @@ type internalClientComm struct {
 
 	lastDelivered CmdPos
 
+	// discardResults, if set, indicates that the rows and the metadata of the
+	// results are discarded, in which case w is not set. See
+	// streamingCommandResult.discard.
+	discardResults bool
+
 	// sync, if set, is called whenever a Sync is executed.
 	sync func([]resWithPos)
 }
@@ -861,7 +866,8 @@ func (icc *internalClientComm) CreateStatementResult(
 // closed.
 func (icc *internalClientComm) createRes(pos CmdPos, onClose func()) *streamingCommandResult {
 	res := &streamingCommandResult{
-		w: icc.w,
+		w:       icc.w,
+		discard: icc.discardResults,
 		closeCallback: func(res *streamingCommandResult, typ resCloseType) {
 			if typ == discarded {
 				return
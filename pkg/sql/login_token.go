@@ -0,0 +1,112 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// Login tokens are created with crdb_internal.create_login_token(), and
+// accepted in place of a password by the "token" HBA method until they
+// expire. They are signed with the key referenced by the
+// server.user_login.login_token.signing_key cluster setting; changing
+// the key invalidates all the tokens issued so far.
+
+// loginTokenSigningKey is the cluster setting that references the key
+// used to sign the login tokens. Whoever controls the key can forge tokens
+// for any user, so the setting can only be changed by the admins of the
+// system tenant, and the tenants cannot point it at a key of their own.
+var loginTokenSigningKey = settings.RegisterValidatedStringSetting(
+	settings.SystemOnly,
+	"server.user_login.login_token.signing_key",
+	"reference to the secret holding the base64-encoded 256-bit key used to sign "+
+		"the login tokens, as a file path or a URL of a supported secret store; "+
+		"the secret must be available on every node. Since the key allows forging "+
+		"tokens for any user, the setting can only be changed by the admins of the system tenant",
+	"",
+	func(_ *settings.Values, s string) error {
+		if s == "" {
+			return nil
+		}
+		return security.ValidateSecretRef(s)
+	},
+)
+
+// CreateLoginToken is part of the tree.EvalPlanner interface.
+func (p *planner) CreateLoginToken(
+	ctx context.Context, user security.SQLUsername, lifetime time.Duration,
+) (string, error) {
+	// Unlike the other credentials, users cannot create login tokens for
+	// themselves without CREATELOGIN: this would let anybody holding a
+	// token extend its lifetime indefinitely.
+	if err := p.CheckRoleOption(ctx, roleoption.CREATELOGIN); err != nil {
+		return "", err
+	}
+	if user.IsRootUser() || user.IsNodeUser() {
+		return "", pgerror.Newf(pgcode.InvalidParameterValue,
+			"login tokens are not supported for user %s", user)
+	}
+	exists, err := p.RoleExists(ctx, user)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", pgerror.Newf(pgcode.UndefinedObject, "role/user %s does not exist", user)
+	}
+	// As for ALTER ROLE, only admins can create login tokens for the
+	// admins, which would otherwise let the users with CREATELOGIN log in
+	// as an admin.
+	isAdmin, err := p.UserHasAdminRole(ctx, user)
+	if err != nil {
+		return "", err
+	}
+	if isAdmin {
+		if err := p.RequireAdminRole(ctx, "create login tokens for admin"); err != nil {
+			return "", err
+		}
+	}
+	if lifetime <= 0 || lifetime > security.MaxLoginTokenLifetime {
+		return "", pgerror.Newf(pgcode.InvalidParameterValue,
+			"the lifetime of a login token must be positive and at most %s",
+			security.MaxLoginTokenLifetime)
+	}
+	key, err := security.LoadLoginTokenSigningKey(
+		ctx, loginTokenSigningKey.Get(&p.ExecCfg().Settings.SV),
+	)
+	if err != nil {
+		return "", errors.WithHintf(err,
+			"The key must be configured with the cluster setting %s.", loginTokenSigningKey.Key())
+	}
+	return security.CreateLoginToken(key, user, timeutil.Now(), lifetime)
+}
+
+// VerifyLoginToken checks a login token presented by a user in place of
+// a password.
+func VerifyLoginToken(
+	ctx context.Context, execCfg *ExecutorConfig, user security.SQLUsername, token string,
+) error {
+	key, err := security.LoadLoginTokenSigningKey(
+		ctx, loginTokenSigningKey.Get(&execCfg.Settings.SV),
+	)
+	if err != nil {
+		return err
+	}
+	return security.ValidateLoginToken(key, user, token, timeutil.Now())
+}
@@ -0,0 +1,109 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"io"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+// runLogonStatements executes the statements of the logon_statements
+// session variable, which is usually a default setting of the role, before
+// the session processes the commands of the client. The statements are
+// executed in the session, so that they can modify it, as a batch of the
+// simple protocol. Their results are collected by an internalClientComm,
+// as for the internal executor, and discarded, except for the first
+// error, which is reported to the client and terminates the session. The
+// notices and the changes of the session variables which are reported to
+// the client, such as application_name, are discarded as well.
+//
+// The statements are not executed for the internal sessions, which would
+// otherwise execute them for every internal query of a session.
+func (ex *connExecutor) runLogonStatements(ctx context.Context) error {
+	sql := ex.sessionData().LogonStatements
+	if sql == "" || ex.executorType == executorTypeInternal {
+		return nil
+	}
+
+	err := func() error {
+		stmts, err := parser.Parse(sql)
+		if err != nil {
+			return err
+		}
+		buf := NewStmtBuf()
+		for i, stmt := range stmts {
+			if err := buf.Push(ctx, ExecStmt{
+				Statement:    stmt,
+				TimeReceived: ex.server.cfg.Clock.PhysicalTime(),
+				LastInBatch:  i == len(stmts)-1,
+			}); err != nil {
+				return err
+			}
+		}
+		if err := buf.Push(ctx, Sync{}); err != nil {
+			return err
+		}
+		buf.Close()
+
+		var results []resWithPos
+		comm := &internalClientComm{
+			// init lastDelivered below the position of the first result (0).
+			lastDelivered:  -1,
+			discardResults: true,
+			sync: func(res []resWithPos) {
+				results = append(results, res...)
+			},
+		}
+		clientComm, stmtBuf := ex.clientComm, ex.stmtBuf
+		ex.clientComm, ex.stmtBuf = comm, buf
+		defer func() {
+			ex.clientComm, ex.stmtBuf = clientComm, stmtBuf
+		}()
+		for {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := ex.execCmd(); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return err
+			}
+		}
+		for _, res := range append(results, comm.results...) {
+			if err := res.Err(); err != nil {
+				return err
+			}
+		}
+		if _, noTxn := ex.machine.CurState().(stateNoTxn); !noTxn {
+			return pgerror.New(pgcode.InvalidTransactionTermination,
+				"logon statements must not leave a transaction open")
+		}
+		return nil
+	}()
+	if err == nil {
+		return nil
+	}
+
+	err = errors.Wrap(err, "executing logon statements")
+	res := ex.clientComm.CreateErrorResult(0 /* pos */)
+	res.SetError(err)
+	res.Close(ctx, IdleTxnBlock)
+	if flushErr := ex.clientComm.Flush(0 /* pos */); flushErr != nil {
+		return errors.CombineErrors(err, flushErr)
+	}
+	return err
+}
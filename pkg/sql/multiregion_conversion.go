@@ -0,0 +1,449 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// multiRegionConversionResumer runs the job created by
+// ALTER DATABASE ... CONVERT TO MULTIREGION. The conversion is broken up into
+// the same steps a user would run by hand: setting the primary region, adding
+// the remaining regions, setting the survival goal and finally altering the
+// locality of each table. The remaining steps are re-planned from the state of
+// the database every time the job is resumed, so steps which have already been
+// run are skipped.
+//
+// If the conversion fails or is canceled after the job has set the primary
+// region, the database, which was a regular database when the job was
+// created, is converted back to a regular database.
+//
+// The same job converts a multi-region database back to a regular database
+// for ALTER DATABASE ... RESET PRIMARY REGION, in which case the steps undo
+// the multi-region configuration of the database instead. A failed demotion
+// is not undone, as the implicit region columns it drops cannot be restored.
+//
+// The statements of the completed steps are recorded in the progress of the
+// job, so that the state in which a failed job left the database is known.
+type multiRegionConversionResumer struct {
+	job *jobs.Job
+}
+
+var _ jobs.Resumer = &multiRegionConversionResumer{}
+
+// multiRegionConversionStep is a single statement run by the conversion job.
+type multiRegionConversionStep struct {
+	// status is reported as the running status of the job while the step runs.
+	status string
+	stmt   string
+	// setsPrimaryRegion is set for the step which makes a regular database a
+	// multi-region database.
+	setsPrimaryRegion bool
+}
+
+// Resume implements the jobs.Resumer interface.
+func (r *multiRegionConversionResumer) Resume(ctx context.Context, execCtx interface{}) error {
+	p := execCtx.(JobExecContext)
+	execCfg := p.ExecCfg()
+	details := r.job.Details().(jobspb.MultiRegionConversionDetails)
+	completed := r.job.Progress().GetMultiRegionConversion().CompletedSteps
+
+	var steps []multiRegionConversionStep
+	if err := DescsTxn(ctx, execCfg, func(
+		ctx context.Context, txn *kv.Txn, col *descs.Collection,
+	) (err error) {
+		if details.Demote {
+			steps, err = planMultiRegionDemotion(ctx, execCfg, txn, col, details)
+		} else {
+			steps, err = planMultiRegionConversion(ctx, execCfg, txn, col, details)
+		}
+		return err
+	}); err != nil {
+		return err
+	}
+	if details.Demote {
+		log.Infof(ctx, "converting multi-region database %d to a regular database in %d steps",
+			details.DatabaseID, len(steps))
+	} else {
+		log.Infof(ctx, "converting database %d to a multi-region database in %d steps",
+			details.DatabaseID, len(steps))
+	}
+
+	total := int(completed) + len(steps)
+	for _, step := range steps {
+		if step.setsPrimaryRegion {
+			// Record that the database may become a multi-region database
+			// before running the step, so that it is converted back even if the
+			// job is interrupted before the completion of the step is recorded.
+			if err := r.job.Update(ctx, nil /* txn */, func(
+				_ *kv.Txn, md jobs.JobMetadata, ju *jobs.JobUpdater,
+			) error {
+				if err := md.CheckRunningOrReverting(); err != nil {
+					return err
+				}
+				md.Progress.GetMultiRegionConversion().PrimaryRegionSet = true
+				ju.UpdateProgress(md.Progress)
+				return nil
+			}); err != nil {
+				return jobs.SimplifyInvalidStatusError(err)
+			}
+		}
+
+		if err := r.runStep(ctx, p, step, step.status); err != nil {
+			return err
+		}
+
+		if err := r.job.FractionProgressed(ctx, nil, /* txn */
+			func(ctx context.Context, details jobspb.ProgressDetails) float32 {
+				prog := details.(*jobspb.Progress_MultiRegionConversion).MultiRegionConversion
+				prog.CompletedSteps++
+				prog.CompletedStatements = append(prog.CompletedStatements, step.stmt)
+				return float32(prog.CompletedSteps) / float32(total)
+			},
+		); err != nil {
+			return jobs.SimplifyInvalidStatusError(err)
+		}
+	}
+	return nil
+}
+
+// runStep runs a step of the conversion, reporting status as the running
+// status of the job while it runs.
+func (r *multiRegionConversionResumer) runStep(
+	ctx context.Context, p JobExecContext, step multiRegionConversionStep, status string,
+) error {
+	execCfg := p.ExecCfg()
+	if fn := execCfg.TestingKnobs.BeforeMultiRegionConversionStep; fn != nil {
+		fn(status)
+	}
+	if err := r.job.RunningStatus(ctx, nil /* txn */, func(
+		_ context.Context, _ jobspb.Details,
+	) (jobs.RunningStatus, error) {
+		return jobs.RunningStatus(status), nil
+	}); err != nil {
+		return jobs.SimplifyInvalidStatusError(err)
+	}
+
+	if _, err := execCfg.InternalExecutor.ExecEx(
+		ctx,
+		"multiregion-conversion",
+		nil, /* txn */
+		sessiondata.InternalExecutorOverride{User: p.User()},
+		step.stmt,
+	); err != nil {
+		return errors.Wrapf(err, "failed to %s", step.status)
+	}
+	return nil
+}
+
+// OnFailOrCancel implements the jobs.Resumer interface.
+func (r *multiRegionConversionResumer) OnFailOrCancel(ctx context.Context, execCtx interface{}) error {
+	p := execCtx.(JobExecContext)
+	execCfg := p.ExecCfg()
+	details := r.job.Details().(jobspb.MultiRegionConversionDetails)
+	prog := r.job.Progress().GetMultiRegionConversion()
+	if len(prog.CompletedStatements) > 0 {
+		log.Warningf(ctx, "conversion of database %d stopped after running: %s",
+			details.DatabaseID, strings.Join(prog.CompletedStatements, "; "))
+	}
+	if details.Demote || !prog.PrimaryRegionSet {
+		// Either the steps which have completed cannot be undone, or the job
+		// has not made the database a multi-region database. Each step leaves
+		// the database in a valid state either way.
+		return nil
+	}
+
+	// The database was a regular database when the job was created, so the
+	// conversion is undone by converting it back to a regular database. The
+	// steps are planned from the state of the database, so they are only run
+	// once even if this is retried.
+	var steps []multiRegionConversionStep
+	if err := DescsTxn(ctx, execCfg, func(
+		ctx context.Context, txn *kv.Txn, col *descs.Collection,
+	) (err error) {
+		steps, err = planMultiRegionDemotion(ctx, execCfg, txn, col, details)
+		return err
+	}); err != nil {
+		if sqlerrors.IsUndefinedDatabaseError(err) || catalog.HasInactiveDescriptorError(err) {
+			// The database has been dropped, there is nothing to undo.
+			return nil
+		}
+		return err
+	}
+	log.Infof(ctx, "converting database %d back to a regular database in %d steps",
+		details.DatabaseID, len(steps))
+	for _, step := range steps {
+		if err := r.runStep(ctx, p, step, fmt.Sprintf("reverting: %s", step.status)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// planMultiRegionConversion returns the steps which remain to be run to
+// convert the database to the multi-region database described by details.
+func planMultiRegionConversion(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	txn *kv.Txn,
+	col *descs.Collection,
+	details jobspb.MultiRegionConversionDetails,
+) ([]multiRegionConversionStep, error) {
+	_, dbDesc, err := col.GetImmutableDatabaseByID(
+		ctx, txn, details.DatabaseID, tree.DatabaseLookupFlags{Required: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+	dbName := tree.Name(dbDesc.GetName())
+
+	var steps []multiRegionConversionStep
+	existing := make(map[catpb.RegionName]struct{})
+	surviveRegionFailure := false
+	if !dbDesc.IsMultiRegion() {
+		steps = append(steps, multiRegionConversionStep{
+			status: fmt.Sprintf("set primary region %s", details.PrimaryRegion),
+			stmt: tree.AsString(&tree.AlterDatabasePrimaryRegion{
+				Name:          dbName,
+				PrimaryRegion: tree.Name(details.PrimaryRegion),
+			}),
+			setsPrimaryRegion: true,
+		})
+		existing[catpb.RegionName(details.PrimaryRegion)] = struct{}{}
+	} else {
+		regionConfig, err := SynthesizeRegionConfig(ctx, txn, dbDesc.GetID(), col)
+		if err != nil {
+			return nil, err
+		}
+		for _, region := range regionConfig.Regions() {
+			existing[region] = struct{}{}
+		}
+		surviveRegionFailure = regionConfig.SurvivalGoal() == descpb.SurvivalGoal_REGION_FAILURE
+	}
+
+	var toAdd tree.NameList
+	for _, region := range details.Regions {
+		if _, ok := existing[catpb.RegionName(region)]; !ok {
+			toAdd = append(toAdd, tree.Name(region))
+		}
+	}
+	if len(toAdd) > 0 {
+		steps = append(steps, multiRegionConversionStep{
+			status: fmt.Sprintf("add regions %s", tree.AsString(&toAdd)),
+			stmt: tree.AsString(&tree.AlterDatabaseAddRegion{
+				Name:        dbName,
+				Regions:     toAdd,
+				IfNotExists: true,
+			}),
+		})
+	}
+
+	if details.SurviveRegionFailure && !surviveRegionFailure {
+		steps = append(steps, multiRegionConversionStep{
+			status: "set survival goal to region failure",
+			stmt: tree.AsString(&tree.AlterDatabaseSurvivalGoal{
+				Name:         dbName,
+				SurvivalGoal: tree.SurvivalGoalRegionFailure,
+			}),
+		})
+	}
+
+	if details.TableLocality == "" {
+		return steps, nil
+	}
+	all, err := col.GetAllDescriptors(ctx, txn)
+	if err != nil {
+		return nil, err
+	}
+	lCtx := newInternalLookupCtx(all.OrderedDescriptors(), dbDesc)
+	for _, tbID := range lCtx.tbIDs {
+		desc := lCtx.tbDescs[tbID]
+		if desc.Dropped() || !desc.IsTable() {
+			continue
+		}
+		// Only tables which still have the locality given to them when the
+		// primary region was set are altered. Any other locality was either set
+		// by a previous run of this job or by the user.
+		if lc := desc.GetLocalityConfig(); lc != nil {
+			rbt := lc.GetRegionalByTable()
+			if rbt == nil || rbt.Region != nil {
+				continue
+			}
+		}
+		scName, found, err := lCtx.GetSchemaName(
+			ctx, desc.GetParentSchemaID(), desc.GetParentID(), execCfg.Settings.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, errors.AssertionFailedf("schema id %d not found", desc.GetParentSchemaID())
+		}
+		tn := tree.MakeTableNameWithSchema(dbName, tree.Name(scName), tree.Name(desc.GetName()))
+		steps = append(steps, multiRegionConversionStep{
+			status: fmt.Sprintf("set %s of table %s", details.TableLocality, tn.FQString()),
+			stmt:   fmt.Sprintf("ALTER TABLE %s SET %s", tn.FQString(), details.TableLocality),
+		})
+	}
+	return steps, nil
+}
+
+// planMultiRegionDemotion returns the steps which remain to be run to convert
+// the multi-region database described by details back to a regular database.
+// The survival goal is lowered to zone failure and the secondary region and
+// the fallback regions of the primary region are dropped first, so that the
+// regions can be dropped. The tables are then made REGIONAL BY TABLE IN
+// PRIMARY REGION and the implicit region columns of the former REGIONAL BY
+// ROW tables are dropped, so that nothing is homed in, or refers to, any of
+// the regions. Finally the regions are dropped, the primary region last, which
+// removes the multi-region configuration of the database and of its zone
+// configurations.
+func planMultiRegionDemotion(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	txn *kv.Txn,
+	col *descs.Collection,
+	details jobspb.MultiRegionConversionDetails,
+) ([]multiRegionConversionStep, error) {
+	_, dbDesc, err := col.GetImmutableDatabaseByID(
+		ctx, txn, details.DatabaseID, tree.DatabaseLookupFlags{Required: true},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !dbDesc.IsMultiRegion() {
+		return nil, nil
+	}
+	dbName := tree.Name(dbDesc.GetName())
+	regionConfig, err := SynthesizeRegionConfig(ctx, txn, dbDesc.GetID(), col)
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []multiRegionConversionStep
+	if regionConfig.SurvivalGoal() == descpb.SurvivalGoal_REGION_FAILURE {
+		steps = append(steps, multiRegionConversionStep{
+			status: "set survival goal to zone failure",
+			stmt: tree.AsString(&tree.AlterDatabaseSurvivalGoal{
+				Name:         dbName,
+				SurvivalGoal: tree.SurvivalGoalZoneFailure,
+			}),
+		})
+	}
+	if regionConfig.HasSecondaryRegion() {
+		steps = append(steps, multiRegionConversionStep{
+			status: fmt.Sprintf("drop secondary region %s", regionConfig.SecondaryRegion()),
+			stmt: tree.AsString(&tree.AlterDatabaseDropSecondaryRegion{
+				DatabaseName: dbName,
+				IfExists:     true,
+			}),
+		})
+	}
+	if len(regionConfig.FallbackRegions()) > 0 {
+		// Setting the primary region without fallback regions resets them.
+		steps = append(steps, multiRegionConversionStep{
+			status: fmt.Sprintf("drop fallback regions of primary region %s", regionConfig.PrimaryRegion()),
+			stmt: tree.AsString(&tree.AlterDatabasePrimaryRegion{
+				Name:          dbName,
+				PrimaryRegion: tree.Name(regionConfig.PrimaryRegion()),
+			}),
+		})
+	}
+
+	all, err := col.GetAllDescriptors(ctx, txn)
+	if err != nil {
+		return nil, err
+	}
+	regionEnumOID := typedesc.TypeIDToOID(regionConfig.RegionEnumID())
+	lCtx := newInternalLookupCtx(all.OrderedDescriptors(), dbDesc)
+	for _, tbID := range lCtx.tbIDs {
+		desc := lCtx.tbDescs[tbID]
+		if desc.Dropped() || !desc.IsTable() {
+			continue
+		}
+		scName, found, err := lCtx.GetSchemaName(
+			ctx, desc.GetParentSchemaID(), desc.GetParentID(), execCfg.Settings.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, errors.AssertionFailedf("schema id %d not found", desc.GetParentSchemaID())
+		}
+		tn := tree.MakeTableNameWithSchema(dbName, tree.Name(scName), tree.Name(desc.GetName()))
+		if lc := desc.GetLocalityConfig(); lc != nil {
+			if rbt := lc.GetRegionalByTable(); lc.GetRegionalByRow() != nil || (rbt != nil && rbt.Region != nil) {
+				steps = append(steps, multiRegionConversionStep{
+					status: fmt.Sprintf("set REGIONAL BY TABLE locality of table %s", tn.FQString()),
+					stmt: fmt.Sprintf(
+						"ALTER TABLE %s SET LOCALITY REGIONAL BY TABLE IN PRIMARY REGION", tn.FQString(),
+					),
+				})
+			}
+		}
+		for _, c := range desc.PublicColumns() {
+			if c.GetType().Oid() != regionEnumOID || !isImplicitRegionColumn(c) {
+				continue
+			}
+			steps = append(steps, multiRegionConversionStep{
+				status: fmt.Sprintf("drop column %s of table %s", c.GetName(), tn.FQString()),
+				stmt: fmt.Sprintf(
+					"ALTER TABLE %s DROP COLUMN %s", tn.FQString(), tree.NameString(c.GetName()),
+				),
+			})
+		}
+	}
+
+	for _, region := range regionConfig.Regions() {
+		if region == regionConfig.PrimaryRegion() {
+			continue
+		}
+		steps = append(steps, multiRegionConversionStep{
+			status: fmt.Sprintf("drop region %s", region),
+			stmt: tree.AsString(&tree.AlterDatabaseDropRegion{
+				Name:     dbName,
+				Region:   tree.Name(region),
+				IfExists: true,
+			}),
+		})
+	}
+	steps = append(steps, multiRegionConversionStep{
+		status: fmt.Sprintf("drop primary region %s", regionConfig.PrimaryRegion()),
+		stmt: tree.AsString(&tree.AlterDatabaseDropRegion{
+			Name:   dbName,
+			Region: tree.Name(regionConfig.PrimaryRegion()),
+		}),
+	})
+	return steps, nil
+}
+
+func init() {
+	jobs.RegisterConstructor(jobspb.TypeMultiRegionConversion, func(job *jobs.Job, settings *cluster.Settings) jobs.Resumer {
+		return &multiRegionConversionResumer{job: job}
+	})
+}
@@ -99,6 +99,22 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.AlterDatabaseAddSuperRegion(ctx, n)
 	case *tree.AlterDatabaseDropSuperRegion:
 		return p.AlterDatabaseDropSuperRegion(ctx, n)
+	case *tree.AlterDatabaseAlterSuperRegion:
+		return p.AlterDatabaseAlterSuperRegion(ctx, n)
+	case *tree.AlterDatabaseSecondaryRegion:
+		return p.AlterDatabaseSecondaryRegion(ctx, n)
+	case *tree.AlterDatabaseDropSecondaryRegion:
+		return p.AlterDatabaseDropSecondaryRegion(ctx, n)
+	case *tree.AlterDatabaseRenameRegion:
+		return p.AlterDatabaseRenameRegion(ctx, n)
+	case *tree.AlterDatabaseToMultiRegion:
+		return p.AlterDatabaseToMultiRegion(ctx, n)
+	case *tree.AlterDatabaseRegionReplicas:
+		return p.AlterDatabaseRegionReplicas(ctx, n)
+	case *tree.AlterDatabaseResetPrimaryRegion:
+		return p.AlterDatabaseResetPrimaryRegion(ctx, n)
+	case *tree.AlterDatabaseDefaultLocality:
+		return p.AlterDatabaseDefaultLocality(ctx, n)
 	case *tree.AlterDefaultPrivileges:
 		return p.alterDefaultPrivileges(ctx, n)
 	case *tree.AlterIndex:
@@ -121,6 +137,10 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.AlterRole(ctx, n)
 	case *tree.AlterRoleSet:
 		return p.AlterRoleSet(ctx, n)
+	case *tree.RenameRole:
+		return p.RenameRole(ctx, n)
+	case *tree.AlterProfile:
+		return p.AlterProfile(ctx, n)
 	case *tree.AlterSequence:
 		return p.AlterSequence(ctx, n)
 	case *tree.CloseCursor:
@@ -131,6 +151,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.CommentOnConstraint(ctx, n)
 	case *tree.CommentOnDatabase:
 		return p.CommentOnDatabase(ctx, n)
+	case *tree.CommentOnRegion:
+		return p.CommentOnRegion(ctx, n)
 	case *tree.CommentOnSchema:
 		return p.CommentOnSchema(ctx, n)
 	case *tree.CommentOnIndex:
@@ -147,6 +169,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.CreateType(ctx, n)
 	case *tree.CreateRole:
 		return p.CreateRole(ctx, n)
+	case *tree.CreateProfile:
+		return p.CreateProfile(ctx, n)
 	case *tree.CreateSequence:
 		return p.CreateSequence(ctx, n)
 	case *tree.CreateExtension:
@@ -165,6 +189,8 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.DropOwnedBy(ctx)
 	case *tree.DropRole:
 		return p.DropRole(ctx, n)
+	case *tree.DropProfile:
+		return p.DropProfile(ctx, n)
 	case *tree.DropSchema:
 		return p.DropSchema(ctx, n)
 	case *tree.DropSequence:
@@ -217,10 +243,20 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.SetSessionAuthorizationDefault()
 	case *tree.SetSessionCharacteristics:
 		return p.SetSessionCharacteristics(n)
+	case *tree.ShowAuthenticationCache:
+		return p.ShowAuthenticationCache(ctx, n)
 	case *tree.ShowClusterSetting:
 		return p.ShowClusterSetting(ctx, n)
+	case *tree.ShowDefaultSessionVariables:
+		return p.ShowDefaultSessionVariables(ctx, n)
+	case *tree.ShowHBARules:
+		return p.ShowHBARules(ctx, n)
+	case *tree.ShowSettingProvenance:
+		return p.ShowSettingProvenance(ctx, n)
 	case *tree.ShowTenantClusterSetting:
 		return p.ShowTenantClusterSetting(ctx, n)
+	case *tree.ShowCreateAllRoles:
+		return p.ShowCreateAllRoles(ctx, n)
 	case *tree.ShowCreateSchedules:
 		return p.ShowCreateSchedule(ctx, n)
 	case *tree.ShowHistogram:
@@ -260,6 +296,14 @@ func init() {
 		&tree.AlterDatabaseSurvivalGoal{},
 		&tree.AlterDatabaseAddSuperRegion{},
 		&tree.AlterDatabaseDropSuperRegion{},
+		&tree.AlterDatabaseAlterSuperRegion{},
+		&tree.AlterDatabaseSecondaryRegion{},
+		&tree.AlterDatabaseDropSecondaryRegion{},
+		&tree.AlterDatabaseRenameRegion{},
+		&tree.AlterDatabaseToMultiRegion{},
+		&tree.AlterDatabaseRegionReplicas{},
+		&tree.AlterDatabaseResetPrimaryRegion{},
+		&tree.AlterDatabaseDefaultLocality{},
 		&tree.AlterDefaultPrivileges{},
 		&tree.AlterIndex{},
 		&tree.AlterSchema{},
@@ -272,9 +316,12 @@ func init() {
 		&tree.AlterSequence{},
 		&tree.AlterRole{},
 		&tree.AlterRoleSet{},
+		&tree.RenameRole{},
+		&tree.AlterProfile{},
 		&tree.CloseCursor{},
 		&tree.CommentOnColumn{},
 		&tree.CommentOnDatabase{},
+		&tree.CommentOnRegion{},
 		&tree.CommentOnSchema{},
 		&tree.CommentOnIndex{},
 		&tree.CommentOnConstraint{},
@@ -286,6 +333,7 @@ func init() {
 		&tree.CreateSequence{},
 		&tree.CreateType{},
 		&tree.CreateRole{},
+		&tree.CreateProfile{},
 		&tree.Deallocate{},
 		&tree.DeclareCursor{},
 		&tree.Discard{},
@@ -293,6 +341,7 @@ func init() {
 		&tree.DropIndex{},
 		&tree.DropOwnedBy{},
 		&tree.DropRole{},
+		&tree.DropProfile{},
 		&tree.DropSchema{},
 		&tree.DropSequence{},
 		&tree.DropTable{},
@@ -319,8 +368,13 @@ func init() {
 		&tree.SetTransaction{},
 		&tree.SetSessionAuthorizationDefault{},
 		&tree.SetSessionCharacteristics{},
+		&tree.ShowAuthenticationCache{},
 		&tree.ShowClusterSetting{},
+		&tree.ShowDefaultSessionVariables{},
+		&tree.ShowHBARules{},
+		&tree.ShowSettingProvenance{},
 		&tree.ShowTenantClusterSetting{},
+		&tree.ShowCreateAllRoles{},
 		&tree.ShowCreateSchedules{},
 		&tree.ShowHistogram{},
 		&tree.ShowTableStats{},
@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	// See above comment about why this is imported.
 	_ "github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
 )
 
 func FuzzParse(data []byte) int {
@@ -31,3 +32,18 @@ func FuzzParse(data []byte) int {
 	}
 	return 1
 }
+
+// FuzzParseRoundtrip checks that every statement which parses survives being
+// formatted and reparsed.
+func FuzzParseRoundtrip(data []byte) int {
+	stmts, err := parser.Parse(string(data))
+	if err != nil {
+		return 0
+	}
+	for i := range stmts {
+		if err := sqlutils.CheckStatementRoundtrip(stmts[i].AST); err != nil {
+			panic(err)
+		}
+	}
+	return 1
+}
@@ -92,6 +92,9 @@ func TestContextualHelp(t *testing.T) {
 
 		{`ALTER ROLE bleh ?? WITH NOCREATEROLE`, `ALTER ROLE`},
 
+		{`ALTER PROFILE ??`, `ALTER PROFILE`},
+		{`ALTER PROFILE p WITH ??`, `ALTER PROFILE`},
+
 		{`ALTER RANGE foo CONFIGURE ??`, `ALTER RANGE`},
 		{`ALTER RANGE ??`, `ALTER RANGE`},
 
@@ -142,6 +145,9 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE ROLE bleh ??`, `CREATE ROLE`},
 		{`CREATE ROLE bleh ?? WITH CREATEROLE`, `CREATE ROLE`},
 
+		{`CREATE PROFILE ??`, `CREATE PROFILE`},
+		{`CREATE PROFILE p WITH ??`, `CREATE PROFILE`},
+
 		{`CREATE VIEW blah (??`, `CREATE VIEW`},
 		{`CREATE VIEW blah AS (SELECT c FROM x) ??`, `CREATE VIEW`},
 		{`CREATE VIEW blah AS SELECT c FROM x ??`, `SELECT`},
@@ -190,6 +196,9 @@ func TestContextualHelp(t *testing.T) {
 		{`DROP ROLE IF ??`, `DROP ROLE`},
 		{`DROP ROLE IF EXISTS bluh ??`, `DROP ROLE`},
 
+		{`DROP PROFILE ??`, `DROP PROFILE`},
+		{`DROP PROFILE IF EXISTS p ??`, `DROP PROFILE`},
+
 		{`DROP SEQUENCE blah ??`, `DROP SEQUENCE`},
 		{`DROP SEQUENCE IF ??`, `DROP SEQUENCE`},
 		{`DROP SEQUENCE IF EXISTS blih, bloh ??`, `DROP SEQUENCE`},
@@ -305,6 +314,9 @@ func TestContextualHelp(t *testing.T) {
 		{`SHOW SESSION all ??`, `SHOW SESSION`},
 		{`SHOW SESSION SESSION_USER ??`, `SHOW SESSION`},
 
+		{`SHOW SETTING ??`, `SHOW SETTING PROVENANCE`},
+		{`SHOW SETTING PROVENANCE ??`, `SHOW SETTING PROVENANCE`},
+
 		{`SHOW SESSIONS ??`, `SHOW SESSIONS`},
 		{`SHOW LOCAL SESSIONS ??`, `SHOW SESSIONS`},
 
@@ -352,6 +364,7 @@ func TestContextualHelp(t *testing.T) {
 		{`SHOW CREATE TABLE blah ??`, `SHOW CREATE`},
 		{`SHOW CREATE VIEW blah ??`, `SHOW CREATE`},
 		{`SHOW CREATE SEQUENCE blah ??`, `SHOW CREATE`},
+		{`SHOW CREATE ALL ROLES ??`, `SHOW CREATE`},
 
 		{`SHOW CREATE SCHEDULE blah ??`, `SHOW CREATE SCHEDULES`},
 		{`SHOW CREATE ALL SCHEDULES ??`, `SHOW CREATE SCHEDULES`},
@@ -359,6 +372,8 @@ func TestContextualHelp(t *testing.T) {
 		{`SHOW DATABASES ??`, `SHOW DATABASES`},
 
 		{`SHOW DEFAULT PRIVILEGES ??`, `SHOW DEFAULT PRIVILEGES`},
+		{`SHOW DEFAULT SESSION ??`, `SHOW DEFAULT SESSION VARIABLES`},
+		{`SHOW DEFAULT SESSION VARIABLES FOR ROLE foo IN DATABASE ??`, `SHOW DEFAULT SESSION VARIABLES`},
 
 		{`SHOW ENUMS ??`, `SHOW ENUMS`},
 		{`SHOW TYPES ??`, `SHOW TYPES`},
@@ -399,6 +414,13 @@ func TestContextualHelp(t *testing.T) {
 		{`SHOW SYNTAX 'foo' ??`, `SHOW SYNTAX`},
 		{`SHOW SAVEPOINT STATUS ??`, `SHOW SAVEPOINT`},
 
+		{`SHOW AUTHENTICATION ??`, `SHOW AUTHENTICATION CACHE`},
+		{`SHOW AUTHENTICATION CACHE ??`, `SHOW AUTHENTICATION CACHE`},
+
+		{`SHOW HBA ??`, `SHOW HBA RULES`},
+		{`SHOW HBA RULES ??`, `SHOW HBA RULES`},
+		{`SHOW HBA RULES FROM 'x' WITH username = 'y' ??`, `SHOW HBA RULES`},
+
 		{`SHOW TRANSFER ??`, `SHOW TRANSFER`},
 		{`SHOW TRANSFER STATE ??`, `SHOW TRANSFER`},
 		{`SHOW TRANSFER STATE WITH ??`, `SHOW TRANSFER`},
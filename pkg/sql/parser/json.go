@@ -0,0 +1,84 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package parser
+
+import (
+	"encoding/json"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// UnmarshalStatementJSON returns the statement described by the JSON
+// representation produced by tree.MarshalStatementJSON.
+//
+// The statement is imported from the structured representation of the
+// document; the SQL of the document is informational only and is ignored.
+func UnmarshalStatementJSON(data []byte) (tree.Statement, error) {
+	var doc struct {
+		Version int             `json:"version"`
+		Tag     string          `json:"tag"`
+		AST     json.RawMessage `json:"ast"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, pgerror.Wrap(err, pgcode.InvalidTextRepresentation, "invalid statement JSON")
+	}
+	if doc.Version != tree.StatementJSONVersion {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+			"unsupported statement JSON version %d, expected %d", doc.Version, tree.StatementJSONVersion)
+	}
+	if len(doc.AST) == 0 || string(doc.AST) == "null" {
+		return nil, pgerror.New(pgcode.InvalidParameterValue, "statement JSON has no AST")
+	}
+	stmt, err := tree.StatementFromJSON(doc.AST, jsonParser{})
+	if err != nil {
+		return nil, pgerror.Wrap(err, pgcode.InvalidParameterValue, "invalid statement JSON AST")
+	}
+	if doc.Tag != "" && doc.Tag != stmt.StatementTag() {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+			"statement JSON has tag %q but its AST is a %s statement", doc.Tag, stmt.StatementTag())
+	}
+	return stmt, nil
+}
+
+// jsonParser implements tree.StatementJSONParser.
+type jsonParser struct{}
+
+var _ tree.StatementJSONParser = jsonParser{}
+
+// ParseExpr is part of the tree.StatementJSONParser interface.
+func (jsonParser) ParseExpr(sql string) (tree.Expr, error) {
+	return ParseExpr(sql)
+}
+
+// ParseType is part of the tree.StatementJSONParser interface.
+func (jsonParser) ParseType(sql string) (tree.ResolvableTypeReference, error) {
+	expr, err := ParseExpr("1::" + sql)
+	if err != nil {
+		return nil, err
+	}
+	switch t := expr.(type) {
+	case *tree.CastExpr:
+		return t.Type, nil
+	case *tree.CollateExpr:
+		// Collated string types are formatted with a COLLATE clause, which
+		// binds more loosely than the cast.
+		if cast, ok := t.Expr.(*tree.CastExpr); ok {
+			if typ, ok := cast.Type.(*types.T); ok {
+				return types.MakeCollatedString(typ, t.Locale), nil
+			}
+		}
+	}
+	return nil, errors.Newf("invalid type %q", sql)
+}
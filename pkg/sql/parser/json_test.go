@@ -0,0 +1,47 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+)
+
+func TestUnmarshalStatementJSONErrors(t *testing.T) {
+	for _, tc := range []struct {
+		json string
+		err  string
+	}{
+		{`{`, `invalid statement JSON`},
+		{`{"version":2,"ast":{"Name":"db","type":"AlterDatabaseResetPrimaryRegion"}}`,
+			`unsupported statement JSON version 2, expected 1`},
+		{`{"version":1,"sql":"SELECT 1"}`, `statement JSON has no AST`},
+		{`{"version":1,"ast":null}`, `statement JSON has no AST`},
+		{`{"version":1,"ast":{"type":"NoSuchStatement"}}`, `unknown node type "NoSuchStatement"`},
+		{`{"version":1,"ast":{"Name":"db","type":"Name"}}`, `cannot use \*tree.Name as tree.Statement`},
+		{`{"version":1,"ast":{"Name":1,"type":"AlterDatabaseResetPrimaryRegion"}}`,
+			`AlterDatabaseResetPrimaryRegion.Name: expected a string`},
+		{`{"version":1,"ast":{"Values":[{"type":"StrVal","value":"'foo"}],"type":"SetVar"}}`,
+			`unterminated string`},
+		{`{"version":1,"tag":"INSERT","sql":"INSERT INTO t VALUES (1)",` +
+			`"ast":{"Name":"db","type":"AlterDatabaseResetPrimaryRegion"}}`,
+			`statement JSON has tag "INSERT" but its AST is a ALTER DATABASE RESET PRIMARY REGION statement`},
+	} {
+		t.Run(tc.json, func(t *testing.T) {
+			_, err := parser.UnmarshalStatementJSON([]byte(tc.json))
+			if !testutils.IsError(err, tc.err) {
+				t.Fatalf("expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
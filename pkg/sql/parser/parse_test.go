@@ -48,6 +48,9 @@ func TestParseDatadriven(t *testing.T) {
 				// Check pretty-print roundtrip via the sqlfmt logic.
 				sqlutils.VerifyStatementPrettyRoundtrip(t, d.Input)
 
+				// Check that the AST survives being formatted and reparsed.
+				sqlutils.VerifyStatementRoundtrip(t, d.Input)
+
 				ref := stmts.String()
 				note := ""
 				if ref != d.Input {
@@ -78,6 +81,21 @@ func TestParseDatadriven(t *testing.T) {
 					}
 				}
 
+				// Check roundtrip through the JSON representation of the AST.
+				for i := range stmts {
+					j, err := tree.MarshalStatementJSON(stmts[i].AST)
+					if err != nil {
+						d.Fatalf(t, "unexpected error when serializing to JSON: %+v", err)
+					}
+					fromJSON, err := parser.UnmarshalStatementJSON(j)
+					if err != nil {
+						d.Fatalf(t, "unexpected error when deserializing from JSON: %+v\n%s", err, j)
+					}
+					if expected, actual := tree.AsString(stmts[i].AST), tree.AsString(fromJSON); expected != actual {
+						d.Fatalf(t, "mismatched AST after JSON roundtrip:\nexpected: %s\nactual:   %s", expected, actual)
+					}
+				}
+
 				fmt.Fprintln(&buf, stmts.StringWithFlags(tree.FmtAnonymize), "-- identifiers removed")
 				if strings.Contains(ref, tree.PasswordSubstitution) {
 					fmt.Fprintln(&buf, stmts.StringWithFlags(tree.FmtShowPasswords), "-- passwords exposed")
@@ -796,25 +796,25 @@ func (u *sqlSymUnion) cursorStmt() tree.CursorStmt {
 
 // Ordinary key words in alphabetical order.
 %token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADMIN AFTER AGGREGATE
-%token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC
-%token <str> ASENSITIVE ASYMMETRIC AT ATTRIBUTE AUTHORIZATION AUTOMATIC AVAILABILITY
+%token <str> ALL ALLOWED ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE APPLICATION ARRAY AS ASC
+%token <str> ASENSITIVE ASYMMETRIC AT ATTRIBUTE AUTHENTICATION AUTHORIZATION AUTOMATIC AVAILABILITY
 
 %token <str> BACKUP BACKUPS BACKWARD BEFORE BEGIN BETWEEN BIGINT BIGSERIAL BINARY BIT
 %token <str> BUCKET_COUNT
 %token <str> BOOLEAN BOTH BOX2D BUNDLE BY
 
-%token <str> CACHE CANCEL CANCELQUERY CASCADE CASE CAST CBRT CHANGEFEED CHAR
+%token <str> CACHE CANCEL CANCELQUERY CASCADE CASE CAST CBRT CHANGE CHANGEFEED CHAR
 %token <str> CHARACTER CHARACTERISTICS CHECK CLOSE
 %token <str> CLUSTER COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMENTS COMMIT
 %token <str> COMMITTED COMPACT COMPLETE COMPLETIONS CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
 %token <str> CONFLICT CONNECTION CONSTRAINT CONSTRAINTS CONTAINS CONTROLCHANGEFEED CONTROLJOB
-%token <str> CONVERSION CONVERT COPY COVERING CREATE CREATEDB CREATELOGIN CREATEROLE
+%token <str> CONVERSION CONVERT COPY COST COVERING CREATE CREATEDB CREATELOGIN CREATEROLE
 %token <str> CROSS CSV CUBE CURRENT CURRENT_CATALOG CURRENT_DATE CURRENT_SCHEMA
 %token <str> CURRENT_ROLE CURRENT_TIME CURRENT_TIMESTAMP
 %token <str> CURRENT_USER CURSOR CYCLE
 
-%token <str> DATA DATABASE DATABASES DATE DAY DEBUG_PAUSE_ON DEC DECIMAL DEFAULT DEFAULTS
-%token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DESC DESTINATION DETACHED
+%token <str> DATA DATABASE DATABASES DATE DAY DAYS DEBUG_PAUSE_ON DEC DECIMAL DEFAULT DEFAULTS
+%token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DESC DESTINATION DETACHED DETAILS
 %token <str> DISCARD DISTINCT DO DOMAIN DOUBLE DROP
 
 %token <str> ELSE ENCODING ENCRYPTED ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
@@ -823,7 +823,7 @@ func (u *sqlSymUnion) cursorStmt() tree.CursorStmt {
 %token <str> EXPERIMENTAL_AUDIT EXPERIMENTAL_RELOCATE
 %token <str> EXPIRATION EXPLAIN EXPORT EXTENSION EXTRACT EXTRACT_DURATION
 
-%token <str> FAILURE FALSE FAMILY FETCH FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH
+%token <str> FAILURE FALLBACK FALSE FAMILY FETCH FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH
 %token <str> FILES FILTER
 %token <str> FIRST FLOAT FLOAT4 FLOAT8 FLOORDIV FOLLOWING FOR FORCE FORCE_INDEX FORCE_ZIGZAG
 %token <str> FOREIGN FORWARD FROM FULL FUNCTION FUNCTIONS
@@ -832,9 +832,9 @@ func (u *sqlSymUnion) cursorStmt() tree.CursorStmt {
 %token <str> GEOMETRYCOLLECTION GEOMETRYCOLLECTIONM GEOMETRYCOLLECTIONZ GEOMETRYCOLLECTIONZM
 %token <str> GLOBAL GOAL GRANT GRANTS GREATEST GROUP GROUPING GROUPS
 
-%token <str> HAVING HASH HIGH HISTOGRAM HOLD HOUR
+%token <str> HAVING HASH HBA HIGH HISTOGRAM HOLD HOUR
 
-%token <str> IDENTITY
+%token <str> IDENTITY IDLE_SESSION_TIMEOUT
 %token <str> IF IFERROR IFNULL IGNORE_FOREIGN_KEYS ILIKE IMMEDIATE IMPORT IN INCLUDE
 %token <str> INCLUDING INCREMENT INCREMENTAL INCREMENTAL_LOCATION
 %token <str> INET INET_CONTAINED_BY_OR_EQUALS
@@ -851,51 +851,53 @@ func (u *sqlSymUnion) cursorStmt() tree.CursorStmt {
 %token <str> LINESTRING LINESTRINGM LINESTRINGZ LINESTRINGZM
 %token <str> LIST LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
 
-%token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MONTH MOVE
+%token <str> MATCH MATERIALIZED MAX_RESULT_BYTES MAX_RESULT_ROWS MERGE MFA MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MONTH MOVE
+%token <str> MULTIREGION
 %token <str> MULTILINESTRING MULTILINESTRINGM MULTILINESTRINGZ MULTILINESTRINGZM
 %token <str> MULTIPOINT MULTIPOINTM MULTIPOINTZ MULTIPOINTZM
-%token <str> MULTIPOLYGON MULTIPOLYGONM MULTIPOLYGONZ MULTIPOLYGONZM
+%token <str> MULTIPOLYGON MULTIPOLYGONM MULTIPOLYGONZ MULTIPOLYGONZM MUST
 
-%token <str> NAN NAME NAMES NATURAL NEVER NEW_DB_NAME NEW_KMS NEXT NO NOCANCELQUERY NOCONTROLCHANGEFEED
-%token <str> NOCONTROLJOB NOCREATEDB NOCREATELOGIN NOCREATEROLE NOLOGIN NOMODIFYCLUSTERSETTING
-%token <str> NOSQLLOGIN NO_INDEX_JOIN NO_ZIGZAG_JOIN NO_FULL_SCAN NONE NONVOTERS NORMAL NOT NOTHING NOTNULL
+%token <str> NAN NAME NAMES NATURAL NETWORK NEVER NEW_DB_NAME NEW_KMS NEXT NO NOCANCELQUERY NOCONTROLCHANGEFEED
+%token <str> NOCONTROLJOB NOCREATEDB NOCREATELOGIN NOCREATEROLE NOLOGIN NOMFA NOMODIFYCLUSTERSETTING
+%token <str> NOREADONLY NOSQLLOGIN NO_INDEX_JOIN NO_ZIGZAG_JOIN NO_FULL_SCAN NONE NONVOTERS NORMAL NOT NOTHING NOTNULL
 %token <str> NOVIEWACTIVITY NOVIEWACTIVITYREDACTED NOVIEWCLUSTERSETTING NOWAIT NULL NULLIF NULLS NUMERIC
+%token <str> NUM_REPLICAS NUM_VOTERS
 
 %token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD_KMS ON ONLY OPT OPTION OPTIONS OR
-%token <str> ORDER ORDINALITY OTHERS OUT OUTER OVER OVERLAPS OVERLAY OWNED OWNER OPERATOR
+%token <str> ORDER ORDINALITY OTHERS OUT OUTER OVER OVERLAPS OVERLAY OVERRIDE OWNED OWNER OPERATOR
 
-%token <str> PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PHYSICAL PLACEMENT PLACING
+%token <str> PARENT PARTIAL PARTITION PARTITIONS PASSWORD PASSWORD_HISTORY PAUSE PAUSED PHYSICAL PLACEMENT PLACING
 %token <str> PLAN PLANS POINT POINTM POINTZ POINTZM POLYGON POLYGONM POLYGONZ POLYGONZM
 %token <str> POSITION PRECEDING PRECISION PREPARE PRESERVE PRIMARY PRIOR PRIORITY PRIVILEGES
-%token <str> PROCEDURAL PUBLIC PUBLICATION
+%token <str> PROCEDURAL PROFILE PROVENANCE PUBLIC PUBLICATION
 
-%token <str> QUERIES QUERY
+%token <str> QUALITY_OF_SERVICE QUERIES QUERY
 
-%token <str> RANGE RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REF REFERENCES REFRESH
+%token <str> RANGE RANGES READ READONLY REAL REASON REASSIGN RECURSIVE RECURRING REF REFERENCES REFRESH
 %token <str> REGCLASS REGION REGIONAL REGIONS REGNAMESPACE REGPROC REGPROCEDURE REGROLE REGTYPE REINDEX
 %token <str> RELATIVE RELOCATE REMOVE_PATH RENAME REPEATABLE REPLACE REPLICATION
 %token <str> RELEASE RESET RESTORE RESTRICT RESTRICTED RESUME RETURNING RETRY REVISION_HISTORY
-%token <str> REVOKE RIGHT ROLE ROLES ROLLBACK ROLLUP ROUTINES ROW ROWS RSHIFT RULE RUNNING
+%token <str> REVOKE RIGHT ROLE ROLES ROLLBACK ROLLUP ROUTINES ROW ROWS RSHIFT RULE RULES RUNNING
 
-%token <str> SAVEPOINT SCANS SCATTER SCHEDULE SCHEDULES SCROLL SCHEMA SCHEMAS SCRUB SEARCH SECOND SELECT SEQUENCE SEQUENCES
+%token <str> SAVEPOINT SCANS SCATTER SCHEDULE SCHEDULES SCROLL SCHEMA SCHEMAS SCRUB SEARCH SECOND SECONDARY SELECT SEQUENCE SEQUENCES
 %token <str> SERIALIZABLE SERVER SESSION SESSIONS SESSION_USER SET SETS SETTING SETTINGS
 %token <str> SHARE SHOW SIMILAR SIMPLE SKIP SKIP_LOCALITIES_CHECK SKIP_MISSING_FOREIGN_KEYS
 %token <str> SKIP_MISSING_SEQUENCES SKIP_MISSING_SEQUENCE_OWNERS SKIP_MISSING_VIEWS SMALLINT SMALLSERIAL SNAPSHOT SOME SPLIT SQL
 %token <str> SQLLOGIN
 
 %token <str> START STATE STATISTICS STATUS STDIN STREAM STRICT STRING STORAGE STORE STORED STORING SUBSTRING SUPER
-%token <str> SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SQRT SUBSCRIPTION STATEMENTS
+%token <str> SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SQRT SUBJECT SUBSCRIPTION STATEMENTS STATEMENT_TIMEOUT
 
-%token <str> TABLE TABLES TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANTS TESTING_RELOCATE TEXT THEN
-%token <str> TIES TIME TIMETZ TIMESTAMP TIMESTAMPTZ TO THROTTLING TRAILING TRACE
-%token <str> TRANSACTION TRANSACTIONS TRANSFER TREAT TRIGGER TRIM TRUE
+%token <str> TABLE TABLES TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANTS TERMINATE TESTING_RELOCATE TEXT THEN
+%token <str> TIES TIME TIMETZ TIMESTAMP TIMESTAMPTZ TIMEZONE TO THROTTLING TRAILING TRACE
+%token <str> TRANSACTION TRANSACTIONS TRANSACTION_TIMEOUT TRANSFER TREAT TRIGGER TRIM TRUE
 %token <str> TRUNCATE TRUSTED TYPE TYPES
 %token <str> TRACING
 
 %token <str> UNBOUNDED UNCOMMITTED UNION UNIQUE UNKNOWN UNLOGGED UNSPLIT
 %token <str> UPDATE UPSERT UNSET UNTIL USE USER USERS USING UUID
 
-%token <str> VALID VALIDATE VALUE VALUES VARBIT VARCHAR VARIADIC VIEW VARYING VIEWACTIVITY VIEWACTIVITYREDACTED
+%token <str> VALID VALIDATE VALUE VALUES VARBIT VARCHAR VARIABLES VARIADIC VIEW VARYING VIEWACTIVITY VIEWACTIVITYREDACTED
 %token <str> VIEWCLUSTERSETTING VIRTUAL VISIBLE VOTERS
 
 %token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRITE
@@ -948,6 +950,7 @@ func (u *sqlSymUnion) cursorStmt() tree.CursorStmt {
 %type <tree.Statement> alter_database_stmt
 %type <tree.Statement> alter_range_stmt
 %type <tree.Statement> alter_partition_stmt
+%type <tree.Statement> alter_profile_stmt
 %type <tree.Statement> alter_role_stmt
 %type <*tree.SetVar> set_or_reset_clause
 %type <tree.Statement> alter_type_stmt
@@ -989,6 +992,14 @@ func (u *sqlSymUnion) cursorStmt() tree.CursorStmt {
 %type <tree.Statement> alter_database_set_stmt
 %type <tree.Statement> alter_database_add_super_region
 %type <tree.Statement> alter_database_drop_super_region
+%type <tree.Statement> alter_database_alter_super_region
+%type <tree.Statement> alter_database_set_secondary_region_stmt
+%type <tree.Statement> alter_database_drop_secondary_region
+%type <tree.Statement> alter_database_rename_region
+%type <tree.Statement> alter_database_convert_to_multiregion_stmt
+%type <tree.Statement> alter_database_reset_primary_region_stmt
+%type <tree.Statement> alter_database_region_replicas_stmt
+%type <tree.Statement> alter_database_default_locality_stmt
 
 // ALTER INDEX
 %type <tree.Statement> alter_oneindex_stmt
@@ -1040,6 +1051,7 @@ func (u *sqlSymUnion) cursorStmt() tree.CursorStmt {
 %type <tree.Statement> create_database_stmt
 %type <tree.Statement> create_extension_stmt
 %type <tree.Statement> create_index_stmt
+%type <tree.Statement> create_profile_stmt
 %type <tree.Statement> create_role_stmt
 %type <tree.Statement> create_schedule_for_backup_stmt
 %type <tree.Statement> create_schema_stmt
@@ -1061,6 +1073,7 @@ func (u *sqlSymUnion) cursorStmt() tree.CursorStmt {
 %type <tree.Statement> drop_ddl_stmt
 %type <tree.Statement> drop_database_stmt
 %type <tree.Statement> drop_index_stmt
+%type <tree.Statement> drop_profile_stmt
 %type <tree.Statement> drop_role_stmt
 %type <tree.Statement> drop_schema_stmt
 %type <tree.Statement> drop_table_stmt
@@ -1118,6 +1131,7 @@ func (u *sqlSymUnion) cursorStmt() tree.CursorStmt {
 %type <tree.Statement> show_csettings_stmt show_local_or_tenant_csettings_stmt
 %type <tree.Statement> show_databases_stmt
 %type <tree.Statement> show_default_privileges_stmt
+%type <tree.Statement> show_default_session_variables_stmt
 %type <tree.Statement> show_enums_stmt
 %type <tree.Statement> show_fingerprints_stmt
 %type <tree.Statement> show_grants_stmt
@@ -1136,10 +1150,13 @@ func (u *sqlSymUnion) cursorStmt() tree.CursorStmt {
 %type <tree.Statement> show_sequences_stmt
 %type <tree.Statement> show_session_stmt
 %type <tree.Statement> show_sessions_stmt
+%type <tree.Statement> show_setting_provenance_stmt
 %type <tree.Statement> show_savepoint_stmt
 %type <tree.Statement> show_stats_stmt
 %type <tree.Statement> show_syntax_stmt
 %type <tree.Statement> show_last_query_stats_stmt
+%type <tree.Statement> show_authentication_cache_stmt
+%type <tree.Statement> show_hba_rules_stmt
 %type <tree.Statement> show_tables_stmt
 %type <tree.Statement> show_trace_stmt
 %type <tree.Statement> show_transaction_stmt
@@ -1219,12 +1236,13 @@ func (u *sqlSymUnion) cursorStmt() tree.CursorStmt {
 %type <tree.ValidationBehavior> opt_validate_behavior
 
 %type <str> opt_template_clause opt_encoding_clause opt_lc_collate_clause opt_lc_ctype_clause
-%type <tree.NameList> opt_regions_list
+%type <tree.NameList> opt_regions_list opt_fallback_regions_clause
 %type <str> region_name primary_region_clause opt_primary_region_clause
+%type <str> secondary_region_clause opt_secondary_region_clause
 %type <tree.DataPlacement> opt_placement_clause placement_clause
 %type <tree.NameList> region_name_list
 %type <tree.SurvivalGoal> survival_goal_clause opt_survival_goal_clause
-%type <*tree.Locality> locality opt_locality
+%type <*tree.Locality> locality opt_locality opt_table_locality_clause
 %type <int32> opt_connection_limit
 
 %type <tree.IsolationLevel> transaction_iso_level
@@ -1234,12 +1252,18 @@ func (u *sqlSymUnion) cursorStmt() tree.CursorStmt {
 
 %type <str> name opt_name opt_name_parens
 %type <str> privilege savepoint_name
-%type <tree.KVOption> role_option password_clause valid_until_clause
+%type <tree.KVOption> role_option password_clause valid_until_clause connection_limit_clause subject_clause
+%type <tree.KVOption> allowed_databases_clause login_window_clause profile_clause
+%type <tree.Expr> opt_login_window_time_zone
+%type <tree.Exprs> opt_login_window_days
 %type <tree.Operator> subquery_op
 %type <*tree.UnresolvedName> func_name func_name_no_crdb_extra
 %type <str> opt_class opt_collate
 
 %type <str> cursor_name database_name index_name opt_index_name column_name insert_column_item statistics_name window_name opt_in_database
+%type <str> opt_for_application
+%type <str> opt_from_network
+%type <bool> opt_locked
 %type <str> family_name opt_family_name table_alias_name constraint_name target_name zone_name partition_name collation_name
 %type <str> db_object_name_component
 %type <*tree.UnresolvedObjectName> table_name db_name standalone_index_name sequence_name type_name view_name db_object_name simple_db_object_name complex_db_object_name
@@ -1274,7 +1298,7 @@ func (u *sqlSymUnion) cursorStmt() tree.CursorStmt {
 %type <[]tree.RangePartition> range_partitions
 %type <empty> opt_all_clause
 %type <empty> opt_privileges_clause
-%type <bool> distinct_clause opt_with_data
+%type <bool> distinct_clause opt_with_data opt_with_terminate_sessions opt_with_override
 %type <tree.DistinctOn> distinct_on_clause
 %type <tree.NameList> opt_column_list insert_column_list opt_stats_columns query_stats_cols
 %type <tree.OrderBy> sort_clause single_sort_clause opt_sort_clause
@@ -1410,6 +1434,7 @@ func (u *sqlSymUnion) cursorStmt() tree.CursorStmt {
 %type <tree.RoleSpec> role_spec opt_owner_clause
 %type <tree.RoleSpecList> role_spec_list
 %type <tree.Expr> zone_value
+%type <tree.Expr> region_replica_count
 %type <tree.Expr> string_or_placeholder
 %type <tree.Expr> string_or_placeholder_list
 %type <str> region_or_regions
@@ -1593,10 +1618,11 @@ stmt:
 
 // %Help: ALTER
 // %Category: Group
-// %Text: ALTER TABLE, ALTER INDEX, ALTER VIEW, ALTER SEQUENCE, ALTER DATABASE, ALTER USER, ALTER ROLE, ALTER DEFAULT PRIVILEGES, ALTER TENANT
+// %Text: ALTER TABLE, ALTER INDEX, ALTER VIEW, ALTER SEQUENCE, ALTER DATABASE, ALTER USER, ALTER ROLE, ALTER PROFILE, ALTER DEFAULT PRIVILEGES, ALTER TENANT
 alter_stmt:
   alter_ddl_stmt      // help texts in sub-rule
 | alter_role_stmt     // EXTEND WITH HELP: ALTER ROLE
+| alter_profile_stmt  // EXTEND WITH HELP: ALTER PROFILE
 | alter_tenant_csetting_stmt  // EXTEND WITH HELP: ALTER TENANT
 | alter_unsupported_stmt
 | ALTER error         // SHOW HELP: ALTER
@@ -1646,7 +1672,7 @@ alter_ddl_stmt:
 //   ALTER TABLE ... PARTITION BY NOTHING
 //   ALTER TABLE ... CONFIGURE ZONE <zoneconfig>
 //   ALTER TABLE ... SET SCHEMA <newschemaname>
-//   ALTER TABLE ... SET LOCALITY [REGIONAL BY [TABLE IN <region> | ROW] | GLOBAL]
+//   ALTER TABLE ... SET LOCALITY [REGIONAL BY [TABLE IN <region> | ROW [AS <column> | AS ( <expr> )]] | GLOBAL]
 //
 // Column qualifiers:
 //   [CONSTRAINT <constraintname>] {NULL | NOT NULL | UNIQUE | PRIMARY KEY | CHECK (<expr>) | DEFAULT <expr>}
@@ -1752,11 +1778,22 @@ alter_sequence_options_stmt:
 // ALTER DATABASE <name> CONFIGURE ZONE <zone config>
 // ALTER DATABASE <name> OWNER TO <newowner>
 // ALTER DATABASE <name> CONVERT TO SCHEMA WITH PARENT <name>
+// ALTER DATABASE <name> CONVERT TO MULTIREGION PRIMARY REGION <region>
+//   [REGIONS <region> [, ...]] [SURVIVE <failure type>] [TABLE LOCALITY <locality>]
 // ALTER DATABASE <name> ADD REGION [IF NOT EXISTS] <region>
-// ALTER DATABASE <name> DROP REGION [IF EXISTS] <region>
-// ALTER DATABASE <name> PRIMARY REGION <region>
-// ALTER DATABASE <name> SURVIVE <failure type>
+// ALTER DATABASE <name> ADD REGIONS [IF NOT EXISTS] <region> [, ...]
+// ALTER DATABASE <name> DROP REGION [IF EXISTS] <region> [RESTRICT | CASCADE [TO <region>]]
+// ALTER DATABASE <name> PRIMARY REGION <region> [FALLBACK ( <region> [, ...] )]
+// ALTER DATABASE <name> RESET PRIMARY REGION
+// ALTER DATABASE <name> SET SECONDARY REGION <region>
+// ALTER DATABASE <name> DROP SECONDARY REGION [IF EXISTS]
+// ALTER DATABASE <name> RENAME REGION <region> TO <region>
+// ALTER DATABASE <name> SET REGION <region> [NUM_VOTERS { <n> | DEFAULT }] [NUM_REPLICAS { <n> | DEFAULT }]
+// ALTER DATABASE <name> SET DEFAULT TABLE LOCALITY <locality>
+// ALTER DATABASE <name> RESET DEFAULT TABLE LOCALITY
+// ALTER DATABASE <name> SURVIVE <failure type> [WITH [NUM_VOTERS { <n> | DEFAULT }] [NUM_REPLICAS { <n> | DEFAULT }]]
 // ALTER DATABASE <name> PLACEMENT { RESTRICTED | DEFAULT }
+// ALTER DATABASE <name> PLACEMENT RESTRICTED EXCEPT TABLES ( <tablename> [, ...] )
 // ALTER DATABASE <name> SET var { TO | = } { value | DEFAULT }
 // ALTER DATABASE <name> RESET { var | ALL }
 // %SeeAlso: WEBDOCS/alter-database.html
@@ -1773,6 +1810,14 @@ alter_database_stmt:
 | alter_database_set_stmt
 | alter_database_add_super_region
 | alter_database_drop_super_region
+| alter_database_alter_super_region
+| alter_database_set_secondary_region_stmt
+| alter_database_drop_secondary_region
+| alter_database_rename_region
+| alter_database_convert_to_multiregion_stmt
+| alter_database_region_replicas_stmt
+| alter_database_reset_primary_region_stmt
+| alter_database_default_locality_stmt
 // ALTER DATABASE has its error help token here because the ALTER DATABASE
 // prefix is spread over multiple non-terminals.
 | ALTER DATABASE error // SHOW HELP: ALTER DATABASE
@@ -1805,20 +1850,44 @@ alter_database_placement_stmt:
       Placement: $4.dataPlacement(),
     }
   }
+| ALTER DATABASE database_name PLACEMENT RESTRICTED EXCEPT TABLES '(' table_name_list ')'
+  {
+    /* SKIP DOC */
+    $$.val = &tree.AlterDatabasePlacement{
+      Name: tree.Name($3),
+      Placement: tree.DataPlacementRestricted,
+      ExceptTables: $9.tableNames(),
+    }
+  }
 
 alter_database_add_region_stmt:
   ALTER DATABASE database_name ADD REGION region_name
   {
     $$.val = &tree.AlterDatabaseAddRegion{
       Name: tree.Name($3),
-      Region: tree.Name($6),
+      Regions: tree.NameList{tree.Name($6)},
     }
   }
 | ALTER DATABASE database_name ADD REGION IF NOT EXISTS region_name
   {
     $$.val = &tree.AlterDatabaseAddRegion{
       Name: tree.Name($3),
-      Region: tree.Name($9),
+      Regions: tree.NameList{tree.Name($9)},
+      IfNotExists: true,
+    }
+  }
+| ALTER DATABASE database_name ADD REGIONS region_name_list
+  {
+    $$.val = &tree.AlterDatabaseAddRegion{
+      Name: tree.Name($3),
+      Regions: $6.nameList(),
+    }
+  }
+| ALTER DATABASE database_name ADD REGIONS IF NOT EXISTS region_name_list
+  {
+    $$.val = &tree.AlterDatabaseAddRegion{
+      Name: tree.Name($3),
+      Regions: $9.nameList(),
       IfNotExists: true,
     }
   }
@@ -1839,6 +1908,59 @@ alter_database_drop_region_stmt:
       IfExists: true,
     }
   }
+| ALTER DATABASE database_name DROP REGION region_name RESTRICT
+  {
+    $$.val = &tree.AlterDatabaseDropRegion{
+      Name: tree.Name($3),
+      Region: tree.Name($6),
+      DropBehavior: tree.DropRestrict,
+    }
+  }
+| ALTER DATABASE database_name DROP REGION IF EXISTS region_name RESTRICT
+  {
+    $$.val = &tree.AlterDatabaseDropRegion{
+      Name: tree.Name($3),
+      Region: tree.Name($8),
+      IfExists: true,
+      DropBehavior: tree.DropRestrict,
+    }
+  }
+| ALTER DATABASE database_name DROP REGION region_name CASCADE
+  {
+    $$.val = &tree.AlterDatabaseDropRegion{
+      Name: tree.Name($3),
+      Region: tree.Name($6),
+      DropBehavior: tree.DropCascade,
+    }
+  }
+| ALTER DATABASE database_name DROP REGION region_name CASCADE TO region_name
+  {
+    $$.val = &tree.AlterDatabaseDropRegion{
+      Name: tree.Name($3),
+      Region: tree.Name($6),
+      DropBehavior: tree.DropCascade,
+      RehomeTo: tree.Name($9),
+    }
+  }
+| ALTER DATABASE database_name DROP REGION IF EXISTS region_name CASCADE
+  {
+    $$.val = &tree.AlterDatabaseDropRegion{
+      Name: tree.Name($3),
+      Region: tree.Name($8),
+      IfExists: true,
+      DropBehavior: tree.DropCascade,
+    }
+  }
+| ALTER DATABASE database_name DROP REGION IF EXISTS region_name CASCADE TO region_name
+  {
+    $$.val = &tree.AlterDatabaseDropRegion{
+      Name: tree.Name($3),
+      Region: tree.Name($8),
+      IfExists: true,
+      DropBehavior: tree.DropCascade,
+      RehomeTo: tree.Name($11),
+    }
+  }
 
 alter_database_survival_goal_stmt:
   ALTER DATABASE database_name survival_goal_clause
@@ -1848,20 +1970,47 @@ alter_database_survival_goal_stmt:
       SurvivalGoal: $4.survivalGoal(),
     }
   }
+| ALTER DATABASE database_name survival_goal_clause WITH NUM_VOTERS region_replica_count
+  {
+    $$.val = &tree.AlterDatabaseSurvivalGoal{
+      Name: tree.Name($3),
+      SurvivalGoal: $4.survivalGoal(),
+      NumVoters: $7.expr(),
+    }
+  }
+| ALTER DATABASE database_name survival_goal_clause WITH NUM_REPLICAS region_replica_count
+  {
+    $$.val = &tree.AlterDatabaseSurvivalGoal{
+      Name: tree.Name($3),
+      SurvivalGoal: $4.survivalGoal(),
+      NumReplicas: $7.expr(),
+    }
+  }
+| ALTER DATABASE database_name survival_goal_clause WITH NUM_VOTERS region_replica_count NUM_REPLICAS region_replica_count
+  {
+    $$.val = &tree.AlterDatabaseSurvivalGoal{
+      Name: tree.Name($3),
+      SurvivalGoal: $4.survivalGoal(),
+      NumVoters: $7.expr(),
+      NumReplicas: $9.expr(),
+    }
+  }
 
 alter_database_primary_region_stmt:
-  ALTER DATABASE database_name primary_region_clause
+  ALTER DATABASE database_name primary_region_clause opt_fallback_regions_clause
   {
     $$.val = &tree.AlterDatabasePrimaryRegion{
       Name: tree.Name($3),
       PrimaryRegion: tree.Name($4),
+      FallbackRegions: $5.nameList(),
     }
   }
-| ALTER DATABASE database_name SET primary_region_clause
+| ALTER DATABASE database_name SET primary_region_clause opt_fallback_regions_clause
   {
     $$.val = &tree.AlterDatabasePrimaryRegion{
       Name: tree.Name($3),
       PrimaryRegion: tree.Name($5),
+      FallbackRegions: $6.nameList(),
     }
   }
 
@@ -1876,14 +2025,108 @@ alter_database_add_super_region:
   }
 
 alter_database_drop_super_region:
-  ALTER DATABASE database_name DROP SUPER REGION name
+  ALTER DATABASE database_name DROP SUPER REGION name opt_with_override
   {
     $$.val = &tree.AlterDatabaseDropSuperRegion{
       DatabaseName: tree.Name($3),
       SuperRegionName: tree.Name($7),
+      Override: $8.bool(),
     }
   }
 
+alter_database_alter_super_region:
+  ALTER DATABASE database_name ALTER SUPER REGION name VALUES name_list opt_with_override
+  {
+    $$.val = &tree.AlterDatabaseAlterSuperRegion{
+      DatabaseName: tree.Name($3),
+      SuperRegionName: tree.Name($7),
+      Regions: $9.nameList(),
+      Override: $10.bool(),
+    }
+  }
+
+opt_with_override:
+  WITH OVERRIDE
+  {
+    $$.val = true
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
+
+alter_database_set_secondary_region_stmt:
+  ALTER DATABASE database_name SET secondary_region_clause
+  {
+    $$.val = &tree.AlterDatabaseSecondaryRegion{
+      DatabaseName: tree.Name($3),
+      SecondaryRegion: tree.Name($5),
+    }
+  }
+
+alter_database_drop_secondary_region:
+  ALTER DATABASE database_name DROP SECONDARY REGION
+  {
+    $$.val = &tree.AlterDatabaseDropSecondaryRegion{
+      DatabaseName: tree.Name($3),
+      IfExists: false,
+    }
+  }
+| ALTER DATABASE database_name DROP SECONDARY REGION IF EXISTS
+  {
+    $$.val = &tree.AlterDatabaseDropSecondaryRegion{
+      DatabaseName: tree.Name($3),
+      IfExists: true,
+    }
+  }
+
+alter_database_rename_region:
+  ALTER DATABASE database_name RENAME REGION region_name TO region_name
+  {
+    $$.val = &tree.AlterDatabaseRenameRegion{
+      DatabaseName: tree.Name($3),
+      OldRegion: tree.Name($6),
+      NewRegion: tree.Name($8),
+    }
+  }
+
+alter_database_region_replicas_stmt:
+  ALTER DATABASE database_name SET REGION region_name NUM_VOTERS region_replica_count
+  {
+    $$.val = &tree.AlterDatabaseRegionReplicas{
+      DatabaseName: tree.Name($3),
+      Region: tree.Name($6),
+      NumVoters: $8.expr(),
+    }
+  }
+| ALTER DATABASE database_name SET REGION region_name NUM_REPLICAS region_replica_count
+  {
+    $$.val = &tree.AlterDatabaseRegionReplicas{
+      DatabaseName: tree.Name($3),
+      Region: tree.Name($6),
+      NumReplicas: $8.expr(),
+    }
+  }
+| ALTER DATABASE database_name SET REGION region_name NUM_VOTERS region_replica_count NUM_REPLICAS region_replica_count
+  {
+    $$.val = &tree.AlterDatabaseRegionReplicas{
+      DatabaseName: tree.Name($3),
+      Region: tree.Name($6),
+      NumVoters: $8.expr(),
+      NumReplicas: $10.expr(),
+    }
+  }
+
+region_replica_count:
+  ICONST
+  {
+    $$.val = $1.numVal()
+  }
+| DEFAULT
+  {
+    $$.val = tree.DefaultVal{}
+  }
+
 
 // %Help: ALTER RANGE - change the parameters of a range
 // %Category: DDL
@@ -3675,6 +3918,10 @@ comment_stmt:
   {
     $$.val = &tree.CommentOnConstraint{Constraint:tree.Name($4), Table: $6.unresolvedObjectName(), Comment: $8.strPtr()}
   }
+| COMMENT ON REGION name opt_in_database IS comment_text
+  {
+    $$.val = &tree.CommentOnRegion{Region: tree.Name($4), Database: tree.Name($5), Comment: $7.strPtr()}
+  }
 | COMMENT ON EXTENSION error { return unimplementedWithIssueDetail(sqllex, 74777, "comment on extension") }
 | COMMENT ON FUNCTION error { return unimplementedWithIssueDetail(sqllex, 17511, "comment on function") }
 
@@ -3695,9 +3942,10 @@ comment_text:
 // %Text:
 // CREATE DATABASE, CREATE TABLE, CREATE INDEX, CREATE TABLE AS,
 // CREATE USER, CREATE VIEW, CREATE SEQUENCE, CREATE STATISTICS,
-// CREATE ROLE, CREATE TYPE, CREATE EXTENSION
+// CREATE ROLE, CREATE PROFILE, CREATE TYPE, CREATE EXTENSION
 create_stmt:
   create_role_stmt     // EXTEND WITH HELP: CREATE ROLE
+| create_profile_stmt  // EXTEND WITH HELP: CREATE PROFILE
 | create_ddl_stmt      // help texts in sub-rule
 | create_stats_stmt    // EXTEND WITH HELP: CREATE STATISTICS
 | create_schedule_for_backup_stmt   // EXTEND WITH HELP: CREATE SCHEDULE FOR BACKUP
@@ -4069,10 +4317,11 @@ discard_stmt:
 // %Category: Group
 // %Text:
 // DROP DATABASE, DROP INDEX, DROP TABLE, DROP VIEW, DROP SEQUENCE,
-// DROP USER, DROP ROLE, DROP TYPE
+// DROP USER, DROP ROLE, DROP PROFILE, DROP TYPE
 drop_stmt:
   drop_ddl_stmt      // help texts in sub-rule
 | drop_role_stmt     // EXTEND WITH HELP: DROP ROLE
+| drop_profile_stmt  // EXTEND WITH HELP: DROP PROFILE
 | drop_schedule_stmt // EXTEND WITH HELP: DROP SCHEDULES
 | drop_unsupported   {}
 | DROP error         // SHOW HELP: DROP
@@ -4259,19 +4508,44 @@ drop_schema_stmt:
 
 // %Help: DROP ROLE - remove a user
 // %Category: Priv
-// %Text: DROP ROLE [IF EXISTS] <user> [, ...]
+// %Text: DROP ROLE [IF EXISTS] <user> [, ...] [WITH TERMINATE SESSIONS]
 // %SeeAlso: CREATE ROLE, SHOW ROLE
 drop_role_stmt:
-  DROP role_or_group_or_user role_spec_list
+  DROP role_or_group_or_user role_spec_list opt_with_terminate_sessions
   {
-    $$.val = &tree.DropRole{Names: $3.roleSpecList(), IfExists: false, IsRole: $2.bool()}
+    $$.val = &tree.DropRole{Names: $3.roleSpecList(), IfExists: false, IsRole: $2.bool(), TerminateSessions: $4.bool()}
   }
-| DROP role_or_group_or_user IF EXISTS role_spec_list
+| DROP role_or_group_or_user IF EXISTS role_spec_list opt_with_terminate_sessions
   {
-    $$.val = &tree.DropRole{Names: $5.roleSpecList(), IfExists: true, IsRole: $2.bool()}
+    $$.val = &tree.DropRole{Names: $5.roleSpecList(), IfExists: true, IsRole: $2.bool(), TerminateSessions: $6.bool()}
   }
 | DROP role_or_group_or_user error // SHOW HELP: DROP ROLE
 
+// %Help: DROP PROFILE - remove a role profile
+// %Category: Priv
+// %Text: DROP PROFILE [IF EXISTS] <name> [, ...]
+// %SeeAlso: CREATE PROFILE, ALTER PROFILE
+drop_profile_stmt:
+  DROP PROFILE name_list
+  {
+    $$.val = &tree.DropProfile{Names: $3.nameList(), IfExists: false}
+  }
+| DROP PROFILE IF EXISTS name_list
+  {
+    $$.val = &tree.DropProfile{Names: $5.nameList(), IfExists: true}
+  }
+| DROP PROFILE error // SHOW HELP: DROP PROFILE
+
+opt_with_terminate_sessions:
+  WITH TERMINATE SESSIONS
+  {
+    $$.val = true
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
+
 table_name_list:
   table_name
   {
@@ -5317,15 +5591,16 @@ zone_value:
 // %Help: SHOW
 // %Category: Group
 // %Text:
-// SHOW BACKUP, SHOW CLUSTER SETTING, SHOW COLUMNS, SHOW CONSTRAINTS,
-// SHOW CREATE, SHOW CREATE SCHEDULES, SHOW DATABASES, SHOW ENUMS, SHOW HISTOGRAM, SHOW INDEXES, SHOW
-// PARTITIONS, SHOW JOBS, SHOW STATEMENTS, SHOW RANGE, SHOW RANGES, SHOW REGIONS, SHOW SURVIVAL GOAL,
-// SHOW ROLES, SHOW SCHEMAS, SHOW SEQUENCES, SHOW SESSION, SHOW SESSIONS,
+// SHOW AUTHENTICATION CACHE, SHOW BACKUP, SHOW CLUSTER SETTING, SHOW COLUMNS, SHOW CONSTRAINTS,
+// SHOW CREATE, SHOW CREATE SCHEDULES, SHOW DATABASES, SHOW DEFAULT SESSION VARIABLES, SHOW ENUMS, SHOW HBA RULES,
+// SHOW HISTOGRAM, SHOW INDEXES, SHOW PARTITIONS, SHOW JOBS, SHOW STATEMENTS, SHOW RANGE, SHOW RANGES, SHOW REGIONS, SHOW SURVIVAL GOAL,
+// SHOW ROLES, SHOW SCHEMAS, SHOW SEQUENCES, SHOW SESSION, SHOW SESSIONS, SHOW SETTING PROVENANCE,
 // SHOW STATISTICS, SHOW SYNTAX, SHOW TABLES, SHOW TRACE, SHOW TRANSACTION,
 // SHOW TRANSACTIONS, SHOW TRANSFER, SHOW TYPES, SHOW USERS, SHOW LAST QUERY STATISTICS,
 // SHOW SCHEDULES, SHOW LOCALITY, SHOW ZONE CONFIGURATION, SHOW FULL TABLE SCANS
 show_stmt:
-  show_backup_stmt           // EXTEND WITH HELP: SHOW BACKUP
+  show_authentication_cache_stmt // EXTEND WITH HELP: SHOW AUTHENTICATION CACHE
+| show_backup_stmt           // EXTEND WITH HELP: SHOW BACKUP
 | show_columns_stmt          // EXTEND WITH HELP: SHOW COLUMNS
 | show_constraints_stmt      // EXTEND WITH HELP: SHOW CONSTRAINTS
 | show_create_stmt           // EXTEND WITH HELP: SHOW CREATE
@@ -5336,6 +5611,7 @@ show_stmt:
 | show_types_stmt            // EXTEND WITH HELP: SHOW TYPES
 | show_fingerprints_stmt
 | show_grants_stmt           // EXTEND WITH HELP: SHOW GRANTS
+| show_hba_rules_stmt        // EXTEND WITH HELP: SHOW HBA RULES
 | show_histogram_stmt        // EXTEND WITH HELP: SHOW HISTOGRAM
 | show_indexes_stmt          // EXTEND WITH HELP: SHOW INDEXES
 | show_partitions_stmt       // EXTEND WITH HELP: SHOW PARTITIONS
@@ -5353,6 +5629,7 @@ show_stmt:
 | show_sequences_stmt        // EXTEND WITH HELP: SHOW SEQUENCES
 | show_session_stmt          // EXTEND WITH HELP: SHOW SESSION
 | show_sessions_stmt         // EXTEND WITH HELP: SHOW SESSIONS
+| show_setting_provenance_stmt // EXTEND WITH HELP: SHOW SETTING PROVENANCE
 | show_stats_stmt            // EXTEND WITH HELP: SHOW STATISTICS
 | show_syntax_stmt           // EXTEND WITH HELP: SHOW SYNTAX
 | show_tables_stmt           // EXTEND WITH HELP: SHOW TABLES
@@ -5366,6 +5643,7 @@ show_stmt:
 | show_last_query_stats_stmt
 | show_full_scans_stmt
 | show_default_privileges_stmt // EXTEND WITH HELP: SHOW DEFAULT PRIVILEGES
+| show_default_session_variables_stmt // EXTEND WITH HELP: SHOW DEFAULT SESSION VARIABLES
 | show_completions_stmt
 
 // %Help: CLOSE - close SQL cursor
@@ -5620,6 +5898,24 @@ show_session_stmt:
 | SHOW SESSION session_var { $$.val = &tree.ShowVar{Name: $3} }
 | SHOW SESSION error // SHOW HELP: SHOW SESSION
 
+// %Help: SHOW SETTING PROVENANCE - show where the value of a session variable comes from
+// %Category: Cfg
+// %Text:
+// SHOW SETTING PROVENANCE <var>
+//
+// The sources which provide a value for the session variable are listed
+// in order of precedence: SET statements of the session, the options of
+// the role, the authorization webhook, the parameters of the client, the
+// default settings of the roles and the default value. The value of the
+// variable comes from the first of them.
+// %SeeAlso: SHOW SESSION, SHOW DEFAULT SESSION VARIABLES
+show_setting_provenance_stmt:
+  SHOW SETTING PROVENANCE session_var
+  {
+    $$.val = &tree.ShowSettingProvenance{Name: $4}
+  }
+| SHOW SETTING error // SHOW HELP: SHOW SETTING PROVENANCE
+
 session_var:
   IDENT
 | IDENT session_var_parts
@@ -5638,6 +5934,10 @@ session_var:
 | SESSION_USER
 | LC_COLLATE
 | LC_CTYPE
+// The role options which set session variables are keywords too.
+| IDLE_SESSION_TIMEOUT
+| STATEMENT_TIMEOUT
+| TRANSACTION_TIMEOUT
 // TIME ZONE is special: it is two tokens, but is really the identifier "TIME ZONE".
 | TIME ZONE { $$ = "timezone" }
 | TIME error // SHOW HELP: SHOW SESSION
@@ -5892,6 +6192,22 @@ show_default_privileges_stmt:
   }
 | SHOW DEFAULT PRIVILEGES error // SHOW HELP: SHOW DEFAULT PRIVILEGES
 
+// %Help: SHOW DEFAULT SESSION VARIABLES - list the default session variables of a role
+// %Category: Priv
+// %Text:
+// SHOW DEFAULT SESSION VARIABLES FOR ROLE <name> [IN DATABASE <database_name>]
+//
+// The defaults which apply when the role logs into the database are
+// listed, along with the database and the role they were set for. The
+// current database is used if none is specified.
+// %SeeAlso: ALTER ROLE, SHOW SESSION
+show_default_session_variables_stmt:
+  SHOW DEFAULT SESSION VARIABLES FOR ROLE role_spec opt_in_database
+  {
+    $$.val = &tree.ShowDefaultSessionVariables{Role: $7.roleSpec(), DatabaseName: tree.Name($8)}
+  }
+| SHOW DEFAULT SESSION error // SHOW HELP: SHOW DEFAULT SESSION VARIABLES
+
 // %Help: SHOW ENUMS - list enums
 // %Category: Misc
 // %Text: SHOW ENUMS
@@ -6317,6 +6633,45 @@ query_stats_cols:
     $$.val = tree.ShowLastQueryStatisticsDefaultColumns
   }
 
+// %Help: SHOW AUTHENTICATION CACHE - display the authentication cache summary
+// %Category: Misc
+// %Text: SHOW AUTHENTICATION CACHE
+// %SeeAlso: SHOW SESSIONS
+show_authentication_cache_stmt:
+  SHOW AUTHENTICATION CACHE
+  {
+    $$.val = &tree.ShowAuthenticationCache{}
+  }
+| SHOW AUTHENTICATION error // SHOW HELP: SHOW AUTHENTICATION CACHE
+
+// %Help: SHOW HBA RULES - display or validate host-based authentication rules
+// %Category: Misc
+// %Text:
+// SHOW HBA RULES [FROM <config>] [WITH <option> [= <value>] [, ...]]
+//
+// Without FROM, the rules of the active configuration of the node are
+// displayed, along with the number of connections they matched and the
+// outcome of their authentication. With FROM, the proposed configuration
+// is validated and its rules are displayed without applying it.
+//
+// Options:
+//    username = <username>   report which rule matches a connection of this user
+//    address  = <address>    ... from this IP address
+//    database = <database>   ... to this database
+//    type     = <conn type>  ... of this type (hostssl, hostnossl or local)
+//
+// %SeeAlso: SHOW CLUSTER SETTING
+show_hba_rules_stmt:
+  SHOW HBA RULES opt_with_options
+  {
+    $$.val = &tree.ShowHBARules{Options: $4.kvOptions()}
+  }
+| SHOW HBA RULES FROM string_or_placeholder opt_with_options
+  {
+    $$.val = &tree.ShowHBARules{Config: $5.expr(), Options: $6.kvOptions()}
+  }
+| SHOW HBA error // SHOW HELP: SHOW HBA RULES
+
 // %Help: SHOW SAVEPOINT - display current savepoint properties
 // %Category: Cfg
 // %Text: SHOW SAVEPOINT STATUS
@@ -6367,6 +6722,7 @@ show_transfer_stmt:
 // %Category: DDL
 // %Text:
 // SHOW CREATE [ TABLE | SEQUENCE | VIEW | DATABASE ] <object_name>
+// SHOW CREATE ALL ROLES
 // SHOW CREATE ALL SCHEMAS
 // SHOW CREATE ALL TABLES
 // SHOW CREATE ALL TYPES
@@ -6396,6 +6752,10 @@ show_create_stmt:
     /* SKIP DOC */
     $$.val = &tree.ShowCreate{Mode: tree.ShowCreateModeDatabase, Name: $4.unresolvedObjectName()}
 	}
+| SHOW CREATE ALL ROLES
+  {
+    $$.val = &tree.ShowCreateAllRoles{}
+  }
 | SHOW CREATE ALL SCHEMAS
   {
     $$.val = &tree.ShowCreateAllSchemas{}
@@ -6575,11 +6935,11 @@ show_survival_goal_stmt:
 // %Help: SHOW REGIONS - shows regions
 // %Category: DDL
 // %Text:
-// SHOW REGIONS
+// SHOW REGIONS [WITH DETAILS]
 // SHOW REGIONS FROM ALL DATABASES
 // SHOW REGIONS FROM CLUSTER
-// SHOW REGIONS FROM DATABASE
-// SHOW REGIONS FROM DATABASE <database>
+// SHOW REGIONS FROM DATABASE [WITH COMMENT]
+// SHOW REGIONS FROM DATABASE <database> [WITH COMMENT]
 show_regions_stmt:
   SHOW REGIONS FROM CLUSTER
   {
@@ -6587,10 +6947,11 @@ show_regions_stmt:
       ShowRegionsFrom: tree.ShowRegionsFromCluster,
     }
   }
-| SHOW REGIONS FROM DATABASE
+| SHOW REGIONS FROM DATABASE with_comment
   {
     $$.val = &tree.ShowRegions{
       ShowRegionsFrom: tree.ShowRegionsFromDatabase,
+      WithComment: $5.bool(),
     }
   }
 | SHOW REGIONS FROM ALL DATABASES
@@ -6599,11 +6960,12 @@ show_regions_stmt:
       ShowRegionsFrom: tree.ShowRegionsFromAllDatabases,
     }
   }
-| SHOW REGIONS FROM DATABASE database_name
+| SHOW REGIONS FROM DATABASE database_name with_comment
   {
     $$.val = &tree.ShowRegions{
       ShowRegionsFrom: tree.ShowRegionsFromDatabase,
       DatabaseName: tree.Name($5),
+      WithComment: $6.bool(),
     }
   }
 | SHOW REGIONS
@@ -6612,6 +6974,13 @@ show_regions_stmt:
       ShowRegionsFrom: tree.ShowRegionsFromDefault,
     }
   }
+| SHOW REGIONS WITH DETAILS
+  {
+    $$.val = &tree.ShowRegions{
+      ShowRegionsFrom: tree.ShowRegionsFromDefault,
+      WithDetails: true,
+    }
+  }
 | SHOW REGIONS error // SHOW HELP: SHOW REGIONS
 
 show_locality_stmt:
@@ -8157,12 +8526,63 @@ create_role_stmt:
   }
 | CREATE role_or_group_or_user error // SHOW HELP: CREATE ROLE
 
+// %Help: CREATE PROFILE - define a new role profile
+// %Category: Priv
+// %Text: CREATE PROFILE [IF NOT EXISTS] <name> [ [WITH] <OPTIONS...> ]
+//
+// A role profile bundles role options which are shared by all the roles
+// assigned to it with ALTER ROLE <name> PROFILE <profile>.
+//
+// Options:
+//   CONNECTION LIMIT <limit>
+//   MFA
+//   READONLY
+//   ALLOWED DATABASES (<database> [, ...])
+//   LOGIN BETWEEN <start> AND <end> [TIMEZONE <zone>] [DAYS (<day> [, ...])]
+//   IDLE_SESSION_TIMEOUT <duration>
+//   STATEMENT_TIMEOUT <duration>
+//   TRANSACTION_TIMEOUT <duration>
+//   QUALITY_OF_SERVICE <level>
+//   MAX_RESULT_ROWS <rows>
+//   MAX_RESULT_BYTES <size>
+//   PASSWORD_HISTORY <count>
+// %SeeAlso: ALTER PROFILE, DROP PROFILE, ALTER ROLE
+create_profile_stmt:
+  CREATE PROFILE name opt_role_options
+  {
+    $$.val = &tree.CreateProfile{Name: tree.Name($3), KVOptions: $4.kvOptions()}
+  }
+| CREATE PROFILE IF NOT EXISTS name opt_role_options
+  {
+    $$.val = &tree.CreateProfile{Name: tree.Name($6), IfNotExists: true, KVOptions: $7.kvOptions()}
+  }
+| CREATE PROFILE error // SHOW HELP: CREATE PROFILE
+
+// %Help: ALTER PROFILE - alter a role profile
+// %Category: Priv
+// %Text: ALTER PROFILE [IF EXISTS] <name> [WITH] <options...>
+//
+// Options are removed from the profile with NOMFA, NOREADONLY or by
+// setting them to NULL.
+// %SeeAlso: CREATE PROFILE, DROP PROFILE, ALTER ROLE
+alter_profile_stmt:
+  ALTER PROFILE name opt_with role_options
+  {
+    $$.val = &tree.AlterProfile{Name: tree.Name($3), KVOptions: $5.kvOptions()}
+  }
+| ALTER PROFILE IF EXISTS name opt_with role_options
+  {
+    $$.val = &tree.AlterProfile{Name: tree.Name($5), IfExists: true, KVOptions: $7.kvOptions()}
+  }
+| ALTER PROFILE error // SHOW HELP: ALTER PROFILE
+
 // %Help: ALTER ROLE - alter a role
 // %Category: Priv
 // %Text:
 // ALTER ROLE <name> [WITH] <options...>
-// ALTER ROLE { name | ALL } [ IN DATABASE database_name ] SET var { TO | = } { value | DEFAULT }
-// ALTER ROLE { name | ALL } [ IN DATABASE database_name ] RESET { var | ALL }
+// ALTER ROLE { name | ALL } [ IN DATABASE database_name ] [ FOR APPLICATION 'pattern' ] [ FROM NETWORK 'cidr' ] SET var { TO | = } { value | DEFAULT } [ LOCKED ]
+// ALTER ROLE { name | ALL } [ IN DATABASE database_name ] [ FOR APPLICATION 'pattern' ] [ FROM NETWORK 'cidr' ] RESET { var | ALL }
+// ALTER ROLE <name> RENAME TO <newname>
 // %SeeAlso: CREATE ROLE, DROP ROLE, SHOW ROLES
 alter_role_stmt:
   ALTER role_or_group_or_user role_spec opt_role_options
@@ -8173,21 +8593,25 @@ alter_role_stmt:
 {
   $$.val = &tree.AlterRole{Name: $5.roleSpec(), IfExists: true, KVOptions: $6.kvOptions(), IsRole: $2.bool()}
 }
-| ALTER role_or_group_or_user role_spec opt_in_database set_or_reset_clause
+| ALTER role_or_group_or_user role_spec opt_in_database opt_for_application opt_from_network set_or_reset_clause opt_locked
   {
-    $$.val = &tree.AlterRoleSet{RoleName: $3.roleSpec(), DatabaseName: tree.Name($4), IsRole: $2.bool(), SetOrReset: $5.setVar()}
+    $$.val = &tree.AlterRoleSet{RoleName: $3.roleSpec(), DatabaseName: tree.Name($4), ApplicationName: $5, SourceNetwork: $6, IsRole: $2.bool(), SetOrReset: $7.setVar(), Locked: $8.bool()}
   }
-| ALTER role_or_group_or_user IF EXISTS role_spec opt_in_database set_or_reset_clause
+| ALTER role_or_group_or_user IF EXISTS role_spec opt_in_database opt_for_application opt_from_network set_or_reset_clause opt_locked
   {
-    $$.val = &tree.AlterRoleSet{RoleName: $5.roleSpec(), IfExists: true, DatabaseName: tree.Name($6), IsRole: $2.bool(), SetOrReset: $7.setVar()}
+    $$.val = &tree.AlterRoleSet{RoleName: $5.roleSpec(), IfExists: true, DatabaseName: tree.Name($6), ApplicationName: $7, SourceNetwork: $8, IsRole: $2.bool(), SetOrReset: $9.setVar(), Locked: $10.bool()}
   }
-| ALTER ROLE_ALL ALL opt_in_database set_or_reset_clause
+| ALTER ROLE_ALL ALL opt_in_database opt_for_application opt_from_network set_or_reset_clause opt_locked
   {
-    $$.val = &tree.AlterRoleSet{AllRoles: true, DatabaseName: tree.Name($4), IsRole: true, SetOrReset: $5.setVar()}
+    $$.val = &tree.AlterRoleSet{AllRoles: true, DatabaseName: tree.Name($4), ApplicationName: $5, SourceNetwork: $6, IsRole: true, SetOrReset: $7.setVar(), Locked: $8.bool()}
   }
-| ALTER USER_ALL ALL opt_in_database set_or_reset_clause
+| ALTER USER_ALL ALL opt_in_database opt_for_application opt_from_network set_or_reset_clause opt_locked
   {
-    $$.val = &tree.AlterRoleSet{AllRoles: true, DatabaseName: tree.Name($4), IsRole: false, SetOrReset: $5.setVar()}
+    $$.val = &tree.AlterRoleSet{AllRoles: true, DatabaseName: tree.Name($4), ApplicationName: $5, SourceNetwork: $6, IsRole: false, SetOrReset: $7.setVar(), Locked: $8.bool()}
+  }
+| ALTER role_or_group_or_user role_spec RENAME TO role_spec
+  {
+    $$.val = &tree.RenameRole{Name: $3.roleSpec(), NewName: $6.roleSpec(), IsRole: $2.bool()}
   }
 | ALTER role_or_group_or_user error // SHOW HELP: ALTER ROLE
 
@@ -8201,6 +8625,36 @@ opt_in_database:
     $$ = ""
   }
 
+opt_for_application:
+  FOR APPLICATION SCONST
+  {
+    $$ = $3
+  }
+| /* EMPTY */
+  {
+    $$ = ""
+  }
+
+opt_from_network:
+  FROM NETWORK SCONST
+  {
+    $$ = $3
+  }
+| /* EMPTY */
+  {
+    $$ = ""
+  }
+
+opt_locked:
+  LOCKED
+  {
+    $$.val = true
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
+
 set_or_reset_clause:
   SET set_rest
   {
@@ -8409,8 +8863,97 @@ role_option:
   {
     $$.val = tree.KVOption{Key: tree.Name($1), Value: nil}
   }
+| PASSWORD MUST CHANGE
+  {
+    $$.val = tree.KVOption{Key: tree.Name("password must change"), Value: nil}
+  }
+| MFA
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: nil}
+  }
+| NOMFA
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: nil}
+  }
+| READONLY
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: nil}
+  }
+| NOREADONLY
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: nil}
+  }
+| COST ICONST
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: $2.numVal()}
+  }
+| TERMINATE SESSIONS
+  {
+    $$.val = tree.KVOption{Key: tree.Name("terminate sessions"), Value: nil}
+  }
 | password_clause
 | valid_until_clause
+| connection_limit_clause
+| subject_clause
+| allowed_databases_clause
+| login_window_clause
+| profile_clause
+| IDLE_SESSION_TIMEOUT string_or_placeholder
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: $2.expr()}
+  }
+| IDLE_SESSION_TIMEOUT NULL
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: tree.DNull}
+  }
+| PASSWORD_HISTORY signed_iconst
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: $2.expr()}
+  }
+| PASSWORD_HISTORY NULL
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: tree.DNull}
+  }
+| STATEMENT_TIMEOUT string_or_placeholder
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: $2.expr()}
+  }
+| STATEMENT_TIMEOUT NULL
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: tree.DNull}
+  }
+| TRANSACTION_TIMEOUT string_or_placeholder
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: $2.expr()}
+  }
+| TRANSACTION_TIMEOUT NULL
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: tree.DNull}
+  }
+| QUALITY_OF_SERVICE string_or_placeholder
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: $2.expr()}
+  }
+| QUALITY_OF_SERVICE NULL
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: tree.DNull}
+  }
+| MAX_RESULT_ROWS signed_iconst
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: $2.expr()}
+  }
+| MAX_RESULT_ROWS NULL
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: tree.DNull}
+  }
+| MAX_RESULT_BYTES string_or_placeholder
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: $2.expr()}
+  }
+| MAX_RESULT_BYTES NULL
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: tree.DNull}
+  }
 
 role_options:
   role_option
@@ -8442,6 +8985,76 @@ valid_until_clause:
     $$.val = tree.KVOption{Key: tree.Name("valid until"), Value: tree.DNull}
   }
 
+// The profile of a role is a name, which is represented as a string.
+profile_clause:
+  PROFILE name
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: tree.NewStrVal($2)}
+  }
+| PROFILE NULL
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: tree.DNull}
+  }
+
+connection_limit_clause:
+  CONNECTION LIMIT signed_iconst
+  {
+    $$.val = tree.KVOption{Key: tree.Name("connection limit"), Value: $3.expr()}
+  }
+
+subject_clause:
+  SUBJECT string_or_placeholder
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: $2.expr()}
+  }
+| SUBJECT NULL
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: tree.DNull}
+  }
+
+allowed_databases_clause:
+  ALLOWED DATABASES '(' string_or_placeholder_list ')'
+  {
+    $$.val = tree.KVOption{Key: tree.Name("allowed databases"), Value: &tree.Tuple{Exprs: $4.exprs()}}
+  }
+| ALLOWED DATABASES NULL
+  {
+    $$.val = tree.KVOption{Key: tree.Name("allowed databases"), Value: tree.DNull}
+  }
+
+// The login window is represented as a tuple of its start and end times,
+// its time zone or NULL, followed by its days of the week if any.
+login_window_clause:
+  LOGIN BETWEEN string_or_placeholder AND string_or_placeholder opt_login_window_time_zone opt_login_window_days
+  {
+    exprs := tree.Exprs{$3.expr(), $5.expr(), $6.expr()}
+    $$.val = tree.KVOption{Key: tree.Name("login window"), Value: &tree.Tuple{Exprs: append(exprs, $7.exprs()...)}}
+  }
+| LOGIN WINDOW NULL
+  {
+    $$.val = tree.KVOption{Key: tree.Name("login window"), Value: tree.DNull}
+  }
+
+opt_login_window_time_zone:
+  TIMEZONE string_or_placeholder
+  {
+    $$.val = $2.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.DNull
+  }
+
+opt_login_window_days:
+  DAYS '(' string_or_placeholder_list ')'
+  {
+    $$.val = $3.exprs()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Exprs(nil)
+  }
+
 opt_view_recursive:
   /* EMPTY */ { /* no error */ }
 | RECURSIVE { return unimplemented(sqllex, "create recursive view") }
@@ -8710,6 +9323,47 @@ alter_database_to_schema_stmt:
     $$.val = &tree.ReparentDatabase{Name: tree.Name($3), Parent: tree.Name($9)}
   }
 
+alter_database_reset_primary_region_stmt:
+  ALTER DATABASE database_name RESET PRIMARY REGION
+  {
+    $$.val = &tree.AlterDatabaseResetPrimaryRegion{Name: tree.Name($3)}
+  }
+
+alter_database_default_locality_stmt:
+  ALTER DATABASE database_name SET DEFAULT TABLE locality
+  {
+    $$.val = &tree.AlterDatabaseDefaultLocality{
+      Name: tree.Name($3),
+      Locality: $7.locality(),
+    }
+  }
+| ALTER DATABASE database_name RESET DEFAULT TABLE LOCALITY
+  {
+    $$.val = &tree.AlterDatabaseDefaultLocality{Name: tree.Name($3)}
+  }
+
+alter_database_convert_to_multiregion_stmt:
+  ALTER DATABASE database_name CONVERT TO MULTIREGION primary_region_clause opt_regions_list opt_survival_goal_clause opt_table_locality_clause
+  {
+    $$.val = &tree.AlterDatabaseToMultiRegion{
+      Name: tree.Name($3),
+      PrimaryRegion: tree.Name($7),
+      Regions: $8.nameList(),
+      SurvivalGoal: $9.survivalGoal(),
+      TableLocality: $10.locality(),
+    }
+  }
+
+opt_table_locality_clause:
+  TABLE locality
+  {
+    $$.val = $2.locality()
+  }
+| /* EMPTY */
+  {
+    $$.val = (*tree.Locality)(nil)
+  }
+
 alter_rename_database_stmt:
   ALTER DATABASE database_name RENAME TO database_name
   {
@@ -8820,6 +9474,13 @@ locality:
       RegionalByRowColumn: tree.Name($6),
     }
   }
+| LOCALITY REGIONAL BY ROW AS '(' a_expr ')'
+  {
+    $$.val = &tree.Locality{
+      LocalityLevel: tree.LocalityLevelRow,
+      RegionalByRowExpr: $7.expr(),
+    }
+  }
 
 alter_table_owner_stmt:
   ALTER TABLE relation_expr OWNER TO role_spec
@@ -9015,6 +9676,8 @@ alter_rename_index_stmt:
 //
 // Commands:
 //   ALTER DEFAULT PRIVILEGES [ FOR { ROLE | USER } target_roles... ] [ IN SCHEMA schema_name...] abbreviated_grant_or_revoke
+//   ALTER DEFAULT PRIVILEGES [ FOR { ROLE | USER } target_roles... ] IN DATABASE database_name abbreviated_grant_or_revoke
+//   ALTER DEFAULT PRIVILEGES FOR ALL ROLES [ IN SCHEMA schema_name... | IN DATABASE database_name ] abbreviated_grant_or_revoke
 alter_default_privileges_stmt:
  ALTER DEFAULT PRIVILEGES opt_for_roles opt_in_schemas abbreviated_grant_stmt
  {
@@ -9052,6 +9715,46 @@ alter_default_privileges_stmt:
      IsGrant: false,
   }
  }
+| ALTER DEFAULT PRIVILEGES opt_for_roles IN DATABASE database_name abbreviated_grant_stmt
+ {
+   database := tree.Name($7)
+   $$.val = &tree.AlterDefaultPrivileges{
+     Roles: $4.roleSpecList(),
+     Database: &database,
+     Grant: $8.abbreviatedGrant(),
+     IsGrant: true,
+   }
+ }
+| ALTER DEFAULT PRIVILEGES opt_for_roles IN DATABASE database_name abbreviated_revoke_stmt
+ {
+   database := tree.Name($7)
+   $$.val = &tree.AlterDefaultPrivileges{
+     Roles: $4.roleSpecList(),
+     Database: &database,
+     Revoke: $8.abbreviatedRevoke(),
+     IsGrant: false,
+   }
+ }
+| ALTER DEFAULT PRIVILEGES FOR ALL ROLES IN DATABASE database_name abbreviated_grant_stmt
+ {
+   database := tree.Name($9)
+   $$.val = &tree.AlterDefaultPrivileges{
+     ForAllRoles: true,
+     Database: &database,
+     Grant: $10.abbreviatedGrant(),
+     IsGrant: true,
+   }
+ }
+| ALTER DEFAULT PRIVILEGES FOR ALL ROLES IN DATABASE database_name abbreviated_revoke_stmt
+ {
+   database := tree.Name($9)
+   $$.val = &tree.AlterDefaultPrivileges{
+     ForAllRoles: true,
+     Database: &database,
+     Revoke: $10.abbreviatedRevoke(),
+     IsGrant: false,
+   }
+ }
 | ALTER DEFAULT PRIVILEGES error // SHOW HELP: ALTER DEFAULT PRIVILEGES
 
 abbreviated_grant_stmt:
@@ -9437,7 +10140,7 @@ transaction_deferrable_mode:
 // %Text: CREATE DATABASE [IF NOT EXISTS] <name>
 // %SeeAlso: WEBDOCS/create-database.html
 create_database_stmt:
-  CREATE DATABASE database_name opt_with opt_template_clause opt_encoding_clause opt_lc_collate_clause opt_lc_ctype_clause opt_connection_limit opt_primary_region_clause opt_regions_list opt_survival_goal_clause opt_placement_clause opt_owner_clause
+  CREATE DATABASE database_name opt_with opt_template_clause opt_encoding_clause opt_lc_collate_clause opt_lc_ctype_clause opt_connection_limit opt_primary_region_clause opt_regions_list opt_survival_goal_clause opt_placement_clause opt_owner_clause opt_secondary_region_clause
   {
     $$.val = &tree.CreateDatabase{
       Name: tree.Name($3),
@@ -9451,9 +10154,10 @@ create_database_stmt:
       SurvivalGoal: $12.survivalGoal(),
       Placement: $13.dataPlacement(),
       Owner: $14.roleSpec(),
+      SecondaryRegion: tree.Name($15),
     }
   }
-| CREATE DATABASE IF NOT EXISTS database_name opt_with opt_template_clause opt_encoding_clause opt_lc_collate_clause opt_lc_ctype_clause opt_connection_limit opt_primary_region_clause opt_regions_list opt_survival_goal_clause opt_placement_clause
+| CREATE DATABASE IF NOT EXISTS database_name opt_with opt_template_clause opt_encoding_clause opt_lc_collate_clause opt_lc_ctype_clause opt_connection_limit opt_primary_region_clause opt_regions_list opt_survival_goal_clause opt_placement_clause opt_secondary_region_clause
   {
     $$.val = &tree.CreateDatabase{
       IfNotExists: true,
@@ -9467,6 +10171,7 @@ create_database_stmt:
       Regions: $14.nameList(),
       SurvivalGoal: $15.survivalGoal(),
       Placement: $16.dataPlacement(),
+      SecondaryRegion: tree.Name($17),
     }
   }
 | CREATE DATABASE error // SHOW HELP: CREATE DATABASE
@@ -9483,6 +10188,18 @@ primary_region_clause:
     $$ = $4
   }
 
+opt_secondary_region_clause:
+  secondary_region_clause
+| /* EMPTY */
+  {
+    $$ = ""
+  }
+
+secondary_region_clause:
+  SECONDARY REGION opt_equal region_name {
+    $$ = $4
+  }
+
 opt_placement_clause:
   placement_clause
 | /* EMPTY */
@@ -9512,6 +10229,16 @@ opt_regions_list:
     $$.val = tree.NameList(nil)
   }
 
+opt_fallback_regions_clause:
+  FALLBACK '(' region_name_list ')'
+  {
+    $$.val = $3.nameList()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.NameList(nil)
+  }
+
 region_or_regions:
   REGION
   {
@@ -13952,11 +14679,14 @@ unreserved_keyword:
 | ADMIN
 | AFTER
 | AGGREGATE
+| ALLOWED
 | ALTER
 | ALWAYS
+| APPLICATION
 | ASENSITIVE
 | AT
 | ATTRIBUTE
+| AUTHENTICATION
 | AUTOMATIC
 | AVAILABILITY
 | BACKUP
@@ -13972,6 +14702,7 @@ unreserved_keyword:
 | CANCEL
 | CANCELQUERY
 | CASCADE
+| CHANGE
 | CHANGEFEED
 | CLOSE
 | CLUSTER
@@ -13994,6 +14725,7 @@ unreserved_keyword:
 | CONVERSION
 | CONVERT
 | COPY
+| COST
 | COVERING
 | CREATEDB
 | CREATELOGIN
@@ -14007,6 +14739,7 @@ unreserved_keyword:
 | DATABASE
 | DATABASES
 | DAY
+| DAYS
 | DEALLOCATE
 | DEBUG_PAUSE_ON
 | DECLARE
@@ -14016,6 +14749,7 @@ unreserved_keyword:
 | DELIMITER
 | DESTINATION
 | DETACHED
+| DETAILS
 | DISCARD
 | DOMAIN
 | DOUBLE
@@ -14040,6 +14774,7 @@ unreserved_keyword:
 | EXPORT
 | EXTENSION
 | FAILURE
+| FALLBACK
 | FILES
 | FILTER
 | FIRST
@@ -14063,11 +14798,13 @@ unreserved_keyword:
 | GRANTS
 | GROUPS
 | HASH
+| HBA
 | HIGH
 | HISTOGRAM
 | HOLD
 | HOUR
 | IDENTITY
+| IDLE_SESSION_TIMEOUT
 | IMMEDIATE
 | IMPORT
 | INCLUDE
@@ -14111,8 +14848,11 @@ unreserved_keyword:
 | MATCH
 | MATERIALIZED
 | MAXVALUE
+| MAX_RESULT_BYTES
+| MAX_RESULT_ROWS
 | MERGE
 | METHOD
+| MFA
 | MINUTE
 | MINVALUE
 | MODIFYCLUSTERSETTING
@@ -14130,8 +14870,11 @@ unreserved_keyword:
 | MULTIPOLYGONZM
 | MONTH
 | MOVE
+| MULTIREGION
+| MUST
 | NAMES
 | NAN
+| NETWORK
 | NEVER
 | NEW_DB_NAME
 | NEW_KMS
@@ -14148,14 +14891,18 @@ unreserved_keyword:
 | NOCONTROLCHANGEFEED
 | NOCONTROLJOB
 | NOLOGIN
+| NOMFA
 | NOMODIFYCLUSTERSETTING
 | NONVOTERS
+| NOREADONLY
 | NOSQLLOGIN
 | NOVIEWACTIVITY
 | NOVIEWACTIVITYREDACTED
 | NOVIEWCLUSTERSETTING
 | NOWAIT
 | NULLS
+| NUM_REPLICAS
+| NUM_VOTERS
 | IGNORE_FOREIGN_KEYS
 | INSENSITIVE
 | OF
@@ -14169,6 +14916,7 @@ unreserved_keyword:
 | ORDINALITY
 | OTHERS
 | OVER
+| OVERRIDE
 | OWNED
 | OWNER
 | PARENT
@@ -14176,6 +14924,7 @@ unreserved_keyword:
 | PARTITION
 | PARTITIONS
 | PASSWORD
+| PASSWORD_HISTORY
 | PAUSE
 | PAUSED
 | PHYSICAL
@@ -14194,13 +14943,17 @@ unreserved_keyword:
 | PRIOR
 | PRIORITY
 | PRIVILEGES
+| PROFILE
+| PROVENANCE
 | PUBLIC
 | PUBLICATION
+| QUALITY_OF_SERVICE
 | QUERIES
 | QUERY
 | RANGE
 | RANGES
 | READ
+| READONLY
 | REASON
 | REASSIGN
 | RECURRING
@@ -14233,6 +14986,7 @@ unreserved_keyword:
 | ROUTINES
 | ROWS
 | RULE
+| RULES
 | RUNNING
 | SCHEDULE
 | SCHEDULES
@@ -14248,6 +15002,7 @@ unreserved_keyword:
 | SCRUB
 | SEARCH
 | SECOND
+| SECONDARY
 | SERIALIZABLE
 | SEQUENCE
 | SEQUENCES
@@ -14272,6 +15027,7 @@ unreserved_keyword:
 | START
 | STATE
 | STATEMENTS
+| STATEMENT_TIMEOUT
 | STATISTICS
 | STDIN
 | STORAGE
@@ -14280,6 +15036,7 @@ unreserved_keyword:
 | STORING
 | STREAM
 | STRICT
+| SUBJECT
 | SUBSCRIPTION
 | SUPER
 | SURVIVE
@@ -14292,12 +15049,15 @@ unreserved_keyword:
 | TEMPLATE
 | TEMPORARY
 | TENANT
+| TERMINATE
 | TESTING_RELOCATE
 | TEXT
 | TIES
+| TIMEZONE
 | TRACE
 | TRANSACTION
 | TRANSACTIONS
+| TRANSACTION_TIMEOUT
 | TRANSFER
 | TRIGGER
 | TRUNCATE
@@ -14319,6 +15079,7 @@ unreserved_keyword:
 | VALID
 | VALIDATE
 | VALUE
+| VARIABLES
 | VARYING
 | VIEW
 | VIEWACTIVITY
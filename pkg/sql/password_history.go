@@ -0,0 +1,144 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/errors"
+)
+
+// passwordHistoryCount is the number of previous passwords of a user
+// which cannot be reused.
+var passwordHistoryCount = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"server.user_login.password_history_count",
+	"the number of most recent passwords of a user, including the current one, "+
+		"which cannot be reused when the password is changed (0 disables the check)",
+	0,
+	settings.NonNegativeInt,
+)
+
+// passwordHistoryOption is the option under which the hashes of the
+// previous passwords of a user are stored in system.role_options, most
+// recent first and separated by newlines. It cannot be set with ALTER
+// ROLE, and it is hidden from SHOW ROLES.
+const passwordHistoryOption = "PASSWORD HISTORY"
+
+// checkAndRecordPasswordHistory must be called before the password of a
+// user is replaced. It returns an error if the new password is one of
+// the last server.user_login.password_history_count passwords of the
+// user, or the last PASSWORD_HISTORY passwords if the profile of the
+// user sets this option. Otherwise, it adds the current password to the history of the
+// user, and drops the passwords which are too old to be checked.
+//
+// The password can be provided in cleartext or, if it was provided by
+// the client as a hash, as its hash.
+func (p *planner) checkAndRecordPasswordHistory(
+	ctx context.Context, opName string, username security.SQLUsername, password string,
+	hashedPassword []byte,
+) error {
+	ie := p.ExecCfg().InternalExecutor
+	n := int(passwordHistoryCount.Get(&p.ExecCfg().Settings.SV))
+	configuredBy := passwordHistoryCount.Key()
+	profileOpts, err := getRoleProfileOptionsForUser(ctx, p.txn, ie, username)
+	if err != nil {
+		return err
+	}
+	if v := profileOpts[roleoption.PASSWORDHISTORY.String()]; v != nil {
+		// The value was validated when it was added to the profile.
+		if n, err = strconv.Atoi(*v); err != nil {
+			return errors.NewAssertionErrorWithWrappedErrf(err, "invalid password history %q", *v)
+		}
+		configuredBy = "the PASSWORD_HISTORY option of the profile of the user"
+	}
+	if n == 0 {
+		return nil
+	}
+
+	row, err := ie.QueryRowEx(
+		ctx, opName, p.txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`SELECT u."hashedPassword", o.value FROM system.public.users AS u `+
+			`LEFT JOIN system.public.role_options AS o `+
+			`ON o.username = u.username AND o.option = $2 WHERE u.username = $1`,
+		username, passwordHistoryOption,
+	)
+	if err != nil || row == nil {
+		return err
+	}
+	var previous [][]byte
+	if row[0] != tree.DNull {
+		if current := []byte(tree.MustBeDBytes(row[0])); len(current) > 0 {
+			previous = append(previous, current)
+		}
+	}
+	if row[1] != tree.DNull {
+		for _, h := range strings.Split(string(tree.MustBeDString(row[1])), "\n") {
+			if h != "" {
+				previous = append(previous, []byte(h))
+			}
+		}
+	}
+	if len(previous) > n {
+		previous = previous[:n]
+	}
+
+	for _, h := range previous {
+		reused := bytes.Equal(h, hashedPassword)
+		if !reused {
+			reused, err = security.CompareHashAndCleartextPassword(ctx, security.LoadPasswordHash(ctx, h), password)
+			if err != nil {
+				return err
+			}
+		}
+		if reused {
+			return errors.WithHintf(
+				pgerror.Newf(pgcode.InvalidPassword,
+					"the new password must differ from the last %d passwords of the user", n),
+				"The number of passwords which cannot be reused is configured by %s.",
+				configuredBy,
+			)
+		}
+	}
+
+	// The new password is stored in system.users, so only the previous
+	// n-1 passwords need to be remembered.
+	if len(previous) > n-1 {
+		previous = previous[:n-1]
+	}
+	if len(previous) == 0 {
+		_, err = ie.ExecEx(
+			ctx, opName, p.txn,
+			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+			`DELETE FROM system.role_options WHERE username = $1 AND option = $2`,
+			username, passwordHistoryOption,
+		)
+		return err
+	}
+	_, err = ie.ExecEx(
+		ctx, opName, p.txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`UPSERT INTO system.role_options (username, option, value) VALUES ($1, $2, $3)`,
+		username, passwordHistoryOption, string(bytes.Join(previous, []byte("\n"))),
+	)
+	return err
+}
@@ -21,6 +21,7 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
@@ -2411,6 +2412,12 @@ https://www.postgresql.org/docs/9.5/view-pg-roles.html`,
 		// need to do the same. This shouldn't be an issue, because pg_roles doesn't
 		// include sensitive information such as password hashes.
 		h := makeOidHasher()
+		// The time of the last login of the roles is a CockroachDB
+		// extension.
+		lastLogins, err := getRoleLastLogins(ctx, p)
+		if err != nil {
+			return err
+		}
 		return forEachRole(ctx, p,
 			func(username security.SQLUsername, isRole bool, options roleOptions, settings tree.Datum) error {
 				isRoot := tree.DBool(username.IsRootUser() || username.IsAdminRole())
@@ -2438,6 +2445,10 @@ https://www.postgresql.org/docs/9.5/view-pg-roles.html`,
 				if err != nil {
 					return err
 				}
+				lastLogin, ok := lastLogins[username.Normalized()]
+				if !ok {
+					lastLogin = tree.DNull
+				}
 
 				return addRow(
 					h.UserOid(username),                  // oid
@@ -2454,6 +2465,7 @@ https://www.postgresql.org/docs/9.5/view-pg-roles.html`,
 					rolValidUntil,                        // rolvaliduntil
 					tree.DBoolFalse,                      // rolbypassrls
 					settings,                             // rolconfig
+					lastLogin,                            // rollastlogin
 				)
 			})
 	},
@@ -3161,18 +3173,31 @@ var pgCatalogDbRoleSettingTable = virtualSchemaTable{
 https://www.postgresql.org/docs/13/catalog-pg-db-role-setting.html`,
 	schema: vtable.PgCatalogDbRoleSetting,
 	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		query := `SELECT database_id, role_name, settings FROM system.public.database_role_settings`
+		if p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.DatabaseRoleSettingsApplicationName) {
+			// The settings scoped to applications have no equivalent in
+			// PostgreSQL.
+			query += ` WHERE application_name = ''`
+			if p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.DatabaseRoleSettingsSourceNetwork) {
+				// Neither have the settings scoped to networks.
+				query += ` AND source_network = ''`
+			}
+		}
 		rows, err := p.extendedEvalCtx.ExecCfg.InternalExecutor.QueryBufferedEx(
 			ctx,
 			"select-db-role-settings",
 			p.EvalContext().Txn,
 			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
-			`SELECT database_id, role_name, settings FROM system.public.database_role_settings`,
+			query,
 		)
 		if err != nil {
 			return err
 		}
 		h := makeOidHasher()
 		for _, row := range rows {
+			// The defaults for all the databases or all the roles use the
+			// OID 0, as in PostgreSQL. The other OIDs match those of
+			// pg_database and pg_roles.
 			databaseID := tree.MustBeDOid(row[0])
 			roleName := tree.MustBeDString(row[1])
 			roleID := oidZero
@@ -3181,9 +3206,9 @@ https://www.postgresql.org/docs/13/catalog-pg-db-role-setting.html`,
 			}
 			settings := tree.MustBeDArray(row[2])
 			if err := addRow(
-				settings,
 				databaseID,
 				roleID,
+				settings,
 			); err != nil {
 				return err
 			}
@@ -12,20 +12,29 @@ package pgwire
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/hex"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/hba"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/identmap"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
 )
 
@@ -59,13 +68,25 @@ type authOptions struct {
 	// auth is the current HBA configuration as returned by
 	// (*Server).GetAuthenticationConfiguration().
 	auth *hba.Conf
+	// hbaRuleStats counts the connections matched by the rules of auth.
+	hbaRuleStats *hbaRuleStats
 	// identMap is used in conjunction with the HBA configuration to
 	// allow system usernames (e.g. GSSAPI principals or X.509 CN's) to
 	// be dynamically mapped to database usernames.
 	identMap *identmap.Conf
+	// hostnameResolver is used to look up the host names of the client
+	// when the HBA configuration contains rules with host names.
+	hostnameResolver hostnameResolver
 	// ie is the server-wide internal executor, used to
 	// retrieve entries from system.users.
 	ie *sql.InternalExecutor
+	// failureDelayer delays the responses to repeated failed
+	// authentication attempts.
+	failureDelayer *authFailureDelayer
+	// authAttempts records the authentication attempts.
+	authAttempts *sql.AuthAttempts
+	// lastLogins records the last successful login of each role.
+	lastLogins *sql.LastLoginRecorder
 
 	// The following fields are only used by tests.
 
@@ -88,7 +109,7 @@ type authOptions struct {
 // if different from the one given initially.
 func (c *conn) handleAuthentication(
 	ctx context.Context, ac AuthConn, authOpt authOptions, execCfg *sql.ExecutorConfig,
-) (connClose func(), _ error) {
+) (connClose func(), retErr error) {
 	if authOpt.testingSkipAuth {
 		return nil, nil
 	}
@@ -97,7 +118,16 @@ func (c *conn) handleAuthentication(
 	}
 
 	// Retrieve the authentication method.
-	tlsState, hbaEntry, authMethod, err := c.findAuthenticationMethod(authOpt)
+	hbaCtx, hbaSpan := tracing.ChildSpan(ctx, "pgwire-hba-evaluation")
+	tlsState, hbaEntry, hbaRule, authMethod, err := c.findAuthenticationMethod(hbaCtx, authOpt)
+	if err == nil {
+		log.VEventf(hbaCtx, 2, "HBA rule: %s", hbaEntry.Input)
+	}
+	hbaSpan.Finish()
+	// Count the connections matched by the HBA rule, if any, and the
+	// outcome of their authentication.
+	hbaRule.recordMatch()
+	defer func() { hbaRule.recordOutcome(retErr == nil) }()
 	if err != nil {
 		ac.LogAuthFailed(ctx, eventpb.AuthFailReason_METHOD_NOT_FOUND, err)
 		return nil, c.sendError(ctx, execCfg, pgerror.WithCandidateCode(err, pgcode.InvalidAuthorizationSpecification))
@@ -106,6 +136,11 @@ func (c *conn) handleAuthentication(
 	ac.SetAuthMethod(hbaEntry.Method.String())
 	ac.LogAuthInfof(ctx, "HBA rule: %s", hbaEntry.Input)
 
+	// The TOTP code of the users with the MFA role option may be
+	// appended to their cleartext password.
+	totpConn := &totpAuthConn{AuthConn: ac}
+	ac = totpConn
+
 	// Populate the AuthMethod with per-connection information so that it
 	// can compose the next layer of behaviors that we're going to apply
 	// to the incoming connection.
@@ -138,49 +173,253 @@ func (c *conn) handleAuthentication(
 	// will be present in c.sessionArgs.User.
 	dbUser := c.sessionArgs.User
 
+	// The default settings of the roles may be scoped to the network the
+	// client connects from.
+	var sourceAddress string
+	if tcpAddr, ok := c.sessionArgs.RemoteAddr.(*net.TCPAddr); ok {
+		sourceAddress = tcpAddr.IP.String()
+	}
+
 	// Check that the requested user exists and retrieve the hashed
 	// password in case password authentication is needed.
-	exists, canLoginSQL, _, isSuperuser, defaultSettings, pwRetrievalFn, err :=
-		sql.GetUserSessionInitInfo(
-			ctx,
-			execCfg,
-			authOpt.ie,
-			dbUser,
-			c.sessionArgs.SessionDefaults["database"],
-		)
+	info, err := sql.GetUserSessionInitInfo(
+		ctx,
+		execCfg,
+		authOpt.ie,
+		dbUser,
+		c.sessionArgs.SessionDefaults["database"],
+		c.sessionArgs.SessionDefaults["application_name"],
+		sourceAddress,
+	)
 	if err != nil {
 		log.Warningf(ctx, "user retrieval failed for user=%q: %+v", dbUser, err)
 		ac.LogAuthFailed(ctx, eventpb.AuthFailReason_USER_RETRIEVAL_ERROR, err)
 		return connClose, c.sendError(ctx, execCfg, pgerror.WithCandidateCode(err, pgcode.InvalidAuthorizationSpecification))
 	}
-	c.sessionArgs.IsSuperuser = isSuperuser
+	c.sessionArgs.IsSuperuser = info.IsSuperuser
+	c.sessionArgs.PasswordMustChange = info.PasswordMustChange
+	c.sessionArgs.ReadOnlyLogin = info.ReadOnly
+	// The HBA rules which only match some databases authenticate the
+	// session for the database requested by the client, so the session is
+	// pinned to it as with the ALLOWED DATABASES role option. Otherwise,
+	// a session authenticated by a trust rule for one database could use
+	// the others with USE or qualified names. If the role option does not
+	// allow the database, the connection is rejected below.
+	allowedDatabases := info.AllowedDatabases
+	if dbName := c.sessionArgs.SessionDefaults["database"]; hbaEntry.Database != nil &&
+		sql.CheckAllowedDatabase(dbUser, allowedDatabases, dbName) == nil {
+		allowedDatabases = []string{dbName}
+	}
+	c.sessionArgs.AllowedDatabases = allowedDatabases
+	c.roleConnectionLimit = info.ConnectionLimit
+	ac.SetAuthInfoCacheHit(info.CacheHit)
 
-	if !exists {
+	if !info.UserExists {
 		ac.LogAuthFailed(ctx, eventpb.AuthFailReason_USER_NOT_FOUND, nil)
+		authOpt.failureDelayer.delayFailure(ctx, dbUser, c.sessionArgs.RemoteAddr)
 		return connClose, c.sendError(ctx, execCfg, pgerror.WithCandidateCode(security.NewErrPasswordUserAuthFailed(dbUser), pgcode.InvalidAuthorizationSpecification))
 	}
 
-	if !canLoginSQL {
+	if !info.CanLoginSQL {
 		ac.LogAuthFailed(ctx, eventpb.AuthFailReason_LOGIN_DISABLED, nil)
 		return connClose, c.sendError(ctx, execCfg, pgerror.Newf(pgcode.InvalidAuthorizationSpecification, "%s does not have login privilege", dbUser))
 	}
 
+	// The second factor is not required in insecure mode, nor when the
+	// session is revived with a token obtained from an authenticated
+	// session.
+	mfaRequired := info.MFARequired && !authOpt.insecure && hbaEntry != &sessionRevivalEntry
+	totpConn.extractCode = mfaRequired
+
 	// At this point, we know that the requested user exists and is
 	// allowed to log in. Now we can delegate to the selected AuthMethod
 	// implementation to complete the authentication.
-	if err := behaviors.Authenticate(ctx, systemIdentity, true /* public */, pwRetrievalFn); err != nil {
+	//
+	// Only the sessions authenticated with the password of the user are
+	// subject to its expiration once established, so we record whether
+	// the method retrieved it.
+	var usedPassword bool
+	trackedPwRetrievalFn := func(ctx context.Context) (bool, security.PasswordHash, error) {
+		usedPassword = true
+		return info.PasswordRetrievalFn(ctx)
+	}
+	authCtx, authSpan := tracing.ChildSpan(ctx, "pgwire-authenticate")
+	log.VEventf(authCtx, 2, "verifying the credentials of user %s with method %s",
+		dbUser, hbaEntry.Method)
+	err = behaviors.Authenticate(authCtx, systemIdentity, true /* public */, trackedPwRetrievalFn, info.RoleSubject)
+	log.VEventf(authCtx, 2, "credentials verified: %t", err == nil)
+	authSpan.Finish()
+	if errors.Is(err, security.ErrHashComputeOverloaded) {
+		// The credentials could not be checked. This is not a failed
+		// attempt, so the failure is not delayed.
+		ac.LogAuthFailed(ctx, eventpb.AuthFailReason_UNKNOWN, err)
+		return connClose, c.sendError(ctx, execCfg, errors.WithHint(
+			pgerror.WithCandidateCode(err, pgcode.TooManyConnections), "Try again later."))
+	}
+	if err != nil {
 		ac.LogAuthFailed(ctx, eventpb.AuthFailReason_CREDENTIALS_INVALID, err)
+		// Delay the response to slow down attempts to guess the
+		// credentials of the user.
+		authOpt.failureDelayer.delayFailure(ctx, dbUser, c.sessionArgs.RemoteAddr)
 		return connClose, c.sendError(ctx, execCfg, pgerror.WithCandidateCode(err, pgcode.InvalidAuthorizationSpecification))
 	}
+	if mfaRequired {
+		if err := c.authenticateTOTP(ctx, totpConn, execCfg, authOpt.ie, dbUser); err != nil {
+			ac.LogAuthFailed(ctx, eventpb.AuthFailReason_CREDENTIALS_INVALID, err)
+			authOpt.failureDelayer.delayFailure(ctx, dbUser, c.sessionArgs.RemoteAddr)
+			return connClose, c.sendError(ctx, execCfg, pgerror.WithCandidateCode(err, pgcode.InvalidAuthorizationSpecification))
+		}
+	}
+	authOpt.failureDelayer.recordSuccess(dbUser, c.sessionArgs.RemoteAddr)
+	c.sessionArgs.PasswordAuthenticated = usedPassword
+	c.sessionArgs.AuthDetails = makeSessionAuthDetails(hbaEntry, tlsState)
+	c.startupNotices = makeExpiryNotices(
+		&execCfg.Settings.SV, timeutil.Now(), tlsState, info.ValidUntil, usedPassword)
+
+	// Like ALLOWED DATABASES, the LOGIN WINDOW role option is checked once
+	// the client is authenticated, so as not to disclose it.
+	if !info.LoginWindow.Allows(timeutil.Now()) {
+		err := pgerror.Newf(pgcode.InvalidAuthorizationSpecification,
+			"%s is not allowed to log in at this time", dbUser)
+		ac.LogAuthFailed(ctx, eventpb.AuthFailReason_LOGIN_DISABLED, err)
+		return connClose, c.sendError(ctx, execCfg, err)
+	}
+
+	// The default settings set with LOCKED take precedence over the values
+	// provided by the client and over the other default settings, and the
+	// session cannot change them. If a variable is locked by several
+	// settings, the one with the highest precedence applies.
+	for _, settingEntry := range info.DefaultSettings {
+		for _, setting := range settingEntry.LockedSettings {
+			keyVal := strings.SplitN(setting, "=", 2)
+			if len(keyVal) != 2 {
+				continue
+			}
+			if err := sql.CheckSessionVariableValueValid(ctx, execCfg.Settings, keyVal[0], keyVal[1]); err != nil {
+				log.Ops.Warningf(ctx, "%s has invalid default setting: %v", dbUser, err)
+				continue
+			}
+			if _, ok := c.sessionArgs.LockedVars[keyVal[0]]; !ok {
+				if c.sessionArgs.LockedVars == nil {
+					c.sessionArgs.LockedVars = make(map[string]struct{})
+				}
+				c.sessionArgs.LockedVars[keyVal[0]] = struct{}{}
+				c.sessionArgs.SessionDefaults[keyVal[0]] = keyVal[1]
+			}
+			c.sessionArgs.AddDefaultProvenance(sql.SessionDefaultProvenance{
+				Variable: keyVal[0],
+				Value:    keyVal[1],
+				Source:   sql.SessionDefaultSourceLockedRoleSetting,
+				Setting:  settingEntry.SettingsCacheKey,
+			})
+		}
+	}
+
+	// Consult the authorization webhook, if any. Like the second factor,
+	// it is not consulted again for revived sessions.
+	if !dbUser.IsRootUser() && !dbUser.IsNodeUser() && hbaEntry != &sessionRevivalEntry {
+		webhookDefaults, err := checkAuthzWebhook(ctx, execCfg.Settings, authzWebhookRequest{
+			User:            dbUser.Normalized(),
+			SystemIdentity:  systemIdentity.Normalized(),
+			Database:        c.sessionArgs.SessionDefaults["database"],
+			ApplicationName: c.sessionArgs.SessionDefaults["application_name"],
+			ClientAddress:   c.sessionArgs.RemoteAddr.String(),
+			ConnectionType:  authOpt.connType.String(),
+			AuthMethod:      hbaEntry.Method.String(),
+		})
+		if err != nil {
+			ac.LogAuthFailed(ctx, eventpb.AuthFailReason_LOGIN_DISABLED, err)
+			return connClose, c.sendError(ctx, execCfg, err)
+		}
+		// The defaults provided by the webhook take precedence over the
+		// values provided by the client, but not over the default settings
+		// of the role set with LOCKED. The overridden defaults are still
+		// recorded for SHOW SETTING PROVENANCE.
+		for name, value := range webhookDefaults {
+			if err := sql.CheckSessionVariableValueValid(ctx, execCfg.Settings, name, value); err != nil {
+				log.Ops.Warningf(ctx, "authorization webhook returned an invalid default setting for %s: %v", dbUser, err)
+				continue
+			}
+			if _, locked := c.sessionArgs.LockedVars[name]; !locked {
+				c.sessionArgs.SessionDefaults[name] = value
+			}
+			c.sessionArgs.AddDefaultProvenance(sql.SessionDefaultProvenance{
+				Variable: name, Value: value, Source: sql.SessionDefaultSourceWebhook,
+			})
+		}
+	}
+
+	// The sessions of the users with the READONLY role option are
+	// read-only, regardless of the defaults provided by the client, the
+	// webhook or the role settings. The session then rejects attempts to
+	// use read-write transactions.
+	if info.ReadOnly {
+		c.sessionArgs.SessionDefaults["default_transaction_read_only"] = "on"
+		c.sessionArgs.AddDefaultProvenance(sql.SessionDefaultProvenance{
+			Variable: "default_transaction_read_only", Value: "on", Source: sql.SessionDefaultSourceRoleOption,
+		})
+	}
+
+	// Likewise, the IDLE_SESSION_TIMEOUT option of the user, or of its
+	// profile, overrides the idle timeout requested by the client. The
+	// session cannot raise the timeout above it, nor disable it.
+	if info.IdleSessionTimeout != 0 {
+		value := strconv.FormatInt(info.IdleSessionTimeout.Milliseconds(), 10)
+		c.sessionArgs.SessionDefaults["idle_in_session_timeout"] = value
+		c.sessionArgs.AddDefaultProvenance(sql.SessionDefaultProvenance{
+			Variable: "idle_in_session_timeout", Value: value, Source: sql.SessionDefaultSourceRoleOption,
+		})
+	}
+	c.sessionArgs.MaxIdleSessionTimeout = info.IdleSessionTimeout
+
+	// The STATEMENT_TIMEOUT and TRANSACTION_TIMEOUT options bound the
+	// corresponding timeouts of the session instead. The defaults which
+	// exceed them are lowered when the session is set up, and the session
+	// cannot raise the timeouts above them.
+	c.sessionArgs.MaxStmtTimeout = info.MaxStatementTimeout
+	c.sessionArgs.MaxTxnTimeout = info.MaxTransactionTimeout
+
+	// The QUALITY_OF_SERVICE option sets the admission control priority of
+	// the transactions of the sessions, so that the roles of background or
+	// reporting workloads can be deprioritized relative to the roles of
+	// latency-sensitive applications. The session can lower the priority,
+	// but cannot raise it above the option.
+	c.sessionArgs.MaxQualityOfService = info.QualityOfService
+	if info.QualityOfService != "" {
+		c.sessionArgs.SessionDefaults["default_transaction_quality_of_service"] = info.QualityOfService
+		c.sessionArgs.AddDefaultProvenance(sql.SessionDefaultProvenance{
+			Variable: "default_transaction_quality_of_service", Value: info.QualityOfService,
+			Source: sql.SessionDefaultSourceRoleOption,
+		})
+	}
+
+	// The MAX_RESULT_ROWS and MAX_RESULT_BYTES options bound the results
+	// of the statements of the session. They are not session variables,
+	// so the client cannot override them.
+	c.sessionArgs.MaxResultRows = info.MaxResultRows
+	c.sessionArgs.MaxResultBytes = info.MaxResultBytes
+
+	// The ALLOWED DATABASES role option is checked once the client is
+	// authenticated, so as not to disclose it, and once the webhook has
+	// provided its defaults, which may include the database.
+	if err := sql.CheckAllowedDatabase(dbUser, allowedDatabases, c.sessionArgs.SessionDefaults["database"]); err != nil {
+		ac.LogAuthFailed(ctx, eventpb.AuthFailReason_LOGIN_DISABLED, err)
+		return connClose, c.sendError(ctx, execCfg, err)
+	}
 
 	// Add all the defaults to this session's defaults. If there is an
 	// error (e.g., a setting that no longer exists, or bad input),
 	// log a warning instead of preventing login.
 	// The defaultSettings array is ordered by precedence. This means that if
 	// SessionDefaults already has an entry for a given setting name, then
-	// it should not be replaced.
-	for _, settingEntry := range defaultSettings {
+	// it should not be replaced. The overridden settings are still recorded
+	// for SHOW SETTING PROVENANCE.
+	// The settings set with LOCKED were applied above.
+	for _, settingEntry := range info.DefaultSettings {
 		for _, setting := range settingEntry.Settings {
+			if settingEntry.IsLocked(setting) {
+				continue
+			}
 			keyVal := strings.SplitN(setting, "=", 2)
 			if len(keyVal) != 2 {
 				log.Ops.Warningf(ctx, "%s has malformed default setting: %q", dbUser, setting)
@@ -193,6 +432,12 @@ func (c *conn) handleAuthentication(
 			if _, ok := c.sessionArgs.SessionDefaults[keyVal[0]]; !ok {
 				c.sessionArgs.SessionDefaults[keyVal[0]] = keyVal[1]
 			}
+			c.sessionArgs.AddDefaultProvenance(sql.SessionDefaultProvenance{
+				Variable: keyVal[0],
+				Value:    keyVal[1],
+				Source:   sql.SessionDefaultSourceRoleSetting,
+				Setting:  settingEntry.SettingsCacheKey,
+			})
 		}
 	}
 
@@ -200,6 +445,24 @@ func (c *conn) handleAuthentication(
 	return connClose, nil
 }
 
+// makeSessionAuthDetails describes how a session was authenticated with
+// the given HBA rule and TLS connection state.
+func makeSessionAuthDetails(
+	hbaEntry *hba.Entry, tlsState tls.ConnectionState,
+) sql.SessionAuthDetails {
+	details := sql.SessionAuthDetails{
+		Method:      hbaEntry.Method.String(),
+		IdentityMap: hbaEntry.GetOption("map"),
+	}
+	if len(tlsState.PeerCertificates) > 0 {
+		cert := tlsState.PeerCertificates[0]
+		fingerprint := sha256.Sum256(cert.Raw)
+		details.ClientCertFingerprint = hex.EncodeToString(fingerprint[:])
+		details.ClientCertExpiry = cert.NotAfter
+	}
+	return details
+}
+
 func (c *conn) authOKMessage() error {
 	c.msgBuilder.initMsg(pgwirebase.ServerMsgAuth)
 	c.msgBuilder.putInt32(authOK)
@@ -230,8 +493,14 @@ func (c *conn) chooseDbRole(
 }
 
 func (c *conn) findAuthenticationMethod(
-	authOpt authOptions,
-) (tlsState tls.ConnectionState, hbaEntry *hba.Entry, methodFn AuthMethod, err error) {
+	ctx context.Context, authOpt authOptions,
+) (
+	tlsState tls.ConnectionState,
+	hbaEntry *hba.Entry,
+	hbaRule *hbaRuleCounters,
+	methodFn AuthMethod,
+	err error,
+) {
 	if authOpt.insecure {
 		// Insecure connections always use "trust" no matter what, and the
 		// remaining of the configuration is ignored.
@@ -248,7 +517,7 @@ func (c *conn) findAuthenticationMethod(
 
 	// Look up the method from the HBA configuration.
 	var mi methodInfo
-	mi, hbaEntry, err = c.lookupAuthenticationMethodUsingRules(authOpt.connType, authOpt.auth)
+	mi, hbaEntry, hbaRule, err = c.lookupAuthenticationMethodUsingRules(ctx, authOpt)
 	if err != nil {
 		return
 	}
@@ -272,14 +541,24 @@ func (c *conn) findAuthenticationMethod(
 		tlsState = tlsConn.ConnectionState()
 	}
 
+	// Check the TLS policy of the rule, and hide its options from the
+	// method.
+	methodEntry, tlsPolicy, err := splitHBATLSPolicy(*hbaEntry)
+	if err != nil {
+		return
+	}
+	if err = tlsPolicy.check(authOpt.connType, tlsState); err != nil {
+		return
+	}
+	hbaEntry = &methodEntry
 	return
 }
 
 func (c *conn) lookupAuthenticationMethodUsingRules(
-	connType hba.ConnType, auth *hba.Conf,
-) (mi methodInfo, entry *hba.Entry, err error) {
+	ctx context.Context, authOpt authOptions,
+) (mi methodInfo, entry *hba.Entry, rule *hbaRuleCounters, err error) {
 	var ip net.IP
-	if connType != hba.ConnLocal {
+	if authOpt.connType != hba.ConnLocal {
 		// Extract the IP address of the client.
 		tcpAddr, ok := c.sessionArgs.RemoteAddr.(*net.TCPAddr)
 		if !ok {
@@ -290,30 +569,78 @@ func (c *conn) lookupAuthenticationMethodUsingRules(
 	}
 
 	// Look up the method.
+	resolver := authOpt.hostnameResolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	// The sessions authenticated by a rule which only matches some
+	// databases are pinned to the database requested by the client.
+	idx, err := findMatchingHBAEntry(ctx, c.sv, resolver, authOpt.auth, authOpt.connType, ip,
+		c.sessionArgs.User, c.sessionArgs.SessionDefaults["database"])
+	if err != nil {
+		return
+	}
+	if idx < 0 {
+		// No match.
+		err = errors.Errorf("no %s entry for host %q, user %q", serverHBAConfSetting, ip, c.sessionArgs.User)
+		return
+	}
+	entry = &authOpt.auth.Entries[idx]
+	return entry.MethodFn.(methodInfo), entry, authOpt.hbaRuleStats.rule(idx), nil
+}
+
+// findMatchingHBAEntry returns the index of the first rule of the HBA
+// configuration which matches a client connection, or -1 if there is
+// none. The host names of the client are only looked up if a rule with
+// a host name is considered.
+func findMatchingHBAEntry(
+	ctx context.Context,
+	sv *settings.Values,
+	resolver hostnameResolver,
+	auth *hba.Conf,
+	connType hba.ConnType,
+	ip net.IP,
+	user security.SQLUsername,
+	database string,
+) (int, error) {
+	var clientHostnames []string
+	hostnamesResolved := false
 	for i := range auth.Entries {
-		entry = &auth.Entries[i]
+		entry := &auth.Entries[i]
 		var connMatch bool
-		connMatch, err = entry.ConnMatches(connType, ip)
-		if err != nil {
-			// TODO(knz): Determine if an error should be reported
-			// upon unknown address formats.
-			// See: https://github.com/cockroachdb/cockroach/issues/43716
-			return
+		if connType != hba.ConnLocal && entry.IsHostname() {
+			if entry.ConnTypeMatches(connType) {
+				if !hostnamesResolved {
+					clientHostnames = lookupClientHostnames(ctx, sv, resolver, ip)
+					hostnamesResolved = true
+				}
+				connMatch = entry.HostnameMatches(clientHostnames)
+			}
+		} else {
+			var err error
+			connMatch, err = entry.ConnMatches(connType, ip)
+			if err != nil {
+				// TODO(knz): Determine if an error should be reported
+				// upon unknown address formats.
+				// See: https://github.com/cockroachdb/cockroach/issues/43716
+				return -1, err
+			}
 		}
 		if !connMatch {
 			// The address does not match.
 			continue
 		}
-		if !entry.UserMatches(c.sessionArgs.User) {
+		if !entry.UserMatches(user) {
 			// The user does not match.
 			continue
 		}
-		return entry.MethodFn.(methodInfo), entry, nil
+		if !entry.DatabaseMatches(database, user) {
+			// The database does not match.
+			continue
+		}
+		return i, nil
 	}
-
-	// No match.
-	err = errors.Errorf("no %s entry for host %q, user %q", serverHBAConfSetting, ip, c.sessionArgs.User)
-	return
+	return -1, nil
 }
 
 // authenticatorIO is the interface used by the connection to pass password data
@@ -366,6 +693,9 @@ type AuthConn interface {
 	// identity for the connection. This is useful for "ambient"
 	// authentication mechanisms, such as GSSAPI.
 	SetSystemIdentity(systemIdentity security.SQLUsername)
+	// SetAuthInfoCacheHit records whether the authentication info of the
+	// user was served from the cache, for the recording of the attempt.
+	SetAuthInfoCacheHit(cacheHit bool)
 	// LogAuthInfof logs details about the progress of the
 	// authentication.
 	LogAuthInfof(ctx context.Context, format string, args ...interface{})
@@ -382,9 +712,16 @@ type authPipe struct {
 	c   *conn // Only used for writing, not for reading.
 	log bool
 
-	connDetails eventpb.CommonConnectionDetails
-	authDetails eventpb.CommonSessionDetails
-	authMethod  string
+	connDetails  eventpb.CommonConnectionDetails
+	authDetails  eventpb.CommonSessionDetails
+	authMethod   string
+	authAttempts *sql.AuthAttempts
+	lastLogins   *sql.LastLoginRecorder
+	// start is when the authentication started, and cacheHit whether the
+	// authentication info of the user was served from the cache, for the
+	// recording of the attempt.
+	start    time.Time
+	cacheHit bool
 
 	ch chan []byte
 	// writerDone is a channel closed by noMorePwdData().
@@ -408,9 +745,12 @@ func newAuthPipe(
 			SystemIdentity: systemIdentity.Normalized(),
 			Transport:      authOpt.connType.String(),
 		},
-		ch:         make(chan []byte),
-		writerDone: make(chan struct{}),
-		readerDone: make(chan authRes, 1),
+		authAttempts: authOpt.authAttempts,
+		lastLogins:   authOpt.lastLogins,
+		start:        timeutil.Now(),
+		ch:           make(chan []byte),
+		writerDone:   make(chan struct{}),
+		readerDone:   make(chan authRes, 1),
 	}
 	return ap
 }
@@ -467,7 +807,42 @@ func (p *authPipe) SetSystemIdentity(systemIdentity security.SQLUsername) {
 	p.authDetails.SystemIdentity = systemIdentity.Normalized()
 }
 
+func (p *authPipe) SetAuthInfoCacheHit(cacheHit bool) {
+	p.cacheHit = cacheHit
+}
+
+// recordAttempt records the outcome of the authentication.
+func (p *authPipe) recordAttempt(success bool, reason, detail string) {
+	user := p.authDetails.User
+	if user == "" {
+		user = p.authDetails.SystemIdentity
+	}
+	now := timeutil.Now()
+	p.authAttempts.Record(sql.AuthAttempt{
+		Timestamp:     now,
+		Username:      user,
+		RemoteAddress: p.connDetails.RemoteAddress,
+		Transport:     p.authDetails.Transport,
+		Method:        p.authMethod,
+		Success:       success,
+		Reason:        reason,
+		Detail:        detail,
+		Latency:       now.Sub(p.start),
+		CacheHit:      p.cacheHit,
+	})
+}
+
 func (p *authPipe) LogAuthOK(ctx context.Context) {
+	p.recordAttempt(true /* success */, "" /* reason */, "" /* detail */)
+	telemetry.Inc(sqltelemetry.AuthMethodCounter(sqltelemetry.AuthTransportSQL, p.authMethod))
+	p.lastLogins.Record(
+		security.MakeSQLUsernameFromPreNormalizedString(p.authDetails.User),
+		sql.LastLogin{
+			Timestamp:     timeutil.Now(),
+			Transport:     p.authDetails.Transport,
+			Method:        p.authMethod,
+			RemoteAddress: p.connDetails.RemoteAddress,
+		})
 	if p.log {
 		ev := &eventpb.ClientAuthenticationOk{
 			CommonConnectionDetails: p.connDetails,
@@ -493,11 +868,12 @@ func (p *authPipe) LogAuthInfof(ctx context.Context, format string, args ...inte
 func (p *authPipe) LogAuthFailed(
 	ctx context.Context, reason eventpb.AuthFailReason, detailedErr error,
 ) {
+	var errStr string
+	if detailedErr != nil {
+		errStr = detailedErr.Error()
+	}
+	p.recordAttempt(false /* success */, reason.String(), errStr)
 	if p.log {
-		var errStr string
-		if detailedErr != nil {
-			errStr = detailedErr.Error()
-		}
 		ev := &eventpb.ClientAuthenticationFailed{
 			CommonConnectionDetails: p.connDetails,
 			CommonSessionDetails:    p.authDetails,
@@ -51,9 +51,10 @@ func (b *AuthBehaviors) Authenticate(
 	systemIdentity security.SQLUsername,
 	clientConnection bool,
 	pwRetrieveFn PasswordRetrievalFn,
+	roleSubject security.DistinguishedName,
 ) error {
 	if found := b.authenticator; found != nil {
-		return found(ctx, systemIdentity, clientConnection, pwRetrieveFn)
+		return found(ctx, systemIdentity, clientConnection, pwRetrieveFn, roleSubject)
 	}
 	return errors.New("no Authenticator provided to AuthBehaviors")
 }
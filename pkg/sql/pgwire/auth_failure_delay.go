@@ -0,0 +1,187 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// authFailureDelayInitial is the cluster setting that holds the delay
+// applied to the response to the first failed authentication attempt.
+var authFailureDelayInitial = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"server.authentication_failure_delay.initial",
+	"the delay before responding to a failed authentication attempt; the delay "+
+		"doubles with each consecutive failure for the same user or client IP "+
+		"address (0 disables the delay)",
+	0,
+	settings.NonNegativeDuration,
+)
+
+// authFailureDelayMax is the cluster setting that holds the maximum
+// delay applied to the response to a failed authentication attempt.
+var authFailureDelayMax = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"server.authentication_failure_delay.max",
+	"the maximum delay before responding to a failed authentication attempt, "+
+		"when server.authentication_failure_delay.initial is set",
+	5*time.Second,
+	settings.NonNegativeDuration,
+)
+
+const (
+	// authFailureMemory is the duration after which the failures for a
+	// user or client address are forgotten if there was no new failure.
+	authFailureMemory = 10 * time.Minute
+	// maxAuthFailureEntries bounds the number of users and client
+	// addresses whose failures are remembered, so that an attacker
+	// trying many user names cannot exhaust the memory of the server.
+	maxAuthFailureEntries = 100000
+)
+
+// authFailureDelayer delays the responses to failed authentication
+// attempts, so that trying many credentials for the same user or from
+// the same client becomes slow, without locking out the user.
+//
+// The number of consecutive failures is counted separately for each
+// user and each client IP address; a successful authentication resets
+// both counts. The delay is the initial delay, doubled for every
+// failure beyond the first one, up to the maximum delay.
+type authFailureDelayer struct {
+	sv         *settings.Values
+	timeSource timeutil.TimeSource
+
+	mu struct {
+		syncutil.Mutex
+		failures map[string]*authFailures
+		// lastGC is the last time old entries were removed.
+		lastGC time.Time
+	}
+}
+
+type authFailures struct {
+	count       int
+	lastFailure time.Time
+}
+
+func (d *authFailureDelayer) init(sv *settings.Values, timeSource timeutil.TimeSource) {
+	d.sv = sv
+	d.timeSource = timeSource
+	d.mu.failures = make(map[string]*authFailures)
+	d.mu.lastGC = timeSource.Now()
+}
+
+// authFailureKeys returns the keys under which the failures for the
+// given user and client address are counted.
+func authFailureKeys(user security.SQLUsername, remoteAddr net.Addr) []string {
+	keys := []string{"user:" + user.Normalized()}
+	if tcpAddr, ok := remoteAddr.(*net.TCPAddr); ok {
+		keys = append(keys, "ip:"+tcpAddr.IP.String())
+	}
+	return keys
+}
+
+// recordFailure records a failed authentication attempt and returns the
+// delay to apply before responding to the client.
+func (d *authFailureDelayer) recordFailure(
+	user security.SQLUsername, remoteAddr net.Addr,
+) time.Duration {
+	initial := authFailureDelayInitial.Get(d.sv)
+	if initial == 0 {
+		return 0
+	}
+	maxDelay := authFailureDelayMax.Get(d.sv)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := d.timeSource.Now()
+	d.maybeGCLocked(now)
+
+	count := 0
+	for _, key := range authFailureKeys(user, remoteAddr) {
+		f, ok := d.mu.failures[key]
+		if !ok {
+			if len(d.mu.failures) >= maxAuthFailureEntries {
+				continue
+			}
+			f = &authFailures{}
+			d.mu.failures[key] = f
+		}
+		f.count++
+		f.lastFailure = now
+		if f.count > count {
+			count = f.count
+		}
+	}
+	if count == 0 {
+		// Too many entries: use the maximum delay.
+		return maxDelay
+	}
+
+	delay := initial
+	for i := 1; i < count && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+// recordSuccess resets the failures for the given user and client
+// address.
+func (d *authFailureDelayer) recordSuccess(user security.SQLUsername, remoteAddr net.Addr) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, key := range authFailureKeys(user, remoteAddr) {
+		delete(d.mu.failures, key)
+	}
+}
+
+// maybeGCLocked removes the entries which have not seen a failure for
+// longer than authFailureMemory.
+func (d *authFailureDelayer) maybeGCLocked(now time.Time) {
+	if now.Sub(d.mu.lastGC) < time.Minute {
+		return
+	}
+	for key, f := range d.mu.failures {
+		if now.Sub(f.lastFailure) >= authFailureMemory {
+			delete(d.mu.failures, key)
+		}
+	}
+	d.mu.lastGC = now
+}
+
+// delayFailure records a failed authentication attempt and waits for
+// the resulting delay, or until the context is canceled.
+func (d *authFailureDelayer) delayFailure(
+	ctx context.Context, user security.SQLUsername, remoteAddr net.Addr,
+) {
+	delay := d.recordFailure(user, remoteAddr)
+	if delay == 0 {
+		return
+	}
+	var t timeutil.Timer
+	defer t.Stop()
+	t.Reset(delay)
+	select {
+	case <-t.C:
+		t.Read = true
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthFailureDelayer(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	timeSource := timeutil.NewManualTime(timeutil.Unix(0, 0))
+	var d authFailureDelayer
+	d.init(&st.SV, timeSource)
+
+	carl := security.MakeSQLUsernameFromPreNormalizedString("carl")
+	alice := security.MakeSQLUsernameFromPreNormalizedString("alice")
+	client := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 26257}
+	other := &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 26257}
+
+	// The delay is disabled by default.
+	require.Zero(t, d.recordFailure(carl, client))
+
+	authFailureDelayInitial.Override(ctx, &st.SV, 100*time.Millisecond)
+	authFailureDelayMax.Override(ctx, &st.SV, time.Second)
+
+	// The delay doubles with every consecutive failure, up to the
+	// maximum.
+	for _, expected := range []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+		time.Second,
+	} {
+		require.Equal(t, expected, d.recordFailure(carl, client))
+	}
+
+	// Failures are counted per user as well as per client address.
+	require.Equal(t, time.Second, d.recordFailure(carl, other))
+	require.Equal(t, time.Second, d.recordFailure(alice, client))
+
+	// A successful authentication resets the failures of the user and
+	// of the client address.
+	d.recordSuccess(carl, client)
+	require.Equal(t, 100*time.Millisecond, d.recordFailure(carl, client))
+	require.Equal(t, 200*time.Millisecond, d.recordFailure(carl, other))
+	d.recordSuccess(carl, other)
+	d.recordSuccess(alice, client)
+
+	// Old failures are eventually forgotten.
+	require.Equal(t, 100*time.Millisecond, d.recordFailure(alice, other))
+	timeSource.Advance(authFailureMemory)
+	require.Equal(t, 100*time.Millisecond, d.recordFailure(carl, client))
+	d.mu.Lock()
+	require.Len(t, d.mu.failures, 2)
+	d.mu.Unlock()
+}
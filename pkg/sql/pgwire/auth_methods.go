@@ -29,6 +29,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/errors"
 	"github.com/xdg-go/scram"
 )
@@ -42,7 +43,8 @@ import (
 //
 // Other methods can be added using RegisterAuthMethod(). This is done
 // e.g. in the CCL modules to add support for GSS authentication using
-// Kerberos.
+// Kerberos. Methods which only need to verify a password-like
+// credential can use the simpler RegisterAuthProvider() instead.
 
 func loadDefaultMethods() {
 	// The "password" method requires a clear text password.
@@ -77,6 +79,14 @@ func loadDefaultMethods() {
 			requireClusterVersion(clusterversion.SCRAMAuthentication),
 			NoOptionsAllowed))
 
+	// The "cert-and-scram-sha-256" method requires both a valid client
+	// certificate and a valid 5-way SCRAM handshake, for deployments
+	// that require two authentication factors.
+	RegisterAuthMethod("cert-and-scram-sha-256", authCertAndScram, hba.ConnHostSSL,
+		chainOptions(
+			requireClusterVersion(clusterversion.SCRAMAuthentication),
+			NoOptionsAllowed))
+
 	// The "reject" method rejects any connection attempt that matches
 	// the current rule.
 	RegisterAuthMethod("reject", authReject, hba.ConnAny, NoOptionsAllowed)
@@ -84,6 +94,13 @@ func loadDefaultMethods() {
 	// The "trust" method accepts any connection attempt that matches
 	// the current rule.
 	RegisterAuthMethod("trust", authTrust, hba.ConnAny, NoOptionsAllowed)
+
+	// The "token" method requires a login token created with
+	// crdb_internal.create_login_token(), sent in place of a password.
+	//
+	// Like "password", this method should only be used over secure
+	// connections.
+	RegisterAuthProvider("token", AuthProviderFunc(authLoginToken), hba.ConnAny, NoOptionsAllowed)
 }
 
 // AuthMethod is a top-level factory for composing the various
@@ -102,6 +119,7 @@ var _ AuthMethod = authScram
 var _ AuthMethod = authCert
 var _ AuthMethod = authCertPassword
 var _ AuthMethod = authCertScram
+var _ AuthMethod = authCertAndScram
 var _ AuthMethod = authTrust
 var _ AuthMethod = authReject
 var _ AuthMethod = authSessionRevivalToken([]byte{})
@@ -124,12 +142,25 @@ func authPassword(
 		systemIdentity security.SQLUsername,
 		clientConnection bool,
 		pwRetrieveFn PasswordRetrievalFn,
+		_ security.DistinguishedName,
 	) error {
 		return passwordAuthenticator(ctx, systemIdentity, clientConnection, pwRetrieveFn, c, execCfg)
 	})
 	return b, nil
 }
 
+// authLoginToken is the AuthProvider for HBA method "token":
+// authenticate using a login token sent in place of a password.
+func authLoginToken(
+	ctx context.Context, systemIdentity security.SQLUsername, c AuthConn, creds AuthCredentials,
+) error {
+	if err := sql.VerifyLoginToken(ctx, creds.ExecCfg, systemIdentity, creds.Password); err != nil {
+		c.LogAuthInfof(ctx, "login token verification failed: %v", err)
+		return security.NewErrPasswordUserAuthFailed(systemIdentity)
+	}
+	return nil
+}
+
 var errExpiredPassword = errors.New("password is expired")
 
 // passwordAuthenticator is the authenticator function for the
@@ -192,9 +223,11 @@ func passwordAuthenticator(
 	}
 
 	// Now check the cleartext password against the retrieved credentials.
+	verifyCtx, sp := tracing.ChildSpan(ctx, "pgwire-verify-password")
 	err = security.UserAuthPasswordHook(
 		false /*insecure*/, password, hashedPassword,
-	)(ctx, systemIdentity, clientConnection)
+	)(verifyCtx, systemIdentity, clientConnection)
+	sp.Finish()
 
 	if err == nil {
 		// Password authentication succeeded using cleartext.  If the
@@ -242,6 +275,7 @@ func authScram(
 		systemIdentity security.SQLUsername,
 		clientConnection bool,
 		pwRetrieveFn PasswordRetrievalFn,
+		_ security.DistinguishedName,
 	) error {
 		return scramAuthenticator(ctx, systemIdentity, clientConnection, pwRetrieveFn, c, execCfg)
 	})
@@ -419,6 +453,7 @@ func authCert(
 		systemIdentity security.SQLUsername,
 		clientConnection bool,
 		pwRetrieveFn PasswordRetrievalFn,
+		roleSubject security.DistinguishedName,
 	) error {
 		if len(tlsState.PeerCertificates) == 0 {
 			return errors.New("no TLS peer certificates, but required for auth")
@@ -427,7 +462,7 @@ func authCert(
 		tlsState.PeerCertificates[0].Subject.CommonName = tree.Name(
 			tlsState.PeerCertificates[0].Subject.CommonName,
 		).Normalize()
-		hook, err := security.UserAuthCertHook(false /*insecure*/, &tlsState)
+		hook, err := security.UserAuthCertHook(false /*insecure*/, &tlsState, roleSubject)
 		if err != nil {
 			return err
 		}
@@ -505,6 +540,7 @@ func authAutoSelectPasswordProtocol(
 		systemIdentity security.SQLUsername,
 		clientConnection bool,
 		pwRetrieveFn PasswordRetrievalFn,
+		_ security.DistinguishedName,
 	) error {
 		// Request information about the password hash.
 		expired, hashedPassword, err := pwRetrieveFn(ctx)
@@ -558,6 +594,43 @@ func authCertScram(
 	return fn(ctx, c, tlsState, execCfg, entry, identMap)
 }
 
+// authCertAndScram is the AuthMethod constructor for HBA method
+// "cert-and-scram-sha-256": authenticate using BOTH a TLS client cert
+// AND a 5-way SCRAM handshake.
+//
+// The certificate is verified first, so that clients without a valid
+// certificate are rejected before the stored credentials are
+// retrieved.
+func authCertAndScram(
+	ctx context.Context,
+	c AuthConn,
+	tlsState tls.ConnectionState,
+	execCfg *sql.ExecutorConfig,
+	entry *hba.Entry,
+	identMap *identmap.Conf,
+) (*AuthBehaviors, error) {
+	certBehaviors, err := authCert(ctx, c, tlsState, execCfg, entry, identMap)
+	if err != nil {
+		return nil, err
+	}
+	b := &AuthBehaviors{}
+	b.SetRoleMapper(certBehaviors.MapRole)
+	b.SetAuthenticator(func(
+		ctx context.Context,
+		systemIdentity security.SQLUsername,
+		clientConnection bool,
+		pwRetrieveFn PasswordRetrievalFn,
+		roleSubject security.DistinguishedName,
+	) error {
+		if err := certBehaviors.Authenticate(ctx, systemIdentity, clientConnection, pwRetrieveFn, roleSubject); err != nil {
+			return err
+		}
+		c.LogAuthInfof(ctx, "client certificate verified, proceeding with SCRAM authentication")
+		return scramAuthenticator(ctx, systemIdentity, clientConnection, pwRetrieveFn, c, execCfg)
+	})
+	return b, nil
+}
+
 // authTrust is the AuthMethod constructor for HBA method "trust":
 // always allow the client, do not perform authentication.
 func authTrust(
@@ -570,7 +643,9 @@ func authTrust(
 ) (*AuthBehaviors, error) {
 	b := &AuthBehaviors{}
 	b.SetRoleMapper(UseProvidedIdentity)
-	b.SetAuthenticator(func(_ context.Context, _ security.SQLUsername, _ bool, _ PasswordRetrievalFn) error {
+	b.SetAuthenticator(func(
+		_ context.Context, _ security.SQLUsername, _ bool, _ PasswordRetrievalFn, _ security.DistinguishedName,
+	) error {
 		return nil
 	})
 	return b, nil
@@ -588,7 +663,9 @@ func authReject(
 ) (*AuthBehaviors, error) {
 	b := &AuthBehaviors{}
 	b.SetRoleMapper(UseProvidedIdentity)
-	b.SetAuthenticator(func(_ context.Context, _ security.SQLUsername, _ bool, _ PasswordRetrievalFn) error {
+	b.SetAuthenticator(func(
+		_ context.Context, _ security.SQLUsername, _ bool, _ PasswordRetrievalFn, _ security.DistinguishedName,
+	) error {
 		return errors.New("authentication rejected by configuration")
 	})
 	return b, nil
@@ -607,7 +684,9 @@ func authSessionRevivalToken(token []byte) AuthMethod {
 	) (*AuthBehaviors, error) {
 		b := &AuthBehaviors{}
 		b.SetRoleMapper(UseProvidedIdentity)
-		b.SetAuthenticator(func(ctx context.Context, user security.SQLUsername, _ bool, _ PasswordRetrievalFn) error {
+		b.SetAuthenticator(func(
+			ctx context.Context, user security.SQLUsername, _ bool, _ PasswordRetrievalFn, _ security.DistinguishedName,
+		) error {
 			c.LogAuthInfof(ctx, "session revival token detected; attempting to use it")
 			if !sql.AllowSessionRevival.Get(&execCfg.Settings.SV) || execCfg.Codec.ForSystemTenant() {
 				return errors.New("session revival tokens are not supported on this cluster")
@@ -0,0 +1,137 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"context"
+	"crypto/tls"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/hba"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/identmap"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+// AuthProvider is a simplified interface for authentication methods
+// which verify a credential sent by the client in place of a password,
+// for example using an external directory or identity service.
+//
+// Unlike an AuthMethod, an AuthProvider does not need to deal with the
+// pgwire authentication protocol or identity mapping: the method
+// registered by RegisterAuthProvider requests the credential from the
+// client as a cleartext password, maps the client-provided identity to
+// a database user using the identity map named by the "map" option of
+// the HBA entry, if any, then calls Authenticate.
+type AuthProvider interface {
+	// Authenticate returns nil if the credentials are valid for the
+	// given system identity, i.e. the user name provided by the client.
+	// The conn can be used for logging (LogAuthInfof, LogAuthFailed)
+	// but must not be used to exchange data with the client.
+	//
+	// When rejecting credentials, providers should return
+	// security.NewErrPasswordUserAuthFailed so as to not disclose the
+	// reason for the failure to the client.
+	Authenticate(ctx context.Context, systemIdentity security.SQLUsername, conn AuthConn, creds AuthCredentials) error
+}
+
+// AuthCredentials are the credentials passed to an AuthProvider, along
+// with the context of the authentication attempt.
+type AuthCredentials struct {
+	// Password is the cleartext password (or token) sent by the client.
+	Password string
+	// TLSState is the state of the client connection, if it uses TLS.
+	TLSState tls.ConnectionState
+	// Entry is the HBA entry which selected the provider. Its options
+	// can be used to configure the provider.
+	Entry *hba.Entry
+	// ExecCfg gives access to e.g. the cluster settings.
+	ExecCfg *sql.ExecutorConfig
+}
+
+// AuthProviderFunc is an adapter to use ordinary functions as
+// AuthProviders.
+type AuthProviderFunc func(
+	ctx context.Context, systemIdentity security.SQLUsername, conn AuthConn, creds AuthCredentials,
+) error
+
+var _ AuthProvider = AuthProviderFunc(nil)
+
+// Authenticate implements the AuthProvider interface.
+func (f AuthProviderFunc) Authenticate(
+	ctx context.Context, systemIdentity security.SQLUsername, conn AuthConn, creds AuthCredentials,
+) error {
+	return f(ctx, systemIdentity, conn, creds)
+}
+
+// RegisterAuthProvider registers an AuthProvider as the HBA
+// authentication method with the given name. See RegisterAuthMethod
+// for the meaning of validConnTypes and checkEntry; checkEntry must
+// accept the "map" option if identity mapping is to be supported.
+//
+// RegisterAuthProvider panics if a method with the same name is
+// already registered. It is meant to be called from init functions,
+// e.g. in the CCL modules or downstream forks.
+func RegisterAuthProvider(
+	method string, provider AuthProvider, validConnTypes hba.ConnType, checkEntry CheckHBAEntry,
+) {
+	if _, ok := hbaAuthMethods[method]; ok {
+		panic(errors.AssertionFailedf("authentication method %q is already registered", method))
+	}
+	RegisterAuthMethod(method, authProviderMethod(provider), validConnTypes, checkEntry)
+}
+
+// authProviderMethod returns the AuthMethod constructor for an HBA
+// method implemented by an AuthProvider: request a cleartext password
+// from the client and verify it using the provider.
+func authProviderMethod(provider AuthProvider) AuthMethod {
+	return func(
+		_ context.Context,
+		c AuthConn,
+		tlsState tls.ConnectionState,
+		execCfg *sql.ExecutorConfig,
+		entry *hba.Entry,
+		identMap *identmap.Conf,
+	) (*AuthBehaviors, error) {
+		b := &AuthBehaviors{}
+		if entry.GetOption("map") != "" {
+			b.SetRoleMapper(HbaMapper(entry, identMap))
+		} else {
+			b.SetRoleMapper(UseProvidedIdentity)
+		}
+		b.SetAuthenticator(func(
+			ctx context.Context, systemIdentity security.SQLUsername, _ bool, _ PasswordRetrievalFn,
+			_ security.DistinguishedName,
+		) error {
+			if err := c.SendAuthRequest(authCleartextPassword, nil /* data */); err != nil {
+				return err
+			}
+			pwdData, err := c.GetPwdData()
+			if err != nil {
+				c.LogAuthFailed(ctx, eventpb.AuthFailReason_PRE_HOOK_ERROR, err)
+				return err
+			}
+			password, err := passwordString(pwdData)
+			if err != nil {
+				c.LogAuthFailed(ctx, eventpb.AuthFailReason_PRE_HOOK_ERROR, err)
+				return err
+			}
+			return provider.Authenticate(ctx, systemIdentity, c, AuthCredentials{
+				Password: password,
+				TLSState: tlsState,
+				Entry:    entry,
+				ExecCfg:  execCfg,
+			})
+		})
+		return b, nil
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"context"
+	"crypto/tls"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/hba"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAuthConn is an AuthConn which answers authentication requests
+// with a fixed response.
+type fakeAuthConn struct {
+	pwdData  []byte
+	requests []int32
+}
+
+var _ AuthConn = (*fakeAuthConn)(nil)
+
+func (c *fakeAuthConn) SendAuthRequest(authType int32, _ []byte) error {
+	c.requests = append(c.requests, authType)
+	return nil
+}
+func (c *fakeAuthConn) GetPwdData() ([]byte, error)                                  { return c.pwdData, nil }
+func (c *fakeAuthConn) AuthOK(context.Context)                                       {}
+func (c *fakeAuthConn) AuthFail(error)                                               {}
+func (c *fakeAuthConn) SetAuthMethod(string)                                         {}
+func (c *fakeAuthConn) SetDbUser(security.SQLUsername)                               {}
+func (c *fakeAuthConn) SetSystemIdentity(security.SQLUsername)                       {}
+func (c *fakeAuthConn) SetAuthInfoCacheHit(bool)                                     {}
+func (c *fakeAuthConn) LogAuthInfof(context.Context, string, ...interface{})         {}
+func (c *fakeAuthConn) LogAuthFailed(context.Context, eventpb.AuthFailReason, error) {}
+func (c *fakeAuthConn) LogAuthOK(context.Context)                                    {}
+
+func TestAuthProvider(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	var got AuthCredentials
+	provider := AuthProviderFunc(func(
+		_ context.Context, systemIdentity security.SQLUsername, _ AuthConn, creds AuthCredentials,
+	) error {
+		got = creds
+		if systemIdentity.Normalized() != "carl" || creds.Password != "secret" {
+			return security.NewErrPasswordUserAuthFailed(systemIdentity)
+		}
+		return nil
+	})
+
+	const method = "test-provider"
+	RegisterAuthProvider(method, provider, hba.ConnAny, nil /* checkEntry */)
+	defer delete(hbaAuthMethods, method)
+	require.Panics(t, func() {
+		RegisterAuthProvider(method, provider, hba.ConnAny, nil /* checkEntry */)
+	})
+
+	authenticate := func(user string, pwdData string) error {
+		c := &fakeAuthConn{pwdData: []byte(pwdData)}
+		entry := &hba.Entry{Options: [][2]string{{"opt", "val"}}}
+		b, err := hbaAuthMethods[method].fn(ctx, c, tls.ConnectionState{}, nil /* execCfg */, entry, nil /* identMap */)
+		require.NoError(t, err)
+		systemIdentity := security.MakeSQLUsernameFromPreNormalizedString(user)
+		users, err := b.MapRole(ctx, systemIdentity)
+		require.NoError(t, err)
+		require.Equal(t, []security.SQLUsername{systemIdentity}, users)
+		err = b.Authenticate(ctx, systemIdentity, true /* clientConnection */, nil /* pwRetrieveFn */, nil /* roleSubject */)
+		require.Equal(t, []int32{authCleartextPassword}, c.requests)
+		return err
+	}
+
+	require.NoError(t, authenticate("carl", "secret\x00"))
+	require.Equal(t, "secret", got.Password)
+	require.Equal(t, "val", got.Entry.GetOption("opt"))
+
+	require.Regexp(t, "password authentication failed", authenticate("carl", "wrong\x00"))
+	require.Regexp(t, "password authentication failed", authenticate("alice", "secret\x00"))
+
+	// Malformed responses are rejected before reaching the provider.
+	got = AuthCredentials{}
+	require.Error(t, authenticate("carl", "secret"))
+	require.Equal(t, AuthCredentials{}, got)
+}
@@ -0,0 +1,198 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"net"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/quotapool"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// authRateLimitRate is the cluster setting that holds the rate at which
+// authentication attempts are allowed from a single client IP address.
+var authRateLimitRate = settings.RegisterFloatSetting(
+	settings.TenantWritable,
+	"server.authentication_rate_limit.rate",
+	"the number of authentication attempts per second allowed from a single "+
+		"client IP address (0 disables the rate limit)",
+	0,
+	settings.NonNegativeFloat,
+)
+
+// authRateLimitBurst is the cluster setting that holds the number of
+// authentication attempts allowed in a burst from a single client IP
+// address.
+var authRateLimitBurst = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"server.authentication_rate_limit.burst",
+	"the number of authentication attempts allowed in a burst from a single "+
+		"client IP address, when server.authentication_rate_limit.rate is set",
+	10,
+	settings.PositiveInt,
+)
+
+// authRateLimitExemptCIDRs is the cluster setting that holds the client
+// address ranges which are not subject to the rate limit.
+var authRateLimitExemptCIDRs = settings.RegisterValidatedStringSetting(
+	settings.TenantWritable,
+	"server.authentication_rate_limit.exempt_cidrs",
+	"comma-separated list of client address ranges, in CIDR notation, which are "+
+		"not subject to the authentication rate limit",
+	"",
+	func(_ *settings.Values, s string) error {
+		_, err := parseCIDRList(s)
+		return err
+	},
+)
+
+// parseCIDRList parses a comma-separated list of address ranges.
+func parseCIDRList(s string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(part)
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// authRateLimiter limits the rate of authentication attempts per client
+// IP address, so that a client trying many credentials cannot saturate
+// the authentication path (and the system tables it reads) at the
+// expense of other clients.
+//
+// Each client IP address is given a token bucket; an attempt consumes
+// one token. Buckets which have been idle for long enough to be full
+// again are forgotten, so that the memory usage is proportional to the
+// number of clients seen recently.
+type authRateLimiter struct {
+	sv         *settings.Values
+	timeSource timeutil.TimeSource
+
+	mu struct {
+		syncutil.Mutex
+		buckets map[string]*authRateLimitBucket
+		// lastGC is the last time idle buckets were removed.
+		lastGC time.Time
+		// exemptCIDRs caches the parsed value of the
+		// server.authentication_rate_limit.exempt_cidrs setting.
+		exemptCIDRs      []*net.IPNet
+		exemptCIDRsValue string
+	}
+}
+
+type authRateLimitBucket struct {
+	quotapool.TokenBucket
+	rate     quotapool.TokensPerSecond
+	burst    quotapool.Tokens
+	lastUsed time.Time
+}
+
+func (l *authRateLimiter) init(sv *settings.Values, timeSource timeutil.TimeSource) {
+	l.sv = sv
+	l.timeSource = timeSource
+	l.mu.buckets = make(map[string]*authRateLimitBucket)
+	l.mu.lastGC = timeSource.Now()
+}
+
+// allow consumes one token from the bucket of the client address and
+// returns an error if none was available. Clients connecting over a
+// unix socket or from an exempt address are never limited.
+func (l *authRateLimiter) allow(remoteAddr net.Addr) error {
+	rate := quotapool.TokensPerSecond(authRateLimitRate.Get(l.sv))
+	if rate == 0 {
+		return nil
+	}
+	tcpAddr, ok := remoteAddr.(*net.TCPAddr)
+	if !ok {
+		return nil
+	}
+	burst := quotapool.Tokens(authRateLimitBurst.Get(l.sv))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.isExemptLocked(tcpAddr.IP) {
+		return nil
+	}
+	now := l.timeSource.Now()
+	l.maybeGCLocked(now, rate, burst)
+
+	key := tcpAddr.IP.String()
+	b, ok := l.mu.buckets[key]
+	if !ok {
+		b = &authRateLimitBucket{}
+		l.mu.buckets[key] = b
+	}
+	if !ok || b.rate != rate || b.burst != burst {
+		b.Init(rate, burst, l.timeSource)
+		b.rate, b.burst = rate, burst
+	}
+	b.lastUsed = now
+	if fulfilled, tryAgainAfter := b.TryToFulfill(1); !fulfilled {
+		return errors.WithHintf(
+			pgerror.New(pgcode.TooManyConnections, "too many authentication attempts"),
+			"try again in %s",
+			tryAgainAfter.Round(time.Millisecond),
+		)
+	}
+	return nil
+}
+
+// isExemptLocked returns whether the address belongs to one of the
+// exempt address ranges.
+func (l *authRateLimiter) isExemptLocked(ip net.IP) bool {
+	if v := authRateLimitExemptCIDRs.Get(l.sv); v != l.mu.exemptCIDRsValue {
+		// The setting was validated, so the error can be ignored.
+		l.mu.exemptCIDRs, _ = parseCIDRList(v)
+		l.mu.exemptCIDRsValue = v
+	}
+	for _, ipNet := range l.mu.exemptCIDRs {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// maybeGCLocked removes the buckets which have been idle for long
+// enough to be full again. Since a full bucket is equivalent to a new
+// one, this does not change the limits applied to the clients.
+func (l *authRateLimiter) maybeGCLocked(
+	now time.Time, rate quotapool.TokensPerSecond, burst quotapool.Tokens,
+) {
+	refill := time.Duration(float64(burst) / float64(rate) * float64(time.Second))
+	if refill < time.Second {
+		refill = time.Second
+	}
+	if now.Sub(l.mu.lastGC) < refill {
+		return
+	}
+	for key, b := range l.mu.buckets {
+		if now.Sub(b.lastUsed) >= refill {
+			delete(l.mu.buckets, key)
+		}
+	}
+	l.mu.lastGC = now
+}
@@ -0,0 +1,93 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthRateLimiter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	timeSource := timeutil.NewManualTime(timeutil.Unix(0, 0))
+	var l authRateLimiter
+	l.init(&st.SV, timeSource)
+
+	client := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 26257}
+	other := &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 26257}
+	exempt := &net.TCPAddr{IP: net.ParseIP("192.168.1.1"), Port: 26257}
+	local := &net.UnixAddr{Name: "/tmp/.s.PGSQL.26257", Net: "unix"}
+
+	// The rate limit is disabled by default.
+	for i := 0; i < 100; i++ {
+		require.NoError(t, l.allow(client))
+	}
+
+	authRateLimitRate.Override(ctx, &st.SV, 1)
+	authRateLimitBurst.Override(ctx, &st.SV, 3)
+	authRateLimitExemptCIDRs.Override(ctx, &st.SV, "192.168.0.0/16, ::1/128")
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, l.allow(client))
+	}
+	err := l.allow(client)
+	require.Error(t, err)
+	require.Equal(t, pgcode.TooManyConnections, pgerror.GetPGCode(err))
+
+	// Other clients are not affected.
+	require.NoError(t, l.allow(other))
+	// Exempt and local clients are never limited.
+	for i := 0; i < 10; i++ {
+		require.NoError(t, l.allow(exempt))
+		require.NoError(t, l.allow(local))
+	}
+
+	// Tokens are replenished over time.
+	timeSource.Advance(time.Second)
+	require.NoError(t, l.allow(client))
+	require.Error(t, l.allow(client))
+
+	// Idle buckets are eventually forgotten.
+	timeSource.Advance(time.Minute)
+	require.NoError(t, l.allow(client))
+	l.mu.Lock()
+	require.Len(t, l.mu.buckets, 1)
+	l.mu.Unlock()
+}
+
+func TestParseCIDRList(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	nets, err := parseCIDRList("")
+	require.NoError(t, err)
+	require.Empty(t, nets)
+
+	nets, err = parseCIDRList("10.0.0.0/8, fe80::/10,")
+	require.NoError(t, err)
+	require.Len(t, nets, 2)
+
+	_, err = parseCIDRList("10.0.0.1")
+	require.Error(t, err)
+}
@@ -0,0 +1,96 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+)
+
+// Users with the MFA role option must present a TOTP code once their
+// primary credentials have been verified. When the authentication method
+// requests a cleartext password, the code must be appended to the
+// password. Otherwise, e.g. with SCRAM or client certificates, the code
+// is requested with an additional cleartext password exchange.
+
+// totpAuthConn is the AuthConn passed to the authentication methods. It
+// extracts the TOTP code appended to the cleartext password of the users
+// with the MFA role option.
+type totpAuthConn struct {
+	AuthConn
+
+	// extractCode is set while the primary credentials of a user with the
+	// MFA role option are verified.
+	extractCode bool
+	// lastRequest is the type of the last authentication request sent to
+	// the client.
+	lastRequest int32
+	// code is the TOTP code extracted from the cleartext password, if any.
+	code string
+}
+
+// SendAuthRequest is part of the AuthConn interface.
+func (c *totpAuthConn) SendAuthRequest(authType int32, data []byte) error {
+	c.lastRequest = authType
+	return c.AuthConn.SendAuthRequest(authType, data)
+}
+
+// GetPwdData is part of the AuthConn interface.
+func (c *totpAuthConn) GetPwdData() ([]byte, error) {
+	data, err := c.AuthConn.GetPwdData()
+	if err != nil || !c.extractCode || c.lastRequest != authCleartextPassword || c.code != "" {
+		return data, err
+	}
+	passwordAndCode, err := passwordString(data)
+	if err != nil {
+		// Let the authentication method report the error.
+		return data, nil //nolint:returnerrcheck
+	}
+	password, code, ok := security.SplitTOTPCode(passwordAndCode)
+	if !ok {
+		return data, nil
+	}
+	c.code = code
+	return append([]byte(password), 0), nil
+}
+
+// authenticateTOTP verifies the TOTP code of a user with the MFA role
+// option, once its primary credentials have been verified.
+func (c *conn) authenticateTOTP(
+	ctx context.Context,
+	ac *totpAuthConn,
+	execCfg *sql.ExecutorConfig,
+	ie *sql.InternalExecutor,
+	user security.SQLUsername,
+) error {
+	ac.extractCode = false
+	code := ac.code
+	if code == "" {
+		// The code was not appended to a cleartext password; request it
+		// separately.
+		if err := ac.SendAuthRequest(authCleartextPassword, nil /* data */); err != nil {
+			return err
+		}
+		data, err := ac.GetPwdData()
+		if err != nil {
+			return err
+		}
+		if code, err = passwordString(data); err != nil {
+			return err
+		}
+	}
+	verifyCtx, sp := tracing.ChildSpan(ctx, "pgwire-verify-totp")
+	defer sp.Finish()
+	return sql.VerifyTOTPCode(verifyCtx, execCfg, ie, user, code)
+}
@@ -0,0 +1,184 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/httputil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// Once a SQL client has been authenticated, an external authorization
+// service, e.g. a policy engine such as Open Policy Agent, can be
+// consulted to decide whether the session is allowed. The service is
+// configured with the server.user_login.authorization_webhook.url
+// cluster setting. It receives a POST request with a JSON-encoded
+// authzWebhookRequest, and must answer with a JSON-encoded
+// authzWebhookResponse.
+//
+// The root and node users are not subject to the webhook, so that the
+// cluster can still be administered when the service is unavailable.
+
+// authzWebhookURL is the cluster setting that holds the URL of the
+// authorization webhook.
+var authzWebhookURL = settings.RegisterValidatedStringSetting(
+	settings.TenantWritable,
+	"server.user_login.authorization_webhook.url",
+	"if set, the http(s) URL of a service consulted once SQL clients are authenticated, "+
+		"which decides whether the session is allowed and can provide session defaults",
+	"",
+	func(_ *settings.Values, s string) error {
+		if s == "" {
+			return nil
+		}
+		u, err := url.Parse(s)
+		if err != nil {
+			return err
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.Newf("the authorization webhook must be an http or https URL, found %q", s)
+		}
+		return nil
+	},
+)
+
+// authzWebhookTimeout is the cluster setting that holds the timeout of
+// the requests to the authorization webhook.
+var authzWebhookTimeout = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"server.user_login.authorization_webhook.timeout",
+	"the maximum duration of a request to the authorization webhook",
+	5*time.Second,
+	settings.PositiveDuration,
+)
+
+// authzWebhookFailOpen is the cluster setting that controls whether the
+// sessions are allowed when the authorization webhook is unavailable.
+var authzWebhookFailOpen = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"server.user_login.authorization_webhook.fail_open.enabled",
+	"if set, sessions are allowed when the authorization webhook cannot be reached "+
+		"or returns an invalid response; otherwise, they are denied",
+	false,
+)
+
+// authzWebhookMaxResponseSize bounds the size of the responses read from
+// the authorization webhook.
+const authzWebhookMaxResponseSize = 1 << 20
+
+// authzWebhookRequest is the request sent to the authorization webhook.
+type authzWebhookRequest struct {
+	// User is the database user of the session.
+	User string `json:"user"`
+	// SystemIdentity is the identity presented by the client, before
+	// identity mapping.
+	SystemIdentity string `json:"system_identity"`
+	// Database is the database requested by the client, if any.
+	Database string `json:"database"`
+	// ApplicationName is the application name provided by the client, if
+	// any.
+	ApplicationName string `json:"application_name"`
+	// ClientAddress is the network address of the client.
+	ClientAddress string `json:"client_address"`
+	// ConnectionType is the HBA connection type, e.g. hostssl.
+	ConnectionType string `json:"connection_type"`
+	// AuthMethod is the HBA method used to authenticate the client.
+	AuthMethod string `json:"auth_method"`
+}
+
+// authzWebhookResponse is the response of the authorization webhook.
+type authzWebhookResponse struct {
+	// Allow is true if the session is allowed.
+	Allow bool `json:"allow"`
+	// Reason is reported to the client when the session is denied.
+	Reason string `json:"reason"`
+	// SessionDefaults are default values for session variables. They
+	// take precedence over the values provided by the client and the
+	// defaults of the role, except those set with LOCKED.
+	SessionDefaults map[string]string `json:"session_defaults"`
+}
+
+// checkAuthzWebhook consults the authorization webhook, if one is
+// configured. It returns an error if the session is denied, and
+// otherwise the session defaults provided by the webhook, if any.
+func checkAuthzWebhook(
+	ctx context.Context, st *cluster.Settings, req authzWebhookRequest,
+) (map[string]string, error) {
+	webhookURL := authzWebhookURL.Get(&st.SV)
+	if webhookURL == "" {
+		return nil, nil
+	}
+	resp, err := callAuthzWebhook(ctx, webhookURL, authzWebhookTimeout.Get(&st.SV), req)
+	if err != nil {
+		if authzWebhookFailOpen.Get(&st.SV) {
+			log.Ops.Warningf(ctx, "authorization webhook failed, allowing the session of %s: %v",
+				req.User, err)
+			return nil, nil
+		}
+		log.Ops.Warningf(ctx, "authorization webhook failed, denying the session of %s: %v",
+			req.User, err)
+		return nil, pgerror.New(pgcode.InvalidAuthorizationSpecification,
+			"unable to authorize the session: the authorization webhook is unavailable")
+	}
+	if !resp.Allow {
+		if resp.Reason == "" {
+			return nil, pgerror.New(pgcode.InvalidAuthorizationSpecification,
+				"session denied by the authorization webhook")
+		}
+		return nil, pgerror.Newf(pgcode.InvalidAuthorizationSpecification,
+			"session denied by the authorization webhook: %s", resp.Reason)
+	}
+	return resp.SessionDefaults, nil
+}
+
+// callAuthzWebhook sends a request to the authorization webhook.
+func callAuthzWebhook(
+	ctx context.Context, webhookURL string, timeout time.Duration, req authzWebhookRequest,
+) (*authzWebhookResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	httpResp, err := httputil.NewClientWithTimeout(timeout).Post(
+		ctx, webhookURL, "application/json", bytes.NewReader(body),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, errors.Newf("unexpected status: %s", httpResp.Status)
+	}
+	respBody, err := ioutil.ReadAll(io.LimitReader(httpResp.Body, authzWebhookMaxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	var resp authzWebhookResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, errors.Wrap(err, "decoding the response")
+	}
+	return &resp, nil
+}
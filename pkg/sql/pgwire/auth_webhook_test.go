@@ -0,0 +1,107 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthzWebhook(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+
+	// The webhook allows alice, with a default statement timeout, and
+	// denies everybody else. It fails for mallory.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req authzWebhookRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var resp authzWebhookResponse
+		switch req.User {
+		case "alice":
+			resp.Allow = true
+			resp.SessionDefaults = map[string]string{"statement_timeout": "10s"}
+		case "mallory":
+			http.Error(w, "oops", http.StatusInternalServerError)
+			return
+		default:
+			resp.Reason = "database " + req.Database + " is off limits"
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	req := func(user string) authzWebhookRequest {
+		return authzWebhookRequest{User: user, Database: "defaultdb", ConnectionType: "hostssl"}
+	}
+
+	// The webhook is disabled by default.
+	defaults, err := checkAuthzWebhook(ctx, st, req("bob"))
+	require.NoError(t, err)
+	require.Nil(t, defaults)
+
+	authzWebhookURL.Override(ctx, &st.SV, srv.URL)
+
+	defaults, err = checkAuthzWebhook(ctx, st, req("alice"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"statement_timeout": "10s"}, defaults)
+
+	_, err = checkAuthzWebhook(ctx, st, req("bob"))
+	require.Error(t, err)
+	require.Equal(t, pgcode.InvalidAuthorizationSpecification, pgerror.GetPGCode(err))
+	require.Contains(t, err.Error(), "session denied by the authorization webhook: database defaultdb is off limits")
+
+	// The sessions are denied when the webhook fails, unless it is
+	// configured to fail open.
+	_, err = checkAuthzWebhook(ctx, st, req("mallory"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "the authorization webhook is unavailable")
+	authzWebhookFailOpen.Override(ctx, &st.SV, true)
+	_, err = checkAuthzWebhook(ctx, st, req("mallory"))
+	require.NoError(t, err)
+	// The webhook still denies sessions when failing open.
+	_, err = checkAuthzWebhook(ctx, st, req("bob"))
+	require.Error(t, err)
+	authzWebhookFailOpen.Override(ctx, &st.SV, false)
+
+	// The requests time out.
+	block := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-block:
+		case <-r.Context().Done():
+		}
+	}))
+	defer slow.Close()
+	defer close(block)
+	authzWebhookURL.Override(ctx, &st.SV, slow.URL)
+	authzWebhookTimeout.Override(ctx, &st.SV, 10*time.Millisecond)
+	_, err = checkAuthzWebhook(ctx, st, req("alice"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "the authorization webhook is unavailable")
+}
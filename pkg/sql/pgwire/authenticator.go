@@ -18,12 +18,14 @@ import (
 
 // Authenticator is a component of an AuthMethod that determines if the
 // given system identity (e.g.: Kerberos or X.509 principal, plain-old
-// username, etc) is who it claims to be.
+// username, etc) is who it claims to be. The roleSubject, if not nil, is
+// the subject that the client certificates of the role must have.
 type Authenticator = func(
 	ctx context.Context,
 	systemIdentity security.SQLUsername,
 	clientConnection bool,
 	pwRetrieveFn PasswordRetrievalFn,
+	roleSubject security.DistinguishedName,
 ) error
 
 // PasswordRetrievalFn defines a method to retrieve a hashed password
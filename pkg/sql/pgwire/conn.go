@@ -109,6 +109,14 @@ type conn struct {
 	// alwaysLogAuthActivity is used force-enables logging of authn events.
 	alwaysLogAuthActivity bool
 
+	// roleConnectionLimit is the CONNECTION LIMIT role option of the
+	// authenticated user, or -1 if the user has none.
+	roleConnectionLimit int32
+
+	// startupNotices are sent to the client once the session is set up,
+	// before the client is ready to issue queries.
+	startupNotices []pgnotice.Notice
+
 	// afterReadMsgTestingKnob is called after reading every message.
 	afterReadMsgTestingKnob func(context.Context) error
 }
@@ -195,6 +203,8 @@ func newConn(
 		rd:          *bufio.NewReader(netConn),
 		sv:          sv,
 		readBuf:     pgwirebase.MakeReadBuffer(pgwirebase.ReadBufferOptionWithClusterSettings(sv)),
+
+		roleConnectionLimit: -1,
 	}
 	c.stmtBuf.Init()
 	c.res.released = true
@@ -250,6 +260,41 @@ func (c *conn) checkMaxConnections(ctx context.Context, sqlServer *sql.Server) e
 	return nil
 }
 
+// checkUserConnectionLimit returns an error if the user already has as
+// many sessions open across the cluster as allowed by the CONNECTION
+// LIMIT role option or by the server.max_connections_per_user cluster
+// setting; the lowest of the two limits applies. As in PostgreSQL,
+// superusers are not subject to these limits.
+//
+// The sessions are counted using the session registries of all the
+// nodes, so this is only done for users which have a limit. Since the
+// count is not synchronized with concurrent connection attempts, the
+// limit may be exceeded briefly when many connections are opened at
+// the same time. The sessions which cannot be counted are ignored.
+func (c *conn) checkUserConnectionLimit(ctx context.Context, sqlServer *sql.Server) error {
+	if c.sessionArgs.IsSuperuser {
+		return nil
+	}
+	execCfg := sqlServer.GetExecutorConfig()
+	limit := c.roleConnectionLimit
+	hint := "the role has a connection limit of %d, which can be modified using ALTER ROLE ... CONNECTION LIMIT"
+	if l, ok := lookupUserConnectionLimit(&execCfg.Settings.SV, c.sessionArgs.User); ok && (limit < 0 || l < limit) {
+		limit = l
+		hint = "the role has a connection limit of %d, which is configured by " + userConnectionLimits.Key()
+	}
+	if limit < 0 || execCfg.SQLStatusServer == nil {
+		return nil
+	}
+	count := countUserSessions(ctx, execCfg.SQLStatusServer, c.sessionArgs.User)
+	if count >= limit {
+		return c.sendError(ctx, execCfg, errors.WithHintf(
+			pgerror.Newf(pgcode.TooManyConnections, "too many connections for role %q", c.sessionArgs.User),
+			hint, limit,
+		))
+	}
+	return nil
+}
+
 func (c *conn) authLogEnabled() bool {
 	return c.alwaysLogAuthActivity || logSessionAuth.Get(c.sv)
 }
@@ -690,10 +735,14 @@ func (c *conn) processCommandsAsync(
 			retCh <- retErr
 		}()
 
-		// Authenticate the connection.
-		if connCloseAuthHandler, retErr = c.handleAuthentication(
-			ctx, ac, authOpt, sqlServer.GetExecutorConfig(),
-		); retErr != nil {
+		// Authenticate the connection. The login sequence is recorded, if
+		// enabled, so that the session can include it in its traces.
+		loginCtx, loginSpan := startLoginTrace(ctx, sqlServer.GetExecutorConfig())
+		connCloseAuthHandler, retErr = c.handleAuthentication(
+			loginCtx, ac, authOpt, sqlServer.GetExecutorConfig(),
+		)
+		c.sessionArgs.LoginTrace = finishLoginTrace(loginSpan)
+		if retErr != nil {
 			// Auth failed or some other error.
 			return
 		}
@@ -703,6 +752,10 @@ func (c *conn) processCommandsAsync(
 		}
 		defer sqlServer.DecrementConnectionCount()
 
+		if retErr = c.checkUserConnectionLimit(ctx, sqlServer); retErr != nil {
+			return
+		}
+
 		if retErr = c.authOKMessage(); retErr != nil {
 			return
 		}
@@ -745,6 +798,13 @@ func (c *conn) bufferNotice(ctx context.Context, noticeErr pgnotice.Notice) erro
 	return writeErrFields(ctx, c.sv, noticeErr, &c.msgBuilder, &c.writerState.buf)
 }
 
+// sendNotice sends a notice to the client directly, outside of the
+// execution of a statement.
+func (c *conn) sendNotice(ctx context.Context, notice pgnotice.Notice) error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgNoticeResponse)
+	return writeErrFields(ctx, c.sv, notice, &c.msgBuilder, c.conn)
+}
+
 func (c *conn) sendInitialConnData(
 	ctx context.Context, sqlServer *sql.Server, onDefaultIntSizeChange func(newSize int32),
 ) (sql.ConnectionHandler, error) {
@@ -779,6 +839,11 @@ func (c *conn) sendInitialConnData(
 	if err := c.sendParamStatus("session_authorization", c.sessionArgs.User.Normalized()); err != nil {
 		return sql.ConnectionHandler{}, err
 	}
+	for _, notice := range c.startupNotices {
+		if err := c.sendNotice(ctx, notice); err != nil {
+			return sql.ConnectionHandler{}, err
+		}
+	}
 
 	if err := c.sendReadyForQuery(connHandler.GetQueryCancelKey()); err != nil {
 		return sql.ConnectionHandler{}, err
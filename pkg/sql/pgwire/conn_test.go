@@ -15,11 +15,16 @@ import (
 	"context"
 	gosql "database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -29,6 +34,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/base"
 	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
@@ -39,6 +45,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
 	"github.com/cockroachdb/cockroach/pkg/sql/tests"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
@@ -1643,12 +1650,13 @@ func TestRoleDefaultSettings(t *testing.T) {
 	defer cleanupFunc()
 
 	for i, tc := range []struct {
-		setupStmt             string
-		postConnectStmt       string
-		databaseOverride      string
-		searchPathOptOverride string
-		userOverride          string
-		expectedSearchPath    string
+		setupStmt               string
+		postConnectStmt         string
+		databaseOverride        string
+		searchPathOptOverride   string
+		userOverride            string
+		applicationNameOverride string
+		expectedSearchPath      string
 	}{
 		// The test cases need to be in order since the default settings have
 		// an order of precedence that is being checked here.
@@ -1749,6 +1757,90 @@ func TestRoleDefaultSettings(t *testing.T) {
 			setupStmt:          "ALTER ROLE ALL IN DATABASE defaultdb RESET ALL",
 			expectedSearchPath: `"$user", public`,
 		},
+		{
+			// The defaults of the roles the user is a member of are inherited.
+			setupStmt:          "CREATE ROLE testgroup; GRANT testgroup TO testuser; ALTER ROLE testgroup SET search_path = 'h'",
+			expectedSearchPath: "h",
+		},
+		{
+			setupStmt:          "ALTER ROLE testgroup IN DATABASE defaultdb SET search_path = 'i'",
+			expectedSearchPath: "i",
+		},
+		{
+			// The defaults of the user take precedence over the inherited ones.
+			setupStmt:          "ALTER ROLE testuser SET search_path = 'j'",
+			expectedSearchPath: "j",
+		},
+		{
+			// The inherited defaults take precedence over the defaults of all
+			// the roles.
+			setupStmt:          "ALTER ROLE testuser RESET search_path; ALTER ROLE ALL SET search_path = 'k'",
+			expectedSearchPath: "i",
+		},
+		{
+			setupStmt:          "REVOKE testgroup FROM testuser",
+			expectedSearchPath: "k",
+		},
+		{
+			// The defaults scoped to the applications only apply to the
+			// sessions of the matching applications.
+			setupStmt:               "ALTER ROLE testuser FOR APPLICATION 'batch-%' SET search_path = 'l'",
+			applicationNameOverride: "batch-nightly",
+			expectedSearchPath:      "l",
+		},
+		{
+			applicationNameOverride: "oltp",
+			expectedSearchPath:      "k",
+		},
+		{
+			expectedSearchPath: "k",
+		},
+		{
+			// The defaults of the user scoped to the application take
+			// precedence over the ones of all the roles.
+			setupStmt:               "ALTER ROLE ALL IN DATABASE defaultdb FOR APPLICATION 'batch-nightly' SET search_path = 'm'",
+			applicationNameOverride: "batch-nightly",
+			expectedSearchPath:      "l",
+		},
+		{
+			setupStmt:               "ALTER ROLE testuser FOR APPLICATION 'batch-%' RESET ALL",
+			applicationNameOverride: "batch-nightly",
+			expectedSearchPath:      "m",
+		},
+		{
+			// If several patterns match, the longest takes precedence.
+			setupStmt:               "ALTER ROLE ALL IN DATABASE defaultdb FOR APPLICATION 'batch-%' SET search_path = 'n'",
+			applicationNameOverride: "batch-nightly",
+			expectedSearchPath:      "m",
+		},
+		{
+			applicationNameOverride: "batch-hourly",
+			expectedSearchPath:      "n",
+		},
+		{
+			// The defaults scoped to the networks only apply to the sessions
+			// of the clients which connect from these networks.
+			setupStmt:               "ALTER ROLE ALL IN DATABASE defaultdb FROM NETWORK '10.0.0.0/8' SET search_path = 'o'",
+			applicationNameOverride: "batch-hourly",
+			expectedSearchPath:      "n",
+		},
+		{
+			setupStmt:               "ALTER ROLE ALL IN DATABASE defaultdb FROM NETWORK '127.0.0.0/8' SET search_path = 'p'",
+			applicationNameOverride: "batch-hourly",
+			expectedSearchPath:      "n",
+		},
+		{
+			expectedSearchPath: "p",
+		},
+		{
+			// If several networks match, the most specific takes precedence.
+			setupStmt:          "ALTER ROLE ALL IN DATABASE defaultdb FROM NETWORK '127.0.0.1/32' SET search_path = 'q'",
+			expectedSearchPath: "q",
+		},
+		{
+			setupStmt:          "ALTER ROLE ALL IN DATABASE defaultdb FROM NETWORK '127.0.0.1/32' RESET ALL",
+			expectedSearchPath: "p",
+		},
 	} {
 		t.Run(fmt.Sprintf("TestRoleDefaultSettings-%d", i), func(t *testing.T) {
 			_, err := db.ExecContext(ctx, tc.setupStmt)
@@ -1768,6 +1860,11 @@ func TestRoleDefaultSettings(t *testing.T) {
 				q.Add("search_path", tc.searchPathOptOverride)
 				pgURLCopy.RawQuery = q.Encode()
 			}
+			if tc.applicationNameOverride != "" {
+				q := pgURLCopy.Query()
+				q.Add("application_name", tc.applicationNameOverride)
+				pgURLCopy.RawQuery = q.Encode()
+			}
 
 			thisDB, err := gosql.Open("postgres", pgURLCopy.String())
 			require.NoError(t, err)
@@ -1932,3 +2029,1130 @@ func TestPGWireRejectsNewConnIfTooManyConns(t *testing.T) {
 		require.Equal(t, 0, getConnectionCount())
 	})
 }
+
+func TestPGWireRejectsNewConnIfRoleConnectionLimitReached(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	testServer, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer testServer.Stopper().Stop(ctx)
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	user := security.TestUser
+	sqlDB.Exec(t, fmt.Sprintf("CREATE USER %[1]s WITH PASSWORD '%[1]s' CONNECTION LIMIT 1", user))
+
+	openConn := func() (*pgx.Conn, error) {
+		pgURL, cleanup := sqlutils.PGUrlWithOptionalClientCerts(
+			t, testServer.ServingSQLAddr(), t.Name(), url.UserPassword(user, user), false, /* withClientCerts */
+		)
+		defer cleanup()
+		return pgx.Connect(ctx, pgURL.String())
+	}
+
+	conn, err := openConn()
+	require.NoError(t, err)
+
+	// The session of the first connection is registered asynchronously.
+	testutils.SucceedsSoon(t, func() error {
+		conn2, err := openConn()
+		if err == nil {
+			_ = conn2.Close(ctx)
+			return errors.New("expected the connection limit to be enforced")
+		}
+		var pgErr *pgconn.PgError
+		require.ErrorAs(t, err, &pgErr)
+		require.Equal(t, pgcode.TooManyConnections.String(), pgErr.Code)
+		require.Contains(t, pgErr.Message, "too many connections for role")
+		return nil
+	})
+
+	// Once the first connection is closed, a new one can be opened.
+	require.NoError(t, conn.Close(ctx))
+	testutils.SucceedsSoon(t, func() error {
+		conn, err = openConn()
+		return err
+	})
+
+	// A negative limit lifts the restriction.
+	sqlDB.Exec(t, fmt.Sprintf("ALTER USER %s CONNECTION LIMIT -1", user))
+	conn2, err := openConn()
+	require.NoError(t, err)
+	require.NoError(t, conn2.Close(ctx))
+
+	// Limits can also be configured for all the users matching a pattern.
+	sqlDB.Exec(t, "SET CLUSTER SETTING server.max_connections_per_user = 'test*=1'")
+	testutils.SucceedsSoon(t, func() error {
+		conn2, err := openConn()
+		if err == nil {
+			_ = conn2.Close(ctx)
+			return errors.New("expected the connection limit to be enforced")
+		}
+		require.Regexp(t, "too many connections for role", err)
+		return nil
+	})
+	require.NoError(t, conn.Close(ctx))
+}
+
+func TestFailedLoginAttemptsAreRecorded(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	testServer, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer testServer.Stopper().Stop(ctx)
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, "CREATE USER carl WITH PASSWORD 'secret'")
+
+	pgURL, cleanup := sqlutils.PGUrlWithOptionalClientCerts(
+		t, testServer.ServingSQLAddr(), t.Name(), url.UserPassword("carl", "wrong"), false, /* withClientCerts */
+	)
+	defer cleanup()
+	_, err := pgx.Connect(ctx, pgURL.String())
+	require.Regexp(t, "password authentication failed", err)
+
+	sqlDB.CheckQueryResults(t,
+		`SELECT username, method, reason FROM crdb_internal.node_failed_login_attempts`,
+		[][]string{{"carl", "cert-password", "CREDENTIALS_INVALID"}},
+	)
+}
+
+func TestAuthAttemptsAreRecorded(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	testServer, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer testServer.Stopper().Stop(ctx)
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, "CREATE USER carl WITH PASSWORD 'secret'")
+
+	for _, password := range []string{"wrong", "secret"} {
+		pgURL, cleanup := sqlutils.PGUrlWithOptionalClientCerts(
+			t, testServer.ServingSQLAddr(), t.Name(), url.UserPassword("carl", password), false, /* withClientCerts */
+		)
+		conn, err := pgx.Connect(ctx, pgURL.String())
+		cleanup()
+		if password == "wrong" {
+			require.Regexp(t, "password authentication failed", err)
+			continue
+		}
+		require.NoError(t, err)
+		require.NoError(t, conn.Close(ctx))
+	}
+
+	// The authentication info of the user is cached by the failed attempt,
+	// and served from the cache to the successful one.
+	sqlDB.CheckQueryResults(t,
+		`SELECT node_id, transport, method, success, reason, cache_hit, latency > '0s'
+       FROM crdb_internal.cluster_auth_attempts
+      WHERE username = 'carl'
+   ORDER BY timestamp`,
+		[][]string{
+			{"1", "hostssl", "cert-password", "false", "CREDENTIALS_INVALID", "false", "true"},
+			{"1", "hostssl", "cert-password", "true", "NULL", "true", "true"},
+		},
+	)
+}
+
+// TestLoginIsTraced verifies that the login sequence of a session is
+// included in its first trace when sql.trace.login.enabled is set.
+func TestLoginIsTraced(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	testServer, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer testServer.Stopper().Stop(ctx)
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, "CREATE USER carl WITH PASSWORD 'secret'")
+
+	pgURL, cleanup := sqlutils.PGUrlWithOptionalClientCerts(
+		t, testServer.ServingSQLAddr(), t.Name(), url.UserPassword("carl", "secret"), false, /* withClientCerts */
+	)
+	defer cleanup()
+
+	// traceSession connects, traces a statement, and returns the messages
+	// of the trace.
+	traceSession := func(conn *pgx.Conn) string {
+		for _, stmt := range []string{"SET tracing = on", "SELECT 1", "SET tracing = off"} {
+			_, err := conn.Exec(ctx, stmt)
+			require.NoError(t, err)
+		}
+		rows, err := conn.Query(ctx, "SELECT message FROM [SHOW TRACE FOR SESSION]")
+		require.NoError(t, err)
+		defer rows.Close()
+		var messages strings.Builder
+		for rows.Next() {
+			var msg string
+			require.NoError(t, rows.Scan(&msg))
+			messages.WriteString(msg + "\n")
+		}
+		require.NoError(t, rows.Err())
+		return messages.String()
+	}
+
+	loginEvents := []string{
+		"HBA rule: ",
+		"auth info cache ",
+		"verifying the credentials of user carl",
+		"password hash computed",
+		"credentials verified: true",
+	}
+
+	for _, enabled := range []bool{false, true} {
+		t.Run(fmt.Sprintf("enabled=%t", enabled), func(t *testing.T) {
+			sqlDB.Exec(t, "SET CLUSTER SETTING sql.trace.login.enabled = $1", enabled)
+			conn, err := pgx.Connect(ctx, pgURL.String())
+			require.NoError(t, err)
+			defer func() { _ = conn.Close(ctx) }()
+
+			trace := traceSession(conn)
+			require.Contains(t, trace, "SELECT 1")
+			for _, event := range loginEvents {
+				if enabled {
+					require.Contains(t, trace, event)
+				} else {
+					require.NotContains(t, trace, event)
+				}
+			}
+
+			// The login sequence is only included in the first trace of the
+			// session.
+			trace = traceSession(conn)
+			require.NotContains(t, trace, loginEvents[0])
+		})
+	}
+}
+
+// TestAuthCacheFallbackIsReported verifies that the logins which bypass
+// the authentication cache are reported with rate-limited events.
+func TestAuthCacheFallbackIsReported(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	sc := log.ScopeWithoutShowLogs(t)
+	defer sc.Close(t)
+
+	ctx := context.Background()
+	testServer, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer testServer.Stopper().Stop(ctx)
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, "CREATE USER carl WITH PASSWORD 'secret'")
+	sqlDB.Exec(t, "SET CLUSTER SETTING server.authentication_cache.enabled = false")
+
+	pgURL, cleanup := sqlutils.PGUrlWithOptionalClientCerts(
+		t, testServer.ServingSQLAddr(), t.Name(), url.UserPassword("carl", "secret"), false, /* withClientCerts */
+	)
+	defer cleanup()
+	for i := 0; i < 3; i++ {
+		conn, err := pgx.Connect(ctx, pgURL.String())
+		require.NoError(t, err)
+		require.NoError(t, conn.Close(ctx))
+	}
+
+	log.Flush()
+	entries, err := log.FetchEntriesFromFiles(0, math.MaxInt64, 10000,
+		regexp.MustCompile(`"EventType":"authentication_cache_fallback"`),
+		log.WithMarkedSensitiveData)
+	require.NoError(t, err)
+	// The logins read both the auth info and the default settings of the
+	// user from the system tables, but only the first fallback is
+	// reported.
+	require.Len(t, entries, 1)
+	require.Contains(t, entries[0].Message, `"Reason":"disabled"`)
+	require.Contains(t, entries[0].Message, `"User":"‹carl›"`)
+}
+
+// TestDefaultSettingsNegativeCacheEntries verifies that the default
+// settings of the users with no settings are served from the cache, whatever
+// the application name of their sessions.
+func TestDefaultSettingsNegativeCacheEntries(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	testServer, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer testServer.Stopper().Stop(ctx)
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, "CREATE USER carl")
+
+	pgURL, cleanup := sqlutils.PGUrl(t, testServer.ServingSQLAddr(), t.Name(), url.User("carl"))
+	defer cleanup()
+	connect := func(applicationName string) {
+		q := pgURL.Query()
+		q.Set("application_name", applicationName)
+		pgURL.RawQuery = q.Encode()
+		conn, err := pgx.Connect(ctx, pgURL.String())
+		require.NoError(t, err)
+		require.NoError(t, conn.Close(ctx))
+	}
+	cacheInfo := func() (entries, misses int) {
+		sqlDB.QueryRow(t,
+			"SELECT settings_entries, misses FROM [SHOW AUTHENTICATION CACHE]",
+		).Scan(&entries, &misses)
+		return entries, misses
+	}
+
+	// The sessions of other applications are served by the negative entries
+	// of the first session, and do not add entries.
+	connect("a")
+	entries, misses := cacheInfo()
+	connect("b")
+	connect("c")
+	entries2, misses2 := cacheInfo()
+	require.Equal(t, entries, entries2)
+	require.Equal(t, misses, misses2)
+
+	// Once the user has settings scoped to an application, the entries of
+	// the application are cached as well.
+	sqlDB.Exec(t, "ALTER ROLE carl FOR APPLICATION 'a' SET search_path = 'x'")
+	connect("a")
+	entries, misses = cacheInfo()
+	connect("a")
+	entries2, misses2 = cacheInfo()
+	require.Equal(t, entries, entries2)
+	require.Equal(t, misses, misses2)
+}
+
+// TestSessionAuthDetails verifies that SHOW SESSIONS reports how the
+// sessions were authenticated.
+func TestSessionAuthDetails(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	testServer, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer testServer.Stopper().Stop(ctx)
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, "CREATE USER carl WITH PASSWORD 'secret'")
+
+	for _, tc := range []struct {
+		user            *url.Userinfo
+		withClientCerts bool
+		expected        []string
+	}{
+		{url.User(security.RootUser), true /* withClientCerts */, []string{"cert-password", "NULL", "true", "true"}},
+		{url.UserPassword("carl", "secret"), false /* withClientCerts */, []string{"cert-password", "NULL", "false", "false"}},
+	} {
+		t.Run(tc.user.Username(), func(t *testing.T) {
+			pgURL, cleanup := sqlutils.PGUrlWithOptionalClientCerts(
+				t, testServer.ServingSQLAddr(), t.Name(), tc.user, tc.withClientCerts,
+			)
+			defer cleanup()
+			conn, err := gosql.Open("postgres", pgURL.String())
+			require.NoError(t, err)
+			defer conn.Close()
+
+			sqlutils.MakeSQLRunner(conn).CheckQueryResults(t,
+				`SELECT auth_method,
+                identity_map,
+                client_cert_fingerprint IS NOT NULL,
+                coalesce(client_cert_expiry > now(), false)
+           FROM [SHOW SESSIONS]
+          WHERE session_id = (SELECT * FROM [SHOW session_id])`,
+				[][]string{tc.expected},
+			)
+		})
+	}
+}
+
+// TestAuthMethodTelemetry verifies that the successful logins are
+// counted per authentication method.
+func TestAuthMethodTelemetry(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	testServer, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer testServer.Stopper().Stop(ctx)
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, "CREATE USER carl WITH PASSWORD 'secret'")
+	sqlDB.Exec(t, `SET CLUSTER SETTING server.host_based_authentication.configuration = '
+host all carl all password
+host all all all cert-password'`)
+
+	passwordCounter := sqltelemetry.AuthMethodCounter(sqltelemetry.AuthTransportSQL, "password")
+	before := telemetry.Read(passwordCounter)
+
+	pgURL, cleanup := sqlutils.PGUrlWithOptionalClientCerts(
+		t, testServer.ServingSQLAddr(), t.Name(), url.UserPassword("carl", "secret"), false, /* withClientCerts */
+	)
+	defer cleanup()
+	conn, err := gosql.Open("postgres", pgURL.String())
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.Ping())
+
+	require.Equal(t, before+1, telemetry.Read(passwordCounter))
+}
+
+// TestCredentialExpiryNotice verifies that the clients which
+// authenticate with a password expiring soon are sent a notice.
+func TestCredentialExpiryNotice(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	testServer, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer testServer.Stopper().Stop(ctx)
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, "CREATE USER soon WITH PASSWORD 'secret' VALID UNTIL $1",
+		timeutil.Now().Add(24*time.Hour).Format(time.RFC3339))
+	sqlDB.Exec(t, "CREATE USER later WITH PASSWORD 'secret' VALID UNTIL $1",
+		timeutil.Now().Add(30*24*time.Hour).Format(time.RFC3339))
+
+	connectAndCollectNotices := func(user string) []*pgconn.Notice {
+		pgURL, cleanup := sqlutils.PGUrlWithOptionalClientCerts(
+			t, testServer.ServingSQLAddr(), t.Name(), url.UserPassword(user, "secret"), false, /* withClientCerts */
+		)
+		defer cleanup()
+		config, err := pgx.ParseConfig(pgURL.String())
+		require.NoError(t, err)
+		var notices []*pgconn.Notice
+		config.OnNotice = func(_ *pgconn.PgConn, n *pgconn.Notice) {
+			notices = append(notices, n)
+		}
+		conn, err := pgx.ConnectConfig(ctx, config)
+		require.NoError(t, err)
+		require.NoError(t, conn.Close(ctx))
+		return notices
+	}
+
+	notices := connectAndCollectNotices("soon")
+	require.Len(t, notices, 1)
+	require.Equal(t, "NOTICE", notices[0].Severity)
+	require.Contains(t, notices[0].Message, "the password of the user expires at")
+	require.Contains(t, notices[0].Hint, "VALID UNTIL")
+
+	require.Empty(t, connectAndCollectNotices("later"))
+
+	// The notices can be disabled.
+	sqlDB.Exec(t, "SET CLUSTER SETTING server.user_login.expiry_notice_window = '0s'")
+	require.Empty(t, connectAndCollectNotices("soon"))
+}
+
+// TestLogonStatements checks that the logon statements configured for a role
+// are executed at the start of its sessions, and that their failure
+// terminates the session.
+func TestLogonStatements(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	defer db.Close()
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, "CREATE USER testuser")
+	sqlDB.Exec(t, "CREATE TABLE defaultdb.logins (username STRING, search_path STRING)")
+	sqlDB.Exec(t, "GRANT INSERT ON defaultdb.logins TO testuser")
+
+	pgURL, cleanupFunc := sqlutils.PGUrl(
+		t, s.ServingSQLAddr(), "TestLogonStatements" /* prefix */, url.User("testuser"),
+	)
+	defer cleanupFunc()
+	connect := func() *pgx.Conn {
+		conn, err := pgx.Connect(ctx, pgURL.String())
+		require.NoError(t, err)
+		return conn
+	}
+
+	// The statements are executed in the session, in order.
+	sqlDB.Exec(t, `ALTER ROLE testuser SET logon_statements = `+
+		`'SET search_path = ''a''; INSERT INTO defaultdb.logins VALUES (current_user, current_setting(''search_path''))'`)
+	conn := connect()
+	var searchPath string
+	require.NoError(t, conn.QueryRow(ctx, "SHOW search_path").Scan(&searchPath))
+	require.Equal(t, "a", searchPath)
+	require.NoError(t, conn.Close(ctx))
+	sqlDB.CheckQueryResults(t, "SELECT * FROM defaultdb.logins", [][]string{{"testuser", "a"}})
+
+	// The statements are executed when the results are not buffered, and the
+	// client cannot replace them with its own connection parameter.
+	q := pgURL.Query()
+	q.Add("avoid_buffering", "on")
+	q.Add("logon_statements", "")
+	unbufferedURL := pgURL
+	unbufferedURL.RawQuery = q.Encode()
+	conn, err := pgx.Connect(ctx, unbufferedURL.String())
+	require.NoError(t, err)
+	require.NoError(t, conn.QueryRow(ctx, "SHOW search_path").Scan(&searchPath))
+	require.Equal(t, "a", searchPath)
+	require.NoError(t, conn.Close(ctx))
+	sqlDB.CheckQueryResults(t, "SELECT count(*) FROM defaultdb.logins", [][]string{{"2"}})
+
+	// The logon statements cannot be configured with invalid SQL.
+	sqlDB.ExpectErr(t, "invalid logon_statements",
+		"ALTER ROLE testuser SET logon_statements = 'SELECT FROM WHERE'")
+
+	// A failure is reported to the client before the session is terminated.
+	for _, tc := range []struct {
+		logonStatements string
+		expectedErr     string
+	}{
+		{
+			logonStatements: "SELECT 1/0",
+			expectedErr:     "executing logon statements: division by zero",
+		},
+		{
+			logonStatements: "BEGIN",
+			expectedErr:     "executing logon statements: logon statements must not leave a transaction open",
+		},
+	} {
+		t.Run(tc.logonStatements, func(t *testing.T) {
+			sqlDB.Exec(t, fmt.Sprintf("ALTER ROLE testuser SET logon_statements = %s",
+				lex.EscapeSQLString(tc.logonStatements)))
+			conn := connect()
+			defer func() { _ = conn.Close(ctx) }()
+			msg, err := conn.PgConn().ReceiveMessage(ctx)
+			require.NoError(t, err)
+			errMsg, ok := msg.(*pgproto3.ErrorResponse)
+			require.True(t, ok, "expected an error, got %T", msg)
+			require.Equal(t, tc.expectedErr, errMsg.Message)
+			_, err = conn.PgConn().ReceiveMessage(ctx)
+			require.Error(t, err)
+		})
+	}
+}
+
+// TestLockedDefaultSettings verifies that the default settings of the roles
+// set with LOCKED override the connection parameters of the client, and that
+// the session cannot change them.
+func TestLockedDefaultSettings(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	defer db.Close()
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, "CREATE USER testuser")
+	sqlDB.Exec(t, "ALTER ROLE testuser SET statement_timeout = '30s' LOCKED")
+	sqlDB.Exec(t, "ALTER ROLE testuser SET search_path = 'a'")
+
+	pgURL, cleanupFunc := sqlutils.PGUrl(
+		t, s.ServingSQLAddr(), "TestLockedDefaultSettings" /* prefix */, url.User("testuser"),
+	)
+	defer cleanupFunc()
+	q := pgURL.Query()
+	q.Add("statement_timeout", "5s")
+	q.Add("search_path", "b")
+	pgURL.RawQuery = q.Encode()
+	conn, err := pgx.Connect(ctx, pgURL.String())
+	require.NoError(t, err)
+	defer func() { _ = conn.Close(ctx) }()
+
+	// The locked setting overrides the connection parameter, unlike the
+	// other settings.
+	var statementTimeout, searchPath string
+	require.NoError(t, conn.QueryRow(ctx, "SHOW statement_timeout").Scan(&statementTimeout))
+	require.Equal(t, "30000", statementTimeout)
+	require.NoError(t, conn.QueryRow(ctx, "SHOW search_path").Scan(&searchPath))
+	require.Equal(t, "b", searchPath)
+
+	// The session cannot change the locked variable.
+	_, err = conn.Exec(ctx, "SET statement_timeout = '1h'")
+	require.EqualError(t, err,
+		`ERROR: parameter "statement_timeout" is locked by a default setting of the role (SQLSTATE 42501)`)
+	_, err = conn.Exec(ctx, "SELECT set_config('statement_timeout', '1h', false)")
+	require.Error(t, err)
+
+	// Resetting the variable restores the locked value.
+	_, err = conn.Exec(ctx, "RESET statement_timeout")
+	require.NoError(t, err)
+	_, err = conn.Exec(ctx, "RESET ALL")
+	require.NoError(t, err)
+	require.NoError(t, conn.QueryRow(ctx, "SHOW statement_timeout").Scan(&statementTimeout))
+	require.Equal(t, "30000", statementTimeout)
+
+	// The locked setting also overrides the defaults provided by the
+	// authorization webhook, unlike the other settings.
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(authzWebhookResponse{
+			Allow: true,
+			SessionDefaults: map[string]string{
+				"statement_timeout": "10s",
+				"search_path":       "c",
+			},
+		})
+	}))
+	defer webhook.Close()
+	sqlDB.Exec(t, "SET CLUSTER SETTING server.user_login.authorization_webhook.url = $1", webhook.URL)
+	webhookConn, err := pgx.Connect(ctx, pgURL.String())
+	require.NoError(t, err)
+	defer func() { _ = webhookConn.Close(ctx) }()
+	require.NoError(t, webhookConn.QueryRow(ctx, "SHOW statement_timeout").Scan(&statementTimeout))
+	require.Equal(t, "30000", statementTimeout)
+	require.NoError(t, webhookConn.QueryRow(ctx, "SHOW search_path").Scan(&searchPath))
+	require.Equal(t, "c", searchPath)
+}
+
+// TestSessionMigrationPortals verifies that the named portals of a session
+// are migrated when the session is deserialized in the same implicit
+// transaction as the statements which use them.
+func TestSessionMigrationPortals(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, _, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	pgURL, cleanup := sqlutils.PGUrl(t, s.ServingSQLAddr(), t.Name(), url.User(security.RootUser))
+	defer cleanup()
+	connect := func() (*pgproto3.Frontend, func()) {
+		conn, err := pgx.Connect(ctx, pgURL.String())
+		require.NoError(t, err)
+		hc, err := conn.PgConn().Hijack()
+		require.NoError(t, err)
+		return pgproto3.NewFrontend(pgproto3.NewChunkReader(hc.Conn), hc.Conn),
+			func() { _ = hc.Conn.Close() }
+	}
+	// send sends the messages, and returns the first column of the rows
+	// received until the server is ready for the next query.
+	send := func(fe *pgproto3.Frontend, msgs ...pgproto3.FrontendMessage) [][]byte {
+		for _, msg := range msgs {
+			require.NoError(t, fe.Send(msg))
+		}
+		var rows [][]byte
+		for {
+			msg, err := fe.Receive()
+			require.NoError(t, err)
+			switch msg := msg.(type) {
+			case *pgproto3.DataRow:
+				rows = append(rows, append([]byte(nil), msg.Values[0]...))
+			case *pgproto3.ErrorResponse:
+				t.Fatalf("unexpected error: %s", msg.Message)
+			case *pgproto3.ReadyForQuery:
+				return rows
+			}
+		}
+	}
+
+	fe, closeConn := connect()
+	defer closeConn()
+	state := send(fe,
+		&pgproto3.Parse{Name: "s", Query: "SELECT $1::INT8 + 1"},
+		&pgproto3.Bind{DestinationPortal: "p", PreparedStatement: "s", Parameters: [][]byte{[]byte("41")}},
+		&pgproto3.Parse{Name: "serialize", Query: "SELECT crdb_internal.serialize_session()"},
+		&pgproto3.Bind{PreparedStatement: "serialize", ResultFormatCodes: []int16{1}},
+		&pgproto3.Execute{},
+		&pgproto3.Sync{},
+	)
+	require.Len(t, state, 1)
+
+	// The portal is created in the new session, where it can be executed.
+	fe2, closeConn2 := connect()
+	defer closeConn2()
+	rows := send(fe2,
+		&pgproto3.Parse{Name: "deserialize", Query: "SELECT crdb_internal.deserialize_session($1)"},
+		&pgproto3.Bind{
+			PreparedStatement:    "deserialize",
+			ParameterFormatCodes: []int16{1},
+			Parameters:           [][]byte{state[0]},
+		},
+		&pgproto3.Execute{},
+		&pgproto3.Execute{Portal: "p"},
+		&pgproto3.Sync{},
+	)
+	require.Equal(t, [][]byte{[]byte("t"), []byte("42")}, rows)
+}
+
+// TestRoleIdleSessionTimeout verifies that the IDLE_SESSION_TIMEOUT role
+// option overrides the option of the profile of the user and the timeout
+// requested by the client.
+func TestRoleIdleSessionTimeout(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	defer db.Close()
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, "CREATE PROFILE p WITH IDLE_SESSION_TIMEOUT '10m'")
+	sqlDB.Exec(t, "CREATE USER testuser WITH PROFILE p")
+
+	pgURL, cleanupFunc := sqlutils.PGUrl(
+		t, s.ServingSQLAddr(), "TestRoleIdleSessionTimeout" /* prefix */, url.User("testuser"),
+	)
+	defer cleanupFunc()
+	q := pgURL.Query()
+	q.Add("idle_in_session_timeout", "0")
+	pgURL.RawQuery = q.Encode()
+
+	checkTimeout := func(expected string) {
+		t.Helper()
+		conn, err := pgx.Connect(ctx, pgURL.String())
+		require.NoError(t, err)
+		defer func() { _ = conn.Close(ctx) }()
+		var timeout string
+		require.NoError(t, conn.QueryRow(ctx, "SHOW idle_in_session_timeout").Scan(&timeout))
+		require.Equal(t, expected, timeout)
+	}
+
+	checkTimeout("600000")
+	sqlDB.Exec(t, "ALTER USER testuser WITH IDLE_SESSION_TIMEOUT '1m'")
+	checkTimeout("60000")
+
+	// The session can lower the timeout, but not raise it above the option
+	// nor disable it.
+	conn, err := pgx.Connect(ctx, pgURL.String())
+	require.NoError(t, err)
+	defer func() { _ = conn.Close(ctx) }()
+	_, err = conn.Exec(ctx, "SET idle_in_session_timeout = '30s'")
+	require.NoError(t, err)
+	for _, stmt := range []string{
+		"SET idle_in_session_timeout = 0",
+		"SET idle_in_session_timeout = '1h'",
+		"SET idle_session_timeout = 0",
+	} {
+		_, err = conn.Exec(ctx, stmt)
+		require.Error(t, err, stmt)
+		require.Regexp(t, "cannot exceed 1m0s, the limit set by a role option of the user", err)
+	}
+	_, err = conn.Exec(ctx, "RESET idle_in_session_timeout")
+	require.NoError(t, err)
+	var timeout string
+	require.NoError(t, conn.QueryRow(ctx, "SHOW idle_in_session_timeout").Scan(&timeout))
+	require.Equal(t, "60000", timeout)
+}
+
+// TestRoleTimeoutOptions verifies that the STATEMENT_TIMEOUT and
+// TRANSACTION_TIMEOUT role options bound the timeouts of the sessions of
+// the user.
+func TestRoleTimeoutOptions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	defer db.Close()
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, "CREATE USER testuser WITH STATEMENT_TIMEOUT '30s' TRANSACTION_TIMEOUT '1m'")
+
+	pgURL, cleanupFunc := sqlutils.PGUrl(
+		t, s.ServingSQLAddr(), "TestRoleTimeoutOptions" /* prefix */, url.User("testuser"),
+	)
+	defer cleanupFunc()
+	connect := func(statementTimeout string) *pgx.Conn {
+		u := pgURL
+		q := u.Query()
+		q.Add("statement_timeout", statementTimeout)
+		u.RawQuery = q.Encode()
+		conn, err := pgx.Connect(ctx, u.String())
+		require.NoError(t, err)
+		return conn
+	}
+	checkTimeout := func(conn *pgx.Conn, varName string, expected string) {
+		t.Helper()
+		var timeout string
+		require.NoError(t, conn.QueryRow(ctx, "SHOW "+varName).Scan(&timeout))
+		require.Equal(t, expected, timeout)
+	}
+
+	// The timeouts requested by the client, or defaulted, are lowered to
+	// the role options when they exceed them.
+	conn := connect("1h")
+	defer func() { _ = conn.Close(ctx) }()
+	checkTimeout(conn, "statement_timeout", "30000")
+	checkTimeout(conn, "transaction_timeout", "60000")
+
+	// The session can lower the timeouts, but not raise nor disable them.
+	_, err := conn.Exec(ctx, "SET statement_timeout = '10s'")
+	require.NoError(t, err)
+	checkTimeout(conn, "statement_timeout", "10000")
+	for _, stmt := range []string{
+		"SET statement_timeout = '1h'",
+		"SET statement_timeout = 0",
+		"SELECT set_config('transaction_timeout', '2m', false)",
+	} {
+		_, err = conn.Exec(ctx, stmt)
+		require.Error(t, err, stmt)
+		require.Contains(t, err.Error(), "the limit set by a role option of the user", stmt)
+	}
+	_, err = conn.Exec(ctx, "RESET ALL")
+	require.NoError(t, err)
+	checkTimeout(conn, "statement_timeout", "30000")
+
+	// The shorter timeouts requested by the client are kept.
+	shortConn := connect("5s")
+	defer func() { _ = shortConn.Close(ctx) }()
+	checkTimeout(shortConn, "statement_timeout", "5000")
+}
+
+// TestRoleQualityOfService verifies that the QUALITY_OF_SERVICE role
+// option, set on a user or on its profile, sets the admission control
+// priority of the sessions of the user.
+func TestRoleQualityOfService(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	defer db.Close()
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, "CREATE PROFILE reporting WITH QUALITY_OF_SERVICE 'background'")
+	sqlDB.Exec(t, "CREATE USER testuser WITH PROFILE reporting")
+
+	pgURL, cleanupFunc := sqlutils.PGUrl(
+		t, s.ServingSQLAddr(), "TestRoleQualityOfService" /* prefix */, url.User("testuser"),
+	)
+	defer cleanupFunc()
+	q := pgURL.Query()
+	q.Add("default_transaction_quality_of_service", "critical")
+	pgURL.RawQuery = q.Encode()
+
+	checkQoS := func(expected string) {
+		t.Helper()
+		conn, err := pgx.Connect(ctx, pgURL.String())
+		require.NoError(t, err)
+		defer func() { _ = conn.Close(ctx) }()
+		var qos, source string
+		require.NoError(t, conn.QueryRow(ctx, "SHOW default_transaction_quality_of_service").Scan(&qos))
+		require.Equal(t, expected, qos)
+		require.NoError(t, conn.QueryRow(ctx,
+			"SELECT source FROM [SHOW SETTING PROVENANCE default_transaction_quality_of_service] WHERE in_effect",
+		).Scan(&source))
+		require.Equal(t, "role option", source)
+	}
+
+	// The option overrides the priority requested by the client.
+	checkQoS("background")
+	sqlDB.Exec(t, "ALTER USER testuser WITH QUALITY_OF_SERVICE 'regular'")
+	checkQoS("regular")
+
+	// The session can lower the priority, but cannot raise it above the
+	// option.
+	conn, err := pgx.Connect(ctx, pgURL.String())
+	require.NoError(t, err)
+	defer func() { _ = conn.Close(ctx) }()
+	_, err = conn.Exec(ctx, "SET default_transaction_quality_of_service = background")
+	require.NoError(t, err)
+	_, err = conn.Exec(ctx, "SET default_transaction_quality_of_service = critical")
+	require.EqualError(t, err, `ERROR: parameter "default_transaction_quality_of_service" `+
+		`cannot exceed regular, the limit set by a role option of the user (SQLSTATE 42501)`)
+	_, err = conn.Exec(ctx, "SET default_transaction_quality_of_service = regular")
+	require.NoError(t, err)
+}
+
+// TestRoleResultLimits verifies that the MAX_RESULT_ROWS and
+// MAX_RESULT_BYTES role options bound the results of the statements of the
+// sessions of the user.
+func TestRoleResultLimits(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	defer db.Close()
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, "CREATE USER testuser WITH MAX_RESULT_ROWS 10 MAX_RESULT_BYTES '1KiB'")
+
+	pgURL, cleanupFunc := sqlutils.PGUrl(
+		t, s.ServingSQLAddr(), "TestRoleResultLimits" /* prefix */, url.User("testuser"),
+	)
+	defer cleanupFunc()
+	conn, err := pgx.Connect(ctx, pgURL.String())
+	require.NoError(t, err)
+	defer func() { _ = conn.Close(ctx) }()
+
+	count := func(query string) (int, error) {
+		rows, err := conn.Query(ctx, query)
+		if err != nil {
+			return 0, err
+		}
+		defer rows.Close()
+		n := 0
+		for rows.Next() {
+			n++
+		}
+		return n, rows.Err()
+	}
+
+	n, err := count("SELECT generate_series(1, 10)")
+	require.NoError(t, err)
+	require.Equal(t, 10, n)
+	_, err = count("SELECT generate_series(1, 11)")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "statement returned more than 10 rows")
+	_, err = count("SELECT repeat('x', 2000)")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "statement returned more than 1.0 KiB of results")
+
+	// Only the rows returned to the client are limited, and the session
+	// remains usable after the limits are exceeded.
+	n, err = count("SELECT count(*) FROM generate_series(1, 100)")
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+}
+
+// TestSessionDefaultsAreLogged verifies that the session defaults applied
+// to the new sessions are reported when sql.log.session_defaults.enabled is
+// set.
+func TestSessionDefaultsAreLogged(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	sc := log.ScopeWithoutShowLogs(t)
+	defer sc.Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, "CREATE USER testuser WITH READONLY")
+	sqlDB.Exec(t, "ALTER ROLE testuser SET search_path = 'app'")
+	sqlDB.Exec(t, "ALTER ROLE testuser SET application_name = 'fromrole'")
+	sqlDB.Exec(t, "SET CLUSTER SETTING sql.log.session_defaults.enabled = true")
+
+	pgURL, cleanupFunc := sqlutils.PGUrl(
+		t, s.ServingSQLAddr(), "TestSessionDefaultsAreLogged" /* prefix */, url.User("testuser"),
+	)
+	defer cleanupFunc()
+	q := pgURL.Query()
+	q.Set("application_name", "fromclient")
+	pgURL.RawQuery = q.Encode()
+
+	// The events are rate-limited, so the sessions of the other users may
+	// prevent the session of testuser from being reported.
+	testutils.SucceedsSoon(t, func() error {
+		conn, err := pgx.Connect(ctx, pgURL.String())
+		if err != nil {
+			return err
+		}
+		if err := conn.Close(ctx); err != nil {
+			return err
+		}
+
+		log.Flush()
+		entries, err := log.FetchEntriesFromFiles(0, math.MaxInt64, 10000,
+			regexp.MustCompile(`"EventType":"session_defaults_applied".*"User":"‹testuser›"`),
+			log.WithMarkedSensitiveData)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return errors.New("no session_defaults_applied event for testuser")
+		}
+		msg := entries[0].Message
+		for _, expected := range []string{
+			`"‹application_name=fromclient (client)›"`,
+			`"‹default_transaction_read_only=on (role option)›"`,
+			`"‹search_path=app (role)›"`,
+		} {
+			if !strings.Contains(msg, expected) {
+				return errors.Errorf("expected %s in %s", expected, msg)
+			}
+		}
+		// The defaults overridden by a source with a higher precedence are
+		// not listed.
+		require.NotContains(t, msg, "fromrole")
+		return nil
+	})
+}
+
+// serializeSession opens a session with the URL, runs the statements in it
+// and returns its serialized state.
+func serializeSession(t *testing.T, pgURL url.URL, stmts ...string) []byte {
+	t.Helper()
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, pgURL.String())
+	require.NoError(t, err)
+	defer func() { _ = conn.Close(ctx) }()
+	for _, stmt := range stmts {
+		_, err := conn.Exec(ctx, stmt)
+		require.NoError(t, err)
+	}
+	var state []byte
+	require.NoError(t, conn.QueryRow(ctx, "SELECT crdb_internal.serialize_session()").Scan(&state))
+	return state
+}
+
+// deserializeSession opens a new session with the URL, deserializes the
+// state into it and returns the values of the session variables.
+func deserializeSession(
+	t *testing.T, pgURL url.URL, state []byte, varNames ...string,
+) ([]string, error) {
+	t.Helper()
+	ctx := context.Background()
+	conn, err := pgx.Connect(ctx, pgURL.String())
+	require.NoError(t, err)
+	defer func() { _ = conn.Close(ctx) }()
+	if _, err := conn.Exec(ctx, "SELECT crdb_internal.deserialize_session($1)", state); err != nil {
+		return nil, err
+	}
+	values := make([]string, len(varNames))
+	for i, varName := range varNames {
+		require.NoError(t, conn.QueryRow(ctx, "SHOW "+varName).Scan(&values[i]))
+	}
+	return values, nil
+}
+
+// TestRoleReadOnlyDeserializeSession verifies that the READONLY role
+// option of the user takes precedence over the deserialized session
+// state.
+func TestRoleReadOnlyDeserializeSession(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	defer db.Close()
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, "CREATE USER testuser")
+
+	pgURL, cleanupFunc := sqlutils.PGUrl(
+		t, s.ServingSQLAddr(), "TestRoleReadOnlyDeserializeSession" /* prefix */, url.User("testuser"),
+	)
+	defer cleanupFunc()
+
+	// The state is serialized before the user is restricted.
+	state := serializeSession(t, pgURL, "SET default_transaction_read_only = off")
+	sqlDB.Exec(t, "ALTER USER testuser WITH READONLY")
+	values, err := deserializeSession(t, pgURL, state, "default_transaction_read_only")
+	require.NoError(t, err)
+	require.Equal(t, []string{"on"}, values)
+}
+
+// TestRoleTimeoutOptionsDeserializeSession verifies that the
+// STATEMENT_TIMEOUT and TRANSACTION_TIMEOUT role options bound the
+// timeouts of a deserialized session.
+func TestRoleTimeoutOptionsDeserializeSession(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	defer db.Close()
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, "CREATE USER testuser")
+
+	pgURL, cleanupFunc := sqlutils.PGUrl(
+		t, s.ServingSQLAddr(), "TestRoleTimeoutOptionsDeserializeSession" /* prefix */, url.User("testuser"),
+	)
+	defer cleanupFunc()
+
+	// The state is serialized before the user is restricted.
+	state := serializeSession(t, pgURL, "SET statement_timeout = '1h'", "SET transaction_timeout = 0")
+	sqlDB.Exec(t, "ALTER USER testuser WITH STATEMENT_TIMEOUT '30s' TRANSACTION_TIMEOUT '1m'")
+	values, err := deserializeSession(t, pgURL, state, "statement_timeout", "transaction_timeout")
+	require.NoError(t, err)
+	require.Equal(t, []string{"30000", "60000"}, values)
+
+	// The timeouts within the limits are preserved.
+	state = serializeSession(t, pgURL, "SET statement_timeout = '10s'")
+	values, err = deserializeSession(t, pgURL, state, "statement_timeout", "transaction_timeout")
+	require.NoError(t, err)
+	require.Equal(t, []string{"10000", "60000"}, values)
+}
+
+// TestLockedRoleSettingsDeserializeSession verifies that the variables
+// locked by a default setting of the role keep their value when a session
+// is deserialized.
+func TestLockedRoleSettingsDeserializeSession(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	defer db.Close()
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, "CREATE USER testuser")
+
+	pgURL, cleanupFunc := sqlutils.PGUrl(
+		t, s.ServingSQLAddr(), "TestLockedRoleSettingsDeserializeSession" /* prefix */, url.User("testuser"),
+	)
+	defer cleanupFunc()
+
+	// The state is serialized before the settings are locked.
+	state := serializeSession(t, pgURL, "SET search_path = 'x'", "SET application_name = 'y'")
+	sqlDB.Exec(t, "ALTER ROLE testuser SET search_path = 'app' LOCKED")
+	values, err := deserializeSession(t, pgURL, state, "search_path", "application_name")
+	require.NoError(t, err)
+	// The variables which are not locked are deserialized.
+	require.Equal(t, []string{"app", "y"}, values)
+}
+
+// TestHBADatabaseRulePinsSession verifies that the sessions authenticated
+// by an HBA rule which only matches some databases cannot use the other
+// databases.
+func TestHBADatabaseRulePinsSession(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	defer db.Close()
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, "CREATE DATABASE sandbox")
+	sqlDB.Exec(t, "CREATE DATABASE prod")
+	sqlDB.Exec(t, "CREATE TABLE prod.t (x INT)")
+	sqlDB.Exec(t, "CREATE USER carl WITH PASSWORD 'doggo'")
+	sqlDB.Exec(t, "GRANT ALL ON DATABASE sandbox TO carl")
+	sqlDB.Exec(t, "GRANT ALL ON DATABASE prod TO carl")
+	sqlDB.Exec(t, "GRANT ALL ON TABLE prod.t TO carl")
+	sqlDB.Exec(t, `SET CLUSTER SETTING server.host_based_authentication.configuration = '
+host sandbox all all trust
+host all all all password'`)
+
+	connect := func(database string, user *url.Userinfo) *pgx.Conn {
+		pgURL, cleanupFunc := sqlutils.PGUrlWithOptionalClientCerts(
+			t, s.ServingSQLAddr(), "TestHBADatabaseRulePinsSession" /* prefix */, user, false, /* withClientCerts */
+		)
+		defer cleanupFunc()
+		pgURL.Path = database
+		conn, err := pgx.Connect(ctx, pgURL.String())
+		require.NoError(t, err)
+		return conn
+	}
+
+	// The session authenticated by the trust rule for sandbox cannot use
+	// prod.
+	conn := connect("sandbox", url.User("carl"))
+	defer func() { _ = conn.Close(ctx) }()
+	for _, stmt := range []string{
+		"USE prod",
+		"SET database = ''",
+		"SELECT * FROM prod.public.t",
+	} {
+		_, err := conn.Exec(ctx, stmt)
+		require.Error(t, err, stmt)
+		require.Regexp(t, "user carl (is not allowed to use database prod|must use one of its allowed databases)", err)
+	}
+	_, err := conn.Exec(ctx, "CREATE TABLE sandbox.public.t (x INT)")
+	require.NoError(t, err)
+
+	// The session authenticated by the rule for all the databases can use
+	// any database.
+	conn2 := connect("prod", url.UserPassword("carl", "doggo"))
+	defer func() { _ = conn2.Close(ctx) }()
+	_, err = conn2.Exec(ctx, "SELECT * FROM sandbox.public.t")
+	require.NoError(t, err)
+	_, err = conn2.Exec(ctx, "USE sandbox")
+	require.NoError(t, err)
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"crypto/tls"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// The clients are warned with a notice during the session startup when
+// the credentials they authenticated with are about to expire, so that
+// the applications, or their operators, get advance warning instead of
+// sudden authentication failures.
+
+// credentialExpiryNoticeWindow is the cluster setting which controls
+// how long before their expiry the credentials are reported.
+var credentialExpiryNoticeWindow = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"server.user_login.expiry_notice_window",
+	"the duration before the expiry of the client certificate, or of the VALID UNTIL "+
+		"option of the user when authenticating with a password, during which the "+
+		"clients are sent a notice upon login (0 to disable)",
+	7*24*time.Hour,
+	settings.NonNegativeDuration,
+)
+
+// makeExpiryNotices returns the notices to send to a client which
+// authenticated at the given time, for the credentials which expire
+// within the notice window. validUntil is the VALID UNTIL option of the
+// user, if any; it is only reported if the client authenticated with
+// the password of the user.
+func makeExpiryNotices(
+	sv *settings.Values,
+	now time.Time,
+	tlsState tls.ConnectionState,
+	validUntil *tree.DTimestamp,
+	usedPassword bool,
+) []pgnotice.Notice {
+	window := credentialExpiryNoticeWindow.Get(sv)
+	if window == 0 {
+		return nil
+	}
+	var notices []pgnotice.Notice
+	if len(tlsState.PeerCertificates) > 0 {
+		if notAfter := tlsState.PeerCertificates[0].NotAfter; notAfter.Sub(now) < window {
+			notices = append(notices, errors.WithHint(
+				pgnotice.Newf("the client certificate expires at %s, in %s",
+					notAfter.UTC().Format(time.RFC3339), formatTimeToExpiry(now, notAfter)),
+				"Renew the client certificate before it expires."))
+		}
+	}
+	if usedPassword && validUntil != nil {
+		if expiry := validUntil.Time; expiry.Sub(now) < window {
+			notices = append(notices, errors.WithHint(
+				pgnotice.Newf("the password of the user expires at %s, in %s",
+					expiry.UTC().Format(time.RFC3339), formatTimeToExpiry(now, expiry)),
+				"Ask an administrator to extend the VALID UNTIL option of the user."))
+		}
+	}
+	return notices
+}
+
+// formatTimeToExpiry formats the remaining time until the given expiry,
+// rounded to the minute.
+func formatTimeToExpiry(now, expiry time.Time) string {
+	return expiry.Sub(now).Round(time.Minute).String()
+}
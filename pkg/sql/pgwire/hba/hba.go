@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"regexp"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/security"
@@ -45,7 +46,7 @@ type Entry struct {
 	// User is the list of users to match. An empty list means "match
 	// any user".
 	User []String
-	// Address is either AnyAddr, *net.IPNet or (unsupported) String for a hostname.
+	// Address is either AnyAddr, *net.IPNet or String for a hostname.
 	Address interface{}
 	Method  String
 	// MethodFn is populated during name resolution of Method.
@@ -205,10 +206,47 @@ func (h Entry) ConnMatches(clientConn ConnType, ip net.IP) (bool, error) {
 	return true, nil
 }
 
+// DatabaseMatches returns true iff the provided database name matches
+// an entry in the Database list or if the database list is empty (the
+// entry matches all).
+//
+// The keyword "sameuser" matches the database with the same name as
+// the user, which must be normalized already. Unquoted values starting
+// with a slash (/) are regular expressions matched against the
+// database name. Other values must be equal to the database name.
+func (h Entry) DatabaseMatches(dbName string, userName security.SQLUsername) bool {
+	if h.Database == nil {
+		return true
+	}
+	for _, d := range h.Database {
+		switch {
+		case d.IsKeyword("sameuser"):
+			if dbName == userName.Normalized() {
+				return true
+			}
+		case d.IsRegexp():
+			// The regular expression was validated when the configuration
+			// was set.
+			if re, err := d.Regexp(); err == nil && re.MatchString(dbName) {
+				return true
+			}
+		default:
+			if d.Value == dbName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // UserMatches returns true iff the provided username matches an
 // entry in the User list or if the user list is empty (the entry
 // matches all).
 //
+// Unquoted values starting with a slash (/) are regular expressions
+// matched against the username. Other values must be equal to the
+// username.
+//
 // The provided username must be normalized already.
 // The function assumes the entry was normalized to contain only
 // one user and its username normalized. See ParseAndNormalize().
@@ -217,6 +255,14 @@ func (h Entry) UserMatches(userName security.SQLUsername) bool {
 		return true
 	}
 	for _, u := range h.User {
+		if u.IsRegexp() {
+			// The regular expression was validated when the configuration
+			// was set.
+			if re, err := u.Regexp(); err == nil && re.MatchString(userName.Normalized()) {
+				return true
+			}
+			continue
+		}
 		if u.Value == userName.Normalized() {
 			return true
 		}
@@ -233,12 +279,48 @@ func (h Entry) AddressMatches(addr net.IP) (bool, error) {
 		return true, nil
 	case *net.IPNet:
 		return a.Contains(addr), nil
+	case String:
+		// Host names cannot be matched against an IP address; see
+		// HostnameMatches.
+		return false, errors.Newf("address %s is a host name", a)
 	default:
-		// This is where name-based validation can occur later.
 		return false, errors.Newf("unknown address type: %T", addr)
 	}
 }
 
+// IsHostname returns true iff the address of the entry is a host
+// name, which requires the host names of the client to be looked up
+// to match the entry. See HostnameMatches.
+func (h Entry) IsHostname() bool {
+	_, ok := h.Address.(String)
+	return ok
+}
+
+// HostnameMatches returns true iff one of the provided host names of
+// the client matches the address of the entry, which must be a host
+// name. As in PostgreSQL, a host name starting with a dot (.) matches
+// the names that end with it; other host names must be equal to the
+// name of the client. The comparison is case-insensitive and ignores
+// the trailing dot of fully qualified names.
+func (h Entry) HostnameMatches(clientNames []string) bool {
+	a, ok := h.Address.(String)
+	if !ok {
+		return false
+	}
+	pattern := strings.ToLower(strings.TrimSuffix(a.Value, "."))
+	for _, name := range clientNames {
+		name = strings.ToLower(strings.TrimSuffix(name, "."))
+		if strings.HasPrefix(pattern, ".") {
+			if strings.HasSuffix(name, pattern) {
+				return true
+			}
+		} else if name == pattern {
+			return true
+		}
+	}
+	return false
+}
+
 // DatabaseString returns a string that describes the database field.
 func (h Entry) DatabaseString() string {
 	if h.Database == nil {
@@ -309,6 +391,17 @@ func (s String) String() string {
 	return s.Value
 }
 
+// IsRegexp returns whether s is an unquoted string starting with a
+// slash, which denotes a regular expression.
+func (s String) IsRegexp() bool {
+	return !s.Quoted && strings.HasPrefix(s.Value, "/")
+}
+
+// Regexp compiles the regular expression denoted by s. See IsRegexp.
+func (s String) Regexp() (*regexp.Regexp, error) {
+	return regexp.Compile(strings.TrimPrefix(s.Value, "/"))
+}
+
 // Empty returns true iff s is the unquoted empty string.
 func (s String) Empty() bool { return s.IsKeyword("") }
 
@@ -339,8 +432,13 @@ outer:
 	for i := range conf.Entries {
 		entry := conf.Entries[i]
 
-		// The database field is not supported yet in CockroachDB.
-		entry.Database = nil
+		// Normalize the 'all' keyword into a nil database list.
+		for _, db := range entry.Database {
+			if db.IsKeyword("all") {
+				entry.Database = nil
+				break
+			}
+		}
 
 		// Normalize the 'all' keyword into AnyAddr.
 		if addr, ok := entry.Address.(String); ok && addr.IsKeyword("all") {
@@ -367,7 +465,11 @@ outer:
 		allUsers := entry.User
 		for userIdx, iu := range allUsers {
 			entry.User = allUsers[userIdx : userIdx+1]
-			entry.User[0].Value = tree.Name(iu.Value).Normalize()
+			if !iu.IsRegexp() {
+				// Regular expressions are matched against the normalized
+				// usernames as-is.
+				entry.User[0].Value = tree.Name(iu.Value).Normalize()
+			}
 			if userIdx > 0 {
 				entry.Generated = true
 			}
@@ -15,6 +15,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/testutils"
 	"github.com/cockroachdb/datadriven"
 	"github.com/kr/pretty"
@@ -98,6 +99,53 @@ func TestMatchConnType(t *testing.T) {
 	}
 }
 
+func TestMatchUser(t *testing.T) {
+	testCases := []struct {
+		user     String
+		userName string
+		match    bool
+	}{
+		{String{Value: "foo"}, "foo", true},
+		{String{Value: "foo"}, "foobar", false},
+		{String{Value: "/^foo"}, "foobar", true},
+		{String{Value: "/^foo"}, "barfoo", false},
+		{String{Value: "/^app_[0-9]+$"}, "app_12", true},
+		{String{Value: "/^app_[0-9]+$"}, "app_x", false},
+		// Quoted values are never regular expressions.
+		{String{Value: "/^foo", Quoted: true}, "foobar", false},
+	}
+	for _, tc := range testCases {
+		entry := Entry{User: []String{tc.user}}
+		if m := entry.UserMatches(security.MakeSQLUsernameFromPreNormalizedString(tc.userName)); m != tc.match {
+			t.Errorf("%s vs %s: expected %v, got %v", tc.user, tc.userName, tc.match, m)
+		}
+	}
+}
+
+func TestMatchHostname(t *testing.T) {
+	testCases := []struct {
+		addr        string
+		clientNames []string
+		match       bool
+	}{
+		{"db.example.com", []string{"db.example.com."}, true},
+		{"db.example.com", []string{"DB.Example.COM"}, true},
+		{"db.example.com", []string{"app.example.com."}, false},
+		{"db.example.com", nil, false},
+		{".example.com", []string{"app.example.com."}, true},
+		{".example.com", []string{"a.b.example.com"}, true},
+		{".example.com", []string{"example.com"}, false},
+		{".example.com", []string{"badexample.com"}, false},
+		{".example.com", []string{"other.org", "app.example.com"}, true},
+	}
+	for _, tc := range testCases {
+		entry := Entry{Address: String{Value: tc.addr}}
+		if m := entry.HostnameMatches(tc.clientNames); m != tc.match {
+			t.Errorf("%s vs %v: expected %v, got %v", tc.addr, tc.clientNames, tc.match, m)
+		}
+	}
+}
+
 // TODO(mjibson): these are untested outside ccl +gss builds.
 var _ = Entry.GetOption
 var _ = Entry.GetOptions
@@ -62,9 +62,12 @@ import (
 // The matching rules are as follows:
 // - A rule matches if the connecting username matches either of the
 //   usernames listed in the rule, or if the pseudo-user 'all' is
-//   present in the user column.
+//   present in the user column. Unquoted usernames starting with a
+//   slash are regular expressions.
 // - A rule matches if the connecting client's IP address is included
-//   in the network address specified in the CIDR notation.
+//   in the network address specified in the CIDR notation, or if one
+//   of the host names of the client matches the host name specified
+//   in the rule. See hba_hostnames.go.
 //
 
 // serverHBAConfSetting is the name of the cluster setting that holds
@@ -110,6 +113,8 @@ func loadLocalHBAConfigUponRemoteSettingChange(
 	server.auth.Lock()
 	defer server.auth.Unlock()
 	server.auth.conf = hbaConfig
+	server.auth.ruleStats.destroy()
+	server.auth.ruleStats = newHBARuleStats(&server.metrics, hbaConfig)
 }
 
 // checkHBASyntaxBeforeUpdatingSetting is run by the SQL gateway each
@@ -137,54 +142,101 @@ func checkHBASyntaxBeforeUpdatingSetting(values *settings.Values, s string) erro
 	}
 
 	for _, entry := range conf.Entries {
-		switch entry.ConnType {
-		case hba.ConnHostAny:
-		case hba.ConnLocal:
-		case hba.ConnHostSSL, hba.ConnHostNoSSL:
-
-		default:
-			return unimplemented.Newf("hba-type-"+entry.ConnType.String(),
-				"unsupported connection type: %s", entry.ConnType)
+		if err := checkHBAEntry(values, entry); err != nil {
+			return err
 		}
-		for _, db := range entry.Database {
-			if !db.IsKeyword("all") {
-				return errors.WithHint(
-					unimplemented.New("hba-per-db", "per-database HBA rules are not supported"),
-					"Use the special value 'all' (without quotes) to match all databases.")
+	}
+	return nil
+}
+
+// checkHBAEntry verifies that the features used by an HBA rule are
+// supported.
+func checkHBAEntry(values *settings.Values, entry hba.Entry) error {
+	switch entry.ConnType {
+	case hba.ConnHostAny:
+	case hba.ConnLocal:
+	case hba.ConnHostSSL, hba.ConnHostNoSSL:
+
+	default:
+		return unimplemented.Newf("hba-type-"+entry.ConnType.String(),
+			"unsupported connection type: %s", entry.ConnType)
+	}
+	for _, db := range entry.Database {
+		switch {
+		case db.IsKeyword("samerole"), db.IsKeyword("samegroup"), db.IsKeyword("replication"):
+			return unimplemented.Newf("hba-db-"+db.Value,
+				"database keyword %q is not supported", db.Value)
+		case db.IsRegexp():
+			if _, err := db.Regexp(); err != nil {
+				return errors.Wrapf(err, "invalid database regular expression %s", db)
 			}
 		}
+	}
 
-		if entry.ConnType != hba.ConnLocal {
-			// Verify the user is not requesting hostname-based validation,
-			// which is not yet implemented.
-			addrOk := true
-			switch t := entry.Address.(type) {
-			case *net.IPNet:
-			case hba.AnyAddr:
-			case hba.String:
-				addrOk = t.IsKeyword("all")
-			default:
-				addrOk = false
+	for _, user := range entry.User {
+		if user.IsRegexp() {
+			if _, err := user.Regexp(); err != nil {
+				return errors.Wrapf(err, "invalid user regular expression %s", user)
 			}
-			if !addrOk {
-				return errors.WithHint(
-					unimplemented.New("hba-hostnames", "hostname-based HBA rules are not supported"),
-					"List the numeric CIDR notation instead, for example: 127.0.0.1/8.\n"+
-						"Alternatively, use 'all' (without quotes) for any IPv4/IPv6 address.")
+		}
+	}
+
+	if entry.ConnType != hba.ConnLocal {
+		// Verify the address is either a CIDR, the keyword "all"
+		// or a host name. Host names are matched using the names
+		// of the client, obtained with a reverse DNS lookup of its
+		// IP address.
+		switch t := entry.Address.(type) {
+		case *net.IPNet:
+		case hba.AnyAddr:
+		case hba.String:
+			if err := checkHBAHostname(t.Value); err != nil {
+				return err
 			}
+		default:
+			return errors.AssertionFailedf("unknown address type: %T", entry.Address)
 		}
+	}
 
-		// Verify that the auth method is supported.
-		method, ok := hbaAuthMethods[entry.Method.Value]
-		if !ok || method.fn == nil {
-			return errors.WithHintf(unimplemented.Newf("hba-method-"+entry.Method.Value,
-				"unknown auth method %q", entry.Method.Value),
-				"Supported methods: %s", listRegisteredMethods())
+	// Verify that the auth method is supported.
+	method, ok := hbaAuthMethods[entry.Method.Value]
+	if !ok || method.fn == nil {
+		return errors.WithHintf(unimplemented.Newf("hba-method-"+entry.Method.Value,
+			"unknown auth method %q", entry.Method.Value),
+			"Supported methods: %s", listRegisteredMethods())
+	}
+	// The TLS policy applies to all the methods.
+	entry, _, err := splitHBATLSPolicy(entry)
+	if err != nil {
+		return err
+	}
+	// Run the per-method validation.
+	if check := hbaCheckHBAEntries[entry.Method.Value]; check != nil {
+		if err := check(values, entry); err != nil {
+			return err
 		}
-		// Run the per-method validation.
-		if check := hbaCheckHBAEntries[entry.Method.Value]; check != nil {
-			if err := check(values, entry); err != nil {
-				return err
+	}
+	return nil
+}
+
+// checkHBAHostname verifies that the address of an HBA rule is a
+// valid host name, optionally starting with a dot to match a domain
+// suffix.
+func checkHBAHostname(name string) error {
+	labels := strings.TrimSuffix(strings.TrimPrefix(name, "."), ".")
+	if labels == "" {
+		return errors.Newf("invalid host name %q", name)
+	}
+	for _, label := range strings.Split(labels, ".") {
+		if label == "" || len(label) > 63 ||
+			strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return errors.Newf("invalid host name %q", name)
+		}
+		for _, c := range label {
+			if !(c == '-' || c == '_' || (c >= '0' && c <= '9') ||
+				(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')) {
+				return errors.WithHint(errors.Newf("invalid host name %q", name),
+					"Use the CIDR notation for IP addresses, for example: 127.0.0.1/8.")
 			}
 		}
 	}
@@ -204,12 +256,7 @@ func ParseAndNormalize(val string) (*hba.Conf, error) {
 		return conf, err
 	}
 
-	if len(conf.Entries) == 0 || !(conf.Entries[0].Equivalent(rootEntry) || conf.Entries[0].Equivalent(rootLocalEntry)) {
-		entries := make([]hba.Entry, 1, len(conf.Entries)+1)
-		entries[0] = rootEntry
-		entries = append(entries, conf.Entries...)
-		conf.Entries = entries
-	}
+	addMandatoryRootRule(conf)
 
 	// Lookup and cache the auth methods.
 	for i := range conf.Entries {
@@ -227,6 +274,18 @@ func ParseAndNormalize(val string) (*hba.Conf, error) {
 	return conf, nil
 }
 
+// addMandatoryRootRule inserts a rule authenticating the root user at
+// the start of the configuration, unless an equivalent rule is present
+// already.
+func addMandatoryRootRule(conf *hba.Conf) {
+	if len(conf.Entries) == 0 || !(conf.Entries[0].Equivalent(rootEntry) || conf.Entries[0].Equivalent(rootLocalEntry)) {
+		entries := make([]hba.Entry, 1, len(conf.Entries)+1)
+		entries[0] = rootEntry
+		entries = append(entries, conf.Entries...)
+		conf.Entries = entries
+	}
+}
+
 var insecureEntry = hba.Entry{
 	ConnType: hba.ConnHostAny,
 	User:     []hba.String{{Value: "all", Quoted: false}},
@@ -283,9 +342,17 @@ local all all      password      # built-in CockroachDB default
 // The data returned by this method is also observable via the debug
 // endpoint /debug/hba_conf.
 func (s *Server) GetAuthenticationConfiguration() (*hba.Conf, *identmap.Conf) {
+	auth, idMap, _ := s.getAuthenticationConfiguration()
+	return auth, idMap
+}
+
+// getAuthenticationConfiguration is like GetAuthenticationConfiguration,
+// but also returns the counters of the rules of the HBA configuration.
+func (s *Server) getAuthenticationConfiguration() (*hba.Conf, *identmap.Conf, *hbaRuleStats) {
 	s.auth.RLock()
 	auth := s.auth.conf
 	idMap := s.auth.identityMap
+	ruleStats := s.auth.ruleStats
 	s.auth.RUnlock()
 
 	if auth == nil {
@@ -296,7 +363,7 @@ func (s *Server) GetAuthenticationConfiguration() (*hba.Conf, *identmap.Conf) {
 	if idMap == nil {
 		idMap = identmap.Empty()
 	}
-	return auth, idMap
+	return auth, idMap, ruleStats
 }
 
 // RegisterAuthMethod registers an AuthMethod for pgwire
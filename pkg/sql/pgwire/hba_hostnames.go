@@ -0,0 +1,101 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/util/contextutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// This file contains the logic to match the host names listed in the
+// address column of HBA rules.
+//
+// As in PostgreSQL, the host names of a client are determined with a
+// reverse DNS lookup of its IP address. Each name is then confirmed
+// with a forward lookup, which must resolve to the IP address of the
+// client; this prevents a client that controls the reverse DNS zone of
+// its address from claiming an arbitrary name. The lookups are only
+// performed when a rule with a host name is considered, and at most
+// once per connection.
+
+// hbaHostnameLookupEnabled is the cluster setting that controls
+// whether the host names of clients are looked up to match HBA rules.
+var hbaHostnameLookupEnabled = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"server.host_based_authentication.hostname_lookup.enabled",
+	"if set, the host names of clients are looked up using reverse DNS to match "+
+		"the HBA rules which specify a host name; otherwise, these rules never match",
+	true,
+).WithPublic()
+
+// hbaHostnameLookupTimeout is the cluster setting that bounds the
+// duration of the lookup of the host names of a client.
+var hbaHostnameLookupTimeout = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"server.host_based_authentication.hostname_lookup.timeout",
+	"the maximum duration of the DNS lookups performed to match the HBA rules "+
+		"which specify a host name",
+	5*time.Second,
+	settings.PositiveDuration,
+).WithPublic()
+
+// hostnameResolver is the interface used to look up the host names of
+// clients. It is implemented by *net.Resolver.
+type hostnameResolver interface {
+	LookupAddr(ctx context.Context, addr string) ([]string, error)
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+var _ hostnameResolver = net.DefaultResolver
+
+// lookupClientHostnames returns the host names of the client with the
+// given IP address whose forward lookup resolves to that address.
+// Lookup errors are logged and result in no names, so that the HBA
+// rules with host names do not match.
+func lookupClientHostnames(
+	ctx context.Context, sv *settings.Values, resolver hostnameResolver, ip net.IP,
+) []string {
+	if !hbaHostnameLookupEnabled.Get(sv) {
+		return nil
+	}
+	var confirmed []string
+	err := contextutil.RunWithTimeout(ctx, "hba hostname lookup", hbaHostnameLookupTimeout.Get(sv),
+		func(ctx context.Context) error {
+			names, err := resolver.LookupAddr(ctx, ip.String())
+			if err != nil {
+				return err
+			}
+			for _, name := range names {
+				addrs, err := resolver.LookupIPAddr(ctx, name)
+				if err != nil {
+					log.Infof(ctx, "unable to confirm host name %q of client %s: %v", name, ip, err)
+					continue
+				}
+				for _, addr := range addrs {
+					if addr.IP.Equal(ip) {
+						confirmed = append(confirmed, name)
+						break
+					}
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		log.Infof(ctx, "unable to look up the host names of client %s: %v", ip, err)
+		return nil
+	}
+	return confirmed
+}
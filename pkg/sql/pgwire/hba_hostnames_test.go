@@ -0,0 +1,77 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeResolver is a hostnameResolver backed by static tables.
+type fakeResolver struct {
+	names map[string][]string
+	addrs map[string][]string
+}
+
+func (r fakeResolver) LookupAddr(_ context.Context, addr string) ([]string, error) {
+	names, ok := r.names[addr]
+	if !ok {
+		return nil, errors.Newf("no names for %s", addr)
+	}
+	return names, nil
+}
+
+func (r fakeResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	addrs, ok := r.addrs[host]
+	if !ok {
+		return nil, errors.Newf("no addresses for %s", host)
+	}
+	var res []net.IPAddr
+	for _, a := range addrs {
+		res = append(res, net.IPAddr{IP: net.ParseIP(a)})
+	}
+	return res, nil
+}
+
+func TestLookupClientHostnames(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	resolver := fakeResolver{
+		names: map[string][]string{
+			"10.0.0.1": {"app1.example.com.", "spoofed.example.org.", "unknown.example.com."},
+		},
+		addrs: map[string][]string{
+			"app1.example.com.":    {"10.0.0.2", "10.0.0.1"},
+			"spoofed.example.org.": {"192.168.0.1"},
+		},
+	}
+
+	// Only the names which resolve back to the client address are kept.
+	names := lookupClientHostnames(ctx, &st.SV, resolver, net.ParseIP("10.0.0.1"))
+	require.Equal(t, []string{"app1.example.com."}, names)
+
+	// A failed reverse lookup results in no names.
+	names = lookupClientHostnames(ctx, &st.SV, resolver, net.ParseIP("10.0.0.3"))
+	require.Empty(t, names)
+
+	// No lookup is performed when disabled.
+	hbaHostnameLookupEnabled.Override(ctx, &st.SV, false)
+	names = lookupClientHostnames(ctx, &st.SV, resolver, net.ParseIP("10.0.0.1"))
+	require.Empty(t, names)
+}
@@ -0,0 +1,125 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"strconv"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/hba"
+	"github.com/cockroachdb/cockroach/pkg/util/metric"
+	"github.com/cockroachdb/cockroach/pkg/util/metric/aggmetric"
+)
+
+// The connections matched by each rule of the active HBA configuration,
+// and the outcome of their authentication, are counted so that
+// operators can detect the rules which never match and the connections
+// which fall through to unexpected rules. The counts are exported as
+// metrics labeled with the rule number, and reported by SHOW HBA RULES.
+// They start over when the configuration changes.
+
+// Fully-qualified names for the HBA rule metrics.
+var (
+	MetaHBARuleMatches = metric.Metadata{
+		Name:        "sql.auth.hba_rule.matches",
+		Help:        "Number of client connections matched by each rule of the HBA configuration",
+		Measurement: "Connections",
+		Unit:        metric.Unit_COUNT,
+	}
+	MetaHBARuleSuccesses = metric.Metadata{
+		Name:        "sql.auth.hba_rule.successes",
+		Help:        "Number of client connections successfully authenticated with each rule of the HBA configuration",
+		Measurement: "Connections",
+		Unit:        metric.Unit_COUNT,
+	}
+	MetaHBARuleFailures = metric.Metadata{
+		Name:        "sql.auth.hba_rule.failures",
+		Help:        "Number of client connections which failed to authenticate with each rule of the HBA configuration",
+		Measurement: "Connections",
+		Unit:        metric.Unit_COUNT,
+	}
+)
+
+// hbaRuleLabel is the label of the HBA rule metrics, which identifies
+// the rule by its (1-based) position in the configuration.
+const hbaRuleLabel = "rule"
+
+// hbaRuleCounters counts the connections matched by an HBA rule.
+type hbaRuleCounters struct {
+	matches   *aggmetric.Counter
+	successes *aggmetric.Counter
+	failures  *aggmetric.Counter
+}
+
+// recordMatch records that the rule matched a connection. It is a
+// no-op if r is nil, i.e. if no rule matched.
+func (r *hbaRuleCounters) recordMatch() {
+	if r == nil {
+		return
+	}
+	r.matches.Inc(1)
+}
+
+// recordOutcome records the outcome of the authentication of a
+// connection matched by the rule. It is a no-op if r is nil.
+func (r *hbaRuleCounters) recordOutcome(success bool) {
+	if r == nil {
+		return
+	}
+	if success {
+		r.successes.Inc(1)
+	} else {
+		r.failures.Inc(1)
+	}
+}
+
+// hbaRuleStats holds the counters of the rules of an HBA configuration.
+type hbaRuleStats struct {
+	rules []hbaRuleCounters
+}
+
+// newHBARuleStats creates the counters of the rules of the given HBA
+// configuration, as children of the HBA rule metrics.
+func newHBARuleStats(metrics *ServerMetrics, conf *hba.Conf) *hbaRuleStats {
+	s := &hbaRuleStats{rules: make([]hbaRuleCounters, len(conf.Entries))}
+	for i := range s.rules {
+		rule := strconv.Itoa(i + 1)
+		s.rules[i] = hbaRuleCounters{
+			matches:   metrics.HBARuleMatches.AddChild(rule),
+			successes: metrics.HBARuleSuccesses.AddChild(rule),
+			failures:  metrics.HBARuleFailures.AddChild(rule),
+		}
+	}
+	return s
+}
+
+// destroy disconnects the counters from the HBA rule metrics, so that
+// the counters of another configuration can take their place. The
+// aggregated metrics retain their values.
+func (s *hbaRuleStats) destroy() {
+	if s == nil {
+		return
+	}
+	for _, r := range s.rules {
+		r.matches.Destroy()
+		r.successes.Destroy()
+		r.failures.Destroy()
+	}
+}
+
+// rule returns the counters of the rule at the given index of the
+// configuration, or nil if idx is negative (no rule matched) or s is
+// nil.
+func (s *hbaRuleStats) rule(idx int) *hbaRuleCounters {
+	if s == nil || idx < 0 || idx >= len(s.rules) {
+		return nil
+	}
+	return &s.rules[idx]
+}
@@ -0,0 +1,188 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/hba"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+var _ sql.HBARuleLister = (*Server)(nil)
+
+// ListHBARules implements the sql.HBARuleLister interface.
+//
+// A proposed configuration is parsed and normalized like the cluster
+// setting would be, but the rules which use unsupported features are
+// reported instead of rejecting the whole configuration. The rules
+// which can never match because an earlier rule matches all their
+// connections are reported too. The rules of the active configuration
+// report how many connections they matched since it was loaded.
+func (s *Server) ListHBARules(
+	ctx context.Context, config *string, conn *sql.HBAConnection,
+) ([]sql.HBARule, error) {
+	var conf *hba.Conf
+	var ruleStats *hbaRuleStats
+	switch {
+	case config == nil:
+		conf, _, ruleStats = s.getAuthenticationConfiguration()
+	case *config == "":
+		// An empty configuration means "use the default".
+		conf = DefaultHBAConfig
+	default:
+		var err error
+		conf, err = hba.ParseAndNormalize(*config)
+		if err != nil {
+			return nil, err
+		}
+		if len(conf.Entries) == 0 {
+			return nil, errors.WithHint(errors.New("no entries"),
+				"To use the default configuration, assign the empty string ('').")
+		}
+		addMandatoryRootRule(conf)
+	}
+
+	sv := &s.execCfg.Settings.SV
+	rules := make([]sql.HBARule, len(conf.Entries))
+	valid := make([]bool, len(conf.Entries))
+	for i := range conf.Entries {
+		entry := &conf.Entries[i]
+		rules[i] = sql.HBARule{
+			ConnType: entry.ConnType.String(),
+			Database: entry.DatabaseString(),
+			User:     entry.UserString(),
+			Address:  entry.AddressString(),
+			Method:   entry.Method.String(),
+			Options:  entry.OptionsString(),
+		}
+		if c := ruleStats.rule(i); c != nil {
+			rules[i].Counts = &sql.HBARuleCounts{
+				Matches:   c.matches.Value(),
+				Successes: c.successes.Value(),
+				Failures:  c.failures.Value(),
+			}
+		}
+		if err := checkHBAEntry(sv, *entry); err != nil {
+			rules[i].Error = err.Error()
+			continue
+		}
+		valid[i] = true
+		for j := 0; j < i; j++ {
+			if valid[j] && hbaEntryShadows(&conf.Entries[j], entry) {
+				rules[i].Error = fmt.Sprintf("unreachable: rule %d matches all the connections of this rule", j+1)
+				break
+			}
+		}
+	}
+
+	if conn != nil {
+		var connType hba.ConnType
+		var ip net.IP
+		switch conn.ConnType {
+		case "local":
+			connType = hba.ConnLocal
+		case "hostnossl":
+			connType = hba.ConnHostNoSSL
+		default:
+			connType = hba.ConnHostSSL
+		}
+		if connType != hba.ConnLocal {
+			if ip = net.ParseIP(conn.Address); ip == nil {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"invalid IP address: %q", conn.Address)
+			}
+		}
+		// The invalid rules are ignored, as they would prevent the
+		// configuration from being applied.
+		var validConf hba.Conf
+		var validIdx []int
+		for i := range conf.Entries {
+			if valid[i] {
+				validConf.Entries = append(validConf.Entries, conf.Entries[i])
+				validIdx = append(validIdx, i)
+			}
+		}
+		idx, err := findMatchingHBAEntry(ctx, sv, net.DefaultResolver, &validConf, connType, ip,
+			conn.User, conn.Database)
+		if err != nil {
+			return nil, err
+		}
+		if idx >= 0 {
+			rules[validIdx[idx]].Matches = true
+		}
+	}
+	return rules, nil
+}
+
+// hbaEntryShadows returns true iff the HBA rule a matches all the
+// connections matched by the rule b, so that b can never match if it
+// comes after a. The check is conservative: a regular expression only
+// shadows an identical one.
+func hbaEntryShadows(a, b *hba.Entry) bool {
+	if a.ConnType&b.ConnType != b.ConnType {
+		return false
+	}
+	if !hbaStringsContain(a.Database, b.Database) || !hbaStringsContain(a.User, b.User) {
+		return false
+	}
+	if b.ConnType == hba.ConnLocal {
+		// Local rules do not have an address.
+		return true
+	}
+	switch addr := a.Address.(type) {
+	case hba.AnyAddr:
+		return true
+	case *net.IPNet:
+		other, ok := b.Address.(*net.IPNet)
+		if !ok {
+			return false
+		}
+		aOnes, aBits := addr.Mask.Size()
+		bOnes, bBits := other.Mask.Size()
+		return aBits == bBits && aOnes <= bOnes && addr.Contains(other.IP)
+	case hba.String:
+		other, ok := b.Address.(hba.String)
+		return ok && other == addr
+	default:
+		return false
+	}
+}
+
+// hbaStringsContain returns true iff the list of values a of an HBA
+// rule includes all the values of the list b. A nil list stands for
+// all the values.
+func hbaStringsContain(a, b []hba.String) bool {
+	if a == nil {
+		return true
+	}
+	if b == nil {
+		return false
+	}
+	for _, bv := range b {
+		found := false
+		for _, av := range a {
+			if av == bv {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/hba"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHBAEntryShadows(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testCases := []struct {
+		first, second string
+		shadows       bool
+	}{
+		{"host all all all cert", "host all alice 10.0.0.0/8 password", true},
+		{"host all alice 10.0.0.0/8 cert", "host all all 10.0.0.0/8 password", false},
+		{"host all all 10.0.0.0/8 cert", "host all all 10.1.0.0/16 password", true},
+		{"host all all 10.1.0.0/16 cert", "host all all 10.0.0.0/8 password", false},
+		{"host all all 10.0.0.0/8 cert", "host all all ::1/128 password", false},
+		{"hostssl all all all cert", "host all all all password", false},
+		{"host all all all cert", "hostnossl all all all password", true},
+		{"host all all all cert", "local all all password", false},
+		{"local all all trust", "local db alice password", true},
+		{"host db1,db2 all all cert", "host db1 all all password", true},
+		{"host db1 all all cert", "host db1,db2 all all password", false},
+		{"host all /^app_ all cert", "host all app_1 all password", false},
+		{"host all all .example.com cert", "host all all .example.com password", true},
+		{"host all all .example.com cert", "host all all db.example.com password", false},
+	}
+	for _, tc := range testCases {
+		conf, err := hba.ParseAndNormalize(tc.first + "\n" + tc.second)
+		require.NoError(t, err)
+		require.Len(t, conf.Entries, 2)
+		require.Equal(t, tc.shadows, hbaEntryShadows(&conf.Entries[0], &conf.Entries[1]),
+			"%s / %s", tc.first, tc.second)
+	}
+}
+
+func TestHBARuleStats(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	metrics := makeServerMetrics(sql.MemoryMetrics{}, time.Minute)
+	stats := newHBARuleStats(&metrics, DefaultHBAConfig)
+	require.Nil(t, stats.rule(-1))
+	require.Nil(t, stats.rule(len(DefaultHBAConfig.Entries)))
+
+	// A connection which matches no rule is not counted.
+	var noRule *hbaRuleCounters
+	noRule.recordMatch()
+	noRule.recordOutcome(false /* success */)
+
+	first, second := stats.rule(0), stats.rule(1)
+	first.recordMatch()
+	first.recordOutcome(true /* success */)
+	second.recordMatch()
+	second.recordOutcome(false /* success */)
+	second.recordMatch()
+	require.Equal(t, int64(1), first.matches.Value())
+	require.Equal(t, int64(1), first.successes.Value())
+	require.Equal(t, int64(0), first.failures.Value())
+	require.Equal(t, int64(2), second.matches.Value())
+	require.Equal(t, int64(0), second.successes.Value())
+	require.Equal(t, int64(1), second.failures.Value())
+	require.Equal(t, int64(3), metrics.HBARuleMatches.Count())
+
+	// The counts start over with a new configuration, but the aggregated
+	// metrics retain their values.
+	stats.destroy()
+	stats = newHBARuleStats(&metrics, DefaultHBAConfig)
+	require.Equal(t, int64(0), stats.rule(1).matches.Value())
+	require.Equal(t, int64(3), metrics.HBARuleMatches.Count())
+	require.Equal(t, int64(1), metrics.HBARuleSuccesses.Count())
+	require.Equal(t, int64(1), metrics.HBARuleFailures.Count())
+}
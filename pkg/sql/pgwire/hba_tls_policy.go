@@ -0,0 +1,161 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"crypto/tls"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/hba"
+	"github.com/cockroachdb/errors"
+)
+
+// HBA rules can restrict the TLS parameters of the connections they
+// match, regardless of the authentication method, using the following
+// options:
+//
+//   - tls_min_version: the minimum TLS version, 1.2 or 1.3.
+//   - tls_ciphers: the comma-separated list of the permitted cipher
+//     suites, using their IANA names, e.g. TLS_AES_128_GCM_SHA256.
+//
+// For example, to require TLS 1.3 from outside the private network:
+//
+//     hostssl all all 10.0.0.0/8 cert-password
+//     hostssl all all all cert-password tls_min_version=1.3
+//
+// Connections which match a rule but do not satisfy its TLS policy,
+// including the connections without TLS matched by a host rule, are
+// rejected; the following rules are not considered.
+
+const (
+	hbaOptionTLSMinVersion = "tls_min_version"
+	hbaOptionTLSCiphers    = "tls_ciphers"
+)
+
+// hbaTLSVersions maps the values of the tls_min_version option to TLS
+// versions.
+var hbaTLSVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// tlsVersionName returns the name of the given TLS version.
+func tlsVersionName(v uint16) string {
+	switch v {
+	case tls.VersionTLS10:
+		return "1.0"
+	case tls.VersionTLS11:
+		return "1.1"
+	case tls.VersionTLS12:
+		return "1.2"
+	case tls.VersionTLS13:
+		return "1.3"
+	default:
+		return "unknown"
+	}
+}
+
+// hbaTLSPolicy is the TLS policy of an HBA rule.
+type hbaTLSPolicy struct {
+	// minVersion is the minimum TLS version, or 0 if unrestricted.
+	minVersion uint16
+	// cipherSuites are the permitted cipher suites, or nil if
+	// unrestricted.
+	cipherSuites []uint16
+}
+
+func (p *hbaTLSPolicy) isSet() bool {
+	return p.minVersion != 0 || p.cipherSuites != nil
+}
+
+// lookupCipherSuite returns the ID of the cipher suite with the given
+// name.
+func lookupCipherSuite(name string) (uint16, bool) {
+	for _, list := range [][]*tls.CipherSuite{tls.CipherSuites(), tls.InsecureCipherSuites()} {
+		for _, cs := range list {
+			if cs.Name == name {
+				return cs.ID, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// splitHBATLSPolicy separates the TLS policy of an HBA rule from the
+// options of its authentication method. The resulting entry does not
+// contain the TLS options, so that it can be validated and used by
+// the authentication method.
+func splitHBATLSPolicy(entry hba.Entry) (hba.Entry, hbaTLSPolicy, error) {
+	var policy hbaTLSPolicy
+	var options [][2]string
+	var quotes []bool
+	for i, op := range entry.Options {
+		switch op[0] {
+		case hbaOptionTLSMinVersion:
+			v, ok := hbaTLSVersions[op[1]]
+			if !ok {
+				return entry, policy, errors.Newf("invalid %s: %q (must be 1.2 or 1.3)", op[0], op[1])
+			}
+			policy.minVersion = v
+		case hbaOptionTLSCiphers:
+			policy.cipherSuites = nil
+			for _, name := range strings.Split(op[1], ",") {
+				id, ok := lookupCipherSuite(strings.TrimSpace(name))
+				if !ok {
+					return entry, policy, errors.WithHint(
+						errors.Newf("invalid %s: unknown cipher suite %q", op[0], name),
+						`Cipher suites are identified by their IANA names, e.g. TLS_AES_128_GCM_SHA256. `+
+							`Lists of cipher suites must be quoted together with the option name, `+
+							`e.g. "tls_ciphers=TLS_AES_128_GCM_SHA256,TLS_AES_256_GCM_SHA384".`)
+				}
+				policy.cipherSuites = append(policy.cipherSuites, id)
+			}
+		default:
+			options = append(options, op)
+			quotes = append(quotes, i < len(entry.OptionQuotes) && entry.OptionQuotes[i])
+		}
+	}
+	if !policy.isSet() {
+		return entry, policy, nil
+	}
+	if entry.ConnType&hba.ConnHostSSL == 0 {
+		return entry, policy, errors.Newf("the %s and %s options require a TLS connection type (host or hostssl)",
+			hbaOptionTLSMinVersion, hbaOptionTLSCiphers)
+	}
+	entry.Options = options
+	entry.OptionQuotes = quotes
+	return entry, policy, nil
+}
+
+// check returns an error if the connection does not satisfy the
+// policy.
+func (p *hbaTLSPolicy) check(connType hba.ConnType, tlsState tls.ConnectionState) error {
+	if !p.isSet() {
+		return nil
+	}
+	if connType != hba.ConnHostSSL {
+		return errors.New("the matching HBA rule requires a TLS connection")
+	}
+	if tlsState.Version < p.minVersion {
+		return errors.Newf("TLS version %s is below the minimum TLS version %s required by the matching HBA rule",
+			tlsVersionName(tlsState.Version), tlsVersionName(p.minVersion))
+	}
+	if p.cipherSuites != nil {
+		for _, id := range p.cipherSuites {
+			if id == tlsState.CipherSuite {
+				return nil
+			}
+		}
+		return errors.Newf("TLS cipher suite %s is not permitted by the matching HBA rule",
+			tls.CipherSuiteName(tlsState.CipherSuite))
+	}
+	return nil
+}
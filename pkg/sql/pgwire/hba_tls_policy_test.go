@@ -0,0 +1,74 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/hba"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHBATLSPolicy(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	tls12 := tls.ConnectionState{Version: tls.VersionTLS12, CipherSuite: tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}
+	tls13 := tls.ConnectionState{Version: tls.VersionTLS13, CipherSuite: tls.TLS_AES_256_GCM_SHA384}
+
+	testCases := []struct {
+		rule     string
+		options  string
+		parseErr string
+		connType hba.ConnType
+		state    tls.ConnectionState
+		checkErr string
+	}{
+		{rule: "host all all all password", connType: hba.ConnHostNoSSL},
+		{rule: `host all all all ldap ldapserver=a tls_min_version=1.3`, options: "ldapserver=a",
+			connType: hba.ConnHostSSL, state: tls13},
+		{rule: `hostssl all all all cert tls_min_version=1.3`,
+			connType: hba.ConnHostSSL, state: tls12, checkErr: "TLS version 1.2 is below the minimum TLS version 1.3"},
+		{rule: `host all all all cert-password tls_min_version=1.2`,
+			connType: hba.ConnHostNoSSL, checkErr: "requires a TLS connection"},
+		{rule: `hostssl all all all cert "tls_ciphers=TLS_AES_256_GCM_SHA384, TLS_AES_128_GCM_SHA256"`,
+			connType: hba.ConnHostSSL, state: tls13},
+		{rule: `hostssl all all all cert tls_ciphers=TLS_AES_256_GCM_SHA384`,
+			connType: hba.ConnHostSSL, state: tls12,
+			checkErr: "TLS cipher suite TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256 is not permitted"},
+		{rule: `hostssl all all all cert tls_min_version=1.1`, parseErr: "must be 1.2 or 1.3"},
+		{rule: `hostssl all all all cert tls_ciphers=AES`, parseErr: `unknown cipher suite "AES"`},
+		{rule: `hostnossl all all all password tls_min_version=1.2`, parseErr: "require a TLS connection type"},
+		{rule: `local all all password tls_min_version=1.2`, parseErr: "require a TLS connection type"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.rule, func(t *testing.T) {
+			conf, err := hba.Parse(tc.rule)
+			require.NoError(t, err)
+			entry, policy, err := splitHBATLSPolicy(conf.Entries[0])
+			if tc.parseErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.parseErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.options, entry.OptionsString())
+			err = policy.check(tc.connType, tc.state)
+			if tc.checkErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.checkErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
+)
+
+// The login sequence of a connection (the evaluation of the HBA
+// configuration, the lookup of the authentication info of the user in
+// the cache and in the system tables, the verification of the
+// credentials, etc) can take hundreds of milliseconds. When
+// sql.trace.login.enabled is set, it is recorded and handed over to the
+// session, which includes it in the session trace (SHOW TRACE FOR
+// SESSION) and in the statement bundles, so that slow connection setups
+// can be explained.
+
+// traceLoginEnabled is the cluster setting that enables the recording of
+// the login sequence of the connections.
+var traceLoginEnabled = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.trace.login.enabled",
+	"set to true to record the login sequence of new SQL connections and include "+
+		"it in the session traces and statement bundles",
+	false,
+)
+
+// startLoginTrace starts the span recording the login sequence of the
+// connection, if enabled. The returned span is nil otherwise.
+func startLoginTrace(
+	ctx context.Context, execCfg *sql.ExecutorConfig,
+) (context.Context, *tracing.Span) {
+	if !traceLoginEnabled.Get(&execCfg.Settings.SV) {
+		return ctx, nil
+	}
+	return tracing.EnsureChildSpan(ctx, execCfg.AmbientCtx.Tracer, "pgwire-login",
+		tracing.WithRecording(tracing.RecordingVerbose))
+}
+
+// finishLoginTrace finishes the span started by startLoginTrace and
+// returns its recording, or nil if the login sequence was not recorded.
+func finishLoginTrace(sp *tracing.Span) tracing.Recording {
+	if sp == nil {
+		return nil
+	}
+	return sp.FinishAndGetRecording(tracing.RecordingVerbose)
+}
@@ -588,7 +588,7 @@ func TestPGPreparedQuery(t *testing.T) {
 			baseTest.SetArgs("def"),
 			baseTest.SetArgs("waa"),
 		}},
-		{"SHOW USERS", []preparedQueryTest{
+		{"SELECT username, options, member_of FROM [SHOW USERS]", []preparedQueryTest{
 			baseTest.Results("abc", "", "{}").
 				Results("admin", "", "{}").
 				Results("root", "", "{admin}").
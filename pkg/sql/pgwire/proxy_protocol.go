@@ -0,0 +1,183 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// Load balancers and proxies in front of the SQL listener can forward
+// the address of the original client using version 2 of the PROXY
+// protocol (https://www.haproxy.org/download/2.5/doc/proxy-protocol.txt).
+// The header is only accepted from the peers listed in the
+// server.sql_proxy_protocol.trusted_cidrs cluster setting, and it is
+// mandatory for them. The client address it contains is then used in
+// place of the address of the proxy for the HBA rules, the
+// authentication rate limit and the logs.
+
+// proxyProtocolTrustedCIDRs is the cluster setting that holds the
+// address ranges of the proxies which send the PROXY protocol header.
+// The peers in these ranges can claim any client address, which the HBA
+// rules and the rate limits then trust, so the setting is system-only.
+var proxyProtocolTrustedCIDRs = settings.RegisterValidatedStringSetting(
+	settings.SystemOnly,
+	"server.sql_proxy_protocol.trusted_cidrs",
+	"comma-separated list of address ranges, in CIDR notation, of the load balancers "+
+		"and proxies which send a PROXY protocol v2 header on SQL connections; the client "+
+		"address in the header is used for HBA rules, rate limiting and logging",
+	"",
+	func(_ *settings.Values, s string) error {
+		_, err := parseCIDRList(s)
+		return err
+	},
+)
+
+// proxyProtocolHeaderTimeout is the time allowed to receive the PROXY
+// protocol header after the connection is accepted.
+const proxyProtocolHeaderTimeout = 10 * time.Second
+
+// proxyProtocolSignature starts every PROXY protocol v2 header.
+var proxyProtocolSignature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+const (
+	proxyProtocolCmdLocal = 0x0
+	proxyProtocolCmdProxy = 0x1
+
+	proxyProtocolFamilyInet  = 0x1
+	proxyProtocolFamilyInet6 = 0x2
+
+	proxyProtocolTransportStream = 0x1
+)
+
+// MatchProxyProtocol returns true if rd starts with a PROXY protocol v2
+// header.
+func MatchProxyProtocol(rd io.Reader) bool {
+	buf := make([]byte, len(proxyProtocolSignature))
+	if _, err := io.ReadFull(rd, buf); err != nil {
+		return false
+	}
+	return bytes.Equal(buf, proxyProtocolSignature)
+}
+
+// proxyConn is a connection received through a proxy, whose remote
+// address is the address of the original client.
+type proxyConn struct {
+	net.Conn
+	remoteAddr net.Addr
+}
+
+// RemoteAddr implements the net.Conn interface.
+func (c *proxyConn) RemoteAddr() net.Addr {
+	return c.remoteAddr
+}
+
+// MaybeReadProxyProtocolHeader reads the PROXY protocol header of a
+// connection received from a trusted proxy, and returns a connection
+// whose remote address is the address of the original client. Other
+// connections are returned as is.
+func (s *Server) MaybeReadProxyProtocolHeader(
+	ctx context.Context, conn net.Conn,
+) (net.Conn, error) {
+	peer, ok := conn.RemoteAddr().(*net.TCPAddr)
+	if !ok {
+		return conn, nil
+	}
+	trusted, err := parseCIDRList(proxyProtocolTrustedCIDRs.Get(&s.execCfg.Settings.SV))
+	if err != nil {
+		return nil, err
+	}
+	isTrusted := false
+	for _, ipNet := range trusted {
+		if ipNet.Contains(peer.IP) {
+			isTrusted = true
+			break
+		}
+	}
+	if !isTrusted {
+		return conn, nil
+	}
+
+	if err := conn.SetReadDeadline(timeutil.Now().Add(proxyProtocolHeaderTimeout)); err != nil {
+		return nil, err
+	}
+	clientAddr, err := readProxyProtocolHeader(conn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading PROXY protocol header from %s", peer)
+	}
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		return nil, err
+	}
+	if clientAddr == nil {
+		// The proxy connected on its own behalf, e.g. for a health check.
+		return conn, nil
+	}
+	return &proxyConn{Conn: conn, remoteAddr: clientAddr}, nil
+}
+
+// readProxyProtocolHeader reads a PROXY protocol v2 header from rd and
+// returns the source address it contains. The result is nil if the
+// header does not carry the address of a TCP client, in which case the
+// connection is used as is.
+func readProxyProtocolHeader(rd io.Reader) (*net.TCPAddr, error) {
+	var hdr [16]byte
+	if _, err := io.ReadFull(rd, hdr[:]); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(hdr[:12], proxyProtocolSignature) {
+		return nil, errors.New("missing PROXY protocol v2 signature")
+	}
+	if version := hdr[12] >> 4; version != 2 {
+		return nil, errors.Newf("unsupported PROXY protocol version %d", version)
+	}
+	cmd := hdr[12] & 0xf
+	family, transport := hdr[13]>>4, hdr[13]&0xf
+	payload := make([]byte, binary.BigEndian.Uint16(hdr[14:16]))
+	if _, err := io.ReadFull(rd, payload); err != nil {
+		return nil, err
+	}
+
+	switch cmd {
+	case proxyProtocolCmdLocal:
+		return nil, nil
+	case proxyProtocolCmdProxy:
+	default:
+		return nil, errors.Newf("unsupported PROXY protocol command %d", cmd)
+	}
+	if transport != proxyProtocolTransportStream {
+		return nil, nil
+	}
+	var ipLen int
+	switch family {
+	case proxyProtocolFamilyInet:
+		ipLen = net.IPv4len
+	case proxyProtocolFamilyInet6:
+		ipLen = net.IPv6len
+	default:
+		return nil, nil
+	}
+	// The addresses are followed by optional TLVs, which are ignored.
+	if len(payload) < 2*ipLen+4 {
+		return nil, errors.New("truncated PROXY protocol addresses")
+	}
+	return &net.TCPAddr{
+		IP:   net.IP(append([]byte(nil), payload[:ipLen]...)),
+		Port: int(binary.BigEndian.Uint16(payload[2*ipLen:])),
+	}, nil
+}
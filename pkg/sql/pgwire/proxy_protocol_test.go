@@ -0,0 +1,103 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+// makeProxyProtocolHeader encodes a PROXY protocol v2 header.
+func makeProxyProtocolHeader(cmd, family byte, src, dst *net.TCPAddr, tlvs []byte) []byte {
+	var addrs []byte
+	if src != nil {
+		ip, dstIP := src.IP.To4(), dst.IP.To4()
+		if family == proxyProtocolFamilyInet6 {
+			ip, dstIP = src.IP.To16(), dst.IP.To16()
+		}
+		addrs = append(append(addrs, ip...), dstIP...)
+		addrs = append(addrs, byte(src.Port>>8), byte(src.Port), byte(dst.Port>>8), byte(dst.Port))
+	}
+	addrs = append(addrs, tlvs...)
+	hdr := append([]byte(nil), proxyProtocolSignature...)
+	hdr = append(hdr, 0x20|cmd, family<<4|proxyProtocolTransportStream)
+	hdr = append(hdr, byte(len(addrs)>>8), byte(len(addrs)))
+	return append(hdr, addrs...)
+}
+
+func TestReadProxyProtocolHeader(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	dst := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 26257}
+	pgStartup := []byte{0, 0, 0, 8, 4, 210, 22, 47}
+
+	testCases := []struct {
+		name     string
+		header   []byte
+		expected string
+		err      string
+	}{
+		{
+			name: "ipv4",
+			header: makeProxyProtocolHeader(proxyProtocolCmdProxy, proxyProtocolFamilyInet,
+				&net.TCPAddr{IP: net.ParseIP("192.168.1.10"), Port: 51234}, dst, nil),
+			expected: "192.168.1.10:51234",
+		},
+		{
+			name: "ipv6 with TLVs",
+			header: makeProxyProtocolHeader(proxyProtocolCmdProxy, proxyProtocolFamilyInet6,
+				&net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 443},
+				&net.TCPAddr{IP: net.ParseIP("2001:db8::2"), Port: 26257}, []byte{0x04, 0, 1, 0}),
+			expected: "[2001:db8::1]:443",
+		},
+		{
+			name:   "local",
+			header: makeProxyProtocolHeader(proxyProtocolCmdLocal, 0, nil, nil, nil),
+		},
+		{
+			name:   "missing header",
+			header: append(append([]byte(nil), pgStartup...), pgStartup...),
+			err:    "missing PROXY protocol v2 signature",
+		},
+		{
+			name: "truncated addresses",
+			header: makeProxyProtocolHeader(proxyProtocolCmdProxy, proxyProtocolFamilyInet6, nil, nil,
+				[]byte{1, 2, 3, 4}),
+			err: "truncated PROXY protocol addresses",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rd := bytes.NewReader(append(append([]byte(nil), tc.header...), pgStartup...))
+			addr, err := readProxyProtocolHeader(rd)
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expected == "" {
+				require.Nil(t, addr)
+			} else {
+				require.Equal(t, tc.expected, addr.String())
+			}
+			// The header is consumed entirely, and nothing more.
+			require.Equal(t, len(pgStartup), rd.Len())
+		})
+	}
+
+	require.True(t, MatchProxyProtocol(bytes.NewReader(testCases[0].header)))
+	require.False(t, MatchProxyProtocol(bytes.NewReader(pgStartup)))
+}
@@ -46,6 +46,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
 	"github.com/cockroachdb/cockroach/pkg/util/metric"
+	"github.com/cockroachdb/cockroach/pkg/util/metric/aggmetric"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
@@ -235,8 +236,18 @@ type Server struct {
 		syncutil.RWMutex
 		conf        *hba.Conf
 		identityMap *identmap.Conf
+		// ruleStats counts the connections matched by the rules of conf.
+		ruleStats *hbaRuleStats
 	}
 
+	// authRateLimiter limits the rate of authentication attempts per
+	// client IP address.
+	authRateLimiter authRateLimiter
+
+	// authFailureDelayer delays the responses to repeated failed
+	// authentication attempts.
+	authFailureDelayer authFailureDelayer
+
 	sqlMemoryPool *mon.BytesMonitor
 	connMonitor   *mon.BytesMonitor
 
@@ -276,6 +287,9 @@ type ServerMetrics struct {
 	PGWireCancelTotalCount      *metric.Counter
 	PGWireCancelIgnoredCount    *metric.Counter
 	PGWireCancelSuccessfulCount *metric.Counter
+	HBARuleMatches              *aggmetric.AggCounter
+	HBARuleSuccesses            *aggmetric.AggCounter
+	HBARuleFailures             *aggmetric.AggCounter
 	ConnMemMetrics              sql.BaseMemoryMetrics
 	SQLMemMetrics               sql.MemoryMetrics
 }
@@ -292,6 +306,9 @@ func makeServerMetrics(
 		PGWireCancelTotalCount:      metric.NewCounter(MetaPGWireCancelTotal),
 		PGWireCancelIgnoredCount:    metric.NewCounter(MetaPGWireCancelIgnored),
 		PGWireCancelSuccessfulCount: metric.NewCounter(MetaPGWireCancelSuccessful),
+		HBARuleMatches:              aggmetric.NewCounter(MetaHBARuleMatches, hbaRuleLabel),
+		HBARuleSuccesses:            aggmetric.NewCounter(MetaHBARuleSuccesses, hbaRuleLabel),
+		HBARuleFailures:             aggmetric.NewCounter(MetaHBARuleFailures, hbaRuleLabel),
 		ConnMemMetrics:              sql.MakeBaseMemMetrics("conns", histogramWindow),
 		SQLMemMetrics:               sqlMemMetrics,
 	}
@@ -353,6 +370,11 @@ func MakeServer(
 	server.mu.connCancelMap = make(cancelChanMap)
 	server.mu.Unlock()
 
+	server.auth.ruleStats = newHBARuleStats(&server.metrics, DefaultHBAConfig)
+
+	server.authRateLimiter.init(&st.SV, timeutil.DefaultTimeSource{})
+	server.authFailureDelayer.init(&st.SV, timeutil.DefaultTimeSource{})
+
 	connAuthConf.SetOnChange(&st.SV, func(ctx context.Context) {
 		loadLocalHBAConfigUponRemoteSettingChange(
 			ambientCtx.AnnotateCtx(context.Background()), server, st)
@@ -846,13 +868,19 @@ func (s *Server) ServeConn(ctx context.Context, conn net.Conn, socketType Socket
 	ctx = logtags.AddTag(ctx, "client", log.SafeOperational(connDetails.RemoteAddress))
 	sp.SetTag("client", attribute.StringValue(connDetails.RemoteAddress))
 
+	// Limit the rate of authentication attempts from the client before
+	// doing any work on its behalf, in particular reading system tables.
+	if err := s.authRateLimiter.allow(sArgs.RemoteAddr); err != nil {
+		return s.sendErr(ctx, conn, err)
+	}
+
 	// If a test is hooking in some authentication option, load it.
 	var testingAuthHook func(context.Context) error
 	if k := s.execCfg.PGWireTestingKnobs; k != nil {
 		testingAuthHook = k.AuthHook
 	}
 
-	hbaConf, identMap := s.GetAuthenticationConfiguration()
+	hbaConf, identMap, hbaRuleStats := s.getAuthenticationConfiguration()
 
 	// Defer the rest of the processing to the connection handler.
 	// This includes authentication.
@@ -861,13 +889,18 @@ func (s *Server) ServeConn(ctx context.Context, conn net.Conn, socketType Socket
 		reserved,
 		connStart,
 		authOptions{
-			connType:        connType,
-			connDetails:     connDetails,
-			insecure:        s.cfg.Insecure,
-			ie:              s.execCfg.InternalExecutor,
-			auth:            hbaConf,
-			identMap:        identMap,
-			testingAuthHook: testingAuthHook,
+			connType:         connType,
+			connDetails:      connDetails,
+			insecure:         s.cfg.Insecure,
+			ie:               s.execCfg.InternalExecutor,
+			auth:             hbaConf,
+			hbaRuleStats:     hbaRuleStats,
+			identMap:         identMap,
+			hostnameResolver: net.DefaultResolver,
+			failureDelayer:   &s.authFailureDelayer,
+			authAttempts:     s.execCfg.AuthAttempts,
+			lastLogins:       s.execCfg.LastLogins,
+			testingAuthHook:  testingAuthHook,
 		},
 	)
 	return nil
@@ -1069,10 +1102,22 @@ func loadParameter(ctx context.Context, key, value string, args *sql.SessionArgs
 	exists, configurable := sql.IsSessionVariableConfigurable(key)
 
 	switch {
+	case key == "logon_statements":
+		// The logon statements are configured for the roles, so that the
+		// client cannot skip or replace them. The value of the client is
+		// ignored in favor of the default setting of the role, if any.
+		log.Warningf(ctx, "ignoring client-provided configuration parameter: %q", key)
+
 	case exists && configurable:
 		args.SessionDefaults[key] = value
+		args.AddDefaultProvenance(sql.SessionDefaultProvenance{
+			Variable: key, Value: value, Source: sql.SessionDefaultSourceClient,
+		})
 	case sql.IsCustomOptionSessionVariable(key):
 		args.CustomOptionSessionDefaults[key] = value
+		args.AddDefaultProvenance(sql.SessionDefaultProvenance{
+			Variable: key, Value: value, Source: sql.SessionDefaultSourceClient,
+		})
 	case !exists:
 		if _, ok := sql.UnsupportedVars[key]; ok {
 			counter := sqltelemetry.UnimplementedClientStatusParameterCounter(key)
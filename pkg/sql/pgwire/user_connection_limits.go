@@ -0,0 +1,133 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"context"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// userConnectionLimits is the cluster setting that holds the maximum
+// number of concurrent connections of the users matching a pattern.
+// Unlike the CONNECTION LIMIT role option, it lets operators impose a
+// limit on all the users of an application without altering them.
+//
+// Like the CONNECTION LIMIT role option, the limits fail open: they are
+// resource controls rather than access controls, so when the sessions
+// of the user cannot be counted on some or all of the nodes, the
+// sessions which could not be counted are ignored rather than locking
+// the user out (see countUserSessions).
+var userConnectionLimits = settings.RegisterValidatedStringSetting(
+	settings.TenantWritable,
+	"server.max_connections_per_user",
+	"comma-separated list of <pattern>=<limit> entries; each user matching a "+
+		"pattern, in which * matches any sequence of characters, can have at most "+
+		"<limit> sessions open across the cluster. The first matching entry applies. "+
+		"Superusers are not subject to these limits. The sessions which cannot be "+
+		"counted because their node is unavailable are ignored",
+	"",
+	func(_ *settings.Values, s string) error {
+		_, err := parseUserConnectionLimits(s)
+		return err
+	},
+)
+
+// userConnectionLimit is an entry of the server.max_connections_per_user
+// cluster setting.
+type userConnectionLimit struct {
+	pattern string
+	limit   int32
+}
+
+// parseUserConnectionLimits parses the value of the
+// server.max_connections_per_user cluster setting.
+func parseUserConnectionLimits(s string) ([]userConnectionLimit, error) {
+	var limits []userConnectionLimit
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		eq := strings.LastIndexByte(entry, '=')
+		if eq < 0 {
+			return nil, errors.Newf("invalid entry %q: expected <pattern>=<limit>", entry)
+		}
+		pattern := strings.ToLower(strings.TrimSpace(entry[:eq]))
+		if pattern == "" {
+			return nil, errors.Newf("invalid entry %q: empty pattern", entry)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errors.Wrapf(err, "invalid pattern %q", pattern)
+		}
+		limit, err := strconv.ParseInt(strings.TrimSpace(entry[eq+1:]), 10, 32)
+		if err != nil || limit < 0 {
+			return nil, errors.Newf("invalid entry %q: the limit must be a non-negative integer", entry)
+		}
+		limits = append(limits, userConnectionLimit{pattern: pattern, limit: int32(limit)})
+	}
+	return limits, nil
+}
+
+// lookupUserConnectionLimit returns the limit of the first entry of the
+// server.max_connections_per_user cluster setting which matches the
+// user, if any.
+func lookupUserConnectionLimit(sv *settings.Values, user security.SQLUsername) (int32, bool) {
+	s := userConnectionLimits.Get(sv)
+	if s == "" {
+		return 0, false
+	}
+	// The setting was validated, so the error can be ignored.
+	limits, _ := parseUserConnectionLimits(s)
+	for _, l := range limits {
+		// Usernames cannot contain slashes, so path.Match behaves
+		// like a plain glob match.
+		if ok, _ := path.Match(l.pattern, user.Normalized()); ok {
+			return l.limit, true
+		}
+	}
+	return 0, false
+}
+
+// countUserSessions counts the sessions of the user open across the
+// cluster. The sessions which cannot be listed, because a node or the
+// whole request failed, are not counted: the connection limits fail open,
+// and the failures are only logged.
+func countUserSessions(
+	ctx context.Context, statusServer serverpb.SQLStatusServer, user security.SQLUsername,
+) int32 {
+	// The connection is not authenticated as an RPC client, so the
+	// request is made on behalf of the node and lists the sessions of
+	// all the users.
+	resp, err := statusServer.ListSessions(ctx, &serverpb.ListSessionsRequest{})
+	if err != nil {
+		log.Warningf(ctx, "unable to list the sessions to check the connection limit of user %s: %v",
+			user, err)
+		return 0
+	}
+	for _, e := range resp.Errors {
+		log.Warningf(ctx, "unable to list the sessions of node %d: %s", e.NodeID, e.Message)
+	}
+	var count int32
+	for i := range resp.Sessions {
+		if resp.Sessions[i].Username == user.Normalized() {
+			count++
+		}
+	}
+	return count
+}
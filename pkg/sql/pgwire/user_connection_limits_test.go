@@ -0,0 +1,117 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUserConnectionLimits(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	limits, err := parseUserConnectionLimits("")
+	require.NoError(t, err)
+	require.Empty(t, limits)
+
+	limits, err = parseUserConnectionLimits(" App_* = 10, reporting=0,")
+	require.NoError(t, err)
+	require.Equal(t, []userConnectionLimit{{"app_*", 10}, {"reporting", 0}}, limits)
+
+	for _, s := range []string{"app", "=10", "app=", "app=-1", "app=abc"} {
+		_, err := parseUserConnectionLimits(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestLookupUserConnectionLimit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	user := func(s string) security.SQLUsername {
+		return security.MakeSQLUsernameFromPreNormalizedString(s)
+	}
+
+	_, ok := lookupUserConnectionLimit(&st.SV, user("app_billing"))
+	require.False(t, ok)
+
+	userConnectionLimits.Override(ctx, &st.SV, "app_billing=2, app_*=5, *=100")
+	for _, tc := range []struct {
+		user  string
+		limit int32
+	}{
+		{"app_billing", 2},
+		{"app_reports", 5},
+		{"carl", 100},
+	} {
+		limit, ok := lookupUserConnectionLimit(&st.SV, user(tc.user))
+		require.True(t, ok, tc.user)
+		require.Equal(t, tc.limit, limit, tc.user)
+	}
+
+	userConnectionLimits.Override(ctx, &st.SV, "app_*=5")
+	_, ok = lookupUserConnectionLimit(&st.SV, user("carl"))
+	require.False(t, ok)
+}
+
+// listSessionsStatusServer is a status server which only implements
+// ListSessions.
+type listSessionsStatusServer struct {
+	serverpb.SQLStatusServer
+	resp *serverpb.ListSessionsResponse
+	err  error
+}
+
+func (s listSessionsStatusServer) ListSessions(
+	context.Context, *serverpb.ListSessionsRequest,
+) (*serverpb.ListSessionsResponse, error) {
+	return s.resp, s.err
+}
+
+// TestCountUserSessions verifies that the connection limits fail open: the
+// sessions which cannot be listed are not counted, whether a node or the
+// whole request failed.
+func TestCountUserSessions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	user := security.MakeSQLUsernameFromPreNormalizedString("carl")
+
+	count := countUserSessions(ctx, listSessionsStatusServer{
+		resp: &serverpb.ListSessionsResponse{
+			Sessions: []serverpb.Session{{Username: "carl"}, {Username: "root"}, {Username: "carl"}},
+		},
+	}, user)
+	require.Equal(t, int32(2), count)
+
+	count = countUserSessions(ctx, listSessionsStatusServer{
+		resp: &serverpb.ListSessionsResponse{
+			Sessions: []serverpb.Session{{Username: "carl"}},
+			Errors:   []serverpb.ListSessionsError{{NodeID: 2, Message: "node unavailable"}},
+		},
+	}, user)
+	require.Equal(t, int32(1), count)
+
+	count = countUserSessions(ctx, listSessionsStatusServer{
+		err: errors.New("cluster unavailable"),
+	}, user)
+	require.Equal(t, int32(0), count)
+}
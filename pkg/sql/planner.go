@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/idxusage"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/transform"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -102,6 +103,10 @@ type extendedEvalContext struct {
 	SchemaChangerState *SchemaChangerState
 
 	statementPreparer statementPreparer
+
+	// defaultsProvenance records the sources of the values of the session
+	// variables when the session was established.
+	defaultsProvenance []SessionDefaultProvenance
 }
 
 // copyFromExecCfg copies relevant fields from an ExecutorConfig.
@@ -819,8 +824,28 @@ func (p *planner) ExecutorConfig() interface{} {
 }
 
 // statementPreparer is an interface used when deserializing a session in order
-// to prepare statements.
+// to prepare statements and create portals.
 type statementPreparer interface {
+	// prepare prepares the statement with the given type hints without adding
+	// it to the session, and returns it.
+	prepare(
+		ctx context.Context,
+		stmt Statement,
+		placeholderHints tree.PlaceholderTypes,
+		rawTypeHints []oid.Oid,
+		origin PreparedStatementOrigin,
+	) (*PreparedStatement, error)
+	// addPortal creates a portal with the given name for the prepared
+	// statement and its arguments.
+	addPortal(
+		ctx context.Context,
+		portalName string,
+		stmt *PreparedStatement,
+		qargs tree.QueryArguments,
+		outFormats []pgwirebase.FormatCode,
+	) error
+	// exhaustPortal marks the portal with the given name as exhausted.
+	exhaustPortal(portalName string)
 	// addPreparedStmt creates a prepared statement with the given name and type
 	// hints, and returns it.
 	addPreparedStmt(
@@ -137,13 +137,40 @@ func makeRequiredConstraintForRegion(r catpb.RegionName) zonepb.Constraint {
 // lease_preferences = [["+region=A"]]
 //
 // See synthesizeVoterConstraints() for explanation on why `voter_constraints`
-// are set the way they are.
+// are set the way they are, and synthesizeLeasePreferences() for the effect of
+// the secondary region of the database.
+//
+// The replica counts set for the regions of the database using ALTER DATABASE
+// ... SET REGION are layered on top of the above. See
+// getNumVotersAndNumReplicasForRegion().
 func zoneConfigForMultiRegionDatabase(
 	regionConfig multiregion.RegionConfig,
 ) (zonepb.ZoneConfig, error) {
-	numVoters, numReplicas := getNumVotersAndNumReplicasForDefaultDatabaseRegions(regionConfig)
+	numVoters, numReplicas := getNumVotersAndNumReplicasForRegion(
+		regionConfig.PrimaryRegion(), regionConfig.Regions(), regionConfig,
+	)
+	if regionConfig.HasReplicaOverrides() {
+		// The tables and partitions homed in the other regions of the database
+		// inherit num_replicas from the database, so it must be large enough
+		// for all of them.
+		for _, region := range regionConfig.Regions() {
+			_, n := getNumVotersAndNumReplicasForRegion(region, regionConfig.Regions(), regionConfig)
+			if n > numReplicas {
+				numReplicas = n
+			}
+		}
+	}
 	var constraints []zonepb.ConstraintsConjunction
-	if regionConfig.IsPlacementRestricted() {
+	if regionConfig.HasReplicaOverrides() {
+		for _, region := range regionConfig.Regions() {
+			if n := getNumReplicasInRegion(region, regionConfig); n > 0 {
+				constraints = append(constraints, zonepb.ConstraintsConjunction{
+					NumReplicas: n,
+					Constraints: []zonepb.Constraint{makeRequiredConstraintForRegion(region)},
+				})
+			}
+		}
+	} else if regionConfig.IsPlacementRestricted() {
 		// In a RESTRICTED placement policy, the database zone config has no
 		// non-voters so that REGIONAL BY [TABLE | ROW] can inherit the RESTRICTED
 		// placement. Voter placement will be set at the table/partition level to
@@ -173,11 +200,9 @@ func zoneConfigForMultiRegionDatabase(
 	}
 
 	return zonepb.ZoneConfig{
-		NumReplicas: &numReplicas,
-		NumVoters:   &numVoters,
-		LeasePreferences: []zonepb.LeasePreference{
-			{Constraints: []zonepb.Constraint{makeRequiredConstraintForRegion(regionConfig.PrimaryRegion())}},
-		},
+		NumReplicas:                 &numReplicas,
+		NumVoters:                   &numVoters,
+		LeasePreferences:            synthesizeLeasePreferences(regionConfig.PrimaryRegion(), regionConfig),
 		NullVoterConstraintsIsEmpty: true,
 		VoterConstraints:            voterConstraints,
 		Constraints:                 constraints,
@@ -264,15 +289,11 @@ func zoneConfigForMultiRegionPartition(
 	zc.VoterConstraints = voterConstraints
 
 	zc.InheritedLeasePreferences = false
-	zc.LeasePreferences = []zonepb.LeasePreference{
-		{Constraints: []zonepb.Constraint{makeRequiredConstraintForRegion(partitionRegion)}},
-	}
+	zc.LeasePreferences = synthesizeLeasePreferences(partitionRegion, regionConfig)
 
 	regions := regionConfig.GetSuperRegionRegionsForRegion(partitionRegion)
 
-	numVoters, numReplicas := getNumVotersAndNumReplicas(
-		len(regions), regionConfig.SurvivalGoal(), regionConfig.IsPlacementRestricted(),
-	)
+	numVoters, numReplicas := getNumVotersAndNumReplicasForRegion(partitionRegion, regions, regionConfig)
 	zc.NumVoters = &numVoters
 
 	maybeAddConstraintsForSuperRegion(partitionRegion, regions, zc, numReplicas, regionConfig)
@@ -292,9 +313,34 @@ func maxFailuresBeforeUnavailability(numVoters int32) int32 {
 func getNumVotersAndNumReplicasForDefaultDatabaseRegions(
 	config multiregion.RegionConfig,
 ) (numVoters, numReplicas int32) {
-	return getNumVotersAndNumReplicas(
-		len(config.Regions()), config.SurvivalGoal(), config.IsPlacementRestricted(),
+	return getNumVotersAndNumReplicasForSurvivalGoal(len(config.Regions()), config)
+}
+
+// getNumVotersAndNumReplicasForSurvivalGoal computes the number of voters and
+// the total number of replicas needed for data spread across numRegions
+// regions of the given region config. The replica counts set along with the
+// survival goal using ALTER DATABASE ... SURVIVE ... WITH NUM_VOTERS ...
+// NUM_REPLICAS ... are used instead of the ones derived from the survival
+// goal. The number of replicas is raised if needed, for instance after regions
+// are added to the database, so that it accounts for all the replicas placed
+// by the constraints.
+func getNumVotersAndNumReplicasForSurvivalGoal(
+	numRegions int, config multiregion.RegionConfig,
+) (numVoters, numReplicas int32) {
+	numVoters, numReplicas = getNumVotersAndNumReplicas(
+		numRegions, config.SurvivalGoal(), config.IsPlacementRestricted(),
 	)
+	setNumVoters, setNumReplicas := config.SurvivalGoalReplicas()
+	if setNumVoters > 0 {
+		numVoters = setNumVoters
+		numReplicas = getMinNumReplicas(
+			numVoters, numRegions, config.SurvivalGoal(), config.IsPlacementRestricted(),
+		)
+	}
+	if setNumReplicas > numReplicas {
+		numReplicas = setNumReplicas
+	}
+	return numVoters, numReplicas
 }
 
 func getNumVotersAndNumReplicas(
@@ -321,29 +367,118 @@ func getNumVotersAndNumReplicas(
 	// are set the way they are.
 	case descpb.SurvivalGoal_ZONE_FAILURE:
 		numVoters = numVotersForZoneSurvival
+	case descpb.SurvivalGoal_REGION_FAILURE:
+		numVoters = numVotersForRegionSurvival
+	}
+	return numVoters, getMinNumReplicas(numVoters, numRegions, survivalGoal, isPlacementRestricted)
+}
+
+// getMinNumReplicas computes the total number of replicas needed for the given
+// number of voters to be placed according to the survival goal, with at least
+// one replica in each of the numRegions regions.
+func getMinNumReplicas(
+	numVoters int32, numRegions int, survivalGoal descpb.SurvivalGoal, isPlacementRestricted bool,
+) (numReplicas int32) {
+	switch survivalGoal {
+	case descpb.SurvivalGoal_ZONE_FAILURE:
 		if isPlacementRestricted {
 			numReplicas = numVoters
 		} else {
 			// <numVoters in the home region> + <1 replica for every other region>
-			numReplicas = (numVotersForZoneSurvival) + (int32(numRegions) - 1)
+			numReplicas = numVoters + (int32(numRegions) - 1)
 		}
 	case descpb.SurvivalGoal_REGION_FAILURE:
 		// <(quorum - 1) voters in the home region> + <1 replica for every other
 		// region>
-		numVoters = numVotersForRegionSurvival
+		//
 		// We place the maximum concurrent replicas that can fail before a range
 		// outage in the home region, and ensure that there's at least one replica
 		// in all other regions.
-		numReplicas = maxFailuresBeforeUnavailability(numVotersForRegionSurvival) + (int32(numRegions) - 1)
+		numReplicas = maxFailuresBeforeUnavailability(numVoters) + (int32(numRegions) - 1)
 		if numReplicas < numVoters {
 			// NumReplicas cannot be less than NumVoters. If we have <= 4 regions, all
 			// replicas will be voting replicas.
 			numReplicas = numVoters
 		}
 	}
+	return numReplicas
+}
+
+// getNumVotersAndNumReplicasForRegion computes the number of voters and the
+// total number of replicas needed for the data homed in the given region,
+// which is spread across the given regions. Without replica counts set for
+// the regions of the database using ALTER DATABASE ... SET REGION, this is the
+// same as getNumVotersAndNumReplicasForSurvivalGoal(). Otherwise, under zone
+// survivability, all the voters are in the home region, so the number of
+// voters is the number of voters in the home region. Under region
+// survivability, the number of voters is raised if needed so that the voters
+// in the home region remain a minority. Every region holds the number of
+// replicas set for it (1 by default, 0 in a RESTRICTED placement policy) and
+// the home region holds at least its voters. The total number of replicas is
+// the sum of these, and at least the number of voters.
+func getNumVotersAndNumReplicasForRegion(
+	region catpb.RegionName, regions catpb.RegionNames, regionConfig multiregion.RegionConfig,
+) (numVoters, numReplicas int32) {
+	numVoters, numReplicas = getNumVotersAndNumReplicasForSurvivalGoal(len(regions), regionConfig)
+	if !regionConfig.HasReplicaOverrides() {
+		return numVoters, numReplicas
+	}
+
+	votersInRegion := getNumVotersInRegion(region, regionConfig)
+	switch regionConfig.SurvivalGoal() {
+	case descpb.SurvivalGoal_ZONE_FAILURE:
+		numVoters = votersInRegion
+	case descpb.SurvivalGoal_REGION_FAILURE:
+		if minNumVoters := 2*votersInRegion + 1; minNumVoters > numVoters {
+			numVoters = minNumVoters
+		}
+	}
+
+	numReplicas = 0
+	for _, r := range regions {
+		n := getNumReplicasInRegion(r, regionConfig)
+		// The secondary region holds as many voters as the primary region for
+		// the data homed in the primary region under region survivability. See
+		// synthesizeVoterConstraints().
+		if (r == region || (region == regionConfig.PrimaryRegion() &&
+			r == regionConfig.SecondaryRegion() &&
+			regionConfig.SurvivalGoal() == descpb.SurvivalGoal_REGION_FAILURE)) &&
+			n < votersInRegion {
+			n = votersInRegion
+		}
+		numReplicas += n
+	}
+	if numReplicas < numVoters {
+		numReplicas = numVoters
+	}
 	return numVoters, numReplicas
 }
 
+// getNumVotersInRegion returns the number of voters placed in the given region
+// for the data homed in it.
+func getNumVotersInRegion(region catpb.RegionName, regionConfig multiregion.RegionConfig) int32 {
+	if override, ok := regionConfig.ReplicaOverride(region); ok && override.NumVoters > 0 {
+		return override.NumVoters
+	}
+	numVoters, _ := getNumVotersAndNumReplicasForDefaultDatabaseRegions(regionConfig)
+	if regionConfig.SurvivalGoal() == descpb.SurvivalGoal_REGION_FAILURE {
+		return maxFailuresBeforeUnavailability(numVoters)
+	}
+	return numVoters
+}
+
+// getNumReplicasInRegion returns the number of replicas, voting or
+// non-voting, constrained to the given region by the database.
+func getNumReplicasInRegion(region catpb.RegionName, regionConfig multiregion.RegionConfig) int32 {
+	if override, ok := regionConfig.ReplicaOverride(region); ok && override.NumReplicas > 0 {
+		return override.NumReplicas
+	}
+	if regionConfig.IsPlacementRestricted() {
+		return 0
+	}
+	return 1
+}
+
 // synthesizeVoterConstraints generates a ConstraintsConjunction clause
 // representing the `voter_constraints` field to be set for the primary region
 // of a multi-region database or the home region of a table in such a database.
@@ -352,7 +487,12 @@ func getNumVotersAndNumReplicas(
 // the primary/home region.
 //
 // Under region survivability, we will constrain exactly <quorum - 1> voting
-// replicas in the primary/home region.
+// replicas in the primary/home region. If the database has a secondary region,
+// another <quorum - 1> voting replicas are constrained to it for the objects
+// homed in the primary region, so that the secondary region can take over the
+// leases if the primary region fails. If the number of voters of the
+// primary/home region has been set using ALTER DATABASE ... SET REGION, it is
+// used instead of <quorum - 1>.
 func synthesizeVoterConstraints(
 	region catpb.RegionName, regionConfig multiregion.RegionConfig,
 ) ([]zonepb.ConstraintsConjunction, error) {
@@ -397,8 +537,8 @@ func synthesizeVoterConstraints(
 			},
 		}, nil
 	case descpb.SurvivalGoal_REGION_FAILURE:
-		numVoters, _ := getNumVotersAndNumReplicasForDefaultDatabaseRegions(regionConfig)
-		return []zonepb.ConstraintsConjunction{
+		votersInRegion := getNumVotersInRegion(region, regionConfig)
+		ret := []zonepb.ConstraintsConjunction{
 			{
 				// We constrain <quorum - 1> voting replicas to the primary region and
 				// allow the rest to "float" around. This allows the allocator inside KV
@@ -432,15 +572,50 @@ func synthesizeVoterConstraints(
 				// |   +------------+   |   |  +------------+   |    |   +------------+   |
 				// +--------------------+   +-------------------+    +--------------------+
 				//
-				NumReplicas: maxFailuresBeforeUnavailability(numVoters),
+				NumReplicas: votersInRegion,
 				Constraints: []zonepb.Constraint{makeRequiredConstraintForRegion(region)},
 			},
-		}, nil
+		}
+		if region == regionConfig.PrimaryRegion() && regionConfig.HasSecondaryRegion() {
+			ret = append(ret, zonepb.ConstraintsConjunction{
+				NumReplicas: votersInRegion,
+				Constraints: []zonepb.Constraint{makeRequiredConstraintForRegion(regionConfig.SecondaryRegion())},
+			})
+		}
+		return ret, nil
 	default:
 		return nil, errors.AssertionFailedf("unknown survival goal: %v", regionConfig.SurvivalGoal())
 	}
 }
 
+// synthesizeLeasePreferences generates the `lease_preferences` field to be set
+// for the primary region of a multi-region database or the home region of a
+// table or partition in such a database. The leaseholders are placed in the
+// region and, for the objects homed in the primary region, fall back to the
+// secondary region of the database, if any, or to the fallback regions of the
+// primary region, in order.
+func synthesizeLeasePreferences(
+	region catpb.RegionName, regionConfig multiregion.RegionConfig,
+) []zonepb.LeasePreference {
+	ret := []zonepb.LeasePreference{
+		{Constraints: []zonepb.Constraint{makeRequiredConstraintForRegion(region)}},
+	}
+	if region != regionConfig.PrimaryRegion() {
+		return ret
+	}
+	if regionConfig.HasSecondaryRegion() {
+		ret = append(ret, zonepb.LeasePreference{
+			Constraints: []zonepb.Constraint{makeRequiredConstraintForRegion(regionConfig.SecondaryRegion())},
+		})
+	}
+	for _, fallbackRegion := range regionConfig.FallbackRegions() {
+		ret = append(ret, zonepb.LeasePreference{
+			Constraints: []zonepb.Constraint{makeRequiredConstraintForRegion(fallbackRegion)},
+		})
+	}
+	return ret
+}
+
 // zoneConfigForMultiRegionTable generates a ZoneConfig stub for a
 // regional-by-table or global table in a multi-region database.
 //
@@ -472,7 +647,9 @@ func zoneConfigForMultiRegionTable(
 		if regionConfig.IsPlacementRestricted() {
 			// We only care about NumVoters here at the table level. NumReplicas is set at
 			// the database level, not at the table/partition level.
-			numVoters, _ := getNumVotersAndNumReplicasForDefaultDatabaseRegions(regionConfig)
+			numVoters, _ := getNumVotersAndNumReplicasForRegion(
+				regionConfig.PrimaryRegion(), regionConfig.Regions(), regionConfig,
+			)
 
 			ret.NumVoters = &numVoters
 			vc, err := synthesizeVoterConstraints(regionConfig.PrimaryRegion(), regionConfig)
@@ -514,9 +691,7 @@ func zoneConfigForMultiRegionTable(
 			return ret, nil
 		}
 
-		numVoters, numReplicas := getNumVotersAndNumReplicas(
-			len(regions), regionConfig.SurvivalGoal(), regionConfig.IsPlacementRestricted(),
-		)
+		numVoters, numReplicas := getNumVotersAndNumReplicasForRegion(primaryRegion, regions, regionConfig)
 		ret.NumVoters = &numVoters
 
 		maybeAddConstraintsForSuperRegion(primaryRegion, regions, ret, numReplicas, regionConfig)
@@ -531,9 +706,7 @@ func zoneConfigForMultiRegionTable(
 		ret.VoterConstraints = voterConstraints
 
 		ret.InheritedLeasePreferences = false
-		ret.LeasePreferences = []zonepb.LeasePreference{
-			{Constraints: []zonepb.Constraint{makeRequiredConstraintForRegion(primaryRegion)}},
-		}
+		ret.LeasePreferences = synthesizeLeasePreferences(primaryRegion, regionConfig)
 	case *catpb.LocalityConfig_RegionalByRow_:
 		// We purposely do not set anything here at table level - this should be done at
 		// partition level instead.
@@ -542,6 +715,37 @@ func zoneConfigForMultiRegionTable(
 	return ret, nil
 }
 
+// zoneConfigForTableInMultiRegionDatabase generates a ZoneConfig stub for the
+// table with the given ID, like zoneConfigForMultiRegionTable does. A table
+// which is an exception to the RESTRICTED placement policy of its database
+// cannot inherit `num_replicas` and `constraints` from the database, which has
+// no non-voting replicas, so these are set at the table level instead.
+func zoneConfigForTableInMultiRegionDatabase(
+	tableID descpb.ID, localityConfig catpb.LocalityConfig, regionConfig multiregion.RegionConfig,
+) (*zonepb.ZoneConfig, error) {
+	tableRegionConfig := regionConfig.ForTable(tableID)
+	zc, err := zoneConfigForMultiRegionTable(localityConfig, tableRegionConfig)
+	if err != nil {
+		return nil, err
+	}
+	if tableRegionConfig.IsPlacementRestricted() == regionConfig.IsPlacementRestricted() {
+		return zc, nil
+	}
+
+	dbZoneConfig, err := zoneConfigForMultiRegionDatabase(tableRegionConfig)
+	if err != nil {
+		return nil, err
+	}
+	if zc.NumReplicas == nil {
+		zc.NumReplicas = dbZoneConfig.NumReplicas
+	}
+	if zc.InheritedConstraints {
+		zc.InheritedConstraints = false
+		zc.Constraints = dbZoneConfig.Constraints
+	}
+	return zc, nil
+}
+
 // applyZoneConfigForMultiRegionTableOption is an option that can be passed into
 // applyZoneConfigForMultiRegionTable.
 type applyZoneConfigForMultiRegionTableOption func(
@@ -560,6 +764,7 @@ func applyZoneConfigForMultiRegionTableOptionNewIndexes(
 		regionConfig multiregion.RegionConfig,
 		table catalog.TableDescriptor,
 	) (hasNewSubzones bool, newZoneConfig zonepb.ZoneConfig, err error) {
+		regionConfig = regionConfig.ForTable(table.GetID())
 		for _, indexID := range indexIDs {
 			for _, region := range regionConfig.Regions() {
 				zc, err := zoneConfigForMultiRegionPartition(region, regionConfig)
@@ -626,7 +831,8 @@ func applyZoneConfigForMultiRegionTableOptionTableNewConfig(
 		regionConfig multiregion.RegionConfig,
 		table catalog.TableDescriptor,
 	) (bool, zonepb.ZoneConfig, error) {
-		localityZoneConfig, err := zoneConfigForMultiRegionTable(
+		localityZoneConfig, err := zoneConfigForTableInMultiRegionDatabase(
+			table.GetID(),
 			newConfig,
 			regionConfig,
 		)
@@ -647,7 +853,8 @@ var ApplyZoneConfigForMultiRegionTableOptionTableAndIndexes = func(
 	table catalog.TableDescriptor,
 ) (bool, zonepb.ZoneConfig, error) {
 	localityConfig := *table.GetLocalityConfig()
-	localityZoneConfig, err := zoneConfigForMultiRegionTable(
+	localityZoneConfig, err := zoneConfigForTableInMultiRegionDatabase(
+		table.GetID(),
 		localityConfig,
 		regionConfig,
 	)
@@ -669,6 +876,7 @@ var ApplyZoneConfigForMultiRegionTableOptionTableAndIndexes = func(
 
 	hasNewSubzones := table.IsLocalityRegionalByRow()
 	if hasNewSubzones {
+		regionConfig := regionConfig.ForTable(table.GetID())
 		for _, region := range regionConfig.Regions() {
 			subzoneConfig, err := zoneConfigForMultiRegionPartition(region, regionConfig)
 			if err != nil {
@@ -919,6 +1127,16 @@ func WithOnlyGlobalTables(opts *updateZoneConfigOptions) {
 	}
 }
 
+// WithOnlyGlobalTablesAndTables modifies an updateZoneConfigOptions to only
+// apply to global tables and the tables with the given IDs.
+func WithOnlyGlobalTablesAndTables(tableIDs catalog.DescriptorIDSet) updateZoneConfigOption {
+	return func(opts *updateZoneConfigOptions) {
+		opts.filterFunc = func(tb *tabledesc.Mutable) bool {
+			return tb.IsLocalityGlobal() || tableIDs.Contains(tb.GetID())
+		}
+	}
+}
+
 // WithOnlyRegionalTablesAndGlobalTables modifies an updateZoneConfigOptions to
 // only apply to global tables and regional tables.
 func WithOnlyRegionalTablesAndGlobalTables(opts *updateZoneConfigOptions) {
@@ -1280,6 +1498,13 @@ func SynthesizeRegionConfig(
 		dbDesc.GetRegionConfig().Placement,
 		superRegions,
 		multiregion.WithTransitioningRegions(transitioningRegionNames),
+		multiregion.WithSecondaryRegion(dbDesc.GetRegionConfig().SecondaryRegion),
+		multiregion.WithFallbackRegions(dbDesc.GetRegionConfig().FallbackRegions),
+		multiregion.WithPlacementExceptions(dbDesc.GetRegionConfig().PlacementExceptions),
+		multiregion.WithReplicaOverrides(dbDesc.GetRegionConfig().RegionReplicaOverrides),
+		multiregion.WithSurvivalGoalReplicas(
+			dbDesc.GetRegionConfig().SurvivalGoalNumVoters, dbDesc.GetRegionConfig().SurvivalGoalNumReplicas,
+		),
 	)
 
 	if err := multiregion.ValidateRegionConfig(regionConfig); err != nil {
@@ -2059,3 +2284,39 @@ func (p *planner) checkNoRegionChangeUnderway(
 	}
 	return nil
 }
+
+// localityConfigFromLocality returns the locality config corresponding to a
+// LOCALITY clause.
+func localityConfigFromLocality(l *tree.Locality) catpb.LocalityConfig {
+	switch l.LocalityLevel {
+	case tree.LocalityLevelGlobal:
+		return tabledesc.LocalityConfigGlobal()
+	case tree.LocalityLevelRow:
+		return tabledesc.LocalityConfigRegionalByRow(l.RegionalByRowColumn)
+	default:
+		return tabledesc.LocalityConfigRegionalByTable(l.TableRegion)
+	}
+}
+
+// localityFromLocalityConfig returns the LOCALITY clause corresponding to a
+// locality config. It is the inverse of localityConfigFromLocality.
+func localityFromLocalityConfig(lc *catpb.LocalityConfig) (*tree.Locality, error) {
+	switch l := lc.Locality.(type) {
+	case *catpb.LocalityConfig_Global_:
+		return &tree.Locality{LocalityLevel: tree.LocalityLevelGlobal}, nil
+	case *catpb.LocalityConfig_RegionalByTable_:
+		res := &tree.Locality{LocalityLevel: tree.LocalityLevelTable}
+		if l.RegionalByTable.Region != nil {
+			res.TableRegion = tree.Name(*l.RegionalByTable.Region)
+		}
+		return res, nil
+	case *catpb.LocalityConfig_RegionalByRow_:
+		res := &tree.Locality{LocalityLevel: tree.LocalityLevelRow}
+		if l.RegionalByRow.As != nil {
+			res.RegionalByRowColumn = tree.Name(*l.RegionalByRow.As)
+		}
+		return res, nil
+	default:
+		return nil, errors.AssertionFailedf("unknown locality %T", l)
+	}
+}
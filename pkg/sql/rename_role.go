@@ -0,0 +1,296 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessioninit"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+// renameRoleNode represents an ALTER ROLE ... RENAME TO statement.
+type renameRoleNode struct {
+	n       *tree.RenameRole
+	oldName security.SQLUsername
+	newName security.SQLUsername
+}
+
+// RenameRole renames a role.
+// Privileges: CREATEROLE privilege.
+func (p *planner) RenameRole(ctx context.Context, n *tree.RenameRole) (planNode, error) {
+	if err := p.CheckRoleOption(ctx, roleoption.CREATEROLE); err != nil {
+		return nil, err
+	}
+	if n.Name.RoleSpecType != tree.RoleName || n.NewName.RoleSpecType != tree.RoleName {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+			"cannot use special role specifier in ALTER ROLE ... RENAME TO")
+	}
+	oldName, err := n.Name.ToSQLUsername(p.SessionData(), security.UsernameValidation)
+	if err != nil {
+		return nil, err
+	}
+	// The new name is validated like the name of a new role.
+	newName, err := n.NewName.ToSQLUsername(p.SessionData(), security.UsernameCreation)
+	if err != nil {
+		return nil, err
+	}
+	if oldName.IsAdminRole() || oldName.IsRootUser() || oldName.IsReserved() {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+			"cannot rename special role %s", oldName)
+	}
+	if newName.IsReserved() {
+		return nil, pgerror.Newf(pgcode.ReservedName,
+			"role name %q is reserved", newName.Normalized())
+	}
+	// Renaming the role of the session would leave the session without a
+	// valid user. This matches Postgres.
+	if oldName == p.SessionData().SessionUser() {
+		return nil, pgerror.New(pgcode.FeatureNotSupported, "session user cannot be renamed")
+	}
+	if oldName == p.User() {
+		return nil, pgerror.New(pgcode.FeatureNotSupported, "current user cannot be renamed")
+	}
+
+	return &renameRoleNode{
+		n:       n,
+		oldName: oldName,
+		newName: newName,
+	}, nil
+}
+
+func (n *renameRoleNode) startExec(params runParams) error {
+	var opName string
+	if n.n.IsRole {
+		sqltelemetry.IncIAMAlterCounter(sqltelemetry.Role)
+		opName = "rename-role"
+	} else {
+		sqltelemetry.IncIAMAlterCounter(sqltelemetry.User)
+		opName = "rename-user"
+	}
+
+	oldExists, err := RoleExists(params.ctx, params.ExecCfg(), params.p.txn, n.oldName)
+	if err != nil {
+		return err
+	}
+	if !oldExists {
+		return pgerror.Newf(pgcode.UndefinedObject, "role/user %s does not exist", n.oldName)
+	}
+	newExists, err := RoleExists(params.ctx, params.ExecCfg(), params.p.txn, n.newName)
+	if err != nil {
+		return err
+	}
+	if newExists {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"a role/user named %s already exists", n.newName.Normalized())
+	}
+
+	// Like for ALTER ROLE, only admins can rename another admin.
+	isAdmin, err := params.p.UserHasAdminRole(params.ctx, n.oldName)
+	if err != nil {
+		return err
+	}
+	if isAdmin {
+		if err := params.p.RequireAdminRole(params.ctx, "ALTER ROLE admin"); err != nil {
+			return err
+		}
+	}
+
+	// Rename the role in all the system tables which refer to it by name.
+	renameIn := func(table, column string) (int, error) {
+		return params.ExecCfg().InternalExecutor.ExecEx(
+			params.ctx,
+			opName,
+			params.p.txn,
+			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+			fmt.Sprintf(`UPDATE %[1]s SET %[2]s = $2 WHERE %[2]s = $1`, table, column),
+			n.oldName,
+			n.newName,
+		)
+	}
+	if _, err := renameIn(sessioninit.UsersTableName.String(), "username"); err != nil {
+		return err
+	}
+	if _, err := renameIn(sessioninit.RoleOptionsTableName.String(), "username"); err != nil {
+		return err
+	}
+	numRoleMembershipsUpdated := 0
+	for _, column := range []string{`"role"`, `"member"`} {
+		rowsUpdated, err := renameIn("system.role_members", column)
+		if err != nil {
+			return err
+		}
+		numRoleMembershipsUpdated += rowsUpdated
+	}
+	numRoleSettingsRowsUpdated, err := renameIn(sessioninit.DatabaseRoleSettingsTableName.String(), "role_name")
+	if err != nil {
+		return err
+	}
+	if _, err := renameIn("system.web_sessions", "username"); err != nil {
+		return err
+	}
+	if _, err := renameIn("system.scheduled_jobs", "owner"); err != nil {
+		return err
+	}
+	if params.p.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.RoleTOTPTable) {
+		if _, err := renameIn("system.role_totp", "username"); err != nil {
+			return err
+		}
+	}
+	if params.p.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.WebAuthnCredentialsTable) {
+		if _, err := renameIn("system.webauthn_credentials", "username"); err != nil {
+			return err
+		}
+	}
+	if params.p.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.RoleLastLoginTable) {
+		if _, err := renameIn("system.role_last_login", "username"); err != nil {
+			return err
+		}
+	}
+
+	if err := n.renameRoleInDescriptors(params); err != nil {
+		return err
+	}
+
+	// Bump role-related table versions to force a refresh of membership/auth
+	// caches.
+	if sessioninit.CacheEnabled.Get(&params.p.ExecCfg().Settings.SV) {
+		if err := params.p.bumpUsersTableVersion(params.ctx); err != nil {
+			return err
+		}
+		if err := params.p.bumpRoleOptionsTableVersion(params.ctx); err != nil {
+			return err
+		}
+		if numRoleSettingsRowsUpdated > 0 {
+			if err := params.p.bumpDatabaseRoleSettingsTableVersion(params.ctx); err != nil {
+				return err
+			}
+		}
+	}
+	if numRoleMembershipsUpdated > 0 {
+		if err := params.p.BumpRoleMembershipTableVersion(params.ctx); err != nil {
+			return err
+		}
+	}
+
+	return params.p.logEvent(params.ctx,
+		0, /* no target */
+		&eventpb.RenameRole{
+			RoleName:    n.oldName.Normalized(),
+			NewRoleName: n.newName.Normalized(),
+		})
+}
+
+// renameRoleInDescriptors renames the role in the privileges, the owners and
+// the default privileges of all the descriptors which refer to it.
+func (n *renameRoleNode) renameRoleInDescriptors(params runParams) error {
+	all, err := params.p.Descriptors().GetAllDescriptors(params.ctx, params.p.txn)
+	if err != nil {
+		return err
+	}
+	jobDesc := tree.AsStringWithFQNames(n.n, params.p.Ann())
+	for _, desc := range all.OrderedDescriptors() {
+		if desc.Dropped() || !descriptorReferencesRole(desc, n.oldName) {
+			continue
+		}
+		mutDesc, err := params.p.Descriptors().GetMutableDescriptorByID(params.ctx, params.p.txn, desc.GetID())
+		if err != nil {
+			return err
+		}
+		mutDesc.GetPrivileges().RenameUser(n.oldName, n.newName)
+		switch mutDesc := mutDesc.(type) {
+		case *dbdesc.Mutable:
+			if defaultPrivs := mutDesc.GetDefaultPrivileges(); defaultPrivs != nil {
+				defaultPrivs.RenameUser(n.oldName, n.newName)
+			}
+			err = params.p.writeNonDropDatabaseChange(params.ctx, mutDesc, jobDesc)
+		case *schemadesc.Mutable:
+			if defaultPrivs := mutDesc.GetDefaultPrivileges(); defaultPrivs != nil {
+				defaultPrivs.RenameUser(n.oldName, n.newName)
+			}
+			err = params.p.writeSchemaDescChange(params.ctx, mutDesc, jobDesc)
+		case *tabledesc.Mutable:
+			err = params.p.writeSchemaChange(params.ctx, mutDesc, descpb.InvalidMutationID, jobDesc)
+		case *typedesc.Mutable:
+			err = params.p.writeTypeSchemaChange(params.ctx, mutDesc, jobDesc)
+		default:
+			return errors.AssertionFailedf("unexpected descriptor type %T", mutDesc)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// descriptorReferencesRole returns whether the role owns the descriptor, has
+// privileges on it or appears in its default privileges.
+func descriptorReferencesRole(desc catalog.Descriptor, role security.SQLUsername) bool {
+	privs := desc.GetPrivileges()
+	if privs.Owner() == role {
+		return true
+	}
+	if _, ok := privs.FindUser(role); ok {
+		return true
+	}
+	var defaultPrivs catalog.DefaultPrivilegeDescriptor
+	switch desc := desc.(type) {
+	case catalog.DatabaseDescriptor:
+		defaultPrivs = desc.GetDefaultPrivilegeDescriptor()
+	case catalog.SchemaDescriptor:
+		defaultPrivs = desc.GetDefaultPrivilegeDescriptor()
+	default:
+		return false
+	}
+	found := false
+	// The func we pass into ForEachDefaultPrivilegeForRole never errs.
+	_ = defaultPrivs.ForEachDefaultPrivilegeForRole(func(
+		defaultPrivilegesForRole catpb.DefaultPrivilegesForRole,
+	) error {
+		if defaultPrivilegesForRole.IsExplicitRole() &&
+			defaultPrivilegesForRole.GetExplicitRole().UserProto.Decode() == role {
+			found = true
+		}
+		for _, objectPrivs := range defaultPrivilegesForRole.DefaultPrivilegesPerObject {
+			if _, ok := objectPrivs.FindUser(role); ok {
+				found = true
+			}
+		}
+		return nil
+	})
+	return found
+}
+
+// Next implements the planNode interface.
+func (*renameRoleNode) Next(runParams) (bool, error) { return false, nil }
+
+// Values implements the planNode interface.
+func (*renameRoleNode) Values() tree.Datums { return tree.Datums{} }
+
+// Close implements the planNode interface.
+func (*renameRoleNode) Close(context.Context) {}
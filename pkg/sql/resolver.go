@@ -139,6 +139,9 @@ func (p *planner) ResolveTargetObject(
 func (p *planner) LookupSchema(
 	ctx context.Context, dbName, scName string,
 ) (found bool, scMeta catalog.ResolvedObjectPrefix, err error) {
+	if err := p.checkAllowedDatabaseLookup(dbName); err != nil {
+		return false, catalog.ResolvedObjectPrefix{}, err
+	}
 	dbDesc, err := p.Descriptors().GetImmutableDatabaseByName(ctx, p.txn, dbName,
 		tree.DatabaseLookupFlags{AvoidLeased: p.avoidLeasedDescriptors})
 	if err != nil || dbDesc == nil {
@@ -168,6 +171,9 @@ func (p *planner) SchemaExists(ctx context.Context, dbName, scName string) (foun
 func (p *planner) LookupObject(
 	ctx context.Context, flags tree.ObjectLookupFlags, dbName, scName, obName string,
 ) (found bool, prefix catalog.ResolvedObjectPrefix, objMeta catalog.Descriptor, err error) {
+	if err := p.checkAllowedDatabaseLookup(dbName); err != nil {
+		return false, catalog.ResolvedObjectPrefix{}, nil, err
+	}
 	sc := p.Accessor()
 	flags.CommonLookupFlags.Required = false
 	flags.CommonLookupFlags.AvoidLeased = p.avoidLeasedDescriptors
@@ -0,0 +1,209 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// The time of the last successful login of each role is recorded in
+// system.role_last_login, so that the roles which are no longer used,
+// such as the stale service accounts, can be identified and
+// decommissioned. It is reported by SHOW ROLES and in the rollastlogin
+// column of pg_catalog.pg_roles.
+//
+// So as not to slow down the logins, they are not written right away:
+// each node coalesces the logins of each role, and writes the last one
+// periodically from a background task. The logins of the last interval
+// are thus lost if the node crashes.
+
+// recordLastLoginEnabled is the cluster setting which enables the
+// recording of the last logins.
+var recordLastLoginEnabled = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"server.user_login.record_last_login.enabled",
+	"if set, the time of the last successful login of each role is recorded in system.role_last_login",
+	true,
+)
+
+// recordLastLoginInterval is the interval at which the last logins are
+// written.
+var recordLastLoginInterval = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"server.user_login.record_last_login.interval",
+	"the interval at which each node writes the last logins of the roles to system.role_last_login",
+	10*time.Second,
+	settings.PositiveDuration,
+)
+
+// maxPendingLastLogins is the number of roles whose last login can be
+// waiting to be written on each node. The logins of the other roles are
+// dropped until the next write.
+const maxPendingLastLogins = 10000
+
+// LastLogin describes a successful login.
+type LastLogin struct {
+	Timestamp time.Time
+	// Transport is the connection type of SQL logins, or "http" for the
+	// DB Console and HTTP API logins.
+	Transport     string
+	Method        string
+	RemoteAddress string
+}
+
+// LastLoginRecorder records the last successful login of each role in
+// system.role_last_login.
+type LastLoginRecorder struct {
+	st *cluster.Settings
+	ie sqlutil.InternalExecutor
+	// disabled is set by the testing knobs.
+	disabled bool
+
+	mu struct {
+		syncutil.Mutex
+		// pending holds the logins which have not been written yet, by
+		// user.
+		pending map[string]LastLogin
+	}
+}
+
+// NewLastLoginRecorder creates a new LastLoginRecorder.
+func NewLastLoginRecorder(
+	st *cluster.Settings, ie sqlutil.InternalExecutor, knobs *ExecutorTestingKnobs,
+) *LastLoginRecorder {
+	return &LastLoginRecorder{
+		st:       st,
+		ie:       ie,
+		disabled: knobs != nil && knobs.DisableLastLoginRecording,
+	}
+}
+
+// Record records a successful login of the given user, to be written
+// by the background task. It does not block.
+func (r *LastLoginRecorder) Record(user security.SQLUsername, login LastLogin) {
+	if r == nil || r.disabled || user.IsNodeUser() || !recordLastLoginEnabled.Get(&r.st.SV) {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mu.pending == nil {
+		r.mu.pending = make(map[string]LastLogin)
+	}
+	normalized := user.Normalized()
+	if _, ok := r.mu.pending[normalized]; !ok && len(r.mu.pending) >= maxPendingLastLogins {
+		return
+	}
+	r.mu.pending[normalized] = login
+}
+
+// Start starts the background task which writes the recorded logins.
+func (r *LastLoginRecorder) Start(ctx context.Context, stopper *stop.Stopper) {
+	ctx, _ = stopper.WithCancelOnQuiesce(ctx)
+	// NB: The only error that should occur here would be if the server were
+	// shutting down so let's swallow it.
+	_ = stopper.RunAsyncTask(ctx, "role-last-login", func(ctx context.Context) {
+		var timer timeutil.Timer
+		defer timer.Stop()
+		for {
+			timer.Reset(recordLastLoginInterval.Get(&r.st.SV))
+			select {
+			case <-timer.C:
+				timer.Read = true
+				r.flush(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// flush writes the recorded logins. Failures are logged.
+func (r *LastLoginRecorder) flush(ctx context.Context) {
+	// The logins are kept until the table exists.
+	if !r.st.Version.IsActive(ctx, clusterversion.RoleLastLoginTable) {
+		return
+	}
+	r.mu.Lock()
+	pending := r.mu.pending
+	r.mu.pending = nil
+	r.mu.Unlock()
+
+	for user, login := range pending {
+		if err := r.write(ctx, user, login); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Warningf(ctx, "unable to record the last login of user %s: %v", user, err)
+		}
+	}
+}
+
+// write writes the last login of the given user, unless a more recent
+// login was written by another node, or the user has been dropped in
+// the meantime.
+func (r *LastLoginRecorder) write(ctx context.Context, user string, login LastLogin) error {
+	var remoteAddr interface{}
+	if login.RemoteAddress != "" {
+		remoteAddr = login.RemoteAddress
+	}
+	_, err := r.ie.ExecEx(
+		ctx, "record-last-login", nil, /* txn */
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`INSERT INTO system.public.role_last_login
+       (username, last_login, transport, method, remote_address)
+SELECT username, $2::TIMESTAMP, $3, $4, $5::STRING FROM system.public.users WHERE username = $1
+    ON CONFLICT (username) DO UPDATE
+   SET last_login = excluded.last_login, transport = excluded.transport,
+       method = excluded.method, remote_address = excluded.remote_address
+ WHERE role_last_login.last_login < excluded.last_login`,
+		user, login.Timestamp, login.Transport, login.Method, remoteAddr,
+	)
+	return err
+}
+
+// getRoleLastLogins returns the time of the last recorded login of each
+// role, by user.
+func getRoleLastLogins(ctx context.Context, p *planner) (map[string]tree.Datum, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.RoleLastLoginTable) {
+		return nil, nil
+	}
+	rows, err := p.ExecCfg().InternalExecutor.QueryBufferedEx(
+		ctx, "read-last-logins", p.txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`SELECT username, last_login FROM system.public.role_last_login`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	lastLogins := make(map[string]tree.Datum, len(rows))
+	for _, row := range rows {
+		ts := tree.MustBeDTimestamp(row[1])
+		d, err := tree.MakeDTimestampTZ(ts.Time, time.Microsecond)
+		if err != nil {
+			return nil, err
+		}
+		lastLogins[string(tree.MustBeDString(row[0]))] = d
+	}
+	return lastLogins, nil
+}
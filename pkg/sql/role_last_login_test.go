@@ -0,0 +1,75 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRoleLastLogin verifies that the last login of a role is recorded,
+// and reported by SHOW ROLES and pg_catalog.pg_roles.
+func TestRoleLastLogin(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `SET CLUSTER SETTING server.user_login.record_last_login.interval = '10ms'`)
+	sqlDB.Exec(t, `CREATE USER foo WITH PASSWORD 'testabc'`)
+
+	// foo has never logged in.
+	sqlDB.CheckQueryResults(t,
+		`SELECT last_login IS NULL FROM [SHOW ROLES] WHERE username = 'foo'`,
+		[][]string{{"true"}})
+
+	fooURL, fooCleanupFn := sqlutils.PGUrlWithOptionalClientCerts(t,
+		s.ServingSQLAddr(), t.Name(), url.UserPassword("foo", "testabc"), false /* withClientCerts */)
+	defer fooCleanupFn()
+	conn, err := pgxConn(t, fooURL)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close(ctx))
+
+	testutils.SucceedsSoon(t, func() error {
+		var recorded bool
+		sqlDB.QueryRow(t,
+			`SELECT last_login IS NOT NULL FROM [SHOW ROLES] WHERE username = 'foo'`,
+		).Scan(&recorded)
+		if !recorded {
+			return errors.New("the login of foo has not been recorded yet")
+		}
+		return nil
+	})
+	sqlDB.CheckQueryResults(t,
+		`SELECT transport, method FROM system.role_last_login WHERE username = 'foo'`,
+		[][]string{{"hostssl", "cert-password"}})
+	sqlDB.CheckQueryResults(t,
+		`SELECT rollastlogin IS NOT NULL FROM pg_catalog.pg_roles WHERE rolname = 'foo'`,
+		[][]string{{"true"}})
+
+	// The last login is forgotten when the role is dropped.
+	sqlDB.Exec(t, `DROP USER foo`)
+	sqlDB.CheckQueryResults(t,
+		`SELECT count(*) FROM system.role_last_login WHERE username = 'foo'`,
+		[][]string{{"0"}})
+}
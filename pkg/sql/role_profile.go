@@ -0,0 +1,480 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessioninit"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+// createProfileNode represents a CREATE PROFILE statement.
+type createProfileNode struct {
+	n           *tree.CreateProfile
+	roleOptions roleoption.List
+}
+
+// alterProfileNode represents an ALTER PROFILE statement.
+type alterProfileNode struct {
+	n           *tree.AlterProfile
+	roleOptions roleoption.List
+}
+
+// dropProfileNode represents a DROP PROFILE statement.
+type dropProfileNode struct {
+	n *tree.DropProfile
+}
+
+// CreateProfile creates a role profile.
+// Privileges: CREATEROLE and CREATELOGIN privileges.
+func (p *planner) CreateProfile(ctx context.Context, n *tree.CreateProfile) (planNode, error) {
+	roleOptions, err := p.planProfileOptions(ctx, n.KVOptions, "CREATE PROFILE")
+	if err != nil {
+		return nil, err
+	}
+	return &createProfileNode{n: n, roleOptions: roleOptions}, nil
+}
+
+// AlterProfile alters a role profile.
+// Privileges: CREATEROLE and CREATELOGIN privileges.
+func (p *planner) AlterProfile(ctx context.Context, n *tree.AlterProfile) (planNode, error) {
+	roleOptions, err := p.planProfileOptions(ctx, n.KVOptions, "ALTER PROFILE")
+	if err != nil {
+		return nil, err
+	}
+	return &alterProfileNode{n: n, roleOptions: roleOptions}, nil
+}
+
+// DropProfile drops role profiles.
+// Privileges: CREATEROLE and CREATELOGIN privileges.
+func (p *planner) DropProfile(ctx context.Context, n *tree.DropProfile) (planNode, error) {
+	if err := p.checkCanManageProfiles(ctx); err != nil {
+		return nil, err
+	}
+	return &dropProfileNode{n: n}, nil
+}
+
+// checkCanManageProfiles checks that the profiles can be used in the
+// cluster and that the user can manage them. Since profiles carry login
+// related options, managing them requires the same privileges as
+// setting these options on a role. The profiles assigned to admins are
+// further checked by checkCanManageProfileMembers.
+func (p *planner) checkCanManageProfiles(ctx context.Context) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.RoleProfilesTable) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use role profiles",
+			clusterversion.ByKey(clusterversion.RoleProfilesTable))
+	}
+	if err := p.CheckRoleOption(ctx, roleoption.CREATEROLE); err != nil {
+		return err
+	}
+	return p.CheckRoleOption(ctx, roleoption.CREATELOGIN)
+}
+
+// planProfileOptions checks the privileges of the user and converts the
+// options of a CREATE or ALTER PROFILE statement to role options.
+func (p *planner) planProfileOptions(
+	ctx context.Context, kvOptions tree.KVOptions, opName string,
+) (roleoption.List, error) {
+	if err := p.checkCanManageProfiles(ctx); err != nil {
+		return nil, err
+	}
+	asStringOrNull := func(e tree.Expr, op string) (func() (bool, string, error), error) {
+		return p.TypeAsStringOrNull(ctx, e, op)
+	}
+	return kvOptions.ToRoleOptions(asStringOrNull, opName)
+}
+
+func (n *createProfileNode) startExec(params runParams) error {
+	const opName = "create-profile"
+	exists, err := profileExists(params, opName, n.n.Name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		if n.n.IfNotExists {
+			return nil
+		}
+		return pgerror.Newf(pgcode.DuplicateObject, "a profile named %s already exists", n.n.Name)
+	}
+	opts := make(roleoption.ProfileOptions)
+	if err := applyProfileOptions(params, n.roleOptions, opts); err != nil {
+		return err
+	}
+	if err := writeProfileOptions(params, opName, n.n.Name, opts); err != nil {
+		return err
+	}
+	return params.p.logEvent(params.ctx,
+		0, /* no target */
+		&eventpb.CreateProfile{
+			ProfileName: string(n.n.Name),
+			Options:     profileOptionStrings(n.roleOptions),
+		})
+}
+
+func (n *alterProfileNode) startExec(params runParams) error {
+	const opName = "alter-profile"
+	opts, err := getRoleProfileOptions(
+		params.ctx, params.p.txn, params.ExecCfg().InternalExecutor, string(n.n.Name),
+	)
+	if err != nil {
+		return err
+	}
+	if opts == nil {
+		if n.n.IfExists {
+			return nil
+		}
+		return pgerror.Newf(pgcode.UndefinedObject, "profile %s does not exist", n.n.Name)
+	}
+	if err := checkCanManageProfileMembers(params, opName, "ALTER PROFILE", n.n.Name); err != nil {
+		return err
+	}
+	if err := applyProfileOptions(params, n.roleOptions, opts); err != nil {
+		return err
+	}
+	if err := writeProfileOptions(params, opName, n.n.Name, opts); err != nil {
+		return err
+	}
+	// The options of the roles assigned to the profile have changed.
+	if err := bumpRoleOptionsTableVersionIfCached(params); err != nil {
+		return err
+	}
+	return params.p.logEvent(params.ctx,
+		0, /* no target */
+		&eventpb.AlterProfile{
+			ProfileName: string(n.n.Name),
+			Options:     profileOptionStrings(n.roleOptions),
+		})
+}
+
+func (n *dropProfileNode) startExec(params runParams) error {
+	const opName = "drop-profile"
+	ie := params.ExecCfg().InternalExecutor
+	for i := range n.n.Names {
+		name := n.n.Names[i]
+		if err := checkCanManageProfileMembers(params, opName, "DROP PROFILE", name); err != nil {
+			return err
+		}
+		row, err := ie.QueryRowEx(
+			params.ctx, opName, params.p.txn,
+			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+			`SELECT count(*) FROM system.public.role_options WHERE option = 'PROFILE' AND value = $1`,
+			string(name),
+		)
+		if err != nil {
+			return err
+		}
+		if numRoles := tree.MustBeDInt(row[0]); numRoles > 0 {
+			return errors.WithHint(
+				pgerror.Newf(pgcode.DependentObjectsStillExist,
+					"cannot drop profile %s: it is assigned to %d roles", name, numRoles),
+				"Use ALTER ROLE ... PROFILE NULL to remove the roles from the profile.",
+			)
+		}
+		rowsDeleted, err := ie.ExecEx(
+			params.ctx, opName, params.p.txn,
+			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+			`DELETE FROM system.public.role_profiles WHERE profile_name = $1`,
+			string(name),
+		)
+		if err != nil {
+			return err
+		}
+		if rowsDeleted == 0 {
+			if n.n.IfExists {
+				continue
+			}
+			return pgerror.Newf(pgcode.UndefinedObject, "profile %s does not exist", name)
+		}
+		if err := params.p.logEvent(params.ctx,
+			0, /* no target */
+			&eventpb.DropProfile{ProfileName: string(name)},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyProfileOptions applies role options to the options of a profile,
+// and checks the values which depend on the cluster settings.
+func applyProfileOptions(
+	params runParams, roleOptions roleoption.List, opts roleoption.ProfileOptions,
+) error {
+	if err := roleOptions.ApplyToProfile(opts); err != nil {
+		return err
+	}
+	for option := range sessionVarRoleOptions {
+		if v := opts[option.String()]; v != nil {
+			if err := checkSessionVarRoleOption(params, option, *v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sessionVarRoleOptions maps the role options which set a session
+// variable of the sessions of a role to the session variable.
+var sessionVarRoleOptions = map[roleoption.Option]string{
+	roleoption.IDLESESSIONTIMEOUT: "idle_in_session_timeout",
+	roleoption.STATEMENTTIMEOUT:   "statement_timeout",
+	roleoption.TRANSACTIONTIMEOUT: "transaction_timeout",
+	roleoption.QUALITYOFSERVICE:   "default_transaction_quality_of_service",
+}
+
+// checkSessionVarRoleOption returns an error if the value of an option of
+// a role or profile which sets a session variable is not a valid value of
+// the variable. The STATEMENT_TIMEOUT and TRANSACTION_TIMEOUT options
+// bound the timeouts of the sessions, and so cannot disable them.
+func checkSessionVarRoleOption(params runParams, option roleoption.Option, value string) error {
+	varName := sessionVarRoleOptions[option]
+	if err := CheckSessionVariableValueValid(
+		params.ctx, params.ExecCfg().Settings, varName, value,
+	); err != nil {
+		return errors.Wrapf(err, "invalid %s", option)
+	}
+	if option != roleoption.STATEMENTTIMEOUT && option != roleoption.TRANSACTIONTIMEOUT {
+		return nil
+	}
+	timeout, err := parseTimeoutRoleOption(varName, value)
+	if err != nil {
+		return err
+	}
+	if timeout == 0 {
+		return pgerror.Newf(pgcode.InvalidParameterValue, "%s must be positive", option)
+	}
+	return nil
+}
+
+// parseTimeoutRoleOption parses the value of a timeout option of a role or
+// profile, which is a valid value of the given session variable.
+func parseTimeoutRoleOption(varName string, value string) (time.Duration, error) {
+	return validateTimeoutVar(duration.IntervalStyle_POSTGRES, value, varName)
+}
+
+// checkRoleSessionVarOptions returns an error if the role options set an
+// option of the role which sets a session variable to an invalid value.
+func checkRoleSessionVarOptions(params runParams, roleOptions roleoption.List) error {
+	for _, ro := range roleOptions {
+		if _, ok := sessionVarRoleOptions[ro.Option]; !ok {
+			continue
+		}
+		isNull, value, err := ro.Value()
+		if err != nil {
+			return err
+		}
+		if isNull {
+			continue
+		}
+		if err := checkSessionVarRoleOption(params, ro.Option, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeProfileOptions stores the options of a profile in
+// system.role_profiles.
+func writeProfileOptions(
+	params runParams, opName string, name tree.Name, opts roleoption.ProfileOptions,
+) error {
+	_, err := params.ExecCfg().InternalExecutor.ExecEx(
+		params.ctx, opName, params.p.txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`UPSERT INTO system.public.role_profiles (profile_name, options) VALUES ($1, $2::JSONB)`,
+		string(name), opts.Encode(),
+	)
+	return err
+}
+
+// checkCanManageProfileMembers returns an error if the profile is assigned
+// to an admin and the user is not an admin. Changing the profile changes
+// the options of all its members, so, as with ALTER ROLE, only admins can
+// do so for the admins.
+func checkCanManageProfileMembers(
+	params runParams, opName string, stmt string, name tree.Name,
+) error {
+	hasAdmin, err := params.p.HasAdminRole(params.ctx)
+	if err != nil || hasAdmin {
+		return err
+	}
+	rows, err := params.ExecCfg().InternalExecutor.QueryBufferedEx(
+		params.ctx, opName, params.p.txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`SELECT username FROM system.public.role_options WHERE option = 'PROFILE' AND value = $1`,
+		string(name),
+	)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		member := security.MakeSQLUsernameFromPreNormalizedString(string(tree.MustBeDString(row[0])))
+		isAdmin, err := params.p.UserHasAdminRole(params.ctx, member)
+		if err != nil {
+			return err
+		}
+		if isAdmin {
+			return params.p.RequireAdminRole(params.ctx,
+				fmt.Sprintf("%s %s, which is assigned to admin %s", stmt, name, member))
+		}
+	}
+	return nil
+}
+
+// profileExists returns whether the profile with the given name exists.
+func profileExists(params runParams, opName string, name tree.Name) (bool, error) {
+	row, err := params.ExecCfg().InternalExecutor.QueryRowEx(
+		params.ctx, opName, params.p.txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`SELECT 1 FROM system.public.role_profiles WHERE profile_name = $1`,
+		string(name),
+	)
+	return row != nil, err
+}
+
+// checkRoleProfileOptions returns an error if the options of a role
+// include options which can only be set in a profile, or if they assign
+// the role to a profile before the profiles can be used.
+func (p *planner) checkRoleProfileOptions(ctx context.Context, roleOptions roleoption.List) error {
+	for _, ro := range roleOptions {
+		if ro.Option.IsProfileOnly() {
+			return errors.WithHint(
+				pgerror.Newf(pgcode.InvalidParameterValue,
+					"%s can only be set in a profile", ro.Option),
+				"Use CREATE PROFILE or ALTER PROFILE to set it in a profile, and ALTER ROLE ... PROFILE to assign the profile to the role.",
+			)
+		}
+	}
+	if roleOptions.Contains(roleoption.PROFILE) &&
+		!p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.RoleProfilesTable) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use role profiles",
+			clusterversion.ByKey(clusterversion.RoleProfilesTable))
+	}
+	return nil
+}
+
+// checkRoleProfileExists returns an error if the role options assign the
+// role to a profile which does not exist.
+func checkRoleProfileExists(params runParams, opName string, roleOptions roleoption.List) error {
+	for _, ro := range roleOptions {
+		if ro.Option != roleoption.PROFILE {
+			continue
+		}
+		isNull, name, err := ro.Value()
+		if err != nil || isNull {
+			return err
+		}
+		exists, err := profileExists(params, opName, tree.Name(name))
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return pgerror.Newf(pgcode.UndefinedObject, "profile %s does not exist", tree.Name(name))
+		}
+	}
+	return nil
+}
+
+// getRoleProfileOptions returns the options of the profile with the given
+// name, or nil if it does not exist.
+func getRoleProfileOptions(
+	ctx context.Context, txn *kv.Txn, ie sqlutil.InternalExecutor, profileName string,
+) (roleoption.ProfileOptions, error) {
+	row, err := ie.QueryRowEx(
+		ctx, "get-profile-options", txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`SELECT options FROM system.public.role_profiles WHERE profile_name = $1`,
+		profileName,
+	)
+	if err != nil || row == nil {
+		return nil, err
+	}
+	return roleoption.DecodeProfileOptions(tree.MustBeDJSON(row[0]).JSON.String())
+}
+
+// getRoleProfileOptionsForUser returns the options of the profile of the
+// given user, or nil if the user is not assigned to a profile.
+func getRoleProfileOptionsForUser(
+	ctx context.Context, txn *kv.Txn, ie sqlutil.InternalExecutor, username security.SQLUsername,
+) (roleoption.ProfileOptions, error) {
+	row, err := ie.QueryRowEx(
+		ctx, "get-user-profile", txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`SELECT value FROM system.public.role_options WHERE username = $1 AND option = 'PROFILE'`,
+		username,
+	)
+	if err != nil || row == nil || row[0] == tree.DNull {
+		return nil, err
+	}
+	return getRoleProfileOptions(ctx, txn, ie, string(tree.MustBeDString(row[0])))
+}
+
+// bumpRoleOptionsTableVersionIfCached forces a refresh of the AuthInfo
+// cache, which includes the options of the profiles of the users.
+func bumpRoleOptionsTableVersionIfCached(params runParams) error {
+	if !sessioninit.CacheEnabled.Get(&params.ExecCfg().Settings.SV) {
+		return nil
+	}
+	return params.p.bumpRoleOptionsTableVersion(params.ctx)
+}
+
+// profileOptionStrings returns the names of the options of a CREATE or
+// ALTER PROFILE statement, for the event log.
+func profileOptionStrings(roleOptions roleoption.List) []string {
+	optStrs := make([]string, len(roleOptions))
+	for i := range optStrs {
+		optStrs[i] = roleOptions[i].String()
+	}
+	return optStrs
+}
+
+// Next implements the planNode interface.
+func (*createProfileNode) Next(runParams) (bool, error) { return false, nil }
+
+// Values implements the planNode interface.
+func (*createProfileNode) Values() tree.Datums { return tree.Datums{} }
+
+// Close implements the planNode interface.
+func (*createProfileNode) Close(context.Context) {}
+
+// Next implements the planNode interface.
+func (*alterProfileNode) Next(runParams) (bool, error) { return false, nil }
+
+// Values implements the planNode interface.
+func (*alterProfileNode) Values() tree.Datums { return tree.Datums{} }
+
+// Close implements the planNode interface.
+func (*alterProfileNode) Close(context.Context) {}
+
+// Next implements the planNode interface.
+func (*dropProfileNode) Next(runParams) (bool, error) { return false, nil }
+
+// Values implements the planNode interface.
+func (*dropProfileNode) Values() tree.Datums { return tree.Datums{} }
+
+// Close implements the planNode interface.
+func (*dropProfileNode) Close(context.Context) {}
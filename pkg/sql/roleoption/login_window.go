@@ -0,0 +1,185 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package roleoption
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// LoginWindow is the value of the LOGIN WINDOW role option, that is the
+// times of the day, and optionally the days of the week, during which a
+// user can log in. It is stored JSON-encoded in system.role_options.
+type LoginWindow struct {
+	// Start and End are the times of the day at which the window opens
+	// and closes, formatted as HH:MM. If End is before Start, the window
+	// spans midnight.
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// TimeZone is the name of the time zone of the window, or empty for
+	// UTC.
+	TimeZone string `json:"time_zone,omitempty"`
+	// Days are the days of the week during which the window opens, as
+	// abbreviated lowercase names, or empty for every day.
+	Days []string `json:"days,omitempty"`
+}
+
+// loginWindowTimeLayout is the layout of the start and end times of a
+// login window.
+const loginWindowTimeLayout = "15:04"
+
+// loginWindowDays maps the names of the days of the week accepted in
+// login windows to the days.
+var loginWindowDays = func() map[string]time.Weekday {
+	days := make(map[string]time.Weekday, 14)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		days[name] = d
+		days[name[:3]] = d
+	}
+	return days
+}()
+
+// MakeLoginWindow makes a login window from the values of a LOGIN
+// BETWEEN clause: its start and end times, its time zone or an empty
+// string, followed by its days of the week if any.
+func MakeLoginWindow(values []string) (LoginWindow, error) {
+	if len(values) < 3 {
+		return LoginWindow{}, errors.AssertionFailedf("invalid login window values %q", values)
+	}
+	w := LoginWindow{Start: values[0], End: values[1], TimeZone: values[2]}
+	for _, day := range values[3:] {
+		d, ok := loginWindowDays[strings.ToLower(day)]
+		if !ok {
+			return LoginWindow{}, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid day of the week %q", day)
+		}
+		w.Days = append(w.Days, strings.ToLower(d.String()[:3]))
+	}
+	if err := w.validate(); err != nil {
+		return LoginWindow{}, err
+	}
+	return w, nil
+}
+
+// DecodeLoginWindow decodes a login window encoded with Encode.
+func DecodeLoginWindow(encoded string) (*LoginWindow, error) {
+	var w LoginWindow
+	if err := json.Unmarshal([]byte(encoded), &w); err != nil {
+		return nil, errors.Wrapf(err, "invalid login window %q", encoded)
+	}
+	if err := w.validate(); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// Encode encodes the login window for storage in system.role_options.
+func (w *LoginWindow) Encode() string {
+	encoded, err := json.Marshal(w)
+	if err != nil {
+		// Marshaling a login window cannot fail.
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "encoding login window"))
+	}
+	return string(encoded)
+}
+
+// validate checks that the times, time zone and days of the login window
+// are valid.
+func (w *LoginWindow) validate() error {
+	start, end, _, err := w.parse()
+	if err != nil {
+		return err
+	}
+	if start == end {
+		return pgerror.New(pgcode.InvalidParameterValue,
+			"the start and end of a login window must differ")
+	}
+	for _, day := range w.Days {
+		if _, ok := loginWindowDays[day]; !ok {
+			return pgerror.Newf(pgcode.InvalidParameterValue, "invalid day of the week %q", day)
+		}
+	}
+	return nil
+}
+
+// parse returns the start and end times of the login window, as
+// durations since midnight, and its time zone.
+func (w *LoginWindow) parse() (start, end time.Duration, loc *time.Location, err error) {
+	parseTime := func(s string) (time.Duration, error) {
+		t, err := time.Parse(loginWindowTimeLayout, s)
+		if err != nil {
+			return 0, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid login window time %q: expected HH:MM", s)
+		}
+		return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+	}
+	if start, err = parseTime(w.Start); err != nil {
+		return 0, 0, nil, err
+	}
+	if end, err = parseTime(w.End); err != nil {
+		return 0, 0, nil, err
+	}
+	loc = time.UTC
+	if w.TimeZone != "" {
+		if loc, err = timeutil.LoadLocation(w.TimeZone); err != nil {
+			return 0, 0, nil, pgerror.Wrapf(err, pgcode.InvalidParameterValue,
+				"invalid login window time zone %q", w.TimeZone)
+		}
+	}
+	return start, end, loc, nil
+}
+
+// Allows returns whether the login window is open at the given time. A
+// nil window is always open. When the window spans midnight, the days
+// of the week apply to the day on which it opened.
+func (w *LoginWindow) Allows(t time.Time) bool {
+	if w == nil {
+		return true
+	}
+	start, end, loc, err := w.parse()
+	if err != nil {
+		// The window was validated when it was decoded.
+		return false
+	}
+	t = t.In(loc)
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	sinceMidnight := t.Sub(midnight)
+	day := t.Weekday()
+	switch {
+	case start < end:
+		if sinceMidnight < start || sinceMidnight >= end {
+			return false
+		}
+	case sinceMidnight >= start:
+		// The window opened today and spans midnight.
+	case sinceMidnight < end:
+		// The window opened yesterday.
+		day = (day + 6) % 7
+	default:
+		return false
+	}
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if loginWindowDays[d] == day {
+			return true
+		}
+	}
+	return false
+}
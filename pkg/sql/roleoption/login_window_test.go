@@ -0,0 +1,88 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package roleoption_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeLoginWindow(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	w, err := roleoption.MakeLoginWindow([]string{"08:00", "18:00", "America/New_York", "Mon", "friday"})
+	require.NoError(t, err)
+	require.Equal(t, roleoption.LoginWindow{
+		Start: "08:00", End: "18:00", TimeZone: "America/New_York", Days: []string{"mon", "fri"},
+	}, w)
+	decoded, err := roleoption.DecodeLoginWindow(w.Encode())
+	require.NoError(t, err)
+	require.Equal(t, w, *decoded)
+
+	for _, tc := range []struct {
+		values []string
+		err    string
+	}{
+		{[]string{"8am", "18:00", ""}, `invalid login window time "8am": expected HH:MM`},
+		{[]string{"08:00", "24:00", ""}, `invalid login window time "24:00": expected HH:MM`},
+		{[]string{"08:00", "08:00", ""}, `the start and end of a login window must differ`},
+		{[]string{"08:00", "18:00", "Mars/Olympus_Mons"}, `invalid login window time zone "Mars/Olympus_Mons"`},
+		{[]string{"08:00", "18:00", "", "someday"}, `invalid day of the week "someday"`},
+	} {
+		_, err := roleoption.MakeLoginWindow(tc.values)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), tc.err)
+	}
+}
+
+func TestLoginWindowAllows(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	at := func(s string) time.Time {
+		ts, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return ts
+	}
+
+	// A nil window is always open.
+	var nilWindow *roleoption.LoginWindow
+	require.True(t, nilWindow.Allows(at("2022-06-01T03:00:00Z")))
+
+	for _, tc := range []struct {
+		name   string
+		window roleoption.LoginWindow
+		time   string
+		allows bool
+	}{
+		{"before", roleoption.LoginWindow{Start: "08:00", End: "18:00"}, "2022-06-01T07:59:00Z", false},
+		{"start", roleoption.LoginWindow{Start: "08:00", End: "18:00"}, "2022-06-01T08:00:00Z", true},
+		{"end", roleoption.LoginWindow{Start: "08:00", End: "18:00"}, "2022-06-01T18:00:00Z", false},
+		// 2022-06-01 is a Wednesday.
+		{"day", roleoption.LoginWindow{Start: "08:00", End: "18:00", Days: []string{"wed"}}, "2022-06-01T12:00:00Z", true},
+		{"other day", roleoption.LoginWindow{Start: "08:00", End: "18:00", Days: []string{"mon", "tue"}}, "2022-06-01T12:00:00Z", false},
+		// 12:00 UTC is 08:00 in New York in the summer.
+		{"time zone", roleoption.LoginWindow{Start: "09:00", End: "17:00", TimeZone: "America/New_York"}, "2022-06-01T12:00:00Z", false},
+		{"time zone open", roleoption.LoginWindow{Start: "08:00", End: "17:00", TimeZone: "America/New_York"}, "2022-06-01T12:00:00Z", true},
+		// A window spanning midnight applies to the day on which it opens.
+		{"overnight evening", roleoption.LoginWindow{Start: "22:00", End: "06:00", Days: []string{"wed"}}, "2022-06-01T23:00:00Z", true},
+		{"overnight morning", roleoption.LoginWindow{Start: "22:00", End: "06:00", Days: []string{"wed"}}, "2022-06-02T05:00:00Z", true},
+		{"overnight other morning", roleoption.LoginWindow{Start: "22:00", End: "06:00", Days: []string{"wed"}}, "2022-06-01T05:00:00Z", false},
+		{"overnight closed", roleoption.LoginWindow{Start: "22:00", End: "06:00"}, "2022-06-01T12:00:00Z", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.allows, tc.window.Allows(at(tc.time)))
+		})
+	}
+}
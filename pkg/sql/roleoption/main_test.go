@@ -0,0 +1,15 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package roleoption_test
+
+import _ "github.com/cockroachdb/cockroach/pkg/util/log"
+
+//go:generate ../../util/leaktest/add-leaktest.sh *_test.go
@@ -35,11 +35,30 @@ func _() {
 	_ = x[NOSQLLOGIN-25]
 	_ = x[VIEWCLUSTERSETTING-26]
 	_ = x[NOVIEWCLUSTERSETTING-27]
+	_ = x[PASSWORDMUSTCHANGE-28]
+	_ = x[CONNECTIONLIMIT-29]
+	_ = x[SUBJECT-30]
+	_ = x[MFA-31]
+	_ = x[NOMFA-32]
+	_ = x[READONLY-33]
+	_ = x[NOREADONLY-34]
+	_ = x[ALLOWEDDATABASES-35]
+	_ = x[LOGINWINDOW-36]
+	_ = x[COST-37]
+	_ = x[TERMINATESESSIONS-38]
+	_ = x[PROFILE-39]
+	_ = x[IDLESESSIONTIMEOUT-40]
+	_ = x[PASSWORDHISTORY-41]
+	_ = x[STATEMENTTIMEOUT-42]
+	_ = x[TRANSACTIONTIMEOUT-43]
+	_ = x[QUALITYOFSERVICE-44]
+	_ = x[MAXRESULTROWS-45]
+	_ = x[MAXRESULTBYTES-46]
 }
 
-const _Option_name = "CREATEROLENOCREATEROLEPASSWORDLOGINNOLOGINVALID UNTILCONTROLJOBNOCONTROLJOBCONTROLCHANGEFEEDNOCONTROLCHANGEFEEDCREATEDBNOCREATEDBCREATELOGINNOCREATELOGINVIEWACTIVITYNOVIEWACTIVITYCANCELQUERYNOCANCELQUERYMODIFYCLUSTERSETTINGNOMODIFYCLUSTERSETTINGDEFAULTSETTINGSVIEWACTIVITYREDACTEDNOVIEWACTIVITYREDACTEDSQLLOGINNOSQLLOGINVIEWCLUSTERSETTINGNOVIEWCLUSTERSETTING"
+const _Option_name = "CREATEROLENOCREATEROLEPASSWORDLOGINNOLOGINVALID UNTILCONTROLJOBNOCONTROLJOBCONTROLCHANGEFEEDNOCONTROLCHANGEFEEDCREATEDBNOCREATEDBCREATELOGINNOCREATELOGINVIEWACTIVITYNOVIEWACTIVITYCANCELQUERYNOCANCELQUERYMODIFYCLUSTERSETTINGNOMODIFYCLUSTERSETTINGDEFAULTSETTINGSVIEWACTIVITYREDACTEDNOVIEWACTIVITYREDACTEDSQLLOGINNOSQLLOGINVIEWCLUSTERSETTINGNOVIEWCLUSTERSETTINGPASSWORD MUST CHANGECONNECTION LIMITSUBJECTMFANOMFAREADONLYNOREADONLYALLOWED DATABASESLOGIN WINDOWCOSTTERMINATE SESSIONSPROFILEIDLE_SESSION_TIMEOUTPASSWORD_HISTORYSTATEMENT_TIMEOUTTRANSACTION_TIMEOUTQUALITY_OF_SERVICEMAX_RESULT_ROWSMAX_RESULT_BYTES"
 
-var _Option_index = [...]uint16{0, 10, 22, 30, 35, 42, 53, 63, 75, 92, 111, 119, 129, 140, 153, 165, 179, 190, 203, 223, 245, 260, 280, 302, 310, 320, 338, 358}
+var _Option_index = [...]uint16{0, 10, 22, 30, 35, 42, 53, 63, 75, 92, 111, 119, 129, 140, 153, 165, 179, 190, 203, 223, 245, 260, 280, 302, 310, 320, 338, 358, 378, 394, 401, 404, 409, 417, 427, 444, 456, 460, 478, 485, 505, 521, 538, 557, 575, 590, 606}
 
 func (i Option) String() string {
 	i -= 1
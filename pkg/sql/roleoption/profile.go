@@ -0,0 +1,115 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package roleoption
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+// profileOptions are the role options which can be part of a role
+// profile. NOMFA and NOREADONLY remove MFA and READONLY from a profile.
+var profileOptions = map[Option]struct{}{
+	CONNECTIONLIMIT:    {},
+	MFA:                {},
+	NOMFA:              {},
+	READONLY:           {},
+	NOREADONLY:         {},
+	ALLOWEDDATABASES:   {},
+	LOGINWINDOW:        {},
+	IDLESESSIONTIMEOUT: {},
+	PASSWORDHISTORY:    {},
+	STATEMENTTIMEOUT:   {},
+	TRANSACTIONTIMEOUT: {},
+	QUALITYOFSERVICE:   {},
+	MAXRESULTROWS:      {},
+	MAXRESULTBYTES:     {},
+}
+
+// IsProfileOnly returns whether the option can only be set in a role
+// profile, and not on a role directly.
+func (o Option) IsProfileOnly() bool {
+	return o == PASSWORDHISTORY
+}
+
+// ProfileOptions are the role options of a role profile, as stored in
+// system.role_profiles. They map the name of each option to its value,
+// or to nil if the option has no value.
+type ProfileOptions map[string]*string
+
+// ApplyToProfile checks that the role options can be part of a role
+// profile and applies them to the options of the profile. The options
+// set to NULL are removed from the profile.
+func (rol List) ApplyToProfile(opts ProfileOptions) error {
+	if err := rol.CheckRoleOptionConflicts(); err != nil {
+		return err
+	}
+	for _, ro := range rol {
+		if _, ok := profileOptions[ro.Option]; !ok {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"role option %s cannot be part of a profile", ro.Option)
+		}
+		switch ro.Option {
+		case NOMFA:
+			delete(opts, MFA.String())
+			continue
+		case NOREADONLY:
+			delete(opts, READONLY.String())
+			continue
+		}
+		valueFn := ro.storedValue()
+		if valueFn == nil {
+			opts[ro.Option.String()] = nil
+			continue
+		}
+		isNull, value, err := valueFn()
+		if err != nil {
+			return err
+		}
+		if isNull {
+			delete(opts, ro.Option.String())
+			continue
+		}
+		if ro.Option == PASSWORDHISTORY {
+			if n, err := strconv.Atoi(value); err != nil || n < 0 {
+				return pgerror.Newf(pgcode.InvalidParameterValue,
+					"%s must be a non-negative integer, got %s", ro.Option, value)
+			}
+		}
+		opts[ro.Option.String()] = &value
+	}
+	return nil
+}
+
+// Encode encodes the options of a profile for storage in
+// system.role_profiles.
+func (opts ProfileOptions) Encode() string {
+	encoded, err := json.Marshal(opts)
+	if err != nil {
+		// Marshaling a map of strings cannot fail.
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "encoding profile options"))
+	}
+	return string(encoded)
+}
+
+// DecodeProfileOptions decodes the options of a profile encoded with
+// Encode.
+func DecodeProfileOptions(encoded string) (ProfileOptions, error) {
+	opts := make(ProfileOptions)
+	if err := json.Unmarshal([]byte(encoded), &opts); err != nil {
+		return nil, errors.Wrapf(err, "invalid profile options %q", encoded)
+	}
+	return opts, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package roleoption_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyToProfile(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	withValue := func(o roleoption.Option, v string) roleoption.RoleOption {
+		return roleoption.RoleOption{
+			Option: o, HasValue: true, Value: func() (bool, string, error) { return false, v, nil },
+		}
+	}
+	withNull := func(o roleoption.Option) roleoption.RoleOption {
+		return roleoption.RoleOption{
+			Option: o, HasValue: true, Value: func() (bool, string, error) { return true, "", nil },
+		}
+	}
+	str := func(s string) *string { return &s }
+
+	opts := make(roleoption.ProfileOptions)
+	require.NoError(t, roleoption.List{
+		withValue(roleoption.CONNECTIONLIMIT, "10"),
+		{Option: roleoption.MFA},
+		withValue(roleoption.PASSWORDHISTORY, "5"),
+	}.ApplyToProfile(opts))
+	require.Equal(t, roleoption.ProfileOptions{
+		"CONNECTION LIMIT": str("10"),
+		"MFA":              nil,
+		"PASSWORD_HISTORY": str("5"),
+	}, opts)
+
+	// The options round-trip through their encoding.
+	decoded, err := roleoption.DecodeProfileOptions(opts.Encode())
+	require.NoError(t, err)
+	require.Equal(t, opts, decoded)
+
+	// NOMFA and NULL values remove options from the profile.
+	require.NoError(t, roleoption.List{
+		{Option: roleoption.NOMFA},
+		withNull(roleoption.PASSWORDHISTORY),
+		withValue(roleoption.CONNECTIONLIMIT, "20"),
+	}.ApplyToProfile(opts))
+	require.Equal(t, roleoption.ProfileOptions{"CONNECTION LIMIT": str("20")}, opts)
+
+	// The result limits are stored as they were specified.
+	require.NoError(t, roleoption.List{
+		withValue(roleoption.MAXRESULTROWS, "1000"),
+		withValue(roleoption.MAXRESULTBYTES, "64MiB"),
+	}.ApplyToProfile(opts))
+	require.Equal(t, str("64MiB"), opts["MAX_RESULT_BYTES"])
+	limit, err := roleoption.ParseResultLimit(roleoption.MAXRESULTBYTES, *opts["MAX_RESULT_BYTES"])
+	require.NoError(t, err)
+	require.Equal(t, int64(64<<20), limit)
+
+	for _, tc := range []struct {
+		options roleoption.List
+		err     string
+	}{
+		{roleoption.List{{Option: roleoption.CREATEDB}}, "role option CREATEDB cannot be part of a profile"},
+		{roleoption.List{withValue(roleoption.PROFILE, "p")}, "role option PROFILE cannot be part of a profile"},
+		{roleoption.List{{Option: roleoption.MFA}, {Option: roleoption.NOMFA}}, "conflicting role options"},
+		{roleoption.List{withValue(roleoption.PASSWORDHISTORY, "-1")}, "PASSWORD_HISTORY must be a non-negative integer"},
+		{roleoption.List{withValue(roleoption.MAXRESULTROWS, "0")}, "MAX_RESULT_ROWS must be positive, got 0"},
+		{roleoption.List{withValue(roleoption.MAXRESULTBYTES, "lots")}, "MAX_RESULT_BYTES must be positive, got lots"},
+	} {
+		err := tc.options.ApplyToProfile(make(roleoption.ProfileOptions))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), tc.err)
+	}
+}
@@ -11,11 +11,15 @@
 package roleoption
 
 import (
+	"encoding/json"
+	"strconv"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/humanizeutil"
 	"github.com/cockroachdb/errors"
 )
 
@@ -62,6 +66,25 @@ const (
 	NOSQLLOGIN
 	VIEWCLUSTERSETTING
 	NOVIEWCLUSTERSETTING
+	PASSWORDMUSTCHANGE // PASSWORD MUST CHANGE
+	CONNECTIONLIMIT    // CONNECTION LIMIT
+	SUBJECT
+	MFA
+	NOMFA
+	READONLY
+	NOREADONLY
+	ALLOWEDDATABASES // ALLOWED DATABASES
+	LOGINWINDOW      // LOGIN WINDOW
+	COST
+	TERMINATESESSIONS // TERMINATE SESSIONS
+	PROFILE
+	IDLESESSIONTIMEOUT // IDLE_SESSION_TIMEOUT
+	PASSWORDHISTORY    // PASSWORD_HISTORY
+	STATEMENTTIMEOUT   // STATEMENT_TIMEOUT
+	TRANSACTIONTIMEOUT // TRANSACTION_TIMEOUT
+	QUALITYOFSERVICE   // QUALITY_OF_SERVICE
+	MAXRESULTROWS      // MAX_RESULT_ROWS
+	MAXRESULTBYTES     // MAX_RESULT_BYTES
 )
 
 // toSQLStmts is a map of Kind -> SQL statement string for applying the
@@ -92,10 +115,26 @@ var toSQLStmts = map[Option]string{
 	NOVIEWACTIVITYREDACTED: `DELETE FROM system.role_options WHERE username = $1 AND option = 'VIEWACTIVITYREDACTED'`,
 	VIEWCLUSTERSETTING:     `UPSERT INTO system.role_options (username, option) VALUES ($1, 'VIEWCLUSTERSETTING')`,
 	NOVIEWCLUSTERSETTING:   `DELETE FROM system.role_options WHERE username = $1 AND option = 'VIEWCLUSTERSETTING'`,
+	PASSWORDMUSTCHANGE:     `UPSERT INTO system.role_options (username, option) VALUES ($1, 'PASSWORD MUST CHANGE')`,
+	CONNECTIONLIMIT:        `UPSERT INTO system.role_options (username, option, value) VALUES ($1, 'CONNECTION LIMIT', $2::INT8::STRING)`,
+	SUBJECT:                `UPSERT INTO system.role_options (username, option, value) VALUES ($1, 'SUBJECT', $2)`,
+	MFA:                    `UPSERT INTO system.role_options (username, option) VALUES ($1, 'MFA')`,
+	NOMFA:                  `DELETE FROM system.role_options WHERE username = $1 AND option = 'MFA'`,
+	READONLY:               `UPSERT INTO system.role_options (username, option) VALUES ($1, 'READONLY')`,
+	NOREADONLY:             `DELETE FROM system.role_options WHERE username = $1 AND option = 'READONLY'`,
+	ALLOWEDDATABASES:       `UPSERT INTO system.role_options (username, option, value) VALUES ($1, 'ALLOWED DATABASES', $2)`,
+	LOGINWINDOW:            `UPSERT INTO system.role_options (username, option, value) VALUES ($1, 'LOGIN WINDOW', $2)`,
+	PROFILE:                `UPSERT INTO system.role_options (username, option, value) VALUES ($1, 'PROFILE', $2)`,
+	IDLESESSIONTIMEOUT:     `UPSERT INTO system.role_options (username, option, value) VALUES ($1, 'IDLE_SESSION_TIMEOUT', $2)`,
+	STATEMENTTIMEOUT:       `UPSERT INTO system.role_options (username, option, value) VALUES ($1, 'STATEMENT_TIMEOUT', $2)`,
+	TRANSACTIONTIMEOUT:     `UPSERT INTO system.role_options (username, option, value) VALUES ($1, 'TRANSACTION_TIMEOUT', $2)`,
+	QUALITYOFSERVICE:       `UPSERT INTO system.role_options (username, option, value) VALUES ($1, 'QUALITY_OF_SERVICE', $2)`,
+	MAXRESULTROWS:          `UPSERT INTO system.role_options (username, option, value) VALUES ($1, 'MAX_RESULT_ROWS', $2)`,
+	MAXRESULTBYTES:         `UPSERT INTO system.role_options (username, option, value) VALUES ($1, 'MAX_RESULT_BYTES', $2)`,
 }
 
 // Mask returns the bitmask for a given role option.
-func (o Option) Mask() uint32 {
+func (o Option) Mask() uint64 {
 	return 1 << o
 }
 
@@ -128,6 +167,25 @@ var ByName = map[string]Option{
 	"NOSQLLOGIN":             NOSQLLOGIN,
 	"VIEWCLUSTERSETTING":     VIEWCLUSTERSETTING,
 	"NOVIEWCLUSTERSETTING":   NOVIEWCLUSTERSETTING,
+	"PASSWORD MUST CHANGE":   PASSWORDMUSTCHANGE,
+	"CONNECTION LIMIT":       CONNECTIONLIMIT,
+	"SUBJECT":                SUBJECT,
+	"MFA":                    MFA,
+	"NOMFA":                  NOMFA,
+	"READONLY":               READONLY,
+	"NOREADONLY":             NOREADONLY,
+	"ALLOWED DATABASES":      ALLOWEDDATABASES,
+	"LOGIN WINDOW":           LOGINWINDOW,
+	"COST":                   COST,
+	"TERMINATE SESSIONS":     TERMINATESESSIONS,
+	"PROFILE":                PROFILE,
+	"IDLE_SESSION_TIMEOUT":   IDLESESSIONTIMEOUT,
+	"PASSWORD_HISTORY":       PASSWORDHISTORY,
+	"STATEMENT_TIMEOUT":      STATEMENTTIMEOUT,
+	"TRANSACTION_TIMEOUT":    TRANSACTIONTIMEOUT,
+	"QUALITY_OF_SERVICE":     QUALITYOFSERVICE,
+	"MAX_RESULT_ROWS":        MAXRESULTROWS,
+	"MAX_RESULT_BYTES":       MAXRESULTBYTES,
 }
 
 // ToOption takes a string and returns the corresponding Option.
@@ -162,30 +220,50 @@ func (rol List) GetSQLStmts(op string) (map[string]func() (bool, string, error),
 			op,
 			strings.ToLower(ro.Option.String()),
 		)
-		// Skip PASSWORD and DEFAULTSETTINGS options.
+		// Skip PASSWORD, COST, TERMINATE SESSIONS and DEFAULTSETTINGS options.
 		// Since PASSWORD still resides in system.users, we handle setting PASSWORD
-		// outside of this set stmt.
+		// outside of this set stmt. COST only applies to the hashing of the
+		// PASSWORD and is not stored. TERMINATE SESSIONS only applies to the
+		// statement which specifies it and is not stored either.
 		// DEFAULTSETTINGS is stored in system.database_role_settings.
 		// TODO(richardjcai): migrate password to system.role_options
-		if ro.Option == PASSWORD || ro.Option == DEFAULTSETTINGS {
+		if ro.Option == PASSWORD || ro.Option == COST || ro.Option == TERMINATESESSIONS ||
+			ro.Option == DEFAULTSETTINGS {
 			continue
 		}
 
 		stmt := toSQLStmts[ro.Option]
-		if ro.HasValue {
-			stmts[stmt] = ro.Value
-		} else {
-			stmts[stmt] = nil
-		}
+		stmts[stmt] = ro.storedValue()
 	}
 
 	return stmts, nil
 }
 
+// storedValue returns the function which checks the value of the role
+// option and returns it in the form in which it is stored, or nil if
+// the option has no value.
+func (ro RoleOption) storedValue() func() (bool, string, error) {
+	if !ro.HasValue {
+		return nil
+	}
+	switch ro.Option {
+	case SUBJECT:
+		return validateSubject(ro.Value)
+	case ALLOWEDDATABASES:
+		return validateAllowedDatabases(ro.Value)
+	case LOGINWINDOW:
+		return encodeLoginWindow(ro.Value)
+	case MAXRESULTROWS, MAXRESULTBYTES:
+		return validateResultLimit(ro.Option, ro.Value)
+	default:
+		return ro.Value
+	}
+}
+
 // ToBitField returns the bitfield representation of
 // a list of role options.
-func (rol List) ToBitField() (uint32, error) {
-	var ret uint32
+func (rol List) ToBitField() (uint64, error) {
+	var ret uint64
 	for _, p := range rol {
 		if ret&p.Option.Mask() != 0 {
 			return 0, pgerror.Newf(pgcode.Syntax, "redundant role options")
@@ -237,7 +315,11 @@ func (rol List) CheckRoleOptionConflicts() error {
 		(roleOptionBits&SQLLOGIN.Mask() != 0 &&
 			roleOptionBits&NOSQLLOGIN.Mask() != 0) ||
 		(roleOptionBits&VIEWCLUSTERSETTING.Mask() != 0 &&
-			roleOptionBits&NOVIEWCLUSTERSETTING.Mask() != 0) {
+			roleOptionBits&NOVIEWCLUSTERSETTING.Mask() != 0) ||
+		(roleOptionBits&MFA.Mask() != 0 &&
+			roleOptionBits&NOMFA.Mask() != 0) ||
+		(roleOptionBits&READONLY.Mask() != 0 &&
+			roleOptionBits&NOREADONLY.Mask() != 0) {
 		return pgerror.Newf(pgcode.Syntax, "conflicting role options")
 	}
 	return nil
@@ -255,3 +337,132 @@ func (rol List) GetPassword() (isNull bool, password string, err error) {
 	// Password option not found.
 	return false, "", errors.New("password not found in role options")
 }
+
+// GetPasswordCost returns the value of the COST option, that is the
+// hashing cost of the password, or 0 if the option is not found.
+func (rol List) GetPasswordCost() (int64, error) {
+	for _, ro := range rol {
+		if ro.Option == COST {
+			_, cost, err := ro.Value()
+			if err != nil {
+				return 0, err
+			}
+			return strconv.ParseInt(cost, 10, 64)
+		}
+	}
+	return 0, nil
+}
+
+// ParseResultLimit parses the value of a MAX_RESULT_ROWS or
+// MAX_RESULT_BYTES role option, that is a positive number of rows or a
+// positive byte size such as '64MiB'.
+func ParseResultLimit(option Option, value string) (int64, error) {
+	var limit int64
+	var err error
+	if option == MAXRESULTBYTES {
+		limit, err = humanizeutil.ParseBytes(value)
+	} else {
+		limit, err = strconv.ParseInt(value, 10, 64)
+	}
+	if err != nil || limit <= 0 {
+		return 0, pgerror.Newf(pgcode.InvalidParameterValue,
+			"%s must be positive, got %s", option, value)
+	}
+	return limit, nil
+}
+
+// validateResultLimit wraps the value of a MAX_RESULT_ROWS or
+// MAX_RESULT_BYTES role option to check that it is a valid limit.
+func validateResultLimit(
+	option Option, value func() (bool, string, error),
+) func() (bool, string, error) {
+	return func() (bool, string, error) {
+		isNull, limit, err := value()
+		if err != nil || isNull {
+			return isNull, limit, err
+		}
+		if _, err := ParseResultLimit(option, limit); err != nil {
+			return false, "", err
+		}
+		return false, limit, nil
+	}
+}
+
+// validateSubject wraps the value of a SUBJECT role option to check
+// that it is a valid distinguished name.
+func validateSubject(value func() (bool, string, error)) func() (bool, string, error) {
+	return func() (bool, string, error) {
+		isNull, subject, err := value()
+		if err != nil || isNull {
+			return isNull, subject, err
+		}
+		if _, err := security.ParseDistinguishedName(subject); err != nil {
+			return false, "", pgerror.WithCandidateCode(err, pgcode.InvalidParameterValue)
+		}
+		return false, subject, nil
+	}
+}
+
+// validateAllowedDatabases wraps the value of an ALLOWED DATABASES role
+// option to check that it is a list of database names.
+func validateAllowedDatabases(value func() (bool, string, error)) func() (bool, string, error) {
+	return func() (bool, string, error) {
+		isNull, encoded, err := value()
+		if err != nil || isNull {
+			return isNull, encoded, err
+		}
+		dbNames, err := DecodeValueList(encoded)
+		if err != nil {
+			return false, "", err
+		}
+		for _, dbName := range dbNames {
+			if dbName == "" {
+				return false, "", pgerror.New(pgcode.InvalidParameterValue,
+					"the allowed databases cannot include an empty name")
+			}
+		}
+		return false, encoded, nil
+	}
+}
+
+// encodeLoginWindow wraps the value of a LOGIN WINDOW role option, that
+// is the list of values of its LOGIN BETWEEN clause, to check and encode
+// the login window.
+func encodeLoginWindow(value func() (bool, string, error)) func() (bool, string, error) {
+	return func() (bool, string, error) {
+		isNull, encoded, err := value()
+		if err != nil || isNull {
+			return isNull, encoded, err
+		}
+		values, err := DecodeValueList(encoded)
+		if err != nil {
+			return false, "", err
+		}
+		w, err := MakeLoginWindow(values)
+		if err != nil {
+			return false, "", err
+		}
+		return false, w.Encode(), nil
+	}
+}
+
+// EncodeValueList encodes a list of values of a role option, such as
+// ALLOWED DATABASES, for storage in system.role_options.
+func EncodeValueList(values []string) string {
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		// Marshaling a list of strings cannot fail.
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "encoding role option values"))
+	}
+	return string(encoded)
+}
+
+// DecodeValueList decodes a list of values of a role option encoded with
+// EncodeValueList.
+func DecodeValueList(encoded string) ([]string, error) {
+	var values []string
+	if err := json.Unmarshal([]byte(encoded), &values); err != nil {
+		return nil, errors.Wrapf(err, "invalid role option values %q", encoded)
+	}
+	return values, nil
+}
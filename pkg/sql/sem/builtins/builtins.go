@@ -6422,7 +6422,7 @@ table's zone configuration this will return NULL.`,
 			Fn: func(evalCtx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
 				return evalCtx.Planner.SerializeSessionState()
 			},
-			Info:       `This function serializes the variables in the current session.`,
+			Info:       `This function serializes the variables, the prepared statements and the named portals of the current session.`,
 			Volatility: tree.VolatilityVolatile,
 		},
 	),
@@ -6438,7 +6438,7 @@ table's zone configuration this will return NULL.`,
 				state := tree.MustBeDBytes(args[0])
 				return evalCtx.Planner.DeserializeSessionState(tree.NewDBytes(state))
 			},
-			Info:       `This function deserializes the serialized variables into the current session.`,
+			Info:       `This function deserializes the serialized variables, prepared statements and portals into the current session.`,
 			Volatility: tree.VolatilityVolatile,
 		},
 	),
@@ -6589,6 +6589,158 @@ table. Returns an error if validation fails.`,
 			Volatility: tree.VolatilityVolatile,
 		},
 	),
+
+	"crdb_internal.enroll_totp": makeBuiltin(
+		tree.FunctionProperties{
+			Category: categorySystemInfo,
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"username", types.String}},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(evalCtx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				user, err := security.MakeSQLUsernameFromUserInput(
+					string(tree.MustBeDString(args[0])), security.UsernameValidation,
+				)
+				if err != nil {
+					return nil, err
+				}
+				uri, err := evalCtx.Planner.EnrollTOTP(evalCtx.Ctx(), user)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDString(uri), nil
+			},
+			Info: `This function enrolls the given user for TOTP authentication, which is
+required from the users with the MFA role option. It returns the otpauth URI to
+load into an authenticator application. A previous enrollment of the user
+is replaced.`,
+			Volatility: tree.VolatilityVolatile,
+		},
+	),
+
+	"crdb_internal.unenroll_totp": makeBuiltin(
+		tree.FunctionProperties{
+			Category: categorySystemInfo,
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"username", types.String}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(evalCtx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				user, err := security.MakeSQLUsernameFromUserInput(
+					string(tree.MustBeDString(args[0])), security.UsernameValidation,
+				)
+				if err != nil {
+					return nil, err
+				}
+				removed, err := evalCtx.Planner.UnenrollTOTP(evalCtx.Ctx(), user)
+				if err != nil {
+					return nil, err
+				}
+				return tree.MakeDBool(tree.DBool(removed)), nil
+			},
+			Info: `This function removes the TOTP enrollment of the given user. It returns
+true if the user was enrolled.`,
+			Volatility: tree.VolatilityVolatile,
+		},
+	),
+
+	"crdb_internal.security_keys": makeBuiltin(
+		tree.FunctionProperties{
+			Category: categorySystemInfo,
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"username", types.String}},
+			ReturnType: tree.FixedReturnType(types.StringArray),
+			Fn: func(evalCtx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				user, err := security.MakeSQLUsernameFromUserInput(
+					string(tree.MustBeDString(args[0])), security.UsernameValidation,
+				)
+				if err != nil {
+					return nil, err
+				}
+				names, err := evalCtx.Planner.ListSecurityKeys(evalCtx.Ctx(), user)
+				if err != nil {
+					return nil, err
+				}
+				arr := tree.NewDArray(types.String)
+				for _, name := range names {
+					if err := arr.Append(tree.NewDString(name)); err != nil {
+						return nil, err
+					}
+				}
+				return arr, nil
+			},
+			Info: `This function returns the names of the security keys registered by the
+given user to log in to the DB Console.`,
+			Volatility: tree.VolatilityVolatile,
+		},
+	),
+
+	"crdb_internal.unregister_security_key": makeBuiltin(
+		tree.FunctionProperties{
+			Category: categorySystemInfo,
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"username", types.String}, {"name", types.String}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(evalCtx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				user, err := security.MakeSQLUsernameFromUserInput(
+					string(tree.MustBeDString(args[0])), security.UsernameValidation,
+				)
+				if err != nil {
+					return nil, err
+				}
+				removed, err := evalCtx.Planner.UnregisterSecurityKey(
+					evalCtx.Ctx(), user, string(tree.MustBeDString(args[1])),
+				)
+				if err != nil {
+					return nil, err
+				}
+				return tree.MakeDBool(tree.DBool(removed)), nil
+			},
+			Info: `This function removes the security key with the given name registered by
+the given user. It returns true if the security key existed. The users who
+have no security keys left can log in to the DB Console with their password
+again.`,
+			Volatility: tree.VolatilityVolatile,
+		},
+	),
+
+	"crdb_internal.create_login_token": makeBuiltin(
+		tree.FunctionProperties{
+			Category: categorySystemInfo,
+		},
+		tree.Overload{
+			Types:      tree.ArgTypes{{"role", types.String}, {"ttl", types.Interval}},
+			ReturnType: tree.FixedReturnType(types.String),
+			Fn: func(evalCtx *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				user, err := security.MakeSQLUsernameFromUserInput(
+					string(tree.MustBeDString(args[0])), security.UsernameValidation,
+				)
+				if err != nil {
+					return nil, err
+				}
+				secs, ok := tree.MustBeDInterval(args[1]).Duration.AsInt64()
+				if !ok || secs > int64(security.MaxLoginTokenLifetime/time.Second) {
+					return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+						"the lifetime of a login token must be positive and at most %s",
+						security.MaxLoginTokenLifetime)
+				}
+				token, err := evalCtx.Planner.CreateLoginToken(
+					evalCtx.Ctx(), user, time.Duration(secs)*time.Second,
+				)
+				if err != nil {
+					return nil, err
+				}
+				return tree.NewDString(token), nil
+			},
+			Info: `This function creates a login token for the given role, valid for the
+given duration (at most 24 hours). The token is accepted in place of a password
+by the "token" authentication method of the HBA configuration. Creating login
+tokens requires the CREATELOGIN role option.`,
+			Volatility: tree.VolatilityVolatile,
+		},
+	),
 }
 
 var lengthImpls = func(incBitOverload bool) builtinDefinition {
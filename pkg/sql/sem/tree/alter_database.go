@@ -19,15 +19,17 @@ type AlterDatabaseOwner struct {
 // Format implements the NodeFormatter interface.
 func (node *AlterDatabaseOwner) Format(ctx *FmtCtx) {
 	ctx.WriteString("ALTER DATABASE ")
-	ctx.FormatNode(&node.Name)
+	ctx.formatDatabaseName(&node.Name)
 	ctx.WriteString(" OWNER TO ")
 	ctx.FormatNode(&node.Owner)
 }
 
-// AlterDatabaseAddRegion represents a ALTER DATABASE ADD REGION statement.
+// AlterDatabaseAddRegion represents a ALTER DATABASE ADD REGION(S) statement.
 type AlterDatabaseAddRegion struct {
-	Name        Name
-	Region      Name
+	Name Name
+	// Regions are the regions to add, which are added as a single schema
+	// change.
+	Regions     NameList
 	IfNotExists bool
 }
 
@@ -36,12 +38,16 @@ var _ Statement = &AlterDatabaseAddRegion{}
 // Format implements the NodeFormatter interface.
 func (node *AlterDatabaseAddRegion) Format(ctx *FmtCtx) {
 	ctx.WriteString("ALTER DATABASE ")
-	ctx.FormatNode(&node.Name)
-	ctx.WriteString(" ADD REGION ")
+	ctx.formatDatabaseName(&node.Name)
+	if len(node.Regions) == 1 {
+		ctx.WriteString(" ADD REGION ")
+	} else {
+		ctx.WriteString(" ADD REGIONS ")
+	}
 	if node.IfNotExists {
 		ctx.WriteString("IF NOT EXISTS ")
 	}
-	ctx.FormatNode(&node.Region)
+	ctx.formatRegionNames(node.Regions, ", ")
 }
 
 // AlterDatabaseDropRegion represents a ALTER DATABASE DROP REGION statement.
@@ -49,6 +55,14 @@ type AlterDatabaseDropRegion struct {
 	Name     Name
 	Region   Name
 	IfExists bool
+	// DropBehavior is DropCascade if the rows of the REGIONAL BY ROW tables
+	// which are homed in the region are rehomed before the region is dropped.
+	// DropRestrict, which is the same as DropDefault, fails if any object
+	// depends on the region.
+	DropBehavior DropBehavior
+	// RehomeTo is the region the rows are rehomed to with DropCascade. The
+	// primary region is used if it is empty.
+	RehomeTo Name
 }
 
 var _ Statement = &AlterDatabaseDropRegion{}
@@ -56,18 +70,29 @@ var _ Statement = &AlterDatabaseDropRegion{}
 // Format implements the NodeFormatter interface.
 func (node *AlterDatabaseDropRegion) Format(ctx *FmtCtx) {
 	ctx.WriteString("ALTER DATABASE ")
-	ctx.FormatNode(&node.Name)
+	ctx.formatDatabaseName(&node.Name)
 	ctx.WriteString(" DROP REGION ")
 	if node.IfExists {
 		ctx.WriteString("IF EXISTS ")
 	}
-	ctx.FormatNode(&node.Region)
+	ctx.formatRegionName(&node.Region)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteString(" ")
+		ctx.WriteString(node.DropBehavior.String())
+	}
+	if node.RehomeTo != "" {
+		ctx.WriteString(" TO ")
+		ctx.formatRegionName(&node.RehomeTo)
+	}
 }
 
 // AlterDatabasePrimaryRegion represents a ALTER DATABASE PRIMARY REGION ... statement.
 type AlterDatabasePrimaryRegion struct {
 	Name          Name
 	PrimaryRegion Name
+	// FallbackRegions are the regions to which the leaseholders of the data
+	// homed in the primary region fall back, in order of preference.
+	FallbackRegions NameList
 }
 
 var _ Statement = &AlterDatabasePrimaryRegion{}
@@ -75,15 +100,26 @@ var _ Statement = &AlterDatabasePrimaryRegion{}
 // Format implements the NodeFormatter interface.
 func (node *AlterDatabasePrimaryRegion) Format(ctx *FmtCtx) {
 	ctx.WriteString("ALTER DATABASE ")
-	ctx.FormatNode(&node.Name)
+	ctx.formatDatabaseName(&node.Name)
 	ctx.WriteString(" PRIMARY REGION ")
-	node.PrimaryRegion.Format(ctx)
+	ctx.formatRegionName(&node.PrimaryRegion)
+	if len(node.FallbackRegions) > 0 {
+		ctx.WriteString(" FALLBACK (")
+		ctx.formatRegionNames(node.FallbackRegions, ", ")
+		ctx.WriteString(")")
+	}
 }
 
 // AlterDatabaseSurvivalGoal represents a ALTER DATABASE SURVIVE ... statement.
 type AlterDatabaseSurvivalGoal struct {
 	Name         Name
 	SurvivalGoal SurvivalGoal
+	// NumVoters and NumReplicas are the numbers of voting replicas and of
+	// replicas used instead of the ones derived from the survival goal. Each of
+	// them is nil if it is not specified, or DefaultVal to use the number
+	// derived from the survival goal.
+	NumVoters   Expr
+	NumReplicas Expr
 }
 
 var _ Statement = &AlterDatabaseSurvivalGoal{}
@@ -91,15 +127,29 @@ var _ Statement = &AlterDatabaseSurvivalGoal{}
 // Format implements the NodeFormatter interface.
 func (node *AlterDatabaseSurvivalGoal) Format(ctx *FmtCtx) {
 	ctx.WriteString("ALTER DATABASE ")
-	ctx.FormatNode(&node.Name)
+	ctx.formatDatabaseName(&node.Name)
 	ctx.WriteString(" ")
 	node.SurvivalGoal.Format(ctx)
+	if node.NumVoters != nil || node.NumReplicas != nil {
+		ctx.WriteString(" WITH")
+	}
+	if node.NumVoters != nil {
+		ctx.WriteString(" NUM_VOTERS ")
+		ctx.FormatNode(node.NumVoters)
+	}
+	if node.NumReplicas != nil {
+		ctx.WriteString(" NUM_REPLICAS ")
+		ctx.FormatNode(node.NumReplicas)
+	}
 }
 
 // AlterDatabasePlacement represents a ALTER DATABASE PLACEMENT statement.
 type AlterDatabasePlacement struct {
 	Name      Name
 	Placement DataPlacement
+	// ExceptTables are the tables which keep non-voting replicas in all the
+	// regions of the database under the RESTRICTED placement policy.
+	ExceptTables TableNames
 }
 
 var _ Statement = &AlterDatabasePlacement{}
@@ -107,9 +157,14 @@ var _ Statement = &AlterDatabasePlacement{}
 // Format implements the NodeFormatter interface.
 func (node *AlterDatabasePlacement) Format(ctx *FmtCtx) {
 	ctx.WriteString("ALTER DATABASE ")
-	ctx.FormatNode(&node.Name)
+	ctx.formatDatabaseName(&node.Name)
 	ctx.WriteString(" ")
 	node.Placement.Format(ctx)
+	if len(node.ExceptTables) > 0 {
+		ctx.WriteString(" EXCEPT TABLES (")
+		ctx.FormatNode(&node.ExceptTables)
+		ctx.WriteString(")")
+	}
 }
 
 // AlterDatabaseAddSuperRegion represents a
@@ -125,16 +180,11 @@ var _ Statement = &AlterDatabaseAddSuperRegion{}
 // Format implements the NodeFormatter interface.
 func (node *AlterDatabaseAddSuperRegion) Format(ctx *FmtCtx) {
 	ctx.WriteString("ALTER DATABASE ")
-	ctx.FormatNode(&node.DatabaseName)
+	ctx.formatDatabaseName(&node.DatabaseName)
 	ctx.WriteString(" ADD SUPER REGION ")
-	ctx.FormatNode(&node.SuperRegionName)
+	ctx.formatSuperRegionName(&node.SuperRegionName)
 	ctx.WriteString(" VALUES ")
-	for i, region := range node.Regions {
-		if i != 0 {
-			ctx.WriteString(",")
-		}
-		ctx.FormatNode(&region)
-	}
+	ctx.formatRegionNames(node.Regions, ",")
 }
 
 // AlterDatabaseDropSuperRegion represents a
@@ -142,6 +192,9 @@ func (node *AlterDatabaseAddSuperRegion) Format(ctx *FmtCtx) {
 type AlterDatabaseDropSuperRegion struct {
 	DatabaseName    Name
 	SuperRegionName Name
+	// Override permits dropping the super region when tables still have data
+	// homed in its regions.
+	Override bool
 }
 
 var _ Statement = &AlterDatabaseDropSuperRegion{}
@@ -149,7 +202,197 @@ var _ Statement = &AlterDatabaseDropSuperRegion{}
 // Format implements the NodeFormatter interface.
 func (node *AlterDatabaseDropSuperRegion) Format(ctx *FmtCtx) {
 	ctx.WriteString("ALTER DATABASE ")
-	ctx.FormatNode(&node.DatabaseName)
+	ctx.formatDatabaseName(&node.DatabaseName)
 	ctx.WriteString(" DROP SUPER REGION ")
-	ctx.FormatNode(&node.SuperRegionName)
+	ctx.formatSuperRegionName(&node.SuperRegionName)
+	if node.Override {
+		ctx.WriteString(" WITH OVERRIDE")
+	}
+}
+
+// AlterDatabaseAlterSuperRegion represents a
+// ALTER DATABASE ALTER SUPER REGION ... statement.
+type AlterDatabaseAlterSuperRegion struct {
+	DatabaseName    Name
+	SuperRegionName Name
+	Regions         []Name
+	// Override permits removing regions from the super region when tables
+	// still have data homed in them.
+	Override bool
+}
+
+var _ Statement = &AlterDatabaseAlterSuperRegion{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDatabaseAlterSuperRegion) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER DATABASE ")
+	ctx.formatDatabaseName(&node.DatabaseName)
+	ctx.WriteString(" ALTER SUPER REGION ")
+	ctx.formatSuperRegionName(&node.SuperRegionName)
+	ctx.WriteString(" VALUES ")
+	ctx.formatRegionNames(node.Regions, ",")
+	if node.Override {
+		ctx.WriteString(" WITH OVERRIDE")
+	}
+}
+
+// AlterDatabaseSecondaryRegion represents a
+// ALTER DATABASE SET SECONDARY REGION ... statement.
+type AlterDatabaseSecondaryRegion struct {
+	DatabaseName    Name
+	SecondaryRegion Name
+}
+
+var _ Statement = &AlterDatabaseSecondaryRegion{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDatabaseSecondaryRegion) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER DATABASE ")
+	ctx.formatDatabaseName(&node.DatabaseName)
+	ctx.WriteString(" SET SECONDARY REGION ")
+	ctx.formatRegionName(&node.SecondaryRegion)
+}
+
+// AlterDatabaseDropSecondaryRegion represents a
+// ALTER DATABASE DROP SECONDARY REGION statement.
+type AlterDatabaseDropSecondaryRegion struct {
+	DatabaseName Name
+	IfExists     bool
+}
+
+var _ Statement = &AlterDatabaseDropSecondaryRegion{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDatabaseDropSecondaryRegion) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER DATABASE ")
+	ctx.formatDatabaseName(&node.DatabaseName)
+	ctx.WriteString(" DROP SECONDARY REGION")
+	if node.IfExists {
+		ctx.WriteString(" IF EXISTS")
+	}
+}
+
+// AlterDatabaseRenameRegion represents a
+// ALTER DATABASE RENAME REGION ... TO ... statement.
+type AlterDatabaseRenameRegion struct {
+	DatabaseName Name
+	OldRegion    Name
+	NewRegion    Name
+}
+
+var _ Statement = &AlterDatabaseRenameRegion{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDatabaseRenameRegion) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER DATABASE ")
+	ctx.formatDatabaseName(&node.DatabaseName)
+	ctx.WriteString(" RENAME REGION ")
+	ctx.formatRegionName(&node.OldRegion)
+	ctx.WriteString(" TO ")
+	ctx.formatRegionName(&node.NewRegion)
+}
+
+// AlterDatabaseToMultiRegion represents a
+// ALTER DATABASE ... CONVERT TO MULTIREGION statement.
+type AlterDatabaseToMultiRegion struct {
+	Name          Name
+	PrimaryRegion Name
+	Regions       NameList
+	SurvivalGoal  SurvivalGoal
+	// TableLocality is the locality the tables of the database are converted
+	// to. If it is nil, the tables become REGIONAL BY TABLE IN PRIMARY REGION
+	// tables.
+	TableLocality *Locality
+}
+
+var _ Statement = &AlterDatabaseToMultiRegion{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDatabaseToMultiRegion) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER DATABASE ")
+	ctx.formatDatabaseName(&node.Name)
+	ctx.WriteString(" CONVERT TO MULTIREGION PRIMARY REGION ")
+	ctx.formatRegionName(&node.PrimaryRegion)
+	if len(node.Regions) > 0 {
+		ctx.WriteString(" REGIONS ")
+		ctx.formatRegionNames(node.Regions, ", ")
+	}
+	if node.SurvivalGoal != SurvivalGoalDefault {
+		ctx.WriteString(" ")
+		ctx.FormatNode(&node.SurvivalGoal)
+	}
+	if node.TableLocality != nil {
+		ctx.WriteString(" TABLE ")
+		ctx.FormatNode(node.TableLocality)
+	}
+}
+
+// AlterDatabaseResetPrimaryRegion represents a
+// ALTER DATABASE ... RESET PRIMARY REGION statement.
+type AlterDatabaseResetPrimaryRegion struct {
+	Name Name
+}
+
+var _ Statement = &AlterDatabaseResetPrimaryRegion{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDatabaseResetPrimaryRegion) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER DATABASE ")
+	ctx.formatDatabaseName(&node.Name)
+	ctx.WriteString(" RESET PRIMARY REGION")
+}
+
+// AlterDatabaseRegionReplicas represents a
+// ALTER DATABASE ... SET REGION ... NUM_VOTERS ... NUM_REPLICAS ... statement.
+type AlterDatabaseRegionReplicas struct {
+	DatabaseName Name
+	Region       Name
+	// NumVoters and NumReplicas are the numbers of voting replicas and of
+	// replicas placed in the region. They are nil if they are left unchanged
+	// and DefaultVal if they are reset to the number generated for the
+	// database.
+	NumVoters   Expr
+	NumReplicas Expr
+}
+
+var _ Statement = &AlterDatabaseRegionReplicas{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDatabaseRegionReplicas) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER DATABASE ")
+	ctx.formatDatabaseName(&node.DatabaseName)
+	ctx.WriteString(" SET REGION ")
+	ctx.formatRegionName(&node.Region)
+	if node.NumVoters != nil {
+		ctx.WriteString(" NUM_VOTERS ")
+		ctx.FormatNode(node.NumVoters)
+	}
+	if node.NumReplicas != nil {
+		ctx.WriteString(" NUM_REPLICAS ")
+		ctx.FormatNode(node.NumReplicas)
+	}
+}
+
+// AlterDatabaseDefaultLocality represents a
+// ALTER DATABASE ... { SET | RESET } DEFAULT TABLE LOCALITY statement.
+type AlterDatabaseDefaultLocality struct {
+	Name Name
+	// Locality is the locality of the tables created in the database without
+	// a LOCALITY clause. It is nil if the default is reset, in which case such
+	// tables are REGIONAL BY TABLE IN PRIMARY REGION tables.
+	Locality *Locality
+}
+
+var _ Statement = &AlterDatabaseDefaultLocality{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterDatabaseDefaultLocality) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER DATABASE ")
+	ctx.formatDatabaseName(&node.Name)
+	if node.Locality == nil {
+		ctx.WriteString(" RESET DEFAULT TABLE LOCALITY")
+		return
+	}
+	ctx.WriteString(" SET DEFAULT TABLE ")
+	ctx.FormatNode(node.Locality)
 }
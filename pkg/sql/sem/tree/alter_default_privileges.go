@@ -24,8 +24,9 @@ type AlterDefaultPrivileges struct {
 	// run on the current database.
 	Schemas ObjectNamePrefixList
 
-	// Database is only used when converting a granting / revoking incompatible
-	// database privileges to an alter default privileges statement.
+	// Database is set by ALTER DEFAULT PRIVILEGES ... IN DATABASE, and when
+	// converting a granting / revoking incompatible database privileges to an
+	// alter default privileges statement.
 	// If it is not set, the current database is used.
 	Database *Name
 
@@ -39,7 +40,9 @@ type AlterDefaultPrivileges struct {
 // Format implements the NodeFormatter interface.
 func (n *AlterDefaultPrivileges) Format(ctx *FmtCtx) {
 	ctx.WriteString("ALTER DEFAULT PRIVILEGES ")
-	if len(n.Roles) > 0 {
+	if n.ForAllRoles {
+		ctx.WriteString("FOR ALL ROLES ")
+	} else if len(n.Roles) > 0 {
 		ctx.WriteString("FOR ROLE ")
 		for i, role := range n.Roles {
 			if i > 0 {
@@ -53,6 +56,10 @@ func (n *AlterDefaultPrivileges) Format(ctx *FmtCtx) {
 		ctx.WriteString("IN SCHEMA ")
 		ctx.FormatNode(n.Schemas)
 		ctx.WriteString(" ")
+	} else if n.Database != nil {
+		ctx.WriteString("IN DATABASE ")
+		ctx.FormatNode(n.Database)
+		ctx.WriteString(" ")
 	}
 	if n.IsGrant {
 		n.Grant.Format(ctx)
@@ -10,6 +10,8 @@
 
 package tree
 
+import "github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+
 // AlterRole represents an `ALTER ROLE ... WITH options` statement.
 type AlterRole struct {
 	Name      RoleSpec
@@ -37,6 +39,24 @@ func (node *AlterRole) Format(ctx *FmtCtx) {
 	}
 }
 
+// AlterProfile represents an `ALTER PROFILE ... [WITH] OPTION` statement.
+type AlterProfile struct {
+	Name      Name
+	IfExists  bool
+	KVOptions KVOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterProfile) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER PROFILE ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" WITH")
+	node.KVOptions.formatAsRoleOptions(ctx)
+}
+
 // AlterRoleSet represents an `ALTER ROLE ... SET` statement.
 type AlterRoleSet struct {
 	RoleName     RoleSpec
@@ -44,7 +64,18 @@ type AlterRoleSet struct {
 	IsRole       bool
 	AllRoles     bool
 	DatabaseName Name
-	SetOrReset   *SetVar
+	// ApplicationName is the LIKE pattern of the application names of the
+	// sessions which the settings apply to. It is empty if the settings
+	// apply to all the sessions.
+	ApplicationName string
+	// SourceNetwork is the network, in CIDR notation, of the client
+	// addresses of the sessions which the settings apply to. It is empty if
+	// the settings apply to all the sessions.
+	SourceNetwork string
+	SetOrReset    *SetVar
+	// Locked is set if the sessions cannot change the variable from its
+	// default value.
+	Locked bool
 }
 
 // Format implements the NodeFormatter interface.
@@ -69,5 +100,46 @@ func (node *AlterRoleSet) Format(ctx *FmtCtx) {
 		ctx.FormatNode(&node.DatabaseName)
 		ctx.WriteString(" ")
 	}
+	if node.ApplicationName != "" {
+		ctx.WriteString("FOR APPLICATION ")
+		if ctx.flags.HasFlags(FmtHideConstants) {
+			ctx.WriteString("'_'")
+		} else {
+			lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, node.ApplicationName, ctx.flags.EncodeFlags())
+		}
+		ctx.WriteString(" ")
+	}
+	if node.SourceNetwork != "" {
+		ctx.WriteString("FROM NETWORK ")
+		if ctx.flags.HasFlags(FmtHideConstants) {
+			ctx.WriteString("'_'")
+		} else {
+			lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, node.SourceNetwork, ctx.flags.EncodeFlags())
+		}
+		ctx.WriteString(" ")
+	}
 	ctx.FormatNode(node.SetOrReset)
+	if node.Locked {
+		ctx.WriteString(" LOCKED")
+	}
+}
+
+// RenameRole represents an `ALTER ROLE ... RENAME TO` statement.
+type RenameRole struct {
+	Name    RoleSpec
+	NewName RoleSpec
+	IsRole  bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *RenameRole) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER")
+	if node.IsRole {
+		ctx.WriteString(" ROLE ")
+	} else {
+		ctx.WriteString(" USER ")
+	}
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" RENAME TO ")
+	ctx.FormatNode(&node.NewName)
 }
@@ -50,6 +50,7 @@ type CreateDatabase struct {
 	SurvivalGoal    SurvivalGoal
 	Placement       DataPlacement
 	Owner           RoleSpec
+	SecondaryRegion Name
 }
 
 // Format implements the NodeFormatter interface.
@@ -58,7 +59,7 @@ func (node *CreateDatabase) Format(ctx *FmtCtx) {
 	if node.IfNotExists {
 		ctx.WriteString("IF NOT EXISTS ")
 	}
-	ctx.FormatNode(&node.Name)
+	ctx.formatDatabaseName(&node.Name)
 	if node.Template != "" {
 		// NB: the template is not currently edited out under FmtAnonymize,
 		// because we don't support custom templates. If/when custom
@@ -102,11 +103,11 @@ func (node *CreateDatabase) Format(ctx *FmtCtx) {
 	}
 	if node.PrimaryRegion != "" {
 		ctx.WriteString(" PRIMARY REGION ")
-		ctx.FormatNode(&node.PrimaryRegion)
+		ctx.formatRegionName(&node.PrimaryRegion)
 	}
 	if node.Regions != nil {
 		ctx.WriteString(" REGIONS = ")
-		ctx.FormatNode(&node.Regions)
+		ctx.formatRegionNames(node.Regions, ", ")
 	}
 	if node.SurvivalGoal != SurvivalGoalDefault {
 		ctx.WriteString(" ")
@@ -121,6 +122,10 @@ func (node *CreateDatabase) Format(ctx *FmtCtx) {
 		ctx.WriteString(" OWNER = ")
 		ctx.FormatNode(&node.Owner)
 	}
+	if node.SecondaryRegion != "" {
+		ctx.WriteString(" SECONDARY REGION ")
+		ctx.formatRegionName(&node.SecondaryRegion)
+	}
 }
 
 // IndexElem represents a column with a direction in a CREATE INDEX statement.
@@ -1860,6 +1865,43 @@ func (o KVOptions) ToRoleOptions(
 						return true, "", nil
 					},
 				}
+			} else if n, ok := ro.Value.(*NumVal); ok {
+				// Integer values, such as the connection limit, are stored
+				// in their canonical string form.
+				v, err := n.AsInt32()
+				if err != nil {
+					return nil, err
+				}
+				roleOptions[i] = roleoption.RoleOption{
+					Option: option, HasValue: true, Value: func() (bool, string, error) {
+						return false, strconv.Itoa(int(v)), nil
+					},
+				}
+			} else if t, ok := ro.Value.(*Tuple); ok {
+				// Lists of values, such as the allowed databases, are stored
+				// in their encoded form. NULL values, e.g. the unspecified time
+				// zone of a login window, are encoded as empty strings.
+				strFns := make([]func() (bool, string, error), len(t.Exprs))
+				for j, e := range t.Exprs {
+					if strFns[j], err = typeAsStringOrNull(e, op); err != nil {
+						return nil, err
+					}
+				}
+				roleOptions[i] = roleoption.RoleOption{
+					Option: option, HasValue: true, Value: func() (bool, string, error) {
+						values := make([]string, len(strFns))
+						for j, strFn := range strFns {
+							isNull, v, err := strFn()
+							if err != nil {
+								return false, "", err
+							}
+							if !isNull {
+								values[j] = v
+							}
+						}
+						return false, roleoption.EncodeValueList(values), nil
+					},
+				}
 			} else {
 				strFn, err := typeAsStringOrNull(ro.Value, op)
 				if err != nil {
@@ -1882,10 +1924,22 @@ func (o KVOptions) ToRoleOptions(
 func (o *KVOptions) formatAsRoleOptions(ctx *FmtCtx) {
 	for _, option := range *o {
 		ctx.WriteByte(' ')
+		if t, ok := option.Value.(*Tuple); ok && option.Key == "login window" {
+			formatLoginWindow(ctx, t.Exprs)
+			continue
+		}
 		// Role option keys are always sequences of keywords separated
 		// by spaces.
 		ctx.WriteString(strings.ToUpper(string(option.Key)))
 
+		// The profile of a role is a name.
+		if s, ok := option.Value.(*StrVal); ok && option.Key == "profile" {
+			ctx.WriteByte(' ')
+			name := Name(s.RawString())
+			ctx.FormatNode(&name)
+			continue
+		}
+
 		// Password is a special case.
 		if strings.HasSuffix(string(option.Key), "password") {
 			ctx.WriteByte(' ')
@@ -1894,10 +1948,20 @@ func (o *KVOptions) formatAsRoleOptions(ctx *FmtCtx) {
 			} else {
 				ctx.WriteString(PasswordSubstitution)
 			}
+		} else if t, ok := option.Value.(*Tuple); ok {
+			// Lists of values are formatted without the trailing comma
+			// of single-element tuples.
+			ctx.WriteString(" (")
+			ctx.FormatNode(&t.Exprs)
+			ctx.WriteByte(')')
 		} else if option.Value != nil {
 			ctx.WriteByte(' ')
 			if ctx.HasFlags(FmtHideConstants) {
-				ctx.WriteString("'_'")
+				if _, ok := option.Value.(*NumVal); ok {
+					ctx.WriteByte('0')
+				} else {
+					ctx.WriteString("'_'")
+				}
 			} else {
 				ctx.FormatNode(option.Value)
 			}
@@ -1905,6 +1969,25 @@ func (o *KVOptions) formatAsRoleOptions(ctx *FmtCtx) {
 	}
 }
 
+// formatLoginWindow formats the value of a LOGIN WINDOW role option, that
+// is its start and end times, its time zone or NULL, followed by its days
+// of the week if any.
+func formatLoginWindow(ctx *FmtCtx, exprs Exprs) {
+	ctx.WriteString("LOGIN BETWEEN ")
+	ctx.FormatNode(exprs[0])
+	ctx.WriteString(" AND ")
+	ctx.FormatNode(exprs[1])
+	if exprs[2] != DNull {
+		ctx.WriteString(" TIMEZONE ")
+		ctx.FormatNode(exprs[2])
+	}
+	if days := exprs[3:]; len(days) > 0 {
+		ctx.WriteString(" DAYS (")
+		ctx.FormatNode(&days)
+		ctx.WriteByte(')')
+	}
+}
+
 // CreateRole represents a CREATE ROLE statement.
 type CreateRole struct {
 	Name        RoleSpec
@@ -1932,6 +2015,27 @@ func (node *CreateRole) Format(ctx *FmtCtx) {
 	}
 }
 
+// CreateProfile represents a CREATE PROFILE statement.
+type CreateProfile struct {
+	Name        Name
+	IfNotExists bool
+	KVOptions   KVOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateProfile) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE PROFILE ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+
+	if len(node.KVOptions) > 0 {
+		ctx.WriteString(" WITH")
+		node.KVOptions.formatAsRoleOptions(ctx)
+	}
+}
+
 // CreateView represents a CREATE VIEW statement.
 type CreateView struct {
 	Name         TableName
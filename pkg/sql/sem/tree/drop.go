@@ -167,6 +167,9 @@ type DropRole struct {
 	Names    RoleSpecList
 	IsRole   bool
 	IfExists bool
+	// TerminateSessions is set to terminate the open sessions of the
+	// dropped roles.
+	TerminateSessions bool
 }
 
 // Format implements the NodeFormatter interface.
@@ -181,6 +184,24 @@ func (node *DropRole) Format(ctx *FmtCtx) {
 		ctx.WriteString("IF EXISTS ")
 	}
 	ctx.FormatNode(&node.Names)
+	if node.TerminateSessions {
+		ctx.WriteString(" WITH TERMINATE SESSIONS")
+	}
+}
+
+// DropProfile represents a DROP PROFILE command.
+type DropProfile struct {
+	Names    NameList
+	IfExists bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DropProfile) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP PROFILE ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Names)
 }
 
 // DropType represents a DROP TYPE command.
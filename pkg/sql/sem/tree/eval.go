@@ -3304,6 +3304,28 @@ type EvalPlanner interface {
 	// constraint on the table.
 	RevalidateUniqueConstraint(ctx context.Context, tableID int, constraintName string) error
 
+	// EnrollTOTP enrolls the given user for TOTP authentication, replacing
+	// its previous enrollment if any, and returns the otpauth:// URI of the
+	// new TOTP secret.
+	EnrollTOTP(ctx context.Context, user security.SQLUsername) (string, error)
+
+	// UnenrollTOTP removes the TOTP enrollment of the given user, and
+	// returns whether the user was enrolled.
+	UnenrollTOTP(ctx context.Context, user security.SQLUsername) (bool, error)
+
+	// ListSecurityKeys returns the names of the security keys registered
+	// by the given user to log in to the DB Console.
+	ListSecurityKeys(ctx context.Context, user security.SQLUsername) ([]string, error)
+
+	// UnregisterSecurityKey removes the security key with the given name
+	// registered by the given user, and returns whether it existed.
+	UnregisterSecurityKey(ctx context.Context, user security.SQLUsername, name string) (bool, error)
+
+	// CreateLoginToken creates a login token for the given user, valid for
+	// the given lifetime, which is accepted in place of a password by the
+	// "token" HBA method.
+	CreateLoginToken(ctx context.Context, user security.SQLUsername, lifetime time.Duration) (string, error)
+
 	// QueryRowEx executes the supplied SQL statement and returns a single row, or
 	// nil if no row is found, or an error if more that one row is returned.
 	//
@@ -3392,10 +3414,11 @@ type EvalSessionAccessor interface {
 // PreparedStatementState is a limited interface that exposes metadata about
 // prepared statements.
 type PreparedStatementState interface {
-	// HasActivePortals returns true if there are portals in the session.
-	HasActivePortals() bool
 	// MigratablePreparedStatements returns a mapping of all prepared statements.
 	MigratablePreparedStatements() []sessiondatapb.MigratableSession_PreparedStatement
+	// MigratablePortals returns the portals of the session which can be
+	// migrated.
+	MigratablePortals() []sessiondatapb.MigratableSession_Portal
 	// HasPortal returns true if there exists a given named portal in the session.
 	HasPortal(s string) bool
 }
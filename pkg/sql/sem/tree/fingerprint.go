@@ -0,0 +1,60 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import "hash/fnv"
+
+// FingerprintFlags control how the names of a statement appear in its
+// fingerprint.
+type FingerprintFlags int
+
+const (
+	// FingerprintHashIdentifiers replaces the names of the statement, except
+	// function names, with a short hash of the name. See FmtHashIdentifiers.
+	FingerprintHashIdentifiers FingerprintFlags = 1 << iota
+
+	// FingerprintAnonymizeIdentifiers replaces the names of the statement,
+	// except function names, with an underscore. It takes precedence over
+	// FingerprintHashIdentifiers.
+	FingerprintAnonymizeIdentifiers
+)
+
+// FormatStatementFingerprint returns the fingerprint of the statement: its
+// normalized text with the constants and placeholders replaced by
+// underscores, and the long lists of constants shortened, so that the
+// executions of the same statement with different values share the same
+// fingerprint. The names are preserved unless the flags specify
+// otherwise.
+//
+// With no flags, this is the fingerprint used for the statement
+// statistics. An empty string is returned for a nil statement.
+func FormatStatementFingerprint(stmt Statement, flags FingerprintFlags) string {
+	if stmt == nil {
+		return ""
+	}
+	fmtFlags := FmtHideConstants
+	if flags&FingerprintAnonymizeIdentifiers != 0 {
+		fmtFlags |= FmtAnonymize
+	} else if flags&FingerprintHashIdentifiers != 0 {
+		fmtFlags |= FmtHashIdentifiers
+	}
+	return AsStringWithFlags(stmt, fmtFlags)
+}
+
+// StatementFingerprintHash returns the 64-bit FNV-1a hash of the
+// fingerprint of the statement, as computed by FormatStatementFingerprint.
+// It is suitable as a compact key for the statements which share a
+// fingerprint.
+func StatementFingerprintHash(stmt Statement, flags FingerprintFlags) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(FormatStatementFingerprint(stmt, flags)))
+	return h.Sum64()
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatStatementFingerprint(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testData := []struct {
+		stmt     string
+		flags    tree.FingerprintFlags
+		expected string
+	}{
+		{`SELECT x FROM test WHERE y IN (4, 5, 6, 7, 8)`, 0,
+			`SELECT x FROM test WHERE y IN (_, _, __more3__)`},
+		{`SELECT x FROM test WHERE y IN (4, 5, 6, 7, 8)`, tree.FingerprintAnonymizeIdentifiers,
+			`SELECT _ FROM _ WHERE _ IN (_, _, __more3__)`},
+		{`SELECT x FROM test WHERE y IN (4, 5, 6, 7, 8)`, tree.FingerprintHashIdentifiers,
+			`SELECT _fd0c5087 FROM _afd071e5 WHERE _fc0c4ef4 IN (_, _, __more3__)`},
+		// Function names are preserved.
+		{`SELECT lower(x) FROM test`, tree.FingerprintHashIdentifiers,
+			`SELECT lower(_fd0c5087) FROM _afd071e5`},
+		// Anonymization takes precedence.
+		{`SELECT x FROM test`, tree.FingerprintAnonymizeIdentifiers | tree.FingerprintHashIdentifiers,
+			`SELECT _ FROM _`},
+	}
+
+	for _, test := range testData {
+		t.Run(test.stmt, func(t *testing.T) {
+			stmt, err := parser.ParseOne(test.stmt)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, tree.FormatStatementFingerprint(stmt.AST, test.flags))
+		})
+	}
+
+	// Statements which only differ by their constants share their
+	// fingerprint.
+	hash := func(sql string, flags tree.FingerprintFlags) uint64 {
+		stmt, err := parser.ParseOne(sql)
+		require.NoError(t, err)
+		return tree.StatementFingerprintHash(stmt.AST, flags)
+	}
+	require.Equal(t,
+		hash(`SELECT x FROM test WHERE y = 1`, 0),
+		hash(`SELECT x FROM test WHERE y = 2`, 0))
+	require.NotEqual(t,
+		hash(`SELECT x FROM test WHERE y = 1`, tree.FingerprintHashIdentifiers),
+		hash(`SELECT x FROM test WHERE z = 1`, tree.FingerprintHashIdentifiers))
+	require.Equal(t,
+		hash(`SELECT x FROM test WHERE y = 1`, tree.FingerprintAnonymizeIdentifiers),
+		hash(`SELECT x FROM test WHERE z = 1`, tree.FingerprintAnonymizeIdentifiers))
+
+	require.Equal(t, "", tree.FormatStatementFingerprint(nil, 0))
+}
@@ -13,6 +13,7 @@ package tree
 import (
 	"bytes"
 	"fmt"
+	"hash/fnv"
 	"strings"
 	"sync"
 
@@ -168,6 +169,20 @@ const (
 	// for simple names (i.e. Name, UnrestrictedName) from statements.
 	// This flag *overrides* `FmtMarkRedactionNode` above.
 	FmtOmitNameRedaction
+
+	// FmtAnonymizeTopology instructs the pretty-printer to replace the
+	// database, region and super region names of multi-region statements
+	// with placeholders such as db1 or region2. Unlike FmtAnonymize, the
+	// same name is always replaced by the same placeholder, so the structure
+	// of the statement remains intact. FmtAnonymize takes precedence.
+	FmtAnonymizeTopology
+
+	// FmtHashIdentifiers instructs the pretty-printer to replace the names
+	// in a statement, except function names, with a short hash of the
+	// name. Unlike FmtAnonymize, statements which use different names
+	// remain distinct, without disclosing the names. FmtAnonymize takes
+	// precedence.
+	FmtHashIdentifiers
 )
 
 // PasswordSubstitution is the string that replaces
@@ -273,6 +288,12 @@ type FmtCtx struct {
 	// indexedTypeFormatter is an optional interceptor for formatting
 	// IDTypeReferences differently than normal.
 	indexedTypeFormatter func(*FmtCtx, *OIDTypeReference)
+	// topologyNames maps the names replaced under FmtAnonymizeTopology to
+	// their placeholders, for each kind of name.
+	topologyNames [numTopologyNameKinds]map[Name]string
+	// nameResolver is an optional resolver used to fully qualify the names
+	// of the databases and tables referenced by the formatted statement.
+	nameResolver FmtNameResolver
 }
 
 // FmtCtxOption is an option to pass into NewFmtCtx.
@@ -309,6 +330,32 @@ func FmtReformatTableNames(tableNameFmt func(*FmtCtx, *TableName)) FmtCtxOption
 	}
 }
 
+// FmtNameResolver resolves the names of the databases and tables
+// referenced by a statement against the catalog. See FmtResolveNames.
+type FmtNameResolver interface {
+	// ResolveDatabaseName returns the name of the database as stored in the
+	// catalog. An empty name refers to the current database. The boolean is
+	// false if the database cannot be resolved.
+	ResolveDatabaseName(name Name) (Name, bool)
+	// ResolveTableName returns the fully qualified name of the table, with
+	// an explicit catalog and schema. The boolean is false if the table
+	// cannot be resolved.
+	ResolveTableName(name *TableName) (TableName, bool)
+}
+
+// FmtResolveNames modifies FmtCtx to format the names of the databases and
+// tables referenced by the statement in their resolved, fully qualified
+// form, using the provided resolver. The names which cannot be resolved,
+// or which are already fully qualified, are formatted as usual.
+//
+// This is used by the event log, so that the recorded statements remain
+// unambiguous after the objects they reference are renamed.
+func FmtResolveNames(r FmtNameResolver) FmtCtxOption {
+	return func(ctx *FmtCtx) {
+		ctx.nameResolver = r
+	}
+}
+
 // FmtIndexedTypeFormat modifies FmtCtx to customize the printing of
 // IDTypeReferences using the provided function.
 func FmtIndexedTypeFormat(fn func(*FmtCtx, *OIDTypeReference)) FmtCtxOption {
@@ -409,6 +456,86 @@ func (ctx *FmtCtx) FormatNameP(s *string) {
 	ctx.FormatNode((*Name)(s))
 }
 
+// topologyNameKind is the kind of a name which is replaced by a placeholder
+// under FmtAnonymizeTopology.
+type topologyNameKind int
+
+const (
+	databaseTopologyName topologyNameKind = iota
+	regionTopologyName
+	superRegionTopologyName
+	numTopologyNameKinds
+)
+
+// topologyNamePrefixes are the prefixes of the placeholders for each kind of
+// name.
+var topologyNamePrefixes = [numTopologyNameKinds]string{
+	databaseTopologyName:    "db",
+	regionTopologyName:      "region",
+	superRegionTopologyName: "super_region",
+}
+
+// formatTopologyName formats a database, region or super region name. Under
+// FmtAnonymizeTopology, the name is replaced by a placeholder numbered after
+// the order in which the names of its kind are first encountered.
+func (ctx *FmtCtx) formatTopologyName(kind topologyNameKind, n *Name) {
+	if !ctx.flags.HasFlags(FmtAnonymizeTopology) || ctx.flags.HasFlags(FmtAnonymize) {
+		ctx.FormatNode(n)
+		return
+	}
+	names := ctx.topologyNames[kind]
+	if names == nil {
+		names = make(map[Name]string)
+		ctx.topologyNames[kind] = names
+	}
+	placeholder, ok := names[*n]
+	if !ok {
+		placeholder = fmt.Sprintf("%s%d", topologyNamePrefixes[kind], len(names)+1)
+		names[*n] = placeholder
+	}
+	ctx.WriteString(placeholder)
+}
+
+// formatHashedName writes the replacement of a name under
+// FmtHashIdentifiers: an underscore followed by the FNV-1a hash of the
+// name, in hexadecimal. The result is a valid identifier.
+func (ctx *FmtCtx) formatHashedName(s string) {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(s))
+	ctx.Printf("_%08x", h.Sum32())
+}
+
+// formatDatabaseName formats the name of a database referenced by a
+// multi-region statement.
+func (ctx *FmtCtx) formatDatabaseName(n *Name) {
+	if ctx.nameResolver != nil {
+		if resolved, ok := ctx.nameResolver.ResolveDatabaseName(*n); ok {
+			n = &resolved
+		}
+	}
+	ctx.formatTopologyName(databaseTopologyName, n)
+}
+
+// formatRegionName formats the name of a region.
+func (ctx *FmtCtx) formatRegionName(n *Name) {
+	ctx.formatTopologyName(regionTopologyName, n)
+}
+
+// formatRegionNames formats a list of region names, separated by sep.
+func (ctx *FmtCtx) formatRegionNames(l []Name, sep string) {
+	for i := range l {
+		if i > 0 {
+			ctx.WriteString(sep)
+		}
+		ctx.formatRegionName(&l[i])
+	}
+}
+
+// formatSuperRegionName formats the name of a super region.
+func (ctx *FmtCtx) formatSuperRegionName(n *Name) {
+	ctx.formatTopologyName(superRegionTopologyName, n)
+}
+
 // FormatNode recurses into a node for pretty-printing.
 // Flag-driven special cases can hook into this.
 func (ctx *FmtCtx) FormatNode(n NodeFormatter) {
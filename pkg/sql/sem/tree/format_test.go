@@ -55,6 +55,25 @@ func TestFormatStatement(t *testing.T) {
 			`SET time zone = utc`},
 		{`SET "time zone" = UTC`, tree.FmtBareStrings,
 			`SET "time zone" = utc`},
+
+		{`CREATE DATABASE foo PRIMARY REGION "us-east1" REGIONS "us-east1", "us-west1" SURVIVE REGION FAILURE`,
+			tree.FmtAnonymizeTopology,
+			`CREATE DATABASE db1 PRIMARY REGION region1 REGIONS = region1, region2 SURVIVE REGION FAILURE`},
+		{`ALTER DATABASE foo ADD SUPER REGION "us" VALUES "us-east1", "us-west1"`,
+			tree.FmtAnonymizeTopology,
+			`ALTER DATABASE db1 ADD SUPER REGION super_region1 VALUES region1,region2`},
+		{`ALTER DATABASE foo RENAME REGION "us-east1" TO "us-east2"`,
+			tree.FmtAnonymizeTopology,
+			`ALTER DATABASE db1 RENAME REGION region1 TO region2`},
+		{`ALTER TABLE foo SET LOCALITY REGIONAL BY TABLE IN "us-east1"`,
+			tree.FmtAnonymizeTopology,
+			`ALTER TABLE foo SET LOCALITY REGIONAL BY TABLE IN region1`},
+		{`SHOW REGIONS FROM DATABASE foo`,
+			tree.FmtAnonymizeTopology,
+			`SHOW REGIONS FROM DATABASE db1`},
+		{`CREATE DATABASE foo PRIMARY REGION "us-east1" REGIONS "us-east1", "us-west1"`,
+			tree.FmtAnonymizeTopology | tree.FmtAnonymize,
+			`CREATE DATABASE _ PRIMARY REGION _ REGIONS = _, _`},
 	}
 
 	for i, test := range testData {
@@ -124,6 +143,64 @@ func TestFormatTableName(t *testing.T) {
 	}
 }
 
+// testNameResolver resolves the database foo, which is the current
+// database, and the table foo.public.t.
+type testNameResolver struct{}
+
+func (testNameResolver) ResolveDatabaseName(name tree.Name) (tree.Name, bool) {
+	if name == "" || name == "foo" {
+		return "foo", true
+	}
+	return "", false
+}
+
+func (testNameResolver) ResolveTableName(name *tree.TableName) (tree.TableName, bool) {
+	if name.ObjectName != "t" || (name.ExplicitSchema && name.SchemaName != "public") {
+		return tree.TableName{}, false
+	}
+	return tree.MakeTableNameWithSchema("foo", "public", "t"), true
+}
+
+func TestFormatResolvedNames(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	testData := []struct {
+		stmt     string
+		expected string
+	}{
+		{`ALTER TABLE t ADD COLUMN y INT8`,
+			`ALTER TABLE foo.public.t ADD COLUMN y INT8`},
+		{`ALTER TABLE public.t DROP COLUMN x`,
+			`ALTER TABLE foo.public.t DROP COLUMN x`},
+		// Fully qualified and unknown names are left as is.
+		{`ALTER TABLE bar.public.t ADD COLUMN y INT8`,
+			`ALTER TABLE bar.public.t ADD COLUMN y INT8`},
+		{`ALTER TABLE u ADD COLUMN y INT8`,
+			`ALTER TABLE u ADD COLUMN y INT8`},
+		{`ALTER TABLE t SET LOCALITY REGIONAL BY TABLE IN "us-east1"`,
+			`ALTER TABLE foo.public.t SET LOCALITY REGIONAL BY TABLE IN "us-east1"`},
+		{`ALTER DATABASE foo OWNER TO bob`,
+			`ALTER DATABASE foo OWNER TO bob`},
+		{`ALTER DATABASE bar ADD REGION "us-east1"`,
+			`ALTER DATABASE bar ADD REGION "us-east1"`},
+	}
+
+	for i, test := range testData {
+		t.Run(fmt.Sprintf("%d %s", i, test.stmt), func(t *testing.T) {
+			stmt, err := parser.ParseOne(test.stmt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f := tree.NewFmtCtx(tree.FmtSimple, tree.FmtResolveNames(testNameResolver{}))
+			f.FormatNode(stmt.AST)
+			stmtStr := f.CloseAndGetString()
+			if stmtStr != test.expected {
+				t.Fatalf("expected %q, got %q", test.expected, stmtStr)
+			}
+		})
+	}
+}
+
 func TestFormatExpr(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
@@ -0,0 +1,167 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+//go:build generator
+// +build generator
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/cockroachdb/cockroach/pkg/cli/exit"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/gostdlib/go/format"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "ERROR:", err)
+		exit.WithCode(exit.UnspecifiedError())
+	}
+}
+
+func run() error {
+	// The arguments may be preceded by "--", which go run requires in order
+	// to not treat the output and input files as part of the program.
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] == "--" {
+		args = args[1:]
+	}
+	if len(args) < 2 {
+		return errors.Newf("usage: %s <output> <input file or directory>...\n", os.Args[0])
+	}
+	out, inputs := args[0], args[1:]
+
+	var files []string
+	for _, in := range inputs {
+		info, err := os.Stat(in)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			files = append(files, in)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(in, "*.go"))
+		if err != nil {
+			return err
+		}
+		files = append(files, matches...)
+	}
+
+	// Every named type of the package which isn't an interface can be held
+	// by a node field of interface type, so all of them are registered.
+	fset := token.NewFileSet()
+	var types []string
+	seen := make(map[string]struct{})
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") || filepath.Base(file) == filepath.Base(out) {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		if f.Name.Name != "tree" {
+			// Skip the generators.
+			continue
+		}
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if typeSpec.Assign.IsValid() || typeSpec.TypeParams != nil {
+					continue
+				}
+				switch typeSpec.Type.(type) {
+				case *ast.InterfaceType, *ast.FuncType, *ast.ChanType:
+					continue
+				}
+				name := typeSpec.Name.Name
+				if name == "_" {
+					continue
+				}
+				if _, ok := seen[name]; ok {
+					return errors.Newf("duplicate type %s", name)
+				}
+				seen[name] = struct{}{}
+				types = append(types, name)
+			}
+		}
+	}
+	if len(types) == 0 {
+		return errors.Newf("no types found in %s", strings.Join(inputs, ", "))
+	}
+	sort.Strings(types)
+
+	tmpl, err := template.New("types").Parse(typesTemplate)
+	if err != nil {
+		return err
+	}
+
+	// Render the template.
+	var gen bytes.Buffer
+	if err := tmpl.Execute(&gen, info{Types: types}); err != nil {
+		return err
+	}
+
+	// Run gofmt on the generated source.
+	formatted, err := format.Source(gen.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "gofmt")
+	}
+
+	// Write the output file.
+	return ioutil.WriteFile(out, formatted, 0666)
+}
+
+type info struct {
+	Types []string
+}
+
+const typesTemplate = `// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Code generated by generate_json_ast_types.go. DO NOT EDIT.
+
+package tree
+
+import "reflect"
+
+// jsonASTTypes maps the names under which the types of this package are
+// serialized by MarshalStatementJSON to the types themselves. It is used to
+// import the nodes held by fields of interface type.
+var jsonASTTypes = map[string]reflect.Type{
+{{- range .Types}}
+	"{{.}}": reflect.TypeOf((*{{.}})(nil)).Elem(),
+{{- end}}
+}
+`
@@ -0,0 +1,273 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+//go:build generator
+// +build generator
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/cockroachdb/cockroach/pkg/cli/exit"
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/gostdlib/go/format"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "ERROR:", err)
+		exit.WithCode(exit.UnspecifiedError())
+	}
+}
+
+func run() error {
+	// The arguments may be preceded by "--", which go run requires in order
+	// to not treat the input and output files as part of the program.
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] == "--" {
+		args = args[1:]
+	}
+	if len(args) < 3 {
+		return errors.Newf("usage: %s <input> <output> <source file or directory>...\n", os.Args[0])
+	}
+	in, out, sources := args[0], args[1], args[2:]
+
+	source, err := ioutil.ReadFile(in)
+	if err != nil {
+		return err
+	}
+	// Every statement node implements StatementTag on a pointer receiver, and
+	// all of those implementations live in the input file.
+	stmtPattern := regexp.MustCompile(`^func \((?:\w+ )?\*(\w+)\) StatementTag\(\) string`)
+	var stmts []string
+	seen := make(map[string]struct{})
+	for _, line := range strings.Split(string(source), "\n") {
+		line = strings.TrimSpace(line)
+		if matches := stmtPattern.FindStringSubmatch(line); len(matches) > 0 {
+			if _, ok := seen[matches[1]]; ok {
+				return errors.Newf("duplicate statement type %s", matches[1])
+			}
+			seen[matches[1]] = struct{}{}
+			stmts = append(stmts, matches[1])
+		}
+	}
+	if len(stmts) == 0 {
+		return errors.Newf("no statement types found in %s", in)
+	}
+
+	// The statements nested inside of a statement are the fields of the
+	// statement which hold a Statement or a pointer to a statement type.
+	fields, err := structFields(sources)
+	if err != nil {
+		return err
+	}
+	data := info{Stmts: make([]stmt, len(stmts))}
+	for i, name := range stmts {
+		data.Stmts[i].Name = name
+		for _, field := range fields[name] {
+			for _, fieldName := range field.Names {
+				switch t := field.Type.(type) {
+				case *ast.Ident:
+					if t.Name == "Statement" {
+						data.Stmts[i].Children = append(data.Stmts[i].Children, child{Field: fieldName.Name})
+					}
+				case *ast.StarExpr:
+					if elem, ok := t.X.(*ast.Ident); ok {
+						if _, ok := seen[elem.Name]; ok {
+							data.Stmts[i].Children = append(data.Stmts[i].Children, child{Field: fieldName.Name, Type: elem.Name})
+						}
+					}
+				}
+			}
+		}
+	}
+
+	tmpl, err := template.New("visitor").Parse(visitorTemplate)
+	if err != nil {
+		return err
+	}
+
+	// Render the template.
+	var gen bytes.Buffer
+	if err := tmpl.Execute(&gen, data); err != nil {
+		return err
+	}
+
+	// Run gofmt on the generated source.
+	formatted, err := format.Source(gen.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "gofmt")
+	}
+
+	// Write the output file.
+	f, err := os.OpenFile(out, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	if _, err := f.Write(formatted); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// structFields returns the fields of the struct types declared in the
+// package files among sources, which are files or directories.
+func structFields(sources []string) (map[string][]*ast.Field, error) {
+	var files []string
+	for _, source := range sources {
+		fi, err := os.Stat(source)
+		if err != nil {
+			return nil, err
+		}
+		if !fi.IsDir() {
+			files = append(files, source)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(source, "*.go"))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+
+	fset := token.NewFileSet()
+	fields := make(map[string][]*ast.Field)
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		if f.Name.Name != "tree" {
+			// Skip the generators.
+			continue
+		}
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if st, ok := typeSpec.Type.(*ast.StructType); ok {
+					fields[typeSpec.Name.Name] = st.Fields.List
+				}
+			}
+		}
+	}
+	return fields, nil
+}
+
+type info struct {
+	Stmts []stmt
+}
+
+type stmt struct {
+	Name string
+	// Children are the fields of the statement which hold other statements.
+	Children []child
+}
+
+type child struct {
+	Field string
+	// Type is the statement type the field points to, or empty if the field
+	// is a Statement.
+	Type string
+}
+
+const visitorTemplate = `// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Code generated by generate_stmt_visitor.go. DO NOT EDIT.
+
+package tree
+
+import "github.com/cockroachdb/errors"
+
+// StatementVisitor is a visitor for Statement nodes. Each method receives a
+// statement of the corresponding type and returns the statement which should
+// replace it, which is usually the statement itself. Implementations which
+// are only interested in a handful of statement types can embed
+// StatementVisitorBase.
+type StatementVisitor interface {
+{{range .Stmts -}}
+	Visit{{.Name}}(*{{.Name}}) (Statement, error)
+{{end}}
+}
+
+// WalkStatement dispatches stmt to the method of v corresponding to its type
+// and returns the statement returned by that method. The statements nested
+// inside of stmt (for example the statement being explained by an EXPLAIN or
+// the query of a CREATE TABLE AS) are walked first and replaced in place by
+// the statements returned for them, so the method for stmt sees them
+// rewritten.
+func WalkStatement(v StatementVisitor, stmt Statement) (Statement, error) {
+	switch t := stmt.(type) {
+{{- range .Stmts}}
+	case *{{.Name}}:
+{{- range .Children}}
+		if t.{{.Field}} != nil {
+			child, err := WalkStatement(v, t.{{.Field}})
+			if err != nil {
+				return nil, err
+			}
+{{- if .Type}}
+			c, ok := child.(*{{.Type}})
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.{{.Field}} with %T", t.{{.Field}}, t, child)
+			}
+			t.{{.Field}} = c
+{{- else}}
+			t.{{.Field}} = child
+{{- end}}
+		}
+{{- end}}
+		return v.Visit{{.Name}}(t)
+{{- end}}
+	default:
+		return nil, errors.AssertionFailedf("unknown statement type %T", stmt)
+	}
+}
+
+// StatementVisitorBase implements StatementVisitor by returning every
+// statement unchanged.
+type StatementVisitorBase struct{}
+
+var _ StatementVisitor = StatementVisitorBase{}
+
+{{range .Stmts}}
+// Visit{{.Name}} is part of the StatementVisitor interface.
+func (StatementVisitorBase) Visit{{.Name}}(n *{{.Name}}) (Statement, error) {
+	return n, nil
+}
+{{end}}`
@@ -0,0 +1,639 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+//go:generate go run ./generate_json_ast_types.go -- json_ast_types_generated.go .
+
+// StatementJSONVersion is the version of the representation produced by
+// MarshalStatementJSON. It must be bumped whenever the representation of an
+// existing node changes in a way which consumers could observe, e.g. when a
+// field of a node is renamed or changes type.
+const StatementJSONVersion = 1
+
+// StatementJSON is the structured representation of a statement produced by
+// MarshalStatementJSON.
+//
+// The AST field mirrors the Go structure of the statement: every node is an
+// object with a "type" key holding the name of the node's type and one key
+// per exported field of the node. Literals (datums and constants), type
+// references and other nodes without exported fields are represented by an
+// object with a "type" key and a "value" key holding the node formatted as
+// SQL. Lists are represented by arrays and unset optional nodes by null.
+//
+// The statement is imported from the AST field; see StatementFromJSON. The
+// SQL field holds the statement formatted as SQL and is informational only.
+type StatementJSON struct {
+	Version int         `json:"version"`
+	Tag     string      `json:"tag"`
+	SQL     string      `json:"sql"`
+	AST     interface{} `json:"ast"`
+}
+
+// MarshalStatementJSON returns the JSON representation of a statement, as
+// described by StatementJSON.
+func MarshalStatementJSON(stmt Statement) ([]byte, error) {
+	doc, err := StatementToJSON(stmt)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+// StatementToJSON returns the structured representation of a statement,
+// which can be serialized with encoding/json.
+func StatementToJSON(stmt Statement) (StatementJSON, error) {
+	if stmt == nil {
+		return StatementJSON{}, errors.AssertionFailedf("cannot serialize nil statement")
+	}
+	var e astEncoder
+	ast, err := e.encode(reflect.ValueOf(stmt))
+	if err != nil {
+		return StatementJSON{}, err
+	}
+	return StatementJSON{
+		Version: StatementJSONVersion,
+		Tag:     stmt.StatementTag(),
+		SQL:     AsString(stmt),
+		AST:     ast,
+	}, nil
+}
+
+var (
+	datumType         = reflect.TypeOf((*Datum)(nil)).Elem()
+	constantType      = reflect.TypeOf((*Constant)(nil)).Elem()
+	nodeFormatterType = reflect.TypeOf((*NodeFormatter)(nil)).Elem()
+	typesTType        = reflect.TypeOf((*types.T)(nil))
+	treePkgPath       = reflect.TypeOf(Name("")).PkgPath()
+)
+
+// astEncoder converts AST nodes to values which can be serialized by
+// encoding/json.
+type astEncoder struct {
+	// path contains the pointers being encoded by the current call stack,
+	// which is used to detect cycles. Parsed statements never contain cycles,
+	// but annotated ones may.
+	path map[uintptr]struct{}
+}
+
+// astTypeName returns the name under which t is serialized. Types from this
+// package are unqualified.
+func astTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.PkgPath() == treePkgPath {
+		return t.Name()
+	}
+	return t.String()
+}
+
+func (e *astEncoder) encode(v reflect.Value) (interface{}, error) {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+		res, err := e.encode(v)
+		if err != nil {
+			return nil, err
+		}
+		// Values held by interfaces need their type to be recoverable, which
+		// is already the case for anything encoded as an object.
+		if _, ok := res.(map[string]interface{}); !ok && res != nil {
+			res = map[string]interface{}{"type": astTypeName(v.Type()), "value": res}
+		}
+		return res, nil
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Type() == typesTType {
+			return e.encodeFormatted(v, v.Interface().(*types.T).SQLString()), nil
+		}
+		if f, ok := e.formatAsValue(v); ok {
+			return f, nil
+		}
+		if e.path == nil {
+			e.path = make(map[uintptr]struct{})
+		}
+		ptr := v.Pointer()
+		if _, ok := e.path[ptr]; ok {
+			return nil, errors.AssertionFailedf("cycle detected while serializing %s", v.Type())
+		}
+		e.path[ptr] = struct{}{}
+		defer delete(e.path, ptr)
+		return e.encode(v.Elem())
+
+	case reflect.Struct:
+		if f, ok := e.formatAsValue(v); ok {
+			return f, nil
+		}
+		obj := map[string]interface{}{"type": astTypeName(v.Type())}
+		if err := e.encodeFields(v, obj); err != nil {
+			return nil, err
+		}
+		return obj, nil
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Bytes(), nil
+		}
+		fallthrough
+
+	case reflect.Array:
+		res := make([]interface{}, v.Len())
+		for i := range res {
+			var err error
+			if res[i], err = e.encode(v.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		res := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			elem, err := e.encode(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			res[fmt.Sprint(iter.Key().Interface())] = elem
+		}
+		return res, nil
+
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.String:
+		return v.String(), nil
+
+	default:
+		// Functions, channels and the like carry no syntactic information.
+		return nil, nil
+	}
+}
+
+// encodeFields adds the exported fields of the struct v to obj. The fields of
+// embedded structs are promoted, mirroring the way they are accessed in Go.
+func (e *astEncoder) encodeFields(v reflect.Value, obj map[string]interface{}) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := e.encodeFields(v.Field(i), obj); err != nil {
+				return err
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		switch field.Type.Kind() {
+		case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+			continue
+		}
+		val, err := e.encode(v.Field(i))
+		if err != nil {
+			return errors.Wrapf(err, "%s.%s", astTypeName(t), field.Name)
+		}
+		obj[field.Name] = val
+	}
+	return nil
+}
+
+// formatAsValue returns the representation of nodes which are opaque when
+// looked at through their exported fields; see formattedAsValue.
+func (e *astEncoder) formatAsValue(v reflect.Value) (interface{}, bool) {
+	if v.Kind() == reflect.Struct {
+		// Methods may be implemented on the pointer receiver.
+		if !formattedAsValue(reflect.PtrTo(v.Type())) {
+			return nil, false
+		}
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		v = p
+	} else if !formattedAsValue(v.Type()) {
+		return nil, false
+	}
+	return e.encodeFormatted(v, AsStringWithFlags(v.Interface().(NodeFormatter), FmtParsable)), true
+}
+
+// formattedAsValue returns whether the nodes of type t, which is a pointer
+// type, are represented by their SQL: datums, constants and other formattable
+// nodes without any exported fields. Type references are represented by their
+// SQL as well, but are handled separately as they aren't formattable nodes.
+func formattedAsValue(t reflect.Type) bool {
+	if t.Kind() != reflect.Ptr || !t.Implements(nodeFormatterType) {
+		return false
+	}
+	return t.Implements(datumType) || t.Implements(constantType) || !hasExportedFields(t.Elem())
+}
+
+func (e *astEncoder) encodeFormatted(v reflect.Value, value string) interface{} {
+	return map[string]interface{}{"type": astTypeName(v.Type()), "value": value}
+}
+
+// hasExportedFields returns whether t is a struct with at least one exported
+// field, including those promoted from embedded structs.
+func hasExportedFields(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		// Nodes which aren't structs, such as lists, are encoded directly.
+		return true
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && hasExportedFields(field.Type) {
+			return true
+		}
+		if field.PkgPath == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// StatementJSONParser parses the SQL of the nodes which a StatementJSON
+// represents by their SQL, so that StatementFromJSON can import them. It is
+// implemented by the parser, which depends on this package.
+type StatementJSONParser interface {
+	// ParseExpr parses a scalar expression, which holds a datum or a
+	// constant.
+	ParseExpr(sql string) (Expr, error)
+	// ParseType parses a type reference.
+	ParseType(sql string) (ResolvableTypeReference, error)
+}
+
+// StatementFromJSON returns the statement described by the AST field of a
+// StatementJSON, as encoded by encoding/json. The nodes represented by their
+// SQL are parsed with p.
+func StatementFromJSON(ast []byte, p StatementJSONParser) (Statement, error) {
+	dec := json.NewDecoder(bytes.NewReader(ast))
+	// Decode the numbers as json.Number so that the 64-bit integers are not
+	// rounded.
+	dec.UseNumber()
+	var data interface{}
+	if err := dec.Decode(&data); err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, errors.New("missing statement")
+	}
+	d := astDecoder{parser: p}
+	var stmt Statement
+	if err := d.decode(data, reflect.ValueOf(&stmt).Elem()); err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
+// astDecoder converts the values decoded by encoding/json back to AST nodes.
+// It reverses astEncoder.
+type astDecoder struct {
+	parser StatementJSONParser
+}
+
+// decode sets v, which must be settable, to the node represented by data.
+func (d *astDecoder) decode(data interface{}, v reflect.Value) error {
+	if data == nil {
+		// Unset optional nodes, lists and maps are represented by null.
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		obj, ok := data.(map[string]interface{})
+		if !ok {
+			return errors.Newf("expected an object for %s, found %T", v.Type(), data)
+		}
+		name, _ := obj["type"].(string)
+		t, ok := lookupASTType(name)
+		if !ok {
+			return errors.Newf("unknown node type %q", name)
+		}
+		var res reflect.Value
+		if decodedFromSQL(reflect.PtrTo(t)) {
+			var err error
+			if res, err = d.decodeFormatted(obj, reflect.PtrTo(t)); err != nil {
+				return err
+			}
+		} else {
+			res = reflect.New(t)
+			if t.Kind() == reflect.Struct {
+				if err := d.decodeFields(obj, res.Elem()); err != nil {
+					return err
+				}
+			} else if err := d.decode(obj["value"], res.Elem()); err != nil {
+				return err
+			}
+		}
+		return assignASTValue(v, res)
+
+	case reflect.Ptr:
+		if decodedFromSQL(v.Type()) {
+			res, err := d.decodeFormatted(data, v.Type())
+			if err != nil {
+				return err
+			}
+			return assignASTValue(v, res)
+		}
+		res := reflect.New(v.Type().Elem())
+		if err := d.decode(data, res.Elem()); err != nil {
+			return err
+		}
+		v.Set(res)
+		return nil
+
+	case reflect.Struct:
+		if formattedAsValue(reflect.PtrTo(v.Type())) {
+			res, err := d.decodeFormatted(data, reflect.PtrTo(v.Type()))
+			if err != nil {
+				return err
+			}
+			return assignASTValue(v, res)
+		}
+		obj, ok := data.(map[string]interface{})
+		if !ok {
+			return errors.Newf("expected an object for %s, found %T", v.Type(), data)
+		}
+		return d.decodeFields(obj, v)
+
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			// encoding/json represents byte slices as base64 strings.
+			s, ok := data.(string)
+			if !ok {
+				return errors.Newf("expected a string for %s, found %T", v.Type(), data)
+			}
+			b, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return err
+			}
+			v.SetBytes(b)
+			return nil
+		}
+		list, ok := data.([]interface{})
+		if !ok {
+			return errors.Newf("expected an array for %s, found %T", v.Type(), data)
+		}
+		v.Set(reflect.MakeSlice(v.Type(), len(list), len(list)))
+		return d.decodeElems(list, v)
+
+	case reflect.Array:
+		list, ok := data.([]interface{})
+		if !ok || len(list) != v.Len() {
+			return errors.Newf("expected an array of %d elements for %s", v.Len(), v.Type())
+		}
+		return d.decodeElems(list, v)
+
+	case reflect.Map:
+		obj, ok := data.(map[string]interface{})
+		if !ok {
+			return errors.Newf("expected an object for %s, found %T", v.Type(), data)
+		}
+		if v.Type().Key().Kind() != reflect.String {
+			return errors.Newf("cannot decode %s", v.Type())
+		}
+		v.Set(reflect.MakeMapWithSize(v.Type(), len(obj)))
+		for key, elemData := range obj {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := d.decode(elemData, elem); err != nil {
+				return err
+			}
+			v.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), elem)
+		}
+		return nil
+
+	case reflect.Bool:
+		b, ok := data.(bool)
+		if !ok {
+			return errors.Newf("expected a boolean for %s, found %T", v.Type(), data)
+		}
+		v.SetBool(b)
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := data.(json.Number)
+		if !ok {
+			return errors.Newf("expected a number for %s, found %T", v.Type(), data)
+		}
+		i, err := strconv.ParseInt(n.String(), 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, ok := data.(json.Number)
+		if !ok {
+			return errors.Newf("expected a number for %s, found %T", v.Type(), data)
+		}
+		u, err := strconv.ParseUint(n.String(), 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		n, ok := data.(json.Number)
+		if !ok {
+			return errors.Newf("expected a number for %s, found %T", v.Type(), data)
+		}
+		f, err := strconv.ParseFloat(n.String(), v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+		return nil
+
+	case reflect.String:
+		s, ok := data.(string)
+		if !ok {
+			return errors.Newf("expected a string for %s, found %T", v.Type(), data)
+		}
+		v.SetString(s)
+		return nil
+
+	default:
+		// The values which carry no syntactic information are not encoded.
+		return nil
+	}
+}
+
+// decodedFromSQL returns whether the nodes of type t, which is a pointer type,
+// are imported by parsing their SQL.
+func decodedFromSQL(t reflect.Type) bool {
+	return t == typesTType || formattedAsValue(t)
+}
+
+// decodeElems decodes the elements of a list into the elements of the slice
+// or array v.
+func (d *astDecoder) decodeElems(list []interface{}, v reflect.Value) error {
+	for i, elemData := range list {
+		if err := d.decode(elemData, v.Index(i)); err != nil {
+			return errors.Wrapf(err, "%s[%d]", v.Type(), i)
+		}
+	}
+	return nil
+}
+
+// decodeFields sets the exported fields of the struct v from obj. It reverses
+// astEncoder.encodeFields. The fields missing from obj are left unset.
+func (d *astDecoder) decodeFields(obj map[string]interface{}, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := d.decodeFields(obj, v.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		switch field.Type.Kind() {
+		case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+			continue
+		}
+		data, ok := obj[field.Name]
+		if !ok {
+			continue
+		}
+		if err := d.decode(data, v.Field(i)); err != nil {
+			return errors.Wrapf(err, "%s.%s", astTypeName(t), field.Name)
+		}
+	}
+	return nil
+}
+
+// decodeFormatted returns the node of type t, which is a pointer type,
+// represented by its SQL in data. It reverses astEncoder.formatAsValue. The
+// result is either of type t or of the type t points to.
+func (d *astDecoder) decodeFormatted(data interface{}, t reflect.Type) (reflect.Value, error) {
+	obj, ok := data.(map[string]interface{})
+	if !ok {
+		return reflect.Value{}, errors.Newf("expected an object for %s, found %T", t, data)
+	}
+	sql, ok := obj["value"].(string)
+	if !ok {
+		return reflect.Value{}, errors.Newf("missing value of %s", astTypeName(t))
+	}
+	switch {
+	case t == typesTType:
+		ref, err := d.parser.ParseType(sql)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(ref), nil
+
+	case t.Implements(datumType):
+		expr, err := d.parser.ParseExpr(sql)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		datum, err := datumFromParsedExpr(expr)
+		if err != nil {
+			return reflect.Value{}, errors.Wrapf(err, "%s", sql)
+		}
+		return reflect.ValueOf(datum), nil
+
+	case t.Implements(constantType):
+		expr, err := d.parser.ParseExpr(sql)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(expr), nil
+
+	default:
+		// The other nodes represented by their SQL have no exported field,
+		// so their zero value is the node.
+		return reflect.New(t.Elem()), nil
+	}
+}
+
+// datumFromParsedExpr returns the datum represented by an expression parsed
+// from the SQL of a datum formatted with FmtParsable, which may annotate the
+// type of a constant.
+func datumFromParsedExpr(expr Expr) (Datum, error) {
+	switch t := expr.(type) {
+	case Datum:
+		return t, nil
+	case *AnnotateTypeExpr:
+		inner := StripParens(t.Expr)
+		if c, ok := inner.(Constant); ok {
+			if typ, ok := t.Type.(*types.T); ok {
+				semaCtx := MakeSemaContext()
+				res, err := c.ResolveAsType(context.Background(), &semaCtx, typ)
+				if err != nil {
+					return nil, err
+				}
+				if datum, ok := res.(Datum); ok {
+					return datum, nil
+				}
+			}
+		}
+	}
+	return nil, errors.Newf("expected a datum, found %T", expr)
+}
+
+// lookupASTType returns the type of the nodes serialized under name.
+func lookupASTType(name string) (reflect.Type, bool) {
+	if name == astTypeName(typesTType) {
+		return typesTType.Elem(), true
+	}
+	t, ok := jsonASTTypes[name]
+	return t, ok
+}
+
+// assignASTValue sets v to the node res, or to the value res points to.
+// Values are preferred over pointers when both can be assigned to v, as the
+// parser uses values for the nodes whose methods have value receivers.
+func assignASTValue(v, res reflect.Value) error {
+	if res.Kind() == reflect.Ptr && !res.IsNil() && res.Elem().Type().AssignableTo(v.Type()) {
+		v.Set(res.Elem())
+		return nil
+	}
+	if res.Type().AssignableTo(v.Type()) {
+		v.Set(res)
+		return nil
+	}
+	return errors.Newf("cannot use %s as %s", res.Type(), v.Type())
+}
@@ -0,0 +1,69 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/datadriven"
+)
+
+// TestMarshalStatementJSON checks the JSON representation of statements
+// against the golden file of the current StatementJSONVersion. The golden
+// file of a version must never change once released, as consumers depend on
+// it: when the representation of an existing node changes, the version must
+// be bumped and a new golden file created with --rewrite.
+func TestMarshalStatementJSON(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	path := testutils.TestDataPath(t, "statement_json", fmt.Sprintf("v%d", tree.StatementJSONVersion))
+	datadriven.RunTest(t, path, func(t *testing.T, d *datadriven.TestData) string {
+		switch d.Cmd {
+		case "marshal":
+			stmt, err := parser.ParseOne(d.Input)
+			if err != nil {
+				d.Fatalf(t, "%v", err)
+			}
+			res, err := tree.MarshalStatementJSON(stmt.AST)
+			if err != nil {
+				d.Fatalf(t, "%v", err)
+			}
+
+			// Check that the statement can be imported back.
+			fromJSON, err := parser.UnmarshalStatementJSON(res)
+			if err != nil {
+				d.Fatalf(t, "%v", err)
+			}
+			if expected, actual := tree.AsString(stmt.AST), tree.AsString(fromJSON); expected != actual {
+				d.Fatalf(t, "mismatched AST after JSON roundtrip:\nexpected: %s\nactual:   %s", expected, actual)
+			}
+
+			var buf bytes.Buffer
+			if err := json.Indent(&buf, res, "", "  "); err != nil {
+				d.Fatalf(t, "%v", err)
+			}
+			return buf.String()
+
+		default:
+			d.Fatalf(t, "unknown command %s", d.Cmd)
+			return ""
+		}
+	})
+}
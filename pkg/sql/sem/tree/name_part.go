@@ -34,6 +34,8 @@ func (n *Name) Format(ctx *FmtCtx) {
 	f := ctx.flags
 	if f.HasFlags(FmtAnonymize) && !isArityIndicatorString(string(*n)) {
 		ctx.WriteByte('_')
+	} else if f.HasFlags(FmtHashIdentifiers) && !isArityIndicatorString(string(*n)) {
+		ctx.formatHashedName(string(*n))
 	} else {
 		lexbase.EncodeRestrictedSQLIdent(&ctx.Buffer, string(*n), f.EncodeFlags())
 	}
@@ -89,6 +91,8 @@ func (u *UnrestrictedName) Format(ctx *FmtCtx) {
 	f := ctx.flags
 	if f.HasFlags(FmtAnonymize) {
 		ctx.WriteByte('_')
+	} else if f.HasFlags(FmtHashIdentifiers) {
+		ctx.formatHashedName(string(*u))
 	} else {
 		lexbase.EncodeUnrestrictedSQLIdent(&ctx.Buffer, string(*u), f.EncodeFlags())
 	}
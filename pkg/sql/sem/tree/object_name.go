@@ -188,6 +188,13 @@ func (u *UnresolvedObjectName) Format(ctx *FmtCtx) {
 			return
 		}
 	}
+	if ctx.nameResolver != nil {
+		tn := u.ToTableName()
+		if resolved, ok := ctx.nameResolver.ResolveTableName(&tn); ok {
+			resolved.Format(ctx)
+			return
+		}
+	}
 
 	for i := u.NumParts; i > 0; i-- {
 		// The first part to print is the last item in u.Parts. It is also
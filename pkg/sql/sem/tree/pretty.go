@@ -1498,13 +1498,22 @@ func (node *PartitionBy) docInner(p *PrettyCfg, kw string) pretty.Doc {
 func (node *Locality) doc(p *PrettyCfg) pretty.Doc {
 	// Final layout:
 	//
-	// LOCALITY [GLOBAL | REGIONAL BY [TABLE [IN [PRIMARY REGION|region]]|ROW]]
+	// LOCALITY [GLOBAL | REGIONAL BY [TABLE [IN [PRIMARY REGION|region]]|ROW [AS (expr)|AS col]]]
 	localityKW := pretty.Keyword("LOCALITY")
 	switch node.LocalityLevel {
 	case LocalityLevelGlobal:
 		return pretty.ConcatSpace(localityKW, pretty.Keyword("GLOBAL"))
 	case LocalityLevelRow:
 		ret := pretty.ConcatSpace(localityKW, pretty.Keyword("REGIONAL BY ROW"))
+		if node.RegionalByRowExpr != nil {
+			return pretty.ConcatSpace(
+				ret,
+				pretty.ConcatSpace(
+					pretty.Keyword("AS"),
+					p.bracket("(", p.Doc(node.RegionalByRowExpr), ")"),
+				),
+			)
+		}
 		if node.RegionalByRowColumn != "" {
 			return pretty.ConcatSpace(
 				ret,
@@ -2273,6 +2282,69 @@ func (node *AlterTableAddColumn) doc(p *PrettyCfg) pretty.Doc {
 	)
 }
 
+func (node *AlterDatabaseAddSuperRegion) doc(p *PrettyCfg) pretty.Doc {
+	return p.rlTable(
+		p.row("ALTER DATABASE", p.Doc(&node.DatabaseName)),
+		p.row("ADD SUPER REGION", p.Doc(&node.SuperRegionName)),
+		p.row("VALUES", p.docRegionList(node.Regions)),
+	)
+}
+
+func (node *AlterDatabaseDropSuperRegion) doc(p *PrettyCfg) pretty.Doc {
+	items := make([]pretty.TableRow, 0, 3)
+	items = append(items,
+		p.row("ALTER DATABASE", p.Doc(&node.DatabaseName)),
+		p.row("DROP SUPER REGION", p.Doc(&node.SuperRegionName)),
+	)
+	if node.Override {
+		items = append(items, p.row("WITH", pretty.Keyword("OVERRIDE")))
+	}
+	return p.rlTable(items...)
+}
+
+func (node *AlterDatabaseAlterSuperRegion) doc(p *PrettyCfg) pretty.Doc {
+	items := make([]pretty.TableRow, 0, 4)
+	items = append(items,
+		p.row("ALTER DATABASE", p.Doc(&node.DatabaseName)),
+		p.row("ALTER SUPER REGION", p.Doc(&node.SuperRegionName)),
+		p.row("VALUES", p.docRegionList(node.Regions)),
+	)
+	if node.Override {
+		items = append(items, p.row("WITH", pretty.Keyword("OVERRIDE")))
+	}
+	return p.rlTable(items...)
+}
+
+func (node *AlterDatabasePlacement) doc(p *PrettyCfg) pretty.Doc {
+	items := make([]pretty.TableRow, 0, 3)
+	items = append(items, p.row("ALTER DATABASE", p.Doc(&node.Name)))
+	switch node.Placement {
+	case DataPlacementDefault:
+		items = append(items, p.row("PLACEMENT", pretty.Keyword("DEFAULT")))
+	case DataPlacementRestricted:
+		items = append(items, p.row("PLACEMENT", pretty.Keyword("RESTRICTED")))
+	}
+	if len(node.ExceptTables) > 0 {
+		tables := make([]pretty.Doc, len(node.ExceptTables))
+		for i := range node.ExceptTables {
+			tables[i] = p.Doc(&node.ExceptTables[i])
+		}
+		items = append(items,
+			p.row("EXCEPT TABLES", p.bracket("(", p.commaSeparated(tables...), ")")),
+		)
+	}
+	return p.rlTable(items...)
+}
+
+// docRegionList returns a comma-separated list of region names.
+func (p *PrettyCfg) docRegionList(regions []Name) pretty.Doc {
+	d := make([]pretty.Doc, len(regions))
+	for i := range regions {
+		d[i] = p.Doc(&regions[i])
+	}
+	return p.commaSeparated(d...)
+}
+
 func (node *Prepare) doc(p *PrettyCfg) pretty.Doc {
 	return p.rlTable(node.docTable(p)...)
 }
@@ -54,6 +54,9 @@ type Locality struct {
 	// RegionalByRowColumn is set if col_name on REGIONAL BY ROW ON <col_name> is
 	// set.
 	RegionalByRowColumn Name
+	// RegionalByRowExpr is set if REGIONAL BY ROW AS (<expr>) is used, in which
+	// case the implicit region column is computed from the expression.
+	RegionalByRowExpr Expr
 }
 
 // Constants to use for telemetry for multi-region table localities.
@@ -63,6 +66,7 @@ const (
 	TelemetryNameRegionalByTableIn = "regional_by_table_in"
 	TelemetryNameRegionalByRow     = "regional_by_row"
 	TelemetryNameRegionalByRowAs   = "regional_by_row_as"
+	TelemetryNameRegionalByRowExpr = "regional_by_row_as_expr"
 )
 
 // TelemetryName returns the telemetry name for a given locality level.
@@ -76,6 +80,9 @@ func (node *Locality) TelemetryName() string {
 		}
 		return TelemetryNameRegionalByTable
 	case LocalityLevelRow:
+		if node.RegionalByRowExpr != nil {
+			return TelemetryNameRegionalByRowExpr
+		}
 		if node.RegionalByRowColumn != PrimaryRegionNotSpecifiedName {
 			return TelemetryNameRegionalByRowAs
 		}
@@ -94,13 +101,17 @@ func (node *Locality) Format(ctx *FmtCtx) {
 	case LocalityLevelTable:
 		ctx.WriteString("REGIONAL BY TABLE IN ")
 		if node.TableRegion != "" {
-			ctx.FormatNode(&node.TableRegion)
+			ctx.formatRegionName(&node.TableRegion)
 		} else {
 			ctx.WriteString("PRIMARY REGION")
 		}
 	case LocalityLevelRow:
 		ctx.WriteString("REGIONAL BY ROW")
-		if node.RegionalByRowColumn != "" {
+		if node.RegionalByRowExpr != nil {
+			ctx.WriteString(" AS (")
+			ctx.FormatNode(node.RegionalByRowExpr)
+			ctx.WriteString(")")
+		} else if node.RegionalByRowColumn != "" {
 			ctx.WriteString(" AS ")
 			ctx.FormatNode(&node.RegionalByRowColumn)
 		}
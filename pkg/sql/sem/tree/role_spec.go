@@ -112,6 +112,10 @@ func (r *RoleSpec) Format(ctx *FmtCtx) {
 	} else {
 		switch r.RoleSpecType {
 		case RoleName:
+			if f.HasFlags(FmtHashIdentifiers) && !isArityIndicatorString(r.Name) {
+				ctx.formatHashedName(r.Name)
+				return
+			}
 			lexbase.EncodeRestrictedSQLIdent(&ctx.Buffer, r.Name, f.EncodeFlags())
 			return
 		case CurrentUser, SessionUser:
@@ -36,7 +36,7 @@ func (node *SetVar) Format(ctx *FmtCtx) {
 	}
 	if node.Reset {
 		ctx.WriteString("RESET ")
-		ctx.WithFlags(ctx.flags & ^FmtAnonymize & ^FmtMarkRedactionNode, func() {
+		ctx.WithFlags(ctx.flags & ^FmtAnonymize & ^FmtHashIdentifiers & ^FmtMarkRedactionNode, func() {
 			// Session var names never contain PII and should be distinguished
 			// for feature tracking purposes.
 			ctx.FormatNameP(&node.Name)
@@ -52,7 +52,7 @@ func (node *SetVar) Format(ctx *FmtCtx) {
 		ctx.FormatNode(&node.Values)
 		ctx.WriteString(")")
 	} else {
-		ctx.WithFlags(ctx.flags & ^FmtAnonymize & ^FmtMarkRedactionNode, func() {
+		ctx.WithFlags(ctx.flags & ^FmtAnonymize & ^FmtHashIdentifiers & ^FmtMarkRedactionNode, func() {
 			// Session var names never contain PII and should be distinguished
 			// for feature tracking purposes.
 			ctx.FormatNameP(&node.Name)
@@ -75,7 +75,7 @@ func (node *SetClusterSetting) Format(ctx *FmtCtx) {
 
 	// Cluster setting names never contain PII and should be distinguished
 	// for feature tracking purposes.
-	ctx.WithFlags(ctx.flags & ^FmtAnonymize & ^FmtMarkRedactionNode, func() {
+	ctx.WithFlags(ctx.flags & ^FmtAnonymize & ^FmtHashIdentifiers & ^FmtMarkRedactionNode, func() {
 		ctx.FormatNameP(&node.Name)
 	})
 
@@ -83,7 +83,7 @@ func (node *SetClusterSetting) Format(ctx *FmtCtx) {
 
 	switch v := node.Value.(type) {
 	case *DBool, *DInt:
-		ctx.WithFlags(ctx.flags & ^FmtAnonymize & ^FmtMarkRedactionNode, func() {
+		ctx.WithFlags(ctx.flags & ^FmtAnonymize & ^FmtHashIdentifiers & ^FmtMarkRedactionNode, func() {
 			ctx.FormatNode(v)
 		})
 	default:
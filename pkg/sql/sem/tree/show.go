@@ -35,7 +35,7 @@ func (node *ShowVar) Format(ctx *FmtCtx) {
 	ctx.WriteString("SHOW ")
 	// Session var names never contain PII and should be distinguished
 	// for feature tracking purposes.
-	ctx.WithFlags(ctx.flags & ^FmtAnonymize & ^FmtMarkRedactionNode, func() {
+	ctx.WithFlags(ctx.flags & ^FmtAnonymize & ^FmtHashIdentifiers & ^FmtMarkRedactionNode, func() {
 		ctx.FormatNameP(&node.Name)
 	})
 }
@@ -50,7 +50,7 @@ func (node *ShowClusterSetting) Format(ctx *FmtCtx) {
 	ctx.WriteString("SHOW CLUSTER SETTING ")
 	// Cluster setting names never contain PII and should be distinguished
 	// for feature tracking purposes.
-	ctx.WithFlags(ctx.flags & ^FmtAnonymize & ^FmtMarkRedactionNode, func() {
+	ctx.WithFlags(ctx.flags & ^FmtAnonymize & ^FmtHashIdentifiers & ^FmtMarkRedactionNode, func() {
 		ctx.FormatNameP(&node.Name)
 	})
 }
@@ -311,7 +311,7 @@ func (node *ShowSurvivalGoal) Format(ctx *FmtCtx) {
 	ctx.WriteString("SHOW SURVIVAL GOAL FROM DATABASE")
 	if node.DatabaseName != "" {
 		ctx.WriteString(" ")
-		ctx.FormatNode(&node.DatabaseName)
+		ctx.formatDatabaseName(&node.DatabaseName)
 	}
 }
 
@@ -333,6 +333,10 @@ const (
 type ShowRegions struct {
 	ShowRegionsFrom ShowRegionsFrom
 	DatabaseName    Name
+	// WithDetails is set for SHOW REGIONS WITH DETAILS.
+	WithDetails bool
+	// WithComment is set for SHOW REGIONS FROM DATABASE ... WITH COMMENT.
+	WithComment bool
 }
 
 // Format implements the NodeFormatter interface.
@@ -346,13 +350,19 @@ func (node *ShowRegions) Format(ctx *FmtCtx) {
 		ctx.WriteString(" FROM DATABASE")
 		if node.DatabaseName != "" {
 			ctx.WriteString(" ")
-			ctx.FormatNode(&node.DatabaseName)
+			ctx.formatDatabaseName(&node.DatabaseName)
 		}
 	case ShowRegionsFromCluster:
 		ctx.WriteString(" FROM CLUSTER")
 	default:
 		panic(fmt.Sprintf("unknown ShowRegionsFrom: %v", node.ShowRegionsFrom))
 	}
+	if node.WithDetails {
+		ctx.WriteString(" WITH DETAILS")
+	}
+	if node.WithComment {
+		ctx.WriteString(" WITH COMMENT")
+	}
 }
 
 // ShowSessions represents a SHOW SESSIONS statement
@@ -526,6 +536,14 @@ func (node *ShowCreate) Format(ctx *FmtCtx) {
 	ctx.FormatNode(node.Name)
 }
 
+// ShowCreateAllRoles represents a SHOW CREATE ALL ROLES statement.
+type ShowCreateAllRoles struct{}
+
+// Format implements the NodeFormatter interface.
+func (node *ShowCreateAllRoles) Format(ctx *FmtCtx) {
+	ctx.WriteString("SHOW CREATE ALL ROLES")
+}
+
 // ShowCreateAllSchemas represents a SHOW CREATE ALL SCHEMAS statement.
 type ShowCreateAllSchemas struct{}
 
@@ -615,7 +633,7 @@ func (node *ShowLastQueryStatistics) Format(ctx *FmtCtx) {
 	ctx.WriteString("SHOW LAST QUERY STATISTICS RETURNING ")
 	// The column names for this statement never contain PII and should
 	// be distinguished for feature tracking purposes.
-	ctx.WithFlags(ctx.flags & ^FmtAnonymize & ^FmtMarkRedactionNode, func() {
+	ctx.WithFlags(ctx.flags & ^FmtAnonymize & ^FmtHashIdentifiers & ^FmtMarkRedactionNode, func() {
 		ctx.FormatNode(&node.Columns)
 	})
 }
@@ -907,6 +925,69 @@ func (node *ShowTransferState) Format(ctx *FmtCtx) {
 	}
 }
 
+// ShowAuthenticationCache represents a SHOW AUTHENTICATION CACHE statement.
+type ShowAuthenticationCache struct{}
+
+// Format implements the NodeFormatter interface.
+func (node *ShowAuthenticationCache) Format(ctx *FmtCtx) {
+	ctx.WriteString("SHOW AUTHENTICATION CACHE")
+}
+
+// ShowHBARules represents a SHOW HBA RULES statement.
+type ShowHBARules struct {
+	// Config is the proposed HBA configuration to validate. If nil, the
+	// active configuration of the node is shown.
+	Config  Expr
+	Options KVOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ShowHBARules) Format(ctx *FmtCtx) {
+	ctx.WriteString("SHOW HBA RULES")
+	if node.Config != nil {
+		ctx.WriteString(" FROM ")
+		ctx.FormatNode(node.Config)
+	}
+	if len(node.Options) > 0 {
+		ctx.WriteString(" WITH ")
+		ctx.FormatNode(&node.Options)
+	}
+}
+
+// ShowDefaultSessionVariables represents a SHOW DEFAULT SESSION VARIABLES
+// FOR ROLE statement.
+type ShowDefaultSessionVariables struct {
+	Role RoleSpec
+	// DatabaseName is the database of the session. If empty, the current
+	// database is used.
+	DatabaseName Name
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ShowDefaultSessionVariables) Format(ctx *FmtCtx) {
+	ctx.WriteString("SHOW DEFAULT SESSION VARIABLES FOR ROLE ")
+	ctx.FormatNode(&node.Role)
+	if node.DatabaseName != "" {
+		ctx.WriteString(" IN DATABASE ")
+		ctx.FormatNode(&node.DatabaseName)
+	}
+}
+
+// ShowSettingProvenance represents a SHOW SETTING PROVENANCE statement.
+type ShowSettingProvenance struct {
+	Name string
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ShowSettingProvenance) Format(ctx *FmtCtx) {
+	ctx.WriteString("SHOW SETTING PROVENANCE ")
+	// Session var names never contain PII and should be distinguished
+	// for feature tracking purposes.
+	ctx.WithFlags(ctx.flags & ^FmtAnonymize & ^FmtHashIdentifiers & ^FmtMarkRedactionNode, func() {
+		ctx.FormatNameP(&node.Name)
+	})
+}
+
 // ShowCompletions represents a SHOW COMPLETIONS statement.
 type ShowCompletions struct {
 	Statement *StrVal
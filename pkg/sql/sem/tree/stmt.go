@@ -24,6 +24,8 @@ import (
 	"strings"
 )
 
+//go:generate go run ./generate_stmt_visitor.go -- stmt.go stmt_visitor_generated.go .
+
 // Instructions for creating new types: If a type needs to satisfy an
 // interface, declare that function along with that interface. This
 // will help users identify the list of types to which they can assert
@@ -289,6 +291,104 @@ func (*AlterDatabaseDropSuperRegion) StatementTag() string { return "ALTER DATAB
 
 func (*AlterDatabaseDropSuperRegion) hiddenFromShowQueries() {}
 
+// StatementReturnType implements the Statement interface.
+func (*AlterDatabaseAlterSuperRegion) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*AlterDatabaseAlterSuperRegion) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterDatabaseAlterSuperRegion) StatementTag() string {
+	return "ALTER DATABASE ALTER SUPER REGION"
+}
+
+func (*AlterDatabaseAlterSuperRegion) hiddenFromShowQueries() {}
+
+// StatementReturnType implements the Statement interface.
+func (*AlterDatabaseSecondaryRegion) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*AlterDatabaseSecondaryRegion) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterDatabaseSecondaryRegion) StatementTag() string { return "ALTER DATABASE SECONDARY REGION" }
+
+func (*AlterDatabaseSecondaryRegion) hiddenFromShowQueries() {}
+
+// StatementReturnType implements the Statement interface.
+func (*AlterDatabaseDropSecondaryRegion) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*AlterDatabaseDropSecondaryRegion) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterDatabaseDropSecondaryRegion) StatementTag() string {
+	return "ALTER DATABASE DROP SECONDARY REGION"
+}
+
+func (*AlterDatabaseDropSecondaryRegion) hiddenFromShowQueries() {}
+
+// StatementReturnType implements the Statement interface.
+func (*AlterDatabaseRenameRegion) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*AlterDatabaseRenameRegion) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterDatabaseRenameRegion) StatementTag() string { return "ALTER DATABASE RENAME REGION" }
+
+func (*AlterDatabaseRenameRegion) hiddenFromShowQueries() {}
+
+// StatementReturnType implements the Statement interface.
+func (*AlterDatabaseToMultiRegion) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*AlterDatabaseToMultiRegion) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterDatabaseToMultiRegion) StatementTag() string {
+	return "ALTER DATABASE CONVERT TO MULTIREGION"
+}
+
+func (*AlterDatabaseToMultiRegion) hiddenFromShowQueries() {}
+
+// StatementReturnType implements the Statement interface.
+func (*AlterDatabaseRegionReplicas) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*AlterDatabaseRegionReplicas) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterDatabaseRegionReplicas) StatementTag() string { return "ALTER DATABASE SET REGION" }
+
+func (*AlterDatabaseRegionReplicas) hiddenFromShowQueries() {}
+
+// StatementReturnType implements the Statement interface.
+func (*AlterDatabaseResetPrimaryRegion) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*AlterDatabaseResetPrimaryRegion) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterDatabaseResetPrimaryRegion) StatementTag() string {
+	return "ALTER DATABASE RESET PRIMARY REGION"
+}
+
+func (*AlterDatabaseResetPrimaryRegion) hiddenFromShowQueries() {}
+
+// StatementReturnType implements the Statement interface.
+func (*AlterDatabaseDefaultLocality) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*AlterDatabaseDefaultLocality) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterDatabaseDefaultLocality) StatementTag() string {
+	return "ALTER DATABASE SET DEFAULT TABLE LOCALITY"
+}
+
+func (*AlterDatabaseDefaultLocality) hiddenFromShowQueries() {}
+
 // StatementReturnType implements the Statement interface.
 func (*AlterDefaultPrivileges) StatementReturnType() StatementReturnType { return DDL }
 
@@ -415,6 +515,24 @@ func (*AlterRoleSet) StatementTag() string { return "ALTER ROLE" }
 
 func (*AlterRoleSet) hiddenFromShowQueries() {}
 
+// StatementReturnType implements the Statement interface.
+func (*RenameRole) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*RenameRole) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*RenameRole) StatementTag() string { return "ALTER ROLE" }
+
+// StatementReturnType implements the Statement interface.
+func (*AlterProfile) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*AlterProfile) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterProfile) StatementTag() string { return "ALTER PROFILE" }
+
 // StatementReturnType implements the Statement interface.
 func (*Analyze) StatementReturnType() StatementReturnType { return DDL }
 
@@ -566,6 +684,15 @@ func (*CommentOnDatabase) StatementType() StatementType { return TypeDDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*CommentOnDatabase) StatementTag() string { return "COMMENT ON DATABASE" }
 
+// StatementReturnType implements the Statement interface.
+func (*CommentOnRegion) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CommentOnRegion) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CommentOnRegion) StatementTag() string { return "COMMENT ON REGION" }
+
 // StatementReturnType implements the Statement interface.
 func (*CommentOnSchema) StatementReturnType() StatementReturnType { return DDL }
 
@@ -707,6 +834,15 @@ func (*CreateRole) StatementTag() string { return "CREATE ROLE" }
 
 func (*CreateRole) hiddenFromShowQueries() {}
 
+// StatementReturnType implements the Statement interface.
+func (*CreateProfile) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*CreateProfile) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateProfile) StatementTag() string { return "CREATE PROFILE" }
+
 // StatementReturnType implements the Statement interface.
 func (*CreateView) StatementReturnType() StatementReturnType { return DDL }
 
@@ -834,6 +970,15 @@ func (*DropRole) cclOnlyStatement() {}
 
 func (*DropRole) hiddenFromShowQueries() {}
 
+// StatementReturnType implements the Statement interface.
+func (*DropProfile) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*DropProfile) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropProfile) StatementTag() string { return "DROP PROFILE" }
+
 // StatementReturnType implements the Statement interface.
 func (*DropType) StatementReturnType() StatementReturnType { return DDL }
 
@@ -1319,6 +1464,15 @@ func (*ShowCreate) StatementType() StatementType { return TypeDML }
 // StatementTag returns a short string identifying the type of statement.
 func (*ShowCreate) StatementTag() string { return "SHOW CREATE" }
 
+// StatementReturnType implements the Statement interface.
+func (*ShowCreateAllRoles) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*ShowCreateAllRoles) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowCreateAllRoles) StatementTag() string { return "SHOW CREATE ALL ROLES" }
+
 // StatementReturnType implements the Statement interface.
 func (*ShowCreateAllSchemas) StatementReturnType() StatementReturnType { return Rows }
 
@@ -1510,6 +1664,15 @@ func (*ShowSchedules) StatementType() StatementType { return TypeDML }
 // StatementTag returns a short string identifying the type of statement.
 func (*ShowSchedules) StatementTag() string { return "SHOW SCHEDULES" }
 
+// StatementReturnType implements the Statement interface.
+func (*ShowSettingProvenance) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*ShowSettingProvenance) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowSettingProvenance) StatementTag() string { return "SHOW SETTING PROVENANCE" }
+
 // StatementReturnType implements the Statement interface.
 func (*ShowSyntax) StatementReturnType() StatementReturnType { return Rows }
 
@@ -1543,6 +1706,33 @@ func (*ShowTransferState) StatementTag() string { return "SHOW TRANSFER STATE" }
 
 func (*ShowTransferState) observerStatement() {}
 
+// StatementReturnType implements the Statement interface.
+func (*ShowAuthenticationCache) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*ShowAuthenticationCache) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowAuthenticationCache) StatementTag() string { return "SHOW AUTHENTICATION CACHE" }
+
+// StatementReturnType implements the Statement interface.
+func (*ShowDefaultSessionVariables) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*ShowDefaultSessionVariables) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowDefaultSessionVariables) StatementTag() string { return "SHOW DEFAULT SESSION VARIABLES" }
+
+// StatementReturnType implements the Statement interface.
+func (*ShowHBARules) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*ShowHBARules) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*ShowHBARules) StatementTag() string { return "SHOW HBA RULES" }
+
 // StatementReturnType implements the Statement interface.
 func (*ShowSavepointStatus) StatementReturnType() StatementReturnType { return Rows }
 
@@ -1782,175 +1972,193 @@ func (*ValuesClause) StatementType() StatementType { return TypeDML }
 // StatementTag returns a short string identifying the type of statement.
 func (*ValuesClause) StatementTag() string { return "VALUES" }
 
-func (n *AlterChangefeed) String() string                { return AsString(n) }
-func (n *AlterChangefeedCmds) String() string            { return AsString(n) }
-func (n *AlterBackup) String() string                    { return AsString(n) }
-func (n *AlterIndex) String() string                     { return AsString(n) }
-func (n *AlterDatabaseOwner) String() string             { return AsString(n) }
-func (n *AlterDatabaseAddRegion) String() string         { return AsString(n) }
-func (n *AlterDatabaseDropRegion) String() string        { return AsString(n) }
-func (n *AlterDatabaseSurvivalGoal) String() string      { return AsString(n) }
-func (n *AlterDatabasePlacement) String() string         { return AsString(n) }
-func (n *AlterDatabasePrimaryRegion) String() string     { return AsString(n) }
-func (n *AlterDatabaseAddSuperRegion) String() string    { return AsString(n) }
-func (n *AlterDatabaseDropSuperRegion) String() string   { return AsString(n) }
-func (n *AlterDefaultPrivileges) String() string         { return AsString(n) }
-func (n *AlterSchema) String() string                    { return AsString(n) }
-func (n *AlterTable) String() string                     { return AsString(n) }
-func (n *AlterTableCmds) String() string                 { return AsString(n) }
-func (n *AlterTableAddColumn) String() string            { return AsString(n) }
-func (n *AlterTableAddConstraint) String() string        { return AsString(n) }
-func (n *AlterTableAlterColumnType) String() string      { return AsString(n) }
-func (n *AlterTableDropColumn) String() string           { return AsString(n) }
-func (n *AlterTableDropConstraint) String() string       { return AsString(n) }
-func (n *AlterTableDropNotNull) String() string          { return AsString(n) }
-func (n *AlterTableDropStored) String() string           { return AsString(n) }
-func (n *AlterTableLocality) String() string             { return AsString(n) }
-func (n *AlterTableSetDefault) String() string           { return AsString(n) }
-func (n *AlterTableSetVisible) String() string           { return AsString(n) }
-func (n *AlterTableSetNotNull) String() string           { return AsString(n) }
-func (n *AlterTableOwner) String() string                { return AsString(n) }
-func (n *AlterTableSetSchema) String() string            { return AsString(n) }
-func (n *AlterTenantSetClusterSetting) String() string   { return AsString(n) }
-func (n *AlterType) String() string                      { return AsString(n) }
-func (n *AlterRole) String() string                      { return AsString(n) }
-func (n *AlterRoleSet) String() string                   { return AsString(n) }
-func (n *AlterSequence) String() string                  { return AsString(n) }
-func (n *Analyze) String() string                        { return AsString(n) }
-func (n *Backup) String() string                         { return AsString(n) }
-func (n *BeginTransaction) String() string               { return AsString(n) }
-func (n *ControlJobs) String() string                    { return AsString(n) }
-func (n *ControlSchedules) String() string               { return AsString(n) }
-func (n *ControlJobsForSchedules) String() string        { return AsString(n) }
-func (n *ControlJobsOfType) String() string              { return AsString(n) }
-func (n *CancelQueries) String() string                  { return AsString(n) }
-func (n *CancelSessions) String() string                 { return AsString(n) }
-func (n *CannedOptPlan) String() string                  { return AsString(n) }
-func (n *CloseCursor) String() string                    { return AsString(n) }
-func (n *CommentOnColumn) String() string                { return AsString(n) }
-func (n *CommentOnConstraint) String() string            { return AsString(n) }
-func (n *CommentOnDatabase) String() string              { return AsString(n) }
-func (n *CommentOnSchema) String() string                { return AsString(n) }
-func (n *CommentOnIndex) String() string                 { return AsString(n) }
-func (n *CommentOnTable) String() string                 { return AsString(n) }
-func (n *CommitTransaction) String() string              { return AsString(n) }
-func (n *CopyFrom) String() string                       { return AsString(n) }
-func (n *CreateChangefeed) String() string               { return AsString(n) }
-func (n *CreateDatabase) String() string                 { return AsString(n) }
-func (n *CreateExtension) String() string                { return AsString(n) }
-func (n *CreateIndex) String() string                    { return AsString(n) }
-func (n *CreateRole) String() string                     { return AsString(n) }
-func (n *CreateTable) String() string                    { return AsString(n) }
-func (n *CreateSchema) String() string                   { return AsString(n) }
-func (n *CreateSequence) String() string                 { return AsString(n) }
-func (n *CreateStats) String() string                    { return AsString(n) }
-func (n *CreateView) String() string                     { return AsString(n) }
-func (n *Deallocate) String() string                     { return AsString(n) }
-func (n *Delete) String() string                         { return AsString(n) }
-func (n *DeclareCursor) String() string                  { return AsString(n) }
-func (n *DropDatabase) String() string                   { return AsString(n) }
-func (n *DropIndex) String() string                      { return AsString(n) }
-func (n *DropOwnedBy) String() string                    { return AsString(n) }
-func (n *DropSchema) String() string                     { return AsString(n) }
-func (n *DropSequence) String() string                   { return AsString(n) }
-func (n *DropTable) String() string                      { return AsString(n) }
-func (n *DropType) String() string                       { return AsString(n) }
-func (n *DropView) String() string                       { return AsString(n) }
-func (n *DropRole) String() string                       { return AsString(n) }
-func (n *Execute) String() string                        { return AsString(n) }
-func (n *Explain) String() string                        { return AsString(n) }
-func (n *ExplainAnalyze) String() string                 { return AsString(n) }
-func (n *Export) String() string                         { return AsString(n) }
-func (n *FetchCursor) String() string                    { return AsString(n) }
-func (n *Grant) String() string                          { return AsString(n) }
-func (n *GrantRole) String() string                      { return AsString(n) }
-func (n *MoveCursor) String() string                     { return AsString(n) }
-func (n *Insert) String() string                         { return AsString(n) }
-func (n *Import) String() string                         { return AsString(n) }
-func (n *ParenSelect) String() string                    { return AsString(n) }
-func (n *Prepare) String() string                        { return AsString(n) }
-func (n *ReassignOwnedBy) String() string                { return AsString(n) }
-func (n *ReleaseSavepoint) String() string               { return AsString(n) }
-func (n *Relocate) String() string                       { return AsString(n) }
-func (n *RelocateRange) String() string                  { return AsString(n) }
-func (n *RefreshMaterializedView) String() string        { return AsString(n) }
-func (n *RenameColumn) String() string                   { return AsString(n) }
-func (n *RenameDatabase) String() string                 { return AsString(n) }
-func (n *ReparentDatabase) String() string               { return AsString(n) }
-func (n *ReplicationStream) String() string              { return AsString(n) }
-func (n *RenameIndex) String() string                    { return AsString(n) }
-func (n *RenameTable) String() string                    { return AsString(n) }
-func (n *Restore) String() string                        { return AsString(n) }
-func (n *Revoke) String() string                         { return AsString(n) }
-func (n *RevokeRole) String() string                     { return AsString(n) }
-func (n *RollbackToSavepoint) String() string            { return AsString(n) }
-func (n *RollbackTransaction) String() string            { return AsString(n) }
-func (n *Savepoint) String() string                      { return AsString(n) }
-func (n *Scatter) String() string                        { return AsString(n) }
-func (n *ScheduledBackup) String() string                { return AsString(n) }
-func (n *Scrub) String() string                          { return AsString(n) }
-func (n *Select) String() string                         { return AsString(n) }
-func (n *SelectClause) String() string                   { return AsString(n) }
-func (n *SetClusterSetting) String() string              { return AsString(n) }
-func (n *SetZoneConfig) String() string                  { return AsString(n) }
-func (n *SetSessionAuthorizationDefault) String() string { return AsString(n) }
-func (n *SetSessionCharacteristics) String() string      { return AsString(n) }
-func (n *SetTransaction) String() string                 { return AsString(n) }
-func (n *SetTracing) String() string                     { return AsString(n) }
-func (n *SetVar) String() string                         { return AsString(n) }
-func (n *ShowBackup) String() string                     { return AsString(n) }
-func (n *ShowClusterSetting) String() string             { return AsString(n) }
-func (n *ShowClusterSettingList) String() string         { return AsString(n) }
-func (n *ShowTenantClusterSetting) String() string       { return AsString(n) }
-func (n *ShowTenantClusterSettingList) String() string   { return AsString(n) }
-func (n *ShowColumns) String() string                    { return AsString(n) }
-func (n *ShowConstraints) String() string                { return AsString(n) }
-func (n *ShowCreate) String() string                     { return AsString(n) }
-func (node *ShowCreateAllSchemas) String() string        { return AsString(node) }
-func (node *ShowCreateAllTables) String() string         { return AsString(node) }
-func (node *ShowCreateAllTypes) String() string          { return AsString(node) }
-func (n *ShowCreateSchedules) String() string            { return AsString(n) }
-func (n *ShowDatabases) String() string                  { return AsString(n) }
-func (n *ShowDatabaseIndexes) String() string            { return AsString(n) }
-func (n *ShowEnums) String() string                      { return AsString(n) }
-func (n *ShowFullTableScans) String() string             { return AsString(n) }
-func (n *ShowGrants) String() string                     { return AsString(n) }
-func (n *ShowHistogram) String() string                  { return AsString(n) }
-func (n *ShowSchedules) String() string                  { return AsString(n) }
-func (n *ShowIndexes) String() string                    { return AsString(n) }
-func (n *ShowJobs) String() string                       { return AsString(n) }
-func (n *ShowChangefeedJobs) String() string             { return AsString(n) }
-func (n *ShowLastQueryStatistics) String() string        { return AsString(n) }
-func (n *ShowPartitions) String() string                 { return AsString(n) }
-func (n *ShowQueries) String() string                    { return AsString(n) }
-func (n *ShowRanges) String() string                     { return AsString(n) }
-func (n *ShowRangeForRow) String() string                { return AsString(n) }
-func (n *ShowRegions) String() string                    { return AsString(n) }
-func (n *ShowRoleGrants) String() string                 { return AsString(n) }
-func (n *ShowRoles) String() string                      { return AsString(n) }
-func (n *ShowSavepointStatus) String() string            { return AsString(n) }
-func (n *ShowSchemas) String() string                    { return AsString(n) }
-func (n *ShowSequences) String() string                  { return AsString(n) }
-func (n *ShowSessions) String() string                   { return AsString(n) }
-func (n *ShowSurvivalGoal) String() string               { return AsString(n) }
-func (n *ShowSyntax) String() string                     { return AsString(n) }
-func (n *ShowTableStats) String() string                 { return AsString(n) }
-func (n *ShowTables) String() string                     { return AsString(n) }
-func (n *ShowTypes) String() string                      { return AsString(n) }
-func (n *ShowTraceForSession) String() string            { return AsString(n) }
-func (n *ShowTransactionStatus) String() string          { return AsString(n) }
-func (n *ShowTransactions) String() string               { return AsString(n) }
-func (n *ShowTransferState) String() string              { return AsString(n) }
-func (n *ShowUsers) String() string                      { return AsString(n) }
-func (n *ShowVar) String() string                        { return AsString(n) }
-func (n *ShowZoneConfig) String() string                 { return AsString(n) }
-func (n *ShowFingerprints) String() string               { return AsString(n) }
-func (n *ShowDefaultPrivileges) String() string          { return AsString(n) }
-func (n *ShowCompletions) String() string                { return AsString(n) }
-func (n *Split) String() string                          { return AsString(n) }
-func (n *StreamIngestion) String() string                { return AsString(n) }
-func (n *Unsplit) String() string                        { return AsString(n) }
-func (n *Truncate) String() string                       { return AsString(n) }
-func (n *UnionClause) String() string                    { return AsString(n) }
-func (n *Update) String() string                         { return AsString(n) }
-func (n *ValuesClause) String() string                   { return AsString(n) }
+func (n *AlterChangefeed) String() string                  { return AsString(n) }
+func (n *AlterChangefeedCmds) String() string              { return AsString(n) }
+func (n *AlterBackup) String() string                      { return AsString(n) }
+func (n *AlterIndex) String() string                       { return AsString(n) }
+func (n *AlterDatabaseOwner) String() string               { return AsString(n) }
+func (n *AlterDatabaseAddRegion) String() string           { return AsString(n) }
+func (n *AlterDatabaseDropRegion) String() string          { return AsString(n) }
+func (n *AlterDatabaseSurvivalGoal) String() string        { return AsString(n) }
+func (n *AlterDatabasePlacement) String() string           { return AsString(n) }
+func (n *AlterDatabasePrimaryRegion) String() string       { return AsString(n) }
+func (n *AlterDatabaseAddSuperRegion) String() string      { return AsString(n) }
+func (n *AlterDatabaseDropSuperRegion) String() string     { return AsString(n) }
+func (n *AlterDatabaseAlterSuperRegion) String() string    { return AsString(n) }
+func (n *AlterDatabaseSecondaryRegion) String() string     { return AsString(n) }
+func (n *AlterDatabaseDropSecondaryRegion) String() string { return AsString(n) }
+func (n *AlterDatabaseRenameRegion) String() string        { return AsString(n) }
+func (n *AlterDatabaseToMultiRegion) String() string       { return AsString(n) }
+func (n *AlterDatabaseRegionReplicas) String() string      { return AsString(n) }
+func (n *AlterDatabaseResetPrimaryRegion) String() string  { return AsString(n) }
+func (n *AlterDatabaseDefaultLocality) String() string     { return AsString(n) }
+func (n *AlterDefaultPrivileges) String() string           { return AsString(n) }
+func (n *AlterSchema) String() string                      { return AsString(n) }
+func (n *AlterTable) String() string                       { return AsString(n) }
+func (n *AlterTableCmds) String() string                   { return AsString(n) }
+func (n *AlterTableAddColumn) String() string              { return AsString(n) }
+func (n *AlterTableAddConstraint) String() string          { return AsString(n) }
+func (n *AlterTableAlterColumnType) String() string        { return AsString(n) }
+func (n *AlterTableDropColumn) String() string             { return AsString(n) }
+func (n *AlterTableDropConstraint) String() string         { return AsString(n) }
+func (n *AlterTableDropNotNull) String() string            { return AsString(n) }
+func (n *AlterTableDropStored) String() string             { return AsString(n) }
+func (n *AlterTableLocality) String() string               { return AsString(n) }
+func (n *AlterTableSetDefault) String() string             { return AsString(n) }
+func (n *AlterTableSetVisible) String() string             { return AsString(n) }
+func (n *AlterTableSetNotNull) String() string             { return AsString(n) }
+func (n *AlterTableOwner) String() string                  { return AsString(n) }
+func (n *AlterTableSetSchema) String() string              { return AsString(n) }
+func (n *AlterTenantSetClusterSetting) String() string     { return AsString(n) }
+func (n *AlterType) String() string                        { return AsString(n) }
+func (n *AlterRole) String() string                        { return AsString(n) }
+func (n *AlterRoleSet) String() string                     { return AsString(n) }
+func (n *RenameRole) String() string                       { return AsString(n) }
+func (n *AlterProfile) String() string                     { return AsString(n) }
+func (n *AlterSequence) String() string                    { return AsString(n) }
+func (n *Analyze) String() string                          { return AsString(n) }
+func (n *Backup) String() string                           { return AsString(n) }
+func (n *BeginTransaction) String() string                 { return AsString(n) }
+func (n *ControlJobs) String() string                      { return AsString(n) }
+func (n *ControlSchedules) String() string                 { return AsString(n) }
+func (n *ControlJobsForSchedules) String() string          { return AsString(n) }
+func (n *ControlJobsOfType) String() string                { return AsString(n) }
+func (n *CancelQueries) String() string                    { return AsString(n) }
+func (n *CancelSessions) String() string                   { return AsString(n) }
+func (n *CannedOptPlan) String() string                    { return AsString(n) }
+func (n *CloseCursor) String() string                      { return AsString(n) }
+func (n *CommentOnColumn) String() string                  { return AsString(n) }
+func (n *CommentOnConstraint) String() string              { return AsString(n) }
+func (n *CommentOnDatabase) String() string                { return AsString(n) }
+func (n *CommentOnRegion) String() string                  { return AsString(n) }
+func (n *CommentOnSchema) String() string                  { return AsString(n) }
+func (n *CommentOnIndex) String() string                   { return AsString(n) }
+func (n *CommentOnTable) String() string                   { return AsString(n) }
+func (n *CommitTransaction) String() string                { return AsString(n) }
+func (n *CopyFrom) String() string                         { return AsString(n) }
+func (n *CreateChangefeed) String() string                 { return AsString(n) }
+func (n *CreateDatabase) String() string                   { return AsString(n) }
+func (n *CreateExtension) String() string                  { return AsString(n) }
+func (n *CreateIndex) String() string                      { return AsString(n) }
+func (n *CreateRole) String() string                       { return AsString(n) }
+func (n *CreateProfile) String() string                    { return AsString(n) }
+func (n *CreateTable) String() string                      { return AsString(n) }
+func (n *CreateSchema) String() string                     { return AsString(n) }
+func (n *CreateSequence) String() string                   { return AsString(n) }
+func (n *CreateStats) String() string                      { return AsString(n) }
+func (n *CreateView) String() string                       { return AsString(n) }
+func (n *Deallocate) String() string                       { return AsString(n) }
+func (n *Delete) String() string                           { return AsString(n) }
+func (n *DeclareCursor) String() string                    { return AsString(n) }
+func (n *DropDatabase) String() string                     { return AsString(n) }
+func (n *DropIndex) String() string                        { return AsString(n) }
+func (n *DropOwnedBy) String() string                      { return AsString(n) }
+func (n *DropSchema) String() string                       { return AsString(n) }
+func (n *DropSequence) String() string                     { return AsString(n) }
+func (n *DropTable) String() string                        { return AsString(n) }
+func (n *DropType) String() string                         { return AsString(n) }
+func (n *DropView) String() string                         { return AsString(n) }
+func (n *DropRole) String() string                         { return AsString(n) }
+func (n *DropProfile) String() string                      { return AsString(n) }
+func (n *Execute) String() string                          { return AsString(n) }
+func (n *Explain) String() string                          { return AsString(n) }
+func (n *ExplainAnalyze) String() string                   { return AsString(n) }
+func (n *Export) String() string                           { return AsString(n) }
+func (n *FetchCursor) String() string                      { return AsString(n) }
+func (n *Grant) String() string                            { return AsString(n) }
+func (n *GrantRole) String() string                        { return AsString(n) }
+func (n *MoveCursor) String() string                       { return AsString(n) }
+func (n *Insert) String() string                           { return AsString(n) }
+func (n *Import) String() string                           { return AsString(n) }
+func (n *ParenSelect) String() string                      { return AsString(n) }
+func (n *Prepare) String() string                          { return AsString(n) }
+func (n *ReassignOwnedBy) String() string                  { return AsString(n) }
+func (n *ReleaseSavepoint) String() string                 { return AsString(n) }
+func (n *Relocate) String() string                         { return AsString(n) }
+func (n *RelocateRange) String() string                    { return AsString(n) }
+func (n *RefreshMaterializedView) String() string          { return AsString(n) }
+func (n *RenameColumn) String() string                     { return AsString(n) }
+func (n *RenameDatabase) String() string                   { return AsString(n) }
+func (n *ReparentDatabase) String() string                 { return AsString(n) }
+func (n *ReplicationStream) String() string                { return AsString(n) }
+func (n *RenameIndex) String() string                      { return AsString(n) }
+func (n *RenameTable) String() string                      { return AsString(n) }
+func (n *Restore) String() string                          { return AsString(n) }
+func (n *Revoke) String() string                           { return AsString(n) }
+func (n *RevokeRole) String() string                       { return AsString(n) }
+func (n *RollbackToSavepoint) String() string              { return AsString(n) }
+func (n *RollbackTransaction) String() string              { return AsString(n) }
+func (n *Savepoint) String() string                        { return AsString(n) }
+func (n *Scatter) String() string                          { return AsString(n) }
+func (n *ScheduledBackup) String() string                  { return AsString(n) }
+func (n *Scrub) String() string                            { return AsString(n) }
+func (n *Select) String() string                           { return AsString(n) }
+func (n *SelectClause) String() string                     { return AsString(n) }
+func (n *SetClusterSetting) String() string                { return AsString(n) }
+func (n *SetZoneConfig) String() string                    { return AsString(n) }
+func (n *SetSessionAuthorizationDefault) String() string   { return AsString(n) }
+func (n *SetSessionCharacteristics) String() string        { return AsString(n) }
+func (n *SetTransaction) String() string                   { return AsString(n) }
+func (n *SetTracing) String() string                       { return AsString(n) }
+func (n *SetVar) String() string                           { return AsString(n) }
+func (n *ShowAuthenticationCache) String() string          { return AsString(n) }
+func (n *ShowBackup) String() string                       { return AsString(n) }
+func (n *ShowClusterSetting) String() string               { return AsString(n) }
+func (n *ShowClusterSettingList) String() string           { return AsString(n) }
+func (n *ShowTenantClusterSetting) String() string         { return AsString(n) }
+func (n *ShowTenantClusterSettingList) String() string     { return AsString(n) }
+func (n *ShowColumns) String() string                      { return AsString(n) }
+func (n *ShowConstraints) String() string                  { return AsString(n) }
+func (n *ShowCreate) String() string                       { return AsString(n) }
+func (node *ShowCreateAllRoles) String() string            { return AsString(node) }
+func (node *ShowCreateAllSchemas) String() string          { return AsString(node) }
+func (node *ShowCreateAllTables) String() string           { return AsString(node) }
+func (node *ShowCreateAllTypes) String() string            { return AsString(node) }
+func (n *ShowCreateSchedules) String() string              { return AsString(n) }
+func (n *ShowDatabases) String() string                    { return AsString(n) }
+func (n *ShowDatabaseIndexes) String() string              { return AsString(n) }
+func (n *ShowDefaultSessionVariables) String() string      { return AsString(n) }
+func (n *ShowEnums) String() string                        { return AsString(n) }
+func (n *ShowFullTableScans) String() string               { return AsString(n) }
+func (n *ShowGrants) String() string                       { return AsString(n) }
+func (n *ShowHBARules) String() string                     { return AsString(n) }
+func (n *ShowHistogram) String() string                    { return AsString(n) }
+func (n *ShowSchedules) String() string                    { return AsString(n) }
+func (n *ShowIndexes) String() string                      { return AsString(n) }
+func (n *ShowJobs) String() string                         { return AsString(n) }
+func (n *ShowChangefeedJobs) String() string               { return AsString(n) }
+func (n *ShowLastQueryStatistics) String() string          { return AsString(n) }
+func (n *ShowPartitions) String() string                   { return AsString(n) }
+func (n *ShowQueries) String() string                      { return AsString(n) }
+func (n *ShowRanges) String() string                       { return AsString(n) }
+func (n *ShowRangeForRow) String() string                  { return AsString(n) }
+func (n *ShowRegions) String() string                      { return AsString(n) }
+func (n *ShowRoleGrants) String() string                   { return AsString(n) }
+func (n *ShowRoles) String() string                        { return AsString(n) }
+func (n *ShowSavepointStatus) String() string              { return AsString(n) }
+func (n *ShowSchemas) String() string                      { return AsString(n) }
+func (n *ShowSequences) String() string                    { return AsString(n) }
+func (n *ShowSessions) String() string                     { return AsString(n) }
+func (n *ShowSettingProvenance) String() string            { return AsString(n) }
+func (n *ShowSurvivalGoal) String() string                 { return AsString(n) }
+func (n *ShowSyntax) String() string                       { return AsString(n) }
+func (n *ShowTableStats) String() string                   { return AsString(n) }
+func (n *ShowTables) String() string                       { return AsString(n) }
+func (n *ShowTypes) String() string                        { return AsString(n) }
+func (n *ShowTraceForSession) String() string              { return AsString(n) }
+func (n *ShowTransactionStatus) String() string            { return AsString(n) }
+func (n *ShowTransactions) String() string                 { return AsString(n) }
+func (n *ShowTransferState) String() string                { return AsString(n) }
+func (n *ShowUsers) String() string                        { return AsString(n) }
+func (n *ShowVar) String() string                          { return AsString(n) }
+func (n *ShowZoneConfig) String() string                   { return AsString(n) }
+func (n *ShowFingerprints) String() string                 { return AsString(n) }
+func (n *ShowDefaultPrivileges) String() string            { return AsString(n) }
+func (n *ShowCompletions) String() string                  { return AsString(n) }
+func (n *Split) String() string                            { return AsString(n) }
+func (n *StreamIngestion) String() string                  { return AsString(n) }
+func (n *Unsplit) String() string                          { return AsString(n) }
+func (n *Truncate) String() string                         { return AsString(n) }
+func (n *UnionClause) String() string                      { return AsString(n) }
+func (n *Update) String() string                           { return AsString(n) }
+func (n *ValuesClause) String() string                     { return AsString(n) }
@@ -0,0 +1,1755 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Code generated by generate_stmt_visitor.go. DO NOT EDIT.
+
+package tree
+
+import "github.com/cockroachdb/errors"
+
+// StatementVisitor is a visitor for Statement nodes. Each method receives a
+// statement of the corresponding type and returns the statement which should
+// replace it, which is usually the statement itself. Implementations which
+// are only interested in a handful of statement types can embed
+// StatementVisitorBase.
+type StatementVisitor interface {
+	VisitAlterChangefeed(*AlterChangefeed) (Statement, error)
+	VisitAlterBackup(*AlterBackup) (Statement, error)
+	VisitAlterDatabaseOwner(*AlterDatabaseOwner) (Statement, error)
+	VisitAlterDatabaseAddRegion(*AlterDatabaseAddRegion) (Statement, error)
+	VisitAlterDatabaseDropRegion(*AlterDatabaseDropRegion) (Statement, error)
+	VisitAlterDatabasePrimaryRegion(*AlterDatabasePrimaryRegion) (Statement, error)
+	VisitAlterDatabaseSurvivalGoal(*AlterDatabaseSurvivalGoal) (Statement, error)
+	VisitAlterDatabasePlacement(*AlterDatabasePlacement) (Statement, error)
+	VisitAlterDatabaseAddSuperRegion(*AlterDatabaseAddSuperRegion) (Statement, error)
+	VisitAlterDatabaseDropSuperRegion(*AlterDatabaseDropSuperRegion) (Statement, error)
+	VisitAlterDatabaseAlterSuperRegion(*AlterDatabaseAlterSuperRegion) (Statement, error)
+	VisitAlterDatabaseSecondaryRegion(*AlterDatabaseSecondaryRegion) (Statement, error)
+	VisitAlterDatabaseDropSecondaryRegion(*AlterDatabaseDropSecondaryRegion) (Statement, error)
+	VisitAlterDatabaseRenameRegion(*AlterDatabaseRenameRegion) (Statement, error)
+	VisitAlterDatabaseToMultiRegion(*AlterDatabaseToMultiRegion) (Statement, error)
+	VisitAlterDatabaseRegionReplicas(*AlterDatabaseRegionReplicas) (Statement, error)
+	VisitAlterDatabaseResetPrimaryRegion(*AlterDatabaseResetPrimaryRegion) (Statement, error)
+	VisitAlterDatabaseDefaultLocality(*AlterDatabaseDefaultLocality) (Statement, error)
+	VisitAlterDefaultPrivileges(*AlterDefaultPrivileges) (Statement, error)
+	VisitAlterIndex(*AlterIndex) (Statement, error)
+	VisitAlterTable(*AlterTable) (Statement, error)
+	VisitAlterTableLocality(*AlterTableLocality) (Statement, error)
+	VisitAlterTableOwner(*AlterTableOwner) (Statement, error)
+	VisitAlterTableSetSchema(*AlterTableSetSchema) (Statement, error)
+	VisitAlterSchema(*AlterSchema) (Statement, error)
+	VisitAlterTenantSetClusterSetting(*AlterTenantSetClusterSetting) (Statement, error)
+	VisitAlterType(*AlterType) (Statement, error)
+	VisitAlterSequence(*AlterSequence) (Statement, error)
+	VisitAlterRole(*AlterRole) (Statement, error)
+	VisitAlterRoleSet(*AlterRoleSet) (Statement, error)
+	VisitRenameRole(*RenameRole) (Statement, error)
+	VisitAlterProfile(*AlterProfile) (Statement, error)
+	VisitAnalyze(*Analyze) (Statement, error)
+	VisitBackup(*Backup) (Statement, error)
+	VisitScheduledBackup(*ScheduledBackup) (Statement, error)
+	VisitBeginTransaction(*BeginTransaction) (Statement, error)
+	VisitControlJobs(*ControlJobs) (Statement, error)
+	VisitControlSchedules(*ControlSchedules) (Statement, error)
+	VisitControlJobsForSchedules(*ControlJobsForSchedules) (Statement, error)
+	VisitControlJobsOfType(*ControlJobsOfType) (Statement, error)
+	VisitCancelQueries(*CancelQueries) (Statement, error)
+	VisitCancelSessions(*CancelSessions) (Statement, error)
+	VisitCannedOptPlan(*CannedOptPlan) (Statement, error)
+	VisitCloseCursor(*CloseCursor) (Statement, error)
+	VisitCommentOnColumn(*CommentOnColumn) (Statement, error)
+	VisitCommentOnConstraint(*CommentOnConstraint) (Statement, error)
+	VisitCommentOnDatabase(*CommentOnDatabase) (Statement, error)
+	VisitCommentOnRegion(*CommentOnRegion) (Statement, error)
+	VisitCommentOnSchema(*CommentOnSchema) (Statement, error)
+	VisitCommentOnIndex(*CommentOnIndex) (Statement, error)
+	VisitCommentOnTable(*CommentOnTable) (Statement, error)
+	VisitCommitTransaction(*CommitTransaction) (Statement, error)
+	VisitCopyFrom(*CopyFrom) (Statement, error)
+	VisitCreateChangefeed(*CreateChangefeed) (Statement, error)
+	VisitCreateDatabase(*CreateDatabase) (Statement, error)
+	VisitCreateExtension(*CreateExtension) (Statement, error)
+	VisitCreateIndex(*CreateIndex) (Statement, error)
+	VisitCreateSchema(*CreateSchema) (Statement, error)
+	VisitCreateTable(*CreateTable) (Statement, error)
+	VisitCreateType(*CreateType) (Statement, error)
+	VisitCreateRole(*CreateRole) (Statement, error)
+	VisitCreateProfile(*CreateProfile) (Statement, error)
+	VisitCreateView(*CreateView) (Statement, error)
+	VisitCreateSequence(*CreateSequence) (Statement, error)
+	VisitCreateStats(*CreateStats) (Statement, error)
+	VisitDeallocate(*Deallocate) (Statement, error)
+	VisitDiscard(*Discard) (Statement, error)
+	VisitDeclareCursor(*DeclareCursor) (Statement, error)
+	VisitDelete(*Delete) (Statement, error)
+	VisitDropDatabase(*DropDatabase) (Statement, error)
+	VisitDropIndex(*DropIndex) (Statement, error)
+	VisitDropTable(*DropTable) (Statement, error)
+	VisitDropView(*DropView) (Statement, error)
+	VisitDropSequence(*DropSequence) (Statement, error)
+	VisitDropRole(*DropRole) (Statement, error)
+	VisitDropProfile(*DropProfile) (Statement, error)
+	VisitDropType(*DropType) (Statement, error)
+	VisitDropSchema(*DropSchema) (Statement, error)
+	VisitExecute(*Execute) (Statement, error)
+	VisitExplain(*Explain) (Statement, error)
+	VisitExplainAnalyze(*ExplainAnalyze) (Statement, error)
+	VisitExport(*Export) (Statement, error)
+	VisitFetchCursor(*FetchCursor) (Statement, error)
+	VisitMoveCursor(*MoveCursor) (Statement, error)
+	VisitGrant(*Grant) (Statement, error)
+	VisitGrantRole(*GrantRole) (Statement, error)
+	VisitInsert(*Insert) (Statement, error)
+	VisitImport(*Import) (Statement, error)
+	VisitParenSelect(*ParenSelect) (Statement, error)
+	VisitPrepare(*Prepare) (Statement, error)
+	VisitReassignOwnedBy(*ReassignOwnedBy) (Statement, error)
+	VisitDropOwnedBy(*DropOwnedBy) (Statement, error)
+	VisitRefreshMaterializedView(*RefreshMaterializedView) (Statement, error)
+	VisitReleaseSavepoint(*ReleaseSavepoint) (Statement, error)
+	VisitRenameColumn(*RenameColumn) (Statement, error)
+	VisitRenameDatabase(*RenameDatabase) (Statement, error)
+	VisitReparentDatabase(*ReparentDatabase) (Statement, error)
+	VisitRenameIndex(*RenameIndex) (Statement, error)
+	VisitRenameTable(*RenameTable) (Statement, error)
+	VisitRelocate(*Relocate) (Statement, error)
+	VisitRelocateRange(*RelocateRange) (Statement, error)
+	VisitReplicationStream(*ReplicationStream) (Statement, error)
+	VisitRestore(*Restore) (Statement, error)
+	VisitRevoke(*Revoke) (Statement, error)
+	VisitRevokeRole(*RevokeRole) (Statement, error)
+	VisitRollbackToSavepoint(*RollbackToSavepoint) (Statement, error)
+	VisitRollbackTransaction(*RollbackTransaction) (Statement, error)
+	VisitSavepoint(*Savepoint) (Statement, error)
+	VisitScatter(*Scatter) (Statement, error)
+	VisitScrub(*Scrub) (Statement, error)
+	VisitSelect(*Select) (Statement, error)
+	VisitSelectClause(*SelectClause) (Statement, error)
+	VisitSetVar(*SetVar) (Statement, error)
+	VisitSetClusterSetting(*SetClusterSetting) (Statement, error)
+	VisitSetTransaction(*SetTransaction) (Statement, error)
+	VisitSetTracing(*SetTracing) (Statement, error)
+	VisitSetZoneConfig(*SetZoneConfig) (Statement, error)
+	VisitSetSessionAuthorizationDefault(*SetSessionAuthorizationDefault) (Statement, error)
+	VisitSetSessionCharacteristics(*SetSessionCharacteristics) (Statement, error)
+	VisitShowVar(*ShowVar) (Statement, error)
+	VisitShowClusterSetting(*ShowClusterSetting) (Statement, error)
+	VisitShowClusterSettingList(*ShowClusterSettingList) (Statement, error)
+	VisitShowTenantClusterSetting(*ShowTenantClusterSetting) (Statement, error)
+	VisitShowTenantClusterSettingList(*ShowTenantClusterSettingList) (Statement, error)
+	VisitShowColumns(*ShowColumns) (Statement, error)
+	VisitShowCreate(*ShowCreate) (Statement, error)
+	VisitShowCreateAllRoles(*ShowCreateAllRoles) (Statement, error)
+	VisitShowCreateAllSchemas(*ShowCreateAllSchemas) (Statement, error)
+	VisitShowCreateAllTables(*ShowCreateAllTables) (Statement, error)
+	VisitShowCreateAllTypes(*ShowCreateAllTypes) (Statement, error)
+	VisitShowCreateSchedules(*ShowCreateSchedules) (Statement, error)
+	VisitShowBackup(*ShowBackup) (Statement, error)
+	VisitShowDatabases(*ShowDatabases) (Statement, error)
+	VisitShowEnums(*ShowEnums) (Statement, error)
+	VisitShowTypes(*ShowTypes) (Statement, error)
+	VisitShowTraceForSession(*ShowTraceForSession) (Statement, error)
+	VisitShowGrants(*ShowGrants) (Statement, error)
+	VisitShowDatabaseIndexes(*ShowDatabaseIndexes) (Statement, error)
+	VisitShowIndexes(*ShowIndexes) (Statement, error)
+	VisitShowPartitions(*ShowPartitions) (Statement, error)
+	VisitShowQueries(*ShowQueries) (Statement, error)
+	VisitShowJobs(*ShowJobs) (Statement, error)
+	VisitShowChangefeedJobs(*ShowChangefeedJobs) (Statement, error)
+	VisitShowRoleGrants(*ShowRoleGrants) (Statement, error)
+	VisitShowSessions(*ShowSessions) (Statement, error)
+	VisitShowTableStats(*ShowTableStats) (Statement, error)
+	VisitShowHistogram(*ShowHistogram) (Statement, error)
+	VisitShowSchedules(*ShowSchedules) (Statement, error)
+	VisitShowSettingProvenance(*ShowSettingProvenance) (Statement, error)
+	VisitShowSyntax(*ShowSyntax) (Statement, error)
+	VisitShowTransactionStatus(*ShowTransactionStatus) (Statement, error)
+	VisitShowTransferState(*ShowTransferState) (Statement, error)
+	VisitShowAuthenticationCache(*ShowAuthenticationCache) (Statement, error)
+	VisitShowDefaultSessionVariables(*ShowDefaultSessionVariables) (Statement, error)
+	VisitShowHBARules(*ShowHBARules) (Statement, error)
+	VisitShowSavepointStatus(*ShowSavepointStatus) (Statement, error)
+	VisitShowLastQueryStatistics(*ShowLastQueryStatistics) (Statement, error)
+	VisitShowUsers(*ShowUsers) (Statement, error)
+	VisitShowFullTableScans(*ShowFullTableScans) (Statement, error)
+	VisitShowRoles(*ShowRoles) (Statement, error)
+	VisitShowZoneConfig(*ShowZoneConfig) (Statement, error)
+	VisitShowRanges(*ShowRanges) (Statement, error)
+	VisitShowRangeForRow(*ShowRangeForRow) (Statement, error)
+	VisitShowSurvivalGoal(*ShowSurvivalGoal) (Statement, error)
+	VisitShowRegions(*ShowRegions) (Statement, error)
+	VisitShowFingerprints(*ShowFingerprints) (Statement, error)
+	VisitShowConstraints(*ShowConstraints) (Statement, error)
+	VisitShowTables(*ShowTables) (Statement, error)
+	VisitShowTransactions(*ShowTransactions) (Statement, error)
+	VisitShowSchemas(*ShowSchemas) (Statement, error)
+	VisitShowSequences(*ShowSequences) (Statement, error)
+	VisitShowDefaultPrivileges(*ShowDefaultPrivileges) (Statement, error)
+	VisitShowCompletions(*ShowCompletions) (Statement, error)
+	VisitSplit(*Split) (Statement, error)
+	VisitStreamIngestion(*StreamIngestion) (Statement, error)
+	VisitUnsplit(*Unsplit) (Statement, error)
+	VisitTruncate(*Truncate) (Statement, error)
+	VisitUpdate(*Update) (Statement, error)
+	VisitUnionClause(*UnionClause) (Statement, error)
+	VisitValuesClause(*ValuesClause) (Statement, error)
+}
+
+// WalkStatement dispatches stmt to the method of v corresponding to its type
+// and returns the statement returned by that method. The statements nested
+// inside of stmt (for example the statement being explained by an EXPLAIN or
+// the query of a CREATE TABLE AS) are walked first and replaced in place by
+// the statements returned for them, so the method for stmt sees them
+// rewritten.
+func WalkStatement(v StatementVisitor, stmt Statement) (Statement, error) {
+	switch t := stmt.(type) {
+	case *AlterChangefeed:
+		return v.VisitAlterChangefeed(t)
+	case *AlterBackup:
+		return v.VisitAlterBackup(t)
+	case *AlterDatabaseOwner:
+		return v.VisitAlterDatabaseOwner(t)
+	case *AlterDatabaseAddRegion:
+		return v.VisitAlterDatabaseAddRegion(t)
+	case *AlterDatabaseDropRegion:
+		return v.VisitAlterDatabaseDropRegion(t)
+	case *AlterDatabasePrimaryRegion:
+		return v.VisitAlterDatabasePrimaryRegion(t)
+	case *AlterDatabaseSurvivalGoal:
+		return v.VisitAlterDatabaseSurvivalGoal(t)
+	case *AlterDatabasePlacement:
+		return v.VisitAlterDatabasePlacement(t)
+	case *AlterDatabaseAddSuperRegion:
+		return v.VisitAlterDatabaseAddSuperRegion(t)
+	case *AlterDatabaseDropSuperRegion:
+		return v.VisitAlterDatabaseDropSuperRegion(t)
+	case *AlterDatabaseAlterSuperRegion:
+		return v.VisitAlterDatabaseAlterSuperRegion(t)
+	case *AlterDatabaseSecondaryRegion:
+		return v.VisitAlterDatabaseSecondaryRegion(t)
+	case *AlterDatabaseDropSecondaryRegion:
+		return v.VisitAlterDatabaseDropSecondaryRegion(t)
+	case *AlterDatabaseRenameRegion:
+		return v.VisitAlterDatabaseRenameRegion(t)
+	case *AlterDatabaseToMultiRegion:
+		return v.VisitAlterDatabaseToMultiRegion(t)
+	case *AlterDatabaseRegionReplicas:
+		return v.VisitAlterDatabaseRegionReplicas(t)
+	case *AlterDatabaseResetPrimaryRegion:
+		return v.VisitAlterDatabaseResetPrimaryRegion(t)
+	case *AlterDatabaseDefaultLocality:
+		return v.VisitAlterDatabaseDefaultLocality(t)
+	case *AlterDefaultPrivileges:
+		return v.VisitAlterDefaultPrivileges(t)
+	case *AlterIndex:
+		return v.VisitAlterIndex(t)
+	case *AlterTable:
+		return v.VisitAlterTable(t)
+	case *AlterTableLocality:
+		return v.VisitAlterTableLocality(t)
+	case *AlterTableOwner:
+		return v.VisitAlterTableOwner(t)
+	case *AlterTableSetSchema:
+		return v.VisitAlterTableSetSchema(t)
+	case *AlterSchema:
+		return v.VisitAlterSchema(t)
+	case *AlterTenantSetClusterSetting:
+		return v.VisitAlterTenantSetClusterSetting(t)
+	case *AlterType:
+		return v.VisitAlterType(t)
+	case *AlterSequence:
+		return v.VisitAlterSequence(t)
+	case *AlterRole:
+		return v.VisitAlterRole(t)
+	case *AlterRoleSet:
+		if t.SetOrReset != nil {
+			child, err := WalkStatement(v, t.SetOrReset)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := child.(*SetVar)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.SetOrReset with %T", t.SetOrReset, t, child)
+			}
+			t.SetOrReset = c
+		}
+		return v.VisitAlterRoleSet(t)
+	case *RenameRole:
+		return v.VisitRenameRole(t)
+	case *AlterProfile:
+		return v.VisitAlterProfile(t)
+	case *Analyze:
+		return v.VisitAnalyze(t)
+	case *Backup:
+		return v.VisitBackup(t)
+	case *ScheduledBackup:
+		return v.VisitScheduledBackup(t)
+	case *BeginTransaction:
+		return v.VisitBeginTransaction(t)
+	case *ControlJobs:
+		if t.Jobs != nil {
+			child, err := WalkStatement(v, t.Jobs)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := child.(*Select)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.Jobs with %T", t.Jobs, t, child)
+			}
+			t.Jobs = c
+		}
+		return v.VisitControlJobs(t)
+	case *ControlSchedules:
+		if t.Schedules != nil {
+			child, err := WalkStatement(v, t.Schedules)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := child.(*Select)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.Schedules with %T", t.Schedules, t, child)
+			}
+			t.Schedules = c
+		}
+		return v.VisitControlSchedules(t)
+	case *ControlJobsForSchedules:
+		if t.Schedules != nil {
+			child, err := WalkStatement(v, t.Schedules)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := child.(*Select)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.Schedules with %T", t.Schedules, t, child)
+			}
+			t.Schedules = c
+		}
+		return v.VisitControlJobsForSchedules(t)
+	case *ControlJobsOfType:
+		return v.VisitControlJobsOfType(t)
+	case *CancelQueries:
+		if t.Queries != nil {
+			child, err := WalkStatement(v, t.Queries)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := child.(*Select)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.Queries with %T", t.Queries, t, child)
+			}
+			t.Queries = c
+		}
+		return v.VisitCancelQueries(t)
+	case *CancelSessions:
+		if t.Sessions != nil {
+			child, err := WalkStatement(v, t.Sessions)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := child.(*Select)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.Sessions with %T", t.Sessions, t, child)
+			}
+			t.Sessions = c
+		}
+		return v.VisitCancelSessions(t)
+	case *CannedOptPlan:
+		return v.VisitCannedOptPlan(t)
+	case *CloseCursor:
+		return v.VisitCloseCursor(t)
+	case *CommentOnColumn:
+		return v.VisitCommentOnColumn(t)
+	case *CommentOnConstraint:
+		return v.VisitCommentOnConstraint(t)
+	case *CommentOnDatabase:
+		return v.VisitCommentOnDatabase(t)
+	case *CommentOnRegion:
+		return v.VisitCommentOnRegion(t)
+	case *CommentOnSchema:
+		return v.VisitCommentOnSchema(t)
+	case *CommentOnIndex:
+		return v.VisitCommentOnIndex(t)
+	case *CommentOnTable:
+		return v.VisitCommentOnTable(t)
+	case *CommitTransaction:
+		return v.VisitCommitTransaction(t)
+	case *CopyFrom:
+		return v.VisitCopyFrom(t)
+	case *CreateChangefeed:
+		return v.VisitCreateChangefeed(t)
+	case *CreateDatabase:
+		return v.VisitCreateDatabase(t)
+	case *CreateExtension:
+		return v.VisitCreateExtension(t)
+	case *CreateIndex:
+		return v.VisitCreateIndex(t)
+	case *CreateSchema:
+		return v.VisitCreateSchema(t)
+	case *CreateTable:
+		if t.AsSource != nil {
+			child, err := WalkStatement(v, t.AsSource)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := child.(*Select)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.AsSource with %T", t.AsSource, t, child)
+			}
+			t.AsSource = c
+		}
+		return v.VisitCreateTable(t)
+	case *CreateType:
+		return v.VisitCreateType(t)
+	case *CreateRole:
+		return v.VisitCreateRole(t)
+	case *CreateProfile:
+		return v.VisitCreateProfile(t)
+	case *CreateView:
+		if t.AsSource != nil {
+			child, err := WalkStatement(v, t.AsSource)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := child.(*Select)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.AsSource with %T", t.AsSource, t, child)
+			}
+			t.AsSource = c
+		}
+		return v.VisitCreateView(t)
+	case *CreateSequence:
+		return v.VisitCreateSequence(t)
+	case *CreateStats:
+		return v.VisitCreateStats(t)
+	case *Deallocate:
+		return v.VisitDeallocate(t)
+	case *Discard:
+		return v.VisitDiscard(t)
+	case *DeclareCursor:
+		if t.Select != nil {
+			child, err := WalkStatement(v, t.Select)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := child.(*Select)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.Select with %T", t.Select, t, child)
+			}
+			t.Select = c
+		}
+		return v.VisitDeclareCursor(t)
+	case *Delete:
+		return v.VisitDelete(t)
+	case *DropDatabase:
+		return v.VisitDropDatabase(t)
+	case *DropIndex:
+		return v.VisitDropIndex(t)
+	case *DropTable:
+		return v.VisitDropTable(t)
+	case *DropView:
+		return v.VisitDropView(t)
+	case *DropSequence:
+		return v.VisitDropSequence(t)
+	case *DropRole:
+		return v.VisitDropRole(t)
+	case *DropProfile:
+		return v.VisitDropProfile(t)
+	case *DropType:
+		return v.VisitDropType(t)
+	case *DropSchema:
+		return v.VisitDropSchema(t)
+	case *Execute:
+		return v.VisitExecute(t)
+	case *Explain:
+		if t.Statement != nil {
+			child, err := WalkStatement(v, t.Statement)
+			if err != nil {
+				return nil, err
+			}
+			t.Statement = child
+		}
+		return v.VisitExplain(t)
+	case *ExplainAnalyze:
+		if t.Statement != nil {
+			child, err := WalkStatement(v, t.Statement)
+			if err != nil {
+				return nil, err
+			}
+			t.Statement = child
+		}
+		return v.VisitExplainAnalyze(t)
+	case *Export:
+		if t.Query != nil {
+			child, err := WalkStatement(v, t.Query)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := child.(*Select)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.Query with %T", t.Query, t, child)
+			}
+			t.Query = c
+		}
+		return v.VisitExport(t)
+	case *FetchCursor:
+		return v.VisitFetchCursor(t)
+	case *MoveCursor:
+		return v.VisitMoveCursor(t)
+	case *Grant:
+		return v.VisitGrant(t)
+	case *GrantRole:
+		return v.VisitGrantRole(t)
+	case *Insert:
+		if t.Rows != nil {
+			child, err := WalkStatement(v, t.Rows)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := child.(*Select)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.Rows with %T", t.Rows, t, child)
+			}
+			t.Rows = c
+		}
+		return v.VisitInsert(t)
+	case *Import:
+		return v.VisitImport(t)
+	case *ParenSelect:
+		if t.Select != nil {
+			child, err := WalkStatement(v, t.Select)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := child.(*Select)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.Select with %T", t.Select, t, child)
+			}
+			t.Select = c
+		}
+		return v.VisitParenSelect(t)
+	case *Prepare:
+		if t.Statement != nil {
+			child, err := WalkStatement(v, t.Statement)
+			if err != nil {
+				return nil, err
+			}
+			t.Statement = child
+		}
+		return v.VisitPrepare(t)
+	case *ReassignOwnedBy:
+		return v.VisitReassignOwnedBy(t)
+	case *DropOwnedBy:
+		return v.VisitDropOwnedBy(t)
+	case *RefreshMaterializedView:
+		return v.VisitRefreshMaterializedView(t)
+	case *ReleaseSavepoint:
+		return v.VisitReleaseSavepoint(t)
+	case *RenameColumn:
+		return v.VisitRenameColumn(t)
+	case *RenameDatabase:
+		return v.VisitRenameDatabase(t)
+	case *ReparentDatabase:
+		return v.VisitReparentDatabase(t)
+	case *RenameIndex:
+		return v.VisitRenameIndex(t)
+	case *RenameTable:
+		return v.VisitRenameTable(t)
+	case *Relocate:
+		if t.Rows != nil {
+			child, err := WalkStatement(v, t.Rows)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := child.(*Select)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.Rows with %T", t.Rows, t, child)
+			}
+			t.Rows = c
+		}
+		return v.VisitRelocate(t)
+	case *RelocateRange:
+		if t.Rows != nil {
+			child, err := WalkStatement(v, t.Rows)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := child.(*Select)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.Rows with %T", t.Rows, t, child)
+			}
+			t.Rows = c
+		}
+		return v.VisitRelocateRange(t)
+	case *ReplicationStream:
+		return v.VisitReplicationStream(t)
+	case *Restore:
+		return v.VisitRestore(t)
+	case *Revoke:
+		return v.VisitRevoke(t)
+	case *RevokeRole:
+		return v.VisitRevokeRole(t)
+	case *RollbackToSavepoint:
+		return v.VisitRollbackToSavepoint(t)
+	case *RollbackTransaction:
+		return v.VisitRollbackTransaction(t)
+	case *Savepoint:
+		return v.VisitSavepoint(t)
+	case *Scatter:
+		return v.VisitScatter(t)
+	case *Scrub:
+		return v.VisitScrub(t)
+	case *Select:
+		return v.VisitSelect(t)
+	case *SelectClause:
+		return v.VisitSelectClause(t)
+	case *SetVar:
+		return v.VisitSetVar(t)
+	case *SetClusterSetting:
+		return v.VisitSetClusterSetting(t)
+	case *SetTransaction:
+		return v.VisitSetTransaction(t)
+	case *SetTracing:
+		return v.VisitSetTracing(t)
+	case *SetZoneConfig:
+		return v.VisitSetZoneConfig(t)
+	case *SetSessionAuthorizationDefault:
+		return v.VisitSetSessionAuthorizationDefault(t)
+	case *SetSessionCharacteristics:
+		return v.VisitSetSessionCharacteristics(t)
+	case *ShowVar:
+		return v.VisitShowVar(t)
+	case *ShowClusterSetting:
+		return v.VisitShowClusterSetting(t)
+	case *ShowClusterSettingList:
+		return v.VisitShowClusterSettingList(t)
+	case *ShowTenantClusterSetting:
+		return v.VisitShowTenantClusterSetting(t)
+	case *ShowTenantClusterSettingList:
+		return v.VisitShowTenantClusterSettingList(t)
+	case *ShowColumns:
+		return v.VisitShowColumns(t)
+	case *ShowCreate:
+		return v.VisitShowCreate(t)
+	case *ShowCreateAllRoles:
+		return v.VisitShowCreateAllRoles(t)
+	case *ShowCreateAllSchemas:
+		return v.VisitShowCreateAllSchemas(t)
+	case *ShowCreateAllTables:
+		return v.VisitShowCreateAllTables(t)
+	case *ShowCreateAllTypes:
+		return v.VisitShowCreateAllTypes(t)
+	case *ShowCreateSchedules:
+		return v.VisitShowCreateSchedules(t)
+	case *ShowBackup:
+		return v.VisitShowBackup(t)
+	case *ShowDatabases:
+		return v.VisitShowDatabases(t)
+	case *ShowEnums:
+		return v.VisitShowEnums(t)
+	case *ShowTypes:
+		return v.VisitShowTypes(t)
+	case *ShowTraceForSession:
+		return v.VisitShowTraceForSession(t)
+	case *ShowGrants:
+		return v.VisitShowGrants(t)
+	case *ShowDatabaseIndexes:
+		return v.VisitShowDatabaseIndexes(t)
+	case *ShowIndexes:
+		return v.VisitShowIndexes(t)
+	case *ShowPartitions:
+		return v.VisitShowPartitions(t)
+	case *ShowQueries:
+		return v.VisitShowQueries(t)
+	case *ShowJobs:
+		if t.Jobs != nil {
+			child, err := WalkStatement(v, t.Jobs)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := child.(*Select)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.Jobs with %T", t.Jobs, t, child)
+			}
+			t.Jobs = c
+		}
+		if t.Schedules != nil {
+			child, err := WalkStatement(v, t.Schedules)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := child.(*Select)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.Schedules with %T", t.Schedules, t, child)
+			}
+			t.Schedules = c
+		}
+		return v.VisitShowJobs(t)
+	case *ShowChangefeedJobs:
+		if t.Jobs != nil {
+			child, err := WalkStatement(v, t.Jobs)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := child.(*Select)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.Jobs with %T", t.Jobs, t, child)
+			}
+			t.Jobs = c
+		}
+		return v.VisitShowChangefeedJobs(t)
+	case *ShowRoleGrants:
+		return v.VisitShowRoleGrants(t)
+	case *ShowSessions:
+		return v.VisitShowSessions(t)
+	case *ShowTableStats:
+		return v.VisitShowTableStats(t)
+	case *ShowHistogram:
+		return v.VisitShowHistogram(t)
+	case *ShowSchedules:
+		return v.VisitShowSchedules(t)
+	case *ShowSettingProvenance:
+		return v.VisitShowSettingProvenance(t)
+	case *ShowSyntax:
+		return v.VisitShowSyntax(t)
+	case *ShowTransactionStatus:
+		return v.VisitShowTransactionStatus(t)
+	case *ShowTransferState:
+		return v.VisitShowTransferState(t)
+	case *ShowAuthenticationCache:
+		return v.VisitShowAuthenticationCache(t)
+	case *ShowDefaultSessionVariables:
+		return v.VisitShowDefaultSessionVariables(t)
+	case *ShowHBARules:
+		return v.VisitShowHBARules(t)
+	case *ShowSavepointStatus:
+		return v.VisitShowSavepointStatus(t)
+	case *ShowLastQueryStatistics:
+		return v.VisitShowLastQueryStatistics(t)
+	case *ShowUsers:
+		return v.VisitShowUsers(t)
+	case *ShowFullTableScans:
+		return v.VisitShowFullTableScans(t)
+	case *ShowRoles:
+		return v.VisitShowRoles(t)
+	case *ShowZoneConfig:
+		return v.VisitShowZoneConfig(t)
+	case *ShowRanges:
+		return v.VisitShowRanges(t)
+	case *ShowRangeForRow:
+		return v.VisitShowRangeForRow(t)
+	case *ShowSurvivalGoal:
+		return v.VisitShowSurvivalGoal(t)
+	case *ShowRegions:
+		return v.VisitShowRegions(t)
+	case *ShowFingerprints:
+		return v.VisitShowFingerprints(t)
+	case *ShowConstraints:
+		return v.VisitShowConstraints(t)
+	case *ShowTables:
+		return v.VisitShowTables(t)
+	case *ShowTransactions:
+		return v.VisitShowTransactions(t)
+	case *ShowSchemas:
+		return v.VisitShowSchemas(t)
+	case *ShowSequences:
+		return v.VisitShowSequences(t)
+	case *ShowDefaultPrivileges:
+		return v.VisitShowDefaultPrivileges(t)
+	case *ShowCompletions:
+		return v.VisitShowCompletions(t)
+	case *Split:
+		if t.Rows != nil {
+			child, err := WalkStatement(v, t.Rows)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := child.(*Select)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.Rows with %T", t.Rows, t, child)
+			}
+			t.Rows = c
+		}
+		return v.VisitSplit(t)
+	case *StreamIngestion:
+		return v.VisitStreamIngestion(t)
+	case *Unsplit:
+		if t.Rows != nil {
+			child, err := WalkStatement(v, t.Rows)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := child.(*Select)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.Rows with %T", t.Rows, t, child)
+			}
+			t.Rows = c
+		}
+		return v.VisitUnsplit(t)
+	case *Truncate:
+		return v.VisitTruncate(t)
+	case *Update:
+		return v.VisitUpdate(t)
+	case *UnionClause:
+		if t.Left != nil {
+			child, err := WalkStatement(v, t.Left)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := child.(*Select)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.Left with %T", t.Left, t, child)
+			}
+			t.Left = c
+		}
+		if t.Right != nil {
+			child, err := WalkStatement(v, t.Right)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := child.(*Select)
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"cannot replace the %T of %T.Right with %T", t.Right, t, child)
+			}
+			t.Right = c
+		}
+		return v.VisitUnionClause(t)
+	case *ValuesClause:
+		return v.VisitValuesClause(t)
+	default:
+		return nil, errors.AssertionFailedf("unknown statement type %T", stmt)
+	}
+}
+
+// StatementVisitorBase implements StatementVisitor by returning every
+// statement unchanged.
+type StatementVisitorBase struct{}
+
+var _ StatementVisitor = StatementVisitorBase{}
+
+// VisitAlterChangefeed is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterChangefeed(n *AlterChangefeed) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterBackup is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterBackup(n *AlterBackup) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterDatabaseOwner is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterDatabaseOwner(n *AlterDatabaseOwner) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterDatabaseAddRegion is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterDatabaseAddRegion(n *AlterDatabaseAddRegion) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterDatabaseDropRegion is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterDatabaseDropRegion(n *AlterDatabaseDropRegion) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterDatabasePrimaryRegion is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterDatabasePrimaryRegion(n *AlterDatabasePrimaryRegion) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterDatabaseSurvivalGoal is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterDatabaseSurvivalGoal(n *AlterDatabaseSurvivalGoal) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterDatabasePlacement is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterDatabasePlacement(n *AlterDatabasePlacement) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterDatabaseAddSuperRegion is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterDatabaseAddSuperRegion(n *AlterDatabaseAddSuperRegion) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterDatabaseDropSuperRegion is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterDatabaseDropSuperRegion(n *AlterDatabaseDropSuperRegion) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterDatabaseAlterSuperRegion is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterDatabaseAlterSuperRegion(n *AlterDatabaseAlterSuperRegion) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterDatabaseSecondaryRegion is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterDatabaseSecondaryRegion(n *AlterDatabaseSecondaryRegion) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterDatabaseDropSecondaryRegion is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterDatabaseDropSecondaryRegion(n *AlterDatabaseDropSecondaryRegion) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterDatabaseRenameRegion is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterDatabaseRenameRegion(n *AlterDatabaseRenameRegion) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterDatabaseToMultiRegion is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterDatabaseToMultiRegion(n *AlterDatabaseToMultiRegion) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterDatabaseRegionReplicas is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterDatabaseRegionReplicas(n *AlterDatabaseRegionReplicas) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterDatabaseResetPrimaryRegion is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterDatabaseResetPrimaryRegion(n *AlterDatabaseResetPrimaryRegion) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterDatabaseDefaultLocality is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterDatabaseDefaultLocality(n *AlterDatabaseDefaultLocality) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterDefaultPrivileges is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterDefaultPrivileges(n *AlterDefaultPrivileges) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterIndex is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterIndex(n *AlterIndex) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterTable is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterTable(n *AlterTable) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterTableLocality is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterTableLocality(n *AlterTableLocality) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterTableOwner is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterTableOwner(n *AlterTableOwner) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterTableSetSchema is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterTableSetSchema(n *AlterTableSetSchema) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterSchema is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterSchema(n *AlterSchema) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterTenantSetClusterSetting is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterTenantSetClusterSetting(n *AlterTenantSetClusterSetting) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterType is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterType(n *AlterType) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterSequence is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterSequence(n *AlterSequence) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterRole is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterRole(n *AlterRole) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterRoleSet is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterRoleSet(n *AlterRoleSet) (Statement, error) {
+	return n, nil
+}
+
+// VisitRenameRole is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitRenameRole(n *RenameRole) (Statement, error) {
+	return n, nil
+}
+
+// VisitAlterProfile is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAlterProfile(n *AlterProfile) (Statement, error) {
+	return n, nil
+}
+
+// VisitAnalyze is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitAnalyze(n *Analyze) (Statement, error) {
+	return n, nil
+}
+
+// VisitBackup is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitBackup(n *Backup) (Statement, error) {
+	return n, nil
+}
+
+// VisitScheduledBackup is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitScheduledBackup(n *ScheduledBackup) (Statement, error) {
+	return n, nil
+}
+
+// VisitBeginTransaction is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitBeginTransaction(n *BeginTransaction) (Statement, error) {
+	return n, nil
+}
+
+// VisitControlJobs is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitControlJobs(n *ControlJobs) (Statement, error) {
+	return n, nil
+}
+
+// VisitControlSchedules is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitControlSchedules(n *ControlSchedules) (Statement, error) {
+	return n, nil
+}
+
+// VisitControlJobsForSchedules is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitControlJobsForSchedules(n *ControlJobsForSchedules) (Statement, error) {
+	return n, nil
+}
+
+// VisitControlJobsOfType is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitControlJobsOfType(n *ControlJobsOfType) (Statement, error) {
+	return n, nil
+}
+
+// VisitCancelQueries is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCancelQueries(n *CancelQueries) (Statement, error) {
+	return n, nil
+}
+
+// VisitCancelSessions is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCancelSessions(n *CancelSessions) (Statement, error) {
+	return n, nil
+}
+
+// VisitCannedOptPlan is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCannedOptPlan(n *CannedOptPlan) (Statement, error) {
+	return n, nil
+}
+
+// VisitCloseCursor is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCloseCursor(n *CloseCursor) (Statement, error) {
+	return n, nil
+}
+
+// VisitCommentOnColumn is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCommentOnColumn(n *CommentOnColumn) (Statement, error) {
+	return n, nil
+}
+
+// VisitCommentOnConstraint is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCommentOnConstraint(n *CommentOnConstraint) (Statement, error) {
+	return n, nil
+}
+
+// VisitCommentOnDatabase is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCommentOnDatabase(n *CommentOnDatabase) (Statement, error) {
+	return n, nil
+}
+
+// VisitCommentOnRegion is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCommentOnRegion(n *CommentOnRegion) (Statement, error) {
+	return n, nil
+}
+
+// VisitCommentOnSchema is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCommentOnSchema(n *CommentOnSchema) (Statement, error) {
+	return n, nil
+}
+
+// VisitCommentOnIndex is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCommentOnIndex(n *CommentOnIndex) (Statement, error) {
+	return n, nil
+}
+
+// VisitCommentOnTable is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCommentOnTable(n *CommentOnTable) (Statement, error) {
+	return n, nil
+}
+
+// VisitCommitTransaction is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCommitTransaction(n *CommitTransaction) (Statement, error) {
+	return n, nil
+}
+
+// VisitCopyFrom is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCopyFrom(n *CopyFrom) (Statement, error) {
+	return n, nil
+}
+
+// VisitCreateChangefeed is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCreateChangefeed(n *CreateChangefeed) (Statement, error) {
+	return n, nil
+}
+
+// VisitCreateDatabase is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCreateDatabase(n *CreateDatabase) (Statement, error) {
+	return n, nil
+}
+
+// VisitCreateExtension is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCreateExtension(n *CreateExtension) (Statement, error) {
+	return n, nil
+}
+
+// VisitCreateIndex is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCreateIndex(n *CreateIndex) (Statement, error) {
+	return n, nil
+}
+
+// VisitCreateSchema is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCreateSchema(n *CreateSchema) (Statement, error) {
+	return n, nil
+}
+
+// VisitCreateTable is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCreateTable(n *CreateTable) (Statement, error) {
+	return n, nil
+}
+
+// VisitCreateType is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCreateType(n *CreateType) (Statement, error) {
+	return n, nil
+}
+
+// VisitCreateRole is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCreateRole(n *CreateRole) (Statement, error) {
+	return n, nil
+}
+
+// VisitCreateProfile is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCreateProfile(n *CreateProfile) (Statement, error) {
+	return n, nil
+}
+
+// VisitCreateView is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCreateView(n *CreateView) (Statement, error) {
+	return n, nil
+}
+
+// VisitCreateSequence is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCreateSequence(n *CreateSequence) (Statement, error) {
+	return n, nil
+}
+
+// VisitCreateStats is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitCreateStats(n *CreateStats) (Statement, error) {
+	return n, nil
+}
+
+// VisitDeallocate is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitDeallocate(n *Deallocate) (Statement, error) {
+	return n, nil
+}
+
+// VisitDiscard is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitDiscard(n *Discard) (Statement, error) {
+	return n, nil
+}
+
+// VisitDeclareCursor is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitDeclareCursor(n *DeclareCursor) (Statement, error) {
+	return n, nil
+}
+
+// VisitDelete is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitDelete(n *Delete) (Statement, error) {
+	return n, nil
+}
+
+// VisitDropDatabase is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitDropDatabase(n *DropDatabase) (Statement, error) {
+	return n, nil
+}
+
+// VisitDropIndex is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitDropIndex(n *DropIndex) (Statement, error) {
+	return n, nil
+}
+
+// VisitDropTable is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitDropTable(n *DropTable) (Statement, error) {
+	return n, nil
+}
+
+// VisitDropView is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitDropView(n *DropView) (Statement, error) {
+	return n, nil
+}
+
+// VisitDropSequence is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitDropSequence(n *DropSequence) (Statement, error) {
+	return n, nil
+}
+
+// VisitDropRole is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitDropRole(n *DropRole) (Statement, error) {
+	return n, nil
+}
+
+// VisitDropProfile is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitDropProfile(n *DropProfile) (Statement, error) {
+	return n, nil
+}
+
+// VisitDropType is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitDropType(n *DropType) (Statement, error) {
+	return n, nil
+}
+
+// VisitDropSchema is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitDropSchema(n *DropSchema) (Statement, error) {
+	return n, nil
+}
+
+// VisitExecute is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitExecute(n *Execute) (Statement, error) {
+	return n, nil
+}
+
+// VisitExplain is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitExplain(n *Explain) (Statement, error) {
+	return n, nil
+}
+
+// VisitExplainAnalyze is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitExplainAnalyze(n *ExplainAnalyze) (Statement, error) {
+	return n, nil
+}
+
+// VisitExport is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitExport(n *Export) (Statement, error) {
+	return n, nil
+}
+
+// VisitFetchCursor is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitFetchCursor(n *FetchCursor) (Statement, error) {
+	return n, nil
+}
+
+// VisitMoveCursor is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitMoveCursor(n *MoveCursor) (Statement, error) {
+	return n, nil
+}
+
+// VisitGrant is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitGrant(n *Grant) (Statement, error) {
+	return n, nil
+}
+
+// VisitGrantRole is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitGrantRole(n *GrantRole) (Statement, error) {
+	return n, nil
+}
+
+// VisitInsert is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitInsert(n *Insert) (Statement, error) {
+	return n, nil
+}
+
+// VisitImport is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitImport(n *Import) (Statement, error) {
+	return n, nil
+}
+
+// VisitParenSelect is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitParenSelect(n *ParenSelect) (Statement, error) {
+	return n, nil
+}
+
+// VisitPrepare is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitPrepare(n *Prepare) (Statement, error) {
+	return n, nil
+}
+
+// VisitReassignOwnedBy is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitReassignOwnedBy(n *ReassignOwnedBy) (Statement, error) {
+	return n, nil
+}
+
+// VisitDropOwnedBy is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitDropOwnedBy(n *DropOwnedBy) (Statement, error) {
+	return n, nil
+}
+
+// VisitRefreshMaterializedView is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitRefreshMaterializedView(n *RefreshMaterializedView) (Statement, error) {
+	return n, nil
+}
+
+// VisitReleaseSavepoint is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitReleaseSavepoint(n *ReleaseSavepoint) (Statement, error) {
+	return n, nil
+}
+
+// VisitRenameColumn is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitRenameColumn(n *RenameColumn) (Statement, error) {
+	return n, nil
+}
+
+// VisitRenameDatabase is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitRenameDatabase(n *RenameDatabase) (Statement, error) {
+	return n, nil
+}
+
+// VisitReparentDatabase is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitReparentDatabase(n *ReparentDatabase) (Statement, error) {
+	return n, nil
+}
+
+// VisitRenameIndex is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitRenameIndex(n *RenameIndex) (Statement, error) {
+	return n, nil
+}
+
+// VisitRenameTable is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitRenameTable(n *RenameTable) (Statement, error) {
+	return n, nil
+}
+
+// VisitRelocate is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitRelocate(n *Relocate) (Statement, error) {
+	return n, nil
+}
+
+// VisitRelocateRange is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitRelocateRange(n *RelocateRange) (Statement, error) {
+	return n, nil
+}
+
+// VisitReplicationStream is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitReplicationStream(n *ReplicationStream) (Statement, error) {
+	return n, nil
+}
+
+// VisitRestore is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitRestore(n *Restore) (Statement, error) {
+	return n, nil
+}
+
+// VisitRevoke is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitRevoke(n *Revoke) (Statement, error) {
+	return n, nil
+}
+
+// VisitRevokeRole is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitRevokeRole(n *RevokeRole) (Statement, error) {
+	return n, nil
+}
+
+// VisitRollbackToSavepoint is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitRollbackToSavepoint(n *RollbackToSavepoint) (Statement, error) {
+	return n, nil
+}
+
+// VisitRollbackTransaction is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitRollbackTransaction(n *RollbackTransaction) (Statement, error) {
+	return n, nil
+}
+
+// VisitSavepoint is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitSavepoint(n *Savepoint) (Statement, error) {
+	return n, nil
+}
+
+// VisitScatter is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitScatter(n *Scatter) (Statement, error) {
+	return n, nil
+}
+
+// VisitScrub is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitScrub(n *Scrub) (Statement, error) {
+	return n, nil
+}
+
+// VisitSelect is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitSelect(n *Select) (Statement, error) {
+	return n, nil
+}
+
+// VisitSelectClause is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitSelectClause(n *SelectClause) (Statement, error) {
+	return n, nil
+}
+
+// VisitSetVar is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitSetVar(n *SetVar) (Statement, error) {
+	return n, nil
+}
+
+// VisitSetClusterSetting is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitSetClusterSetting(n *SetClusterSetting) (Statement, error) {
+	return n, nil
+}
+
+// VisitSetTransaction is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitSetTransaction(n *SetTransaction) (Statement, error) {
+	return n, nil
+}
+
+// VisitSetTracing is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitSetTracing(n *SetTracing) (Statement, error) {
+	return n, nil
+}
+
+// VisitSetZoneConfig is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitSetZoneConfig(n *SetZoneConfig) (Statement, error) {
+	return n, nil
+}
+
+// VisitSetSessionAuthorizationDefault is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitSetSessionAuthorizationDefault(n *SetSessionAuthorizationDefault) (Statement, error) {
+	return n, nil
+}
+
+// VisitSetSessionCharacteristics is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitSetSessionCharacteristics(n *SetSessionCharacteristics) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowVar is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowVar(n *ShowVar) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowClusterSetting is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowClusterSetting(n *ShowClusterSetting) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowClusterSettingList is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowClusterSettingList(n *ShowClusterSettingList) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowTenantClusterSetting is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowTenantClusterSetting(n *ShowTenantClusterSetting) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowTenantClusterSettingList is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowTenantClusterSettingList(n *ShowTenantClusterSettingList) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowColumns is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowColumns(n *ShowColumns) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowCreate is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowCreate(n *ShowCreate) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowCreateAllRoles is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowCreateAllRoles(n *ShowCreateAllRoles) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowCreateAllSchemas is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowCreateAllSchemas(n *ShowCreateAllSchemas) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowCreateAllTables is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowCreateAllTables(n *ShowCreateAllTables) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowCreateAllTypes is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowCreateAllTypes(n *ShowCreateAllTypes) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowCreateSchedules is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowCreateSchedules(n *ShowCreateSchedules) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowBackup is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowBackup(n *ShowBackup) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowDatabases is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowDatabases(n *ShowDatabases) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowEnums is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowEnums(n *ShowEnums) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowTypes is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowTypes(n *ShowTypes) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowTraceForSession is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowTraceForSession(n *ShowTraceForSession) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowGrants is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowGrants(n *ShowGrants) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowDatabaseIndexes is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowDatabaseIndexes(n *ShowDatabaseIndexes) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowIndexes is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowIndexes(n *ShowIndexes) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowPartitions is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowPartitions(n *ShowPartitions) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowQueries is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowQueries(n *ShowQueries) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowJobs is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowJobs(n *ShowJobs) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowChangefeedJobs is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowChangefeedJobs(n *ShowChangefeedJobs) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowRoleGrants is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowRoleGrants(n *ShowRoleGrants) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowSessions is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowSessions(n *ShowSessions) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowTableStats is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowTableStats(n *ShowTableStats) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowHistogram is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowHistogram(n *ShowHistogram) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowSchedules is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowSchedules(n *ShowSchedules) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowSettingProvenance is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowSettingProvenance(n *ShowSettingProvenance) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowSyntax is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowSyntax(n *ShowSyntax) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowTransactionStatus is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowTransactionStatus(n *ShowTransactionStatus) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowTransferState is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowTransferState(n *ShowTransferState) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowAuthenticationCache is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowAuthenticationCache(n *ShowAuthenticationCache) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowDefaultSessionVariables is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowDefaultSessionVariables(n *ShowDefaultSessionVariables) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowHBARules is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowHBARules(n *ShowHBARules) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowSavepointStatus is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowSavepointStatus(n *ShowSavepointStatus) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowLastQueryStatistics is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowLastQueryStatistics(n *ShowLastQueryStatistics) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowUsers is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowUsers(n *ShowUsers) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowFullTableScans is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowFullTableScans(n *ShowFullTableScans) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowRoles is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowRoles(n *ShowRoles) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowZoneConfig is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowZoneConfig(n *ShowZoneConfig) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowRanges is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowRanges(n *ShowRanges) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowRangeForRow is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowRangeForRow(n *ShowRangeForRow) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowSurvivalGoal is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowSurvivalGoal(n *ShowSurvivalGoal) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowRegions is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowRegions(n *ShowRegions) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowFingerprints is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowFingerprints(n *ShowFingerprints) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowConstraints is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowConstraints(n *ShowConstraints) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowTables is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowTables(n *ShowTables) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowTransactions is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowTransactions(n *ShowTransactions) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowSchemas is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowSchemas(n *ShowSchemas) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowSequences is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowSequences(n *ShowSequences) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowDefaultPrivileges is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowDefaultPrivileges(n *ShowDefaultPrivileges) (Statement, error) {
+	return n, nil
+}
+
+// VisitShowCompletions is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitShowCompletions(n *ShowCompletions) (Statement, error) {
+	return n, nil
+}
+
+// VisitSplit is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitSplit(n *Split) (Statement, error) {
+	return n, nil
+}
+
+// VisitStreamIngestion is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitStreamIngestion(n *StreamIngestion) (Statement, error) {
+	return n, nil
+}
+
+// VisitUnsplit is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitUnsplit(n *Unsplit) (Statement, error) {
+	return n, nil
+}
+
+// VisitTruncate is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitTruncate(n *Truncate) (Statement, error) {
+	return n, nil
+}
+
+// VisitUpdate is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitUpdate(n *Update) (Statement, error) {
+	return n, nil
+}
+
+// VisitUnionClause is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitUnionClause(n *UnionClause) (Statement, error) {
+	return n, nil
+}
+
+// VisitValuesClause is part of the StatementVisitor interface.
+func (StatementVisitorBase) VisitValuesClause(n *ValuesClause) (Statement, error) {
+	return n, nil
+}
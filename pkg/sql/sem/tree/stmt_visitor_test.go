@@ -0,0 +1,181 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// renameDatabaseVisitor rewrites the database targeted by a handful of ALTER
+// DATABASE statements.
+type renameDatabaseVisitor struct {
+	tree.StatementVisitorBase
+	from, to tree.Name
+}
+
+func (v *renameDatabaseVisitor) rename(n *tree.Name) {
+	if *n == v.from {
+		*n = v.to
+	}
+}
+
+func (v *renameDatabaseVisitor) VisitAlterDatabaseAddRegion(
+	n *tree.AlterDatabaseAddRegion,
+) (tree.Statement, error) {
+	v.rename(&n.Name)
+	return n, nil
+}
+
+func (v *renameDatabaseVisitor) VisitAlterDatabaseDropSuperRegion(
+	n *tree.AlterDatabaseDropSuperRegion,
+) (tree.Statement, error) {
+	v.rename(&n.DatabaseName)
+	return n, nil
+}
+
+func (v *renameDatabaseVisitor) VisitAlterDatabaseResetPrimaryRegion(
+	n *tree.AlterDatabaseResetPrimaryRegion,
+) (tree.Statement, error) {
+	// Replace the node rather than modifying it in place.
+	return &tree.AlterDatabaseResetPrimaryRegion{Name: v.to}, nil
+}
+
+func TestWalkStatement(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testCases := []struct {
+		sql      string
+		expected string
+	}{
+		{
+			`ALTER DATABASE db ADD REGION "us-east1"`,
+			`ALTER DATABASE db2 ADD REGION "us-east1"`,
+		},
+		{
+			`ALTER DATABASE other ADD REGION "us-east1"`,
+			`ALTER DATABASE other ADD REGION "us-east1"`,
+		},
+		{
+			`ALTER DATABASE db DROP SUPER REGION "s"`,
+			`ALTER DATABASE db2 DROP SUPER REGION s`,
+		},
+		{
+			`ALTER DATABASE db RESET PRIMARY REGION`,
+			`ALTER DATABASE db2 RESET PRIMARY REGION`,
+		},
+		{
+			// Nested statements are walked as well.
+			`EXPLAIN ALTER DATABASE db ADD REGION "us-east1"`,
+			`EXPLAIN ALTER DATABASE db2 ADD REGION "us-east1"`,
+		},
+		{
+			// Nested statements are replaced by the result of their visit.
+			`EXPLAIN ALTER DATABASE db RESET PRIMARY REGION`,
+			`EXPLAIN ALTER DATABASE db2 RESET PRIMARY REGION`,
+		},
+		{
+			`PREPARE p AS ALTER DATABASE db RESET PRIMARY REGION`,
+			`PREPARE p AS ALTER DATABASE db2 RESET PRIMARY REGION`,
+		},
+		{
+			// Statements without an override are returned unchanged.
+			`ALTER DATABASE db DROP REGION "us-east1"`,
+			`ALTER DATABASE db DROP REGION "us-east1"`,
+		},
+		{
+			`SELECT 1`,
+			`SELECT 1`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.sql, func(t *testing.T) {
+			stmt, err := parser.ParseOne(tc.sql)
+			require.NoError(t, err)
+			v := &renameDatabaseVisitor{from: "db", to: "db2"}
+			res, err := tree.WalkStatement(v, stmt.AST)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, tree.AsString(res))
+		})
+	}
+}
+
+// replaceSelectVisitor replaces every SELECT statement.
+type replaceSelectVisitor struct {
+	tree.StatementVisitorBase
+	replacement tree.Statement
+}
+
+func (v *replaceSelectVisitor) VisitSelect(*tree.Select) (tree.Statement, error) {
+	return v.replacement, nil
+}
+
+func TestWalkStatementNestedSelect(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testCases := []struct {
+		sql         string
+		replacement string
+		expected    string
+		err         string
+	}{
+		{
+			sql:         `EXPLAIN SELECT 1`,
+			replacement: `SELECT 2`,
+			expected:    `EXPLAIN SELECT 2`,
+		},
+		{
+			sql:         `CREATE TABLE t AS SELECT 1`,
+			replacement: `SELECT 2`,
+			expected:    `CREATE TABLE t AS SELECT 2`,
+		},
+		{
+			sql:         `CREATE VIEW v AS SELECT 1`,
+			replacement: `SELECT 2`,
+			expected:    `CREATE VIEW v AS SELECT 2`,
+		},
+		{
+			// Statements nested several levels deep are replaced as well.
+			sql:         `EXPLAIN CREATE TABLE t AS SELECT 1`,
+			replacement: `SELECT 2`,
+			expected:    `EXPLAIN CREATE TABLE t AS SELECT 2`,
+		},
+		{
+			// The query of a CREATE TABLE AS can only be replaced by a SELECT.
+			sql:         `CREATE TABLE t AS SELECT 1`,
+			replacement: `SHOW application_name`,
+			err:         `cannot replace the \*tree.Select of \*tree.CreateTable.AsSource with \*tree.ShowVar`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.sql, func(t *testing.T) {
+			stmt, err := parser.ParseOne(tc.sql)
+			require.NoError(t, err)
+			replacement, err := parser.ParseOne(tc.replacement)
+			require.NoError(t, err)
+			v := &replaceSelectVisitor{replacement: replacement.AST}
+			res, err := tree.WalkStatement(v, stmt.AST)
+			if tc.err != "" {
+				require.Regexp(t, tc.err, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, tree.AsString(res))
+		})
+	}
+}
@@ -28,6 +28,11 @@ type TableName struct {
 
 // Format implements the NodeFormatter interface.
 func (t *TableName) Format(ctx *FmtCtx) {
+	if ctx.nameResolver != nil && !t.ExplicitCatalog {
+		if resolved, ok := ctx.nameResolver.ResolveTableName(t); ok {
+			t = &resolved
+		}
+	}
 	if ctx.tableNameFormatter != nil {
 		ctx.tableNameFormatter(ctx, t)
 		return
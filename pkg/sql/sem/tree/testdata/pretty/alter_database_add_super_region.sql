@@ -0,0 +1 @@
+ALTER DATABASE movr ADD SUPER REGION americas VALUES "us-east1", "us-west1", "ca-central1"
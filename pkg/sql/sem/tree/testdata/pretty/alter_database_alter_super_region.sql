@@ -0,0 +1 @@
+ALTER DATABASE movr ALTER SUPER REGION americas VALUES "us-east1", "us-west1" WITH OVERRIDE
@@ -0,0 +1 @@
+ALTER DATABASE movr DROP SUPER REGION americas WITH OVERRIDE
@@ -0,0 +1 @@
+ALTER DATABASE movr PLACEMENT RESTRICTED EXCEPT TABLES (movr.public.users, rides, promo_codes)
@@ -0,0 +1,100 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// logSessionDefaults is the cluster setting which enables the
+// session_defaults_applied events. They let the operators audit which
+// values the sessions actually received from ALTER ROLE ... SET and the
+// other sources of the defaults, which SHOW SETTING PROVENANCE only shows
+// from within each session.
+var logSessionDefaults = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.log.session_defaults.enabled",
+	"when set, log the values provided for the session variables when SQL sessions "+
+		"are established, along with their sources; at most one event is logged per "+
+		"second on each node",
+	false,
+)
+
+// sessionDefaultsEventInterval is the minimum interval between two
+// session_defaults_applied events on a node.
+const sessionDefaultsEventInterval = time.Second
+
+// sessionDefaultsReporter reports the session defaults applied to the new
+// sessions of a node.
+type sessionDefaultsReporter struct {
+	mu struct {
+		syncutil.Mutex
+		every log.EveryN
+		// skipped is the number of sessions not reported since the last
+		// event.
+		skipped uint64
+	}
+}
+
+// report reports the values provided for the session variables of a new
+// session, unless an event was reported less than
+// sessionDefaultsEventInterval ago or the reports are disabled.
+func (r *sessionDefaultsReporter) report(
+	ctx context.Context, sv *settings.Values, args *SessionArgs,
+) {
+	if !logSessionDefaults.Get(sv) {
+		return
+	}
+	r.mu.Lock()
+	if !r.mu.every.ShouldLog() {
+		r.mu.skipped++
+		r.mu.Unlock()
+		return
+	}
+	skipped := r.mu.skipped
+	r.mu.skipped = 0
+	r.mu.Unlock()
+
+	log.StructuredEvent(ctx, &eventpb.SessionDefaultsApplied{
+		User:          args.User.Normalized(),
+		Defaults:      appliedSessionDefaults(args.DefaultsProvenance),
+		SkippedEvents: skipped,
+	})
+}
+
+// appliedSessionDefaults returns the values in effect among the values
+// provided for the session variables, formatted as
+// `variable=value (source)` and sorted by variable. For each variable, the
+// value in effect is the one of the source with the highest precedence;
+// among the default settings of the roles, it is the first one recorded.
+func appliedSessionDefaults(provenance []SessionDefaultProvenance) []string {
+	applied := make(map[string]SessionDefaultProvenance)
+	for _, entry := range provenance {
+		if prev, ok := applied[entry.Variable]; ok && prev.Source <= entry.Source {
+			continue
+		}
+		applied[entry.Variable] = entry
+	}
+	defaults := make([]string, 0, len(applied))
+	for _, entry := range applied {
+		defaults = append(defaults, fmt.Sprintf("%s=%s (%s)", entry.Variable, entry.Value, entry.sourceName()))
+	}
+	sort.Strings(defaults)
+	return defaults
+}
@@ -0,0 +1,173 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessioninit"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// The NOLOGIN and VALID UNTIL role options are checked when clients
+// connect. When the server.user_login.session_revalidation.interval
+// cluster setting is set, each node also periodically revalidates its
+// sessions, and terminates those whose user was dropped, can no longer
+// log in, or whose password expired if the session was authenticated
+// with it. The sessions are terminated once they have been invalid for
+// longer than server.user_login.session_revalidation.grace_period.
+
+// sessionRevalidationInterval is the cluster setting that controls how
+// often the sessions are revalidated.
+var sessionRevalidationInterval = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"server.user_login.session_revalidation.interval",
+	"how often each node terminates the sessions of the users who were dropped, "+
+		"can no longer log in, or whose password expired (0 disables the revalidation)",
+	0,
+	settings.NonNegativeDuration,
+)
+
+// sessionRevalidationGracePeriod is the cluster setting that controls
+// how long sessions can remain open once they are no longer valid.
+var sessionRevalidationGracePeriod = settings.RegisterDurationSetting(
+	settings.TenantWritable,
+	"server.user_login.session_revalidation.grace_period",
+	"how long a session can remain open once its user can no longer log in or its "+
+		"password expired, when server.user_login.session_revalidation.interval is set",
+	0,
+	settings.NonNegativeDuration,
+)
+
+// authenticatedSession is the authentication information of a session
+// in the registry.
+type authenticatedSession struct {
+	user         security.SQLUsername
+	withPassword bool
+}
+
+// authenticatedSessions returns the authentication information of the
+// sessions in the registry.
+func (r *SessionRegistry) authenticatedSessions() map[ClusterWideID]authenticatedSession {
+	r.Lock()
+	defer r.Unlock()
+	sessions := make(map[ClusterWideID]authenticatedSession, len(r.sessions))
+	for id, s := range r.sessions {
+		user, withPassword := s.authenticatedUser()
+		sessions[id] = authenticatedSession{user: user, withPassword: withPassword}
+	}
+	return sessions
+}
+
+// startSessionRevalidation starts the task which periodically
+// revalidates the sessions of the node.
+func (s *Server) startSessionRevalidation(ctx context.Context, stopper *stop.Stopper) {
+	sv := &s.cfg.Settings.SV
+	intervalChanged := make(chan struct{}, 1)
+	sessionRevalidationInterval.SetOnChange(sv, func(context.Context) {
+		select {
+		case intervalChanged <- struct{}{}:
+		default:
+		}
+	})
+	_ = stopper.RunAsyncTask(ctx, "session-revalidation", func(ctx context.Context) {
+		ctx, cancel := stopper.WithCancelOnQuiesce(ctx)
+		defer cancel()
+		var timer timeutil.Timer
+		defer timer.Stop()
+		// invalidSince records when the sessions were first found to be
+		// invalid, for the grace period.
+		invalidSince := make(map[ClusterWideID]time.Time)
+		for {
+			var tick <-chan time.Time
+			if interval := sessionRevalidationInterval.Get(sv); interval > 0 {
+				timer.Reset(interval)
+				tick = timer.C
+			}
+			select {
+			case <-tick:
+				timer.Read = true
+				invalidSince = s.revalidateSessions(ctx, invalidSince)
+			case <-intervalChanged:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// revalidateSessions terminates the sessions which have been invalid
+// for longer than the grace period. invalidSince records when the
+// sessions were first found to be invalid during the previous rounds;
+// the updated record is returned.
+func (s *Server) revalidateSessions(
+	ctx context.Context, invalidSince map[ClusterWideID]time.Time,
+) map[ClusterWideID]time.Time {
+	now := timeutil.Now()
+	gracePeriod := sessionRevalidationGracePeriod.Get(&s.cfg.Settings.SV)
+	// The auth info is looked up once per user, and is usually served by
+	// the cache, which is invalidated when roles are altered.
+	authInfos := make(map[security.SQLUsername]*sessioninit.AuthInfo)
+	newInvalidSince := make(map[ClusterWideID]time.Time)
+	for id, session := range s.cfg.SessionRegistry.authenticatedSessions() {
+		// Like when they connect, root and node are always allowed.
+		if session.user.IsRootUser() || session.user.IsNodeUser() {
+			continue
+		}
+		authInfo, ok := authInfos[session.user]
+		if !ok {
+			info, err := s.cfg.SessionInitCache.GetAuthInfo(
+				ctx, s.cfg.Settings, s.cfg.InternalExecutor, s.cfg.DB, s.cfg.CollectionFactory,
+				session.user, retrieveAuthInfo,
+			)
+			if err != nil {
+				log.Warningf(ctx, "unable to revalidate the sessions of user %s: %v", session.user, err)
+			} else {
+				authInfo = &info
+			}
+			authInfos[session.user] = authInfo
+		}
+		if authInfo == nil {
+			continue
+		}
+
+		var reason string
+		since, noticed := invalidSince[id]
+		if !noticed {
+			since = now
+		}
+		switch {
+		case !authInfo.UserExists:
+			reason = "the user was dropped"
+		case !authInfo.CanLoginSQL:
+			reason = "the user is no longer allowed to log in"
+		case session.withPassword && authInfo.ValidUntil != nil && !now.Before(authInfo.ValidUntil.Time):
+			reason = "the password of the user expired"
+			since = authInfo.ValidUntil.Time
+		default:
+			continue
+		}
+		if now.Sub(since) < gracePeriod {
+			newInvalidSince[id] = since
+			continue
+		}
+		log.Ops.Infof(ctx, "terminating session %s of user %s: %s", id, session.user, reason)
+		if _, err := s.cfg.SessionRegistry.CancelSession(id.GetBytes()); err != nil {
+			log.Warningf(ctx, "unable to terminate session %s: %v", id, err)
+		}
+	}
+	return newInvalidSince
+}
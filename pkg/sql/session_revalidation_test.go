@@ -0,0 +1,80 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSessionRevalidation verifies that the sessions of the users who
+// can no longer log in, or whose password expired, are terminated.
+func TestSessionRevalidation(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	sqlDB.Exec(t, `CREATE USER foo WITH PASSWORD 'testabc'`)
+	fooURL, fooCleanupFn := sqlutils.PGUrlWithOptionalClientCerts(t,
+		s.ServingSQLAddr(), t.Name(), url.UserPassword("foo", "testabc"), false /* withClientCerts */)
+	defer fooCleanupFn()
+
+	// The sessions are not revalidated by default.
+	func() {
+		conn, err := pgxConn(t, fooURL)
+		require.NoError(t, err)
+		defer func() { _ = conn.Close(ctx) }()
+		sqlDB.Exec(t, `ALTER USER foo VALID UNTIL '2000-01-01'`)
+		_, err = conn.Exec(ctx, "SELECT 1")
+		require.NoError(t, err)
+		sqlDB.Exec(t, `ALTER USER foo VALID UNTIL NULL`)
+	}()
+
+	sqlDB.Exec(t, `SET CLUSTER SETTING server.user_login.session_revalidation.interval = '10ms'`)
+
+	for _, tc := range []struct {
+		name, alter, restore string
+	}{
+		{"nologin", `ALTER USER foo NOLOGIN`, `ALTER USER foo LOGIN`},
+		{"valid until", `ALTER USER foo VALID UNTIL '2000-01-01'`, `ALTER USER foo VALID UNTIL NULL`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			conn, err := pgxConn(t, fooURL)
+			require.NoError(t, err)
+			defer func() { _ = conn.Close(ctx) }()
+			_, err = conn.Exec(ctx, "SELECT 1")
+			require.NoError(t, err)
+
+			sqlDB.Exec(t, tc.alter)
+			defer sqlDB.Exec(t, tc.restore)
+			testutils.SucceedsSoon(t, func() error {
+				if _, err := conn.Exec(ctx, "SELECT 1"); err == nil {
+					return errors.New("the session is still open")
+				}
+				return nil
+			})
+		})
+	}
+}
@@ -11,6 +11,8 @@
 package sql
 
 import (
+	"context"
+
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -63,13 +65,6 @@ func serializeSessionState(
 		)
 	}
 
-	if prepStmtsState.HasActivePortals() {
-		return nil, pgerror.Newf(
-			pgcode.InvalidTransactionState,
-			"cannot serialize a session which has active portals",
-		)
-	}
-
 	if sd == nil {
 		return nil, pgerror.Newf(
 			pgcode.InvalidTransactionState,
@@ -89,6 +84,7 @@ func serializeSessionState(
 	sessiondata.MarshalNonLocal(sd, &m.SessionData)
 	m.LocalOnlySessionData = sd.LocalOnlySessionData
 	m.PreparedStatements = prepStmtsState.MigratablePreparedStatements()
+	m.Portals = prepStmtsState.MigratablePortals()
 
 	b, err := protoutil.Marshal(&m)
 	if err != nil {
@@ -128,7 +124,39 @@ func (p *planner) DeserializeSessionState(state *tree.DBytes) (*tree.DBool, erro
 	}
 	sd.SessionData = m.SessionData
 	sd.LocalUnmigratableSessionData = evalCtx.SessionData().LocalUnmigratableSessionData
+	if err := CheckAllowedDatabase(sd.SessionUser(), sd.AllowedDatabases, sd.Database); err != nil {
+		return nil, err
+	}
 	sd.LocalOnlySessionData = m.LocalOnlySessionData
+	// The READONLY role option of the user takes precedence over the
+	// deserialized state. This must be done once the local-only data,
+	// which holds default_transaction_read_only, is restored.
+	if sd.ReadOnlyLogin {
+		sd.DefaultTxnReadOnly = true
+	}
+	// Likewise, the deserialized timeouts are lowered to the
+	// STATEMENT_TIMEOUT, TRANSACTION_TIMEOUT and IDLE_SESSION_TIMEOUT role
+	// options, as the defaults of the session were when it was established.
+	if exceedsTimeoutCeiling(sd.StmtTimeout, sd.MaxStmtTimeout) {
+		sd.StmtTimeout = sd.MaxStmtTimeout
+	}
+	if exceedsTimeoutCeiling(sd.TransactionTimeout, sd.MaxTxnTimeout) {
+		sd.TransactionTimeout = sd.MaxTxnTimeout
+	}
+	if exceedsTimeoutCeiling(sd.IdleInSessionTimeout, sd.MaxIdleSessionTimeout) {
+		sd.IdleInSessionTimeout = sd.MaxIdleSessionTimeout
+	}
+	// The quality of service is lowered to the QUALITY_OF_SERVICE role
+	// option as well.
+	if qos, ok := sessiondatapb.ParseQoSLevelFromString(sd.MaxQualityOfService); ok &&
+		sd.DefaultTxnQualityOfService > qos {
+		sd.DefaultTxnQualityOfService = qos
+	}
+	// The variables locked by a default setting of the role cannot be
+	// changed by the deserialized state either.
+	if err := p.resetLockedSessionVars(evalCtx.Context, sd); err != nil {
+		return nil, err
+	}
 	if sd.SessionUser().Normalized() != evalCtx.SessionData().SessionUser().Normalized() {
 		return nil, pgerror.Newf(
 			pgcode.InsufficientPrivilege,
@@ -150,25 +178,9 @@ func (p *planner) DeserializeSessionState(state *tree.DBytes) (*tree.DBool, erro
 		id := GenerateClusterWideID(evalCtx.ExecCfg.Clock.Now(), evalCtx.ExecCfg.NodeID.SQLInstanceID())
 		stmt := makeStatement(parserStmt, id)
 
-		var placeholderTypes tree.PlaceholderTypes
-		if len(prepStmt.PlaceholderTypeHints) > 0 {
-			// Prepare the mapping of SQL placeholder names to types. Pre-populate it
-			// with the type hints that were serialized.
-			placeholderTypes = make(tree.PlaceholderTypes, stmt.NumPlaceholders)
-			for i, t := range prepStmt.PlaceholderTypeHints {
-				// If the OID is user defined or unknown, then skip it and let the
-				// statementPreparer resolve the type.
-				if t == 0 || t == oid.T_unknown || types.IsOIDUserDefinedType(t) {
-					placeholderTypes[i] = nil
-					continue
-				}
-				v, ok := types.OidToType[t]
-				if !ok {
-					err := pgwirebase.NewProtocolViolationErrorf("unknown oid type: %v", t)
-					return nil, err
-				}
-				placeholderTypes[i] = v
-			}
+		placeholderTypes, err := makePlaceholderTypesFromHints(prepStmt.PlaceholderTypeHints, stmt)
+		if err != nil {
+			return nil, err
 		}
 
 		_, err = evalCtx.statementPreparer.addPreparedStmt(
@@ -181,7 +193,143 @@ func (p *planner) DeserializeSessionState(state *tree.DBytes) (*tree.DBool, erro
 		}
 	}
 
+	for i := range m.Portals {
+		if err := p.deserializePortal(&m.Portals[i], sd); err != nil {
+			return nil, err
+		}
+	}
+
 	*p.SessionData() = *sd
 
 	return tree.MakeDBool(true), nil
 }
+
+// resetLockedSessionVars sets the session variables locked by a default
+// setting of the role to the values they were given when the session was
+// established. The variables which cannot be set through a
+// sessionDataMutator are skipped, since they are not stored in the
+// session data.
+func (p *planner) resetLockedSessionVars(ctx context.Context, sd *sessiondata.SessionData) error {
+	m := sessionDataMutator{
+		data: sd,
+		sessionDataMutatorBase: sessionDataMutatorBase{
+			defaults: p.sessionDataMutatorIterator.defaults,
+			settings: p.ExecCfg().Settings,
+		},
+	}
+	for name := range sd.LockedVars {
+		value, ok := m.defaults[name]
+		if !ok {
+			continue
+		}
+		_, v, err := getSessionVar(name, true /* missingOk */)
+		if err != nil {
+			return err
+		}
+		if v.Set == nil {
+			continue
+		}
+		if err := v.Set(ctx, m, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// makePlaceholderTypesFromHints prepares the mapping of the SQL placeholder
+// names of the statement to types, pre-populated with the type hints that
+// were serialized.
+func makePlaceholderTypesFromHints(
+	hints []oid.Oid, stmt Statement,
+) (tree.PlaceholderTypes, error) {
+	if len(hints) == 0 {
+		return nil, nil
+	}
+	placeholderTypes := make(tree.PlaceholderTypes, stmt.NumPlaceholders)
+	for i, t := range hints {
+		// If the OID is user defined or unknown, then skip it and let the
+		// statementPreparer resolve the type.
+		if t == 0 || t == oid.T_unknown || types.IsOIDUserDefinedType(t) {
+			placeholderTypes[i] = nil
+			continue
+		}
+		v, ok := types.OidToType[t]
+		if !ok {
+			return nil, pgwirebase.NewProtocolViolationErrorf("unknown oid type: %v", t)
+		}
+		placeholderTypes[i] = v
+	}
+	return placeholderTypes, nil
+}
+
+// deserializePortal creates the serialized portal in the current session.
+// Its arguments are evaluated again, and its statement is prepared again
+// without being added to the prepared statements of the session.
+func (p *planner) deserializePortal(
+	portal *sessiondatapb.MigratableSession_Portal, sd *sessiondata.SessionData,
+) error {
+	evalCtx := p.ExtendedEvalContext()
+	ctx := evalCtx.Context
+	if evalCtx.PreparedStatementState.HasPortal(portal.Name) {
+		return pgerror.Newf(pgcode.DuplicateCursor, "portal %q already exists", portal.Name)
+	}
+
+	// The arguments are evaluated before the statement is prepared, since
+	// preparing it uses the planner.
+	qargs := make(tree.QueryArguments, len(portal.Arguments))
+	for i, arg := range portal.Arguments {
+		expr, err := parser.ParseExpr(arg)
+		if err != nil {
+			return err
+		}
+		typedExpr, err := tree.TypeCheck(ctx, expr, p.SemaCtx(), types.Any)
+		if err != nil {
+			return err
+		}
+		if qargs[i], err = typedExpr.Eval(p.EvalContext()); err != nil {
+			return err
+		}
+	}
+
+	parserStmt, err := parser.ParseOneWithInt(
+		portal.PreparedStatement.SQL,
+		parser.NakedIntTypeFromDefaultIntSize(sd.DefaultIntSize),
+	)
+	if err != nil {
+		return err
+	}
+	id := GenerateClusterWideID(evalCtx.ExecCfg.Clock.Now(), evalCtx.ExecCfg.NodeID.SQLInstanceID())
+	stmt := makeStatement(parserStmt, id)
+	placeholderTypes, err := makePlaceholderTypesFromHints(
+		portal.PreparedStatement.PlaceholderTypeHints, stmt,
+	)
+	if err != nil {
+		return err
+	}
+	prepared, err := evalCtx.statementPreparer.prepare(
+		ctx, stmt, placeholderTypes, portal.PreparedStatement.PlaceholderTypeHints,
+		PreparedStatementOriginSessionMigration,
+	)
+	if err != nil {
+		return err
+	}
+	// The portal holds its own reference to the prepared statement.
+	defer prepared.decRef(ctx)
+	// The hints were inferred when the statement was first prepared.
+	prepared.InferredTypes = portal.PreparedStatement.PlaceholderTypeHints
+
+	outFormats := make([]pgwirebase.FormatCode, len(portal.OutFormats))
+	for i, f := range portal.OutFormats {
+		outFormats[i] = pgwirebase.FormatCode(f)
+	}
+
+	if err := evalCtx.statementPreparer.addPortal(
+		ctx, portal.Name, prepared, qargs, outFormats,
+	); err != nil {
+		return err
+	}
+	if portal.Exhausted {
+		evalCtx.statementPreparer.exhaustPortal(portal.Name)
+	}
+	return nil
+}
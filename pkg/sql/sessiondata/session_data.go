@@ -168,6 +168,43 @@ type LocalUnmigratableSessionData struct {
 	// descpb.ID -> descpb.ID, but cannot be stored as such due to package
 	// dependencies. Temporary tables are not supported in session migrations.
 	DatabaseIDToTempSchemaID map[uint32]uint32
+	// PasswordMustChange is set when the session user has the PASSWORD MUST
+	// CHANGE role option. The session is then only allowed to change the
+	// password of the user, after which the flag is cleared.
+	PasswordMustChange bool
+	// PasswordAuthenticated is set when the session was authenticated with
+	// the password of the session user. The session is then subject to the
+	// VALID UNTIL role option of the user.
+	PasswordAuthenticated bool
+	// ReadOnlyLogin is set when the session user has the READONLY role
+	// option. The session is then restricted to read-only transactions.
+	ReadOnlyLogin bool
+	// AllowedDatabases is set when the session user has the ALLOWED
+	// DATABASES role option. The session cannot then use other databases.
+	AllowedDatabases []string
+	// LockedVars are the session variables set by a default setting of the
+	// role with LOCKED. The session cannot change them.
+	LockedVars map[string]struct{}
+	// MaxStmtTimeout and MaxTxnTimeout are set when the session user has
+	// the STATEMENT_TIMEOUT and TRANSACTION_TIMEOUT role options. The
+	// session cannot then raise statement_timeout and transaction_timeout
+	// above them, nor disable these timeouts.
+	MaxStmtTimeout time.Duration
+	MaxTxnTimeout  time.Duration
+	// MaxIdleSessionTimeout is set when the session user has the
+	// IDLE_SESSION_TIMEOUT role option. The session cannot then raise
+	// idle_in_session_timeout above it, nor disable it.
+	MaxIdleSessionTimeout time.Duration
+	// MaxQualityOfService is set when the session user has the
+	// QUALITY_OF_SERVICE role option. The session cannot then raise
+	// default_transaction_quality_of_service above it.
+	MaxQualityOfService string
+	// MaxResultRows and MaxResultBytes are set when the session user has
+	// the MAX_RESULT_ROWS and MAX_RESULT_BYTES role options. The
+	// statements of the session then fail when their results exceed them.
+	// They are not session variables, so the session cannot change them.
+	MaxResultRows  int64
+	MaxResultBytes int64
 
 	///////////////////////////////////////////////////////////////////////////
 	// WARNING: consider whether a session parameter you're adding needs to  //
@@ -13,21 +13,28 @@ package sessioninit
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 	"unsafe"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil/singleflight"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tracing"
 	"github.com/cockroachdb/logtags"
 )
 
@@ -44,6 +51,17 @@ var CacheEnabled = settings.RegisterBoolSetting(
 	true,
 ).WithPublic()
 
+// CacheMaxEntries is a cluster setting that limits the number of entries
+// the sessioninit.Cache can hold, in addition to the limit imposed by the
+// memory budget.
+var CacheMaxEntries = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"server.authentication_cache.max_entries",
+	"maximum number of entries held by the authentication cache (0 = no limit)",
+	0,
+	settings.NonNegativeInt,
+)
+
 // Cache is a shared cache for hashed passwords and other information used
 // during user authentication and session initialization.
 type Cache struct {
@@ -55,11 +73,57 @@ type Cache struct {
 	// authInfoCache is a mapping from username to AuthInfo.
 	authInfoCache map[security.SQLUsername]AuthInfo
 	// settingsCache is a mapping from (dbID, username) to default settings.
-	settingsCache map[SettingsCacheKey][]string
+	settingsCache map[SettingsCacheKey]SettingsCacheEntry
 	// populateCacheGroup is used to ensure that there is at most one in-flight
 	// request for populating each cache entry.
 	populateCacheGroup singleflight.Group
 	stopper            *stop.Stopper
+	// hits and misses count the lookups that were, respectively, served and
+	// not served from the cache since the node started.
+	hits   int64
+	misses int64
+	// hitRatioWindow reports the hit ratio of each day to telemetry.
+	hitRatioWindow hitRatioWindow
+	// fallbacks reports the logins which bypass the cache.
+	fallbacks fallbackReporter
+	// fullWarning rate limits the warnings logged when entries are not
+	// cached because the cache holds CacheMaxEntries entries; until the
+	// cache is invalidated, every login which misses the cache would
+	// otherwise log one.
+	fullWarning log.EveryN
+}
+
+// cacheFullWarningInterval is the minimum interval between two warnings
+// that the cache is full.
+const cacheFullWarningInterval = time.Minute
+
+// CacheInfo is a point-in-time summary of the contents of a Cache.
+type CacheInfo struct {
+	// AuthInfoEntries is the number of users with a cached AuthInfo.
+	AuthInfoEntries int
+	// SettingsEntries is the number of cached (database, user) settings keys.
+	SettingsEntries int
+	// Bytes is the amount of memory accounted to the cache.
+	Bytes int64
+	// Hits is the number of lookups served from the cache.
+	Hits int64
+	// Misses is the number of lookups that had to read the system tables.
+	Misses int64
+	// UsersTableVersion, RoleOptionsTableVersion and
+	// DatabaseRoleSettingsTableVersion are the descriptor versions that the
+	// cached data is based on.
+	UsersTableVersion                descpb.DescriptorVersion
+	RoleOptionsTableVersion          descpb.DescriptorVersion
+	DatabaseRoleSettingsTableVersion descpb.DescriptorVersion
+}
+
+// HitRatio returns the fraction of lookups that were served from the cache,
+// or 0 if there have been no lookups.
+func (i CacheInfo) HitRatio() float64 {
+	if total := i.Hits + i.Misses; total > 0 {
+		return float64(i.Hits) / float64(total)
+	}
+	return 0
 }
 
 // AuthInfo contains data that is used to perform an authentication attempt.
@@ -74,12 +138,75 @@ type AuthInfo struct {
 	HashedPassword security.PasswordHash
 	// ValidUntil is the VALID UNTIL role option.
 	ValidUntil *tree.DTimestamp
+	// PasswordMustChange is set to true if the user has the PASSWORD MUST
+	// CHANGE role option.
+	PasswordMustChange bool
+	// ConnectionLimit is the CONNECTION LIMIT role option, or -1 if the
+	// number of connections of the user is not limited.
+	ConnectionLimit int32
+	// Subject is the SUBJECT role option, that is the distinguished name
+	// of the client certificates of the user, or empty if not set.
+	Subject string
+	// MFARequired is set to true if the user has the MFA role option.
+	MFARequired bool
+	// ReadOnly is set to true if the user has the READONLY role option.
+	ReadOnly bool
+	// AllowedDatabases is the ALLOWED DATABASES role option, that is the
+	// databases the user can connect to, or nil if not restricted.
+	AllowedDatabases []string
+	// LoginWindow is the LOGIN WINDOW role option, that is the times
+	// during which the user can log in, or nil if not restricted.
+	LoginWindow *roleoption.LoginWindow
+	// IdleSessionTimeout is the IDLE_SESSION_TIMEOUT option of the user or
+	// of its profile, which sets and bounds the idle timeout of its
+	// sessions, or 0 if not set.
+	IdleSessionTimeout time.Duration
+	// MaxStatementTimeout and MaxTransactionTimeout are the
+	// STATEMENT_TIMEOUT and TRANSACTION_TIMEOUT options of the user or of
+	// its profile, which bound the corresponding timeouts of its sessions,
+	// or 0 if not set.
+	MaxStatementTimeout   time.Duration
+	MaxTransactionTimeout time.Duration
+	// QualityOfService is the QUALITY_OF_SERVICE option of the user or of
+	// its profile, that is the default and highest admission control
+	// priority of its sessions, or empty if not set.
+	QualityOfService string
+	// MaxResultRows and MaxResultBytes are the MAX_RESULT_ROWS and
+	// MAX_RESULT_BYTES options of the user or of its profile, which bound
+	// the results of the statements of its sessions, or 0 if not set.
+	MaxResultRows  int64
+	MaxResultBytes int64
+	// CacheHit is set to true if the AuthInfo was served from the cache
+	// rather than read from the system tables. It is not itself cached.
+	CacheHit bool
 }
 
 // SettingsCacheKey is the key used for the settingsCache.
 type SettingsCacheKey struct {
 	DatabaseID descpb.ID
 	Username   security.SQLUsername
+	// ApplicationName is the application name of the sessions. If it is
+	// set, the key is for the settings of the rows whose application name
+	// pattern matches it, otherwise for the settings of the rows which apply
+	// to all the applications.
+	ApplicationName string
+	// SourceAddress is the IP address of the clients of the sessions. If it
+	// is set, the key is for the settings of the rows whose network contains
+	// it, otherwise for the settings of the rows which apply to all the
+	// networks.
+	SourceAddress string
+}
+
+// IsUnscoped returns whether the key is for the settings which apply to all
+// the applications and networks.
+func (k SettingsCacheKey) IsUnscoped() bool {
+	return k.ApplicationName == "" && k.SourceAddress == ""
+}
+
+// unscoped returns the key for the settings of the same database and role
+// which apply to all the applications and networks.
+func (k SettingsCacheKey) unscoped() SettingsCacheKey {
+	return SettingsCacheKey{DatabaseID: k.DatabaseID, Username: k.Username}
 }
 
 // SettingsCacheEntry represents an entry in the settingsCache. It is
@@ -87,6 +214,27 @@ type SettingsCacheKey struct {
 type SettingsCacheEntry struct {
 	SettingsCacheKey
 	Settings []string
+	// LockedSettings are the settings, in the same "name=value" form, which
+	// the rows of the entry set with LOCKED. The clients cannot override
+	// them.
+	LockedSettings []string
+	// Negative is set on an entry whose key is unscoped if the database and
+	// role of the key have no settings at all, whatever their application
+	// and network. The entries of the scoped keys of the same database and
+	// role are then known to be empty, so they are not cached: the negative
+	// entry serves them, for any application name and source address.
+	Negative bool
+}
+
+// IsLocked returns whether the setting, in the "name=value" form, was set
+// with LOCKED.
+func (e SettingsCacheEntry) IsLocked(setting string) bool {
+	for _, s := range e.LockedSettings {
+		if s == setting {
+			return true
+		}
+	}
+	return false
 }
 
 // NewCache initializes a new sessioninit.Cache.
@@ -94,6 +242,23 @@ func NewCache(account mon.BoundAccount, stopper *stop.Stopper) *Cache {
 	return &Cache{
 		boundAccount: account,
 		stopper:      stopper,
+		fullWarning:  log.Every(cacheFullWarningInterval),
+	}
+}
+
+// Info returns a summary of the current contents of the cache.
+func (a *Cache) Info() CacheInfo {
+	a.Lock()
+	defer a.Unlock()
+	return CacheInfo{
+		AuthInfoEntries:                  len(a.authInfoCache),
+		SettingsEntries:                  len(a.settingsCache),
+		Bytes:                            a.boundAccount.Used(),
+		Hits:                             a.hits,
+		Misses:                           a.misses,
+		UsersTableVersion:                a.usersTableVersion,
+		RoleOptionsTableVersion:          a.roleOptionsTableVersion,
+		DatabaseRoleSettingsTableVersion: a.dbRoleSettingsTableVersion,
 	}
 }
 
@@ -115,7 +280,12 @@ func (a *Cache) GetAuthInfo(
 		username security.SQLUsername,
 	) (AuthInfo, error),
 ) (aInfo AuthInfo, err error) {
+	ctx, sp := tracing.ChildSpan(ctx, "sessioninit-get-auth-info")
+	defer sp.Finish()
 	if !CacheEnabled.Get(&settings.SV) {
+		telemetry.Inc(sqltelemetry.AuthCacheDisabledCounter)
+		a.fallbacks.report(ctx, fallbackReasonDisabled, username)
+		log.VEventf(ctx, 2, "cache disabled; reading the auth info of user %s from the system tables", username)
 		return readFromSystemTables(ctx, nil /* txn */, ie, username)
 	}
 	err = f.Txn(ctx, ie, db, func(
@@ -144,6 +314,9 @@ func (a *Cache) GetAuthInfo(
 		// trying to cache anything.
 		if usersTableDesc.IsUncommittedVersion() ||
 			roleOptionsTableDesc.IsUncommittedVersion() {
+			telemetry.Inc(sqltelemetry.AuthCacheFallbackCounter(sqltelemetry.AuthCacheFallbackUncommittedVersion))
+			a.fallbacks.report(ctx, sqltelemetry.AuthCacheFallbackUncommittedVersion, username)
+			log.VEventf(ctx, 2, "system tables modified; reading the auth info of user %s from the system tables", username)
 			aInfo, err = readFromSystemTables(ctx, txn, ie, username)
 			return err
 		}
@@ -155,8 +328,11 @@ func (a *Cache) GetAuthInfo(
 		aInfo, found = a.readAuthInfoFromCache(ctx, usersTableVersion, roleOptionsTableVersion, username)
 
 		if found {
+			log.VEventf(ctx, 2, "auth info cache hit for user %s", username)
+			aInfo.CacheHit = true
 			return nil
 		}
+		log.VEventf(ctx, 2, "auth info cache miss; reading the auth info of user %s from the system tables", username)
 
 		// Lookup the data outside the lock. There will be at most one
 		// request in-flight for each user. The user and role_options table
@@ -171,6 +347,7 @@ func (a *Cache) GetAuthInfo(
 			return err
 		}
 		aInfo = val.(AuthInfo)
+		log.VEventf(ctx, 2, "read the auth info of user %s from the system tables", username)
 
 		// Write data back to the cache if the table version hasn't changed.
 		a.maybeWriteAuthInfoBackToCache(
@@ -179,6 +356,7 @@ func (a *Cache) GetAuthInfo(
 			roleOptionsTableVersion,
 			aInfo,
 			username,
+			CacheMaxEntries.Get(&settings.SV),
 		)
 		return nil
 	})
@@ -197,9 +375,11 @@ func (a *Cache) readAuthInfoFromCache(
 	// one we already have.
 	isEligibleForCache := a.clearCacheIfStale(ctx, usersTableVersion, roleOptionsTableVersion, a.dbRoleSettingsTableVersion)
 	if !isEligibleForCache {
+		a.recordLookupLocked(false /* found */)
 		return AuthInfo{}, false
 	}
 	ai, foundAuthInfo := a.authInfoCache[username]
+	a.recordLookupLocked(foundAuthInfo)
 	return ai, foundAuthInfo
 }
 
@@ -240,6 +420,7 @@ func (a *Cache) maybeWriteAuthInfoBackToCache(
 	roleOptionsTableVersion descpb.DescriptorVersion,
 	aInfo AuthInfo,
 	username security.SQLUsername,
+	maxEntries int64,
 ) bool {
 	a.Lock()
 	defer a.Unlock()
@@ -247,6 +428,21 @@ func (a *Cache) maybeWriteAuthInfoBackToCache(
 	if a.usersTableVersion != usersTableVersion || a.roleOptionsTableVersion != roleOptionsTableVersion {
 		return false
 	}
+	if _, ok := a.authInfoCache[username]; ok {
+		// Another request has already cached the entry for these table
+		// versions.
+		return true
+	}
+	if !a.hasRoomLocked(1, maxEntries) {
+		// The cache is full. As for the memory limit below, authentication
+		// can still proceed without caching the entry.
+		telemetry.Inc(sqltelemetry.AuthCacheFallbackCounter(sqltelemetry.AuthCacheFallbackFull))
+		a.fallbacks.report(ctx, sqltelemetry.AuthCacheFallbackFull, username)
+		if a.fullWarning.ShouldLog() {
+			log.Ops.Warningf(ctx, "authentication cache is full (%d entries); not caching authentication info", maxEntries)
+		}
+		return true
+	}
 	// Table version remains the same: update map, unlock, return.
 	const sizeOfUsername = int(unsafe.Sizeof(security.SQLUsername{}))
 	const sizeOfAuthInfo = int(unsafe.Sizeof(AuthInfo{}))
@@ -264,6 +460,8 @@ func (a *Cache) maybeWriteAuthInfoBackToCache(
 		// If there is no memory available to cache the entry, we can still
 		// proceed with authentication so that users are not locked out of
 		// the database.
+		telemetry.Inc(sqltelemetry.AuthCacheFallbackCounter(sqltelemetry.AuthCacheFallbackNoMemory))
+		a.fallbacks.report(ctx, sqltelemetry.AuthCacheFallbackNoMemory, username)
 		log.Ops.Warningf(ctx, "no memory available to cache authentication info: %v", err)
 	} else {
 		a.authInfoCache[username] = aInfo
@@ -271,9 +469,46 @@ func (a *Cache) maybeWriteAuthInfoBackToCache(
 	return true
 }
 
+// WriteThroughAuthInfo stores the AuthInfo for the given user in the cache,
+// based on the provided table versions. It is meant to be called after the
+// transaction that produced these table versions has committed, so that the
+// node which executed a role change does not need to read the system tables
+// again for the next login of that user. Other nodes refresh their cache when
+// they observe the new table versions.
+func (a *Cache) WriteThroughAuthInfo(
+	ctx context.Context,
+	settings *cluster.Settings,
+	usersTableVersion descpb.DescriptorVersion,
+	roleOptionsTableVersion descpb.DescriptorVersion,
+	username security.SQLUsername,
+	aInfo AuthInfo,
+) {
+	if !CacheEnabled.Get(&settings.SV) {
+		return
+	}
+	a.Lock()
+	isEligibleForCache := a.clearCacheIfStale(
+		ctx, usersTableVersion, roleOptionsTableVersion, a.dbRoleSettingsTableVersion,
+	)
+	a.Unlock()
+	if !isEligibleForCache {
+		return
+	}
+	a.maybeWriteAuthInfoBackToCache(
+		ctx,
+		usersTableVersion,
+		roleOptionsTableVersion,
+		aInfo,
+		username,
+		CacheMaxEntries.Get(&settings.SV),
+	)
+}
+
 // GetDefaultSettings consults the sessioninit.Cache and returns the list of
-// SettingsCacheEntry for the provided username and databaseName. If the
-// information is not in the cache, or if the underlying tables have changed
+// SettingsCacheEntry for the provided username, databaseName,
+// applicationName and sourceAddress. The default settings of the roles in
+// memberOf, which the user is a member of, are inherited by the user; see
+// GenerateSettingsCacheKeys. If the information is not in the cache, or if the underlying tables have changed
 // since the cache was populated, then the readFromSystemTables callback is
 // used to load new data.
 func (a *Cache) GetDefaultSettings(
@@ -283,15 +518,23 @@ func (a *Cache) GetDefaultSettings(
 	db *kv.DB,
 	f *descs.CollectionFactory,
 	username security.SQLUsername,
+	memberOf []security.SQLUsername,
 	databaseName string,
+	applicationName string,
+	sourceAddress string,
 	readFromSystemTables func(
 		ctx context.Context,
 		txn *kv.Txn,
 		ie sqlutil.InternalExecutor,
 		username security.SQLUsername,
+		memberOf []security.SQLUsername,
 		databaseID descpb.ID,
+		applicationName string,
+		sourceAddress string,
 	) ([]SettingsCacheEntry, error),
 ) (settingsEntries []SettingsCacheEntry, err error) {
+	ctx, sp := tracing.ChildSpan(ctx, "sessioninit-get-default-settings")
+	defer sp.Finish()
 	err = f.Txn(ctx, ie, db, func(
 		ctx context.Context, txn *kv.Txn, descriptors *descs.Collection,
 	) error {
@@ -317,18 +560,40 @@ func (a *Cache) GetDefaultSettings(
 			}
 		}
 
+		// The default settings are not read for root, so its entries, which
+		// would otherwise hide the negative entries of the keys it shares with
+		// the other users, are not cached.
+		if username.IsRootUser() {
+			settingsEntries, err = readFromSystemTables(
+				ctx, txn, ie, username, memberOf, databaseID, applicationName, sourceAddress,
+			)
+			return err
+		}
+
 		// If the underlying table versions are not committed or if the cache is
 		// disabled, stop and avoid trying to cache anything.
 		// We can't check if the cache is disabled earlier, since we always need to
 		// start the `CollectionFactory.Txn()` regardless in order to look up the
 		// database descriptor ID.
-		if dbRoleSettingsTableDesc.IsUncommittedVersion() || !CacheEnabled.Get(&settings.SV) {
+		cacheEnabled := CacheEnabled.Get(&settings.SV)
+		if dbRoleSettingsTableDesc.IsUncommittedVersion() || !cacheEnabled {
+			if !cacheEnabled {
+				telemetry.Inc(sqltelemetry.AuthCacheDisabledCounter)
+				a.fallbacks.report(ctx, fallbackReasonDisabled, username)
+			} else {
+				telemetry.Inc(sqltelemetry.AuthCacheFallbackCounter(sqltelemetry.AuthCacheFallbackUncommittedVersion))
+				a.fallbacks.report(ctx, sqltelemetry.AuthCacheFallbackUncommittedVersion, username)
+			}
+			log.VEventf(ctx, 2, "reading the default settings of user %s from the system tables", username)
 			settingsEntries, err = readFromSystemTables(
 				ctx,
 				txn,
 				ie,
 				username,
+				memberOf,
 				databaseID,
+				applicationName,
+				sourceAddress,
 			)
 			return err
 		}
@@ -336,26 +601,37 @@ func (a *Cache) GetDefaultSettings(
 
 		// Check version and maybe clear cache while holding the mutex.
 		var found bool
-		settingsEntries, found = a.readDefaultSettingsFromCache(ctx, dbRoleSettingsTableVersion, username, databaseID)
+		settingsEntries, found = a.readDefaultSettingsFromCache(
+			ctx, dbRoleSettingsTableVersion, username, memberOf, databaseID, applicationName, sourceAddress,
+		)
 
 		if found {
+			log.VEventf(ctx, 2, "default settings cache hit for user %s", username)
 			return nil
 		}
+		log.VEventf(ctx, 2, "default settings cache miss; reading the default settings of user %s from the system tables", username)
 
 		// Lookup the data outside the lock. There will be at most one request
-		// in-flight for each user+database. The db_role_settings table version is
-		// also part of the request key so that we don't read data from an old
-		// version of the table.
+		// in-flight for each user+database+application+address. The
+		// db_role_settings table version is also part of the request key so
+		// that we don't read data from an old version of the table, and so are
+		// the roles the user is a member of, since they determine the entries
+		// which are read.
 		val, err := a.loadCacheValue(
-			ctx, fmt.Sprintf("defaultsettings-%s-%d-%d", username.Normalized(), databaseID, dbRoleSettingsTableVersion),
+			ctx, fmt.Sprintf("defaultsettings-%s-%d-%q-%q-%d-%s",
+				username.Normalized(), databaseID, applicationName, sourceAddress,
+				dbRoleSettingsTableVersion, memberOfKey(memberOf)),
 			func(loadCtx context.Context) (interface{}, error) {
-				return readFromSystemTables(loadCtx, txn, ie, username, databaseID)
+				return readFromSystemTables(
+					loadCtx, txn, ie, username, memberOf, databaseID, applicationName, sourceAddress,
+				)
 			},
 		)
 		if err != nil {
 			return err
 		}
 		settingsEntries = val.([]SettingsCacheEntry)
+		log.VEventf(ctx, 2, "read the default settings of user %s from the system tables", username)
 
 		// Write the fetched data back to the cache if the table version hasn't
 		// changed.
@@ -363,6 +639,8 @@ func (a *Cache) GetDefaultSettings(
 			ctx,
 			dbRoleSettingsTableVersion,
 			settingsEntries,
+			username,
+			CacheMaxEntries.Get(&settings.SV),
 		)
 		return nil
 	})
@@ -373,7 +651,10 @@ func (a *Cache) readDefaultSettingsFromCache(
 	ctx context.Context,
 	dbRoleSettingsTableVersion descpb.DescriptorVersion,
 	username security.SQLUsername,
+	memberOf []security.SQLUsername,
 	databaseID descpb.ID,
+	applicationName string,
+	sourceAddress string,
 ) ([]SettingsCacheEntry, bool) {
 	a.Lock()
 	defer a.Unlock()
@@ -383,6 +664,7 @@ func (a *Cache) readDefaultSettingsFromCache(
 		ctx, a.usersTableVersion, a.roleOptionsTableVersion, dbRoleSettingsTableVersion,
 	)
 	if !isEligibleForCache {
+		a.recordLookupLocked(false /* found */)
 		return nil, false
 	}
 	foundAllDefaultSettings := true
@@ -393,17 +675,77 @@ func (a *Cache) readDefaultSettingsFromCache(
 	// so the order of the returned []SettingsCacheEntry is important and the
 	// caller must take care not to apply a setting if it has already appeared
 	// earlier in the list.
-	for _, k := range GenerateSettingsCacheKeys(databaseID, username) {
+	for _, k := range GenerateSettingsCacheKeys(
+		databaseID, username, memberOf, applicationName, sourceAddress,
+	) {
 		s, ok := a.settingsCache[k]
+		if !ok && !k.IsUnscoped() {
+			if unscoped, found := a.settingsCache[k.unscoped()]; found && unscoped.Negative {
+				s, ok = SettingsCacheEntry{SettingsCacheKey: k, Settings: []string{}}, true
+			}
+		}
 		if !ok {
 			foundAllDefaultSettings = false
 			break
 		}
-		sEntries = append(sEntries, SettingsCacheEntry{k, s})
+		sEntries = append(sEntries, s)
 	}
+	a.recordLookupLocked(foundAllDefaultSettings)
 	return sEntries, foundAllDefaultSettings
 }
 
+// recordLookupLocked updates the hit and miss counters. The caller must hold
+// the mutex.
+func (a *Cache) recordLookupLocked(found bool) {
+	if found {
+		a.hits++
+		telemetry.Inc(sqltelemetry.AuthCacheHitCounter)
+	} else {
+		a.misses++
+		telemetry.Inc(sqltelemetry.AuthCacheMissCounter)
+	}
+	if bucket, ok := a.hitRatioWindow.record(timeutil.Now(), found); ok {
+		telemetry.Inc(sqltelemetry.AuthCacheDailyHitRatioCounter(bucket))
+	}
+}
+
+// hitRatioWindowDuration is the duration of the windows over which the hit
+// ratio is reported to telemetry.
+const hitRatioWindowDuration = 24 * time.Hour
+
+// hitRatioWindow computes the hit ratio of the lookups over consecutive
+// windows of hitRatioWindowDuration. The hit and miss counters cannot be
+// used for this, as telemetry reports their sum over all the nodes and over
+// the whole reporting period, which hides the nodes and days on which the
+// cache was ineffective.
+type hitRatioWindow struct {
+	// start is the time of the first lookup of the current window, or zero
+	// before the first lookup.
+	start  time.Time
+	hits   int64
+	misses int64
+}
+
+// record records a lookup performed at the given time. When the lookup
+// closes the current window, it returns the hit ratio of that window as a
+// percentage rounded down to a multiple of 10, so that a ratio of 100 means
+// that no lookup missed the cache.
+func (w *hitRatioWindow) record(now time.Time, found bool) (bucket int64, ok bool) {
+	if w.start.IsZero() {
+		w.start = now
+	} else if now.Sub(w.start) >= hitRatioWindowDuration {
+		bucket = w.hits * 100 / (w.hits + w.misses) / 10 * 10
+		ok = true
+		*w = hitRatioWindow{start: now}
+	}
+	if found {
+		w.hits++
+	} else {
+		w.misses++
+	}
+	return bucket, ok
+}
+
 // maybeWriteDefaultSettingsBackToCache tries to put the fetched SettingsCacheEntry
 // list into the settingsCache, and returns true if it succeeded. If the
 // underlying system tables have been modified since they were read, the
@@ -412,6 +754,8 @@ func (a *Cache) maybeWriteDefaultSettingsBackToCache(
 	ctx context.Context,
 	dbRoleSettingsTableVersion descpb.DescriptorVersion,
 	settingsEntries []SettingsCacheEntry,
+	username security.SQLUsername,
+	maxEntries int64,
 ) bool {
 	a.Lock()
 	defer a.Unlock()
@@ -420,36 +764,84 @@ func (a *Cache) maybeWriteDefaultSettingsBackToCache(
 		return false
 	}
 
+	// The entries of the scoped keys which are served by a negative entry
+	// are not cached.
+	isServedByNegativeEntry := func(sEntry SettingsCacheEntry) bool {
+		if sEntry.IsUnscoped() {
+			return false
+		}
+		unscopedKey := sEntry.unscoped()
+		if unscoped, ok := a.settingsCache[unscopedKey]; ok {
+			return unscoped.Negative
+		}
+		for _, other := range settingsEntries {
+			if other.SettingsCacheKey == unscopedKey {
+				return other.Negative
+			}
+		}
+		return false
+	}
+
 	// Table version remains the same: update map, unlock, return.
 	const sizeOfSettingsCacheEntry = int(unsafe.Sizeof(SettingsCacheEntry{}))
 	sizeOfSettings := 0
+	newEntries := 0
 	for _, sEntry := range settingsEntries {
 		if _, ok := a.settingsCache[sEntry.SettingsCacheKey]; ok {
 			// Avoid double-counting memory if a key is already in the cache.
 			continue
 		}
+		if isServedByNegativeEntry(sEntry) {
+			continue
+		}
+		newEntries++
 		sizeOfSettings += sizeOfSettingsCacheEntry
 		sizeOfSettings += len(sEntry.SettingsCacheKey.Username.Normalized())
+		sizeOfSettings += len(sEntry.SettingsCacheKey.ApplicationName)
+		sizeOfSettings += len(sEntry.SettingsCacheKey.SourceAddress)
 		for _, s := range sEntry.Settings {
 			sizeOfSettings += len(s)
 		}
+		for _, s := range sEntry.LockedSettings {
+			sizeOfSettings += len(s)
+		}
+	}
+	if !a.hasRoomLocked(newEntries, maxEntries) {
+		telemetry.Inc(sqltelemetry.AuthCacheFallbackCounter(sqltelemetry.AuthCacheFallbackFull))
+		a.fallbacks.report(ctx, sqltelemetry.AuthCacheFallbackFull, username)
+		if a.fullWarning.ShouldLog() {
+			log.Ops.Warningf(ctx, "authentication cache is full (%d entries); not caching default settings", maxEntries)
+		}
+		return true
 	}
 	if err := a.boundAccount.Grow(ctx, int64(sizeOfSettings)); err != nil {
 		// If there is no memory available to cache the entry, we can still
 		// proceed with authentication so that users are not locked out of
 		// the database.
+		telemetry.Inc(sqltelemetry.AuthCacheFallbackCounter(sqltelemetry.AuthCacheFallbackNoMemory))
+		a.fallbacks.report(ctx, sqltelemetry.AuthCacheFallbackNoMemory, username)
 		log.Ops.Warningf(ctx, "no memory available to cache authentication info: %v", err)
 	} else {
 		for _, sEntry := range settingsEntries {
 			// Avoid re-storing an existing key.
-			if _, ok := a.settingsCache[sEntry.SettingsCacheKey]; !ok {
-				a.settingsCache[sEntry.SettingsCacheKey] = sEntry.Settings
+			if _, ok := a.settingsCache[sEntry.SettingsCacheKey]; !ok && !isServedByNegativeEntry(sEntry) {
+				a.settingsCache[sEntry.SettingsCacheKey] = sEntry
 			}
 		}
 	}
 	return true
 }
 
+// hasRoomLocked returns true if numNew more entries can be added to the cache
+// without exceeding maxEntries. A maxEntries of 0 means there is no limit. The
+// caller must hold the mutex.
+func (a *Cache) hasRoomLocked(numNew int, maxEntries int64) bool {
+	if maxEntries <= 0 {
+		return true
+	}
+	return int64(len(a.authInfoCache)+len(a.settingsCache)+numNew) <= maxEntries
+}
+
 // clearCacheIfStale compares the cached table versions to the current table
 // versions. If the cached versions are older, the cache is cleared. If the
 // cached versions are newer, then false is returned to indicate that the
@@ -469,7 +861,7 @@ func (a *Cache) clearCacheIfStale(
 		a.roleOptionsTableVersion = roleOptionsTableVersion
 		a.dbRoleSettingsTableVersion = dbRoleSettingsTableVersion
 		a.authInfoCache = make(map[security.SQLUsername]AuthInfo)
-		a.settingsCache = make(map[SettingsCacheKey][]string)
+		a.settingsCache = make(map[SettingsCacheKey]SettingsCacheEntry)
 		a.boundAccount.Empty(ctx)
 	} else if a.usersTableVersion > usersTableVersion ||
 		a.roleOptionsTableVersion > roleOptionsTableVersion ||
@@ -482,27 +874,71 @@ func (a *Cache) clearCacheIfStale(
 }
 
 // GenerateSettingsCacheKeys returns a slice of all the SettingsCacheKey
-// that are relevant for the given databaseID and username. The slice is
-// ordered in descending order of precedence.
+// that are relevant for the given databaseID, username, applicationName and
+// sourceAddress. The slice is ordered in descending order of precedence:
+//
+//  1. the defaults of the user in the database;
+//  2. the defaults of the user in all the databases;
+//  3. for each role in memberOf, which the user is a member of, the
+//     defaults of the role in the database, then in all the databases;
+//  4. the defaults of all the roles in the database;
+//  5. the defaults of all the roles in all the databases.
+//
+// If applicationName is set, each of these is preceded by the defaults
+// which are scoped to the applications matching applicationName. Likewise,
+// if sourceAddress is set, the defaults which are scoped to the networks
+// containing sourceAddress precede the others; the application takes
+// precedence over the network.
+//
+// The roles in memberOf are expected to be sorted, so that the precedence
+// among them does not depend on the order in which the memberships were
+// looked up.
 func GenerateSettingsCacheKeys(
-	databaseID descpb.ID, username security.SQLUsername,
+	databaseID descpb.ID,
+	username security.SQLUsername,
+	memberOf []security.SQLUsername,
+	applicationName string,
+	sourceAddress string,
 ) []SettingsCacheKey {
-	return []SettingsCacheKey{
-		{
-			DatabaseID: databaseID,
-			Username:   username,
-		},
-		{
-			DatabaseID: defaultDatabaseID,
-			Username:   username,
-		},
-		{
-			DatabaseID: databaseID,
-			Username:   defaultUsername,
-		},
-		{
-			DatabaseID: defaultDatabaseID,
-			Username:   defaultUsername,
-		},
+	appNames := []string{""}
+	if applicationName != "" {
+		appNames = []string{applicationName, ""}
+	}
+	addresses := []string{""}
+	if sourceAddress != "" {
+		addresses = []string{sourceAddress, ""}
+	}
+	keys := make([]SettingsCacheKey, 0, len(appNames)*len(addresses)*(2*len(memberOf)+4))
+	appendKeys := func(databaseID descpb.ID, username security.SQLUsername) {
+		for _, appName := range appNames {
+			for _, address := range addresses {
+				keys = append(keys, SettingsCacheKey{
+					DatabaseID:      databaseID,
+					Username:        username,
+					ApplicationName: appName,
+					SourceAddress:   address,
+				})
+			}
+		}
+	}
+	for _, role := range append([]security.SQLUsername{username}, memberOf...) {
+		appendKeys(databaseID, role)
+		appendKeys(defaultDatabaseID, role)
+	}
+	appendKeys(databaseID, defaultUsername)
+	appendKeys(defaultDatabaseID, defaultUsername)
+	return keys
+}
+
+// memberOfKey returns a string which identifies the list of roles, for use
+// in the keys of the in-flight cache requests.
+func memberOfKey(memberOf []security.SQLUsername) string {
+	var b strings.Builder
+	for i, role := range memberOf {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(role.Normalized())
 	}
+	return b.String()
 }
@@ -0,0 +1,80 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sessioninit
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// The logins which bypass the cache are reported with
+// authentication_cache_fallback events, so that operators can see when
+// and why the authentication degraded to reading the system tables. The
+// events are rate-limited for each reason, since a misconfigured cache
+// may be bypassed by every login.
+
+// fallbackReasonDisabled is the reason reported when the cache is
+// disabled. The other reasons are those of the telemetry counters.
+const fallbackReasonDisabled = "disabled"
+
+// fallbackEventInterval is the minimum interval between two
+// authentication_cache_fallback events for the same reason.
+const fallbackEventInterval = time.Minute
+
+// fallbackReporter reports the logins which bypass the cache.
+type fallbackReporter struct {
+	mu struct {
+		syncutil.Mutex
+		// reasons holds the rate limiter of each reason.
+		reasons map[string]*fallbackReasonState
+	}
+}
+
+type fallbackReasonState struct {
+	every log.EveryN
+	// skipped is the number of fallbacks not reported since the last
+	// event.
+	skipped uint64
+}
+
+// report reports that the cache was bypassed for the given reason
+// during the login of the given user, unless an event was already
+// reported for the same reason less than fallbackEventInterval ago.
+func (r *fallbackReporter) report(ctx context.Context, reason string, user security.SQLUsername) {
+	r.mu.Lock()
+	if r.mu.reasons == nil {
+		r.mu.reasons = make(map[string]*fallbackReasonState)
+	}
+	state, ok := r.mu.reasons[reason]
+	if !ok {
+		state = &fallbackReasonState{every: log.Every(fallbackEventInterval)}
+		r.mu.reasons[reason] = state
+	}
+	if !state.every.ShouldLog() {
+		state.skipped++
+		r.mu.Unlock()
+		return
+	}
+	skipped := state.skipped
+	state.skipped = 0
+	r.mu.Unlock()
+
+	log.StructuredEvent(ctx, &eventpb.AuthenticationCacheFallback{
+		Reason:        reason,
+		User:          user.Normalized(),
+		SkippedEvents: skipped,
+	})
+}
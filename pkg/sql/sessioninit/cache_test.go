@@ -0,0 +1,185 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sessioninit
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/stretchr/testify/require"
+)
+
+// makeTestCache returns a cache based on version 1 of the system tables.
+func makeTestCache(ctx context.Context, t *testing.T, st *cluster.Settings) (*Cache, func()) {
+	m := mon.NewUnlimitedMonitor(ctx, "test", mon.MemoryResource, nil, nil, math.MaxInt64, st)
+	stopper := stop.NewStopper()
+	c := NewCache(m.MakeBoundAccount(), stopper)
+	c.Lock()
+	require.True(t, c.clearCacheIfStale(ctx, 1, 1, 1))
+	c.Unlock()
+	return c, func() {
+		c.Lock()
+		c.boundAccount.Close(ctx)
+		c.Unlock()
+		m.Stop(ctx)
+		stopper.Stop(ctx)
+	}
+}
+
+func testUser(name string) security.SQLUsername {
+	return security.MakeSQLUsernameFromPreNormalizedString(name)
+}
+
+// TestCacheMaxEntries verifies that the cache holds at most maxEntries
+// entries, and that the entries which do not fit are not cached.
+func TestCacheMaxEntries(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	c, cleanup := makeTestCache(ctx, t, st)
+	defer cleanup()
+
+	const maxEntries = 2
+	for _, name := range []string{"alice", "bob", "carl"} {
+		// The entries which do not fit are not cached, but this does not
+		// prevent the login from proceeding.
+		require.True(t, c.maybeWriteAuthInfoBackToCache(
+			ctx, 1, 1, AuthInfo{UserExists: true}, testUser(name), maxEntries,
+		))
+	}
+	require.Equal(t, maxEntries, c.Info().AuthInfoEntries)
+	for _, tc := range []struct {
+		user   string
+		cached bool
+	}{
+		{"alice", true},
+		{"bob", true},
+		{"carl", false},
+	} {
+		_, found := c.readAuthInfoFromCache(ctx, 1, 1, testUser(tc.user))
+		require.Equal(t, tc.cached, found, tc.user)
+	}
+
+	// The default settings count toward the same limit.
+	settingsEntries := []SettingsCacheEntry{{
+		SettingsCacheKey: SettingsCacheKey{DatabaseID: 1, Username: testUser("alice")},
+		Settings:         []string{"timezone=UTC"},
+	}}
+	require.True(t, c.maybeWriteDefaultSettingsBackToCache(
+		ctx, 1, settingsEntries, testUser("alice"), maxEntries,
+	))
+	require.Zero(t, c.Info().SettingsEntries)
+
+	// A limit of 0 lifts the restriction.
+	require.True(t, c.maybeWriteAuthInfoBackToCache(
+		ctx, 1, 1, AuthInfo{UserExists: true}, testUser("carl"), 0, /* maxEntries */
+	))
+	require.True(t, c.maybeWriteDefaultSettingsBackToCache(
+		ctx, 1, settingsEntries, testUser("alice"), 0, /* maxEntries */
+	))
+	info := c.Info()
+	require.Equal(t, 3, info.AuthInfoEntries)
+	require.Equal(t, 1, info.SettingsEntries)
+}
+
+// TestCacheWriteThroughAuthInfo verifies that WriteThroughAuthInfo stores
+// the AuthInfo of the user for the table versions written by a role change,
+// and invalidates the entries of the older versions.
+func TestCacheWriteThroughAuthInfo(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	st := cluster.MakeTestingClusterSettings()
+	c, cleanup := makeTestCache(ctx, t, st)
+	defer cleanup()
+
+	require.True(t, c.maybeWriteAuthInfoBackToCache(
+		ctx, 1, 1, AuthInfo{UserExists: true}, testUser("alice"), 0, /* maxEntries */
+	))
+	require.True(t, c.maybeWriteAuthInfoBackToCache(
+		ctx, 1, 1, AuthInfo{UserExists: true}, testUser("bob"), 0, /* maxEntries */
+	))
+
+	// The role change bumped the version of role_options.
+	c.WriteThroughAuthInfo(ctx, st, 1, 2, testUser("alice"), AuthInfo{UserExists: true, ReadOnly: true})
+	aInfo, found := c.readAuthInfoFromCache(ctx, 1, 2, testUser("alice"))
+	require.True(t, found)
+	require.True(t, aInfo.ReadOnly)
+	_, found = c.readAuthInfoFromCache(ctx, 1, 2, testUser("bob"))
+	require.False(t, found)
+
+	// The AuthInfo of older table versions is not stored.
+	c.WriteThroughAuthInfo(ctx, st, 1, 1, testUser("bob"), AuthInfo{UserExists: true})
+	_, found = c.readAuthInfoFromCache(ctx, 1, 2, testUser("bob"))
+	require.False(t, found)
+
+	// Nothing is stored when the cache is disabled.
+	CacheEnabled.Override(ctx, &st.SV, false)
+	c.WriteThroughAuthInfo(ctx, st, 1, 3, testUser("bob"), AuthInfo{UserExists: true})
+	require.Equal(t, CacheInfo{
+		AuthInfoEntries:                  1,
+		Bytes:                            c.Info().Bytes,
+		Hits:                             1,
+		Misses:                           2,
+		UsersTableVersion:                1,
+		RoleOptionsTableVersion:          2,
+		DatabaseRoleSettingsTableVersion: 1,
+	}, c.Info())
+}
+
+// TestHitRatioWindow verifies that the hit ratio is reported once per
+// window, rounded down to a multiple of 10.
+func TestHitRatioWindow(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	var w hitRatioWindow
+	start := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	record := func(at time.Duration, found bool) (int64, bool) {
+		return w.record(start.Add(at), found)
+	}
+
+	// Two hits out of three lookups at the start of the first window.
+	for _, found := range []bool{true, false, true} {
+		_, ok := record(0, found)
+		require.False(t, ok)
+	}
+	_, ok := record(hitRatioWindowDuration-time.Nanosecond, true)
+	require.False(t, ok)
+
+	// The first lookup of the next day closes the first window: three hits
+	// out of four lookups are reported as 70%.
+	bucket, ok := record(hitRatioWindowDuration, false)
+	require.True(t, ok)
+	require.Equal(t, int64(70), bucket)
+
+	// The second window starts with that miss, and the first lookup after
+	// several idle days closes it.
+	bucket, ok = record(5*hitRatioWindowDuration, true)
+	require.True(t, ok)
+	require.Equal(t, int64(0), bucket)
+
+	// A window where every lookup hit is the only one reported as 100%.
+	bucket, ok = record(6*hitRatioWindowDuration, true)
+	require.True(t, ok)
+	require.Equal(t, int64(100), bucket)
+}
@@ -40,6 +40,9 @@ func (p *planner) SetSessionCharacteristics(n *tree.SetSessionCharacteristics) (
 		case tree.ReadOnly:
 			m.SetDefaultTransactionReadOnly(true)
 		case tree.ReadWrite:
+			if err := checkReadOnlyLogin(m.data, false /* readOnly */); err != nil {
+				return err
+			}
 			m.SetDefaultTransactionReadOnly(false)
 		case tree.UnspecifiedReadWriteMode:
 		default:
@@ -12,6 +12,7 @@ package sql
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
@@ -400,6 +402,20 @@ func stmtTimeoutVarSet(ctx context.Context, m sessionDataMutator, s string) erro
 	return nil
 }
 
+func transactionTimeoutVarSet(ctx context.Context, m sessionDataMutator, s string) error {
+	timeout, err := validateTimeoutVar(
+		m.data.GetIntervalStyle(),
+		s,
+		"transaction_timeout",
+	)
+	if err != nil {
+		return err
+	}
+
+	m.SetTransactionTimeout(timeout)
+	return nil
+}
+
 func lockTimeoutVarSet(ctx context.Context, m sessionDataMutator, s string) error {
 	timeout, err := validateTimeoutVar(
 		m.data.GetIntervalStyle(),
@@ -480,3 +496,62 @@ func newCannotChangeParameterError(varName string) error {
 	return pgerror.Newf(pgcode.CantChangeRuntimeParam,
 		"parameter %q cannot be changed", varName)
 }
+
+func newLockedParameterError(varName string) error {
+	return pgerror.Newf(pgcode.InsufficientPrivilege,
+		"parameter %q is locked by a default setting of the role", varName)
+}
+
+func newRoleOptionCeilingError(varName string, ceiling fmt.Stringer) error {
+	return pgerror.Newf(pgcode.InsufficientPrivilege,
+		"parameter %q cannot exceed %s, the limit set by a role option of the user",
+		varName, ceiling)
+}
+
+// checkTimeoutCeiling returns an error if the new value of a session
+// variable is a timeout above the limit set by a role option of the user,
+// or disables it. The value the variable was given when the session was
+// established, to which it is reset, is within the limit.
+func (p *planner) checkTimeoutCeiling(varName, newVal string) error {
+	var ceiling time.Duration
+	switch varName {
+	case "statement_timeout":
+		ceiling = p.SessionData().MaxStmtTimeout
+	case "transaction_timeout":
+		ceiling = p.SessionData().MaxTxnTimeout
+	case "idle_in_session_timeout", "idle_session_timeout":
+		ceiling = p.SessionData().MaxIdleSessionTimeout
+	}
+	if ceiling == 0 {
+		return nil
+	}
+	if defVal, ok := p.sessionDataMutatorIterator.defaults[varName]; ok && newVal == defVal {
+		return nil
+	}
+	timeout, err := validateTimeoutVar(p.SessionData().GetIntervalStyle(), newVal, varName)
+	if err != nil {
+		return err
+	}
+	if exceedsTimeoutCeiling(timeout, ceiling) {
+		return newRoleOptionCeilingError(varName, ceiling)
+	}
+	return nil
+}
+
+// checkQualityOfServiceCeiling returns an error if the new value of a
+// session variable is a quality of service above the one set by the
+// QUALITY_OF_SERVICE role option of the user.
+func (p *planner) checkQualityOfServiceCeiling(varName, newVal string) error {
+	if varName != "default_transaction_quality_of_service" {
+		return nil
+	}
+	ceiling, ok := sessiondatapb.ParseQoSLevelFromString(p.SessionData().MaxQualityOfService)
+	if !ok {
+		return nil
+	}
+	// The invalid values are rejected when the variable is set.
+	if qos, ok := sessiondatapb.ParseQoSLevelFromString(newVal); ok && qos > ceiling {
+		return newRoleOptionCeilingError(varName, ceiling)
+	}
+	return nil
+}
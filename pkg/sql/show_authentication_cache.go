@@ -0,0 +1,72 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessioninit"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+var showAuthenticationCacheColumns = colinfo.ResultColumns{
+	{Name: "node_id", Typ: types.Int},
+	{Name: "enabled", Typ: types.Bool},
+	{Name: "auth_info_entries", Typ: types.Int},
+	{Name: "settings_entries", Typ: types.Int},
+	{Name: "bytes", Typ: types.Int},
+	{Name: "hits", Typ: types.Int},
+	{Name: "misses", Typ: types.Int},
+	{Name: "hit_ratio", Typ: types.Float},
+	{Name: "users_table_version", Typ: types.Int},
+	{Name: "role_options_table_version", Typ: types.Int},
+	{Name: "database_role_settings_table_version", Typ: types.Int},
+}
+
+// ShowAuthenticationCache returns a SHOW AUTHENTICATION CACHE statement.
+// Privileges: admin.
+func (p *planner) ShowAuthenticationCache(
+	ctx context.Context, n *tree.ShowAuthenticationCache,
+) (planNode, error) {
+	if err := p.RequireAdminRole(ctx, "SHOW AUTHENTICATION CACHE"); err != nil {
+		return nil, err
+	}
+	return &delayedNode{
+		name:    n.String(),
+		columns: showAuthenticationCacheColumns,
+		constructor: func(ctx context.Context, p *planner) (planNode, error) {
+			execCfg := p.ExecCfg()
+			info := execCfg.SessionInitCache.Info()
+			nodeID, _ := execCfg.NodeID.OptionalNodeID() // zero if not available
+			row := tree.Datums{
+				tree.NewDInt(tree.DInt(nodeID)),
+				tree.MakeDBool(tree.DBool(sessioninit.CacheEnabled.Get(&execCfg.Settings.SV))),
+				tree.NewDInt(tree.DInt(info.AuthInfoEntries)),
+				tree.NewDInt(tree.DInt(info.SettingsEntries)),
+				tree.NewDInt(tree.DInt(info.Bytes)),
+				tree.NewDInt(tree.DInt(info.Hits)),
+				tree.NewDInt(tree.DInt(info.Misses)),
+				tree.NewDFloat(tree.DFloat(info.HitRatio())),
+				tree.NewDInt(tree.DInt(info.UsersTableVersion)),
+				tree.NewDInt(tree.DInt(info.RoleOptionsTableVersion)),
+				tree.NewDInt(tree.DInt(info.DatabaseRoleSettingsTableVersion)),
+			}
+			v := p.newContainerValuesNode(showAuthenticationCacheColumns, 1)
+			if _, err := v.rows.AddRow(ctx, row); err != nil {
+				v.Close(ctx)
+				return nil, err
+			}
+			return v, nil
+		},
+	}, nil
+}
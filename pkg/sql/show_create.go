@@ -13,11 +13,15 @@ package sql
 import (
 	"bytes"
 	"context"
+	"go/constant"
+	"strconv"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catformat"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/multiregion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -245,3 +249,109 @@ func (p *planner) ShowCreate(
 
 	return stmt, err
 }
+
+// showCreateDatabaseAlterStatements returns the statements which, run after
+// the CREATE DATABASE statement of a multi-region database, restore the parts
+// of its topology which CREATE DATABASE cannot express: the super regions, the
+// secondary region if the database has super regions (as the secondary region
+// must be in the super region of the primary region), the fallback regions of
+// the primary region, the replica counts set along with the survival goal or
+// for individual regions, the default table locality and the comments on the
+// regions.
+//
+// The tables which are exceptions to the RESTRICTED placement policy are not
+// included, as they do not exist yet when the database is created.
+func showCreateDatabaseAlterStatements(
+	db catalog.DatabaseDescriptor,
+	regionConfig multiregion.RegionConfig,
+	survivalGoal tree.SurvivalGoal,
+	comments map[catpb.RegionName]string,
+) ([]string, error) {
+	dbName := tree.Name(db.GetName())
+	var stmts []tree.Statement
+	for _, superRegion := range regionConfig.SuperRegions() {
+		regions := make([]tree.Name, len(superRegion.Regions))
+		for i, region := range superRegion.Regions {
+			regions[i] = tree.Name(region)
+		}
+		stmts = append(stmts, &tree.AlterDatabaseAddSuperRegion{
+			DatabaseName:    dbName,
+			SuperRegionName: tree.Name(superRegion.SuperRegionName),
+			Regions:         regions,
+		})
+	}
+	if len(regionConfig.SuperRegions()) > 0 && regionConfig.HasSecondaryRegion() {
+		stmts = append(stmts, &tree.AlterDatabaseSecondaryRegion{
+			DatabaseName:    dbName,
+			SecondaryRegion: tree.Name(regionConfig.SecondaryRegion()),
+		})
+	}
+	if fallbackRegions := regionConfig.FallbackRegions(); len(fallbackRegions) > 0 {
+		regions := make(tree.NameList, len(fallbackRegions))
+		for i, region := range fallbackRegions {
+			regions[i] = tree.Name(region)
+		}
+		stmts = append(stmts, &tree.AlterDatabasePrimaryRegion{
+			Name:            dbName,
+			PrimaryRegion:   tree.Name(regionConfig.PrimaryRegion()),
+			FallbackRegions: regions,
+		})
+	}
+	if numVoters, numReplicas := regionConfig.SurvivalGoalReplicas(); numVoters > 0 || numReplicas > 0 {
+		stmts = append(stmts, &tree.AlterDatabaseSurvivalGoal{
+			Name:         dbName,
+			SurvivalGoal: survivalGoal,
+			NumVoters:    replicaCountExpr(numVoters),
+			NumReplicas:  replicaCountExpr(numReplicas),
+		})
+	}
+	for _, region := range regionConfig.Regions() {
+		override, ok := regionConfig.ReplicaOverride(region)
+		if !ok {
+			continue
+		}
+		stmts = append(stmts, &tree.AlterDatabaseRegionReplicas{
+			DatabaseName: dbName,
+			Region:       tree.Name(region),
+			NumVoters:    replicaCountExpr(override.NumVoters),
+			NumReplicas:  replicaCountExpr(override.NumReplicas),
+		})
+	}
+	if lc := db.GetRegionConfig().DefaultTableLocality; lc != nil {
+		locality, err := localityFromLocalityConfig(lc)
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, &tree.AlterDatabaseDefaultLocality{
+			Name:     dbName,
+			Locality: locality,
+		})
+	}
+	for _, region := range regionConfig.Regions() {
+		comment, ok := comments[region]
+		if !ok {
+			continue
+		}
+		stmts = append(stmts, &tree.CommentOnRegion{
+			Region:   tree.Name(region),
+			Database: dbName,
+			Comment:  &comment,
+		})
+	}
+
+	ret := make([]string, len(stmts))
+	for i, stmt := range stmts {
+		ret[i] = tree.AsString(stmt)
+	}
+	return ret, nil
+}
+
+// replicaCountExpr returns the expression of a replica count in the
+// statements returned by showCreateDatabaseAlterStatements, or nil if the count
+// is not set.
+func replicaCountExpr(count int32) tree.Expr {
+	if count == 0 {
+		return nil
+	}
+	return tree.NewNumVal(constant.MakeInt64(int64(count)), strconv.Itoa(int(count)), false /* negative */)
+}
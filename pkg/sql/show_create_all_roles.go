@@ -0,0 +1,312 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+var showCreateAllRolesColumns = colinfo.ResultColumns{
+	{Name: "create_statement", Typ: types.String},
+}
+
+// ShowCreateAllRoles returns a SHOW CREATE ALL ROLES statement. It lists
+// the statements which recreate the roles of the cluster, except root and
+// admin, so that they can be replayed in another cluster: a CREATE
+// statement per role with its options and its hashed password, then the
+// GRANT statements of the memberships of the roles, then the ALTER ROLE
+// ... SET statements of the default session settings of the roles.
+// Privileges: admin.
+func (p *planner) ShowCreateAllRoles(
+	ctx context.Context, n *tree.ShowCreateAllRoles,
+) (planNode, error) {
+	if err := p.RequireAdminRole(ctx, "SHOW CREATE ALL ROLES"); err != nil {
+		return nil, err
+	}
+
+	return &delayedNode{
+		name:    n.String(),
+		columns: showCreateAllRolesColumns,
+		constructor: func(ctx context.Context, p *planner) (planNode, error) {
+			var stmts []tree.Statement
+			for _, f := range []func(context.Context) ([]tree.Statement, error){
+				p.createRoleStatements,
+				p.grantRoleStatements,
+				p.alterRoleSetStatements,
+			} {
+				s, err := f(ctx)
+				if err != nil {
+					return nil, err
+				}
+				stmts = append(stmts, s...)
+			}
+
+			v := p.newContainerValuesNode(showCreateAllRolesColumns, len(stmts))
+			for _, stmt := range stmts {
+				row := tree.Datums{
+					tree.NewDString(tree.AsStringWithFlags(stmt, tree.FmtShowPasswords)),
+				}
+				if _, err := v.rows.AddRow(ctx, row); err != nil {
+					v.Close(ctx)
+					return nil, err
+				}
+			}
+			return v, nil
+		},
+	}, nil
+}
+
+// queryRoleTable runs a query against the system tables of the roles on
+// behalf of SHOW CREATE ALL ROLES.
+func (p *planner) queryRoleTable(
+	ctx context.Context, opName string, query string,
+) ([]tree.Datums, error) {
+	return p.ExecCfg().InternalExecutor.QueryBufferedEx(
+		ctx, opName, p.txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		query,
+	)
+}
+
+// isReservedRole returns whether the role exists in every cluster, and so
+// is not recreated by SHOW CREATE ALL ROLES.
+func isReservedRole(name string) bool {
+	return name == security.RootUser || name == security.AdminRole
+}
+
+// createRoleStatements returns the CREATE ROLE and CREATE USER statements
+// which recreate the roles with their options and hashed passwords.
+func (p *planner) createRoleStatements(ctx context.Context) ([]tree.Statement, error) {
+	users, err := p.queryRoleTable(ctx, "show-create-all-roles-users",
+		`SELECT username, "hashedPassword", "isRole" FROM system.public.users ORDER BY username`)
+	if err != nil {
+		return nil, err
+	}
+	options, err := p.queryRoleTable(ctx, "show-create-all-roles-options",
+		`SELECT username, option, value FROM system.public.role_options ORDER BY username, option`)
+	if err != nil {
+		return nil, err
+	}
+	optionsByRole := make(map[string][]tree.Datums)
+	for _, row := range options {
+		name := string(tree.MustBeDString(row[0]))
+		optionsByRole[name] = append(optionsByRole[name], row)
+	}
+
+	var stmts []tree.Statement
+	for _, row := range users {
+		name := string(tree.MustBeDString(row[0]))
+		if isReservedRole(name) {
+			continue
+		}
+		stmt := &tree.CreateRole{
+			Name:   tree.MakeRoleSpecWithRoleName(name),
+			IsRole: bool(tree.MustBeDBool(row[2])),
+		}
+		canLogin := true
+		for _, optionRow := range optionsByRole[name] {
+			option := string(tree.MustBeDString(optionRow[1]))
+			// Some rows hold internal state rather than role options, such
+			// as the hashes of the previous passwords of the user, which
+			// must not be listed.
+			if _, ok := roleoption.ByName[option]; !ok {
+				continue
+			}
+			if option == roleoption.NOLOGIN.String() {
+				canLogin = false
+			}
+			kvOption, ok, err := makeRoleOptionKVOption(option, optionRow[2])
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+			stmt.KVOptions = append(stmt.KVOptions, kvOption)
+		}
+		// Unlike users, the roles cannot log in by default.
+		if stmt.IsRole && canLogin {
+			stmt.KVOptions = append(stmt.KVOptions, tree.KVOption{Key: "login"})
+		}
+		if row[1] != tree.DNull {
+			hash := security.LoadPasswordHash(ctx, []byte(tree.MustBeDBytes(row[1])))
+			if preHashed, ok := security.PreHashedPassword(hash); ok {
+				stmt.KVOptions = append(stmt.KVOptions, tree.KVOption{
+					Key: "password", Value: tree.NewDString(preHashed),
+				})
+			}
+		}
+		stmts = append(stmts, stmt)
+	}
+	return stmts, nil
+}
+
+// makeRoleOptionKVOption returns the role option stored in
+// system.role_options as it appears in a CREATE ROLE statement. It returns
+// false for an option whose value was reset to NULL, which is the same as
+// an unset option.
+func makeRoleOptionKVOption(option string, value tree.Datum) (tree.KVOption, bool, error) {
+	kvOption := tree.KVOption{Key: tree.Name(strings.ToLower(option))}
+	if value == tree.DNull {
+		switch option {
+		case roleoption.VALIDUNTIL.String(), roleoption.CONNECTIONLIMIT.String(),
+			roleoption.SUBJECT.String(), roleoption.ALLOWEDDATABASES.String(),
+			roleoption.LOGINWINDOW.String(), roleoption.PROFILE.String(),
+			roleoption.IDLESESSIONTIMEOUT.String(), roleoption.STATEMENTTIMEOUT.String(),
+			roleoption.TRANSACTIONTIMEOUT.String(), roleoption.QUALITYOFSERVICE.String(),
+			roleoption.MAXRESULTROWS.String(), roleoption.MAXRESULTBYTES.String():
+			return tree.KVOption{}, false, nil
+		}
+		return kvOption, true, nil
+	}
+	s := string(tree.MustBeDString(value))
+	switch option {
+	case roleoption.CONNECTIONLIMIT.String(), roleoption.MAXRESULTROWS.String():
+		limit, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return tree.KVOption{}, false, errors.Wrapf(err, "invalid %s %q", strings.ToLower(option), s)
+		}
+		kvOption.Value = tree.NewDInt(tree.DInt(limit))
+	case roleoption.ALLOWEDDATABASES.String():
+		databases, err := roleoption.DecodeValueList(s)
+		if err != nil {
+			return tree.KVOption{}, false, err
+		}
+		t := &tree.Tuple{}
+		for _, db := range databases {
+			t.Exprs = append(t.Exprs, tree.NewDString(db))
+		}
+		kvOption.Value = t
+	case roleoption.LOGINWINDOW.String():
+		w, err := roleoption.DecodeLoginWindow(s)
+		if err != nil {
+			return tree.KVOption{}, false, err
+		}
+		t := &tree.Tuple{Exprs: tree.Exprs{tree.NewDString(w.Start), tree.NewDString(w.End), tree.DNull}}
+		if w.TimeZone != "" {
+			t.Exprs[2] = tree.NewDString(w.TimeZone)
+		}
+		for _, day := range w.Days {
+			t.Exprs = append(t.Exprs, tree.NewDString(day))
+		}
+		kvOption.Value = t
+	case roleoption.PROFILE.String():
+		kvOption.Value = tree.NewStrVal(s)
+	default:
+		kvOption.Value = tree.NewDString(s)
+	}
+	return kvOption, true, nil
+}
+
+// grantRoleStatements returns the GRANT statements which recreate the
+// memberships of the roles.
+func (p *planner) grantRoleStatements(ctx context.Context) ([]tree.Statement, error) {
+	members, err := p.queryRoleTable(ctx, "show-create-all-roles-members",
+		`SELECT "role", member, "isAdmin" FROM system.public.role_members ORDER BY "role", member`)
+	if err != nil {
+		return nil, err
+	}
+	var stmts []tree.Statement
+	for _, row := range members {
+		role := string(tree.MustBeDString(row[0]))
+		member := string(tree.MustBeDString(row[1]))
+		if role == security.AdminRole && member == security.RootUser {
+			continue
+		}
+		stmts = append(stmts, &tree.GrantRole{
+			Roles:       tree.NameList{tree.Name(role)},
+			Members:     tree.RoleSpecList{tree.MakeRoleSpecWithRoleName(member)},
+			AdminOption: bool(tree.MustBeDBool(row[2])),
+		})
+	}
+	return stmts, nil
+}
+
+// alterRoleSetStatements returns the ALTER ROLE ... SET statements which
+// recreate the default session settings of the roles. The settings of
+// the databases which were dropped are skipped.
+func (p *planner) alterRoleSetStatements(ctx context.Context) ([]tree.Statement, error) {
+	scopeColumns := `'' AS application_name, '' AS source_network`
+	if p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.DatabaseRoleSettingsSourceNetwork) {
+		scopeColumns = `application_name, source_network`
+	} else if p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.DatabaseRoleSettingsApplicationName) {
+		scopeColumns = `application_name, '' AS source_network`
+	}
+	lockedSettingsColumn := `NULL::STRING[] AS locked_settings`
+	if p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.DatabaseRoleSettingsLocked) {
+		lockedSettingsColumn = `locked_settings`
+	}
+	settings, err := p.queryRoleTable(ctx, "show-create-all-roles-settings", `
+SELECT database_id, role_name, `+scopeColumns+`, settings, `+lockedSettingsColumn+`
+FROM system.public.database_role_settings
+ORDER BY 1, 2, 3, 4`)
+	if err != nil {
+		return nil, err
+	}
+
+	var stmts []tree.Statement
+	for _, row := range settings {
+		base := tree.AlterRoleSet{
+			IsRole:          true,
+			ApplicationName: string(tree.MustBeDString(row[2])),
+			SourceNetwork:   string(tree.MustBeDString(row[3])),
+		}
+		if databaseID := descpb.ID(tree.MustBeDOid(row[0]).DInt); databaseID != 0 {
+			found, dbDesc, err := p.Descriptors().GetImmutableDatabaseByID(
+				ctx, p.txn, databaseID, tree.DatabaseLookupFlags{AvoidLeased: true},
+			)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				continue
+			}
+			base.DatabaseName = tree.Name(dbDesc.GetName())
+		}
+		if roleName := string(tree.MustBeDString(row[1])); roleName != "" {
+			base.RoleName = tree.MakeRoleSpecWithRoleName(roleName)
+		} else {
+			base.AllRoles = true
+		}
+		locked := make(map[string]struct{})
+		if row[5] != tree.DNull {
+			for _, name := range tree.MustBeDArray(row[5]).Array {
+				locked[string(tree.MustBeDString(name))] = struct{}{}
+			}
+		}
+		for _, s := range tree.MustBeDArray(row[4]).Array {
+			keyVal := strings.SplitN(string(tree.MustBeDString(s)), "=", 2)
+			if len(keyVal) != 2 {
+				continue
+			}
+			stmt := base
+			stmt.SetOrReset = &tree.SetVar{
+				Name:   keyVal[0],
+				Values: tree.Exprs{tree.NewDString(keyVal[1])},
+			}
+			_, stmt.Locked = locked[keyVal[0]]
+			stmts = append(stmts, &stmt)
+		}
+	}
+	return stmts, nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessioninit"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+var showDefaultSessionVariablesColumns = colinfo.ResultColumns{
+	{Name: "variable", Typ: types.String},
+	{Name: "value", Typ: types.String},
+	{Name: "database_name", Typ: types.String},
+	{Name: "role_name", Typ: types.String},
+}
+
+// ShowDefaultSessionVariables returns a SHOW DEFAULT SESSION VARIABLES
+// statement. It lists the defaults of the session variables which apply
+// when the role logs into the database, along with the
+// system.database_role_settings row each of them comes from. The role
+// inherits the defaults of the roles it is a member of. A NULL
+// database_name or role_name stands for the defaults of all the databases
+// or all the roles.
+// Privileges: CREATEROLE, unless the role is the current user.
+func (p *planner) ShowDefaultSessionVariables(
+	ctx context.Context, n *tree.ShowDefaultSessionVariables,
+) (planNode, error) {
+	roleName, err := n.Role.ToSQLUsername(p.SessionData(), security.UsernameValidation)
+	if err != nil {
+		return nil, err
+	}
+	if roleName != p.User() {
+		if err := p.CheckRoleOption(ctx, roleoption.CREATEROLE); err != nil {
+			return nil, err
+		}
+	}
+
+	dbName := string(n.DatabaseName)
+	if dbName == "" {
+		dbName = p.CurrentDatabase()
+	}
+	dbID := descpb.ID(0)
+	if dbName != "" {
+		dbDesc, err := p.Descriptors().GetImmutableDatabaseByName(ctx, p.txn, dbName,
+			tree.DatabaseLookupFlags{Required: true})
+		if err != nil {
+			return nil, err
+		}
+		dbID = dbDesc.GetID()
+	}
+
+	return &delayedNode{
+		name:    n.String(),
+		columns: showDefaultSessionVariablesColumns,
+		constructor: func(ctx context.Context, p *planner) (planNode, error) {
+			exists, err := p.RoleExists(ctx, roleName)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				return nil, pgerror.Newf(pgcode.UndefinedObject, "role/user %s does not exist", roleName)
+			}
+
+			settings, err := p.getEffectiveDefaultSettings(ctx, roleName, dbID)
+			if err != nil {
+				return nil, err
+			}
+			v := p.newContainerValuesNode(showDefaultSessionVariablesColumns, 0)
+			for _, setting := range settings {
+				dbDatum, roleDatum := tree.DNull, tree.DNull
+				if setting.source.DatabaseID != 0 {
+					dbDatum = tree.NewDString(dbName)
+				}
+				if !setting.source.Username.Undefined() {
+					roleDatum = tree.NewDString(setting.source.Username.Normalized())
+				}
+				row := tree.Datums{
+					tree.NewDString(setting.variable),
+					tree.NewDString(setting.value),
+					dbDatum,
+					roleDatum,
+				}
+				if _, err := v.rows.AddRow(ctx, row); err != nil {
+					v.Close(ctx)
+					return nil, err
+				}
+			}
+			return v, nil
+		},
+	}, nil
+}
+
+// effectiveDefaultSetting is a default session setting which applies when
+// a role logs into a database.
+type effectiveDefaultSetting struct {
+	variable string
+	value    string
+	// source identifies the system.database_role_settings row the setting
+	// comes from. A zero DatabaseID or an undefined Username stand for all
+	// the databases or all the roles.
+	source sessioninit.SettingsCacheKey
+}
+
+// getEffectiveDefaultSettings returns the default session settings which
+// apply when the role logs into the database with the given ID, including
+// the ones inherited from the roles it is a member of. The settings are
+// resolved as when the sessions are initialized: the malformed and invalid
+// settings are skipped, and a setting with a higher precedence hides the
+// others, unless only the latter was set with LOCKED. The settings are
+// returned in order of precedence.
+func (p *planner) getEffectiveDefaultSettings(
+	ctx context.Context, roleName security.SQLUsername, dbID descpb.ID,
+) ([]effectiveDefaultSetting, error) {
+	memberships, err := p.MemberOfWithAdminOption(ctx, roleName)
+	if err != nil {
+		return nil, err
+	}
+	settingsEntries, err := retrieveDefaultSettings(
+		ctx, p.ExecCfg().Settings, p.txn, p.ExecCfg().InternalExecutor, roleName,
+		sortedMemberships(memberships), dbID, "" /* applicationName */, "", /* sourceAddress */
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The settings set with LOCKED take precedence over the others.
+	var settings []effectiveDefaultSetting
+	seen := make(map[string]struct{})
+	for _, locked := range []bool{true, false} {
+		for _, entry := range settingsEntries {
+			for _, setting := range entry.Settings {
+				if entry.IsLocked(setting) != locked {
+					continue
+				}
+				keyVal := strings.SplitN(setting, "=", 2)
+				if len(keyVal) != 2 {
+					continue
+				}
+				if _, ok := seen[keyVal[0]]; ok {
+					continue
+				}
+				if err := CheckSessionVariableValueValid(
+					ctx, p.ExecCfg().Settings, keyVal[0], keyVal[1],
+				); err != nil {
+					continue
+				}
+				seen[keyVal[0]] = struct{}{}
+				settings = append(settings, effectiveDefaultSetting{
+					variable: keyVal[0],
+					value:    keyVal[1],
+					source:   entry.SettingsCacheKey,
+				})
+			}
+		}
+	}
+	return settings, nil
+}
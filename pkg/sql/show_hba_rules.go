@@ -0,0 +1,220 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// HBARule describes a rule of a host-based authentication (HBA)
+// configuration, as reported by SHOW HBA RULES.
+type HBARule struct {
+	ConnType string
+	Database string
+	User     string
+	Address  string
+	Method   string
+	Options  string
+	// Error explains why the rule is invalid or can never match, if
+	// applicable.
+	Error string
+	// Matches is true for the rule that applies to the HBAConnection
+	// passed to ListHBARules, if any.
+	Matches bool
+	// Counts is only set for the rules of the active configuration of
+	// the node.
+	Counts *HBARuleCounts
+}
+
+// HBARuleCounts counts the client connections matched by a rule of the
+// active HBA configuration since it was loaded, and the outcome of
+// their authentication.
+type HBARuleCounts struct {
+	Matches   int64
+	Successes int64
+	Failures  int64
+}
+
+// HBAConnection describes a hypothetical client connection, to report
+// which HBA rule applies to it.
+type HBAConnection struct {
+	// ConnType is "hostssl", "hostnossl" or "local".
+	ConnType string
+	// Address is the IP address of the client. It is ignored for local
+	// connections.
+	Address  string
+	User     security.SQLUsername
+	Database string
+}
+
+// HBARuleLister lists the rules of HBA configurations. It is
+// implemented by the pgwire server.
+type HBARuleLister interface {
+	// ListHBARules returns the rules of the proposed HBA configuration
+	// config, or those of the active configuration of the node if config
+	// is nil. If conn is not nil, the rule which applies to it is marked.
+	ListHBARules(ctx context.Context, config *string, conn *HBAConnection) ([]HBARule, error)
+}
+
+var showHBARulesColumns = colinfo.ResultColumns{
+	{Name: "rule", Typ: types.Int},
+	{Name: "type", Typ: types.String},
+	{Name: "database", Typ: types.String},
+	{Name: "user_name", Typ: types.String},
+	{Name: "address", Typ: types.String},
+	{Name: "auth_method", Typ: types.String},
+	{Name: "options", Typ: types.String},
+	{Name: "error", Typ: types.String},
+	{Name: "matches", Typ: types.Bool},
+	{Name: "match_count", Typ: types.Int},
+	{Name: "success_count", Typ: types.Int},
+	{Name: "failure_count", Typ: types.Int},
+}
+
+var showHBARulesOptions = map[string]KVStringOptValidate{
+	"username": KVStringOptRequireValue,
+	"address":  KVStringOptRequireValue,
+	"database": KVStringOptRequireValue,
+	"type":     KVStringOptRequireValue,
+}
+
+// ShowHBARules returns a SHOW HBA RULES statement.
+// Privileges: admin.
+func (p *planner) ShowHBARules(ctx context.Context, n *tree.ShowHBARules) (planNode, error) {
+	if err := p.RequireAdminRole(ctx, "SHOW HBA RULES"); err != nil {
+		return nil, err
+	}
+	var configFn func() (string, error)
+	if n.Config != nil {
+		var err error
+		configFn, err = p.TypeAsString(ctx, n.Config, "SHOW HBA RULES")
+		if err != nil {
+			return nil, err
+		}
+	}
+	optsFn, err := p.TypeAsStringOpts(ctx, n.Options, showHBARulesOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &delayedNode{
+		name:    n.String(),
+		columns: showHBARulesColumns,
+		constructor: func(ctx context.Context, p *planner) (planNode, error) {
+			var config *string
+			if configFn != nil {
+				s, err := configFn()
+				if err != nil {
+					return nil, err
+				}
+				config = &s
+			}
+			opts, err := optsFn()
+			if err != nil {
+				return nil, err
+			}
+			var conn *HBAConnection
+			if len(opts) > 0 {
+				if conn, err = makeHBAConnection(opts); err != nil {
+					return nil, err
+				}
+			}
+
+			lister := p.ExecCfg().HBARules
+			if lister == nil {
+				return nil, errors.AssertionFailedf("HBA rules are not available")
+			}
+			rules, err := lister.ListHBARules(ctx, config, conn)
+			if err != nil {
+				return nil, err
+			}
+
+			v := p.newContainerValuesNode(showHBARulesColumns, len(rules))
+			for i, r := range rules {
+				errDatum := tree.DNull
+				if r.Error != "" {
+					errDatum = tree.NewDString(r.Error)
+				}
+				matches := tree.DNull
+				if conn != nil {
+					matches = tree.MakeDBool(tree.DBool(r.Matches))
+				}
+				matchCount, successCount, failureCount := tree.DNull, tree.DNull, tree.DNull
+				if r.Counts != nil {
+					matchCount = tree.NewDInt(tree.DInt(r.Counts.Matches))
+					successCount = tree.NewDInt(tree.DInt(r.Counts.Successes))
+					failureCount = tree.NewDInt(tree.DInt(r.Counts.Failures))
+				}
+				row := tree.Datums{
+					tree.NewDInt(tree.DInt(i + 1)),
+					tree.NewDString(r.ConnType),
+					tree.NewDString(r.Database),
+					tree.NewDString(r.User),
+					tree.NewDString(r.Address),
+					tree.NewDString(r.Method),
+					tree.NewDString(r.Options),
+					errDatum,
+					matches,
+					matchCount,
+					successCount,
+					failureCount,
+				}
+				if _, err := v.rows.AddRow(ctx, row); err != nil {
+					v.Close(ctx)
+					return nil, err
+				}
+			}
+			return v, nil
+		},
+	}, nil
+}
+
+// makeHBAConnection creates the HBAConnection described by the options
+// of a SHOW HBA RULES statement.
+func makeHBAConnection(opts map[string]string) (*HBAConnection, error) {
+	userName, ok := opts["username"]
+	if !ok {
+		return nil, pgerror.New(pgcode.InvalidParameterValue,
+			"the username option is required to report the matching rule")
+	}
+	user, err := security.MakeSQLUsernameFromUserInput(userName, security.UsernameValidation)
+	if err != nil {
+		return nil, err
+	}
+	conn := &HBAConnection{
+		ConnType: "hostssl",
+		User:     user,
+		Address:  opts["address"],
+		Database: opts["database"],
+	}
+	if connType, ok := opts["type"]; ok {
+		switch connType {
+		case "hostssl", "hostnossl", "local":
+			conn.ConnType = connType
+		default:
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid connection type %q: expected hostssl, hostnossl or local", connType)
+		}
+	}
+	if conn.ConnType != "local" && conn.Address == "" {
+		return nil, pgerror.New(pgcode.InvalidParameterValue,
+			"the address option is required to report the matching rule")
+	}
+	return conn, nil
+}
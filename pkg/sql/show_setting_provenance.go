@@ -0,0 +1,181 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+var showSettingProvenanceColumns = colinfo.ResultColumns{
+	{Name: "source", Typ: types.String},
+	{Name: "value", Typ: types.String},
+	{Name: "source_role", Typ: types.String},
+	{Name: "in_effect", Typ: types.Bool},
+}
+
+// The sources of the values of the session variables listed by SHOW
+// SETTING PROVENANCE, in addition to the sources of the default settings
+// of the roles.
+const (
+	settingSourceSession    = "session"
+	settingSourceRoleOption = "role option"
+	settingSourceWebhook    = "authorization webhook"
+	settingSourceClient     = "client"
+)
+
+// ShowSettingProvenance returns a SHOW SETTING PROVENANCE statement. It
+// lists the sources which provide a value for the session variable, in
+// order of precedence: a SET statement of the session, the values provided
+// when the session was established, and the default value of the
+// variable. The value of the variable comes from the first source, which is
+// the only one in effect.
+// Privileges: None.
+func (p *planner) ShowSettingProvenance(
+	ctx context.Context, n *tree.ShowSettingProvenance,
+) (planNode, error) {
+	name := strings.ToLower(n.Name)
+	_, v, err := getSessionVar(name, false /* missingOk */)
+	if err != nil {
+		return nil, err
+	}
+
+	return &delayedNode{
+		name:    n.String(),
+		columns: showSettingProvenanceColumns,
+		constructor: func(ctx context.Context, p *planner) (planNode, error) {
+			current, err := v.Get(p.ExtendedEvalContext())
+			if err != nil {
+				return nil, err
+			}
+
+			var provenance []SessionDefaultProvenance
+			for _, entry := range p.extendedEvalCtx.defaultsProvenance {
+				if entry.Variable == name {
+					provenance = append(provenance, entry)
+				}
+			}
+			// The default settings of the roles were recorded in order of
+			// precedence, which the stable sort preserves.
+			sort.SliceStable(provenance, func(i, j int) bool {
+				return provenance[i].Source < provenance[j].Source
+			})
+
+			// The value of the variable comes from the session if it differs
+			// from the value it had when the session was established.
+			hasDefault, defaultValue := true, ""
+			if len(provenance) > 0 {
+				defaultValue = provenance[0].Value
+			} else {
+				hasDefault, defaultValue = getSessionVarDefaultString(
+					name, v, p.sessionDataMutatorIterator.sessionDataMutatorBase,
+				)
+			}
+
+			type provenanceRow struct {
+				source     string
+				value      tree.Datum
+				sourceRole tree.Datum
+			}
+			var rows []provenanceRow
+			if hasDefault && p.normalizeSessionVarValue(ctx, v, defaultValue) != current {
+				rows = append(rows, provenanceRow{
+					source: settingSourceSession, value: tree.NewDString(current), sourceRole: tree.DNull,
+				})
+			}
+			for _, entry := range provenance {
+				row := provenanceRow{
+					source: entry.sourceName(), value: tree.NewDString(entry.Value), sourceRole: tree.DNull,
+				}
+				if !entry.Setting.Username.Undefined() {
+					row.sourceRole = tree.NewDString(entry.Setting.Username.Normalized())
+				}
+				rows = append(rows, row)
+			}
+			// A variable with no default value has the value it was given when
+			// the session was created.
+			defaultRow := provenanceRow{
+				source: settingSourceDefault, value: tree.NewDString(current), sourceRole: tree.DNull,
+			}
+			if v.GlobalDefault != nil {
+				defaultRow.value = tree.NewDString(v.GlobalDefault(&p.ExecCfg().Settings.SV))
+			}
+			rows = append(rows, defaultRow)
+
+			values := p.newContainerValuesNode(showSettingProvenanceColumns, len(rows))
+			for i, row := range rows {
+				if _, err := values.rows.AddRow(ctx, tree.Datums{
+					tree.NewDString(row.source),
+					row.value,
+					row.sourceRole,
+					tree.MakeDBool(i == 0),
+				}); err != nil {
+					values.Close(ctx)
+					return nil, err
+				}
+			}
+			return values, nil
+		},
+	}, nil
+}
+
+// normalizeSessionVarValue returns the value the session variable would
+// have if it were set to the given value, so that it can be compared with
+// its current value. The value is returned as is if the variable cannot be
+// set to it.
+func (p *planner) normalizeSessionVarValue(
+	ctx context.Context, v sessionVar, value string,
+) string {
+	if v.Set == nil {
+		return value
+	}
+	sd := p.SessionData().Clone()
+	m := sessionDataMutator{
+		data: sd,
+		sessionDataMutatorBase: sessionDataMutatorBase{
+			defaults: SessionDefaults(map[string]string{}),
+			settings: p.ExecCfg().Settings,
+		},
+	}
+	if err := v.Set(ctx, m, value); err != nil {
+		return value
+	}
+	evalCtx := p.ExtendedEvalContextCopy()
+	evalCtx.SessionDataStack = sessiondata.NewStack(sd)
+	normalized, err := v.Get(evalCtx)
+	if err != nil {
+		return value
+	}
+	return normalized
+}
+
+// sourceName returns the name of the source of the value, as listed by
+// SHOW SETTING PROVENANCE.
+func (p SessionDefaultProvenance) sourceName() string {
+	switch p.Source {
+	case SessionDefaultSourceRoleOption:
+		return settingSourceRoleOption
+	case SessionDefaultSourceWebhook:
+		return settingSourceWebhook
+	case SessionDefaultSourceClient:
+		return settingSourceClient
+	case SessionDefaultSourceLockedRoleSetting:
+		return settingSourceOfKey(p.Setting) + " (locked)"
+	default:
+		return settingSourceOfKey(p.Setting)
+	}
+}
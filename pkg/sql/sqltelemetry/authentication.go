@@ -0,0 +1,71 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sqltelemetry
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+)
+
+// AuthCacheHitCounter is to be incremented every time a lookup in the
+// authentication cache is served from memory.
+var AuthCacheHitCounter = telemetry.GetCounterOnce("auth.cache.hit")
+
+// AuthCacheMissCounter is to be incremented every time a lookup in the
+// authentication cache needs to read the system tables.
+var AuthCacheMissCounter = telemetry.GetCounterOnce("auth.cache.miss")
+
+// AuthCacheDisabledCounter is to be incremented every time authentication
+// information is looked up while the authentication cache is disabled.
+var AuthCacheDisabledCounter = telemetry.GetCounterOnce("auth.cache.disabled")
+
+// AuthCacheDailyHitRatioCounter is to be incremented at the end of every day
+// during which the authentication cache was looked up, with the hit ratio of
+// that day as a percentage rounded down to a multiple of 10.
+func AuthCacheDailyHitRatioCounter(bucket int64) telemetry.Counter {
+	return telemetry.GetCounter(fmt.Sprintf("auth.cache.daily_hit_ratio.%d", bucket))
+}
+
+const (
+	// AuthCacheFallbackUncommittedVersion is used when the cache is bypassed
+	// because a system table descriptor has an uncommitted version.
+	AuthCacheFallbackUncommittedVersion = "uncommitted_version"
+	// AuthCacheFallbackNoMemory is used when an entry could not be cached
+	// because the memory budget was exhausted.
+	AuthCacheFallbackNoMemory = "no_memory"
+	// AuthCacheFallbackFull is used when an entry could not be cached because
+	// the cache already holds the maximum number of entries.
+	AuthCacheFallbackFull = "full"
+)
+
+// AuthCacheFallbackCounter is to be incremented every time the
+// authentication cache cannot be used for the given reason.
+func AuthCacheFallbackCounter(reason string) telemetry.Counter {
+	return telemetry.GetCounter(fmt.Sprintf("auth.cache.fallback.%s", reason))
+}
+
+const (
+	// AuthTransportSQL is the transport of the SQL logins in the
+	// authentication method counters.
+	AuthTransportSQL = "sql"
+	// AuthTransportHTTP is the transport of the DB Console and HTTP API
+	// logins in the authentication method counters.
+	AuthTransportHTTP = "http"
+)
+
+// AuthMethodCounter is to be incremented every time a client is
+// successfully authenticated with the given method over the given
+// transport. For the SQL logins, the method is the one of the HBA rule
+// which matched the connection.
+func AuthMethodCounter(transport, method string) telemetry.Counter {
+	return telemetry.GetCounter(fmt.Sprintf("auth.%s.method.%s", transport, method))
+}
@@ -51,6 +51,48 @@ var (
 		"sql.multiregion.drop_primary_region",
 	)
 
+	// AlterDatabaseAddSuperRegionCounter is to be incremented when a super
+	// region is added to a database.
+	AlterDatabaseAddSuperRegionCounter = telemetry.GetCounterOnce(
+		"sql.multiregion.add_super_region",
+	)
+
+	// AlterDatabaseDropSuperRegionCounter is to be incremented when a super
+	// region is dropped from a database.
+	AlterDatabaseDropSuperRegionCounter = telemetry.GetCounterOnce(
+		"sql.multiregion.drop_super_region",
+	)
+
+	// AlterDatabaseAlterSuperRegionCounter is to be incremented when the
+	// regions of a super region are altered.
+	AlterDatabaseAlterSuperRegionCounter = telemetry.GetCounterOnce(
+		"sql.multiregion.alter_super_region",
+	)
+
+	// CreateDatabaseSecondaryRegionCounter is to be incremented when a
+	// multi-region database is created with a secondary region.
+	CreateDatabaseSecondaryRegionCounter = telemetry.GetCounterOnce(
+		"sql.multiregion.create_database.secondary_region",
+	)
+
+	// AlterDatabaseSetSecondaryRegionCounter is to be incremented when the
+	// secondary region of a database is set.
+	AlterDatabaseSetSecondaryRegionCounter = telemetry.GetCounterOnce(
+		"sql.multiregion.alter_database.set_secondary_region",
+	)
+
+	// AlterDatabaseDropSecondaryRegionCounter is to be incremented when the
+	// secondary region of a database is dropped.
+	AlterDatabaseDropSecondaryRegionCounter = telemetry.GetCounterOnce(
+		"sql.multiregion.alter_database.drop_secondary_region",
+	)
+
+	// AlterDatabasePlacementExceptTablesCounter is to be incremented when the
+	// placement policy of a database is altered with tables exempted from it.
+	AlterDatabasePlacementExceptTablesCounter = telemetry.GetCounterOnce(
+		"sql.multiregion.alter_database.placement.except_tables",
+	)
+
 	// ImportIntoMultiRegionDatabaseCounter is to be incremented when an import
 	// statement is run against a multi-region database.
 	ImportIntoMultiRegionDatabaseCounter = telemetry.GetCounterOnce(
@@ -90,6 +132,15 @@ func AlterDatabaseSurvivalGoalCounter(goal string) telemetry.Counter {
 	return telemetry.GetCounter(fmt.Sprintf("sql.multiregion.alter_database.survival_goal.%s", goal))
 }
 
+// AlterDatabaseSurvivalGoalChangeCounter is to be incremented when the
+// survival goal on a multi-region database is being altered, recording both
+// the previous and the new survival goal.
+func AlterDatabaseSurvivalGoalChangeCounter(from, to string) telemetry.Counter {
+	return telemetry.GetCounter(
+		fmt.Sprintf("sql.multiregion.alter_database.survival_goal.from.%s.to.%s", from, to),
+	)
+}
+
 // CreateDatabasePlacementCounter is to be incremented when a placement policy
 // is set on a new multi-region database.
 func CreateDatabasePlacementCounter(placement string) telemetry.Counter {
@@ -106,6 +157,14 @@ func AlterDatabasePlacementCounter(placement string) telemetry.Counter {
 	)
 }
 
+// AlterDatabaseDefaultTableLocalityCounter is to be incremented when the
+// default table locality of a multi-region database is set or reset.
+func AlterDatabaseDefaultTableLocalityCounter(locality string) telemetry.Counter {
+	return telemetry.GetCounter(
+		fmt.Sprintf("sql.multiregion.alter_database.default_table_locality.%s", locality),
+	)
+}
+
 // CreateTableLocalityCounter is to be incremented every time a locality
 // is set on a table.
 func CreateTableLocalityCounter(locality string) telemetry.Counter {
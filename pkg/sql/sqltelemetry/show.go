@@ -32,6 +32,8 @@ const (
 	RegionsFromAllDatabases
 	// RegionsFromDatabase represents the SHOW REGIONS FROM DATABASE command.
 	RegionsFromDatabase
+	// RegionsWithDetails represents the SHOW REGIONS WITH DETAILS command.
+	RegionsWithDetails
 	// SurvivalGoal represents the SHOW SURVIVAL GOAL command.
 	SurvivalGoal
 	// Partitions represents the SHOW PARTITIONS command.
@@ -71,6 +73,7 @@ var showTelemetryNameMap = map[ShowTelemetryType]string{
 	RegionsFromCluster:      "regions_from_cluster",
 	RegionsFromDatabase:     "regions_from_database",
 	RegionsFromAllDatabases: "regions_from_all_databases",
+	RegionsWithDetails:      "regions_with_details",
 	SurvivalGoal:            "survival_goal",
 	Queries:                 "queries",
 	Indexes:                 "indexes",
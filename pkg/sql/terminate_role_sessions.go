@@ -0,0 +1,97 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+// The open sessions of a role are not affected when the role is altered
+// so that it can no longer log in, or when it is dropped, until they are
+// revalidated (see session_revalidation.go). ALTER ROLE ... WITH NOLOGIN
+// TERMINATE SESSIONS and DROP ROLE ... WITH TERMINATE SESSIONS terminate
+// them right away, across the cluster, once the transaction commits.
+//
+// The sessions are terminated on behalf of the user who executes the
+// statement, so the usual rules of CANCEL SESSION apply.
+
+// checkCanTerminateRoleSessions returns an error if the current user is
+// not allowed to terminate the sessions of other users.
+func (p *planner) checkCanTerminateRoleSessions(ctx context.Context) error {
+	return p.CheckRoleOption(ctx, roleoption.CANCELQUERY)
+}
+
+// terminateRoleSessionsOnCommit terminates the sessions of the given
+// users across the cluster, except for the current session, once the
+// current transaction commits. Failures to terminate the sessions are
+// logged.
+func (p *planner) terminateRoleSessionsOnCommit(users []security.SQLUsername) {
+	statusServer := p.ExecCfg().SQLStatusServer
+	requester := p.User()
+	currentSession := p.ExtendedEvalContext().SessionID
+	p.txn.AddCommitTrigger(func(ctx context.Context) {
+		for _, user := range users {
+			if err := terminateRoleSessions(
+				ctx, statusServer, requester, user, currentSession,
+			); err != nil {
+				log.Warningf(ctx, "unable to terminate the sessions of user %s: %v", user, err)
+			}
+		}
+	})
+}
+
+// terminateRoleSessions terminates the sessions of the given user
+// across the cluster, except for the given session.
+func terminateRoleSessions(
+	ctx context.Context,
+	statusServer serverpb.SQLStatusServer,
+	requester security.SQLUsername,
+	user security.SQLUsername,
+	except ClusterWideID,
+) error {
+	resp, err := statusServer.ListSessions(ctx, &serverpb.ListSessionsRequest{
+		Username: user.Normalized(),
+	})
+	if err != nil {
+		return err
+	}
+	for _, e := range resp.Errors {
+		log.Warningf(ctx, "unable to list the sessions of user %s on node %d: %s",
+			user, e.NodeID, e.Message)
+	}
+	var terminated int
+	for _, session := range resp.Sessions {
+		if bytes.Equal(session.ID, except.GetBytes()) {
+			continue
+		}
+		r, err := statusServer.CancelSession(ctx, &serverpb.CancelSessionRequest{
+			NodeId:    fmt.Sprintf("%d", session.NodeID),
+			SessionID: session.ID,
+			Username:  requester.Normalized(),
+		})
+		if err != nil {
+			return err
+		}
+		// The session may have been closed in the meantime.
+		if r.Canceled {
+			terminated++
+		}
+	}
+	log.Ops.Infof(ctx, "terminated %d sessions of user %s", terminated, user)
+	return nil
+}
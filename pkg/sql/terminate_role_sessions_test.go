@@ -0,0 +1,81 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTerminateRoleSessions verifies that the sessions of a role are
+// terminated when it is disabled or dropped WITH TERMINATE SESSIONS.
+func TestTerminateRoleSessions(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+
+	fooURL, fooCleanupFn := sqlutils.PGUrlWithOptionalClientCerts(t,
+		s.ServingSQLAddr(), t.Name(), url.UserPassword("foo", "testabc"), false /* withClientCerts */)
+	defer fooCleanupFn()
+
+	for _, tc := range []struct {
+		name, alter, restore string
+		terminated           bool
+	}{
+		{"nologin", `ALTER USER foo NOLOGIN`, `ALTER USER foo LOGIN`, false},
+		{"nologin terminate", `ALTER USER foo NOLOGIN TERMINATE SESSIONS`, `ALTER USER foo LOGIN`, true},
+		{"valid until future terminate", `ALTER USER foo VALID UNTIL '2100-01-01' TERMINATE SESSIONS`,
+			`ALTER USER foo VALID UNTIL NULL`, false},
+		{"valid until past terminate", `ALTER USER foo VALID UNTIL '2000-01-01' TERMINATE SESSIONS`,
+			`ALTER USER foo VALID UNTIL NULL`, true},
+		{"drop", `DROP USER foo`, ``, false},
+		{"drop terminate", `DROP USER foo WITH TERMINATE SESSIONS`, ``, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB.Exec(t, `CREATE USER IF NOT EXISTS foo WITH PASSWORD 'testabc'`)
+			conn, err := pgxConn(t, fooURL)
+			require.NoError(t, err)
+			defer func() { _ = conn.Close(ctx) }()
+			_, err = conn.Exec(ctx, "SELECT 1")
+			require.NoError(t, err)
+
+			sqlDB.Exec(t, tc.alter)
+			if tc.restore != "" {
+				defer sqlDB.Exec(t, tc.restore)
+			}
+			if !tc.terminated {
+				_, err := conn.Exec(ctx, "SELECT 1")
+				require.NoError(t, err)
+				return
+			}
+			testutils.SucceedsSoon(t, func() error {
+				if _, err := conn.Exec(ctx, "SELECT 1"); err == nil {
+					return errors.New("the session is still open")
+				}
+				return nil
+			})
+		})
+	}
+}
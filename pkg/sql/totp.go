@@ -0,0 +1,204 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// Users with the MFA role option must present a TOTP code in addition
+// to their primary credentials when they log in. Users are enrolled with
+// crdb_internal.enroll_totp(), which stores a new TOTP secret in
+// system.role_totp, encrypted with the key referenced by the
+// server.user_login.totp.encryption_key cluster setting, and returns the
+// URI to load into an authenticator application.
+
+// totpEncryptionKey is the cluster setting that references the key used
+// to encrypt the TOTP secrets. The reference names a file on the nodes or
+// a secret of the operator, so only the system tenant can change it.
+var totpEncryptionKey = settings.RegisterValidatedStringSetting(
+	settings.SystemOnly,
+	"server.user_login.totp.encryption_key",
+	"reference to the secret holding the base64-encoded 256-bit key used to encrypt "+
+		"the TOTP secrets of the users, as a file path or a URL of a supported secret store; "+
+		"the secret must be available on every node",
+	"",
+	func(_ *settings.Values, s string) error {
+		if s == "" {
+			return nil
+		}
+		return security.ValidateSecretRef(s)
+	},
+)
+
+// totpIssuer identifies the cluster in authenticator applications.
+const totpIssuer = "CockroachDB"
+
+// checkCanManageCredentials returns an error if the current user cannot
+// manage the second factor or security keys of the given user, which
+// are introduced by the given version. Users can manage their own
+// credentials, and users with the CREATELOGIN role option can manage
+// the credentials of the other users. As for ALTER ROLE, only admins can
+// manage the credentials of the admins. what describes the operation in
+// the errors.
+func (p *planner) checkCanManageCredentials(
+	ctx context.Context, user security.SQLUsername, what string, version clusterversion.Key,
+) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, version) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is not supported until the cluster upgrade is finalized", what)
+	}
+	if user.IsRootUser() || user.IsNodeUser() {
+		return pgerror.Newf(pgcode.InvalidParameterValue,
+			"%s is not supported for user %s", what, user)
+	}
+	if user != p.User() {
+		if err := p.CheckRoleOption(ctx, roleoption.CREATELOGIN); err != nil {
+			return err
+		}
+	}
+	exists, err := p.RoleExists(ctx, user)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return pgerror.Newf(pgcode.UndefinedObject, "role/user %s does not exist", user)
+	}
+	if user != p.User() {
+		isAdmin, err := p.UserHasAdminRole(ctx, user)
+		if err != nil {
+			return err
+		}
+		if isAdmin {
+			return p.RequireAdminRole(ctx, "manage the credentials of admin")
+		}
+	}
+	return nil
+}
+
+// checkCanManageTOTP returns an error if the current user cannot manage
+// the TOTP enrollment of the given user.
+func (p *planner) checkCanManageTOTP(ctx context.Context, user security.SQLUsername) error {
+	return p.checkCanManageCredentials(ctx, user, "TOTP enrollment", clusterversion.RoleTOTPTable)
+}
+
+// EnrollTOTP is part of the tree.EvalPlanner interface.
+func (p *planner) EnrollTOTP(ctx context.Context, user security.SQLUsername) (string, error) {
+	if err := p.checkCanManageTOTP(ctx, user); err != nil {
+		return "", err
+	}
+	key, err := security.LoadTOTPEncryptionKey(ctx, totpEncryptionKey.Get(&p.ExecCfg().Settings.SV))
+	if err != nil {
+		return "", errors.WithHintf(err,
+			"The key must be configured with the cluster setting %s.", totpEncryptionKey.Key())
+	}
+	secret, err := security.GenerateTOTPSecret()
+	if err != nil {
+		return "", err
+	}
+	encrypted, err := security.EncryptTOTPSecret(key, secret)
+	if err != nil {
+		return "", err
+	}
+	// A new enrollment replaces the previous one, if any.
+	if _, err := p.ExecCfg().InternalExecutor.ExecEx(
+		ctx, "enroll-totp", p.Txn(),
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`UPSERT INTO system.role_totp (username, secret, created, last_counter) VALUES ($1, $2, now(), 0)`,
+		user, encrypted,
+	); err != nil {
+		return "", err
+	}
+	return security.TOTPProvisioningURI(totpIssuer, user, secret), nil
+}
+
+// UnenrollTOTP is part of the tree.EvalPlanner interface.
+func (p *planner) UnenrollTOTP(ctx context.Context, user security.SQLUsername) (bool, error) {
+	if err := p.checkCanManageTOTP(ctx, user); err != nil {
+		return false, err
+	}
+	n, err := p.ExecCfg().InternalExecutor.ExecEx(
+		ctx, "unenroll-totp", p.Txn(),
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`DELETE FROM system.role_totp WHERE username = $1`,
+		user,
+	)
+	return n > 0, err
+}
+
+// VerifyTOTPCode checks the TOTP code presented by a user with the MFA
+// role option. Each code is only accepted once.
+func VerifyTOTPCode(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	ie *InternalExecutor,
+	user security.SQLUsername,
+	code string,
+) error {
+	notEnrolledErr := errors.WithHint(
+		errors.Newf("user %s is required to use a TOTP code but is not enrolled", user),
+		"Enroll the user with crdb_internal.enroll_totp().")
+	if !execCfg.Settings.Version.IsActive(ctx, clusterversion.RoleTOTPTable) {
+		return notEnrolledErr
+	}
+	row, err := ie.QueryRowEx(
+		ctx, "get-totp-secret", nil, /* txn */
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`SELECT secret, last_counter FROM system.public.role_totp WHERE username = $1`,
+		user,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "error looking up the TOTP secret of user %s", user)
+	}
+	if row == nil {
+		return notEnrolledErr
+	}
+	key, err := security.LoadTOTPEncryptionKey(ctx, totpEncryptionKey.Get(&execCfg.Settings.SV))
+	if err != nil {
+		return err
+	}
+	secret, err := security.DecryptTOTPSecret(key, []byte(tree.MustBeDBytes(row[0])))
+	if err != nil {
+		return err
+	}
+	counter, err := security.VerifyTOTPCode(
+		secret, code, timeutil.Now(), int64(tree.MustBeDInt(row[1])),
+	)
+	if err != nil {
+		return err
+	}
+	// Record the counter of the code, unless a concurrent login attempt
+	// already used it.
+	n, err := ie.ExecEx(
+		ctx, "update-totp-counter", nil, /* txn */
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`UPDATE system.public.role_totp SET last_counter = $2 WHERE username = $1 AND last_counter < $2`,
+		user, counter,
+	)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return security.ErrInvalidTOTPCode
+	}
+	return nil
+}
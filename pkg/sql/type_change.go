@@ -32,6 +32,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/lease"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -111,6 +112,7 @@ func (p *planner) writeTypeSchemaChange(
 		newDetails := jobspb.TypeSchemaChangeDetails{
 			TypeID:               typeDesc.ID,
 			TransitioningMembers: transitioningMembers,
+			RehomeRowsToRegion:   record.Details.(jobspb.TypeSchemaChangeDetails).RehomeRowsToRegion,
 		}
 		record.Details = newDetails
 		record.AppendDescription(jobDesc)
@@ -150,6 +152,22 @@ func (p *planner) writeTypeSchemaChange(
 	return p.writeTypeDesc(ctx, typeDesc)
 }
 
+// setTypeSchemaChangeRehomeRowsToRegion sets the region to which the type
+// schema change job queued for the multi-region enum rehomes the rows of the
+// REGIONAL BY ROW tables homed in the regions being dropped.
+func (p *planner) setTypeSchemaChangeRehomeRowsToRegion(
+	typeDesc *typedesc.Mutable, region catpb.RegionName,
+) error {
+	record, ok := p.extendedEvalCtx.SchemaChangeJobRecords[typeDesc.ID]
+	if !ok {
+		return errors.AssertionFailedf("no type schema change job queued for type %d", typeDesc.ID)
+	}
+	details := record.Details.(jobspb.TypeSchemaChangeDetails)
+	details.RehomeRowsToRegion = string(region)
+	record.Details = details
+	return nil
+}
+
 func (p *planner) writeTypeDesc(ctx context.Context, typeDesc *typedesc.Mutable) error {
 	// Write the type out to a batch.
 	b := p.txn.NewBatch()
@@ -169,7 +187,10 @@ type typeSchemaChanger struct {
 	// for a typeSchemaChanger. This is used to group transitions together and
 	// ensure proper rollback semantics on job failure.
 	transitioningMembers [][]byte
-	execCfg              *ExecutorConfig
+	// rehomeRowsToRegion, if set, is the region to which the rows of the
+	// REGIONAL BY ROW tables homed in the regions being dropped are rehomed.
+	rehomeRowsToRegion string
+	execCfg            *ExecutorConfig
 }
 
 // TypeSchemaChangerTestingKnobs contains testing knobs for the typeSchemaChanger.
@@ -346,7 +367,16 @@ func (t *typeSchemaChanger) exec(ctx context.Context) error {
 			})
 		}
 
-		// First, we check if any of the enum values that are being removed are in
+		// If the regions being dropped were dropped with CASCADE, the rows of
+		// the REGIONAL BY ROW tables which are homed in them are rehomed first,
+		// so that they do not fail the validation below.
+		if t.rehomeRowsToRegion != "" {
+			if err := t.rehomeRegionalByRowRows(ctx); err != nil {
+				return err
+			}
+		}
+
+		// Then, we check if any of the enum values that are being removed are in
 		// use and fail. This is done in a separate txn to the one that mutates the
 		// descriptor, as this validation can take arbitrarily long.
 		validateDrops := func(ctx context.Context, txn *kv.Txn, descsCol *descs.Collection) error {
@@ -974,6 +1004,112 @@ func (t *typeSchemaChanger) canRemoveEnumValue(
 	return t.canRemoveEnumValueFromArrayUsages(ctx, arrayTypeDesc, member, txn, descsCol)
 }
 
+// rehomeRowsBatchSize is the number of rows rehomed by each transaction of
+// rehomeRegionalByRowRows.
+const rehomeRowsBatchSize = 1000
+
+// rehomeRegionalByRowRows updates the rows of the REGIONAL BY ROW tables which
+// are homed in the regions being dropped by the job, so that they are homed in
+// t.rehomeRowsToRegion instead. The rows are updated in batches, each in its
+// own transaction, as there can be arbitrarily many of them. Note that the
+// rehomed rows are not moved back if the job fails afterwards.
+func (t *typeSchemaChanger) rehomeRegionalByRowRows(ctx context.Context) error {
+	var dbName string
+	var stmts []string
+	if err := DescsTxn(ctx, t.execCfg, func(
+		ctx context.Context, txn *kv.Txn, descsCol *descs.Collection,
+	) error {
+		stmts = nil
+		typeDesc, err := descsCol.GetMutableTypeVersionByID(ctx, txn, t.typeID)
+		if err != nil {
+			return err
+		}
+		if typeDesc.Kind != descpb.TypeDescriptor_MULTIREGION_ENUM {
+			return nil
+		}
+		var removing []string
+		for i := range typeDesc.EnumMembers {
+			member := &typeDesc.EnumMembers[i]
+			if t.isTransitioningInCurrentJob(member) && enumMemberIsRemoving(member) {
+				sqlPhysRep, err := convertToSQLStringRepresentation(member.PhysicalRepresentation)
+				if err != nil {
+					return err
+				}
+				removing = append(removing, sqlPhysRep)
+			}
+		}
+		if len(removing) == 0 {
+			return nil
+		}
+		_, dbDesc, err := descsCol.GetImmutableDatabaseByID(
+			ctx, txn, typeDesc.ParentID, tree.DatabaseLookupFlags{Required: true})
+		if err != nil {
+			return err
+		}
+		dbName = dbDesc.GetName()
+		for _, id := range typeDesc.ReferencingDescriptorIDs {
+			desc, err := descsCol.GetImmutableTableByID(ctx, txn, id, tree.ObjectLookupFlags{})
+			if err != nil {
+				return err
+			}
+			if !desc.IsLocalityRegionalByRow() {
+				continue
+			}
+			colName, err := desc.GetRegionalByRowTableRegionColumnName()
+			if err != nil {
+				return err
+			}
+			col, err := desc.FindColumnWithName(colName)
+			if err != nil {
+				return err
+			}
+			if col.IsComputed() {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"cannot rehome the rows of table %q as its region column %q is computed",
+					desc.GetName(), colName)
+			}
+			scDesc, err := descsCol.GetImmutableSchemaByID(
+				ctx, txn, desc.GetParentSchemaID(), tree.SchemaLookupFlags{Required: true})
+			if err != nil {
+				return err
+			}
+			tn := tree.MakeTableNameWithSchema(
+				tree.Name(dbName), tree.Name(scDesc.GetName()), tree.Name(desc.GetName()))
+			stmts = append(stmts, fmt.Sprintf(
+				"UPDATE %s SET %s = %s WHERE %s IN (%s) LIMIT %d",
+				tn.FQString(),
+				colName.String(),
+				lexbase.EscapeSQLString(t.rehomeRowsToRegion),
+				colName.String(),
+				strings.Join(removing, ", "),
+				rehomeRowsBatchSize,
+			))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	override := sessiondata.InternalExecutorOverride{
+		User:     security.RootUserName(),
+		Database: dbName,
+	}
+	for _, stmt := range stmts {
+		for {
+			rowsAffected, err := t.execCfg.InternalExecutor.ExecEx(
+				ctx, "rehome-regional-by-row-rows", nil /* txn */, override, stmt,
+			)
+			if err != nil {
+				return errors.Wrapf(err, "could not rehome rows to region %q", t.rehomeRowsToRegion)
+			}
+			if rowsAffected < rehomeRowsBatchSize {
+				break
+			}
+		}
+	}
+	return nil
+}
+
 // findUsagesOfEnumValueInPartitioning is a recursive function to explore all of
 // the values used in partitioning and its subpartitions. The fakePrefixDatums
 // should be nil when first calling this function. They are needed to support
@@ -1281,6 +1417,7 @@ func (t *typeChangeResumer) Resume(ctx context.Context, execCtx interface{}) err
 	tc := &typeSchemaChanger{
 		typeID:               t.job.Details().(jobspb.TypeSchemaChangeDetails).TypeID,
 		transitioningMembers: t.job.Details().(jobspb.TypeSchemaChangeDetails).TransitioningMembers,
+		rehomeRowsToRegion:   t.job.Details().(jobspb.TypeSchemaChangeDetails).RehomeRowsToRegion,
 		execCfg:              p.ExecCfg(),
 	}
 	return tc.execWithRetry(ctx)
@@ -12,16 +12,23 @@ package sql
 
 import (
 	"context"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessioninit"
@@ -33,6 +40,25 @@ import (
 	"github.com/cockroachdb/errors"
 )
 
+// UserSessionInitInfo is the information about a user which is needed to
+// authenticate it and to initialize its SQL sessions.
+type UserSessionInitInfo struct {
+	// AuthInfo holds the authentication related information of the user,
+	// except for its hashed password.
+	sessioninit.AuthInfo
+	// IsSuperuser is set to true if the user is a member of the admin role.
+	IsSuperuser bool
+	// DefaultSettings are the default session variable settings which apply
+	// to the sessions of the user.
+	DefaultSettings []sessioninit.SettingsCacheEntry
+	// RoleSubject is the distinguished name of the client certificates of
+	// the user, or nil if not set.
+	RoleSubject security.DistinguishedName
+	// PasswordRetrievalFn returns the hashed password of the user, and
+	// whether it has expired.
+	PasswordRetrievalFn func(ctx context.Context) (expired bool, hashedPassword security.PasswordHash, err error)
+}
+
 // GetUserSessionInitInfo determines if the given user exists and
 // also returns a password retrieval function, other authentication-related
 // information, and default session variable settings that are to be applied
@@ -76,15 +102,9 @@ func GetUserSessionInitInfo(
 	ie *InternalExecutor,
 	username security.SQLUsername,
 	databaseName string,
-) (
-	exists bool,
-	canLoginSQL bool,
-	canLoginDBConsole bool,
-	isSuperuser bool,
-	defaultSettings []sessioninit.SettingsCacheEntry,
-	pwRetrieveFn func(ctx context.Context) (expired bool, hashedPassword security.PasswordHash, err error),
-	err error,
-) {
+	applicationName string,
+	sourceAddress string,
+) (info UserSessionInitInfo, err error) {
 	runFn := getUserInfoRunFn(execCfg, username, "get-user-timeout")
 
 	if username.IsRootUser() {
@@ -93,7 +113,9 @@ func GetUserSessionInitInfo(
 		// necessary.
 		rootFn := func(ctx context.Context) (expired bool, ret security.PasswordHash, err error) {
 			err = runFn(ctx, func(ctx context.Context) error {
-				authInfo, _, err := retrieveSessionInitInfoWithCache(ctx, execCfg, ie, username, databaseName)
+				authInfo, _, err := retrieveSessionInitInfoWithCache(
+					ctx, execCfg, ie, username, databaseName, applicationName, sourceAddress,
+				)
 				if err != nil {
 					return err
 				}
@@ -107,19 +129,38 @@ func GetUserSessionInitInfo(
 			return false /* expired */, ret, err
 		}
 
+		// The subject of the certificates of root can only be
+		// configured using the cluster setting, since its role options are
+		// not looked up.
+		roleSubject, err := security.GetClientCertSubject(&execCfg.Settings.SV, username, "" /* roleSubject */)
+		if err != nil {
+			return UserSessionInitInfo{}, err
+		}
+
 		// Root user cannot have password expiry and must have login.
 		// It also never has default settings applied to it.
-		return true, true, true, true, nil, rootFn, nil
+		return UserSessionInitInfo{
+			AuthInfo: sessioninit.AuthInfo{
+				UserExists:        true,
+				CanLoginSQL:       true,
+				CanLoginDBConsole: true,
+				ConnectionLimit:   -1,
+			},
+			IsSuperuser:         true,
+			RoleSubject:         roleSubject,
+			PasswordRetrievalFn: rootFn,
+		}, nil
 	}
 
 	var authInfo sessioninit.AuthInfo
 	var settingsEntries []sessioninit.SettingsCacheEntry
+	var isSuperuser bool
 
 	if err = runFn(ctx, func(ctx context.Context) error {
 		// Other users must reach for system.users no matter what, because
 		// only that contains the truth about whether the user exists.
 		authInfo, settingsEntries, err = retrieveSessionInitInfoWithCache(
-			ctx, execCfg, ie, username, databaseName,
+			ctx, execCfg, ie, username, databaseName, applicationName, sourceAddress,
 		)
 		if err != nil {
 			return err
@@ -151,12 +192,20 @@ func GetUserSessionInitInfo(
 		err = errors.Wrap(errors.Handled(err), "internal error while retrieving user account memberships")
 	}
 
-	return authInfo.UserExists,
-		authInfo.CanLoginSQL,
-		authInfo.CanLoginDBConsole,
-		isSuperuser,
-		settingsEntries,
-		func(ctx context.Context) (expired bool, ret security.PasswordHash, err error) {
+	var roleSubject security.DistinguishedName
+	if err == nil {
+		roleSubject, err = security.GetClientCertSubject(&execCfg.Settings.SV, username, authInfo.Subject)
+		if err != nil {
+			err = errors.Wrapf(err, "invalid certificate subject for user %s", username)
+		}
+	}
+
+	info = UserSessionInitInfo{
+		AuthInfo:        authInfo,
+		IsSuperuser:     isSuperuser,
+		DefaultSettings: settingsEntries,
+		RoleSubject:     roleSubject,
+		PasswordRetrievalFn: func(ctx context.Context) (expired bool, ret security.PasswordHash, err error) {
 			ret = authInfo.HashedPassword
 			if authInfo.ValidUntil != nil {
 				// NB: we compute the expiration as late as possible,
@@ -173,7 +222,14 @@ func GetUserSessionInitInfo(
 			}
 			return expired, ret, nil
 		},
-		err
+	}
+	// The password is only available through PasswordRetrievalFn, which
+	// checks its expiration.
+	info.HashedPassword = nil
+	// The login window also applies to DB Console logins. SQL logins check
+	// it once the client has been authenticated.
+	info.CanLoginDBConsole = authInfo.CanLoginDBConsole && authInfo.LoginWindow.Allows(timeutil.Now())
+	return info, err
 }
 
 func getUserInfoRunFn(
@@ -203,6 +259,8 @@ func retrieveSessionInitInfoWithCache(
 	ie *InternalExecutor,
 	username security.SQLUsername,
 	databaseName string,
+	applicationName string,
+	sourceAddress string,
 ) (aInfo sessioninit.AuthInfo, settingsEntries []sessioninit.SettingsCacheEntry, err error) {
 	if err = func() (retErr error) {
 		aInfo, retErr = execCfg.SessionInitCache.GetAuthInfo(
@@ -221,6 +279,21 @@ func retrieveSessionInitInfoWithCache(
 		if username.IsRootUser() || !aInfo.UserExists {
 			return nil
 		}
+		// The user inherits the default settings of the roles it is a member
+		// of.
+		memberOf, retErr := retrieveSortedMemberships(ctx, execCfg, ie, username)
+		if retErr != nil {
+			return retErr
+		}
+		// The defaults cannot be scoped to the applications until the
+		// application_name column is added to system.database_role_settings.
+		if !execCfg.Settings.Version.IsActive(ctx, clusterversion.DatabaseRoleSettingsApplicationName) {
+			applicationName = ""
+		}
+		// Likewise for the networks and the source_network column.
+		if !execCfg.Settings.Version.IsActive(ctx, clusterversion.DatabaseRoleSettingsSourceNetwork) {
+			sourceAddress = ""
+		}
 		settingsEntries, retErr = execCfg.SessionInitCache.GetDefaultSettings(
 			ctx,
 			execCfg.Settings,
@@ -228,8 +301,25 @@ func retrieveSessionInitInfoWithCache(
 			execCfg.DB,
 			execCfg.CollectionFactory,
 			username,
+			memberOf,
 			databaseName,
-			retrieveDefaultSettings,
+			applicationName,
+			sourceAddress,
+			func(
+				ctx context.Context,
+				txn *kv.Txn,
+				ie sqlutil.InternalExecutor,
+				username security.SQLUsername,
+				memberOf []security.SQLUsername,
+				databaseID descpb.ID,
+				applicationName string,
+				sourceAddress string,
+			) ([]sessioninit.SettingsCacheEntry, error) {
+				return retrieveDefaultSettings(
+					ctx, execCfg.Settings, txn, ie, username, memberOf, databaseID,
+					applicationName, sourceAddress,
+				)
+			},
 		)
 		return retErr
 	}(); err != nil {
@@ -240,9 +330,42 @@ func retrieveSessionInitInfoWithCache(
 	return aInfo, settingsEntries, err
 }
 
+// retrieveSortedMemberships returns the roles which the user is a member
+// of, directly or indirectly, sorted by name.
+func retrieveSortedMemberships(
+	ctx context.Context, execCfg *ExecutorConfig, ie *InternalExecutor, username security.SQLUsername,
+) (memberOf []security.SQLUsername, err error) {
+	err = execCfg.CollectionFactory.Txn(ctx, ie, execCfg.DB, func(
+		ctx context.Context, txn *kv.Txn, descriptors *descs.Collection,
+	) error {
+		memberships, err := MemberOfWithAdminOption(ctx, execCfg, ie, descriptors, txn, username)
+		if err != nil {
+			return err
+		}
+		memberOf = sortedMemberships(memberships)
+		return nil
+	})
+	return memberOf, err
+}
+
+// sortedMemberships returns the roles of the membership map returned by
+// MemberOfWithAdminOption, sorted by name.
+func sortedMemberships(memberships map[security.SQLUsername]bool) []security.SQLUsername {
+	memberOf := make([]security.SQLUsername, 0, len(memberships))
+	for role := range memberships {
+		memberOf = append(memberOf, role)
+	}
+	sort.Slice(memberOf, func(i, j int) bool {
+		return memberOf[i].Normalized() < memberOf[j].Normalized()
+	})
+	return memberOf
+}
+
 func retrieveAuthInfo(
 	ctx context.Context, txn *kv.Txn, ie sqlutil.InternalExecutor, username security.SQLUsername,
 ) (aInfo sessioninit.AuthInfo, retErr error) {
+	aInfo.ConnectionLimit = -1
+
 	// Use fully qualified table name to avoid looking up "".system.users.
 	const getHashedPassword = `SELECT "hashedPassword" FROM system.public.users ` +
 		`WHERE username=$1`
@@ -271,69 +394,207 @@ func retrieveAuthInfo(
 		return aInfo, nil
 	}
 
-	// Use fully qualified table name to avoid looking up "".system.role_options.
-	const getLoginDependencies = `SELECT option, value FROM system.public.role_options ` +
-		`WHERE username=$1 AND option IN ('NOLOGIN', 'VALID UNTIL', 'NOSQLLOGIN')`
-
-	roleOptsIt, err := ie.QueryIteratorEx(
-		ctx, "get-login-dependencies", txn,
-		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
-		getLoginDependencies,
-		username,
-	)
-	if err != nil {
-		return aInfo, errors.Wrapf(err, "error looking up user %s", username)
-	}
-	// We have to make sure to close the iterator since we might return from
-	// the for loop early (before Next() returns false).
-	defer func() { retErr = errors.CombineErrors(retErr, roleOptsIt.Close()) }()
-
 	// To support users created before 20.1, allow all USERS/ROLES to login
 	// if NOLOGIN is not found.
 	aInfo.CanLoginSQL = true
 	aInfo.CanLoginDBConsole = true
-	var ok bool
-	for ok, err = roleOptsIt.Next(ctx); ok; ok, err = roleOptsIt.Next(ctx) {
-		row := roleOptsIt.Cur()
-		option := string(tree.MustBeDString(row[0]))
-
-		if option == "NOLOGIN" {
-			aInfo.CanLoginSQL = false
-			aInfo.CanLoginDBConsole = false
-		}
-		if option == "NOSQLLOGIN" {
-			aInfo.CanLoginSQL = false
-		}
-
-		if option == "VALID UNTIL" {
-			if tree.DNull.Compare(nil, row[1]) != 0 {
-				ts := string(tree.MustBeDString(row[1]))
-				// This is okay because the VALID UNTIL is stored as a string
-				// representation of a TimestampTZ which has the same underlying
-				// representation in the table as a Timestamp (UTC time).
-				timeCtx := tree.NewParseTimeContext(timeutil.Now())
-				aInfo.ValidUntil, _, err = tree.ParseDTimestamp(timeCtx, ts, time.Microsecond)
-				if err != nil {
-					return aInfo, errors.Wrap(err,
-						"error trying to parse timestamp while retrieving password valid until value")
-				}
+
+	// The options set on the role itself take precedence over the
+	// options of its profile.
+	setByRole := make(map[string]struct{})
+	var profileName string
+	if err := func() (retErr error) {
+		// Use fully qualified table name to avoid looking up "".system.role_options.
+		const getLoginDependencies = `SELECT option, value FROM system.public.role_options ` +
+			`WHERE username=$1 AND option IN ('NOLOGIN', 'VALID UNTIL', 'NOSQLLOGIN', 'PASSWORD MUST CHANGE', 'CONNECTION LIMIT', 'SUBJECT', 'MFA', 'READONLY', 'ALLOWED DATABASES', 'LOGIN WINDOW', 'IDLE_SESSION_TIMEOUT', 'STATEMENT_TIMEOUT', 'TRANSACTION_TIMEOUT', 'QUALITY_OF_SERVICE', 'MAX_RESULT_ROWS', 'MAX_RESULT_BYTES', 'PROFILE')`
+
+		roleOptsIt, err := ie.QueryIteratorEx(
+			ctx, "get-login-dependencies", txn,
+			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+			getLoginDependencies,
+			username,
+		)
+		if err != nil {
+			return errors.Wrapf(err, "error looking up user %s", username)
+		}
+		// We have to make sure to close the iterator since we might return from
+		// the for loop early (before Next() returns false).
+		defer func() { retErr = errors.CombineErrors(retErr, roleOptsIt.Close()) }()
+
+		var ok bool
+		for ok, err = roleOptsIt.Next(ctx); ok; ok, err = roleOptsIt.Next(ctx) {
+			row := roleOptsIt.Cur()
+			option := string(tree.MustBeDString(row[0]))
+			setByRole[option] = struct{}{}
+
+			if option == "PROFILE" && row[1] != tree.DNull {
+				profileName = string(tree.MustBeDString(row[1]))
+			}
+			if err := applyAuthInfoOption(&aInfo, option, row[1]); err != nil {
+				return err
 			}
 		}
+		return err
+	}(); err != nil {
+		return aInfo, err
+	}
+
+	if profileName == "" {
+		return aInfo, nil
+	}
+	profileOpts, err := getRoleProfileOptions(ctx, txn, ie, profileName)
+	if err != nil {
+		return aInfo, errors.Wrapf(err, "error looking up profile of user %s", username)
+	}
+	for option, value := range profileOpts {
+		if _, ok := setByRole[option]; ok {
+			continue
+		}
+		var datum tree.Datum = tree.DNull
+		if value != nil {
+			datum = tree.NewDString(*value)
+		}
+		if err := applyAuthInfoOption(&aInfo, option, datum); err != nil {
+			return aInfo, err
+		}
+	}
+	return aInfo, nil
+}
+
+// applyAuthInfoOption applies a role option retrieved from
+// system.role_options or from the profile of the user to its AuthInfo.
+func applyAuthInfoOption(aInfo *sessioninit.AuthInfo, option string, value tree.Datum) (err error) {
+	if option == "NOLOGIN" {
+		aInfo.CanLoginSQL = false
+		aInfo.CanLoginDBConsole = false
+	}
+	if option == "NOSQLLOGIN" {
+		aInfo.CanLoginSQL = false
+	}
+	if option == "PASSWORD MUST CHANGE" {
+		aInfo.PasswordMustChange = true
+	}
+	if option == "CONNECTION LIMIT" && value != tree.DNull {
+		var limit int64
+		limit, err = strconv.ParseInt(string(tree.MustBeDString(value)), 10, 32)
+		if err != nil {
+			return errors.Wrap(err,
+				"error trying to parse connection limit while retrieving user info")
+		}
+		aInfo.ConnectionLimit = int32(limit)
+	}
+	if option == "SUBJECT" && value != tree.DNull {
+		aInfo.Subject = string(tree.MustBeDString(value))
+	}
+	if option == "MFA" {
+		aInfo.MFARequired = true
+	}
+	if option == "READONLY" {
+		aInfo.ReadOnly = true
+	}
+	if option == "ALLOWED DATABASES" && value != tree.DNull {
+		aInfo.AllowedDatabases, err = roleoption.DecodeValueList(string(tree.MustBeDString(value)))
+		if err != nil {
+			return errors.Wrap(err,
+				"error trying to parse allowed databases while retrieving user info")
+		}
+	}
+	if option == "LOGIN WINDOW" && value != tree.DNull {
+		aInfo.LoginWindow, err = roleoption.DecodeLoginWindow(string(tree.MustBeDString(value)))
+		if err != nil {
+			return errors.Wrap(err,
+				"error trying to parse login window while retrieving user info")
+		}
+	}
+	if option == "IDLE_SESSION_TIMEOUT" && value != tree.DNull {
+		aInfo.IdleSessionTimeout, err = parseTimeoutRoleOption(
+			"idle_in_session_timeout", string(tree.MustBeDString(value)))
+		if err != nil {
+			return errors.Wrap(err,
+				"error trying to parse idle session timeout while retrieving user info")
+		}
+	}
+	if option == "STATEMENT_TIMEOUT" && value != tree.DNull {
+		aInfo.MaxStatementTimeout, err = parseTimeoutRoleOption(
+			"statement_timeout", string(tree.MustBeDString(value)))
+		if err != nil {
+			return errors.Wrap(err,
+				"error trying to parse statement timeout while retrieving user info")
+		}
+	}
+	if option == "TRANSACTION_TIMEOUT" && value != tree.DNull {
+		aInfo.MaxTransactionTimeout, err = parseTimeoutRoleOption(
+			"transaction_timeout", string(tree.MustBeDString(value)))
+		if err != nil {
+			return errors.Wrap(err,
+				"error trying to parse transaction timeout while retrieving user info")
+		}
+	}
+	if option == "QUALITY_OF_SERVICE" && value != tree.DNull {
+		aInfo.QualityOfService = string(tree.MustBeDString(value))
+	}
+	if option == "MAX_RESULT_ROWS" && value != tree.DNull {
+		aInfo.MaxResultRows, err = roleoption.ParseResultLimit(
+			roleoption.MAXRESULTROWS, string(tree.MustBeDString(value)))
+		if err != nil {
+			return errors.Wrap(err,
+				"error trying to parse max result rows while retrieving user info")
+		}
+	}
+	if option == "MAX_RESULT_BYTES" && value != tree.DNull {
+		aInfo.MaxResultBytes, err = roleoption.ParseResultLimit(
+			roleoption.MAXRESULTBYTES, string(tree.MustBeDString(value)))
+		if err != nil {
+			return errors.Wrap(err,
+				"error trying to parse max result bytes while retrieving user info")
+		}
 	}
 
-	return aInfo, err
+	if option == "VALID UNTIL" {
+		if tree.DNull.Compare(nil, value) != 0 {
+			ts := string(tree.MustBeDString(value))
+			// This is okay because the VALID UNTIL is stored as a string
+			// representation of a TimestampTZ which has the same underlying
+			// representation in the table as a Timestamp (UTC time).
+			timeCtx := tree.NewParseTimeContext(timeutil.Now())
+			aInfo.ValidUntil, _, err = tree.ParseDTimestamp(timeCtx, ts, time.Microsecond)
+			if err != nil {
+				return errors.Wrap(err,
+					"error trying to parse timestamp while retrieving password valid until value")
+			}
+		}
+	}
+	return nil
 }
 
+// retrieveDefaultSettings reads the default settings of the user in the
+// database, including the ones inherited from the roles in memberOf, which
+// the user is a member of, and the ones scoped to the applications matching
+// applicationName and to the networks containing sourceAddress. The entries
+// are returned in order of precedence; see
+// sessioninit.GenerateSettingsCacheKeys.
 func retrieveDefaultSettings(
 	ctx context.Context,
+	st *cluster.Settings,
 	txn *kv.Txn,
 	ie sqlutil.InternalExecutor,
 	username security.SQLUsername,
+	memberOf []security.SQLUsername,
 	databaseID descpb.ID,
+	applicationName string,
+	sourceAddress string,
 ) (settingsEntries []sessioninit.SettingsCacheEntry, retErr error) {
+	// A malformed address does not match any network.
+	sourceIP := net.ParseIP(sourceAddress)
+	if sourceIP == nil {
+		sourceAddress = ""
+	}
+
 	// Add an empty slice for all the keys so that something gets cached and
 	// prevents a lookup for the same key from happening later.
-	keys := sessioninit.GenerateSettingsCacheKeys(databaseID, username)
+	keys := sessioninit.GenerateSettingsCacheKeys(
+		databaseID, username, memberOf, applicationName, sourceAddress,
+	)
 	settingsEntries = make([]sessioninit.SettingsCacheEntry, len(keys))
 	for i, k := range keys {
 		settingsEntries[i] = sessioninit.SettingsCacheEntry{
@@ -347,24 +608,74 @@ func retrieveDefaultSettings(
 		return settingsEntries, nil
 	}
 
+	// The settings set with LOCKED are stored in their own column.
+	lockedSettingsColumn := `NULL::STRING[] AS locked_settings`
+	if st.Version.IsActive(ctx, clusterversion.DatabaseRoleSettingsLocked) {
+		lockedSettingsColumn = `locked_settings`
+	}
+	// All the rows of the roles in the database are read, including the
+	// ones scoped to other applications and networks, so that the keys of
+	// the databases and roles which have no settings at all can be cached
+	// as negative entries. The application_matches column tells whether the
+	// row applies to the application of the session.
 	// Use fully qualified table name to avoid looking up "".system.role_options.
-	const getDefaultSettings = `
+	getDefaultSettings := `
 SELECT
-  database_id, role_name, settings
+  database_id, role_name, '' AS application_name, '' AS source_network, settings,
+  ` + lockedSettingsColumn + `, true AS application_matches
 FROM
   system.public.database_role_settings
 WHERE
-  (database_id = 0 AND role_name = $1)
-  OR (database_id = $2 AND role_name = $1)
-  OR (database_id = $2 AND role_name = '')
-  OR (database_id = 0 AND role_name = '');
+  database_id IN (0, $2)
+  AND (role_name = '' OR role_name = ANY($1::STRING[]));
 `
+	roleNames := make([]string, 0, len(memberOf)+1)
+	roleNames = append(roleNames, username.Normalized())
+	for _, role := range memberOf {
+		roleNames = append(roleNames, role.Normalized())
+	}
+	args := []interface{}{roleNames, databaseID}
+	if st.Version.IsActive(ctx, clusterversion.DatabaseRoleSettingsSourceNetwork) {
+		// The rows scoped to the networks are matched against the source
+		// address of the session below.
+		getDefaultSettings = `
+SELECT
+  database_id, role_name, application_name, source_network, settings,
+  ` + lockedSettingsColumn + `,
+  application_name = '' OR ($3 != '' AND $3 LIKE application_name) AS application_matches
+FROM
+  system.public.database_role_settings
+WHERE
+  database_id IN (0, $2)
+  AND (role_name = '' OR role_name = ANY($1::STRING[]))
+ORDER BY
+  length(application_name) DESC, application_name;
+`
+		args = append(args, applicationName)
+	} else if st.Version.IsActive(ctx, clusterversion.DatabaseRoleSettingsApplicationName) {
+		// The rows scoped to the applications only apply if the session has
+		// an application name matching their pattern. If several patterns
+		// match, the longest takes precedence.
+		getDefaultSettings = `
+SELECT
+  database_id, role_name, application_name, '' AS source_network, settings,
+  ` + lockedSettingsColumn + `,
+  application_name = '' OR ($3 != '' AND $3 LIKE application_name) AS application_matches
+FROM
+  system.public.database_role_settings
+WHERE
+  database_id IN (0, $2)
+  AND (role_name = '' OR role_name = ANY($1::STRING[]))
+ORDER BY
+  length(application_name) DESC, application_name;
+`
+		args = append(args, applicationName)
+	}
 	defaultSettingsIt, err := ie.QueryIteratorEx(
 		ctx, "get-default-settings", txn,
 		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
 		getDefaultSettings,
-		username,
-		databaseID,
+		args...,
 	)
 	if err != nil {
 		return nil, errors.Wrapf(err, "error looking up user %s", username)
@@ -373,31 +684,103 @@ WHERE
 	// the for loop early (before Next() returns false).
 	defer func() { retErr = errors.CombineErrors(retErr, defaultSettingsIt.Close()) }()
 
+	type fetchedRow struct {
+		key            sessioninit.SettingsCacheKey
+		settings       []string
+		lockedSettings []string
+		// prefixLen is the length of the prefix of the network of the row,
+		// or -1 if the row applies to all the networks.
+		prefixLen int
+	}
+	var rows []fetchedRow
+	// hasSettings records the databases and roles which have settings,
+	// whatever their application and network.
+	hasSettings := make(map[sessioninit.SettingsCacheKey]struct{})
 	var ok bool
 	for ok, err = defaultSettingsIt.Next(ctx); ok; ok, err = defaultSettingsIt.Next(ctx) {
 		row := defaultSettingsIt.Cur()
 		fetechedDatabaseID := descpb.ID(tree.MustBeDOid(row[0]).DInt)
 		fetchedUsername := security.MakeSQLUsernameFromPreNormalizedString(string(tree.MustBeDString(row[1])))
-		settingsDatum := tree.MustBeDArray(row[2])
+		hasSettings[sessioninit.SettingsCacheKey{
+			DatabaseID: fetechedDatabaseID,
+			Username:   fetchedUsername,
+		}] = struct{}{}
+		if !tree.MustBeDBool(row[6]) {
+			continue
+		}
+		settingsDatum := tree.MustBeDArray(row[4])
 		fetchedSettings := make([]string, settingsDatum.Len())
 		for i, s := range settingsDatum.Array {
 			fetchedSettings[i] = string(tree.MustBeDString(s))
 		}
+		// The locked variables are stored by name; they are translated into
+		// the settings of the row which set them.
+		var fetchedLockedSettings []string
+		if row[5] != tree.DNull {
+			lockedNames := make(map[string]struct{})
+			for _, name := range tree.MustBeDArray(row[5]).Array {
+				lockedNames[string(tree.MustBeDString(name))] = struct{}{}
+			}
+			for _, setting := range fetchedSettings {
+				keyVal := strings.SplitN(setting, "=", 2)
+				if _, ok := lockedNames[keyVal[0]]; ok {
+					fetchedLockedSettings = append(fetchedLockedSettings, setting)
+				}
+			}
+		}
 
-		thisKey := sessioninit.SettingsCacheKey{
-			DatabaseID: fetechedDatabaseID,
-			Username:   fetchedUsername,
+		thisRow := fetchedRow{
+			key: sessioninit.SettingsCacheKey{
+				DatabaseID: fetechedDatabaseID,
+				Username:   fetchedUsername,
+			},
+			settings:       fetchedSettings,
+			lockedSettings: fetchedLockedSettings,
+			prefixLen:      -1,
 		}
-		// Add the result to the settings list. Note that we don't use a map
-		// because the list is in order of precedence.
-		for i, s := range settingsEntries {
-			if s.SettingsCacheKey == thisKey {
-				settingsEntries[i].Settings = fetchedSettings
+		if tree.MustBeDString(row[2]) != "" {
+			thisRow.key.ApplicationName = applicationName
+		}
+		if network := string(tree.MustBeDString(row[3])); network != "" {
+			_, ipNet, err := net.ParseCIDR(network)
+			if err != nil || !ipNet.Contains(sourceIP) {
+				continue
 			}
+			thisRow.key.SourceAddress = sourceAddress
+			thisRow.prefixLen, _ = ipNet.Mask.Size()
 		}
+		rows = append(rows, thisRow)
+	}
+	if err != nil {
+		return settingsEntries, err
 	}
+	for i := range settingsEntries {
+		if k := settingsEntries[i].SettingsCacheKey; k.IsUnscoped() {
+			_, ok := hasSettings[k]
+			settingsEntries[i].Negative = !ok
+		}
+	}
+	// If several networks contain the source address, the most specific
+	// takes precedence.
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].prefixLen > rows[j].prefixLen
+	})
 
-	return settingsEntries, err
+	// Add the results to the settings list. Note that we don't use a map
+	// because the list is in order of precedence. The settings of the rows
+	// scoped to different applications or networks are appended to the same
+	// entry, in order of precedence as well.
+	for _, row := range rows {
+		for i, s := range settingsEntries {
+			if s.SettingsCacheKey == row.key {
+				settingsEntries[i].Settings = append(settingsEntries[i].Settings, row.settings...)
+				settingsEntries[i].LockedSettings = append(
+					settingsEntries[i].LockedSettings, row.lockedSettings...,
+				)
+			}
+		}
+	}
+	return settingsEntries, nil
 }
 
 var userLoginTimeout = settings.RegisterDurationSetting(
@@ -408,6 +791,15 @@ var userLoginTimeout = settings.RegisterDurationSetting(
 	settings.NonNegativeDuration,
 ).WithPublic()
 
+// passwordMustChangeAfterReset controls whether users whose password is
+// changed by another user get the PASSWORD MUST CHANGE role option.
+var passwordMustChangeAfterReset = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"server.user_login.password_must_change_after_reset.enabled",
+	"if set, users whose password is set by another user must change it when they next log in",
+	false,
+)
+
 // GetAllRoles returns a "set" (map) of Roles -> true.
 func (p *planner) GetAllRoles(ctx context.Context) (map[security.SQLUsername]bool, error) {
 	query := `SELECT username FROM system.users`
@@ -495,6 +887,56 @@ func (p *planner) bumpRoleOptionsTableVersion(ctx context.Context) error {
 	)
 }
 
+// writeThroughAuthInfoOnCommit arranges for the AuthInfo of the given user,
+// as modified by the planner's transaction, to replace the one in the local
+// sessioninit.Cache once the transaction commits. The next login of the user
+// on this node then does not need to read the system tables, although the
+// transaction invalidated the cache. See addAuthInfoWriteThroughTrigger.
+func (p *planner) writeThroughAuthInfoOnCommit(
+	ctx context.Context, username security.SQLUsername,
+) error {
+	return addAuthInfoWriteThroughTrigger(ctx, p.ExecCfg(), p.txn, p.Descriptors(), username)
+}
+
+// addAuthInfoWriteThroughTrigger reads the AuthInfo for the given user as
+// seen by the given transaction, and adds a commit trigger to the
+// transaction which stores it in the local sessioninit.Cache. Nothing is
+// stored if the transaction does not commit. The caller is responsible for
+// bumping the versions of the users and/or role_options tables, so that the
+// entry is keyed by the table versions written by this transaction.
+func addAuthInfoWriteThroughTrigger(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	txn *kv.Txn,
+	descsCol *descs.Collection,
+	username security.SQLUsername,
+) error {
+	aInfo, err := retrieveAuthInfo(ctx, txn, execCfg.InternalExecutor, username)
+	if err != nil {
+		return err
+	}
+	usersTable, err := descsCol.GetMutableTableByID(
+		ctx, txn, keys.UsersTableID, tree.ObjectLookupFlagsWithRequired(),
+	)
+	if err != nil {
+		return err
+	}
+	roleOptionsTable, err := descsCol.GetMutableTableByID(
+		ctx, txn, keys.RoleOptionsTableID, tree.ObjectLookupFlagsWithRequired(),
+	)
+	if err != nil {
+		return err
+	}
+	usersTableVersion := usersTable.GetVersion()
+	roleOptionsTableVersion := roleOptionsTable.GetVersion()
+	txn.AddCommitTrigger(func(ctx context.Context) {
+		execCfg.SessionInitCache.WriteThroughAuthInfo(
+			ctx, execCfg.Settings, usersTableVersion, roleOptionsTableVersion, username, aInfo,
+		)
+	})
+	return nil
+}
+
 // bumpDatabaseRoleSettingsTableVersion increases the table version for the
 // database_role_settings table.
 func (p *planner) bumpDatabaseRoleSettingsTableVersion(ctx context.Context) error {
@@ -722,7 +1164,14 @@ func updateUserPasswordHash(
 				return err
 			}
 			// WriteDesc will internally bump the version.
-			return d.WriteDesc(ctx, false /* kvTrace */, usersTable, txn)
+			if err := d.WriteDesc(ctx, false /* kvTrace */, usersTable, txn); err != nil {
+				return err
+			}
+			// The version bump above invalidates the authentication cache.
+			// Store the converted hash in the local cache upon commit, so that
+			// the next login on this node does not need to read system.users
+			// again.
+			return addAuthInfoWriteThroughTrigger(ctx, execCfg, txn, d, username)
 		})
 	})
 }
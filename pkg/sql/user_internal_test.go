@@ -0,0 +1,90 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessioninit"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAuthInfoWriteThroughOnCommit verifies that the AuthInfo written
+// through the authentication cache by a transaction is stored once the
+// transaction commits, and not before, nor if it rolls back.
+func TestAuthInfoWriteThroughOnCommit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, kvDB := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	sqlutils.MakeSQLRunner(db).Exec(t, `CREATE USER foo`)
+
+	execCfg := s.ExecutorConfig().(ExecutorConfig)
+	cache := execCfg.SessionInitCache
+	username := security.MakeSQLUsernameFromPreNormalizedString("foo")
+
+	// writeThrough runs a transaction which bumps the version of
+	// system.role_options, as role changes do, and writes the AuthInfo of
+	// foo through the cache. The transaction rolls back if rollback is set.
+	// It returns the state of the cache before the end of the transaction.
+	errRollback := errors.New("rollback")
+	writeThrough := func(rollback bool) (sessioninit.CacheInfo, error) {
+		var beforeEnd sessioninit.CacheInfo
+		err := execCfg.CollectionFactory.Txn(ctx, execCfg.InternalExecutor, kvDB,
+			func(ctx context.Context, txn *kv.Txn, d *descs.Collection) error {
+				roleOptionsTable, err := d.GetMutableTableByID(
+					ctx, txn, keys.RoleOptionsTableID, tree.ObjectLookupFlagsWithRequired(),
+				)
+				if err != nil {
+					return err
+				}
+				if err := d.WriteDesc(ctx, false /* kvTrace */, roleOptionsTable, txn); err != nil {
+					return err
+				}
+				if err := addAuthInfoWriteThroughTrigger(ctx, &execCfg, txn, d, username); err != nil {
+					return err
+				}
+				beforeEnd = cache.Info()
+				if rollback {
+					return errRollback
+				}
+				return nil
+			})
+		return beforeEnd, err
+	}
+
+	initial := cache.Info()
+	beforeEnd, err := writeThrough(true /* rollback */)
+	require.ErrorIs(t, err, errRollback)
+	require.Equal(t, initial, beforeEnd)
+	require.Equal(t, initial, cache.Info())
+
+	beforeEnd, err = writeThrough(false /* rollback */)
+	require.NoError(t, err)
+	require.Equal(t, initial, beforeEnd)
+	committed := cache.Info()
+	require.Greater(t, committed.RoleOptionsTableVersion, initial.RoleOptionsTableVersion)
+	require.Equal(t, 1, committed.AuthInfoEntries)
+}
@@ -0,0 +1,218 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil/singleflight"
+	"github.com/cockroachdb/errors"
+)
+
+// maxOpenTxnsPerUser is the cluster setting which limits the number of
+// explicit transactions each user can have open concurrently. It
+// contains the applications which leak transactions, which would
+// otherwise hold their locks and intents until their sessions are closed.
+//
+// The limit applies across the cluster. Each node counts the transactions
+// opened through it, and caches the count of the transactions open on the
+// other nodes for openTxnCountRefreshInterval, so that BEGIN does not reach
+// all the nodes every time. The limit may thus be exceeded briefly when
+// transactions of the user are opened through several nodes at the same
+// time. The transactions which were already open on the node when the limit
+// was set are not counted.
+var maxOpenTxnsPerUser = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"sql.max_open_transactions_per_user",
+	"the maximum number of explicit transactions each user can have open "+
+		"concurrently across the cluster; BEGIN fails with a retryable error "+
+		"once the limit is reached. Superusers are not subject to this limit; "+
+		"use 0 to disable",
+	0,
+	settings.NonNegativeInt,
+)
+
+// openTxnCountRefreshInterval is how long the count of the open
+// transactions of a user on the other nodes is cached.
+const openTxnCountRefreshInterval = time.Second
+
+// userOpenTxnCounts counts the open explicit transactions of each user on
+// the node, and caches the count of those on the other nodes.
+type userOpenTxnCounts struct {
+	// group deduplicates the concurrent refreshes of the count of the
+	// transactions of a user on the other nodes.
+	group singleflight.Group
+
+	mu struct {
+		syncutil.Mutex
+		// local counts the open explicit transactions of each user on the
+		// node.
+		local map[security.SQLUsername]int64
+		// remote caches the count of the open explicit transactions of each
+		// user on the other nodes.
+		remote map[security.SQLUsername]remoteOpenTxnCount
+	}
+}
+
+// remoteOpenTxnCount is the count of the open explicit transactions of a
+// user on the other nodes, as of a given time.
+type remoteOpenTxnCount struct {
+	count int64
+	asOf  time.Time
+}
+
+// remoteCount returns the count of the open explicit transactions of the
+// user on the nodes other than the given one, which is refreshed with the
+// session registries of all the nodes when the cached count is older than
+// openTxnCountRefreshInterval. As for the connection limits, the sessions
+// which cannot be listed are not counted rather than blocking the user.
+func (c *userOpenTxnCounts) remoteCount(
+	ctx context.Context,
+	statusServer serverpb.SQLStatusServer,
+	instanceID base.SQLInstanceID,
+	user security.SQLUsername,
+	now time.Time,
+) int64 {
+	c.mu.Lock()
+	cached, ok := c.mu.remote[user]
+	c.mu.Unlock()
+	if ok && now.Sub(cached.asOf) < openTxnCountRefreshInterval {
+		return cached.count
+	}
+
+	res, _, _ := c.group.Do(user.Normalized(), func() (interface{}, error) {
+		var count int64
+		resp, err := statusServer.ListSessions(ctx, &serverpb.ListSessionsRequest{
+			Username: user.Normalized(),
+		})
+		if err != nil {
+			log.Warningf(ctx, "unable to list the sessions to check the open transaction limit of user %s: %v",
+				user, err)
+		} else {
+			for _, e := range resp.Errors {
+				log.Warningf(ctx, "unable to list the sessions of node %d: %s", e.NodeID, e.Message)
+			}
+			for i := range resp.Sessions {
+				session := &resp.Sessions[i]
+				// The transactions open on this node are counted locally.
+				if base.SQLInstanceID(BytesToClusterWideID(session.ID).GetNodeID()) == instanceID {
+					continue
+				}
+				if session.ActiveTxn != nil && !session.ActiveTxn.Implicit {
+					count++
+				}
+			}
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		// Forget the stale counts of the other users, which may not be
+		// opening transactions anymore.
+		for u, r := range c.mu.remote {
+			if now.Sub(r.asOf) >= openTxnCountRefreshInterval {
+				delete(c.mu.remote, u)
+			}
+		}
+		if c.mu.remote == nil {
+			c.mu.remote = make(map[security.SQLUsername]remoteOpenTxnCount)
+		}
+		c.mu.remote[user] = remoteOpenTxnCount{count: count, asOf: now}
+		return count, nil
+	})
+	return res.(int64)
+}
+
+// tryAcquire counts a new open transaction for the user on the node,
+// unless the user already has limit transactions open, including the
+// remote ones open on the other nodes. It returns whether the transaction
+// was counted.
+func (c *userOpenTxnCounts) tryAcquire(user security.SQLUsername, limit, remote int64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mu.local[user]+remote >= limit {
+		return false
+	}
+	if c.mu.local == nil {
+		c.mu.local = make(map[security.SQLUsername]int64)
+	}
+	c.mu.local[user]++
+	return true
+}
+
+// release stops counting an open transaction of the user on the node.
+func (c *userOpenTxnCounts) release(user security.SQLUsername) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mu.local[user] <= 1 {
+		delete(c.mu.local, user)
+		return
+	}
+	c.mu.local[user]--
+}
+
+// checkUserOpenTxnLimit returns an error if the user of the session
+// already has as many explicit transactions open across the cluster as
+// allowed by the sql.max_open_transactions_per_user cluster setting, and
+// otherwise counts the transaction the session is about to open. The
+// transaction stops being counted once it is over (see
+// releaseUserOpenTxn).
+//
+// The error is retryable: the transaction can be attempted again once
+// other transactions of the user are over. The retries are cheap, since
+// the transactions on the other nodes are only counted again once their
+// cached count is stale.
+func (ex *connExecutor) checkUserOpenTxnLimit(ctx context.Context) error {
+	if ex.executorType == executorTypeInternal || ex.sessionData().IsSuperuser ||
+		ex.extraTxnState.openTxnUser != nil {
+		return nil
+	}
+	limit := maxOpenTxnsPerUser.Get(&ex.server.cfg.Settings.SV)
+	if limit == 0 {
+		return nil
+	}
+	user := ex.sessionData().User()
+	var remote int64
+	if statusServer := ex.server.cfg.SQLStatusServer; statusServer != nil {
+		remote = ex.server.userOpenTxns.remoteCount(
+			ctx, statusServer, ex.server.cfg.NodeID.SQLInstanceID(), user,
+			ex.server.cfg.Clock.PhysicalTime(),
+		)
+	}
+	if !ex.server.userOpenTxns.tryAcquire(user, limit, remote) {
+		return errors.WithHintf(
+			pgerror.Newf(pgcode.SerializationFailure,
+				"too many open transactions for role %q", user),
+			"the role can have at most %d explicit transactions open, which is configured by %s; "+
+				"retry the transaction once other transactions of the role are over",
+			limit, maxOpenTxnsPerUser.Key(),
+		)
+	}
+	ex.extraTxnState.openTxnUser = &user
+	return nil
+}
+
+// releaseUserOpenTxn stops counting the transaction of the session in the
+// open transactions of its user, if it was counted.
+func (ex *connExecutor) releaseUserOpenTxn() {
+	if user := ex.extraTxnState.openTxnUser; user != nil {
+		ex.server.userOpenTxns.release(*user)
+		ex.extraTxnState.openTxnUser = nil
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/jackc/pgconn"
+	pgx "github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUserOpenTxnLimit verifies that the users cannot open more explicit
+// transactions than allowed by sql.max_open_transactions_per_user.
+func TestUserOpenTxnLimit(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE USER foo WITH PASSWORD 'testabc'`)
+	sqlDB.Exec(t, `SET CLUSTER SETTING sql.max_open_transactions_per_user = 2`)
+
+	fooURL, fooCleanupFn := sqlutils.PGUrlWithOptionalClientCerts(t,
+		s.ServingSQLAddr(), t.Name(), url.UserPassword("foo", "testabc"), false /* withClientCerts */)
+	defer fooCleanupFn()
+
+	var conns [3]*pgx.Conn
+	for i := range conns {
+		conn, err := pgxConn(t, fooURL)
+		require.NoError(t, err)
+		defer func() { _ = conn.Close(ctx) }()
+		conns[i] = conn
+	}
+
+	for _, conn := range conns[:2] {
+		_, err := conn.Exec(ctx, "BEGIN")
+		require.NoError(t, err)
+	}
+	// The implicit transactions are not limited.
+	_, err := conns[2].Exec(ctx, "SELECT 1")
+	require.NoError(t, err)
+	_, err = conns[2].Exec(ctx, "BEGIN")
+	require.Error(t, err)
+	require.Regexp(t, `too many open transactions for role "foo"`, err)
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	require.Equal(t, "40001", pgErr.Code)
+
+	// The transaction can be attempted again once another one is over,
+	// whether it was committed, rolled back or abandoned by closing its
+	// session.
+	_, err = conns[0].Exec(ctx, "COMMIT")
+	require.NoError(t, err)
+	_, err = conns[2].Exec(ctx, "BEGIN")
+	require.NoError(t, err)
+	_, err = conns[2].Exec(ctx, "ROLLBACK")
+	require.NoError(t, err)
+	_, err = conns[2].Exec(ctx, "BEGIN")
+	require.NoError(t, err)
+	_, err = conns[0].Exec(ctx, "BEGIN")
+	require.Error(t, err)
+	require.NoError(t, conns[1].Close(ctx))
+	testutils.SucceedsSoon(t, func() error {
+		_, err := conns[0].Exec(ctx, "BEGIN")
+		return err
+	})
+	for _, i := range []int{0, 2} {
+		_, err := conns[i].Exec(ctx, "COMMIT")
+		require.NoError(t, err)
+	}
+
+	// The limit can be disabled.
+	sqlDB.Exec(t, `SET CLUSTER SETTING sql.max_open_transactions_per_user = 0`)
+	for _, i := range []int{0, 2} {
+		_, err := conns[i].Exec(ctx, "BEGIN")
+		require.NoError(t, err)
+	}
+}
+
+// TestUserOpenTxnLimitAcrossNodes verifies that the limit applies to the
+// transactions of the users open through all the nodes.
+func TestUserOpenTxnLimitAcrossNodes(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tc := serverutils.StartNewTestCluster(t, 2 /* numNodes */, base.TestClusterArgs{})
+	defer tc.Stopper().Stop(ctx)
+	sqlDB := sqlutils.MakeSQLRunner(tc.ServerConn(0))
+	sqlDB.Exec(t, `CREATE USER foo WITH PASSWORD 'testabc'`)
+	sqlDB.Exec(t, `SET CLUSTER SETTING sql.max_open_transactions_per_user = 2`)
+
+	connect := func(node int) *pgx.Conn {
+		fooURL, fooCleanupFn := sqlutils.PGUrlWithOptionalClientCerts(t,
+			tc.Server(node).ServingSQLAddr(), t.Name(), url.UserPassword("foo", "testabc"),
+			false /* withClientCerts */)
+		defer fooCleanupFn()
+		conn, err := pgxConn(t, fooURL)
+		require.NoError(t, err)
+		return conn
+	}
+	conns := []*pgx.Conn{connect(0), connect(0), connect(1)}
+	defer func() {
+		for _, conn := range conns {
+			_ = conn.Close(ctx)
+		}
+	}()
+
+	// One transaction is open through each node, so the limit is reached
+	// for the third one.
+	for _, i := range []int{0, 2} {
+		_, err := conns[i].Exec(ctx, "BEGIN")
+		require.NoError(t, err)
+	}
+	_, err := conns[1].Exec(ctx, "BEGIN")
+	require.Error(t, err)
+	require.Regexp(t, `too many open transactions for role "foo"`, err)
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	require.Equal(t, "40001", pgErr.Code)
+
+	// The transaction can be retried once the one open through the other
+	// node is over, and the count of the transactions on the other nodes
+	// has been refreshed.
+	_, err = conns[2].Exec(ctx, "COMMIT")
+	require.NoError(t, err)
+	testutils.SucceedsSoon(t, func() error {
+		_, err := conns[1].Exec(ctx, "BEGIN")
+		return err
+	})
+}
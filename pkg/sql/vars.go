@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/delegate"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
@@ -289,6 +290,11 @@ var varGen = map[string]sessionVar{
 			return dbName, nil
 		},
 		Set: func(_ context.Context, m sessionDataMutator, dbName string) error {
+			if err := CheckAllowedDatabase(
+				m.data.SessionUser(), m.data.AllowedDatabases, dbName,
+			); err != nil {
+				return err
+			}
 			m.SetDatabase(dbName)
 			return nil
 		},
@@ -461,6 +467,9 @@ var varGen = map[string]sessionVar{
 			if err != nil {
 				return err
 			}
+			if err := checkReadOnlyLogin(m.data, b); err != nil {
+				return err
+			}
 			m.SetDefaultTransactionReadOnly(b)
 			return nil
 		},
@@ -1311,6 +1320,19 @@ var varGen = map[string]sessionVar{
 		},
 	},
 
+	// See https://www.postgresql.org/docs/17/runtime-config-client.html#GUC-TRANSACTION-TIMEOUT
+	`transaction_timeout`: {
+		GetStringVal: makeTimeoutVarGetter(`transaction_timeout`),
+		Set:          transactionTimeoutVarSet,
+		Get: func(evalCtx *extendedEvalContext) (string, error) {
+			ms := evalCtx.SessionData().TransactionTimeout.Nanoseconds() / int64(time.Millisecond)
+			return strconv.FormatInt(ms, 10), nil
+		},
+		GlobalDefault: func(sv *settings.Values) string {
+			return "0s"
+		},
+	},
+
 	`idle_in_session_timeout`: {
 		GetStringVal: makeTimeoutVarGetter(`idle_in_session_timeout`),
 		Set:          idleInSessionTimeoutVarSet,
@@ -1390,6 +1412,9 @@ var varGen = map[string]sessionVar{
 			if err != nil {
 				return err
 			}
+			if err := checkReadOnlyLogin(m.data, b); err != nil {
+				return err
+			}
 			m.SetReadOnly(b)
 			return nil
 		},
@@ -2007,6 +2032,40 @@ var varGen = map[string]sessionVar{
 		},
 		GlobalDefault: globalTrue,
 	},
+
+	// CockroachDB extension.
+	`allow_unvalidated_role_settings`: {
+		GetStringVal: makePostgresBoolGetStringValFn(`allow_unvalidated_role_settings`),
+		Set: func(_ context.Context, m sessionDataMutator, s string) error {
+			b, err := paramparse.ParseBoolVar("allow_unvalidated_role_settings", s)
+			if err != nil {
+				return err
+			}
+			m.SetAllowUnvalidatedRoleSettings(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext) (string, error) {
+			return formatBoolAsPostgresSetting(evalCtx.SessionData().AllowUnvalidatedRoleSettings), nil
+		},
+		GlobalDefault: globalFalse,
+	},
+
+	// CockroachDB extension.
+	`logon_statements`: {
+		Set: func(_ context.Context, m sessionDataMutator, s string) error {
+			if _, err := parser.Parse(s); err != nil {
+				return pgerror.Wrap(err, pgcode.InvalidParameterValue, "invalid logon_statements")
+			}
+			m.SetLogonStatements(s)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext) (string, error) {
+			return evalCtx.SessionData().LogonStatements, nil
+		},
+		GlobalDefault: func(_ *settings.Values) string {
+			return ""
+		},
+	},
 }
 
 const compatErrMsg = "this parameter is currently recognized only for compatibility and has no effect in CockroachDB."
@@ -2317,6 +2376,26 @@ func (p *planner) SetSessionVar(ctx context.Context, varName, newVal string, isL
 		return newCannotChangeParameterError(name)
 	}
 
+	// A variable locked by a default setting of the role can only be reset
+	// to the value it was given when the session was established.
+	if _, ok := p.SessionData().LockedVars[name]; ok {
+		if defVal, ok := p.sessionDataMutatorIterator.defaults[name]; !ok || newVal != defVal {
+			return newLockedParameterError(name)
+		}
+	}
+
+	// A timeout bounded by a role option of the user cannot be raised above
+	// the option, nor disabled.
+	if err := p.checkTimeoutCeiling(name, newVal); err != nil {
+		return err
+	}
+
+	// Likewise, the quality of service cannot be raised above the
+	// QUALITY_OF_SERVICE role option of the user.
+	if err := p.checkQualityOfServiceCeiling(name, newVal); err != nil {
+		return err
+	}
+
 	// Note for RuntimeSet and SetWithPlanner we do not use the sessionDataMutator
 	// as the callers need items that are only accessible by higher level
 	// objects - and some of the computation potentially expensive so should be
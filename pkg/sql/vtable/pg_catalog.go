@@ -656,7 +656,8 @@ CREATE TABLE pg_catalog.pg_roles (
 	rolpassword STRING,
 	rolvaliduntil TIMESTAMPTZ,
 	rolbypassrls BOOL,
-	rolconfig STRING[]
+	rolconfig STRING[],
+	rollastlogin TIMESTAMPTZ
 )`
 
 // PGCatalogSecLabels describes the schema of the pg_catalog.pg_seclabels table.
@@ -933,9 +934,9 @@ CREATE TABLE pg_catalog.pg_aggregate (
 // contains the same data no matter which database the current session is using.
 const PgCatalogDbRoleSetting = `
 CREATE TABLE pg_catalog.pg_db_role_setting (
-	setconfig STRING[],
 	setdatabase OID,
-	setrole OID
+	setrole OID,
+	setconfig STRING[]
 )`
 
 // PgCatalogShadow is the implementation of pg_catalog.pg_shadow
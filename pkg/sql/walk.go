@@ -342,8 +342,17 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&alterDatabaseDropRegionNode{}):      "alter database drop region",
 	reflect.TypeOf(&alterDatabaseAddSuperRegion{}):      "alter database add super region",
 	reflect.TypeOf(&alterDatabaseDropSuperRegion{}):     "alter database drop super region",
+	reflect.TypeOf(&alterDatabaseAlterSuperRegion{}):    "alter database alter super region",
+	reflect.TypeOf(&alterDatabaseSecondaryRegion{}):     "alter database set secondary region",
+	reflect.TypeOf(&alterDatabaseDropSecondaryRegion{}): "alter database drop secondary region",
+	reflect.TypeOf(&alterDatabaseRenameRegion{}):        "alter database rename region",
+	reflect.TypeOf(&alterDatabaseToMultiRegionNode{}):   "alter database convert to multiregion",
+	reflect.TypeOf(&alterDatabaseRegionReplicas{}):      "alter database set region",
+	reflect.TypeOf(&alterDatabaseResetPrimaryRegion{}):  "alter database reset primary region",
+	reflect.TypeOf(&alterDatabaseDefaultLocality{}):     "alter database set default table locality",
 	reflect.TypeOf(&alterDefaultPrivilegesNode{}):       "alter default privileges",
 	reflect.TypeOf(&alterIndexNode{}):                   "alter index",
+	reflect.TypeOf(&alterProfileNode{}):                 "alter profile",
 	reflect.TypeOf(&alterSequenceNode{}):                "alter sequence",
 	reflect.TypeOf(&alterSchemaNode{}):                  "alter schema",
 	reflect.TypeOf(&alterTableNode{}):                   "alter table",
@@ -362,6 +371,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&commentOnColumnNode{}):              "comment on column",
 	reflect.TypeOf(&commentOnConstraintNode{}):          "comment on constraint",
 	reflect.TypeOf(&commentOnDatabaseNode{}):            "comment on database",
+	reflect.TypeOf(&commentOnRegionNode{}):              "comment on region",
 	reflect.TypeOf(&commentOnIndexNode{}):               "comment on index",
 	reflect.TypeOf(&commentOnTableNode{}):               "comment on table",
 	reflect.TypeOf(&commentOnSchemaNode{}):              "comment on schema",
@@ -369,6 +379,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&controlSchedulesNode{}):             "control schedules",
 	reflect.TypeOf(&createDatabaseNode{}):               "create database",
 	reflect.TypeOf(&createExtensionNode{}):              "create extension",
+	reflect.TypeOf(&createProfileNode{}):                "create profile",
 	reflect.TypeOf(&createIndexNode{}):                  "create index",
 	reflect.TypeOf(&createSequenceNode{}):               "create sequence",
 	reflect.TypeOf(&createSchemaNode{}):                 "create schema",
@@ -383,6 +394,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&distinctNode{}):                     "distinct",
 	reflect.TypeOf(&dropDatabaseNode{}):                 "drop database",
 	reflect.TypeOf(&dropIndexNode{}):                    "drop index",
+	reflect.TypeOf(&dropProfileNode{}):                  "drop profile",
 	reflect.TypeOf(&dropSequenceNode{}):                 "drop sequence",
 	reflect.TypeOf(&dropSchemaNode{}):                   "drop schema",
 	reflect.TypeOf(&dropTableNode{}):                    "drop table",
@@ -419,6 +431,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&renameColumnNode{}):                 "rename column",
 	reflect.TypeOf(&renameDatabaseNode{}):               "rename database",
 	reflect.TypeOf(&renameIndexNode{}):                  "rename index",
+	reflect.TypeOf(&renameRoleNode{}):                   "rename role",
 	reflect.TypeOf(&renameTableNode{}):                  "rename table",
 	reflect.TypeOf(&reparentDatabaseNode{}):             "reparent database",
 	reflect.TypeOf(&renderNode{}):                       "render",
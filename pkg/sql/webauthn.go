@@ -0,0 +1,163 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/errors"
+)
+
+// Users can register security keys from the DB Console, and then log in
+// to the DB Console with them instead of their password. The security
+// keys are stored in system.webauthn_credentials, and can be listed and
+// removed with the crdb_internal.security_keys() and
+// crdb_internal.unregister_security_key() builtins.
+
+// SecurityKey is a security key registered by a user.
+type SecurityKey struct {
+	// Name identifies the security key among the keys of the user.
+	Name string
+	security.WebAuthnCredential
+}
+
+// RegisterSecurityKey stores a security key registered by the given
+// user.
+func RegisterSecurityKey(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	ie *InternalExecutor,
+	user security.SQLUsername,
+	key SecurityKey,
+) error {
+	if !execCfg.Settings.Version.IsActive(ctx, clusterversion.WebAuthnCredentialsTable) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"security keys are not supported until the cluster upgrade is finalized")
+	}
+	if key.Name == "" {
+		return pgerror.New(pgcode.InvalidParameterValue, "the security key must have a name")
+	}
+	_, err := ie.ExecEx(
+		ctx, "register-security-key", nil, /* txn */
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`INSERT INTO system.public.webauthn_credentials
+       (username, name, credential_id, public_key, sign_count)
+VALUES ($1, $2, $3, $4, $5)`,
+		user, key.Name, key.ID, key.PublicKey, int64(key.SignCount),
+	)
+	if pgerror.GetPGCode(err) == pgcode.UniqueViolation {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"a security key named %q is already registered for user %s", key.Name, user)
+	}
+	return err
+}
+
+// GetSecurityKeys returns the security keys registered by the given user.
+func GetSecurityKeys(
+	ctx context.Context, execCfg *ExecutorConfig, ie *InternalExecutor, user security.SQLUsername,
+) ([]SecurityKey, error) {
+	if !execCfg.Settings.Version.IsActive(ctx, clusterversion.WebAuthnCredentialsTable) {
+		return nil, nil
+	}
+	rows, err := ie.QueryBufferedEx(
+		ctx, "get-security-keys", nil, /* txn */
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`SELECT name, credential_id, public_key, sign_count
+   FROM system.public.webauthn_credentials
+  WHERE username = $1
+  ORDER BY name`,
+		user,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error looking up the security keys of user %s", user)
+	}
+	keys := make([]SecurityKey, len(rows))
+	for i, row := range rows {
+		keys[i] = SecurityKey{
+			Name: string(tree.MustBeDString(row[0])),
+			WebAuthnCredential: security.WebAuthnCredential{
+				ID:        []byte(tree.MustBeDBytes(row[1])),
+				PublicKey: []byte(tree.MustBeDBytes(row[2])),
+				SignCount: uint32(tree.MustBeDInt(row[3])),
+			},
+		}
+	}
+	return keys, nil
+}
+
+// RecordSecurityKeyUse records the signature counter reported by a
+// security key when it was used to log in. It returns false if the
+// security key was concurrently used or removed, in which case the
+// login must be rejected.
+func RecordSecurityKeyUse(
+	ctx context.Context,
+	ie *InternalExecutor,
+	user security.SQLUsername,
+	key SecurityKey,
+	signCount uint32,
+) (bool, error) {
+	n, err := ie.ExecEx(
+		ctx, "record-security-key-use", nil, /* txn */
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`UPDATE system.public.webauthn_credentials
+    SET sign_count = $4, last_used = now()
+  WHERE username = $1 AND name = $2 AND sign_count = $3`,
+		user, key.Name, int64(key.SignCount), int64(signCount),
+	)
+	return n > 0, err
+}
+
+// ListSecurityKeys is part of the tree.EvalPlanner interface.
+func (p *planner) ListSecurityKeys(ctx context.Context, user security.SQLUsername) ([]string, error) {
+	if err := p.checkCanManageCredentials(
+		ctx, user, "security key management", clusterversion.WebAuthnCredentialsTable,
+	); err != nil {
+		return nil, err
+	}
+	rows, err := p.ExecCfg().InternalExecutor.QueryBufferedEx(
+		ctx, "list-security-keys", p.Txn(),
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`SELECT name FROM system.webauthn_credentials WHERE username = $1 ORDER BY name`,
+		user,
+	)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(rows))
+	for i, row := range rows {
+		names[i] = string(tree.MustBeDString(row[0]))
+	}
+	return names, nil
+}
+
+// UnregisterSecurityKey is part of the tree.EvalPlanner interface.
+func (p *planner) UnregisterSecurityKey(
+	ctx context.Context, user security.SQLUsername, name string,
+) (bool, error) {
+	if err := p.checkCanManageCredentials(
+		ctx, user, "security key management", clusterversion.WebAuthnCredentialsTable,
+	); err != nil {
+		return false, err
+	}
+	n, err := p.ExecCfg().InternalExecutor.ExecEx(
+		ctx, "unregister-security-key", p.Txn(),
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`DELETE FROM system.webauthn_credentials WHERE username = $1 AND name = $2`,
+		user, name,
+	)
+	return n > 0, err
+}
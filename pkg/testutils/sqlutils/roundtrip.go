@@ -0,0 +1,143 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sqlutils
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// roundtripVariant renders a statement as SQL in one of the ways which must
+// parse back to the statement.
+type roundtripVariant struct {
+	name   string
+	format func(tree.Statement) string
+}
+
+func makePrettyVariant(
+	name string, lineWidth int, align tree.PrettyAlignMode, useTabs bool, caseFn func(string) string,
+) roundtripVariant {
+	cfg := tree.DefaultPrettyCfg()
+	// Be careful to not simplify otherwise the statements won't round trip.
+	cfg.Simplify = false
+	cfg.LineWidth = lineWidth
+	cfg.Align = align
+	cfg.UseTabs = useTabs
+	cfg.Case = caseFn
+	return roundtripVariant{
+		name:   name,
+		format: func(stmt tree.Statement) string { return cfg.Pretty(stmt) },
+	}
+}
+
+// roundtripVariants are the renderings of statements checked by
+// CheckStatementRoundtrip. Each of them is an independent way of turning an
+// AST into SQL, so a node whose Format and doc methods disagree with the
+// grammar is caught by at least one of them.
+var roundtripVariants = []roundtripVariant{
+	{
+		name:   "simple",
+		format: func(stmt tree.Statement) string { return tree.AsStringWithFlags(stmt, tree.FmtSimple) },
+	},
+	{
+		name:   "parsable",
+		format: func(stmt tree.Statement) string { return tree.AsStringWithFlags(stmt, tree.FmtParsable) },
+	},
+	makePrettyVariant("pretty", tree.DefaultLineWidth, tree.PrettyNoAlign, true /* useTabs */, nil),
+	// A line width of 1 forces every optional line break to be taken.
+	makePrettyVariant("pretty narrow", 1, tree.PrettyAlignAndDeindent, false /* useTabs */, nil),
+	makePrettyVariant("pretty lower", tree.DefaultLineWidth, tree.PrettyAlignOnly, false /* useTabs */, strings.ToLower),
+}
+
+// CheckStatementRoundtrip verifies that stmt is parsed back to an identical
+// AST when formatted, either with the various formatting flags used to
+// produce SQL meant to be reparsed or with the pretty printer.
+//
+// ASTs are compared through their JSON representation (see
+// tree.StatementToJSON), which covers every exported field of every node.
+// Some nodes are normalized when they are formatted, for example string
+// literals which don't need to be escaped. When the AST obtained from a
+// rendering differs from stmt, it is compared against the AST obtained by
+// reparsing the simple rendering of stmt instead, which must be a fixpoint.
+func CheckStatementRoundtrip(stmt tree.Statement) error {
+	origJSON, err := astJSON(stmt)
+	if err != nil {
+		return err
+	}
+	var normalizedJSON string
+	for _, v := range roundtripVariants {
+		sql := v.format(stmt)
+		reparsed, err := parser.ParseOne(sql)
+		if err != nil {
+			return errors.Wrapf(err, "%s: reparsing %q", v.name, sql)
+		}
+		reparsedJSON, err := astJSON(reparsed.AST)
+		if err != nil {
+			return err
+		}
+		if reparsedJSON == origJSON {
+			continue
+		}
+		if normalizedJSON == "" {
+			normalized, err := parser.ParseOne(tree.AsStringWithFlags(stmt, tree.FmtSimple))
+			if err != nil {
+				return err
+			}
+			if normalizedJSON, err = astJSON(normalized.AST); err != nil {
+				return err
+			}
+		}
+		if reparsedJSON != normalizedJSON {
+			return errors.Newf(
+				"%s: AST mismatch after formatting\noriginal SQL: %s\nformatted SQL: %s\noriginal AST: %s\nreparsed AST: %s",
+				v.name, tree.AsString(stmt), sql, origJSON, reparsedJSON,
+			)
+		}
+	}
+	return nil
+}
+
+func astJSON(stmt tree.Statement) (string, error) {
+	doc, err := tree.StatementToJSON(stmt)
+	if err != nil {
+		return "", err
+	}
+	res, err := json.Marshal(doc.AST)
+	if err != nil {
+		return "", err
+	}
+	return string(res), nil
+}
+
+// VerifyStatementRoundtrip verifies that the SQL statements in sql round trip
+// through the formatters, as described by CheckStatementRoundtrip.
+//
+// Statements added to the parser's datadriven tests are checked
+// automatically; this is exported so that other tests and the fuzzers can
+// check the statements they produce.
+func VerifyStatementRoundtrip(t *testing.T, sql string) {
+	t.Helper()
+
+	stmts, err := parser.Parse(sql)
+	if err != nil {
+		t.Fatalf("%s: %s", err, sql)
+	}
+	for i := range stmts {
+		if err := CheckStatementRoundtrip(stmts[i].AST); err != nil {
+			t.Fatalf("%+v", err)
+		}
+	}
+}
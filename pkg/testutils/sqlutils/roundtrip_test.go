@@ -0,0 +1,60 @@
+// Copyright 2022 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sqlutils_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+)
+
+func TestVerifyStatementRoundtrip(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	for _, sql := range []string{
+		`ALTER DATABASE db ADD REGION IF NOT EXISTS "us-east1"`,
+		`ALTER DATABASE db DROP REGION "us-east1" CASCADE TO "us-west1"`,
+		`ALTER DATABASE db PRIMARY REGION "us-east1"`,
+		`ALTER DATABASE db RESET PRIMARY REGION`,
+		`ALTER DATABASE db SURVIVE REGION FAILURE`,
+		`ALTER DATABASE db PLACEMENT RESTRICTED EXCEPT TABLES (t1, s.t2)`,
+		`ALTER DATABASE db SET SECONDARY REGION "us-west1"`,
+		`ALTER DATABASE db DROP SECONDARY REGION IF EXISTS`,
+		`ALTER DATABASE db ADD SUPER REGION "americas" VALUES "us-east1", "us-west1"`,
+		`ALTER DATABASE db ALTER SUPER REGION "americas" VALUES "us-east1"`,
+		`ALTER DATABASE db DROP SUPER REGION "americas"`,
+		`ALTER DATABASE db SET REGION "us-east1" NUM_VOTERS 3 NUM_REPLICAS 5`,
+		`ALTER DATABASE db CONVERT TO MULTIREGION PRIMARY REGION "us-east1" REGIONS "us-west1" SURVIVE REGION FAILURE TABLE LOCALITY GLOBAL`,
+		`SELECT a + 1, 'str' FROM t WHERE b IN (1, 2) ORDER BY c DESC`,
+	} {
+		t.Run(sql, func(t *testing.T) {
+			sqlutils.VerifyStatementRoundtrip(t, sql)
+		})
+	}
+}
+
+func TestCheckStatementRoundtripMismatch(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	// A statement without regions cannot be produced by the parser, so it
+	// doesn't survive being formatted and reparsed.
+	stmt := &tree.AlterDatabaseAddRegion{Name: "db"}
+	err := sqlutils.CheckStatementRoundtrip(stmt)
+	if !testutils.IsError(err, "reparsing") {
+		t.Fatalf("expected reparsing error, got %v", err)
+	}
+}
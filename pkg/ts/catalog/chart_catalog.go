@@ -2224,6 +2224,15 @@ var charts = []sectionDescription{
 				},
 				AxisLabel: "Latency",
 			},
+			{
+				Title: "HBA Rule Matches",
+				Metrics: []string{
+					"sql.auth.hba_rule.matches",
+					"sql.auth.hba_rule.successes",
+					"sql.auth.hba_rule.failures",
+				},
+				AxisLabel: "Connections",
+			},
 			{
 				Title: "Open Transactions",
 				Metrics: []string{
@@ -2823,6 +2832,7 @@ var charts = []sectionDescription{
 					"jobs.auto_span_config_reconciliation.currently_running",
 					"jobs.auto_sql_stats_compaction.currently_running",
 					"jobs.stream_replication.currently_running",
+					"jobs.multiregion_conversion.currently_running",
 				},
 			},
 			{
@@ -2836,6 +2846,7 @@ var charts = []sectionDescription{
 					"jobs.create_stats.currently_idle",
 					"jobs.import.currently_idle",
 					"jobs.migration.currently_idle",
+					"jobs.multiregion_conversion.currently_idle",
 					"jobs.new_schema_change.currently_idle",
 					"jobs.restore.currently_idle",
 					"jobs.schema_change.currently_idle",
@@ -3019,6 +3030,17 @@ var charts = []sectionDescription{
 					"jobs.auto_sql_stats_compaction.resume_retry_error",
 				},
 			},
+			{
+				Title: "Multi-Region Conversion",
+				Metrics: []string{
+					"jobs.multiregion_conversion.fail_or_cancel_completed",
+					"jobs.multiregion_conversion.fail_or_cancel_failed",
+					"jobs.multiregion_conversion.fail_or_cancel_retry_error",
+					"jobs.multiregion_conversion.resume_completed",
+					"jobs.multiregion_conversion.resume_failed",
+					"jobs.multiregion_conversion.resume_retry_error",
+				},
+			},
 		},
 	},
 	{